@@ -1,6 +1,8 @@
 package bot
 
 import (
+	"context"
+
 	"telegram-bot-starter/bot/handlers"
 	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/config"
@@ -9,22 +11,47 @@ import (
 	tele "gopkg.in/telebot.v4"
 )
 
-func RegisterRoutes(bot *tele.Bot, handler *handlers.Handler, log logger.LoggerI, cfg *config.Config) *middleware.RateLimiter {
+func RegisterRoutes(ctx context.Context, bot *tele.Bot, handler *handlers.Handler, log logger.LoggerI, cfg *config.Config) *middleware.RateLimiter {
 	// Apply middleware
 	// Recovery middleware MUST be first — it catches panics from all subsequent handlers/middleware.
 	// Without it, a panic kills the polling goroutine silently (container stays up, bot stops responding).
-	bot.Use(middleware.RecoveryMiddleware(log))
+	bot.Use(middleware.RecoveryMiddleware(log, cfg.Bot.ErrorReportChatID()))
+
+	// Give every update its own deadline, derived from ctx so shutdown cancels it
+	bot.Use(middleware.ContextMiddleware(ctx, cfg.Bot.UpdateTimeout))
+
+	// Tag every update with a correlation ID and log handler entry/exit
+	bot.Use(middleware.LoggingMiddleware(log))
+
+	// Drop repeated taps on the same inline button before they start a second flow
+	bot.Use(middleware.NewCallbackDedupe(cfg.Bot.CallbackDedupeWindow, log).Middleware())
 
 	// Apply rate limiter middleware
-	rateLimiter := middleware.NewRateLimiter(cfg, log)
+	rateLimiter := middleware.NewRateLimiter(cfg, log, handler.IsAdmin)
 	bot.Use(rateLimiter.Middleware())
 
 	// Register command handlers
 	bot.Handle("/start", handler.HandleStart)
 	bot.Handle("/help", handler.HandleHelp)
+	bot.Handle("/profile", handler.HandleUserProfile)
+	bot.Handle("/myjobs", handler.HandleUserMyJobs)
 	bot.Handle("/about", handler.HandleAbout)
 	bot.Handle("/settings", handler.HandleSettings)
 	bot.Handle("/admin", handler.HandleAdminPanel)
+	bot.Handle("/panel", handler.HandleAdminPanel)
+	bot.Handle("/stats", handler.HandleAdminStatistics)
+	bot.Handle("/violations", handler.HandleViolationsCommand)
+	bot.Handle("/verify", handler.HandleVerifyCommand)
+	bot.Handle("/offer", handler.HandleOfferCommand)
+	bot.Handle("/checkin", handler.HandleCheckInCommand)
+	bot.Handle("/pending", handler.HandlePendingCommand)
+	bot.Handle("/reengage", handler.HandleReengageCommand)
+	bot.Handle("/expiry", handler.HandleExpiryCommand)
+	bot.Handle("/refunds", handler.HandleRefundsCommand)
+	bot.Handle("/booking", handler.HandleBookingHistoryCommand)
+	bot.Handle("/activity", handler.HandleAdminActivityCommand)
+	bot.Handle("/backup", handler.HandleBackupCommand)
+	bot.Handle("/backup_status", handler.HandleBackupStatusCommand)
 
 	// Register callback handler (routing lives in handlers/callback_router.go)
 	bot.Handle(tele.OnCallback, handler.HandleCallback)
@@ -38,6 +65,9 @@ func RegisterRoutes(bot *tele.Bot, handler *handlers.Handler, log logger.LoggerI
 	// Register photo handler (for payment proofs)
 	bot.Handle(tele.OnPhoto, handler.HandlePhoto)
 
+	// Register document handler (for receipts exported as PDF)
+	bot.Handle(tele.OnDocument, handler.HandleDocument)
+
 	// Register location handler (for job locations)
 	bot.Handle(tele.OnLocation, handler.HandleLocation)
 
@@ -0,0 +1,199 @@
+// Package fsm routes private-chat input through declaratively registered
+// multi-step conversation flows (registration, job creation, profile edit, ...).
+//
+// The current step lives in users.state. A flow owns a set of states, may
+// restrict who can continue it, and expires when the user leaves it
+// untouched for longer than its timeout.
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// ErrInvalidTransition is returned when a state change is not allowed by the registered flows
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// Handler handles an update for a user who is in a flow
+type Handler func(c tele.Context, user *models.User) error
+
+// Guard reports whether the user may continue the flow. Input from users
+// who fail the guard is not routed to the flow.
+type Guard func(c tele.Context, user *models.User) bool
+
+// Flow declares one multi-step conversation
+type Flow struct {
+	Name string
+
+	// States lists the flow's states. Prefix adds every state starting with
+	// it, for flows whose steps are numerous or built dynamically.
+	States []models.UserState
+	Prefix string
+
+	Guard   Guard         // nil: anyone in the flow may continue it
+	Timeout time.Duration // 0: the flow never expires
+
+	Input   Handler // text input while in the flow (required)
+	Cancel  Handler // "cancel" button; nil leaves cancellation to the caller
+	Expired Handler // after a stale state was reset to idle; nil lets the input fall through
+}
+
+// Owns reports whether state belongs to the flow
+func (f *Flow) Owns(state models.UserState) bool {
+	if f.Prefix != "" && strings.HasPrefix(string(state), f.Prefix) {
+		return true
+	}
+	for _, s := range f.States {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// StateStore persists user states. storage.UserRepoI satisfies it.
+type StateStore interface {
+	UpdateState(ctx context.Context, id int64, state models.UserState) error
+}
+
+// Machine dispatches input to the flow owning the user's current state
+type Machine struct {
+	store StateStore
+	log   logger.LoggerI
+	now   func() time.Time
+	flows []*Flow
+}
+
+// New creates an empty machine; add flows with Register
+func New(store StateStore, log logger.LoggerI) *Machine {
+	return &Machine{
+		store: store,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+// Register adds flows. A state owned by several flows goes to the one registered first.
+func (m *Machine) Register(flows ...*Flow) {
+	for _, f := range flows {
+		if f.Input == nil {
+			panic(fmt.Sprintf("fsm: flow %q has no input handler", f.Name))
+		}
+		m.flows = append(m.flows, f)
+	}
+}
+
+// Flow returns the flow owning state, or nil if no flow does (idle and unknown states)
+func (m *Machine) Flow(state models.UserState) *Flow {
+	if state == models.StateIdle || state == "" {
+		return nil
+	}
+	for _, f := range m.flows {
+		if f.Owns(state) {
+			return f
+		}
+	}
+	return nil
+}
+
+// IsStale reports whether the user has been inactive in their flow for longer than its timeout
+func (m *Machine) IsStale(user *models.User) bool {
+	f := m.Flow(user.State)
+	if f == nil || f.Timeout <= 0 || user.StateUpdatedAt.IsZero() {
+		return false
+	}
+	return m.now().Sub(user.StateUpdatedAt) > f.Timeout
+}
+
+// Transition moves the user to state to and persists it.
+//
+// Idle is always reachable. Any other target must belong to a registered
+// flow, and a user already in a flow can only move within it: switching
+// flows goes through idle so the previous flow's leftovers are cleaned up.
+func (m *Machine) Transition(ctx context.Context, user *models.User, to models.UserState) error {
+	if to != models.StateIdle {
+		target := m.Flow(to)
+		if target == nil {
+			return fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, to)
+		}
+		if current := m.Flow(user.State); current != nil && current != target {
+			return fmt.Errorf("%w: %q (%s) -> %q (%s)", ErrInvalidTransition, user.State, current.Name, to, target.Name)
+		}
+	}
+
+	if err := m.store.UpdateState(ctx, user.ID, to); err != nil {
+		return fmt.Errorf("failed to update user state: %w", err)
+	}
+	user.State = to
+	user.StateUpdatedAt = m.now()
+	return nil
+}
+
+// Dispatch routes text input to the user's flow. It returns false when no
+// flow took the update, so the caller can fall back to menu handling.
+func (m *Machine) Dispatch(c tele.Context, user *models.User) (bool, error) {
+	f := m.active(c, user)
+	if f == nil {
+		return false, nil
+	}
+
+	if m.IsStale(user) {
+		return m.expire(c, user, f)
+	}
+
+	return true, f.Input(c, user)
+}
+
+// Cancel runs the cancel handler of the user's flow. It returns false when
+// the user is not in a flow or the flow has no cancel handler.
+func (m *Machine) Cancel(c tele.Context, user *models.User) (bool, error) {
+	f := m.active(c, user)
+	if f == nil || f.Cancel == nil {
+		return false, nil
+	}
+	return true, f.Cancel(c, user)
+}
+
+// active returns the user's flow if they pass its guard
+func (m *Machine) active(c tele.Context, user *models.User) *Flow {
+	f := m.Flow(user.State)
+	if f == nil {
+		return nil
+	}
+	if f.Guard != nil && !f.Guard(c, user) {
+		return nil
+	}
+	return f
+}
+
+// expire resets a stale flow back to idle and lets the flow tell the user
+func (m *Machine) expire(c tele.Context, user *models.User, f *Flow) (bool, error) {
+	ctx := middleware.UpdateContext(c)
+	log := logger.FromContext(ctx, m.log)
+
+	log.Info("Conversation flow expired",
+		logger.String("flow", f.Name),
+		logger.String("state", string(user.State)),
+		logger.Any("user_id", user.ID),
+		logger.String("idle_for", m.now().Sub(user.StateUpdatedAt).Round(time.Second).String()),
+	)
+
+	if err := m.Transition(ctx, user, models.StateIdle); err != nil {
+		log.Error("Failed to reset expired flow", logger.Error(err))
+		return true, err
+	}
+
+	if f.Expired == nil {
+		return false, nil
+	}
+	return true, f.Expired(c, user)
+}
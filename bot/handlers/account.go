@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleDeleteAccount starts account deletion from the profile menu
+func (h *Handler) HandleDeleteAccount(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+
+	if ok, err := h.storage.Registration().IsUserRegistered(ctx, c.Sender().ID); err != nil || !ok {
+		return c.Send("❌ Siz hali ro'yxatdan o'tmagansiz. /start buyrug'ini bosing.")
+	}
+
+	return c.Send(messages.MsgDeleteAccountWarning, keyboards.DeleteAccountKeyboard(false), tele.ModeHTML)
+}
+
+// HandleDeleteAccountConfirm asks for the second, final confirmation
+func (h *Handler) HandleDeleteAccountConfirm(c tele.Context) error {
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.MsgDeleteAccountFinal, keyboards.DeleteAccountKeyboard(true), tele.ModeHTML)
+}
+
+// HandleDeleteAccountFinal deletes the account after both confirmations
+func (h *Handler) HandleDeleteAccountFinal(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+	userID := c.Sender().ID
+
+	if err := h.services.Registration().DeleteAccount(ctx, userID); err != nil {
+		if errors.Is(err, service.ErrActiveBookings) {
+			if err := c.Respond(); err != nil {
+				h.log.Error("Failed to respond to callback", logger.Error(err))
+			}
+			return c.Edit(messages.MsgDeleteAccountActiveBookings)
+		}
+		h.log.Error("Failed to delete account", logger.Error(err), logger.Any("user_id", userID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi", ShowAlert: true})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ O'chirildi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	if err := c.Delete(); err != nil {
+		h.log.Error("Failed to delete confirmation message", logger.Error(err))
+	}
+	// A new message is needed to drop the profile reply keyboard
+	return c.Send(messages.MsgAccountDeleted, &tele.ReplyMarkup{RemoveKeyboard: true})
+}
+
+// HandleDeleteAccountCancel keeps the account
+func (h *Handler) HandleDeleteAccountCancel(c tele.Context) error {
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.MsgDeleteAccountCancelled)
+}
@@ -2,25 +2,29 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
-	"time"
 
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/helper"
 	"telegram-bot-starter/pkg/keyboards"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/pkg/validation"
+	"telegram-bot-starter/service"
+	"telegram-bot-starter/storage"
 
 	tele "gopkg.in/telebot.v4"
 )
 
-// IsAdmin checks if a user is an admin
+// IsAdmin checks if a user is an admin. The list can change at runtime, so it is read from the settings cache.
 func (h *Handler) IsAdmin(userID int64) bool {
-	return slices.Contains(h.cfg.Bot.AdminIDs, userID)
+	return h.services.Settings().IsAdmin(context.Background(), userID)
 }
 
 // HandleAdminPanel shows the admin panel
@@ -38,21 +42,15 @@ func (h *Handler) HandleCreateJob(c tele.Context) error {
 		return c.Send("❌ Sizda admin huquqi yo'q.")
 	}
 
-	ctx := context.Background()
-
-	// Update user state to start job creation
-	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateCreatingJobIshHaqqi); err != nil {
-		h.log.Error("Failed to update user state", logger.Error(err))
-		return c.Send(messages.MsgError)
-	}
-
 	// Store empty job in session (we'll use user state + temp storage)
-	h.setTempJob(c.Sender().ID, &models.Job{
+	job := &models.Job{
 		Status:          models.JobStatusActive,
 		RequiredWorkers: 1,
-	})
+	}
+	h.clearTempJob(c.Sender().ID)
+	h.setTempJob(c.Sender().ID, job)
 
-	return c.Send(messages.MsgEnterIshHaqqi, keyboards.CancelKeyboard())
+	return h.promptJobCreationStep(c, models.StateCreatingJobIshHaqqi, job)
 }
 
 // HandleAdminStatistics shows statistics for admin
@@ -61,7 +59,7 @@ func (h *Handler) HandleAdminStatistics(c tele.Context) error {
 		return c.Send("❌ Sizda admin huquqi yo'q.")
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Gather all stats
 	totalUsers, err := h.storage.User().GetTotalCount(ctx)
@@ -161,34 +159,41 @@ func (h *Handler) HandleAdminStatistics(c tele.Context) error {
 		helper.FormatMoney(rejectedBookings),
 	)
 
-	return c.Send(msg, tele.ModeHTML)
+	return c.Send(msg, keyboards.AdminStatisticsKeyboard(), tele.ModeHTML)
 }
 
-// HandleJobList shows the list of jobs
-func (h *Handler) HandleJobList(c tele.Context) error {
+// jobInterestListLimit caps the jobs listed in the interested-but-unregistered view
+const jobInterestListLimit = 20
+
+// HandleJobInterestList shows, per job, how many users opened the signup link but haven't registered
+func (h *Handler) HandleJobInterestList(c tele.Context) error {
 	if !h.IsAdmin(c.Sender().ID) {
-		return c.Send("❌ Sizda admin huquqi yo'q.")
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
-	jobs, err := h.storage.Job().GetAll(ctx, nil)
+	ctx := middleware.UpdateContext(c)
+
+	counts, err := h.storage.JobInterest().GetUnregisteredCounts(ctx, jobInterestListLimit)
 	if err != nil {
-		h.log.Error("Failed to get jobs", logger.Error(err))
-		return c.Send(messages.MsgError)
+		h.log.Error("Failed to get job interest counts", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+	for _, count := range counts {
+		if count.Job, err = h.storage.Job().GetByID(ctx, count.JobID); err != nil {
+			h.log.Error("Failed to get job", logger.Error(err), logger.Any("job_id", count.JobID))
+		}
 	}
 
-	if len(jobs) == 0 {
-		// if err := c.Respond(); err != nil {
-		// 	h.log.Error("Failed to respond to callback", logger.Error(err))
-		// }
-		return c.Send("📋 Hozircha ishlar yo'q.", keyboards.AdminMenuReplyKeyboard())
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
 
-	// if err := c.Respond(); err != nil {
-	// 	h.log.Error("Failed to respond to callback", logger.Error(err))
-	// }
+	return c.Send(messages.FormatJobInterestCounts(counts), tele.ModeHTML)
+}
 
-	return c.Send("📋 Ishlar ro'yxati:", keyboards.JobListKeyboard(jobs))
+// HandleJobList shows the list of jobs as a new message, active jobs only; the list itself toggles to all jobs
+func (h *Handler) HandleJobList(c tele.Context) error {
+	return h.showJobList(c, false, 1, false)
 }
 
 // HandleJobDetail shows job detail with edit options
@@ -204,7 +209,7 @@ func (h *Handler) HandleJobDetail(c tele.Context, jobIDStr string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	job, err := h.storage.Job().GetByID(ctx, jobID)
 	if err != nil {
 		h.log.Error("Failed to get job", logger.Error(err))
@@ -216,7 +221,7 @@ func (h *Handler) HandleJobDetail(c tele.Context, jobIDStr string) error {
 	}
 
 	// Single-message enforcement per admin: Delete this admin's previous message if exists
-	h.deleteAdminMessageForAdmin(job.ID, c.Sender().ID)
+	h.deleteAdminMessageForAdmin(ctx, job.ID, c.Sender().ID)
 
 	// Send new admin message
 	msg := messages.FormatJobDetailAdmin(job)
@@ -258,7 +263,7 @@ func (h *Handler) HandleEditJobField(c tele.Context, params string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	job, err := h.storage.Job().GetByID(ctx, jobID)
 	if err != nil {
 		h.log.Error("Failed to get job", logger.Error(err))
@@ -306,6 +311,15 @@ func (h *Handler) HandleEditJobField(c tele.Context, params string) error {
 	case "employer_phone":
 		state = models.StateEditingJobEmployerPhone
 		prompt = messages.MsgEnterEmployerPhone
+	case "talablar":
+		state = models.StateEditingJobTalablar
+		prompt = messages.MsgEnterJobRequirements
+	case "caption_a":
+		state = models.StateEditingJobCaptionA
+		prompt = fmt.Sprintf(messages.MsgEnterJobCaption, models.CaptionVariantA.Display())
+	case "caption_b":
+		state = models.StateEditingJobCaptionB
+		prompt = fmt.Sprintf(messages.MsgEnterJobCaption, models.CaptionVariantB.Display())
 	default:
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri maydon"})
 	}
@@ -315,8 +329,9 @@ func (h *Handler) HandleEditJobField(c tele.Context, params string) error {
 		return c.Send(messages.MsgError)
 	}
 
-	// Store job being edited
+	// Store job being edited, and the version the admin is looking at
 	h.setEditingJobID(c.Sender().ID, job.ID)
+	h.setEditingJobVersion(c.Sender().ID, job.Version)
 
 	if err := c.Respond(); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
@@ -379,23 +394,45 @@ func (h *Handler) HandleChangeJobStatus(c tele.Context, params string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
 	}
 
-	statusStr := parts[1]
-	var status models.JobStatus
-	switch statusStr {
-	case "open":
-		status = models.JobStatusActive
-	case "toldi":
-		status = models.JobStatusFull
-	case "closed":
-		status = models.JobStatusCompleted
+	status, ok := keyboards.ParseJobStatusCallback(parts[1])
+	if !ok {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
-	// Update status in database
+	prev, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi"})
+	}
+
+	// Buttons only offer valid next states, but another admin may have changed the job since
+	if !prev.CanTransitionTo(status) {
+		h.log.Info("Rejected job status transition",
+			logger.Any("job_id", jobID), logger.Any("from", prev.Status), logger.Any("to", status))
+		if err := c.Respond(&tele.CallbackResponse{Text: "⚠️ Bu holatga o'tkazib bo'lmaydi", ShowAlert: true}); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		return c.Edit(messages.FormatJobDetailAdmin(prev), keyboards.JobDetailKeyboard(prev), tele.ModeHTML)
+	}
+
+	// Update status in database; the write re-checks the transition against the stored job
 	if err := h.storage.Job().UpdateStatus(ctx, jobID, status); err != nil {
-		h.log.Error("Failed to update job status", logger.Error(err))
-		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+		if !errors.Is(err, storage.ErrInvalidJobTransition) {
+			h.log.Error("Failed to update job status", logger.Error(err))
+			return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+		}
+		h.log.Info("Rejected job status transition", logger.Error(err))
+		if err := c.Respond(&tele.CallbackResponse{Text: "⚠️ Bu holatga o'tkazib bo'lmaydi", ShowAlert: true}); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		current, err := h.storage.Job().GetByID(ctx, jobID)
+		if err != nil {
+			h.log.Error("Failed to get job", logger.Error(err))
+			return c.Send(messages.MsgError)
+		}
+		return c.Edit(messages.FormatJobDetailAdmin(current), keyboards.JobDetailKeyboard(current), tele.ModeHTML)
 	}
 
 	// Get updated job
@@ -408,6 +445,7 @@ func (h *Handler) HandleChangeJobStatus(c tele.Context, params string) error {
 	// Update channel message if exists
 	if job.ChannelMessageID != 0 {
 		h.updateChannelMessage(job)
+		h.syncChannelLocation(ctx, prev, job)
 	}
 
 	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Status yangilandi"}); err != nil {
@@ -415,13 +453,78 @@ func (h *Handler) HandleChangeJobStatus(c tele.Context, params string) error {
 	}
 
 	// Update ALL admin messages (broadcasts to all admins)
-	h.updateAllAdminMessages(job)
+	go h.updateAllAdminMessages(context.WithoutCancel(ctx), job, c.Sender().ID)
+
+	// Closing the job hands the payroll sheet to the admins and the employer
+	if status == models.JobStatusCompleted && prev.Status != models.JobStatusCompleted {
+		go h.sendJobPayroll(context.WithoutCancel(ctx), job, c.Sender().ID)
+	}
 
 	// Show updated job detail to current admin
 	msg := messages.FormatJobDetailAdmin(job)
 	return c.Edit(msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
 }
 
+// HandlePreviewJob shows the admin the channel post exactly as it will be published, with the
+// signup button inactive, before anything is sent to the channel
+func (h *Handler) HandlePreviewJob(c tele.Context, jobIDStr string) error {
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		h.log.Error("Invalid job ID in callback", logger.Error(err), logger.Any("job_id_str", jobIDStr))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if job.ChannelMessageID != 0 {
+		return c.Respond(&tele.CallbackResponse{Text: "⚠️ Bu ish allaqachon kanalda"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "👁 Kanaldagi ko'rinishi. Tekshirib, yuboring."}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.FormatJobForChannel(job), keyboards.JobPublishPreviewKeyboard(job.ID), tele.ModeHTML)
+}
+
+// HandlePreviewJobEdit turns the preview back into the job detail with its edit buttons
+func (h *Handler) HandlePreviewJobEdit(c tele.Context, jobIDStr string) error {
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		h.log.Error("Invalid job ID in callback", logger.Error(err), logger.Any("job_id_str", jobIDStr))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.FormatJobDetailAdmin(job), keyboards.JobDetailKeyboard(job), tele.ModeHTML)
+}
+
+// HandleJobPreviewNoop answers taps on the preview's signup button, which only works in the channel
+func (h *Handler) HandleJobPreviewNoop(c tele.Context) error {
+	return c.Respond(&tele.CallbackResponse{Text: "ℹ️ Bu tugma kanaldagi e'londa ishlaydi"})
+}
+
 // HandlePublishJob publishes the job to the channel (only if not yet published)
 func (h *Handler) HandlePublishJob(c tele.Context, jobIDStr string) error {
 	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
@@ -434,7 +537,7 @@ func (h *Handler) HandlePublishJob(c tele.Context, jobIDStr string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	job, err := h.storage.Job().GetByID(ctx, jobID)
 	if err != nil {
 		h.log.Error("Failed to get job", logger.Error(err))
@@ -449,8 +552,8 @@ func (h *Handler) HandlePublishJob(c tele.Context, jobIDStr string) error {
 	// Format job message for channel
 	msg := messages.FormatJobForChannel(job)
 
-	// Create inline keyboard with signup button
-	signupBtn := keyboards.JobSignupKeyboard(job.ID, h.cfg.Bot.Username)
+	// Create inline keyboard with signup button; the post shows caption A, reposts alternate B and A
+	signupBtn := keyboards.JobCaptionSignupKeyboard(job, models.CaptionVariantA, h.cfg.Bot.Username)
 
 	// Send to channel
 	channelID := tele.ChatID(h.cfg.Bot.ChannelID)
@@ -467,36 +570,25 @@ func (h *Handler) HandlePublishJob(c tele.Context, jobIDStr string) error {
 
 	job.ChannelMessageID = int64(sentMsg.ID)
 
-	// Send location as a reply to the channel message if it exists
-	if job.Location != "" {
-		parts := strings.SplitN(job.Location, ",", 2)
-		if len(parts) == 2 {
-			lat, errLat := strconv.ParseFloat(strings.TrimSpace(parts[0]), 32)
-			lng, errLng := strconv.ParseFloat(strings.TrimSpace(parts[1]), 32)
-			if errLat == nil && errLng == nil {
-				location := &tele.Location{
-					Lat: float32(lat),
-					Lng: float32(lng),
-				}
-				_, err := h.bot.Send(channelID, location, &tele.SendOptions{
-					ReplyTo: sentMsg,
-				})
-				if err != nil {
-					h.log.Error("Failed to send location to channel",
-						logger.Error(err),
-						logger.Any("job_id", job.ID),
-					)
-				}
-			}
+	// Drafts (e.g. cloned jobs) start accepting bookings once published
+	if job.Status == models.JobStatusDraft {
+		if err := h.storage.Job().UpdateStatus(ctx, job.ID, models.JobStatusActive); err != nil {
+			h.log.Error("Failed to activate draft job", logger.Error(err), logger.Any("job_id", job.ID))
+		} else {
+			job.Status = models.JobStatusActive
 		}
 	}
 
+	// Reply to the channel message with a map preview, or the location pin without a map service
+	h.postChannelLocation(ctx, job)
+
 	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Kanalga yuborildi!"}); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
+	h.services.ChannelIndex().Notify()
 
 	// Update ALL admin messages (broadcast to all admins)
-	h.updateAllAdminMessages(job)
+	go h.updateAllAdminMessages(context.WithoutCancel(ctx), job, c.Sender().ID)
 
 	// Update current admin's message view
 	detailMsg := messages.FormatJobDetailAdmin(job)
@@ -515,7 +607,7 @@ func (h *Handler) HandleDeleteChannelMessage(c tele.Context, jobIDStr string) er
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	job, err := h.storage.Job().GetByID(ctx, jobID)
 	if err != nil {
 		h.log.Error("Failed to get job", logger.Error(err))
@@ -533,6 +625,7 @@ func (h *Handler) HandleDeleteChannelMessage(c tele.Context, jobIDStr string) er
 		h.log.Error("Failed to delete channel message", logger.Error(err))
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Xabarni o'chirishda xatolik"})
 	}
+	h.removeChannelLocation(ctx, job)
 
 	// Clear channel message ID from job
 	if err := h.storage.Job().UpdateChannelMessageID(ctx, job.ID, 0); err != nil {
@@ -544,9 +637,10 @@ func (h *Handler) HandleDeleteChannelMessage(c tele.Context, jobIDStr string) er
 	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Kanal xabari o'chirildi"}); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
+	h.services.ChannelIndex().Notify()
 
 	// Update ALL admin messages (broadcast channel message deletion to all admins)
-	h.updateAllAdminMessages(job)
+	go h.updateAllAdminMessages(context.WithoutCancel(ctx), job, c.Sender().ID)
 
 	// Show updated job detail to current admin
 	msg := messages.FormatJobDetailAdmin(job)
@@ -565,7 +659,7 @@ func (h *Handler) HandleDeleteJob(c tele.Context, jobIDStr string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Get job first to delete channel message
 	job, err := h.storage.Job().GetByID(ctx, jobID)
@@ -574,16 +668,17 @@ func (h *Handler) HandleDeleteJob(c tele.Context, jobIDStr string) error {
 		return c.Send(messages.MsgError)
 	}
 
-	// Delete channel message if exists
+	// Delete channel message and its location reply if exist
 	if job.ChannelMessageID != 0 {
 		msgToDelete := &tele.Message{ID: int(job.ChannelMessageID), Chat: &tele.Chat{ID: h.cfg.Bot.ChannelID}}
 		if err := h.bot.Delete(msgToDelete); err != nil {
 			h.log.Error("Failed to delete channel message", logger.Error(err))
 		}
 	}
+	h.removeChannelLocation(ctx, job)
 
 	// Delete ALL admin messages from Telegram chats
-	h.deleteAllAdminMessages(jobID)
+	h.deleteAllAdminMessages(ctx, jobID)
 
 	// Delete from database (will cascade delete admin_job_messages)
 	if err := h.storage.Job().Delete(ctx, jobID); err != nil {
@@ -594,11 +689,61 @@ func (h *Handler) HandleDeleteJob(c tele.Context, jobIDStr string) error {
 	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Ish o'chirildi"}); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
+	if job.ChannelMessageID != 0 {
+		h.services.ChannelIndex().Notify()
+	}
 
 	c.Delete()
 	return c.Send("✅ Ish muvaffaqiyatli o'chirildi.", keyboards.AdminMenuReplyKeyboard())
 }
 
+// HandleCloneJob duplicates a job as a DRAFT and asks for the new work date
+func (h *Handler) HandleCloneJob(c tele.Context, jobIDStr string) error {
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		h.log.Error("Invalid job ID in callback", logger.Error(err), logger.Any("job_id_str", jobIDStr))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi"})
+	}
+
+	clone := job.Clone()
+	clone.CreatedByAdminID = c.Sender().ID
+	clone, err = h.storage.Job().Create(ctx, clone)
+	if err != nil {
+		h.log.Error("Failed to clone job", logger.Error(err), logger.Any("job_id", jobID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	h.log.Info("Job cloned",
+		logger.Any("job_id", jobID),
+		logger.Any("clone_id", clone.ID),
+		logger.Any("admin_id", c.Sender().ID),
+	)
+
+	// Reuse the work date edit flow on the clone; cancelling shows the clone's detail
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateEditingJobIshKuni); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	h.setEditingJobID(c.Sender().ID, clone.ID)
+
+	if err := c.Respond(&tele.CallbackResponse{Text: fmt.Sprintf("✅ Nusxa: №%s", messages.JobNumber(clone))}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	msg := fmt.Sprintf("%s\n\nJoriy qiymat: %s", messages.MsgJobClonedEnterIshKuni, clone.WorkDate)
+	return c.Send(msg, keyboards.CancelEditKeyboard(clone.ID))
+}
+
 // HandleAdminTextInput handles text input during job creation/editing
 func (h *Handler) HandleAdminTextInput(c tele.Context, user *models.User) error {
 	text := strings.TrimSpace(c.Text())
@@ -613,39 +758,47 @@ func (h *Handler) HandleAdminTextInput(c tele.Context, user *models.User) error
 		return h.handleJobEditingInput(c, user, text)
 	}
 
+	// Handle FAQ add/edit flow
+	if strings.HasPrefix(string(user.State), "faq_") {
+		return h.handleFAQInput(c, user, text)
+	}
+
+	// Handle new public offer text
+	if user.State == models.StateEditingOffer {
+		return h.handleOfferInput(c, text)
+	}
+
+	// Handle runtime settings (super admins)
+	if strings.HasPrefix(string(user.State), "settings_") {
+		return h.handleRuntimeSettingsInput(c, user, text)
+	}
+
 	return nil
 }
 
 func (h *Handler) handleJobCreationInput(c tele.Context, user *models.User, text string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	job := h.getTempJob(c.Sender().ID)
 	if job == nil {
 		job = &models.Job{Status: models.JobStatusDraft, RequiredWorkers: 1}
 	}
 
-	var nextState models.UserState
-	var nextPrompt string
-
 	switch user.State {
 	case models.StateCreatingJobIshHaqqi:
-		job.Salary = text
-		nextState = models.StateCreatingJobOvqat
-		nextPrompt = messages.MsgEnterOvqat
+		salary, verr := validation.ValidateSalary(text)
+		if verr != nil {
+			return c.Send(verr.Message)
+		}
+		job.Salary = salary
 
 	case models.StateCreatingJobOvqat:
 		job.Food = text
-		nextState = models.StateCreatingJobVaqt
-		nextPrompt = messages.MsgEnterVaqt
 
 	case models.StateCreatingJobVaqt:
 		job.WorkTime = text
-		nextState = models.StateCreatingJobManzil
-		nextPrompt = messages.MsgEnterManzil
 
 	case models.StateCreatingJobManzil:
 		job.Address = text
-		nextState = models.StateCreatingJobLocation
-		nextPrompt = messages.MsgEnterLocation
 		// Location will be handled by HandleLocation, not text input
 
 	case models.StateCreatingJobLocation:
@@ -657,17 +810,13 @@ func (h *Handler) handleJobCreationInput(c tele.Context, user *models.User, text
 		} else {
 			job.Location = text
 		}
-		nextState = models.StateCreatingJobXizmatHaqqi
-		nextPrompt = messages.MsgEnterXizmatHaqqi
 
 	case models.StateCreatingJobXizmatHaqqi:
-		xizmatHaqqi, err := strconv.Atoi(text)
-		if err != nil {
-			return c.Send("❌ Iltimos, raqam kiriting. Masalan: 9990")
+		xizmatHaqqi, verr := validation.ParseMoney(text)
+		if verr != nil {
+			return c.Send(verr.Message)
 		}
 		job.ServiceFee = xizmatHaqqi
-		nextState = models.StateCreatingJobAvtobuslar
-		nextPrompt = messages.MsgEnterAvtobuslar
 
 	case models.StateCreatingJobAvtobuslar:
 		// Allow skipping buses field
@@ -676,18 +825,12 @@ func (h *Handler) handleJobCreationInput(c tele.Context, user *models.User, text
 		} else {
 			job.Buses = text
 		}
-		nextState = models.StateCreatingJobIshTavsifi
-		nextPrompt = messages.MsgEnterIshTavsifi
 
 	case models.StateCreatingJobIshTavsifi:
 		job.AdditionalInfo = text
-		nextState = models.StateCreatingJobIshKuni
-		nextPrompt = messages.MsgEnterIshKuni
 
 	case models.StateCreatingJobIshKuni:
 		job.WorkDate = text
-		nextState = models.StateCreatingJobKerakli
-		nextPrompt = messages.MsgEnterKerakliIshchilar
 
 	case models.StateCreatingJobKerakli:
 		kerakli, err := strconv.Atoi(text)
@@ -695,70 +838,101 @@ func (h *Handler) handleJobCreationInput(c tele.Context, user *models.User, text
 			return c.Send("❌ Iltimos, 1 dan katta raqam kiriting.")
 		}
 		job.RequiredWorkers = kerakli
-		nextState = models.StateCreatingJobEmployerPhone
-		nextPrompt = messages.MsgEnterEmployerPhone
 
 	case models.StateCreatingJobEmployerPhone:
-		job.EmployerPhone = text
-
-		// Save job to database
-		job.CreatedByAdminID = c.Sender().ID
-		newJob, err := h.storage.Job().Create(ctx, job)
-		if err != nil {
-			h.log.Error("Failed to create job", logger.Error(err))
-			return c.Send(messages.MsgError)
+		if verr := validation.ValidatePhone(text); verr != nil {
+			return c.Send(verr.Message)
 		}
-
-		// Reset user state
-		if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
-			h.log.Error("Failed to update user state", logger.Error(err))
+		phone := validation.NormalizePhone(text)
+
+		// Known phone: link the existing employer and go on to the review
+		employer, err := h.employerByPhone(ctx, phone, text)
+		if err == nil {
+			job.EmployerID = employer.ID
+			job.EmployerPhone = employer.Phone
+			return h.advanceJobCreation(c, job, user.State)
 		}
-
-		// Clear temp job
-		h.clearTempJob(c.Sender().ID)
-
-		// Show job preview with publish option
-		msg := fmt.Sprintf("✅ Ish yaratildi!\n\n%s", messages.FormatJobDetailAdmin(job))
-		adminMsg, err := c.Bot().Send(c.Sender(), msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
-		if err != nil {
-			h.log.Error("Failed to send updated job detail", logger.Error(err))
+		if !errors.Is(err, storage.ErrNotFound) {
+			h.log.Error("Failed to get employer by phone", logger.Error(err))
 			return c.Send(messages.MsgError)
 		}
-
-		// Save new admin message ID using new system
-		adminMessage := &models.AdminJobMessage{
-			JobID:     newJob.ID,
-			AdminID:   c.Sender().ID,
-			MessageID: int64(adminMsg.ID),
-		}
-		if err := h.storage.AdminMessage().Upsert(ctx, adminMessage); err != nil {
-			h.log.Error("Failed to save admin message ID", logger.Error(err))
+		job.EmployerID = 0
+		job.EmployerPhone = phone
+		h.setTempJob(c.Sender().ID, job)
+		return h.promptJobCreationStep(c, models.StateCreatingJobEmployerName, job)
+
+	case models.StateCreatingJobEmployerName:
+		employer := &models.Employer{Name: text, Phone: job.EmployerPhone}
+		if err := h.storage.Employer().Create(ctx, employer); err != nil {
+			if !errors.Is(err, storage.ErrAlreadyExists) {
+				h.log.Error("Failed to create employer", logger.Error(err))
+				return c.Send(messages.MsgError)
+			}
+			// Another admin created the same employer meanwhile
+			employer, err = h.storage.Employer().GetByPhone(ctx, job.EmployerPhone)
+			if err != nil {
+				h.log.Error("Failed to get employer by phone", logger.Error(err))
+				return c.Send(messages.MsgError)
+			}
 		}
+		job.EmployerID = employer.ID
 
-		// Notify all other admins about the new job
-		go h.notifyOtherAdminsNewJob(newJob, c.Sender().ID)
+	case models.StateCreatingJobReview:
+		return c.Send(messages.MsgJobReviewUseButtons)
 
+	default:
 		return nil
+	}
+
+	return h.advanceJobCreation(c, job, user.State)
+}
+
+// finishJobCreation saves the drafted job and shows it to the creating admin
+func (h *Handler) finishJobCreation(c tele.Context, job *models.Job) error {
+	ctx := middleware.UpdateContext(c)
 
+	// Save job to database
+	job.CreatedByAdminID = c.Sender().ID
+	newJob, err := h.storage.Job().Create(ctx, job)
+	if err != nil {
+		h.log.Error("Failed to create job", logger.Error(err))
+		return c.Send(messages.MsgError)
 	}
 
-	// Update temp job and state
-	h.setTempJob(c.Sender().ID, job)
-	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, nextState); err != nil {
+	// Reset user state
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
 		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+
+	// Clear temp job
+	h.clearTempJob(c.Sender().ID)
+
+	// Show job preview with publish option
+	msg := fmt.Sprintf("✅ Ish yaratildi!\n\n%s", messages.FormatJobDetailAdmin(job))
+	adminMsg, err := c.Bot().Send(c.Sender(), msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
+	if err != nil {
+		h.log.Error("Failed to send updated job detail", logger.Error(err))
 		return c.Send(messages.MsgError)
 	}
 
-	// Use skip button for optional fields (location, buses)
-	if nextState == models.StateCreatingJobLocation || nextState == models.StateCreatingJobAvtobuslar {
-		return c.Send(nextPrompt, keyboards.CancelOrSkipKeyboard())
+	// Save new admin message ID using new system
+	adminMessage := &models.AdminJobMessage{
+		JobID:     newJob.ID,
+		AdminID:   c.Sender().ID,
+		MessageID: int64(adminMsg.ID),
+	}
+	if err := h.storage.AdminMessage().Upsert(ctx, adminMessage); err != nil {
+		h.log.Error("Failed to save admin message ID", logger.Error(err))
 	}
 
-	return c.Send(nextPrompt, keyboards.CancelKeyboard())
+	// Notify all other admins about the new job
+	go h.notifyOtherAdminsNewJob(context.WithoutCancel(ctx), newJob, c.Sender().ID)
+
+	return nil
 }
 
 func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	jobID := h.getEditingJobID(c.Sender().ID)
 	if jobID == 0 {
 		return c.Send(messages.MsgError)
@@ -770,9 +944,30 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 		return c.Send(messages.MsgError)
 	}
 
+	if user.State == models.StateEditingJobEmployerNotes {
+		return h.handleEmployerNotesInput(c, job, text)
+	}
+	if user.State == models.StateEditingJobEmployerContact {
+		return h.handleEmployerContactInput(c, job, text)
+	}
+	if user.State == models.StateEditingJobAddWorker {
+		return h.handleAddWorkerSearchInput(c, job, text)
+	}
+
+	// Write against the version the admin saw, so a change made meanwhile isn't overwritten
+	if version := h.getEditingJobVersion(c.Sender().ID); version != 0 {
+		job.Version = version
+	}
+
+	before := *job
+	reopened := false
 	switch user.State {
 	case models.StateEditingJobIshHaqqi:
-		job.Salary = text
+		salary, verr := validation.ValidateSalary(text)
+		if verr != nil {
+			return c.Send(verr.Message)
+		}
+		job.Salary = salary
 	case models.StateEditingJobOvqat:
 		job.Food = text
 	case models.StateEditingJobVaqt:
@@ -782,9 +977,9 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 	case models.StateEditingJobLocation:
 		job.Location = text
 	case models.StateEditingJobXizmatHaqqi:
-		xizmatHaqqi, err := strconv.Atoi(text)
-		if err != nil {
-			return c.Send("❌ Iltimos, raqam kiriting. Masalan: 9990")
+		xizmatHaqqi, verr := validation.ParseMoney(text)
+		if verr != nil {
+			return c.Send(verr.Message)
 		}
 		job.ServiceFee = xizmatHaqqi
 	case models.StateEditingJobAvtobuslar:
@@ -804,6 +999,14 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 			return c.Send("❌ Iltimos, 1 dan katta raqam kiriting.")
 		}
 		job.RequiredWorkers = kerakli
+
+		// More places than confirmed + reserved reopen a FULL job; no more than confirmed close an ACTIVE one
+		if job.Status == models.JobStatusFull && !job.IsFull() {
+			job.Status = models.JobStatusActive
+			reopened = true
+		} else if job.Status == models.JobStatusActive && job.IsCompletelyFull() {
+			job.Status = models.JobStatusFull
+		}
 	case models.StateEditingJobConfirmed:
 		confirmed, err := strconv.Atoi(text)
 		if err != nil || confirmed < 0 {
@@ -815,18 +1018,42 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 		job.ConfirmedSlots = confirmed
 
 		// Automatically update job status based on confirmed slots
-		if job.ConfirmedSlots >= job.RequiredWorkers {
-			job.Status = models.JobStatusFull
-		} else if job.Status == models.JobStatusFull && job.ConfirmedSlots < job.RequiredWorkers {
-			// If job was full but now has available slots, reopen it
-			job.Status = models.JobStatusActive
-		}
+		job.Status = job.SlotStatus()
 	case models.StateEditingJobEmployerPhone:
-		job.EmployerPhone = text
+		if verr := validation.ValidatePhone(text); verr != nil {
+			return c.Send(verr.Message)
+		}
+		job.EmployerPhone = validation.NormalizePhone(text)
+		// Re-link the job to whichever employer owns the new phone
+		job.EmployerID = 0
+		if employer, err := h.employerByPhone(ctx, job.EmployerPhone, text); err == nil {
+			job.EmployerID = employer.ID
+			job.EmployerPhone = employer.Phone
+		}
+	case models.StateEditingJobTalablar:
+		req, verr := validation.ParseJobRequirements(text)
+		if verr != nil {
+			return c.Send(verr.Message)
+		}
+		job.MinAge, job.MaxAge, job.MinHeight, job.MinWeight = req.MinAge, req.MaxAge, req.MinHeight, req.MinWeight
+		job.Gender = req.Gender
+	case models.StateEditingJobCaptionA, models.StateEditingJobCaptionB:
+		caption, verr := validation.ParseJobCaption(text)
+		if verr != nil {
+			return c.Send(verr.Message)
+		}
+		if user.State == models.StateEditingJobCaptionA {
+			job.CaptionA = caption
+		} else {
+			job.CaptionB = caption
+		}
 	}
 
 	// Update job in database
 	if err := h.storage.Job().Update(ctx, job); err != nil {
+		if errors.Is(err, storage.ErrVersionConflict) {
+			return h.handleJobEditConflict(c, job.ID)
+		}
 		h.log.Error("Failed to update job", logger.Error(err))
 		return c.Send(messages.MsgError)
 	}
@@ -834,10 +1061,17 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 	// Update channel message if exists
 	if job.ChannelMessageID != 0 {
 		h.updateChannelMessage(job)
+		h.syncChannelLocation(ctx, &before, job)
+		if reopened && h.cfg.Bot.ReopenNotice {
+			h.announceReopenedJob(ctx, job)
+		}
 	}
 
 	// Update ALL other admin messages (excluding current admin)
-	go h.updateOtherAdminMessages(job.ID, c.Sender().ID)
+	go h.updateOtherAdminMessages(context.WithoutCancel(ctx), job.ID, c.Sender().ID)
+
+	// Confirmed workers need to know about a new date, time or place
+	go h.notifyWorkersJobChanged(context.WithoutCancel(ctx), &before, job)
 
 	// Reset user state
 	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
@@ -856,7 +1090,7 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 	}
 
 	// Single-message enforcement per admin: Delete this admin's previous message
-	h.deleteAdminMessageForAdmin(job.ID, c.Sender().ID)
+	h.deleteAdminMessageForAdmin(ctx, job.ID, c.Sender().ID)
 
 	// Send new admin message with updated info and success notification
 	msg := fmt.Sprintf("✅ Yangilandi!\n\n%s", messages.FormatJobDetailAdmin(job))
@@ -881,7 +1115,7 @@ func (h *Handler) handleJobEditingInput(c tele.Context, user *models.User, text
 
 // HandleCancelJobCreation cancels the job creation flow
 func (h *Handler) HandleCancelJobCreation(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
 		h.log.Error("Failed to update user state", logger.Error(err))
@@ -903,7 +1137,7 @@ func (h *Handler) HandleCancelJobCreation(c tele.Context) error {
 
 // HandleSkipField handles skipping optional fields during job creation
 func (h *Handler) HandleSkipField(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	user, err := h.storage.User().GetOrCreateUser(ctx, c.Sender().ID, c.Sender().Username, c.Sender().FirstName, c.Sender().LastName)
 	if err != nil {
 		h.log.Error("Failed to get user", logger.Error(err))
@@ -939,25 +1173,29 @@ func (h *Handler) HandleSkipField(c tele.Context) error {
 
 // Helper to update channel message
 func (h *Handler) updateChannelMessage(job *models.Job) {
-	msg := &tele.Message{
-		ID:   int(job.ChannelMessageID),
-		Chat: &tele.Chat{ID: h.cfg.Bot.ChannelID},
-	}
+	// Batched with the job's other channel edits; failures are logged by the sender
+	_ = h.services.Sender().UpdateChannelJobPost(context.Background(), job)
 
-	channelMsg := messages.FormatJobForChannel(job)
+	// Status, slots or the listed fields may have changed
+	h.services.ChannelIndex().Notify()
+}
 
-	// Only show signup button if job is ACTIVE
-	var keyboard *tele.ReplyMarkup
-	if job.Status == models.JobStatusActive {
-		keyboard = keyboards.JobSignupKeyboard(job.ID, h.cfg.Bot.Username)
-	} else {
-		// Remove buttons for non-active jobs (FULL, COMPLETED, CANCELLED, DRAFT)
-		keyboard = &tele.ReplyMarkup{}
+// announceReopenedJob replies to the job's channel post that new places are open
+func (h *Handler) announceReopenedJob(ctx context.Context, job *models.Job) {
+	opts := &tele.SendOptions{
+		ReplyTo:     &tele.Message{ID: int(job.ChannelMessageID)},
+		ReplyMarkup: keyboards.JobSignupKeyboard(job.ID, h.cfg.Bot.Username),
+		ParseMode:   tele.ModeHTML,
 	}
-
-	if _, err := h.bot.Edit(msg, channelMsg, keyboard, tele.ModeHTML); err != nil {
-		h.log.Error("Failed to update channel message", logger.Error(err))
+	if _, err := h.bot.Send(&tele.Chat{ID: h.cfg.Bot.ChannelID}, messages.FormatSlotsReopened(job), opts); err != nil {
+		logger.FromContext(ctx, h.log).Error("Failed to post reopened job notice", logger.Error(err), logger.Any("job_id", job.ID))
+		return
 	}
+
+	logger.FromContext(ctx, h.log).Info("Reopened job announced in channel",
+		logger.Any("job_id", job.ID),
+		logger.Int("available_slots", job.AvailableSlots()),
+	)
 }
 
 // Helper to get job field value for display
@@ -974,7 +1212,7 @@ func getJobFieldValue(job *models.Job, field string) string {
 	case "location":
 		return job.Location
 	case "xizmat_haqqi":
-		return fmt.Sprintf("%d", job.ServiceFee)
+		return helper.Money(job.ServiceFee).String()
 	case "avtobuslar":
 		return job.Buses
 	case "ish_tavsifi":
@@ -987,6 +1225,12 @@ func getJobFieldValue(job *models.Job, field string) string {
 		return fmt.Sprintf("%d", job.ConfirmedSlots)
 	case "employer_phone":
 		return job.EmployerPhone
+	case "talablar":
+		return messages.FormatJobRequirements(job)
+	case "caption_a":
+		return job.CaptionA
+	case "caption_b":
+		return job.CaptionB
 	default:
 		return ""
 	}
@@ -1004,7 +1248,7 @@ func (h *Handler) HandleViewJobBookings(c tele.Context, jobIDStr string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Get job details
 	job, err := h.storage.Job().GetByID(ctx, jobID)
@@ -1037,11 +1281,24 @@ func (h *Handler) HandleViewJobBookings(c tele.Context, jobIDStr string) error {
 
 	// Build message with user details
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "👥 <b>ISH №%d - YOZILGANLAR</b>\n\n", job.OrderNumber)
+	fmt.Fprintf(&sb, "👥 <b>ISH №%s - YOZILGANLAR</b>\n\n", messages.JobNumber(job))
 	fmt.Fprintf(&sb, "📅 Ish kuni: %s\n", job.WorkDate)
 	fmt.Fprintf(&sb, "📊 Jami: %d ta ishchi\n\n", len(activeBookings))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━\n\n")
 
+	// Ratings already given for this job, keyed by booking ID
+	ratings := make(map[int64]int)
+	jobRatings, err := h.storage.WorkerRating().GetByJobID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get worker ratings", logger.Error(err))
+	}
+	for _, r := range jobRatings {
+		ratings[r.BookingID] = r.Rating
+	}
+
+	menu := &tele.ReplyMarkup{}
+	var rows []tele.Row
+
 	for i, booking := range activeBookings {
 		// Get user's Telegram info
 		user, err := h.storage.User().GetByID(ctx, booking.UserID)
@@ -1075,16 +1332,27 @@ func (h *Handler) HandleViewJobBookings(c tele.Context, jobIDStr string) error {
 		}
 
 		fmt.Fprintf(&sb, "📞 Telefon: %s\n", registeredUser.Phone)
+		sb.WriteString(messages.FormatSharedPhoneWarning(h.sharedPhoneUserIDs(ctx, booking.UserID, registeredUser.Phone)))
 		fmt.Fprintf(&sb, "🎂 Yosh: %d\n", registeredUser.Age)
 		fmt.Fprintf(&sb, "⚖️ Vazn/Bo'y: %d kg / %d cm\n", registeredUser.Weight, registeredUser.Height)
-		fmt.Fprintf(&sb, "📊 Holat: %s %s\n", statusIcon, statusText)
+		if registeredUser.Gender != models.GenderUnknown {
+			fmt.Fprintf(&sb, "🚻 Jins: %s\n", registeredUser.Gender.Display())
+		}
+		if registeredUser.City != "" {
+			fmt.Fprintf(&sb, "🏙 Shahar: %s\n", registeredUser.City)
+		}
+		fmt.Fprintf(&sb, "📊 Holat: %s %s · <code>/booking %d</code>\n", statusIcon, statusText, booking.ID)
+		if booking.Status == models.BookingStatusConfirmed {
+			fmt.Fprintf(&sb, "🗓 Davomat: %s\n", attendanceDisplay(booking.Attendance))
+			rows = append(rows, h.bookingReviewRow(menu, booking, ratings[booking.ID], i+1, &sb))
+		}
 		sb.WriteString("\n")
 	}
 
 	// Add back button
-	menu := &tele.ReplyMarkup{}
 	btnBack := menu.Data("⬅️ Orqaga", fmt.Sprintf("job_detail_%d", jobID))
-	menu.Inline(menu.Row(btnBack))
+	rows = append(rows, menu.Row(btnBack))
+	menu.Inline(rows...)
 
 	if err := c.Respond(); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
@@ -1093,10 +1361,139 @@ func (h *Handler) HandleViewJobBookings(c tele.Context, jobIDStr string) error {
 	return c.Edit(sb.String(), menu, tele.ModeHTML)
 }
 
-// Helper to delete admin message for a specific admin (single-message per admin enforcement)
-func (h *Handler) deleteAdminMessageForAdmin(jobID, adminID int64) {
-	ctx := context.Background()
+// HandleMarkAttendance records whether a confirmed worker showed up (params: <bookingID>_<attended|no_show|reset>)
+func (h *Handler) HandleMarkAttendance(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	bookingIDStr, value, ok := strings.Cut(params, "_")
+	if !ok {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+
+	var attendance models.AttendanceStatus
+	switch value {
+	case "attended":
+		attendance = models.AttendanceAttended
+	case "no_show":
+		attendance = models.AttendanceNoShow
+	case "reset":
+		attendance = models.AttendanceUnmarked
+	default:
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
 
+	ctx := middleware.UpdateContext(c)
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Buyurtma topilmadi."})
+	}
+	if booking.Status != models.BookingStatusConfirmed {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Faqat tasdiqlangan ishchilar uchun."})
+	}
+
+	if err := h.storage.Booking().SetAttendance(ctx, bookingID, attendance); err != nil {
+		h.log.Error("Failed to set attendance", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	// Re-render the bookings list (it answers the callback itself)
+	return h.HandleViewJobBookings(c, strconv.FormatInt(booking.JobID, 10))
+}
+
+// HandleRateWorker stores an admin's 1-5 rating for a worker who attended (params: <bookingID>_<rating>)
+func (h *Handler) HandleRateWorker(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	bookingIDStr, ratingStr, ok := strings.Cut(params, "_")
+	if !ok {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+	bookingID, err1 := strconv.ParseInt(bookingIDStr, 10, 64)
+	rating, err2 := strconv.Atoi(ratingStr)
+	if err1 != nil || err2 != nil || rating < 1 || rating > 5 {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Buyurtma topilmadi."})
+	}
+	if booking.Status != models.BookingStatusConfirmed || booking.Attendance != models.AttendanceAttended {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Avval ishchi kelganini belgilang."})
+	}
+
+	workerRating := &models.WorkerRating{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		JobID:     booking.JobID,
+		AdminID:   c.Sender().ID,
+		Rating:    rating,
+	}
+	if err := h.storage.WorkerRating().Upsert(ctx, workerRating); err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	return h.HandleViewJobBookings(c, strconv.FormatInt(booking.JobID, 10))
+}
+
+// bookingReviewRow writes the rating line for a confirmed booking and returns its buttons:
+// attendance marks until attendance is set, then 1-5 rating buttons (attended only) plus a reset
+func (h *Handler) bookingReviewRow(menu *tele.ReplyMarkup, booking *models.JobBooking, rating, num int, sb *strings.Builder) tele.Row {
+	btnReset := menu.Data(fmt.Sprintf("🔄 %d", num), fmt.Sprintf("booking_attendance_%d_reset", booking.ID))
+
+	switch booking.Attendance {
+	case models.AttendanceAttended:
+		if rating > 0 {
+			fmt.Fprintf(sb, "⭐ Baho: %d/5\n", rating)
+		} else {
+			sb.WriteString("⭐ Baho: — baholanmagan\n")
+		}
+
+		row := menu.Row()
+		for r := 1; r <= 5; r++ {
+			btnText := fmt.Sprintf("%d⭐", r)
+			if r == rating {
+				btnText = fmt.Sprintf("✅ %d", r)
+			}
+			row = append(row, menu.Data(btnText, fmt.Sprintf("rate_worker_%d_%d", booking.ID, r)))
+		}
+		return append(row, btnReset)
+
+	case models.AttendanceNoShow:
+		return menu.Row(btnReset)
+
+	default:
+		btnAttended := menu.Data(fmt.Sprintf("✅ %d. Keldi", num), fmt.Sprintf("booking_attendance_%d_attended", booking.ID))
+		btnNoShow := menu.Data(fmt.Sprintf("🚫 %d. Kelmadi", num), fmt.Sprintf("booking_attendance_%d_no_show", booking.ID))
+		return menu.Row(btnAttended, btnNoShow)
+	}
+}
+
+// attendanceDisplay returns the admin-facing text for an attendance mark
+func attendanceDisplay(a models.AttendanceStatus) string {
+	switch a {
+	case models.AttendanceAttended:
+		return "✅ Keldi"
+	case models.AttendanceNoShow:
+		return "🚫 Kelmadi"
+	default:
+		return "— belgilanmagan"
+	}
+}
+
+// Helper to delete admin message for a specific admin (single-message per admin enforcement)
+func (h *Handler) deleteAdminMessageForAdmin(ctx context.Context, jobID, adminID int64) {
 	// Get the admin's message for this job
 	adminMsg, err := h.storage.AdminMessage().Get(ctx, jobID, adminID)
 	if err != nil {
@@ -1122,10 +1519,24 @@ func (h *Handler) deleteAdminMessageForAdmin(jobID, adminID int64) {
 	}
 }
 
-// Helper to update all admin messages for a job (broadcasts job updates)
-func (h *Handler) updateAllAdminMessages(job *models.Job) {
-	ctx := context.Background()
+// handleJobEditConflict ends an edit whose job was changed by someone else in the meantime
+func (h *Handler) handleJobEditConflict(c tele.Context, jobID int64) error {
+	ctx := middleware.UpdateContext(c)
+	h.log.Info("Job edit rejected: version conflict",
+		logger.Any("job_id", jobID),
+		logger.Any("admin_id", c.Sender().ID))
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	h.clearEditingJobID(c.Sender().ID)
+
+	return c.Send(messages.MsgJobEditConflict, keyboards.JobRefreshKeyboard(jobID))
+}
 
+// Helper to update all admin messages for a job (broadcasts job updates).
+// Edits go through the sender queue; failures are reported to initiatorID.
+func (h *Handler) updateAllAdminMessages(ctx context.Context, job *models.Job, initiatorID int64) {
 	// Get all admin messages for this job
 	adminMessages, err := h.storage.AdminMessage().GetAllByJobID(ctx, job.ID)
 	if err != nil {
@@ -1133,33 +1544,68 @@ func (h *Handler) updateAllAdminMessages(job *models.Job) {
 		return
 	}
 
-	// Update each admin's message
-	for _, adminMsg := range adminMessages {
-		msgToEdit := &tele.Message{
-			ID:   int(adminMsg.MessageID),
-			Chat: &tele.Chat{ID: adminMsg.AdminID},
-		}
+	h.editAdminMessages(ctx, job, adminMessages, initiatorID)
+}
 
-		msg := messages.FormatJobDetailAdmin(job)
-		_, err := h.bot.Edit(msgToEdit, msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
+// RefreshAdminMessages re-renders the stored admin messages of every open job so their text and
+// buttons match the running version after a deploy. Jobs go one at a time through the sender queue.
+func (h *Handler) RefreshAdminMessages(ctx context.Context) {
+	refreshed := 0
+	for _, status := range []models.JobStatus{models.JobStatusActive, models.JobStatusFull} {
+		jobs, err := h.storage.Job().GetAll(ctx, &status)
 		if err != nil {
-			h.log.Error("Failed to update admin message",
-				logger.Error(err),
-				logger.Any("admin_id", adminMsg.AdminID),
-				logger.Any("job_id", job.ID))
-			// If message not found, remove from database
-			if err.Error() == "telegram: message not found (400)" ||
-				err.Error() == "telegram: message to edit not found (400)" {
-				h.storage.AdminMessage().Delete(ctx, job.ID, adminMsg.AdminID)
+			h.log.Error("Failed to get jobs for admin message refresh", logger.Error(err), logger.Any("status", status))
+			continue
+		}
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				return
 			}
+			h.updateAllAdminMessages(ctx, job, 0)
+			refreshed++
 		}
 	}
+
+	h.log.Info("Admin job messages refreshed", logger.Int("jobs", refreshed))
 }
 
-// Helper to update other admin messages (excluding current admin)
-func (h *Handler) updateOtherAdminMessages(jobID, currentAdminID int64) {
-	ctx := context.Background()
+// notifyWorkersJobChanged tells confirmed workers when the date, time, address or location
+// of their job changed, with a fresh location pin when there is one
+func (h *Handler) notifyWorkersJobChanged(ctx context.Context, before, job *models.Job) {
+	changes := before.CriticalChanges(job)
+	if len(changes) == 0 {
+		return
+	}
 
+	bookings, err := h.storage.Booking().GetJobBookings(ctx, job.ID)
+	if err != nil {
+		h.log.Error("Failed to get job bookings for change notice", logger.Error(err), logger.Any("job_id", job.ID))
+		return
+	}
+
+	msg := messages.FormatJobChangedNotice(job, changes)
+	notified := 0
+	for _, booking := range bookings {
+		if booking.Status != models.BookingStatusConfirmed {
+			continue
+		}
+		if err := h.services.Sender().Send(ctx, booking.UserID, msg, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send job change notice", logger.Error(err), logger.Any("user_id", booking.UserID))
+			continue
+		}
+		h.sendJobLocation(ctx, booking.UserID, job)
+		notified++
+	}
+
+	h.log.Info("Confirmed workers notified about job change",
+		logger.Any("job_id", job.ID),
+		logger.Int("changes", len(changes)),
+		logger.Int("notified", notified),
+	)
+}
+
+// Helper to update other admin messages (excluding current admin)
+func (h *Handler) updateOtherAdminMessages(ctx context.Context, jobID, currentAdminID int64) {
 	// Get the updated job
 	job, err := h.storage.Job().GetByID(ctx, jobID)
 	if err != nil {
@@ -1174,52 +1620,99 @@ func (h *Handler) updateOtherAdminMessages(jobID, currentAdminID int64) {
 		return
 	}
 
-	// Update each admin's message (except current admin)
+	// Skip current admin, they already got their updated message
+	others := make([]*models.AdminJobMessage, 0, len(adminMessages))
 	for _, adminMsg := range adminMessages {
-		if adminMsg.AdminID == currentAdminID {
-			continue // Skip current admin, they already got their updated message
+		if adminMsg.AdminID != currentAdminID {
+			others = append(others, adminMsg)
 		}
+	}
 
-		msgToEdit := &tele.Message{
-			ID:   int(adminMsg.MessageID),
-			Chat: &tele.Chat{ID: adminMsg.AdminID},
+	h.editAdminMessages(ctx, job, others, currentAdminID)
+}
+
+// editAdminMessages queues an edit of every given admin job message
+func (h *Handler) editAdminMessages(ctx context.Context, job *models.Job, adminMessages []*models.AdminJobMessage, initiatorID int64) {
+	if len(adminMessages) == 0 {
+		return
+	}
+
+	msg := messages.FormatJobDetailAdmin(job)
+	reqs := make([]*service.MessageRequest, len(adminMessages))
+	for i, adminMsg := range adminMessages {
+		reqs[i] = &service.MessageRequest{
+			ChatID:    adminMsg.AdminID,
+			Message:   msg,
+			Options:   []any{keyboards.JobDetailKeyboard(job), tele.ModeHTML},
+			IsEdit:    true,
+			MessageID: int(adminMsg.MessageID),
 		}
+	}
 
-		msg := messages.FormatJobDetailAdmin(job)
-		_, err := h.bot.Edit(msgToEdit, msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
-		if err != nil {
-			h.log.Error("Failed to update other admin message",
-				logger.Error(err),
-				logger.Any("admin_id", adminMsg.AdminID),
-				logger.Any("job_id", jobID))
-			// If message not found, remove from database
-			if err.Error() == "telegram: message not found (400)" ||
-				err.Error() == "telegram: message to edit not found (400)" {
-				h.storage.AdminMessage().Delete(ctx, jobID, adminMsg.AdminID)
-			}
+	failed := 0
+	for i, resp := range h.services.Sender().Deliver(ctx, reqs) {
+		if resp.Error == nil {
+			continue
 		}
+		adminMsg := adminMessages[i]
+		h.log.Error("Failed to update admin message",
+			logger.Error(resp.Error),
+			logger.Any("admin_id", adminMsg.AdminID),
+			logger.Any("job_id", job.ID))
+		// If message not found, remove from database
+		if isMessageGone(resp.Error) {
+			h.storage.AdminMessage().Delete(ctx, job.ID, adminMsg.AdminID)
+			continue
+		}
+		failed++
 	}
+
+	h.reportFanOutFailures(ctx, initiatorID, failed, len(reqs))
 }
 
 // Helper to notify other admins about a new job
-func (h *Handler) notifyOtherAdminsNewJob(job *models.Job, creatorAdminID int64) {
-	ctx := context.Background()
+func (h *Handler) notifyOtherAdminsNewJob(ctx context.Context, job *models.Job, creatorAdminID int64) {
+	msg := fmt.Sprintf("🆕 Yangi ish yaratildi!\n\n%s", messages.FormatJobDetailAdmin(job))
+
+	// A dedicated operations group gets a read-only copy; the shared admin group
+	// stays payments-only as before. Group members aren't all admins, so the phone is masked.
+	if h.cfg.Bot.OpsGroupID != 0 {
+		groupMsg := fmt.Sprintf("🆕 Yangi ish yaratildi!\n\n%s", messages.FormatJobDetailForGroup(job))
+		if _, err := h.bot.Send(&tele.Chat{ID: h.cfg.Bot.OpsGroupID}, groupMsg, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to notify operations group about new job",
+				logger.Error(err),
+				logger.Any("job_id", job.ID))
+		}
+	}
 
 	// Notify all other admins
-	for _, adminID := range h.cfg.Bot.AdminIDs {
+	var reqs []*service.MessageRequest
+	for _, adminID := range h.services.Settings().AdminIDs(ctx) {
 		if adminID == creatorAdminID {
 			continue // Skip the admin who created the job
 		}
+		if !h.adminWantsNotification(ctx, adminID, models.NotifyNewJobs) {
+			continue // Admin turned off new job notifications
+		}
+		reqs = append(reqs, &service.MessageRequest{
+			ChatID:  adminID,
+			Message: msg,
+			Options: []any{keyboards.JobDetailKeyboard(job), tele.ModeHTML},
+		})
+	}
+	if len(reqs) == 0 {
+		return
+	}
 
-		// Send job detail to other admin
-		msg := fmt.Sprintf("🆕 Yangi ish yaratildi!\n\n%s", messages.FormatJobDetailAdmin(job))
-		chat := &tele.Chat{ID: adminID}
-		sentMsg, err := h.bot.Send(chat, msg, keyboards.JobDetailKeyboard(job), tele.ModeHTML)
-		if err != nil {
+	failed := 0
+	for i, resp := range h.services.Sender().Deliver(ctx, reqs) {
+		adminID := reqs[i].ChatID
+		if resp.Error != nil {
 			h.log.Error("Failed to notify other admin",
-				logger.Error(err),
+				logger.Error(resp.Error),
 				logger.Any("admin_id", adminID),
 				logger.Any("job_id", job.ID))
+			failed++
 			continue
 		}
 
@@ -1227,18 +1720,35 @@ func (h *Handler) notifyOtherAdminsNewJob(job *models.Job, creatorAdminID int64)
 		adminMessage := &models.AdminJobMessage{
 			JobID:     job.ID,
 			AdminID:   adminID,
-			MessageID: int64(sentMsg.ID),
+			MessageID: int64(resp.MessageID),
 		}
 		if err := h.storage.AdminMessage().Upsert(ctx, adminMessage); err != nil {
 			h.log.Error("Failed to save admin message for other admin", logger.Error(err))
 		}
 	}
+
+	h.reportFanOutFailures(ctx, creatorAdminID, failed, len(reqs))
 }
 
-// Helper to delete all admin messages for a job (used when deleting job)
-func (h *Handler) deleteAllAdminMessages(jobID int64) {
-	ctx := context.Background()
+// reportFanOutFailures tells the admin who triggered a broadcast how many admins it missed
+func (h *Handler) reportFanOutFailures(ctx context.Context, adminID int64, failed, total int) {
+	if failed == 0 || adminID == 0 {
+		return
+	}
+	msg := fmt.Sprintf(messages.MsgAdminFanOutPartial, failed, total)
+	if err := h.services.Sender().Send(ctx, adminID, msg); err != nil {
+		h.log.Error("Failed to report fan-out failures", logger.Error(err), logger.Any("admin_id", adminID))
+	}
+}
+
+// isMessageGone reports whether Telegram no longer has the message we tried to edit
+func isMessageGone(err error) bool {
+	return err.Error() == "telegram: message not found (400)" ||
+		err.Error() == "telegram: message to edit not found (400)"
+}
 
+// Helper to delete all admin messages for a job (used when deleting job)
+func (h *Handler) deleteAllAdminMessages(ctx context.Context, jobID int64) {
 	// Get all admin messages for this job
 	adminMessages, err := h.storage.AdminMessage().GetAllByJobID(ctx, jobID)
 	if err != nil {
@@ -1263,9 +1773,49 @@ func (h *Handler) deleteAllAdminMessages(jobID int64) {
 	}
 }
 
+// RevokeStaleAdminMessages removes the stored job messages of users who are no longer admins,
+// so their job cards stop offering admin buttons. A message Telegram won't delete is
+// overwritten without a keyboard instead. Runs on startup and after the admin list changes.
+func (h *Handler) RevokeStaleAdminMessages(ctx context.Context) {
+	adminMessages, err := h.storage.AdminMessage().GetAll(ctx)
+	if err != nil {
+		h.log.Error("Failed to get admin messages for revocation", logger.Error(err))
+		return
+	}
+
+	admins := h.services.Settings().AdminIDs(ctx)
+	revoked := make(map[int64]int)
+	for _, adminMsg := range adminMessages {
+		if slices.Contains(admins, adminMsg.AdminID) {
+			continue
+		}
+
+		msg := &tele.Message{ID: int(adminMsg.MessageID), Chat: &tele.Chat{ID: adminMsg.AdminID}}
+		if err := h.bot.Delete(msg); err != nil && !isMessageGone(err) {
+			if _, err := h.bot.Edit(msg, messages.MsgAdminJobMsgRevoked); err != nil && !isMessageGone(err) {
+				h.log.Error("Failed to revoke admin message",
+					logger.Error(err),
+					logger.Any("admin_id", adminMsg.AdminID),
+					logger.Any("job_id", adminMsg.JobID))
+			}
+		}
+
+		if err := h.storage.AdminMessage().Delete(ctx, adminMsg.JobID, adminMsg.AdminID); err != nil {
+			h.log.Error("Failed to clear revoked admin message", logger.Error(err))
+			continue
+		}
+		revoked[adminMsg.AdminID]++
+	}
+
+	for adminID, count := range revoked {
+		h.log.Info("Revoked job messages of removed admin",
+			logger.Any("admin_id", adminID),
+			logger.Int("messages", count))
+	}
+}
+
 // handleJobCreationLocationInput handles location input during job creation
 func (h *Handler) handleJobCreationLocationInput(c tele.Context, user *models.User, locationStr string) error {
-	ctx := context.Background()
 	job := h.getTempJob(c.Sender().ID)
 	if job == nil {
 		job = &models.Job{Status: models.JobStatusDraft, RequiredWorkers: 1}
@@ -1274,21 +1824,12 @@ func (h *Handler) handleJobCreationLocationInput(c tele.Context, user *models.Us
 	// Store location
 	job.Location = locationStr
 
-	// Update state to next step
-	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateCreatingJobXizmatHaqqi); err != nil {
-		h.log.Error("Failed to update user state", logger.Error(err))
-		return c.Send(messages.MsgError)
-	}
-
-	// Update temp job
-	h.setTempJob(c.Sender().ID, job)
-
-	return c.Send(messages.MsgEnterXizmatHaqqi, keyboards.CancelKeyboard())
+	return h.advanceJobCreation(c, job, models.StateCreatingJobLocation)
 }
 
 // handleJobEditingLocationInput handles location input during job editing
 func (h *Handler) handleJobEditingLocationInput(c tele.Context, user *models.User, locationStr string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	jobID := h.getEditingJobID(c.Sender().ID)
 	if jobID == 0 {
 		return c.Send(messages.MsgError)
@@ -1300,22 +1841,34 @@ func (h *Handler) handleJobEditingLocationInput(c tele.Context, user *models.Use
 		return c.Send(messages.MsgError)
 	}
 
+	if version := h.getEditingJobVersion(c.Sender().ID); version != 0 {
+		job.Version = version
+	}
+
 	// Update location
+	before := *job
 	job.Location = locationStr
 
 	// Update job in database
 	if err := h.storage.Job().Update(ctx, job); err != nil {
+		if errors.Is(err, storage.ErrVersionConflict) {
+			return h.handleJobEditConflict(c, job.ID)
+		}
 		h.log.Error("Failed to update job", logger.Error(err))
 		return c.Send(messages.MsgError)
 	}
 
-	// Update channel message if exists
+	// Update channel message and move its location reply to the new pin
 	if job.ChannelMessageID != 0 {
 		h.updateChannelMessage(job)
+		h.syncChannelLocation(ctx, &before, job)
 	}
 
 	// Update ALL other admin messages (excluding current admin)
-	go h.updateOtherAdminMessages(job.ID, c.Sender().ID)
+	go h.updateOtherAdminMessages(context.WithoutCancel(ctx), job.ID, c.Sender().ID)
+
+	// Confirmed workers get the new pin
+	go h.notifyWorkersJobChanged(context.WithoutCancel(ctx), &before, job)
 
 	// Reset user state
 	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
@@ -1381,7 +1934,7 @@ func (h *Handler) showUsersListPage(c tele.Context, page int, isCallback bool) e
 		return c.Send("❌ Sizda admin huquqi yo'q.")
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Get total count
 	totalCount, err := h.storage.Registration().GetTotalRegisteredCount(ctx)
@@ -1442,8 +1995,14 @@ func (h *Handler) showUsersListPage(c tele.Context, page int, isCallback bool) e
 		msg.WriteString(fmt.Sprintf("<b>%d. %s %s</b>\n", userIndex, status, user.FullName))
 		msg.WriteString(fmt.Sprintf("   📞 %s\n", user.Phone))
 		msg.WriteString(fmt.Sprintf("   👤 Yosh: %d | Vazn: %d kg | Bo'y: %d sm\n", user.Age, user.Weight, user.Height))
+		if user.Gender != models.GenderUnknown {
+			msg.WriteString(fmt.Sprintf("   🚻 %s\n", user.Gender.Display()))
+		}
+		if user.City != "" {
+			msg.WriteString(fmt.Sprintf("   🏙 %s\n", user.City))
+		}
 		msg.WriteString(fmt.Sprintf("   🆔 User ID: <code>%d</code>\n", user.UserID))
-		msg.WriteString(fmt.Sprintf("   📅 %s\n\n", user.CreatedAt.Add(5*time.Hour).Format("02.01.2006 15:04")))
+		msg.WriteString(fmt.Sprintf("   📅 %s\n\n", user.CreatedAt.In(config.Timezone).Format("02.01.2006 15:04")))
 	}
 
 	// Create pagination keyboard
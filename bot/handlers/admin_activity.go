@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// adminActivityDefaultDays is the period of /activity without an argument
+	adminActivityDefaultDays = 30
+	// adminActivityMaxDays caps the period, keeping the report query cheap
+	adminActivityMaxDays = 365
+)
+
+// HandleAdminActivityCommand shows what each admin did in the last days: /activity [days]
+func (h *Handler) HandleAdminActivityCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	days := adminActivityDefaultDays
+	if payload := strings.TrimSpace(c.Message().Payload); payload != "" {
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < 1 || n > adminActivityMaxDays {
+			return c.Send("ℹ️ Foydalanish: /activity [kunlar soni, 1-365]\n\nMasalan: /activity 7")
+		}
+		days = n
+	}
+
+	// The period starts at midnight so "7 days" is today and the six before it
+	now := config.NowLocal()
+	from := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, config.Timezone)
+
+	ctx := middleware.UpdateContext(c)
+	activity, err := h.storage.Audit().GetAdminActivity(ctx, from, now)
+	if err != nil {
+		h.log.Error("Failed to get admin activity", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	names := make(map[int64]string, len(activity))
+	for _, a := range activity {
+		if user, err := h.storage.User().GetByID(ctx, a.AdminID); err == nil {
+			names[a.AdminID] = messages.FormatAdminName(user)
+		}
+	}
+
+	return c.Send(messages.FormatAdminActivity(activity, from, names), tele.ModeHTML)
+}
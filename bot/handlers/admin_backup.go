@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleBackupCommand takes a database backup: /backup (super admins). The backup can take
+// minutes, so it runs in the background and the result is sent when it is done.
+func (h *Handler) HandleBackupCommand(c tele.Context) error {
+	if !h.services.Settings().IsSuperAdmin(c.Sender().ID) {
+		return c.Send(messages.MsgSuperAdminOnly)
+	}
+	backups := h.services.Backup()
+	if !backups.Enabled() {
+		return c.Send(messages.MsgBackupDisabled)
+	}
+
+	if err := c.Send(messages.MsgBackupStarted); err != nil {
+		h.log.Error("Failed to send backup notice", logger.Error(err))
+	}
+	go h.runBackup(context.WithoutCancel(middleware.UpdateContext(c)), c.Chat().ID, c.Sender().ID)
+	return nil
+}
+
+// runBackup takes the backup and reports the result to the chat that asked for it
+func (h *Handler) runBackup(ctx context.Context, chatID, adminID int64) {
+	log := logger.FromContext(ctx, h.log)
+
+	run, err := h.services.Backup().Run(ctx, adminID)
+	msg := ""
+	switch {
+	case errors.Is(err, service.ErrBackupRunning):
+		msg = messages.MsgBackupRunning
+	case run != nil:
+		// Failed runs come back filled in too, with the error
+		msg = messages.FormatBackupResult(run)
+	default:
+		log.Error("Failed to take backup", logger.Error(err))
+		msg = messages.MsgError
+	}
+
+	if err := h.services.Sender().Send(ctx, chatID, msg, tele.ModeHTML); err != nil {
+		log.Error("Failed to send backup result", logger.Error(err))
+	}
+}
+
+// HandleBackupStatusCommand shows when the last successful backup was taken: /backup_status (super admins)
+func (h *Handler) HandleBackupStatusCommand(c tele.Context) error {
+	if !h.services.Settings().IsSuperAdmin(c.Sender().ID) {
+		return c.Send(messages.MsgSuperAdminOnly)
+	}
+	backups := h.services.Backup()
+	if !backups.Enabled() {
+		return c.Send(messages.MsgBackupDisabled)
+	}
+
+	status, err := backups.Status(middleware.UpdateContext(c))
+	if err != nil {
+		h.log.Error("Failed to get backup status", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	return c.Send(messages.FormatBackupStatus(status, config.NowLocal()), tele.ModeHTML)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// receiptBookingRef finds the booking in a receipt card caption ("Booking ID: #12") or an album photo caption ("Booking #12")
+var receiptBookingRef = regexp.MustCompile(`Booking(?: ID:)? #(\d+)`)
+
+// adminReplyText returns the text of the bot message an admin replied to in chatID or in the admin's
+// private chat, or "" when the message is not such a reply
+func (h *Handler) adminReplyText(c tele.Context, chatID int64) string {
+	msg := c.Message()
+	if msg == nil || msg.ReplyTo == nil || msg.ReplyTo.Sender == nil || !msg.ReplyTo.Sender.IsBot {
+		return ""
+	}
+	if c.Chat().Type != tele.ChatPrivate && (chatID == 0 || c.Chat().ID != chatID) {
+		return ""
+	}
+	if !h.IsAdmin(c.Sender().ID) {
+		return ""
+	}
+	if msg.ReplyTo.Caption != "" {
+		return msg.ReplyTo.Caption
+	}
+	return msg.ReplyTo.Text
+}
+
+// relayBookingID returns the booking an admin's reply is about, or 0 when the message is not
+// an admin's reply to a receipt card in the payments group or in the admin's private chat
+func (h *Handler) relayBookingID(c tele.Context) int64 {
+	match := receiptBookingRef.FindStringSubmatch(h.adminReplyText(c, h.cfg.Bot.PaymentsChatID()))
+	if match == nil {
+		return 0
+	}
+	bookingID, _ := strconv.ParseInt(match[1], 10, 64)
+	return bookingID
+}
+
+// HandleAdminRelay sends an admin's reply to a receipt card to the booking's user.
+// The user sees the job and booking it is about, never the admin's account.
+func (h *Handler) HandleAdminRelay(c tele.Context, bookingID int64) error {
+	ctx := middleware.UpdateContext(c)
+
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking for admin reply", logger.Error(err), logger.Any("booking_id", bookingID))
+		return c.Reply(messages.MsgAdminRelayNoBooking)
+	}
+
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		h.log.Error("Failed to get job for admin reply", logger.Error(err), logger.Any("job_id", booking.JobID))
+		return c.Reply(messages.MsgAdminRelayNoBooking)
+	}
+
+	text := messages.FormatAdminRelay(job, booking.ID, c.Text())
+	if err := h.services.Sender().Send(ctx, booking.UserID, text, tele.ModeHTML); err != nil {
+		return c.Reply(messages.MsgAdminRelayFailed)
+	}
+
+	h.log.Info("Admin reply relayed to user",
+		logger.Any("admin_id", c.Sender().ID),
+		logger.Any("user_id", booking.UserID),
+		logger.Any("booking_id", booking.ID),
+	)
+	return c.Reply(messages.MsgAdminRelaySent)
+}
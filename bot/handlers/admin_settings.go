@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleAdminSettings shows the admin's notification preferences, and the runtime settings to super admins
+func (h *Handler) HandleAdminSettings(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	prefs, err := h.storage.AdminPrefs().Get(ctx, c.Sender().ID)
+	if err != nil {
+		h.log.Error("Failed to get admin notification prefs", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := c.Send(messages.MsgAdminNotificationSettings, keyboards.AdminNotificationSettingsKeyboard(prefs), tele.ModeHTML); err != nil {
+		return err
+	}
+
+	if !h.services.Settings().IsSuperAdmin(c.Sender().ID) {
+		return nil
+	}
+	return c.Send(h.runtimeSettingsText(ctx), keyboards.RuntimeSettingsKeyboard(), tele.ModeHTML)
+}
+
+// HandleToggleAdminNotification flips a single notification category for the admin
+func (h *Handler) HandleToggleAdminNotification(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	prefs, err := h.storage.AdminPrefs().Get(ctx, c.Sender().ID)
+	if err != nil {
+		h.log.Error("Failed to get admin notification prefs", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if !prefs.Toggle(models.NotificationCategory(params)) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri sozlama"})
+	}
+
+	if err := h.storage.AdminPrefs().Upsert(ctx, prefs); err != nil {
+		h.log.Error("Failed to save admin notification prefs", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Saqlandi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(messages.MsgAdminNotificationSettings, keyboards.AdminNotificationSettingsKeyboard(prefs), tele.ModeHTML)
+}
+
+// adminWantsNotification reports whether the admin has the given notification category enabled.
+// On storage errors the category default is used so notifications are not silently lost.
+func (h *Handler) adminWantsNotification(ctx context.Context, adminID int64, category models.NotificationCategory) bool {
+	prefs, err := h.storage.AdminPrefs().Get(ctx, adminID)
+	if err != nil {
+		h.log.Error("Failed to get admin notification prefs",
+			logger.Error(err),
+			logger.Any("admin_id", adminID))
+		prefs = models.DefaultAdminNotificationPrefs(adminID)
+	}
+	return prefs.IsEnabled(category)
+}
@@ -2,20 +2,24 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
 
 	tele "gopkg.in/telebot.v4"
 )
 
 // HandleJobBookingStart starts the job booking flow for a registered user
 func (h *Handler) HandleJobBookingStart(c tele.Context, user *models.User, jobID int64) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Get job details
 	job, err := h.storage.Job().GetByID(ctx, jobID)
@@ -39,24 +43,26 @@ func (h *Handler) HandleJobBookingStart(c tele.Context, user *models.User, jobID
 		return c.Send("❌ Bu ishga barcha joylar band.")
 	}
 
-	// Show job details with booking confirmation
-	msg := messages.FormatJobDetailUser(job)
+	// Deployments may require joining the channel first
+	if prompted, err := h.promptChannelSubscription(c, user.ID, jobID); prompted {
+		return err
+	}
 
-	// Create confirmation keyboard
-	menu := &tele.ReplyMarkup{}
-	btnConfirm := menu.Data("✅ Ha, yozilaman", fmt.Sprintf("book_confirm_%d", jobID))
-	btnCancel := menu.Data("❌ Yo'q, bekor qilish", "book_cancel")
-	menu.Inline(
-		menu.Row(btnConfirm),
-		menu.Row(btnCancel),
-	)
+	// Preview where the job is, as the channel post does
+	if preview := h.jobLocationPreview(job); preview != nil {
+		if err := c.Send(preview); err != nil {
+			h.log.Error("Failed to send job location", logger.Error(err), logger.Any("job_id", jobID))
+		}
+	}
 
-	return c.Send(msg, menu, tele.ModeHTML)
+	// Show job details with booking confirmation
+	msg := messages.FormatJobDetailUser(job)
+	return c.Send(msg, keyboards.JobBookingKeyboard(jobID), tele.ModeHTML)
 }
 
 // HandleRegistrationStartWithJob starts registration flow while saving the target job ID
 func (h *Handler) HandleRegistrationStartWithJob(c tele.Context, jobID int64) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Get job to show what they're signing up for
 	job, err := h.storage.Job().GetByID(ctx, jobID)
@@ -68,7 +74,7 @@ func (h *Handler) HandleRegistrationStartWithJob(c tele.Context, jobID int64) er
 	msg := fmt.Sprintf(`
 👋 Salom!
 
-Siz <b>№%d</b> raqamli ishga yozilmoqchisiz.
+Siz <b>№%s</b> raqamli ishga yozilmoqchisiz.
 
 Avval ro'yxatdan o'tishingiz kerak. Ro'yxatdan o'tish bir necha daqiqani oladi.
 
@@ -81,7 +87,7 @@ Ro'yxatdan o'tgandan so'ng, ishga yozilish jarayonini davom ettirishingiz mumkin
 
 Davom etamizmi?
 `,
-		job.OrderNumber,
+		messages.JobNumber(job),
 		job.Salary,
 		job.WorkDate,
 		job.Address,
@@ -106,7 +112,7 @@ func (h *Handler) HandleStartRegistrationForJob(c tele.Context, jobIDStr string)
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	if err := c.Respond(); err != nil {
@@ -147,7 +153,7 @@ func (h *Handler) HandleBookingConfirm(c tele.Context, jobIDStr string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	if err := c.Respond(); err != nil {
@@ -158,6 +164,15 @@ func (h *Handler) HandleBookingConfirm(c tele.Context, jobIDStr string) error {
 		}
 	}
 
+	// A new offer version must be accepted before booking
+	if prompted, err := h.promptPendingOffer(c, userID, jobID); prompted {
+		return err
+	}
+	// Leaving the channel after opening the job doesn't get around the requirement
+	if prompted, err := h.promptChannelSubscription(c, userID, jobID); prompted {
+		return err
+	}
+
 	// Check idempotency through service
 	existingBooking, _ := h.services.Booking().CheckIdempotency(ctx, userID, jobID)
 	if existingBooking != nil {
@@ -171,6 +186,9 @@ func (h *Handler) HandleBookingConfirm(c tele.Context, jobIDStr string) error {
 		if existingBooking.Status == models.BookingStatusPaymentSubmitted {
 			return c.Edit("⚠️ Sizning to'lovingiz ko'rib chiqilmoqda. Iltimos, admin javobini kuting.")
 		}
+		if existingBooking.Status == models.BookingStatusPaymentRejectedRetryable && !existingBooking.IsExpired() {
+			return c.Edit("🔁 Joyingiz saqlanmoqda. Iltimos, aniqroq to'lov chekini yuboring.")
+		}
 		if existingBooking.Status == models.BookingStatusConfirmed {
 			return c.Edit("✅ Siz allaqachon tasdiqlangansiz!")
 		}
@@ -209,21 +227,39 @@ func (h *Handler) HandleBookingConfirm(c tele.Context, jobIDStr string) error {
 		}
 
 		// 3. User constraint errors
-		if strings.Contains(errStr, "you have another active booking") {
-			return c.Edit("⚠️ Sizda allaqachon boshqa faol bandlovingiz bor. Iltimos, avval uni yakunlang yoki bekor qiling.")
+		var limitErr *service.ActiveBookingLimitError
+		if errors.As(err, &limitErr) {
+			return c.Edit(h.formatActiveBookingLimit(ctx, limitErr), tele.ModeHTML)
+		}
+		var reqErr *service.RequirementsNotMetError
+		if errors.As(err, &reqErr) {
+			return c.Edit(messages.FormatRequirementsNotMet(reqErr.Job, reqErr.Unmet, reqErr.Profile), tele.ModeHTML)
 		}
-		if strings.Contains(errStr, "payment is being reviewed") || strings.Contains(errStr, "you have a payment under review") {
+		if errStr == "payment is being reviewed" {
 			return c.Edit("⚠️ Sizning boshqa ish uchun to'lovingiz ko'rib chiqilmoqda. Iltimos, admin javobini kuting.")
 		}
+		if errStr == "payment resubmission pending" {
+			return c.Edit("🔁 Joyingiz saqlanmoqda. Iltimos, aniqroq to'lov chekini yuboring.")
+		}
 		if errStr == "booking already confirmed" {
 			return c.Edit("✅ Siz allaqachon tasdiqlangansiz!")
 		}
+		if errors.Is(err, service.ErrPhoneAlreadyBooked) {
+			return c.Edit(messages.MsgPhoneAlreadyBooked)
+		}
+		if errors.Is(err, service.ErrInvalidBookingTransition) {
+			return c.Edit("⏳ Oldingi band qilishingiz hali yopilmoqda. Bir daqiqadan so'ng qayta urinib ko'ring.")
+		}
 
 		return c.Edit("❌ Xatolik yuz berdi. Iltimos, qaytadan urinib ko'ring.")
 	}
 
+	// The reservation is committed; show the held place on the channel post
+	h.services.ChannelPosts().Schedule(jobID)
+
 	// Success! Send payment instructions
-	msg := messages.FormatPaymentInstructions(job, h.cfg.Payment.CardNumber, h.cfg.Payment.CardHolderName)
+	card := h.services.Settings().Card(ctx)
+	msg := messages.FormatPaymentInstructions(job, card.Number, card.HolderName)
 
 	// Edit the message
 	if err := c.Edit(msg, tele.ModeHTML); err != nil {
@@ -231,26 +267,37 @@ func (h *Handler) HandleBookingConfirm(c tele.Context, jobIDStr string) error {
 		return c.Send(msg, tele.ModeHTML)
 	}
 
+	// Start the booking's status message below the instructions
+	if h.services.BookingStatus().Enabled() {
+		go h.services.BookingStatus().Refresh(context.WithoutCancel(middleware.UpdateContext(c)), booking.ID)
+	}
+
 	// Store the callback message ID in the booking for later deletion/editing
 	if c.Callback() != nil && c.Callback().Message != nil {
 		messageID := int64(c.Callback().Message.ID)
-		// Update booking with message ID in a separate transaction (non-critical)
+		// Only the message column is written, so a receipt or expiry landing meanwhile keeps its status (non-critical)
+		// Detached from the update deadline, which ends when this handler returns
+		updateCtx := context.WithoutCancel(middleware.UpdateContext(c))
 		go func() {
-			updateCtx := context.Background()
-			tx, err := h.storage.Transaction().Begin(updateCtx)
-			if err != nil {
-				return
-			}
-			// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
-			defer h.storage.Transaction().Rollback(updateCtx, tx)
-
-			booking.PaymentInstructionMsgID = messageID
-			if err := h.storage.Booking().Update(updateCtx, tx, booking); err != nil {
-				return
+			if err := h.storage.Booking().SetPaymentInstructionMsgID(updateCtx, booking.ID, messageID); err != nil {
+				h.log.Error("Failed to save payment instruction message", logger.Error(err))
 			}
-			h.storage.Transaction().Commit(updateCtx, tx)
 		}()
 	}
 
 	return nil
 }
+
+// formatActiveBookingLimit explains which of the user's bookings keep them from booking another job
+func (h *Handler) formatActiveBookingLimit(ctx context.Context, limitErr *service.ActiveBookingLimitError) string {
+	jobs := make(map[int64]*models.Job, len(limitErr.Blocking))
+	for _, b := range limitErr.Blocking {
+		job, err := h.storage.Job().GetByID(ctx, b.JobID)
+		if err != nil {
+			h.log.Error("Failed to get blocking job", logger.Error(err), logger.Any("job_id", b.JobID))
+			continue
+		}
+		jobs[b.JobID] = job
+	}
+	return messages.FormatActiveBookingLimit(limitErr.Limit, limitErr.AwaitingReceipt, limitErr.Blocking, jobs)
+}
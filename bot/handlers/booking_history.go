@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleBookingHistoryCommand shows a booking's status changes to an admin: /booking <id>
+func (h *Handler) HandleBookingHistoryCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	bookingID, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(c.Message().Payload), "#"), 10, 64)
+	if err != nil || bookingID <= 0 {
+		return c.Send("ℹ️ Foydalanish: /booking <id>\n\nMasalan: /booking 125")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Send("❌ Booking topilmadi.")
+		}
+		h.log.Error("Failed to get booking", logger.Error(err), logger.Any("booking_id", bookingID))
+		return c.Send(messages.MsgError)
+	}
+
+	events, err := h.storage.Booking().GetEvents(ctx, bookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking events", logger.Error(err), logger.Any("booking_id", bookingID))
+		return c.Send(messages.MsgError)
+	}
+
+	// The history is still worth showing if the job was deleted
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		job = nil
+	}
+
+	return c.Send(messages.FormatBookingHistory(booking, job, events), tele.ModeHTML)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// userCommands is the command menu of every private chat
+var userCommands = []tele.Command{
+	{Text: "start", Description: "Bosh menyu"},
+	{Text: "profile", Description: "Mening ma'lumotlarim"},
+	{Text: "myjobs", Description: "Mening ishlarim"},
+	{Text: "help", Description: "Yordam va ko'p so'raladigan savollar"},
+}
+
+// adminCommands is the command menu of an admin's chat; it replaces userCommands there
+var adminCommands = []tele.Command{
+	{Text: "start", Description: "Bosh menyu"},
+	{Text: "panel", Description: "Admin panel"},
+	{Text: "stats", Description: "Statistika"},
+	{Text: "pending", Description: "Tekshiruvni kutayotgan to'lovlar"},
+	{Text: "help", Description: "Yordam va ko'p so'raladigan savollar"},
+}
+
+// adminCommandScope limits a command menu to one admin's private chat
+func adminCommandScope(adminID int64) tele.CommandScope {
+	return tele.CommandScope{Type: tele.CommandScopeChat, ChatID: adminID}
+}
+
+// SetupCommands registers the user command menu for private chats and the admin menu for each
+// current admin. Runs on startup; failures are logged, the commands work without a menu.
+func (h *Handler) SetupCommands(ctx context.Context) {
+	scope := tele.CommandScope{Type: tele.CommandScopeAllPrivateChats}
+	if err := h.bot.SetCommands(userCommands, scope); err != nil {
+		h.log.Error("Failed to set user commands", logger.Error(err))
+	}
+	h.syncAdminCommands(ctx, nil, h.services.Settings().AdminIDs(ctx))
+}
+
+// syncAdminCommands gives added admins the admin menu and takes it back from removed ones
+func (h *Handler) syncAdminCommands(ctx context.Context, before, after []int64) {
+	for _, id := range after {
+		if slices.Contains(before, id) {
+			continue
+		}
+		if err := h.bot.SetCommands(adminCommands, adminCommandScope(id)); err != nil {
+			logger.FromContext(ctx, h.log).Error("Failed to set admin commands", logger.Error(err), logger.Any("admin_id", id))
+		}
+	}
+	for _, id := range before {
+		if slices.Contains(after, id) {
+			continue
+		}
+		if err := h.bot.DeleteCommands(adminCommandScope(id)); err != nil {
+			logger.FromContext(ctx, h.log).Error("Failed to delete admin commands", logger.Error(err), logger.Any("admin_id", id))
+		}
+	}
+}
@@ -58,35 +58,73 @@ func (h *Handler) staticCallbacks() map[string]tele.HandlerFunc {
 		"admin_menu":          h.HandleAdminPanel,
 		"admin_create_job":    h.HandleCreateJob,
 		"admin_job_list":      h.HandleJobList,
+		"job_list_noop":       h.HandleJobListNoop,
+		"job_preview_noop":    h.HandleJobPreviewNoop,
+		"admin_job_interest":  h.HandleJobInterestList,
 		"cancel_job_creation": h.HandleCancelJobCreation,
 		"skip_field":          h.HandleSkipField,
+		"job_create_back":     h.HandleJobCreationBack,
+		"job_create_save":     h.HandleJobCreationSave,
 
 		// Registration
-		"reg_accept_offer":     h.HandleAcceptOffer,
-		"reg_decline_offer":    h.HandleDeclineOffer,
-		"reg_continue":         h.HandleContinueRegistration,
-		"reg_restart":          h.HandleRestartRegistration,
-		"reg_confirm":          h.HandleConfirmRegistration,
-		"reg_edit":             h.HandleEditRegistration,
-		"reg_cancel":           h.HandleCancelRegistration,
-		"reg_back_to_confirm":  h.HandleBackToConfirm,
-		"reg_edit_full_name":   func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldFullName) },
-		"reg_edit_phone":       func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldPhone) },
-		"reg_edit_age":         func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldAge) },
-		"reg_edit_body_params": func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldBodyParams) },
+		"reg_accept_offer":        h.HandleAcceptOffer,
+		"reg_decline_offer":       h.HandleDeclineOffer,
+		"reg_continue":            h.HandleContinueRegistration,
+		"reg_restart":             h.HandleRestartRegistration,
+		"reg_confirm":             h.HandleConfirmRegistration,
+		"reg_edit":                h.HandleEditRegistration,
+		"reg_cancel":              h.HandleCancelRegistration,
+		"reg_back_to_confirm":     h.HandleBackToConfirm,
+		"reg_edit_full_name":      func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldFullName) },
+		"reg_edit_phone":          func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldPhone) },
+		"reg_edit_age":            func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldAge) },
+		"reg_edit_body_params":    func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldBodyParams) },
+		"reg_edit_gender":         func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldGender) },
+		"reg_edit_city":           func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldCity) },
+		"reg_edit_passport_photo": func(c tele.Context) error { return h.HandleEditField(c, models.EditFieldPassport) },
 
 		// Booking
 		"book_cancel": func(c tele.Context) error { return c.Edit("❌ Bekor qilindi.", keyboards.BackKeyboard()) },
 
+		// Payment
+		"payment_amount_skip": h.HandlePaymentAmountSkip,
+
+		// Public offer
+		"offer_decline":       h.HandleOfferDecline,
+		"offer_admin_new":     h.HandleOfferAdminNew,
+		"offer_admin_publish": h.HandleOfferAdminPublish,
+		"offer_admin_cancel":  h.HandleOfferAdminCancel,
+
+		// Runtime settings (super admins)
+		"settings_admins": h.HandleRuntimeSettingsAdmins,
+		"settings_card":   h.HandleRuntimeSettingsCard,
+		"settings_cancel": h.HandleRuntimeSettingsCancel,
+
+		// FAQ
+		"faq_home":      h.HandleHelpCallback,
+		"faq_admin":     h.HandleAdminFAQ,
+		"faq_admin_add": h.HandleAdminFAQAdd,
+
 		// User
-		"user_my_jobs": h.HandleUserMyJobs,
-		"user_profile": h.HandleUserProfile,
+		"user_my_jobs":   h.HandleUserMyJobs,
+		"user_profile":   h.HandleUserProfile,
+		"user_open_jobs": h.HandleUserOpenJobs,
 
 		// Profile editing
 		"edit_profile_full_name":   func(c tele.Context) error { return h.HandleEditProfileField(c, "full_name") },
 		"edit_profile_phone":       func(c tele.Context) error { return h.HandleEditProfileField(c, "phone") },
 		"edit_profile_age":         func(c tele.Context) error { return h.HandleEditProfileField(c, "age") },
 		"edit_profile_body_params": func(c tele.Context) error { return h.HandleEditProfileField(c, "body_params") },
+
+		// Notification preferences
+		"user_notify_marketing": h.HandleMarketingToggle,
+		"reengage_optout":       h.HandleReengageOptOut,
+		"reengage_optin":        h.HandleReengageOptIn,
+
+		// Account deletion
+		"account_delete_confirm": h.HandleDeleteAccountConfirm,
+		"account_delete_final":   h.HandleDeleteAccountFinal,
+		"account_delete_cancel":  h.HandleDeleteAccountCancel,
 	}
 }
 
@@ -96,21 +134,81 @@ func (h *Handler) dynamicCallbacks() []callbackRoute {
 	return []callbackRoute{
 		// Admin — job management
 		{"job_detail_", h.HandleJobDetail},
+		{"job_list_", h.HandleJobListView},
 		{"edit_job_", h.HandleEditJobField},
 		{"job_status_", h.HandleChangeJobStatus},
+		{"job_cancel_confirm_", h.HandleJobCancelConfirm},
+		{"job_cancel_", h.HandleJobCancel},
+		{"refund_paid_", h.HandleRefundPaid},
+		{"preview_job_edit_", h.HandlePreviewJobEdit},
+		{"preview_job_", h.HandlePreviewJob},
 		{"publish_job_", h.HandlePublishJob},
 		{"delete_channel_msg_", h.HandleDeleteChannelMessage},
 		{"delete_job_", h.HandleDeleteJob},
+		{"clone_job_", h.HandleCloneJob},
 		{"view_job_bookings_", h.HandleViewJobBookings},
+		{"job_funnel_", h.HandleJobFunnel},
+		{"booking_attendance_", h.HandleMarkAttendance},
+		{"rate_worker_", h.HandleRateWorker},
+		{"add_worker_pick_", h.HandleAddWorkerPick},
+		{"add_worker_", h.HandleAddWorker},
+		{"resend_location_", h.HandleResendLocation},
+
+		// Admin — employers
+		{"job_employer_pick_", h.HandlePickJobEmployer},
+		{"job_create_edit_", h.HandleJobCreationEdit},
+		{"job_employer_", h.HandleJobEmployer},
+		{"employer_rate_", h.HandleRateEmployer},
+		{"employer_notes_", h.HandleEditEmployerNotes},
+		{"employer_contact_", h.HandleEditEmployerContact},
+		{"payroll_export_", h.HandlePayrollExport},
+
+		// Admin — violations
+		{"user_violations_", h.HandleUserViolations},
+		{"violation_forgive_", h.HandleForgiveViolation},
+		{"violation_block_", h.HandleEscalateBlock},
+		{"violation_receipt_", h.HandleViolationReceipt},
+
+		// Admin — duplicate accounts
+		{"dup_merge_", h.HandleMergeDuplicate},
+		{"dup_reject_", h.HandleRejectDuplicate},
+
+		// Admin — FAQ management
+		{"faq_admin_entry_", h.HandleAdminFAQEntry},
+		{"faq_admin_edit_", h.HandleAdminFAQEdit},
+		{"faq_admin_delete_confirm_", h.HandleAdminFAQDeleteConfirm},
+		{"faq_admin_delete_", h.HandleAdminFAQDelete},
+
+		// User — FAQ
+		{"faq_cat_", h.HandleFAQCategory},
+		{"faq_q_", h.HandleFAQQuestion},
+
+		// Admin — notification settings
+		{"admin_notify_toggle_", h.HandleToggleAdminNotification},
+
+		// User — job feedback
+		{"feedback_pay_", h.HandleFeedbackPay},
+		{"feedback_cond_", h.HandleFeedbackConditions},
+
+		// User — public offer
+		{"reg_accept_offer_", h.HandleAcceptOfferVersion},
+		{"offer_accept_", h.HandleOfferAccept},
 
 		// User — booking
+		{"user_job_refresh_", h.HandleUserJobRefresh},
+		{"user_job_", h.HandleUserJobView},
 		{"book_confirm_", h.HandleBookingConfirm},
+		{"sub_check_", h.HandleSubscriptionCheck},
 		{"start_reg_job_", h.HandleStartRegistrationForJob},
 
 		// Admin — payment approval
 		{"approve_payment_", h.HandleApprovePayment},
 		{"reject_payment_", h.HandleRejectPayment},
 		{"block_user_", h.HandleBlockUser},
+		{"waive_req_", h.HandleWaiveRequirements},
+
+		// Support threads (admins and the thread's user)
+		{"support_close_", h.HandleSupportClose},
 
 		// Pagination
 		{"users_page_", h.HandleUsersListPage},
@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"context"
 	"strings"
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/pkg/keyboards"
 	"telegram-bot-starter/pkg/logger"
@@ -16,7 +16,7 @@ func (h *Handler) HandleHelpCallback(c tele.Context) error {
 	if err := c.Respond(); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
-	return c.Edit(messages.MsgHelp, tele.ModeHTML)
+	return h.showFAQ(c, true)
 }
 
 // HandleAboutCallback handles the about button callback
@@ -32,12 +32,12 @@ func (h *Handler) HandleSettingsCallback(c tele.Context) error {
 	if err := c.Respond(); err != nil {
 		h.log.Error("Failed to respond to callback", logger.Error(err))
 	}
-	return c.Edit(messages.MsgSettings, keyboards.BackKeyboard(), tele.ModeHTML)
+	return h.showUserSettings(c, true)
 }
 
 // HandleBackCallback handles the back button callback
 func (h *Handler) HandleBackCallback(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Get user to check state
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// isJobClosed reports whether the job no longer takes workers, so its channel post loses the location
+func isJobClosed(job *models.Job) bool {
+	return job.Status == models.JobStatusCompleted || job.Status == models.JobStatusCancelled
+}
+
+// postChannelLocation replies to the job's channel post with its location preview and stores the reply's ID
+func (h *Handler) postChannelLocation(ctx context.Context, job *models.Job) {
+	preview := h.jobLocationPreview(job)
+	if preview == nil || job.ChannelMessageID == 0 {
+		return
+	}
+
+	opts := &tele.SendOptions{ReplyTo: &tele.Message{ID: int(job.ChannelMessageID)}}
+	sent, err := h.bot.Send(tele.ChatID(h.cfg.Bot.ChannelID), preview, opts)
+	if err != nil {
+		logger.FromContext(ctx, h.log).Error("Failed to send location to channel", logger.Error(err), logger.Any("job_id", job.ID))
+		return
+	}
+
+	if err := h.storage.Job().UpdateChannelLocationMessageID(ctx, job.ID, int64(sent.ID)); err != nil {
+		logger.FromContext(ctx, h.log).Error("Failed to save channel location message ID", logger.Error(err), logger.Any("job_id", job.ID))
+	}
+	job.ChannelLocationMessageID = int64(sent.ID)
+}
+
+// removeChannelLocation deletes the location reply of the job's channel post, if any
+func (h *Handler) removeChannelLocation(ctx context.Context, job *models.Job) {
+	if job.ChannelLocationMessageID == 0 {
+		return
+	}
+
+	msg := &tele.Message{ID: int(job.ChannelLocationMessageID), Chat: &tele.Chat{ID: h.cfg.Bot.ChannelID}}
+	if err := h.bot.Delete(msg); err != nil {
+		// Most likely removed by hand already; forget it either way
+		logger.FromContext(ctx, h.log).Warn("Failed to delete channel location message", logger.Error(err), logger.Any("job_id", job.ID))
+	}
+
+	if err := h.storage.Job().UpdateChannelLocationMessageID(ctx, job.ID, 0); err != nil {
+		logger.FromContext(ctx, h.log).Error("Failed to clear channel location message ID", logger.Error(err), logger.Any("job_id", job.ID))
+	}
+	job.ChannelLocationMessageID = 0
+}
+
+// syncChannelLocation brings the location reply in line with an edited job: a closed job loses it,
+// a reopened one gets it back and a changed location replaces it
+func (h *Handler) syncChannelLocation(ctx context.Context, before, job *models.Job) {
+	if job.ChannelMessageID == 0 {
+		return
+	}
+	switch {
+	case isJobClosed(job):
+		h.removeChannelLocation(ctx, job)
+	case isJobClosed(before) || before.Location != job.Location:
+		h.removeChannelLocation(ctx, job)
+		h.postChannelLocation(ctx, job)
+	}
+}
@@ -2,23 +2,27 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/pkg/helper"
 	"telegram-bot-starter/pkg/keyboards"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/pkg/messages"
 	"telegram-bot-starter/pkg/validation"
+	"telegram-bot-starter/service"
+	"telegram-bot-starter/storage"
 
 	tele "gopkg.in/telebot.v4"
 )
 
 // HandleStart handles the /start command
 func (h *Handler) HandleStart(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	user := c.Sender()
 
 	// Get or create user in storage
@@ -31,28 +35,45 @@ func (h *Handler) HandleStart(c tele.Context) error {
 	// Reset any editing state (profile edit, job edit) so /start always goes to clean menu
 	if strings.HasPrefix(string(dbUser.State), "editing_profile_") ||
 		strings.HasPrefix(string(dbUser.State), "editing_job_") ||
-		strings.HasPrefix(string(dbUser.State), "creating_job_") {
+		strings.HasPrefix(string(dbUser.State), "creating_job_") ||
+		strings.HasPrefix(string(dbUser.State), "faq_") ||
+		strings.HasPrefix(string(dbUser.State), "offer_") {
 		h.storage.User().UpdateState(ctx, user.ID, models.StateIdle)
 		dbUser.State = models.StateIdle
 	}
 
-	// Check for deep link parameter (e.g., /start job_123)
+	// Check for deep link parameter (e.g., /start job_123, or job_123_b for a caption variant's link)
 	payload := c.Message().Payload
-	if payload != "" && strings.HasPrefix(payload, "job_") {
-		jobIDStr := strings.TrimPrefix(payload, "job_")
-		jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
-		if err == nil {
+	if payload != "" && strings.HasPrefix(payload, models.JobPayload) {
+		jobID, variant, ok := models.ParseJobPayload(payload)
+		if ok {
+			// First step of the job's conversion funnel
+			if err := h.storage.Job().RecordLinkStart(ctx, jobID, user.ID, variant); err != nil {
+				h.log.Error("Failed to record job link start", logger.Error(err))
+			}
+
 			// Check if user is registered by looking in registered_users table
 			registeredUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, user.ID)
-			if err == nil && registeredUser != nil {
-				// User is registered, start booking flow
+			if err == nil && registeredUser != nil && registeredUser.IsActive {
+				// User is registered, start booking flow once the current offer is accepted
+				if prompted, err := h.promptPendingOffer(c, user.ID, jobID); prompted {
+					return err
+				}
 				return h.HandleJobBookingStart(c, dbUser, jobID)
 			}
-			// User not registered yet, save job ID and start registration
+			// User not registered yet: remember the interest for the reminder, then start registration
+			if err := h.storage.JobInterest().Record(ctx, jobID, user.ID); err != nil {
+				h.log.Error("Failed to record job interest", logger.Error(err))
+			}
 			return h.HandleRegistrationStartWithJob(c, jobID)
 		}
 	}
 
+	// Admin scanned a worker's check-in QR code (/start checkin_<code>)
+	if strings.HasPrefix(payload, models.CheckInPayload) && h.IsAdmin(user.ID) {
+		return h.checkInByCode(c, models.NormalizeVoucherCode(strings.TrimPrefix(payload, models.CheckInPayload)))
+	}
+
 	// Check if this is an admin
 	if h.IsAdmin(user.ID) {
 		return c.Send(messages.MsgAdminPanel, keyboards.AdminMenuReplyKeyboard())
@@ -64,7 +85,7 @@ func (h *Handler) HandleStart(c tele.Context) error {
 
 // HandleHelp handles the /help command
 func (h *Handler) HandleHelp(c tele.Context) error {
-	return c.Send(messages.MsgHelp, tele.ModeHTML)
+	return h.showFAQ(c, false)
 }
 
 // HandleAbout handles the /about command
@@ -74,15 +95,29 @@ func (h *Handler) HandleAbout(c tele.Context) error {
 
 // HandleSettings handles the /settings command
 func (h *Handler) HandleSettings(c tele.Context) error {
-	return c.Send(messages.MsgSettings, keyboards.BackKeyboard(), tele.ModeHTML)
+	return h.showUserSettings(c, false)
 }
 
 // HandleText handles regular text messages
 func (h *Handler) HandleText(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	sender := c.Sender()
 	text := strings.TrimSpace(c.Text())
 
+	// Comments in the channel discussion group are not private-chat input
+	if h.isDiscussionChat(c) {
+		return h.HandleDiscussionText(c)
+	}
+
+	// An admin replying to a support message or a receipt card writes to that user, whatever flow the admin is in.
+	// Support is checked first: a user's support text may itself mention a booking.
+	if threadID := h.supportThreadID(c); threadID != 0 {
+		return h.HandleSupportReply(c, threadID)
+	}
+	if bookingID := h.relayBookingID(c); bookingID != 0 {
+		return h.HandleAdminRelay(c, bookingID)
+	}
+
 	// Get or create user
 	user, err := h.storage.User().GetOrCreateUser(ctx, sender.ID, sender.Username, sender.FirstName, sender.LastName)
 	if err != nil {
@@ -92,33 +127,16 @@ func (h *Handler) HandleText(c tele.Context) error {
 
 	// Handle cancel button from reply keyboard
 	if text == "❌ Bekor qilish" {
-		// Check if user is in profile editing flow
-		isEditingProfile := strings.HasPrefix(string(user.State), "editing_profile_")
-		if isEditingProfile {
-			return h.HandleCancelProfileEdit(c)
+		if handled, err := h.flows.Cancel(c, user); handled {
+			return err
 		}
 		// Otherwise, it's registration cancellation
 		return h.HandleCancelRegistration(c)
 	}
 
-	// Check if user is in registration flow
-	if h.IsInRegistrationFlow(user.State) {
-		regState := h.GetRegistrationState(user.State)
-		return h.HandleRegistrationTextInput(c, regState)
-	}
-
-	// Check if user is in job creation/editing flow (admin only)
-	isCreatingJob := strings.HasPrefix(string(user.State), "creating_job_")
-	isEditingJob := strings.HasPrefix(string(user.State), "editing_job_")
-
-	if h.IsAdmin(sender.ID) && (isCreatingJob || isEditingJob) {
-		return h.HandleAdminTextInput(c, user)
-	}
-
-	// Check if user is editing their profile
-	isEditingProfile := strings.HasPrefix(string(user.State), "editing_profile_")
-	if isEditingProfile {
-		return h.HandleProfileEditInput(c, user)
+	// Route input of multi-step flows (registration, job creation, profile edit, ...)
+	if handled, err := h.flows.Dispatch(c, user); handled {
+		return err
 	}
 
 	// Handle admin menu reply buttons
@@ -132,18 +150,27 @@ func (h *Handler) HandleText(c tele.Context) error {
 			return h.HandleRegisteredUsersList(c)
 		case "📊 Statistika":
 			return h.HandleAdminStatistics(c)
+		case "⚙️ Sozlamalar":
+			return h.HandleAdminSettings(c)
+		case "❓ FAQ":
+			return h.HandleAdminFAQ(c)
 		}
 	}
 
 	// Handle user menu reply buttons
 	switch text {
+	case "🔎 Ochiq ishlar":
+		return h.HandleUserOpenJobs(c)
 	case "👤 Profil":
 		return h.HandleUserProfile(c)
 	case "📋 Mening ishlarim":
 		return h.HandleUserMyJobs(c)
 	case "❓ Yordam":
-		// Check if we have a specific help message for users, otherwise generic
 		return h.HandleHelp(c)
+	case "⚙️ Sozlamalar":
+		return h.HandleSettings(c)
+	case "✉️ Adminga yozish":
+		return h.HandleSupportStart(c)
 	// Profile edit buttons
 	case "👤 Ism familiya":
 		return h.HandleEditProfileField(c, "full_name")
@@ -153,6 +180,10 @@ func (h *Handler) HandleText(c tele.Context) error {
 		return h.HandleEditProfileField(c, "age")
 	case "📏 Vazn va Bo'y":
 		return h.HandleEditProfileField(c, "body_params")
+	case "🚻 Jins":
+		return h.HandleEditProfileField(c, "gender")
+	case "🗑 Hisobni o'chirish":
+		return h.HandleDeleteAccount(c)
 	case "🏠 Asosiy menyu":
 		return h.HandleBackToMainMenu(c)
 	}
@@ -160,12 +191,13 @@ func (h *Handler) HandleText(c tele.Context) error {
 	// Default: check user state
 	switch user.State {
 	case models.StateIdle:
-		// Echo the message back with a prefix
-		// Only echo if not an admin command or handled above
-		if !h.IsAdmin(sender.ID) {
-			// Don't echo, just ignore or send help hint?
-			// For now, let's just ignore to avoid spamming
-			return nil
+		// Free text from a user with an open support thread goes to the admins; otherwise the
+		// user is pointed back to the menu once it happens often enough
+		if !h.IsAdmin(sender.ID) && c.Chat().Type == tele.ChatPrivate {
+			if relayed, err := h.relayToOpenSupportThread(c, user); relayed {
+				return err
+			}
+			return h.handleUnknownText(c)
 		}
 		return nil
 	default:
@@ -176,7 +208,7 @@ func (h *Handler) HandleText(c tele.Context) error {
 
 // HandleContact handles contact sharing messages
 func (h *Handler) HandleContact(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	sender := c.Sender()
 
 	// Get user
@@ -203,10 +235,7 @@ func (h *Handler) HandleContact(c tele.Context) error {
 			return c.Send("❌ Iltimos, o'z telefon raqamingizni yuboring.")
 		}
 
-		phone := contact.PhoneNumber
-		if !strings.HasPrefix(phone, "+") {
-			phone = "+" + phone
-		}
+		phone := validation.NormalizePhone(contact.PhoneNumber)
 
 		// Get registered user
 		regUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, sender.ID)
@@ -216,13 +245,18 @@ func (h *Handler) HandleContact(c tele.Context) error {
 		}
 
 		// Update phone
+		before := *regUser
 		regUser.Phone = phone
 
 		// Update registered user in database
 		if err := h.storage.Registration().UpdateRegisteredUser(ctx, regUser); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return c.Send(messages.MsgPhoneTaken)
+			}
 			h.log.Error("Failed to update registered user", logger.Error(err))
 			return c.Send(messages.MsgError)
 		}
+		h.auditProfileEdit(ctx, &before, regUser)
 
 		// Reset user state
 		if err := h.storage.User().UpdateState(ctx, sender.ID, models.StateIdle); err != nil {
@@ -264,22 +298,82 @@ func (h *Handler) HandlePhoto(c tele.Context) error {
 		return nil
 	}
 
-	return h.HandlePaymentReceiptSubmission(c, photo.FileID)
+	// Admin forwarded a worker's voucher QR; the code is in the caption
+	if h.IsAdmin(c.Sender().ID) {
+		if code := models.ExtractVoucherCode(c.Message().Caption); code != "" {
+			return h.checkInByCode(c, code)
+		}
+	}
+
+	// Passport photo step of registration
+	ctx := middleware.UpdateContext(c)
+	if user, err := h.storage.User().GetByID(ctx, c.Sender().ID); err == nil && user.State == models.UserState(models.RegStatePassportPhoto) {
+		return h.HandleRegistrationPassportPhoto(c, photo.FileID)
+	}
+
+	// Several screenshots of one transfer come as an album: one submission for all of them
+	if c.Message().AlbumID != "" {
+		return h.handleReceiptAlbumPhoto(c, photo.FileID)
+	}
+
+	return h.HandlePaymentReceiptSubmission(c, []string{photo.FileID}, false, int64(c.Message().ID))
 }
 
-// HandlePaymentReceiptSubmission handles payment receipt photo submission
-func (h *Handler) HandlePaymentReceiptSubmission(c tele.Context, photoFileID string) error {
-	ctx := context.Background()
+// receiptDocumentMaxSize caps a receipt sent as a file; bank PDF exports are a few hundred KB
+const receiptDocumentMaxSize = 10 << 20
+
+// receiptDocumentTypes are the file types accepted as payment receipts
+var receiptDocumentTypes = []string{"application/pdf", "image/jpeg", "image/png", "image/webp"}
+
+// HandleDocument handles files sent in the private chat; the only file the bot expects is a payment receipt
+func (h *Handler) HandleDocument(c tele.Context) error {
+	doc := c.Message().Document
+	if doc == nil || c.Chat().Type != tele.ChatPrivate {
+		return nil
+	}
+
+	if !slices.Contains(receiptDocumentTypes, doc.MIME) {
+		return c.Send(messages.MsgReceiptDocumentType)
+	}
+	if doc.FileSize > receiptDocumentMaxSize {
+		return c.Send(messages.MsgReceiptDocumentTooLarge)
+	}
+
+	return h.HandlePaymentReceiptSubmission(c, []string{doc.FileID}, true, int64(c.Message().ID))
+}
+
+// HandlePaymentReceiptSubmission handles payment receipt submission. fileIDs holds every photo of an album receipt;
+// isDocument marks a receipt sent as a file.
+func (h *Handler) HandlePaymentReceiptSubmission(c tele.Context, fileIDs []string, isDocument bool, msgID int64) error {
+	ctx := middleware.UpdateContext(c)
 	user := c.Sender()
 
 	// Check if user has registered
-	_, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, user.ID)
-	if err != nil {
+	regUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, user.ID)
+	if err != nil || !regUser.IsActive {
 		return c.Send("❌ Iltimos, avval ro'yxatdan o'ting: /start")
 	}
 
+	receipt := pendingReceipt{fileIDs: fileIDs, isDocument: isDocument, msgID: msgID}
+
+	// A number in the caption is the amount; otherwise ask for it when configured and time allows
+	amount, verr := validation.ParseMoney(c.Message().Caption)
+	if verr != nil && h.cfg.Payment.AskAmount {
+		if deadline := h.receiptDeadline(ctx, user.ID); deadline.Sub(h.clock.Now()) > paymentAmountMinTimeLeft {
+			return h.askPaymentAmount(c, user.ID, receipt, deadline)
+		}
+	}
+
+	return h.submitPaymentReceipt(c, receipt, amount)
+}
+
+// submitPaymentReceipt submits the receipt with the amount the user gave (0 = none) and forwards it to the admins
+func (h *Handler) submitPaymentReceipt(c tele.Context, receipt pendingReceipt, amount int) error {
+	ctx := middleware.UpdateContext(c)
+	user := c.Sender()
+
 	// Submit payment through service
-	booking, err := h.services.Payment().SubmitPayment(ctx, user.ID, photoFileID, int64(c.Message().ID))
+	booking, err := h.services.Payment().SubmitPayment(ctx, user.ID, receipt.fileIDs, receipt.isDocument, receipt.msgID, amount)
 	if err != nil {
 		h.log.Error("Failed to submit payment", logger.Error(err))
 
@@ -293,10 +387,20 @@ Iltimos, avval ish uchun joy band qiling, keyin to'lov chekini yuboring.`)
 
 Afsuski, sizning booking vaqti tugagan. Iltimos, qaytadan joy band qiling.`)
 		}
+		if errors.Is(err, service.ErrInvalidBookingTransition) {
+			return c.Send("⚠️ Bu booking holati o'zgargan. Iltimos, /myjobs orqali tekshiring.")
+		}
 
 		return c.Send("❌ Xatolik yuz berdi. Iltimos, qaytadan urinib ko'ring.")
 	}
 
+	// The status tracker replaces the confirmation, reposted so it sits below the receipt
+	if h.services.BookingStatus().Enabled() {
+		h.services.BookingStatus().Repost(ctx, booking.ID)
+		go h.ForwardPaymentToAdminGroup(context.WithoutCancel(ctx), booking)
+		return nil
+	}
+
 	// Send confirmation to user
 	msg := `✅ <b>TO'LOV CHEKI QABUL QILINDI!</b>
 
@@ -313,7 +417,7 @@ Sabr qilganingiz uchun rahmat! 🙏`
 	}
 
 	// Forward to admin group
-	go h.ForwardPaymentToAdminGroup(ctx, booking, photoFileID)
+	go h.ForwardPaymentToAdminGroup(context.WithoutCancel(ctx), booking)
 
 	return nil
 }
@@ -325,7 +429,7 @@ func (h *Handler) HandleLocation(c tele.Context) error {
 		return nil
 	}
 
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	user, err := h.storage.User().GetByID(ctx, c.Sender().ID)
 	if err != nil {
 		return c.Send("❌ Xatolik yuz berdi.")
@@ -352,14 +456,21 @@ func (h *Handler) HandleLocation(c tele.Context) error {
 	return nil
 }
 
-// HandleUserProfile displays the user's profile
+// HandleUserProfile displays the user's profile ("👤 Profil", /profile, user_profile callback)
 func (h *Handler) HandleUserProfile(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
+	// From the inline main menu the tap still needs an answer
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+	}
+
 	// Get registered user details
 	regUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, userID)
-	if err != nil {
+	if err != nil || !regUser.IsActive {
 		return c.Send("❌ Siz hali ro'yxatdan o'tmagansiz. /start buyrug'ini bosing.")
 	}
 
@@ -369,12 +480,14 @@ func (h *Handler) HandleUserProfile(c tele.Context) error {
 📞 <b>Telefon:</b> %s
 🎂 <b>Yosh:</b> %d
 ⚖️ <b>Vazn:</b> %d kg
-📏 <b>Bo'y:</b> %d sm`,
+📏 <b>Bo'y:</b> %d sm
+🚻 <b>Jins:</b> %s`,
 		regUser.FullName,
 		regUser.Phone,
 		regUser.Age,
 		regUser.Weight,
 		regUser.Height,
+		regUser.Gender.Display(),
 	)
 
 	// First send profile, then in separate message show the edit prompt with keyboard
@@ -387,7 +500,7 @@ func (h *Handler) HandleUserProfile(c tele.Context) error {
 
 // HandleBackToMainMenu handles returning to main menu from profile edit
 func (h *Handler) HandleBackToMainMenu(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Reset user state to idle
@@ -407,15 +520,22 @@ Asosiy menyudasiz. Quyidagi tugmalardan foydalaning:`, regUser.FullName)
 	return c.Send(msg, keyboards.UserMainMenuReplyKeyboard())
 }
 
-// HandleUserMyJobs displays the user's bookings
+// HandleUserMyJobs displays the user's bookings ("📋 Mening ishlarim", /myjobs, user_my_jobs callback)
 func (h *Handler) HandleUserMyJobs(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+	}
+
 	// Get user's bookings
 	// We want active bookings: Reserved, PaymentSubmitted, Confirmed
 	statuses := []models.BookingStatus{
 		models.BookingStatusSlotReserved,
+		models.BookingStatusPaymentRejectedRetryable,
 		models.BookingStatusPaymentSubmitted,
 		models.BookingStatusConfirmed,
 	}
@@ -448,6 +568,9 @@ func (h *Handler) HandleUserMyJobs(c tele.Context) error {
 		case models.BookingStatusSlotReserved:
 			statusIcon = "⏳"
 			statusText = "To'lov kutilmoqda"
+		case models.BookingStatusPaymentRejectedRetryable:
+			statusIcon = "🔁"
+			statusText = "Chekni qayta yuboring"
 		case models.BookingStatusPaymentSubmitted:
 			statusIcon = "📩"
 			statusText = "Tekshirilmoqda"
@@ -456,7 +579,7 @@ func (h *Handler) HandleUserMyJobs(c tele.Context) error {
 			statusText = "Tasdiqlangan"
 		}
 
-		fmt.Fprintf(&sb, "<b>━━━━━ ISH №%d ━━━━━</b>\n", job.OrderNumber)
+		fmt.Fprintf(&sb, "<b>━━━━━ ISH №%s ━━━━━</b>\n", messages.JobNumber(job))
 		fmt.Fprintf(&sb, "📊 Holat: %s %s\n\n", statusIcon, statusText)
 		fmt.Fprintf(&sb, "📅 Ish kuni: %s\n", job.WorkDate)
 		fmt.Fprintf(&sb, "💰 Ish haqqi: %s\n", job.Salary)
@@ -473,7 +596,7 @@ func (h *Handler) HandleUserMyJobs(c tele.Context) error {
 			fmt.Fprintf(&sb, "🚌 Avtobuslar: %s\n", job.Buses)
 		}
 
-		fmt.Fprintf(&sb, "💳 Xizmat haqi: %s so'm\n", helper.FormatMoney(job.ServiceFee))
+		fmt.Fprintf(&sb, "💳 Xizmat haqi: %s\n", helper.Money(job.ServiceFee))
 
 		if job.AdditionalInfo != "" {
 			fmt.Fprintf(&sb, "📝 Qo'shimcha: %s\n", job.AdditionalInfo)
@@ -487,7 +610,7 @@ func (h *Handler) HandleUserMyJobs(c tele.Context) error {
 
 // HandleEditProfileField starts editing a profile field
 func (h *Handler) HandleEditProfileField(c tele.Context, field string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Check if user is registered
@@ -517,6 +640,10 @@ func (h *Handler) HandleEditProfileField(c tele.Context, field string) error {
 		state = models.StateEditingProfileBodyParams
 		prompt = messages.MsgEnterBodyParams
 		currentValue = fmt.Sprintf("%d kg, %d sm", regUser.Weight, regUser.Height)
+	case "gender":
+		state = models.StateEditingProfileGender
+		prompt = messages.MsgEnterGender
+		currentValue = regUser.Gender.Display()
 	default:
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri maydon"})
 	}
@@ -532,13 +659,16 @@ func (h *Handler) HandleEditProfileField(c tele.Context, field string) error {
 		// Use special keyboard for phone
 		return c.Send(prompt+"\n\nJoriy qiymat: "+currentValue, keyboards.RequestPhoneKeyboard())
 	}
+	if field == "gender" {
+		return c.Send(prompt+"\n\nJoriy qiymat: "+currentValue, keyboards.GenderKeyboard())
+	}
 
 	return c.Send(prompt+"\n\nJoriy qiymat: "+currentValue, keyboards.ReplyCancelKeyboard())
 }
 
 // HandleProfileEditInput handles text input during profile editing
 func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	text := strings.TrimSpace(c.Text())
 
 	// Get registered user
@@ -548,6 +678,7 @@ func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) erro
 		return c.Send(messages.MsgError)
 	}
 
+	before := *regUser
 	switch user.State {
 	case models.StateEditingProfileFullName:
 		if err := validation.ValidateFullName(text); err != nil {
@@ -565,7 +696,7 @@ func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) erro
 		if err := validation.ValidatePhone(phone); err != nil {
 			return c.Send(err.Error())
 		}
-		regUser.Phone = phone
+		regUser.Phone = validation.NormalizePhone(phone)
 
 	case models.StateEditingProfileAge:
 		age, err := validation.ValidateAge(text)
@@ -581,13 +712,24 @@ func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) erro
 		}
 		regUser.Weight = weight
 		regUser.Height = height
+
+	case models.StateEditingProfileGender:
+		gender, err := validation.ParseGender(text)
+		if err != nil {
+			return c.Send(err.Error(), keyboards.GenderKeyboard())
+		}
+		regUser.Gender = gender
 	}
 
 	// Update registered user in database
 	if err := h.storage.Registration().UpdateRegisteredUser(ctx, regUser); err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return c.Send(messages.MsgPhoneTaken)
+		}
 		h.log.Error("Failed to update registered user", logger.Error(err))
 		return c.Send(messages.MsgError)
 	}
+	h.auditProfileEdit(ctx, &before, regUser)
 
 	// Reset user state
 	if err := h.storage.User().UpdateState(ctx, user.ID, models.StateIdle); err != nil {
@@ -604,12 +746,14 @@ func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) erro
 🎂 <b>Yosh:</b> %d
 ⚖️ <b>Vazn:</b> %d kg
 📏 <b>Bo'y:</b> %d sm
+🚻 <b>Jins:</b> %s
 `,
 		regUser.FullName,
 		regUser.Phone,
 		regUser.Age,
 		regUser.Weight,
 		regUser.Height,
+		regUser.Gender.Display(),
 	)
 
 	if err := c.Send(msg, tele.ModeHTML); err != nil {
@@ -619,9 +763,50 @@ func (h *Handler) HandleProfileEditInput(c tele.Context, user *models.User) erro
 	return c.Send(messages.MsgSelectEditField, keyboards.ProfileEditKeyboard())
 }
 
+// auditProfileEdit records name/phone edits and warns admins if the worker has unfinished confirmed jobs
+func (h *Handler) auditProfileEdit(ctx context.Context, before, after *models.RegisteredUser) {
+	var changes []*models.ProfileChange
+	if before.FullName != after.FullName {
+		changes = append(changes, &models.ProfileChange{Field: models.ProfileFieldFullName, OldValue: before.FullName, NewValue: after.FullName})
+	}
+	if before.Phone != after.Phone {
+		changes = append(changes, &models.ProfileChange{Field: models.ProfileFieldPhone, OldValue: before.Phone, NewValue: after.Phone})
+	}
+
+	var jobs []*models.Job
+	for _, ch := range changes {
+		upcoming, err := h.services.Registration().RecordProfileChange(ctx, after.UserID, ch.Field, ch.OldValue, ch.NewValue)
+		if err != nil {
+			h.log.Error("Failed to record profile change", logger.Error(err), logger.Any("user_id", after.UserID))
+			continue
+		}
+		jobs = upcoming
+	}
+
+	if len(jobs) > 0 {
+		go h.notifyAdminsProfileChange(context.WithoutCancel(ctx), after, changes, jobs)
+	}
+}
+
+// notifyAdminsProfileChange sends the profile change notice to admins who keep it enabled
+func (h *Handler) notifyAdminsProfileChange(ctx context.Context, user *models.RegisteredUser, changes []*models.ProfileChange, jobs []*models.Job) {
+	msg := messages.FormatProfileChangeAlert(user, changes, jobs)
+	for _, adminID := range h.services.Settings().AdminIDs(ctx) {
+		if !h.adminWantsNotification(ctx, adminID, models.NotifyProfileEdit) {
+			continue
+		}
+		if err := h.services.Sender().Send(ctx, adminID, msg, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send profile change notice",
+				logger.Error(err),
+				logger.Any("admin_id", adminID),
+				logger.Any("user_id", user.UserID))
+		}
+	}
+}
+
 // HandleCancelProfileEdit handles canceling profile edit
 func (h *Handler) HandleCancelProfileEdit(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Reset user state
@@ -644,12 +829,14 @@ func (h *Handler) HandleCancelProfileEdit(c tele.Context) error {
 🎂 <b>Yosh:</b> %d
 ⚖️ <b>Vazn:</b> %d kg
 📏 <b>Bo'y:</b> %d sm
+🚻 <b>Jins:</b> %s
 `,
 		regUser.FullName,
 		regUser.Phone,
 		regUser.Age,
 		regUser.Weight,
 		regUser.Height,
+		regUser.Gender.Display(),
 	)
 
 	if err := c.Send(msg, tele.ModeHTML); err != nil {
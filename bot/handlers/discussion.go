@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// discussionReplyCooldown is how long a user is not answered again under the same post
+const discussionReplyCooldown = time.Hour
+
+// discussionQuestionWords mark a comment as a question about the job (Uzbek and Russian)
+var discussionQuestionWords = []string{
+	"?", "qanday", "qayer", "qachon", "qancha", "nechi", "narx", "yozil", "ro'yxat", "joy bor",
+	"bormi", "mumkinmi", "как", "где", "когда", "сколько", "запис", "можно",
+}
+
+// replyThrottle remembers which user was answered under which post so a thread is not flooded
+type replyThrottle struct {
+	mu      sync.Mutex
+	replied map[string]time.Time
+}
+
+func newReplyThrottle() *replyThrottle {
+	return &replyThrottle{replied: make(map[string]time.Time)}
+}
+
+// allow reports whether the key may be answered now and records the answer
+func (t *replyThrottle) allow(key string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for k, at := range t.replied {
+		if now.Sub(at) >= discussionReplyCooldown {
+			delete(t.replied, k)
+		}
+	}
+	if _, ok := t.replied[key]; ok {
+		return false
+	}
+	t.replied[key] = now
+	return true
+}
+
+// isDiscussionChat reports whether the message was written in the channel's discussion group
+func (h *Handler) isDiscussionChat(c tele.Context) bool {
+	chat := c.Chat()
+	if chat == nil || chat.Type == tele.ChatPrivate {
+		return false
+	}
+	if h.cfg.Bot.DiscussionGroupID != 0 {
+		return chat.ID == h.cfg.Bot.DiscussionGroupID
+	}
+	return h.channelPostID(c.Message()) != 0
+}
+
+// channelPostID returns the channel message a comment replies to, or 0 when it is not under a channel post
+func (h *Handler) channelPostID(msg *tele.Message) int {
+	if msg == nil || msg.ReplyTo == nil {
+		return 0
+	}
+	post := msg.ReplyTo
+	if !post.AutomaticForward || post.Origin == nil || post.Origin.Chat == nil {
+		return 0
+	}
+	if h.cfg.Bot.ChannelID != 0 && post.Origin.Chat.ID != h.cfg.Bot.ChannelID {
+		return 0
+	}
+	return post.Origin.MessageID
+}
+
+// isJobQuestion reports whether a comment looks like a question about the job
+func isJobQuestion(text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range discussionQuestionWords {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleDiscussionText answers job questions left under channel posts with the signup link and FAQ.
+// Other discussion messages are ignored so they never reach the private-chat flows.
+func (h *Handler) HandleDiscussionText(c tele.Context) error {
+	if !h.cfg.Bot.DiscussionAutoReply || c.Sender() == nil || c.Sender().IsBot {
+		return nil
+	}
+
+	postID := h.channelPostID(c.Message())
+	if postID == 0 || !isJobQuestion(c.Text()) {
+		return nil
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByChannelMessageID(ctx, int64(postID))
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			h.log.Error("Failed to get job for discussion comment", logger.Error(err))
+		}
+		return nil
+	}
+
+	if !h.discussion.allow(fmt.Sprintf("%d:%d", c.Sender().ID, job.ID), time.Now()) {
+		return nil
+	}
+
+	if job.Status != models.JobStatusActive {
+		return c.Reply(fmt.Sprintf(messages.MsgDiscussionJobClosed, messages.JobNumber(job)))
+	}
+
+	return c.Reply(
+		fmt.Sprintf(messages.MsgDiscussionAutoReply, messages.JobNumber(job)),
+		keyboards.JobSignupKeyboard(job.ID, h.cfg.Bot.Username),
+		tele.ModeHTML,
+	)
+}
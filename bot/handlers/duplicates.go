@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// notifyAdminsDuplicatePhone sends the duplicate account card to the ops group, or to each admin without one
+func (h *Handler) notifyAdminsDuplicatePhone(ctx context.Context, draft *models.RegistrationDraft, existing *models.RegisteredUser) {
+	block, err := h.storage.User().GetBlockStatus(ctx, existing.UserID)
+	if err != nil {
+		h.log.Error("Failed to get block status", logger.Error(err), logger.Any("user_id", existing.UserID))
+	}
+
+	msg := messages.FormatDuplicatePhoneAlert(existing, draft, block)
+	menu := keyboards.DuplicateAccountKeyboard(existing.UserID, draft.UserID)
+
+	if groupID := h.cfg.Bot.OpsChatID(); groupID != 0 {
+		if err := h.services.Sender().Send(ctx, groupID, msg, menu, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send duplicate phone alert to group", logger.Error(err))
+		}
+		return
+	}
+
+	for _, adminID := range h.services.Settings().AdminIDs(ctx) {
+		if err := h.services.Sender().Send(ctx, adminID, msg, menu, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send duplicate phone alert to admin",
+				logger.Error(err),
+				logger.Any("admin_id", adminID))
+		}
+	}
+}
+
+// sharedPhoneUserIDs returns the other accounts registered with the phone, so admins can spot
+// one worker booking through several Telegram accounts
+func (h *Handler) sharedPhoneUserIDs(ctx context.Context, userID int64, phone string) []int64 {
+	ids, err := h.storage.Registration().GetUserIDsByPhone(ctx, phone)
+	if err != nil {
+		h.log.Error("Failed to get accounts sharing the phone", logger.Error(err), logger.Any("user_id", userID))
+		return nil
+	}
+
+	var others []int64
+	for _, id := range ids {
+		if id != userID {
+			others = append(others, id)
+		}
+	}
+	return others
+}
+
+// HandleMergeDuplicate moves the phone to the new account: dup_merge_<old_user_id>_<new_user_id>
+func (h *Handler) HandleMergeDuplicate(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	parts := strings.Split(params, "_")
+	if len(parts) != 2 {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ma'lumot"})
+	}
+	oldUserID, err1 := strconv.ParseInt(parts[0], 10, 64)
+	newUserID, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri user ID."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.services.Registration().MergeDuplicateAccount(ctx, oldUserID, newUserID, c.Sender().ID); err != nil {
+		h.log.Error("Failed to merge duplicate account",
+			logger.Error(err),
+			logger.Any("old_user_id", oldUserID),
+			logger.Any("new_user_id", newUserID))
+
+		text := "❌ Xatolik yuz berdi"
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			text = "⚠️ Akkaunt yoki ro'yxatdan o'tish qoralamasi topilmadi. Ehtimol allaqachon ko'rib chiqilgan."
+		case errors.Is(err, storage.ErrAlreadyExists):
+			text = "⚠️ Raqam hozir boshqa faol akkauntga tegishli."
+		}
+		return c.Respond(&tele.CallbackResponse{Text: text, ShowAlert: true})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, newUserID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err), logger.Any("user_id", newUserID))
+	}
+
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		if err := h.services.Sender().Send(bg, newUserID, messages.MsgDuplicateMerged, keyboards.UserMainMenuReplyKeyboard()); err != nil {
+			h.log.Error("Failed to notify merged user", logger.Error(err), logger.Any("user_id", newUserID))
+		}
+		if err := h.services.Sender().Send(bg, oldUserID, messages.MsgAccountDeactivatedDuplicate); err != nil {
+			h.log.Error("Failed to notify deactivated user", logger.Error(err), logger.Any("user_id", oldUserID))
+		}
+	}()
+
+	h.markDuplicateResolved(c, "🔁 <b>YANGI AKKAUNTGA O'TKAZILDI</b>")
+	return c.Respond(&tele.CallbackResponse{Text: "✅ O'tkazildi"})
+}
+
+// HandleRejectDuplicate keeps the phone with the old account and asks the new one for another number
+func (h *Handler) HandleRejectDuplicate(c tele.Context, newUserIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	newUserID, err := strconv.ParseInt(newUserIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri user ID."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	result, err := h.services.Registration().GoToEditState(ctx, newUserID, models.EditFieldPhone)
+	if err != nil {
+		h.log.Error("Failed to send user back to phone step", logger.Error(err), logger.Any("user_id", newUserID))
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Respond(&tele.CallbackResponse{Text: "⚠️ Ro'yxatdan o'tish qoralamasi topilmadi.", ShowAlert: true})
+		}
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, newUserID, models.UserState(result.NextState)); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err), logger.Any("user_id", newUserID))
+	}
+
+	go func(ctx context.Context) {
+		msg := messages.MsgDuplicateRejected + "\n\n" + messages.MsgEnterPhone
+		if err := h.services.Sender().Send(ctx, newUserID, msg, keyboards.PhoneRequestKeyboard()); err != nil {
+			h.log.Error("Failed to notify rejected user", logger.Error(err), logger.Any("user_id", newUserID))
+		}
+	}(context.WithoutCancel(ctx))
+
+	h.markDuplicateResolved(c, "❌ <b>RAD ETILDI</b>")
+	return c.Respond(&tele.CallbackResponse{Text: "❌ Rad etildi"})
+}
+
+// markDuplicateResolved stamps the alert with the decision and removes its buttons
+func (h *Handler) markDuplicateResolved(c tele.Context, status string) {
+	adminName := c.Sender().Username
+	if adminName == "" {
+		adminName = c.Sender().FirstName
+	}
+
+	text := c.Message().Text + fmt.Sprintf("\n\n%s\n👤 Admin: @%s\n⏰ Vaqt: %s",
+		status,
+		adminName,
+		config.NowLocal().Format("02.01.2006 15:04"),
+	)
+	if err := c.Edit(text, &tele.ReplyMarkup{}, tele.ModeHTML); err != nil {
+		h.log.Error("Failed to edit duplicate alert", logger.Error(err))
+	}
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// employerHistoryLimit is how many recent jobs the employer view lists
+const employerHistoryLimit = 5
+
+// employerByPhone finds an employer by the normalized phone, falling back to the phone as typed
+// for employers saved before phones were normalized
+func (h *Handler) employerByPhone(ctx context.Context, phone, typed string) (*models.Employer, error) {
+	employer, err := h.storage.Employer().GetByPhone(ctx, phone)
+	if errors.Is(err, storage.ErrNotFound) && typed != phone {
+		return h.storage.Employer().GetByPhone(ctx, strings.TrimSpace(typed))
+	}
+	return employer, err
+}
+
+// HandlePickJobEmployer links an existing employer to the job being created and shows the review
+func (h *Handler) HandlePickJobEmployer(c tele.Context, employerIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	employerID, err := strconv.ParseInt(employerIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	user, err := h.storage.User().GetByID(ctx, c.Sender().ID)
+	job := h.getTempJob(c.Sender().ID)
+	if err != nil || job == nil || user.State != models.StateCreatingJobEmployerPhone {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish yaratish jarayoni topilmadi."})
+	}
+
+	employer, err := h.storage.Employer().GetByID(ctx, employerID)
+	if err != nil {
+		h.log.Error("Failed to get employer", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish beruvchi topilmadi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	job.EmployerID = employer.ID
+	job.EmployerPhone = employer.Phone
+	return h.advanceJobCreation(c, job, user.State)
+}
+
+// HandleJobEmployer shows the employer of a job with stats and recent job history
+func (h *Handler) HandleJobEmployer(c tele.Context, jobIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return h.showJobEmployer(c, jobID, true)
+}
+
+// HandleRateEmployer sets the employer rating from the employer view (params: <jobID>_<rating>)
+func (h *Handler) HandleRateEmployer(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	parts := strings.Split(params, "_")
+	if len(parts) != 2 {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+	jobID, err1 := strconv.ParseInt(parts[0], 10, 64)
+	rating, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || rating < 1 || rating > 5 {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	employer, err := h.getJobEmployer(ctx, jobID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish beruvchi topilmadi."})
+	}
+
+	employer.Rating = rating
+	if err := h.storage.Employer().Update(ctx, employer); err != nil {
+		h.log.Error("Failed to update employer rating", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Saqlandi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return h.showJobEmployer(c, jobID, true)
+}
+
+// HandleEditEmployerNotes asks the admin for new notes about the job's employer
+func (h *Handler) HandleEditEmployerNotes(c tele.Context, jobIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	employer, err := h.getJobEmployer(ctx, jobID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish beruvchi topilmadi."})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateEditingJobEmployerNotes); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.setEditingJobID(c.Sender().ID, jobID)
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Send(messages.MsgEnterEmployerNotes+"\n\nJoriy qiymat: "+employer.Notes, keyboards.CancelEditKeyboard(jobID))
+}
+
+// handleEmployerNotesInput saves the notes typed by the admin and shows the employer again
+func (h *Handler) handleEmployerNotesInput(c tele.Context, job *models.Job, text string) error {
+	ctx := middleware.UpdateContext(c)
+
+	employer, err := h.getJobEmployer(ctx, job.ID)
+	if err != nil {
+		return c.Send(messages.MsgError)
+	}
+
+	employer.Notes = text
+	if err := h.storage.Employer().Update(ctx, employer); err != nil {
+		h.log.Error("Failed to update employer notes", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	h.clearEditingJobID(c.Sender().ID)
+
+	return h.showJobEmployer(c, job.ID, false)
+}
+
+// getJobEmployer loads the employer linked to a job
+func (h *Handler) getJobEmployer(ctx context.Context, jobID int64) (*models.Employer, error) {
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return nil, err
+	}
+	if job.EmployerID == 0 {
+		return nil, fmt.Errorf("job %d has no employer", jobID)
+	}
+
+	employer, err := h.storage.Employer().GetByID(ctx, job.EmployerID)
+	if err != nil {
+		h.log.Error("Failed to get employer", logger.Error(err))
+		return nil, err
+	}
+	return employer, nil
+}
+
+// showJobEmployer renders the employer view, editing the callback message or sending a new one
+func (h *Handler) showJobEmployer(c tele.Context, jobID int64, edit bool) error {
+	ctx := middleware.UpdateContext(c)
+
+	employer, err := h.getJobEmployer(ctx, jobID)
+	if err != nil {
+		return c.Send("❌ Bu ishga ish beruvchi bog'lanmagan.")
+	}
+
+	stats, err := h.storage.Employer().GetStats(ctx, employer.ID)
+	if err != nil {
+		h.log.Error("Failed to get employer stats", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	feedback, err := h.storage.JobFeedback().GetEmployerSummary(ctx, employer.ID)
+	if err != nil {
+		h.log.Error("Failed to get employer feedback summary", logger.Error(err))
+		feedback = &models.FeedbackSummary{}
+	}
+
+	jobs, err := h.storage.Job().GetByEmployerID(ctx, employer.ID, employerHistoryLimit)
+	if err != nil {
+		h.log.Error("Failed to get employer jobs", logger.Error(err))
+	}
+
+	msg := messages.FormatEmployerDetail(employer, stats, feedback, jobs)
+	menu := keyboards.EmployerDetailKeyboard(employer, jobID)
+	if edit {
+		return c.Edit(msg, menu, tele.ModeHTML)
+	}
+	return c.Send(msg, menu, tele.ModeHTML)
+}
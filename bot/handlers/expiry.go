@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// expiryStallTicks is how many missed ticks make /expiry warn that the worker looks stuck
+const expiryStallTicks = 3
+
+// HandleExpiryCommand shows the expiry worker's last pass, totals and how many
+// expired reservations are still waiting to be released (/expiry)
+func (h *Handler) HandleExpiryCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+	if h.expiry == nil {
+		return c.Send(messages.MsgError)
+	}
+
+	ctx := middleware.UpdateContext(c)
+	status := h.expiry.Status()
+	now := time.Now()
+
+	backlog := "—"
+	if count, err := h.storage.Booking().CountExpired(ctx, now); err != nil {
+		h.log.Error("Failed to count expired bookings", logger.Error(err))
+	} else {
+		backlog = fmt.Sprintf("%d ta", count)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("⏰ <b>BRON MUDDATI NAZORATCHISI</b>\n\n")
+	fmt.Fprintf(&sb, "🔁 Har %s, bir tranzaksiyada %d ta\n\n", status.Interval, status.BatchSize)
+
+	if status.LastRunAt.IsZero() {
+		sb.WriteString("🕐 Oxirgi tekshiruv: hali bo'lmagan\n")
+	} else {
+		fmt.Fprintf(&sb, "🕐 Oxirgi tekshiruv: %s (%s oldin)\n",
+			status.LastRunAt.In(config.Timezone).Format("02.01.2006 15:04:05"),
+			now.Sub(status.LastRunAt).Round(time.Second))
+		fmt.Fprintf(&sb, "   Topildi: %d, bekor qilindi: %d, xatolar: %d\n",
+			status.LastCandidates, status.LastExpired, status.LastErrors)
+	}
+	fmt.Fprintf(&sb, "📦 Navbatda: %s\n\n", backlog)
+	fmt.Fprintf(&sb, "📊 Ishga tushgandan beri: %d tekshiruv, %d ta bekor qilingan, %d ta xato\n",
+		status.Passes, status.Processed, status.Errors)
+
+	switch {
+	case now.Before(status.PausedUntil):
+		fmt.Fprintf(&sb, "\n⚠️ Xatolar sababli %s gacha to'xtatilgan",
+			status.PausedUntil.In(config.Timezone).Format("15:04:05"))
+	case !status.LastRunAt.IsZero() && now.Sub(status.LastRunAt) > expiryStallTicks*status.Interval:
+		sb.WriteString("\n⚠️ Nazoratchi uzoq vaqtdan beri ishlamayapti — loglarni tekshiring")
+	}
+
+	return c.Send(sb.String(), tele.ModeHTML)
+}
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Length limits for admin FAQ input; answers leave room for the question header within Telegram's 4096
+const (
+	faqCategoryMaxLen = 100
+	faqQuestionMaxLen = 300
+	faqAnswerMaxLen   = 3500
+)
+
+// ========== User FAQ browser ==========
+
+// showFAQ sends (or edits into) the FAQ start page: the category list, or the questions
+// directly when there is only one category
+func (h *Handler) showFAQ(c tele.Context, edit bool) error {
+	ctx := middleware.UpdateContext(c)
+	entries, err := h.storage.FAQ().GetAll(ctx)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entries", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	send := c.Send
+	if edit {
+		send = c.Edit
+	}
+
+	categories := models.GroupFAQByCategory(entries)
+	switch len(categories) {
+	case 0:
+		return send(messages.MsgFAQEmpty)
+	case 1:
+		return send(fmt.Sprintf(messages.MsgFAQCategory, categories[0].Name),
+			keyboards.FAQQuestionsKeyboard(categories[0], false), tele.ModeHTML)
+	default:
+		return send(messages.MsgFAQ, keyboards.FAQCategoriesKeyboard(categories), tele.ModeHTML)
+	}
+}
+
+// HandleFAQCategory lists the questions of the category that the given entry belongs to (faq_cat_<entryID>)
+func (h *Handler) HandleFAQCategory(c tele.Context, entryIDStr string) error {
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	entries, err := h.storage.FAQ().GetAll(ctx)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entries", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	categories := models.GroupFAQByCategory(entries)
+	for _, category := range categories {
+		for _, entry := range category.Entries {
+			if entry.ID == entryID {
+				return c.Edit(fmt.Sprintf(messages.MsgFAQCategory, category.Name),
+					keyboards.FAQQuestionsKeyboard(category, len(categories) > 1), tele.ModeHTML)
+			}
+		}
+	}
+
+	// The entry was deleted meanwhile: start over
+	return h.showFAQ(c, true)
+}
+
+// HandleFAQQuestion shows the answer to one question (faq_q_<entryID>)
+func (h *Handler) HandleFAQQuestion(c tele.Context, entryIDStr string) error {
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	entry, err := h.storage.FAQ().GetByID(ctx, entryID)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entry", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Savol topilmadi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(fmt.Sprintf(messages.MsgFAQAnswer, entry.Question, entry.Answer),
+		keyboards.FAQAnswerKeyboard(entry.ID), tele.ModeHTML)
+}
+
+// ========== Admin FAQ management ==========
+
+// HandleAdminFAQ lists all FAQ entries for editing; it also cancels any FAQ input in progress
+func (h *Handler) HandleAdminFAQ(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		if c.Callback() != nil {
+			return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+		}
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	h.resetFAQInput(c)
+
+	entries, err := h.storage.FAQ().GetAll(ctx)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entries", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		return c.Edit(messages.MsgAdminFAQ, keyboards.AdminFAQKeyboard(entries), tele.ModeHTML)
+	}
+	return c.Send(messages.MsgAdminFAQ, keyboards.AdminFAQKeyboard(entries), tele.ModeHTML)
+}
+
+// HandleAdminFAQEntry shows one FAQ entry with its edit actions (faq_admin_entry_<entryID>)
+func (h *Handler) HandleAdminFAQEntry(c tele.Context, entryIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	entry, err := h.storage.FAQ().GetByID(ctx, entryID)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entry", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Savol topilmadi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(formatAdminFAQEntry(entry), keyboards.AdminFAQEntryKeyboard(entry.ID), tele.ModeHTML)
+}
+
+// HandleAdminFAQAdd starts adding a new FAQ entry: category, question, then answer
+func (h *Handler) HandleAdminFAQAdd(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateFAQAddCategory); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.setTempFAQ(c.Sender().ID, &models.FAQEntry{})
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	prompt := messages.MsgEnterFAQCategory
+	if entries, err := h.storage.FAQ().GetAll(ctx); err == nil && len(entries) > 0 {
+		var names []string
+		for _, category := range models.GroupFAQByCategory(entries) {
+			names = append(names, category.Name)
+		}
+		prompt += "\n\nMavjud bo'limlar: " + strings.Join(names, ", ")
+	}
+
+	return c.Send(prompt, keyboards.AdminFAQCancelKeyboard())
+}
+
+// HandleAdminFAQEdit asks for a new value of one field (faq_admin_edit_<field>_<entryID>)
+func (h *Handler) HandleAdminFAQEdit(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	field, entryIDStr, _ := strings.Cut(params, "_")
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	entry, err := h.storage.FAQ().GetByID(ctx, entryID)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entry", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Savol topilmadi."})
+	}
+
+	var state models.UserState
+	var prompt, current string
+	switch field {
+	case "category":
+		state, prompt, current = models.StateFAQEditCategory, messages.MsgEnterFAQCategory, entry.Category
+	case "question":
+		state, prompt, current = models.StateFAQEditQuestion, messages.MsgEnterFAQQuestion, entry.Question
+	case "answer":
+		state, prompt, current = models.StateFAQEditAnswer, messages.MsgEnterFAQAnswer, entry.Answer
+	default:
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri maydon"})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, state); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.setEditingFAQID(c.Sender().ID, entry.ID)
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Send(prompt+"\n\nJoriy qiymat: "+current, keyboards.AdminFAQCancelKeyboard())
+}
+
+// HandleAdminFAQDelete asks to confirm deleting an FAQ entry (faq_admin_delete_<entryID>)
+func (h *Handler) HandleAdminFAQDelete(c tele.Context, entryIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	entry, err := h.storage.FAQ().GetByID(ctx, entryID)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entry", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Savol topilmadi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(fmt.Sprintf(messages.MsgFAQDeleteConfirm, entry.Question), keyboards.AdminFAQDeleteKeyboard(entry.ID))
+}
+
+// HandleAdminFAQDeleteConfirm deletes an FAQ entry and shows the list again (faq_admin_delete_confirm_<entryID>)
+func (h *Handler) HandleAdminFAQDeleteConfirm(c tele.Context, entryIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri savol ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.FAQ().Delete(ctx, entryID); err != nil {
+		h.log.Error("Failed to delete FAQ entry", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	entries, err := h.storage.FAQ().GetAll(ctx)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entries", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "✅ O'chirildi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ O'chirildi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(messages.MsgAdminFAQ, keyboards.AdminFAQKeyboard(entries), tele.ModeHTML)
+}
+
+// handleFAQInput processes text typed while adding or editing an FAQ entry
+func (h *Handler) handleFAQInput(c tele.Context, user *models.User, text string) error {
+	ctx := middleware.UpdateContext(c)
+	if text == "" {
+		return nil
+	}
+
+	maxLen := faqAnswerMaxLen
+	switch user.State {
+	case models.StateFAQAddCategory, models.StateFAQEditCategory:
+		maxLen = faqCategoryMaxLen
+	case models.StateFAQAddQuestion, models.StateFAQEditQuestion:
+		maxLen = faqQuestionMaxLen
+	}
+	if utf8.RuneCountInString(text) > maxLen {
+		return c.Send(messages.MsgFAQTooLong)
+	}
+
+	switch user.State {
+	case models.StateFAQAddCategory, models.StateFAQAddQuestion, models.StateFAQAddAnswer:
+		return h.handleFAQAddInput(c, user.State, text)
+	}
+
+	entryID := h.getEditingFAQID(c.Sender().ID)
+	if entryID == 0 {
+		return c.Send(messages.MsgError)
+	}
+
+	entry, err := h.storage.FAQ().GetByID(ctx, entryID)
+	if err != nil {
+		h.log.Error("Failed to get FAQ entry", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	switch user.State {
+	case models.StateFAQEditCategory:
+		entry.Category = text
+	case models.StateFAQEditQuestion:
+		entry.Question = text
+	case models.StateFAQEditAnswer:
+		entry.Answer = text
+	default:
+		return nil
+	}
+
+	if err := h.storage.FAQ().Update(ctx, entry); err != nil {
+		h.log.Error("Failed to update FAQ entry", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	h.resetFAQInput(c)
+	return c.Send("✅ Saqlandi.\n\n"+formatAdminFAQEntry(entry), keyboards.AdminFAQEntryKeyboard(entry.ID), tele.ModeHTML)
+}
+
+// handleFAQAddInput collects the fields of a new FAQ entry and saves it after the answer
+func (h *Handler) handleFAQAddInput(c tele.Context, state models.UserState, text string) error {
+	ctx := middleware.UpdateContext(c)
+	entry := h.getTempFAQ(c.Sender().ID)
+	if entry == nil {
+		return c.Send(messages.MsgError)
+	}
+
+	var nextState models.UserState
+	var nextPrompt string
+
+	switch state {
+	case models.StateFAQAddCategory:
+		entry.Category = text
+		nextState, nextPrompt = models.StateFAQAddQuestion, messages.MsgEnterFAQQuestion
+	case models.StateFAQAddQuestion:
+		entry.Question = text
+		nextState, nextPrompt = models.StateFAQAddAnswer, messages.MsgEnterFAQAnswer
+	case models.StateFAQAddAnswer:
+		entry.Answer = text
+		if err := h.storage.FAQ().Create(ctx, entry); err != nil {
+			h.log.Error("Failed to create FAQ entry", logger.Error(err))
+			return c.Send(messages.MsgError)
+		}
+		h.resetFAQInput(c)
+		return c.Send("✅ Savol qo'shildi.\n\n"+formatAdminFAQEntry(entry), keyboards.AdminFAQEntryKeyboard(entry.ID), tele.ModeHTML)
+	}
+
+	h.setTempFAQ(c.Sender().ID, entry)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, nextState); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	return c.Send(nextPrompt, keyboards.AdminFAQCancelKeyboard())
+}
+
+// resetFAQInput returns the admin to idle and drops any FAQ entry being added or edited
+func (h *Handler) resetFAQInput(c tele.Context) {
+	ctx := middleware.UpdateContext(c)
+	user, err := h.storage.User().GetByID(ctx, c.Sender().ID)
+	if err == nil && strings.HasPrefix(string(user.State), "faq_") {
+		if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+			h.log.Error("Failed to update user state", logger.Error(err))
+		}
+	}
+	h.clearTempFAQ(c.Sender().ID)
+	h.clearEditingFAQID(c.Sender().ID)
+}
+
+// formatAdminFAQEntry renders an FAQ entry for the admin edit view
+func formatAdminFAQEntry(entry *models.FAQEntry) string {
+	return fmt.Sprintf(messages.MsgAdminFAQEntry, entry.Category, entry.Question, entry.Answer)
+}
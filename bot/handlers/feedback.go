@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleFeedbackPay stores the "was pay correct?" answer and asks about conditions (params: <bookingID>_<yes|no>)
+func (h *Handler) HandleFeedbackPay(c tele.Context, params string) error {
+	feedback, answer, ok := h.loadFeedbackAnswer(c, params)
+	if !ok {
+		return nil
+	}
+
+	feedback.PayCorrect = &answer
+	if err := h.storage.JobFeedback().UpdateAnswers(middleware.UpdateContext(c), feedback); err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(messages.MsgFeedbackConditions, keyboards.FeedbackConditionsKeyboard(feedback.BookingID))
+}
+
+// HandleFeedbackConditions stores the "were conditions okay?" answer and thanks the worker
+func (h *Handler) HandleFeedbackConditions(c tele.Context, params string) error {
+	feedback, answer, ok := h.loadFeedbackAnswer(c, params)
+	if !ok {
+		return nil
+	}
+
+	feedback.ConditionsOK = &answer
+	if err := h.storage.JobFeedback().UpdateAnswers(middleware.UpdateContext(c), feedback); err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Edit(messages.MsgFeedbackThanks)
+}
+
+// loadFeedbackAnswer parses <bookingID>_<yes|no> and loads the sender's feedback row.
+// On failure it has already answered the callback and returns ok=false.
+func (h *Handler) loadFeedbackAnswer(c tele.Context, params string) (*models.JobFeedback, bool, bool) {
+	bookingIDStr, answerStr, found := strings.Cut(params, "_")
+	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
+	if !found || err != nil || (answerStr != "yes" && answerStr != "no") {
+		c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri parametrlar"})
+		return nil, false, false
+	}
+
+	feedback, err := h.storage.JobFeedback().GetByBookingID(middleware.UpdateContext(c), bookingID)
+	if err != nil || feedback.UserID != c.Sender().ID {
+		c.Respond(&tele.CallbackResponse{Text: "❌ So'rovnoma topilmadi."})
+		return nil, false, false
+	}
+
+	return feedback, answerStr == "yes", true
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/fsm"
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// How long a multi-step flow may sit untouched before the next input resets it
+const (
+	registrationFlowTimeout = 24 * time.Hour
+	jobFlowTimeout          = 2 * time.Hour
+	adminTextFlowTimeout    = time.Hour
+	profileEditFlowTimeout  = 30 * time.Minute
+	supportFlowTimeout      = time.Hour
+	paymentAmountTimeout    = 15 * time.Minute
+)
+
+// registerFlows declares the multi-step conversations routed by HandleText.
+// A new flow needs its states, an input handler and, if it keeps in-memory
+// session data, an expiry cleanup.
+func (h *Handler) registerFlows() {
+	h.flows.Register(
+		&fsm.Flow{
+			Name:    "registration",
+			States:  registrationFlowStates(),
+			Timeout: registrationFlowTimeout,
+			Input: func(c tele.Context, user *models.User) error {
+				return h.HandleRegistrationTextInput(c, h.GetRegistrationState(user.State))
+			},
+			Cancel:  func(c tele.Context, _ *models.User) error { return h.HandleCancelRegistration(c) },
+			Expired: h.flowExpired(h.cancelRegistrationDraft, keyboards.RemoveReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "job_creation",
+			Prefix:  "creating_job_",
+			Guard:   h.adminGuard,
+			Timeout: jobFlowTimeout,
+			Input:   h.HandleAdminTextInput,
+			Expired: h.flowExpired(sessionCleanup(h.clearTempJob), keyboards.AdminMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "job_editing",
+			Prefix:  "editing_job_",
+			Guard:   h.adminGuard,
+			Timeout: jobFlowTimeout,
+			Input:   h.HandleAdminTextInput,
+			Expired: h.flowExpired(sessionCleanup(h.clearEditingJobID), keyboards.AdminMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "faq",
+			Prefix:  "faq_",
+			Guard:   h.adminGuard,
+			Timeout: adminTextFlowTimeout,
+			Input:   h.HandleAdminTextInput,
+			Expired: h.flowExpired(sessionCleanup(h.clearTempFAQ, h.clearEditingFAQID), keyboards.AdminMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "offer",
+			States:  []models.UserState{models.StateEditingOffer},
+			Guard:   h.adminGuard,
+			Timeout: adminTextFlowTimeout,
+			Input:   h.HandleAdminTextInput,
+			Expired: h.flowExpired(sessionCleanup(h.clearTempOffer), keyboards.AdminMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "settings",
+			Prefix:  "settings_",
+			Guard:   h.superAdminGuard,
+			Timeout: adminTextFlowTimeout,
+			Input:   h.HandleAdminTextInput,
+			Expired: h.flowExpired(nil, keyboards.AdminMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "profile_edit",
+			Prefix:  "editing_profile_",
+			Timeout: profileEditFlowTimeout,
+			Input:   h.HandleProfileEditInput,
+			Cancel:  func(c tele.Context, _ *models.User) error { return h.HandleCancelProfileEdit(c) },
+			Expired: h.flowExpired(nil, keyboards.UserMainMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "support",
+			States:  []models.UserState{models.StateSupportMessage},
+			Timeout: supportFlowTimeout,
+			Input:   h.HandleSupportInput,
+			Cancel:  h.HandleSupportCancel,
+			Expired: h.flowExpired(nil, keyboards.UserMainMenuReplyKeyboard),
+		},
+		&fsm.Flow{
+			Name:    "payment_amount",
+			States:  []models.UserState{models.StatePaymentAmount},
+			Timeout: paymentAmountTimeout,
+			Input:   h.HandlePaymentAmountInput,
+			Cancel:  func(c tele.Context, user *models.User) error { return h.submitPendingReceipt(c, user.ID, 0) },
+			Expired: h.paymentAmountExpired,
+		},
+	)
+}
+
+// registrationFlowStates lists the user states of the registration flow
+func registrationFlowStates() []models.UserState {
+	regStates := []models.RegistrationState{
+		models.RegStatePublicOffer,
+		models.RegStateFullName,
+		models.RegStatePhone,
+		models.RegStateAge,
+		models.RegStateBodyParams,
+		models.RegStateGender,
+		models.RegStateCity,
+		models.RegStatePassportPhoto,
+		models.RegStateConfirm,
+	}
+	states := make([]models.UserState, len(regStates))
+	for i, s := range regStates {
+		states[i] = models.UserState(s)
+	}
+	return states
+}
+
+// adminGuard keeps admin-only flows from reacting to users who lost admin rights mid-flow
+func (h *Handler) adminGuard(c tele.Context, _ *models.User) bool {
+	return h.IsAdmin(c.Sender().ID)
+}
+
+// superAdminGuard keeps the settings flow to super admins
+func (h *Handler) superAdminGuard(c tele.Context, _ *models.User) bool {
+	return h.services.Settings().IsSuperAdmin(c.Sender().ID)
+}
+
+// flowExpired builds an expiry handler that drops the flow's leftovers and tells the user
+func (h *Handler) flowExpired(cleanup func(ctx context.Context, userID int64), keyboard func() *tele.ReplyMarkup) fsm.Handler {
+	return func(c tele.Context, user *models.User) error {
+		if cleanup != nil {
+			cleanup(middleware.UpdateContext(c), user.ID)
+		}
+		return c.Send(messages.MsgFlowExpired, keyboard())
+	}
+}
+
+// sessionCleanup adapts in-memory session clearers to a flow expiry cleanup
+func sessionCleanup(clear ...func(userID int64)) func(ctx context.Context, userID int64) {
+	return func(_ context.Context, userID int64) {
+		for _, fn := range clear {
+			fn(userID)
+		}
+	}
+}
+
+// cancelRegistrationDraft deletes the registration draft left behind by an expired registration
+func (h *Handler) cancelRegistrationDraft(ctx context.Context, userID int64) {
+	if err := h.services.Registration().CancelRegistration(ctx, userID); err != nil {
+		logger.FromContext(ctx, h.log).Error("Failed to cancel expired registration", logger.Error(err))
+	}
+}
@@ -1,32 +1,44 @@
 package handlers
 
 import (
+	"telegram-bot-starter/bot/fsm"
 	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/service"
 	"telegram-bot-starter/storage"
-
-	telebot "gopkg.in/telebot.v4"
 )
 
 // Handler contains all bot command and callback handlers
 type Handler struct {
 	log      logger.LoggerI
 	storage  storage.StorageI
-	bot      *telebot.Bot
+	bot      service.BotAPI
 	cfg      *config.Config
 	services service.ServiceManagerI
+	expiry   service.ExpiryMonitor
+	clock    service.Clock
+
+	discussion    *replyThrottle // Throttles auto-replies in the channel discussion group
+	flows         *fsm.Machine   // Routes text input of multi-step flows (see flows.go)
+	receiptAlbums *receiptAlbums // Collects receipts sent as albums (see receipt_album.go)
+	unknownText   *unknownText   // Counts unrecognized messages of idle users (see unknown_text.go)
 }
 type NewHandlerParams struct {
 	Logger   logger.LoggerI
 	Storage  storage.StorageI
-	Bot      *telebot.Bot
+	Bot      service.BotAPI
 	Cfg      *config.Config
 	Services service.ServiceManagerI
+	Expiry   service.ExpiryMonitor
+	Clock    service.Clock // nil: the system clock
 }
 
 // NewHandler creates a new instance of bot handlers
 func NewHandler(params NewHandlerParams) *Handler {
+	clock := params.Clock
+	if clock == nil {
+		clock = service.SystemClock{}
+	}
 
 	h := &Handler{
 		log:      params.Logger,
@@ -34,6 +46,14 @@ func NewHandler(params NewHandlerParams) *Handler {
 		bot:      params.Bot,
 		cfg:      params.Cfg,
 		services: params.Services,
+		expiry:   params.Expiry,
+		clock:    clock,
+
+		discussion:    newReplyThrottle(),
+		flows:         fsm.New(params.Storage.User(), params.Logger),
+		receiptAlbums: newReceiptAlbums(),
+		unknownText:   newUnknownText(),
 	}
+	h.registerFlows()
 	return h
 }
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// refundListLimit caps the refunds shown by /refunds; each gets its own button
+const refundListLimit = 20
+
+// HandleJobCancel asks the admin to confirm cancelling a job (job_cancel_<jobID>)
+func (h *Handler) HandleJobCancel(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi."})
+	}
+	if job.Status == models.JobStatusCancelled || job.Status == models.JobStatusCompleted {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgJobNotCancellable, ShowAlert: true})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(fmt.Sprintf(messages.MsgJobCancelConfirm, messages.JobNumber(job)),
+		keyboards.JobCancelConfirmKeyboard(job.ID), tele.ModeHTML)
+}
+
+// HandleJobCancelConfirm cancels the job, tells its workers and refreshes the channel post
+// and the admins' job cards (job_cancel_confirm_<jobID>)
+func (h *Handler) HandleJobCancelConfirm(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+
+	result, err := h.services.Booking().CancelJob(ctx, jobID, adminID)
+	if errors.Is(err, service.ErrJobNotCancellable) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgJobNotCancellable, ShowAlert: true})
+	}
+	if err != nil {
+		h.log.Error("Failed to cancel job", logger.Error(err), logger.Any("job_id", jobID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	job := result.Job
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "🚫 Ish bekor qilindi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	if job.ChannelMessageID != 0 {
+		h.updateChannelMessage(job)
+		h.removeChannelLocation(ctx, job)
+	}
+	go h.updateAllAdminMessages(context.WithoutCancel(ctx), job, adminID)
+	go h.notifyJobCancelled(context.WithoutCancel(ctx), result, adminID)
+
+	return c.Edit(messages.FormatJobDetailAdmin(job), keyboards.JobDetailKeyboard(job), tele.ModeHTML)
+}
+
+// notifyJobCancelled tells every worker of a cancelled job, with a refund note for those who paid,
+// and reports the result to the admin who cancelled it
+func (h *Handler) notifyJobCancelled(ctx context.Context, result *models.JobCancellation, adminID int64) {
+	refunds := make(map[int64]*models.Refund, len(result.Refunds))
+	for _, refund := range result.Refunds {
+		refunds[refund.BookingID] = refund
+	}
+
+	reqs := make([]*service.MessageRequest, 0, len(result.Bookings))
+	for _, booking := range result.Bookings {
+		reqs = append(reqs, &service.MessageRequest{
+			ChatID:  booking.UserID,
+			Message: messages.FormatJobCancelledNotice(result.Job, refunds[booking.ID]),
+			Options: []any{tele.ModeHTML},
+		})
+	}
+
+	if h.services.BookingStatus().Enabled() {
+		for _, booking := range result.Bookings {
+			h.services.BookingStatus().Refresh(ctx, booking.ID)
+		}
+	}
+
+	sent := 0
+	for i, resp := range h.services.Sender().Deliver(ctx, reqs) {
+		if resp.Error != nil {
+			h.log.Error("Failed to notify worker about cancelled job",
+				logger.Error(resp.Error),
+				logger.Any("job_id", result.Job.ID),
+				logger.Any("user_id", reqs[i].ChatID))
+			continue
+		}
+		sent++
+	}
+
+	h.log.Info("Workers notified about cancelled job",
+		logger.Any("job_id", result.Job.ID),
+		logger.Any("admin_id", adminID),
+		logger.Int("sent", sent),
+		logger.Int("total", len(reqs)),
+	)
+
+	if err := h.services.Sender().Send(ctx, adminID, messages.FormatJobCancelResult(result.Job, sent, len(reqs), result.Refunds)); err != nil {
+		h.log.Error("Failed to report job cancellation", logger.Error(err))
+	}
+}
+
+// HandleRefundsCommand lists the service fees still owed to workers of cancelled jobs (/refunds)
+func (h *Handler) HandleRefundsCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	refunds, err := h.storage.Refund().GetPending(ctx)
+	if err != nil {
+		h.log.Error("Failed to get pending refunds", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	if len(refunds) == 0 {
+		return c.Send(messages.MsgNoPendingRefunds)
+	}
+
+	shown := refunds
+	if len(shown) > refundListLimit {
+		shown = shown[:refundListLimit]
+	}
+	users := make(map[int64]*models.RegisteredUser, len(shown))
+	for _, refund := range shown {
+		if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, refund.UserID); err == nil {
+			users[refund.UserID] = registered
+		}
+	}
+
+	return c.Send(messages.FormatRefundList(shown, len(refunds), users), keyboards.RefundListKeyboard(shown), tele.ModeHTML)
+}
+
+// HandleRefundPaid marks a refund paid and tells the worker (refund_paid_<refundID>)
+func (h *Handler) HandleRefundPaid(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	refundID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	refund, err := h.storage.Refund().GetByID(ctx, refundID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Qaytarish topilmadi"})
+	}
+
+	paid, err := h.storage.Refund().MarkPaid(ctx, refund.ID, c.Sender().ID)
+	if err != nil {
+		h.log.Error("Failed to mark refund paid", logger.Error(err), logger.Any("refund_id", refund.ID))
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgError})
+	}
+	if !paid {
+		return c.Respond(&tele.CallbackResponse{Text: "ℹ️ Bu qaytarish allaqachon belgilangan"})
+	}
+
+	h.log.Info("Refund marked paid",
+		logger.Any("refund_id", refund.ID),
+		logger.Any("booking_id", refund.BookingID),
+		logger.Any("admin_id", c.Sender().ID),
+	)
+
+	if err := h.services.Sender().Send(ctx, refund.UserID, messages.FormatRefundPaidNotice(refund)); err != nil {
+		h.log.Error("Failed to notify user about refund", logger.Error(err), logger.Any("user_id", refund.UserID))
+	}
+	return c.Respond(&tele.CallbackResponse{Text: fmt.Sprintf("✅ #%d to'landi deb belgilandi", refund.ID)})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleJobFunnel shows how a job's audience moved from the deep link to the work day (job_funnel_<jobID>)
+func (h *Handler) HandleJobFunnel(c tele.Context, jobIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi."})
+	}
+
+	funnel, err := h.storage.Job().GetFunnel(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job funnel", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	msg := messages.FormatJobFunnel(job, funnel)
+	if err := c.Edit(msg, keyboards.JobFunnelKeyboard(jobID), tele.ModeHTML); err != nil && !errors.Is(err, tele.ErrMessageNotModified) {
+		h.log.Error("Failed to show job funnel", logger.Error(err))
+		return c.Send(msg, keyboards.JobFunnelKeyboard(jobID), tele.ModeHTML)
+	}
+	return nil
+}
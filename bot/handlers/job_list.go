@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// activeJobListStatuses are the jobs of the default "active" view: everything not finished yet
+var activeJobListStatuses = []models.JobStatus{models.JobStatusDraft, models.JobStatusActive, models.JobStatusFull}
+
+// HandleJobListView shows a page of the job list (job_list_<active|all>_<page>), editing the list in place
+func (h *Handler) HandleJobListView(c tele.Context, params string) error {
+	view, pageStr, _ := strings.Cut(params, "_")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		page = 1
+	}
+	return h.showJobList(c, view == "all", page, true)
+}
+
+// HandleJobListNoop answers taps on the job list buttons that are only there for layout (date headers, page counter)
+func (h *Handler) HandleJobListNoop(c tele.Context) error {
+	return c.Respond()
+}
+
+// showJobList shows a page of the admin job list grouped by work date; showAll includes completed and cancelled jobs.
+// Pages come newest first from storage, so grouping by work date happens within the page.
+func (h *Handler) showJobList(c tele.Context, showAll bool, page int, isCallback bool) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		if isCallback {
+			return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+		}
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+	}
+
+	ctx := middleware.UpdateContext(c)
+	statuses := activeJobListStatuses
+	if showAll {
+		statuses = nil
+	}
+
+	count, err := h.storage.Job().GetCountByStatuses(ctx, statuses)
+	if err != nil {
+		h.log.Error("Failed to count jobs", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	if count == 0 {
+		total, err := h.storage.Job().GetTotalCount(ctx)
+		if err == nil && total == 0 {
+			if isCallback {
+				return c.Edit("📋 Hozircha ishlar yo'q.")
+			}
+			return c.Send("📋 Hozircha ishlar yo'q.", keyboards.AdminMenuReplyKeyboard())
+		}
+	}
+
+	pageSize := h.cfg.Bot.JobListPageSize
+	totalPages := max((count+pageSize-1)/pageSize, 1)
+	page = min(max(page, 1), totalPages)
+
+	jobs, err := h.storage.Job().GetAllPaginated(ctx, statuses, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.log.Error("Failed to get jobs", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	msg := messages.FormatJobListTitle(count, showAll)
+	keyboard := keyboards.JobListKeyboard(groupJobsByWorkDate(jobs, config.NowLocal()), showAll, page, totalPages)
+
+	if isCallback {
+		return c.Edit(msg, keyboard, tele.ModeHTML)
+	}
+	return c.Send(msg, keyboard, tele.ModeHTML)
+}
+
+// groupJobsByWorkDate sorts jobs under date headers: today, tomorrow and each later date in order,
+// then past dates (newest first) and work dates that can't be parsed
+func groupJobsByWorkDate(jobs []*models.Job, now time.Time) []keyboards.JobListGroup {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	byDate := make(map[string][]*models.Job)
+	var dates []time.Time
+	var past, undated []*models.Job
+	for _, job := range jobs {
+		workDate, ok := helper.ParseWorkDate(job.WorkDate, job.CreatedAt.In(config.Timezone))
+		switch {
+		case !ok:
+			undated = append(undated, job)
+		case workDate.Before(today):
+			past = append(past, job)
+		default:
+			key := workDate.Format(time.DateOnly)
+			if _, seen := byDate[key]; !seen {
+				dates = append(dates, workDate)
+			}
+			byDate[key] = append(byDate[key], job)
+		}
+	}
+
+	byNumber := func(a, b *models.Job) int { return cmp.Compare(a.OrderNumber, b.OrderNumber) }
+	slices.SortFunc(dates, time.Time.Compare)
+
+	var groups []keyboards.JobListGroup
+	for _, date := range dates {
+		dayJobs := byDate[date.Format(time.DateOnly)]
+		slices.SortFunc(dayJobs, byNumber)
+		groups = append(groups, keyboards.JobListGroup{Title: workDateHeader(date, today), Jobs: dayJobs})
+	}
+	if len(past) > 0 {
+		slices.SortFunc(past, func(a, b *models.Job) int { return byNumber(b, a) })
+		groups = append(groups, keyboards.JobListGroup{Title: "⌛ O'tgan kunlar", Jobs: past})
+	}
+	if len(undated) > 0 {
+		slices.SortFunc(undated, byNumber)
+		groups = append(groups, keyboards.JobListGroup{Title: "❔ Sanasi aniqlanmagan", Jobs: undated})
+	}
+	return groups
+}
+
+// workDateHeader is the header of a work date in the job list
+func workDateHeader(date, today time.Time) string {
+	switch {
+	case date.Equal(today):
+		return fmt.Sprintf("📅 Bugun — %s", date.Format("02.01"))
+	case date.Equal(today.AddDate(0, 0, 1)):
+		return fmt.Sprintf("📅 Ertaga — %s", date.Format("02.01"))
+	default:
+		return fmt.Sprintf("📅 %s", date.Format("02.01.2006"))
+	}
+}
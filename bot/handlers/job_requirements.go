@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// requirementMismatch returns the job requirements the worker misses and whether an admin already waived them
+func (h *Handler) requirementMismatch(ctx context.Context, job *models.Job, registered *models.RegisteredUser) ([]models.Requirement, bool) {
+	unmet := job.UnmetRequirements(registered)
+	if len(unmet) == 0 {
+		return nil, false
+	}
+
+	waived, err := h.storage.Job().IsRequirementWaived(ctx, job.ID, registered.UserID)
+	if err != nil {
+		h.log.Error("Failed to check requirement waiver", logger.Error(err),
+			logger.Any("job_id", job.ID), logger.Any("user_id", registered.UserID))
+	}
+	return unmet, waived
+}
+
+// requirementsBlockApproval reports whether the booking's worker misses the job's requirements without a waiver.
+// Workers are screened at booking, so this catches requirements added or profiles edited afterwards.
+func (h *Handler) requirementsBlockApproval(ctx context.Context, bookingID int64) bool {
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		return false
+	}
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil || !job.HasRequirements() {
+		return false
+	}
+	registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID)
+	if err != nil {
+		return false
+	}
+
+	unmet, waived := h.requirementMismatch(ctx, job, registered)
+	return len(unmet) > 0 && !waived
+}
+
+// HandleWaiveRequirements lets the worker of a receipt card through the job's requirements (waive_req_<bookingID>).
+// The waiver is kept per job and user, so it also covers the user booking the job again.
+func (h *Handler) HandleWaiveRequirements(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	bookingID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri booking ID.", ShowAlert: true})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	booking, err := h.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Booking topilmadi.", ShowAlert: true})
+	}
+	if booking.Status != models.BookingStatusPaymentSubmitted {
+		return c.Respond(&tele.CallbackResponse{Text: "⚠️ Bu to'lov allaqachon qayta ishlangan.", ShowAlert: true})
+	}
+
+	if err := h.storage.Job().WaiveRequirements(ctx, booking.JobID, booking.UserID, c.Sender().ID); err != nil {
+		h.log.Error("Failed to waive job requirements", logger.Error(err), logger.Any("booking_id", booking.ID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi.", ShowAlert: true})
+	}
+
+	h.log.Info("Job requirements waived",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("job_id", booking.JobID),
+		logger.Any("user_id", booking.UserID),
+		logger.Any("admin_id", c.Sender().ID),
+	)
+
+	adminUsername := c.Sender().Username
+	if adminUsername == "" {
+		adminUsername = c.Sender().FirstName
+	}
+	updatedCaption := c.Message().Caption + fmt.Sprintf("\n\n%s\n👤 Admin: @%s\n⏰ Vaqt: %s",
+		messages.MsgRequirementsWaived,
+		adminUsername,
+		config.NowLocal().Format("02.01.2006 15:04"),
+	)
+	if err := h.services.Sender().EditCaption(c.Message(), updatedCaption, paymentReviewKeyboard(booking, false), tele.ModeHTML); err != nil {
+		h.log.Error("Failed to edit admin message caption", logger.Error(err))
+	}
+
+	return c.Respond(&tele.CallbackResponse{Text: messages.MsgRequirementsWaived})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// jobCreationStep is one question of the job creation wizard
+type jobCreationStep struct {
+	state    models.UserState
+	prompt   string
+	optional bool // Offers the skip button
+}
+
+// jobCreationSteps lists the wizard questions in order. The employer name is asked only for a new
+// employer and counts as part of the employer step.
+var jobCreationSteps = []jobCreationStep{
+	{state: models.StateCreatingJobIshHaqqi, prompt: messages.MsgEnterIshHaqqi},
+	{state: models.StateCreatingJobOvqat, prompt: messages.MsgEnterOvqat},
+	{state: models.StateCreatingJobVaqt, prompt: messages.MsgEnterVaqt},
+	{state: models.StateCreatingJobManzil, prompt: messages.MsgEnterManzil},
+	{state: models.StateCreatingJobLocation, prompt: messages.MsgEnterLocation, optional: true},
+	{state: models.StateCreatingJobXizmatHaqqi, prompt: messages.MsgEnterXizmatHaqqi},
+	{state: models.StateCreatingJobAvtobuslar, prompt: messages.MsgEnterAvtobuslar, optional: true},
+	{state: models.StateCreatingJobIshTavsifi, prompt: messages.MsgEnterIshTavsifi},
+	{state: models.StateCreatingJobIshKuni, prompt: messages.MsgEnterIshKuni},
+	{state: models.StateCreatingJobKerakli, prompt: messages.MsgEnterKerakliIshchilar},
+	{state: models.StateCreatingJobEmployerPhone, prompt: messages.MsgEnterEmployerPhone},
+}
+
+// jobCreationStepIndex returns the position of state in jobCreationSteps, or -1
+func jobCreationStepIndex(state models.UserState) int {
+	if state == models.StateCreatingJobEmployerName {
+		state = models.StateCreatingJobEmployerPhone
+	}
+	return slices.IndexFunc(jobCreationSteps, func(s jobCreationStep) bool { return s.state == state })
+}
+
+// jobCreationField is the getJobFieldValue field name of a step, e.g. "ish_haqqi"
+func jobCreationField(state models.UserState) string {
+	return strings.TrimPrefix(string(state), "creating_job_")
+}
+
+// promptJobCreationStep moves the admin to a wizard step and asks its question
+func (h *Handler) promptJobCreationStep(c tele.Context, state models.UserState, job *models.Job) error {
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+
+	if err := h.storage.User().UpdateState(ctx, adminID, state); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	index := jobCreationStepIndex(state)
+	step := jobCreationSteps[index]
+	canGoBack := index > 0 || h.isJobReviewing(adminID)
+
+	switch state {
+	case models.StateCreatingJobEmployerName:
+		text := messages.FormatJobCreationStep(index+1, len(jobCreationSteps), messages.MsgEnterEmployerName, "")
+		return c.Send(text, keyboards.JobCreationStepKeyboard(true, false))
+
+	case models.StateCreatingJobEmployerPhone:
+		// Offer existing employers to pick from
+		employers, err := h.storage.Employer().GetAll(ctx)
+		if err != nil {
+			h.log.Error("Failed to get employers", logger.Error(err))
+		}
+		text := messages.FormatJobCreationStep(index+1, len(jobCreationSteps), step.prompt, job.EmployerPhone)
+		if len(employers) == 0 {
+			return c.Send(text, keyboards.JobCreationStepKeyboard(canGoBack, false))
+		}
+		text = messages.FormatJobCreationStep(index+1, len(jobCreationSteps), messages.MsgPickOrEnterEmployer, job.EmployerPhone)
+		return c.Send(text, keyboards.EmployerPickKeyboard(employers))
+	}
+
+	// A new draft has no service fee yet; "0" isn't worth echoing back
+	current := getJobFieldValue(job, jobCreationField(state))
+	if state == models.StateCreatingJobXizmatHaqqi && job.ServiceFee == 0 {
+		current = ""
+	}
+
+	text := messages.FormatJobCreationStep(index+1, len(jobCreationSteps), step.prompt, current)
+	return c.Send(text, keyboards.JobCreationStepKeyboard(canGoBack, step.optional))
+}
+
+// advanceJobCreation stores the answered step and moves on: to the next step, or back to the
+// review when the admin came from there
+func (h *Handler) advanceJobCreation(c tele.Context, job *models.Job, answered models.UserState) error {
+	h.setTempJob(c.Sender().ID, job)
+
+	next := jobCreationStepIndex(answered) + 1
+	if h.isJobReviewing(c.Sender().ID) || next >= len(jobCreationSteps) {
+		return h.showJobCreationReview(c, job)
+	}
+	return h.promptJobCreationStep(c, jobCreationSteps[next].state, job)
+}
+
+// showJobCreationReview shows the drafted job with per-field edit buttons before it is saved
+func (h *Handler) showJobCreationReview(c tele.Context, job *models.Job) error {
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+
+	if err := h.storage.User().UpdateState(ctx, adminID, models.StateCreatingJobReview); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	h.setTempJob(adminID, job)
+	h.setJobReviewing(adminID)
+
+	return c.Send(messages.FormatJobCreationReview(job), keyboards.JobCreationReviewKeyboard(), tele.ModeHTML)
+}
+
+// jobCreationDraft returns the admin's state and draft when a job creation is in progress
+func (h *Handler) jobCreationDraft(c tele.Context) (models.UserState, *models.Job, bool) {
+	user, err := h.storage.User().GetByID(middleware.UpdateContext(c), c.Sender().ID)
+	job := h.getTempJob(c.Sender().ID)
+	if err != nil || job == nil || !strings.HasPrefix(string(user.State), "creating_job_") {
+		return "", nil, false
+	}
+	return user.State, job, true
+}
+
+// HandleJobCreationBack returns to the previous wizard step, or to the review when editing from there
+func (h *Handler) HandleJobCreationBack(c tele.Context) error {
+	state, job, ok := h.jobCreationDraft(c)
+	if !ok {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish yaratish jarayoni topilmadi."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	c.Delete()
+
+	// From the employer name, back means re-entering the phone; the new employer isn't linked yet
+	if state == models.StateCreatingJobEmployerName {
+		return h.promptJobCreationStep(c, models.StateCreatingJobEmployerPhone, job)
+	}
+
+	if h.isJobReviewing(c.Sender().ID) {
+		return h.showJobCreationReview(c, job)
+	}
+
+	index := jobCreationStepIndex(state)
+	if index <= 0 {
+		return h.promptJobCreationStep(c, jobCreationSteps[0].state, job)
+	}
+	return h.promptJobCreationStep(c, jobCreationSteps[index-1].state, job)
+}
+
+// HandleJobCreationEdit asks again for one field from the review (job_create_edit_<field>)
+func (h *Handler) HandleJobCreationEdit(c tele.Context, field string) error {
+	state, job, ok := h.jobCreationDraft(c)
+	if !ok || state != models.StateCreatingJobReview {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish yaratish jarayoni topilmadi."})
+	}
+
+	target := models.UserState("creating_job_" + field)
+	if jobCreationStepIndex(target) < 0 || target == models.StateCreatingJobEmployerName {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri maydon"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	c.Delete()
+	return h.promptJobCreationStep(c, target, job)
+}
+
+// HandleJobCreationSave saves the reviewed job
+func (h *Handler) HandleJobCreationSave(c tele.Context) error {
+	state, job, ok := h.jobCreationDraft(c)
+	if !ok || state != models.StateCreatingJobReview {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish yaratish jarayoni topilmadi."})
+	}
+
+	// Leaving the employer name step via the review drops the link to an employer
+	if job.EmployerID == 0 {
+		if err := c.Respond(&tele.CallbackResponse{Text: messages.MsgJobEmployerMissing, ShowAlert: true}); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		return h.promptJobCreationStep(c, models.StateCreatingJobEmployerPhone, job)
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	c.Delete()
+	return h.finishJobCreation(c, job)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleResendLocation sends the job's location pin, address and time to every
+// confirmed worker again (resend_location_<jobID>), e.g. on the morning of the job
+func (h *Handler) HandleResendLocation(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi."})
+	}
+
+	bookings, err := h.storage.Booking().GetJobBookings(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job bookings", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+	var userIDs []int64
+	for _, booking := range bookings {
+		if booking.Status == models.BookingStatusConfirmed {
+			userIDs = append(userIDs, booking.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgNoConfirmedWorkers, ShowAlert: true})
+	}
+
+	if !h.startLocationResend(jobID) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgLocationResendBusy, ShowAlert: true})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: fmt.Sprintf("📤 %d ta ishchiga yuborilmoqda...", len(userIDs))}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	// The queue paces the sends, so this can take a while for a big job
+	go h.resendLocation(context.WithoutCancel(ctx), job, userIDs, c.Sender().ID)
+	return nil
+}
+
+// resendLocation queues the pin and the reminder for each worker and reports the result to the admin
+func (h *Handler) resendLocation(ctx context.Context, job *models.Job, userIDs []int64, adminID int64) {
+	defer h.finishLocationResend(job.ID)
+
+	location, hasPin := parseJobLocation(job)
+	reminder := messages.FormatLocationReminder(job)
+
+	var reqs []*service.MessageRequest
+	for _, userID := range userIDs {
+		if hasPin {
+			reqs = append(reqs, &service.MessageRequest{ChatID: userID, Location: location})
+		}
+		reqs = append(reqs, &service.MessageRequest{
+			ChatID:  userID,
+			Message: reminder,
+			Options: []any{tele.ModeHTML},
+		})
+	}
+
+	// A worker counts as reached when the reminder, the last of their messages, arrived
+	sent := 0
+	for i, resp := range h.services.Sender().Deliver(ctx, reqs) {
+		if resp.Error != nil {
+			h.log.Error("Failed to resend job location",
+				logger.Error(resp.Error),
+				logger.Any("job_id", job.ID),
+				logger.Any("user_id", reqs[i].ChatID))
+			continue
+		}
+		if reqs[i].Location == nil {
+			sent++
+		}
+	}
+
+	h.log.Info("Job location re-sent to confirmed workers",
+		logger.Any("job_id", job.ID),
+		logger.Any("admin_id", adminID),
+		logger.Int("sent", sent),
+		logger.Int("total", len(userIDs)),
+	)
+
+	if err := h.services.Sender().Send(ctx, adminID, messages.FormatLocationResendResult(job, sent, len(userIDs))); err != nil {
+		h.log.Error("Failed to report location resend", logger.Error(err))
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// workerSearchLimit caps the users offered after one search
+	workerSearchLimit = 10
+
+	// workerSearchMinDigits is how many digits a query needs before it also matches phones
+	workerSearchMinDigits = 4
+)
+
+// HandleAddWorker asks the admin who should be added to the job (add_worker_<jobID>)
+func (h *Handler) HandleAddWorker(c tele.Context, jobIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateEditingJobAddWorker); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.setEditingJobID(c.Sender().ID, jobID)
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return c.Send(messages.MsgEnterWorkerSearch, keyboards.CancelEditKeyboard(jobID))
+}
+
+// handleAddWorkerSearchInput looks up registered users by the name or phone the admin typed
+func (h *Handler) handleAddWorkerSearchInput(c tele.Context, job *models.Job, text string) error {
+	ctx := middleware.UpdateContext(c)
+
+	var digits strings.Builder
+	for _, r := range text {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+	phoneDigits := digits.String()
+	if len(phoneDigits) < workerSearchMinDigits {
+		phoneDigits = ""
+	}
+
+	users, err := h.storage.Registration().SearchRegisteredUsers(ctx, text, phoneDigits, workerSearchLimit)
+	if err != nil {
+		h.log.Error("Failed to search registered users", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	if len(users) == 0 {
+		return c.Send(messages.MsgWorkerNotFound, keyboards.CancelEditKeyboard(job.ID))
+	}
+
+	return c.Send(messages.MsgPickWorker, keyboards.AddWorkerResultsKeyboard(job.ID, users))
+}
+
+// HandleAddWorkerPick books the chosen user onto the job (add_worker_pick_<jobID>_<userID>)
+func (h *Handler) HandleAddWorkerPick(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	parts := strings.Split(params, "_")
+	if len(parts) != 2 {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ma'lumot"})
+	}
+	jobID, err1 := strconv.ParseInt(parts[0], 10, 64)
+	userID, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ma'lumot"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	booking, job, err := h.services.Booking().CreateManualBooking(ctx, jobID, userID, c.Sender().ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNoFreeSlots):
+			return c.Respond(&tele.CallbackResponse{Text: messages.MsgWorkerNoFreeSlots, ShowAlert: true})
+		case errors.Is(err, service.ErrJobNotBookable):
+			return c.Respond(&tele.CallbackResponse{Text: messages.MsgWorkerJobNotActive, ShowAlert: true})
+		case errors.Is(err, service.ErrAlreadyBooked):
+			return c.Respond(&tele.CallbackResponse{Text: messages.MsgWorkerAlreadyBooked, ShowAlert: true})
+		case errors.Is(err, service.ErrPhoneAlreadyBooked):
+			return c.Respond(&tele.CallbackResponse{Text: messages.MsgWorkerPhoneBooked, ShowAlert: true})
+		}
+		h.log.Error("Failed to create manual booking", logger.Error(err),
+			logger.Any("job_id", jobID), logger.Any("user_id", userID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi", ShowAlert: true})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	h.clearEditingJobID(c.Sender().ID)
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Ishchi qo'shildi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	name := strconv.FormatInt(userID, 10)
+	if regUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, userID); err == nil {
+		name = fmt.Sprintf("%s (%s)", regUser.FullName, regUser.Phone)
+	}
+
+	adminUsername := c.Sender().Username
+	if adminUsername == "" {
+		adminUsername = c.Sender().FirstName
+	}
+
+	text := fmt.Sprintf("✅ <b>Ishchi ishga qo'shildi</b>\n\n👤 %s\n💼 Ish: №%s (%d/%d)\n👤 Admin: @%s\n⏰ Vaqt: %s",
+		name,
+		messages.JobNumber(job),
+		job.ConfirmedSlots,
+		job.RequiredWorkers,
+		adminUsername,
+		config.NowLocal().Format("02.01.2006 15:04"),
+	)
+	if err := c.Edit(text, &tele.ReplyMarkup{}, tele.ModeHTML); err != nil {
+		h.log.Error("Failed to edit worker pick message", logger.Error(err))
+	}
+
+	go h.notifyUserBookingConfirmed(context.WithoutCancel(ctx), booking,
+		"✅ <b>SIZ ISHGA YOZILDINGIZ!</b>",
+		"🎉 Admin sizni ushbu ishga qo'shdi.")
+
+	return nil
+}
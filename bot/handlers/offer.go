@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// offerMaxLen leaves room for the "Oferta yangilandi" header within Telegram's 4096 characters
+const offerMaxLen = 3800
+
+// ========== Re-prompting registered users ==========
+
+// promptPendingOffer asks a registered user to accept the current offer if they accepted an older one.
+// It reports whether the prompt was sent; jobID (0 = none) is the booking to continue with afterwards.
+// Storage errors let the user through so a broken consent lookup doesn't block bookings.
+func (h *Handler) promptPendingOffer(c tele.Context, userID int64, jobID int64) (bool, error) {
+	ctx := middleware.UpdateContext(c)
+	offer, err := h.services.Registration().PendingOffer(ctx, userID)
+	if err != nil {
+		h.log.Error("Failed to check pending public offer", logger.Error(err), logger.Any("user_id", userID))
+		return false, nil
+	}
+	if offer == nil {
+		return false, nil
+	}
+
+	return true, c.Send(fmt.Sprintf(messages.MsgOfferUpdated, offer.Version, offer.Content),
+		keyboards.OfferUpdateKeyboard(offer.Version, jobID))
+}
+
+// HandleOfferAccept records a registered user's consent to a new offer version (offer_accept_<version>_<jobID>)
+func (h *Handler) HandleOfferAccept(c tele.Context, params string) error {
+	versionStr, jobIDStr, _ := strings.Cut(params, "_")
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri versiya"})
+	}
+	jobID, _ := strconv.ParseInt(jobIDStr, 10, 64)
+
+	ctx := middleware.UpdateContext(c)
+	userID := c.Sender().ID
+
+	offer, err := h.services.Registration().AcceptOffer(ctx, userID, version)
+	if errors.Is(err, service.ErrOfferOutdated) {
+		if err := c.Respond(&tele.CallbackResponse{Text: messages.MsgOfferOutdated, ShowAlert: true}); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		return c.Edit(fmt.Sprintf(messages.MsgOfferUpdated, offer.Version, offer.Content),
+			keyboards.OfferUpdateKeyboard(offer.Version, jobID))
+	}
+	if err != nil {
+		h.log.Error("Failed to accept public offer", logger.Error(err), logger.Any("user_id", userID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "Oferta qabul qilindi ✅"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	if err := c.Edit(fmt.Sprintf(messages.MsgOfferAccepted, offer.Version)); err != nil {
+		h.log.Error("Failed to edit offer message", logger.Error(err))
+	}
+
+	if jobID == 0 {
+		return nil
+	}
+
+	user, err := h.storage.User().GetByID(ctx, userID)
+	if err != nil {
+		h.log.Error("Failed to get user", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	return h.HandleJobBookingStart(c, user, jobID)
+}
+
+// HandleOfferDecline handles a registered user declining a new offer version
+func (h *Handler) HandleOfferDecline(c tele.Context) error {
+	if err := c.Respond(&tele.CallbackResponse{Text: "Oferta rad etildi ❌"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.MsgOfferDeclined)
+}
+
+// ========== Admin: publishing new versions ==========
+
+// HandleOfferCommand shows the current offer version to admins (/offer)
+func (h *Handler) HandleOfferCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	offer, err := h.services.Registration().CurrentOffer(ctx)
+	if errors.Is(err, service.ErrNoPublicOffer) {
+		return c.Send(messages.MsgAdminOfferNone, keyboards.AdminOfferKeyboard())
+	}
+	if err != nil {
+		h.log.Error("Failed to get public offer", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	return c.Send(fmt.Sprintf(messages.MsgAdminOffer,
+		offer.Version,
+		offer.PublishedAt.In(config.Timezone).Format("02.01.2006 15:04"),
+		offer.Content,
+	), keyboards.AdminOfferKeyboard())
+}
+
+// HandleOfferAdminNew asks the admin for the text of the next offer version
+func (h *Handler) HandleOfferAdminNew(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateEditingOffer); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.clearTempOffer(c.Sender().ID)
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("❌ Bekor qilish", "offer_admin_cancel")))
+	return c.Send(messages.MsgEnterOfferText, menu)
+}
+
+// handleOfferInput keeps the typed offer text and shows it for review before publishing
+func (h *Handler) handleOfferInput(c tele.Context, text string) error {
+	if text == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(text) > offerMaxLen {
+		return c.Send(fmt.Sprintf(messages.MsgOfferTooLong, offerMaxLen))
+	}
+
+	ctx := middleware.UpdateContext(c)
+	nextVersion := 1
+	if offer, err := h.services.Registration().CurrentOffer(ctx); err == nil {
+		nextVersion = offer.Version + 1
+	}
+
+	h.setTempOffer(c.Sender().ID, text)
+	return c.Send(fmt.Sprintf(messages.MsgOfferPreview, nextVersion, text), keyboards.AdminOfferPreviewKeyboard())
+}
+
+// HandleOfferAdminPublish publishes the previewed text as the next offer version
+func (h *Handler) HandleOfferAdminPublish(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+
+	content := h.getTempOffer(adminID)
+	if content == "" {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+		return c.Edit(messages.MsgOfferDraftEmpty)
+	}
+
+	offer, err := h.services.Registration().PublishOffer(ctx, content, adminID)
+	if err != nil {
+		h.log.Error("Failed to publish public offer", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi, qaytadan urinib ko'ring"})
+	}
+
+	h.clearTempOffer(adminID)
+	if err := h.storage.User().UpdateState(ctx, adminID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ E'lon qilindi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(fmt.Sprintf(messages.MsgOfferPublished, offer.Version))
+}
+
+// HandleOfferAdminCancel discards the offer text being written
+func (h *Handler) HandleOfferAdminCancel(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+
+	h.clearTempOffer(c.Sender().ID)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "❌ Bekor qilindi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit("❌ Yangi oferta bekor qilindi.")
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// openJobsLimit caps the buttons of "🔎 Ochiq ishlar"
+const openJobsLimit = 40
+
+// HandleUserOpenJobs lists the published jobs with free places ("🔎 Ochiq ishlar")
+func (h *Handler) HandleUserOpenJobs(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.log.Error("Failed to respond to callback", logger.Error(err))
+		}
+	}
+
+	status := models.JobStatusActive
+	all, err := h.storage.Job().GetAll(ctx, &status)
+	if err != nil {
+		h.log.Error("Failed to get open jobs", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	var jobs []*models.Job
+	for _, job := range all {
+		if job.IsActive() && job.ChannelMessageID != 0 {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		return c.Send(messages.MsgNoOpenJobs)
+	}
+
+	// Oldest first, like the pinned channel index
+	slices.SortFunc(jobs, func(a, b *models.Job) int { return int(a.ID - b.ID) })
+	if len(jobs) > openJobsLimit {
+		jobs = jobs[:openJobsLimit]
+	}
+
+	return c.Send(messages.MsgOpenJobs, keyboards.OpenJobsKeyboard(jobs), tele.ModeHTML)
+}
+
+// HandleUserJobView opens a job chosen from "🔎 Ochiq ishlar": the job card for registered
+// users, otherwise registration with the job remembered, as with the channel signup link
+func (h *Handler) HandleUserJobView(c tele.Context, params string) error {
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	sender := c.Sender()
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	user, err := h.storage.User().GetOrCreateUser(ctx, sender.ID, sender.Username, sender.FirstName, sender.LastName)
+	if err != nil {
+		h.log.Error("Failed to get/create user", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	registeredUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, user.ID)
+	if err == nil && registeredUser != nil && registeredUser.IsActive {
+		if prompted, err := h.promptPendingOffer(c, user.ID, jobID); prompted {
+			return err
+		}
+		return h.HandleJobBookingStart(c, user, jobID)
+	}
+
+	if err := h.storage.JobInterest().Record(ctx, jobID, user.ID); err != nil {
+		h.log.Error("Failed to record job interest", logger.Error(err))
+	}
+	return h.HandleRegistrationStartWithJob(c, jobID)
+}
+
+// HandleUserJobRefresh re-reads the job and updates the card with the current free places
+func (h *Handler) HandleUserJobRefresh(c tele.Context, params string) error {
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi."})
+	}
+
+	if job.Status != models.JobStatusActive {
+		c.Respond()
+		return c.Edit("❌ Bu ish endi faol emas.")
+	}
+	if job.IsFull() {
+		c.Respond()
+		if job.ReservedSlots > 0 {
+			return c.Edit(messages.FormatNoAvailableSlots(job), tele.ModeHTML)
+		}
+		return c.Edit("❌ Bu ishga barcha joylar band.")
+	}
+
+	err = c.Edit(messages.FormatJobDetailUser(job), keyboards.JobBookingKeyboard(jobID), tele.ModeHTML)
+	if errors.Is(err, tele.ErrMessageNotModified) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgJobCardCurrent})
+	}
+	if err != nil {
+		h.log.Error("Failed to refresh job card", logger.Error(err), logger.Any("job_id", jobID))
+		return c.Respond()
+	}
+	return c.Respond(&tele.CallbackResponse{Text: messages.MsgJobCardUpdated})
+}
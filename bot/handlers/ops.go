@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"context"
+
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// NotifyOps sends an operational alert to the ops group, or to each admin without one
+func (h *Handler) NotifyOps(ctx context.Context, msg string) {
+	if groupID := h.cfg.Bot.OpsChatID(); groupID != 0 {
+		if err := h.services.Sender().Send(ctx, groupID, msg, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send ops alert to group", logger.Error(err))
+		}
+		return
+	}
+
+	for _, adminID := range h.services.Settings().AdminIDs(ctx) {
+		if err := h.services.Sender().Send(ctx, adminID, msg, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send ops alert to admin",
+				logger.Error(err),
+				logger.Any("admin_id", adminID))
+		}
+	}
+}
@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/helper"
 	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
 
 	tele "gopkg.in/telebot.v4"
 )
 
-// ForwardPaymentToAdminGroup forwards payment receipt to admin group with approval buttons
-func (h *Handler) ForwardPaymentToAdminGroup(ctx context.Context, booking *models.JobBooking, receiptFileID string) error {
+// ForwardPaymentToAdminGroup forwards payment receipt to admin group with approval buttons.
+// The rest of an album receipt follows the captioned photo, one photo per message.
+func (h *Handler) ForwardPaymentToAdminGroup(ctx context.Context, booking *models.JobBooking) error {
 	// Get job details
 	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
 	if err != nil {
@@ -37,8 +41,21 @@ func (h *Handler) ForwardPaymentToAdminGroup(ctx context.Context, booking *model
 		return err
 	}
 
+	// Worker reliability from past ratings and attendance
+	reliability := "—"
+	if rel, err := h.storage.WorkerRating().GetReliability(ctx, booking.UserID); err == nil {
+		reliability = messages.FormatWorkerReliability(rel)
+	}
+
+	unmet, waived := h.requirementMismatch(ctx, job, registeredUser)
+
 	// Format message for admin group
-	message := fmt.Sprintf(`🆕 <b>YANGI TO'LOV CHEKI</b>
+	title := "🆕 <b>YANGI TO'LOV CHEKI</b>"
+	if booking.PaymentRejections > 0 {
+		title = fmt.Sprintf("🔁 <b>QAYTA YUBORILGAN TO'LOV CHEKI</b> (%d-urinish)", booking.PaymentRejections+1)
+	}
+
+	message := fmt.Sprintf(`%s
 
 👤 <b>Foydalanuvchi:</b>
 • Ism: %s
@@ -47,20 +64,22 @@ func (h *Handler) ForwardPaymentToAdminGroup(ctx context.Context, booking *model
 • Yosh: %d
 • Vazn: %d kg
 • Bo'y: %d sm
-
+• Ishonchlilik: %s
+%s%s
 💼 <b>Ish ma'lumotlari:</b>
-• Tartib raqami: #%d
+• Tartib raqami: #%s
 • Ish haqqi: %s
 • Ish kuni: %s
 • Vaqt: %s
 • Manzil: %s
 • Ovqat: %s
-• Xizmat haqqi: %s so'm
+• Xizmat haqqi: %s
 
 📋 <b>Booking ID:</b> #%d
 ⏰ <b>Yuborilgan vaqt:</b> %s
-
-👇 <b>To'lov cheki:</b>`,
+%s
+👇 <b>To'lov cheki:</b>%s`,
+		title,
 		registeredUser.FullName,
 		registeredUser.Phone,
 		telegramUser.Username,
@@ -68,54 +87,167 @@ func (h *Handler) ForwardPaymentToAdminGroup(ctx context.Context, booking *model
 		registeredUser.Age,
 		registeredUser.Weight,
 		registeredUser.Height,
-		job.OrderNumber,
+		reliability,
+		messages.FormatSharedPhoneWarning(h.sharedPhoneUserIDs(ctx, booking.UserID, registeredUser.Phone)),
+		messages.FormatRequirementMismatch(job, unmet, registeredUser, waived),
+		messages.JobNumber(job),
 		job.Salary,
 		job.WorkDate,
 		job.WorkTime,
 		job.Address,
 		job.Food,
-		helper.FormatMoney(job.ServiceFee),
+		helper.Money(job.ServiceFee),
 		booking.ID,
 		config.NowLocal().Format("02.01.2006 15:04"),
+		messages.FormatPaidAmount(booking.PaidAmount, job.ServiceFee),
+		messages.FormatReceiptPhotoCount(len(booking.ReceiptFileIDs())),
 	)
 
-	// Create photo message
-	photo := &tele.Photo{
+	// Receipt with the card as its caption; a PDF export goes as a document with the same caption
+	var receipt tele.Sendable = &tele.Photo{
 		File: tele.File{
-			FileID: receiptFileID,
+			FileID: booking.PaymentReceiptFileID,
 		},
 		Caption: message,
 	}
+	if booking.PaymentReceiptIsDocument {
+		receipt = &tele.Document{
+			File: tele.File{
+				FileID: booking.PaymentReceiptFileID,
+			},
+			Caption: message,
+		}
+	}
+
+	keyboard := paymentReviewKeyboard(booking, len(unmet) > 0 && !waived)
+
+	// The payments group is shared, so it always gets the receipt.
+	// Without a group, each admin who kept payment notifications on gets it directly.
+	if groupID := h.cfg.Bot.PaymentsChatID(); groupID != 0 {
+		err = h.services.Sender().SendAny(ctx, groupID, receipt, keyboard, tele.ModeHTML)
+		if err != nil {
+			return fmt.Errorf("failed to send to payments group: %w", err)
+		}
+		h.sendReceiptExtras(ctx, groupID, booking)
+	} else {
+		for _, adminID := range h.services.Settings().AdminIDs(ctx) {
+			if !h.adminWantsNotification(ctx, adminID, models.NotifyPayments) {
+				continue
+			}
+			if err := h.services.Sender().SendAny(ctx, adminID, receipt, keyboard, tele.ModeHTML); err != nil {
+				h.log.Error("Failed to send payment receipt to admin",
+					logger.Error(err),
+					logger.Any("admin_id", adminID),
+					logger.Any("booking_id", booking.ID))
+				continue
+			}
+			h.sendReceiptExtras(ctx, adminID, booking)
+		}
+	}
+
+	h.log.Info("Payment receipt forwarded to admin group",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("user_id", booking.UserID),
+	)
+
+	return nil
+}
 
-	// Create inline keyboard with approval buttons
+// paymentReviewKeyboard holds the approval buttons of a receipt card; canWaive adds the requirements override
+func paymentReviewKeyboard(booking *models.JobBooking, canWaive bool) *tele.ReplyMarkup {
 	keyboard := &tele.ReplyMarkup{}
-	keyboard.Inline(
+	rows := []tele.Row{
 		keyboard.Row(
 			keyboard.Data("✅ Tasdiqlash", fmt.Sprintf("approve_payment_%d", booking.ID)),
 			keyboard.Data("❌ Rad etish", fmt.Sprintf("reject_payment_%d", booking.ID)),
 		),
+	}
+	if canWaive {
+		rows = append(rows, keyboard.Row(
+			keyboard.Data("🎯 Talabdan ozod qilish", fmt.Sprintf("waive_req_%d", booking.ID)),
+		))
+	}
+	rows = append(rows,
 		keyboard.Row(
 			keyboard.Data("🚫 Foydalanuvchini bloklash", fmt.Sprintf("block_user_%d_%d", booking.UserID, booking.ID)),
 		),
+		keyboard.Row(
+			keyboard.Data("📋 Qoidabuzarliklar", fmt.Sprintf("user_violations_%d", booking.UserID)),
+		),
 	)
+	keyboard.Inline(rows...)
+	return keyboard
+}
 
-	// Send to admin group via SenderService
-	err = h.services.Sender().SendPhoto(ctx, h.cfg.Bot.AdminGroupID, photo, keyboard, tele.ModeHTML)
+// sendReceiptExtras sends the remaining photos of an album receipt, numbered so they read as one receipt
+func (h *Handler) sendReceiptExtras(ctx context.Context, chatID int64, booking *models.JobBooking) {
+	total := len(booking.ReceiptFileIDs())
+	for i, fileID := range booking.PaymentReceiptExtraFileIDs {
+		photo := &tele.Photo{
+			File:    tele.File{FileID: fileID},
+			Caption: fmt.Sprintf(messages.MsgReceiptAlbumPhoto, i+2, total, booking.ID),
+		}
+		if err := h.services.Sender().SendPhoto(ctx, chatID, photo, tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send receipt album photo",
+				logger.Error(err),
+				logger.Any("chat_id", chatID),
+				logger.Any("booking_id", booking.ID))
+		}
+	}
+}
+
+// pendingListLimit caps the receipts listed by /pending
+const pendingListLimit = 20
+
+// HandlePendingCommand lists receipts waiting for review, oldest first (/pending)
+func (h *Handler) HandlePendingCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	pending, err := h.storage.Booking().GetPendingApprovals(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to send to admin group: %w", err)
+		h.log.Error("Failed to get pending approvals", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	if len(pending) == 0 {
+		return c.Send(messages.MsgNoPendingPayments)
 	}
 
-	h.log.Info("Payment receipt forwarded to admin group",
-		logger.Any("booking_id", booking.ID),
-		logger.Any("user_id", booking.UserID),
-	)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📩 <b>KUTILAYOTGAN TO'LOVLAR</b> (%d ta)\n\n", len(pending))
 
-	return nil
+	now := time.Now()
+	for i, booking := range pending {
+		if i == pendingListLimit {
+			fmt.Fprintf(&sb, "\n… va yana %d ta", len(pending)-pendingListLimit)
+			break
+		}
+
+		jobLabel := fmt.Sprintf("ID %d", booking.JobID)
+		if job, err := h.storage.Job().GetByID(ctx, booking.JobID); err == nil {
+			jobLabel = "№" + messages.JobNumber(job)
+		}
+		name := fmt.Sprintf("ID %d", booking.UserID)
+		if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID); err == nil {
+			name = registered.FullName
+		}
+		waited := "—"
+		if booking.PaymentSubmittedAt != nil {
+			waited = fmt.Sprintf("%d daq", int(now.Sub(*booking.PaymentSubmittedAt).Minutes()))
+		}
+
+		fmt.Fprintf(&sb, "%d. %s — %s (<code>%d</code>) — ⏳ %s, booking #%d\n",
+			i+1, jobLabel, name, booking.UserID, waited, booking.ID)
+	}
+
+	return c.Send(sb.String(), tele.ModeHTML)
 }
 
 // HandleApprovePayment handles admin approval of payment
 func (h *Handler) HandleApprovePayment(c tele.Context, params string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Check if user is admin
 	if !h.IsAdmin(c.Sender().ID) {
@@ -132,6 +264,11 @@ func (h *Handler) HandleApprovePayment(c tele.Context, params string) error {
 		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri booking ID.", ShowAlert: true})
 	}
 
+	// A worker who misses the job's requirements needs an explicit waiver first
+	if h.requirementsBlockApproval(ctx, bookingID) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgApproveRequirementsUnmet, ShowAlert: true})
+	}
+
 	// Approve payment through service
 	booking, err := h.services.Payment().ApprovePayment(ctx, bookingID, c.Sender().ID)
 	if err != nil {
@@ -157,7 +294,10 @@ func (h *Handler) HandleApprovePayment(c tele.Context, params string) error {
 	}
 
 	// Notify user
-	go h.notifyUserPaymentApproved(booking)
+	go h.notifyUserPaymentApproved(context.WithoutCancel(ctx), booking)
+	if h.services.BookingStatus().Enabled() {
+		go h.services.BookingStatus().Refresh(context.WithoutCancel(ctx), booking.ID)
+	}
 
 	// Update admin group message
 	adminUsername := c.Sender().Username
@@ -182,7 +322,7 @@ func (h *Handler) HandleApprovePayment(c tele.Context, params string) error {
 
 // HandleRejectPayment handles admin rejection of payment
 func (h *Handler) HandleRejectPayment(c tele.Context, params string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Check if user is admin
 	if !h.IsAdmin(c.Sender().ID) {
@@ -227,8 +367,20 @@ func (h *Handler) HandleRejectPayment(c tele.Context, params string) error {
 		})
 	}
 
-	// Notify user
-	go h.notifyUserPaymentRejected(booking)
+	// Notify user; the status tracker shows the reason and deadline itself
+	retryable := booking.Status == models.BookingStatusPaymentRejectedRetryable
+	if !retryable {
+		// The place was released
+		h.services.ChannelPosts().Schedule(booking.JobID)
+	}
+	switch {
+	case h.services.BookingStatus().Enabled():
+		go h.services.BookingStatus().Repost(context.WithoutCancel(ctx), booking.ID)
+	case retryable:
+		go h.notifyUserPaymentRetryable(context.WithoutCancel(ctx), booking)
+	default:
+		go h.notifyUserPaymentRejected(context.WithoutCancel(ctx), booking)
+	}
 
 	// Update admin group message
 	adminUsername := c.Sender().Username
@@ -236,7 +388,14 @@ func (h *Handler) HandleRejectPayment(c tele.Context, params string) error {
 		adminUsername = c.Sender().FirstName
 	}
 
-	updatedCaption := c.Message().Caption + fmt.Sprintf("\n\n❌ <b>RAD ETILDI</b>\n👤 Admin: @%s\n⏰ Vaqt: %s\n💬 Sabab: %s",
+	status := "❌ <b>RAD ETILDI</b>"
+	if retryable {
+		status = fmt.Sprintf("❌ <b>RAD ETILDI — chekni qayta yuborish mumkin (%d/%d)</b>",
+			booking.PaymentRejections, h.cfg.Payment.ResubmitAttempts)
+	}
+
+	updatedCaption := c.Message().Caption + fmt.Sprintf("\n\n%s\n👤 Admin: @%s\n⏰ Vaqt: %s\n💬 Sabab: %s",
+		status,
 		adminUsername,
 		config.NowLocal().Format("02.01.2006 15:04"),
 		booking.RejectionReason,
@@ -254,7 +413,7 @@ func (h *Handler) HandleRejectPayment(c tele.Context, params string) error {
 
 // HandleBlockUser handles blocking a user
 func (h *Handler) HandleBlockUser(c tele.Context, params string) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 
 	// Check if user is admin
 	if !h.IsAdmin(c.Sender().ID) {
@@ -290,6 +449,7 @@ func (h *Handler) HandleBlockUser(c tele.Context, params string) error {
 			ShowAlert: true,
 		})
 	}
+	h.services.ChannelPosts().Schedule(booking.JobID)
 
 	// Get violation count to determine notification type
 	violationCount, err := h.storage.User().GetViolationCount(ctx, nil, userID)
@@ -308,7 +468,10 @@ func (h *Handler) HandleBlockUser(c tele.Context, params string) error {
 	}
 
 	// Notify user based on violation count
-	go h.notifyUserViolation(userID, int64(job.OrderNumber), violationCount)
+	go h.notifyUserViolation(context.WithoutCancel(ctx), userID, messages.JobNumber(job), violationCount)
+	if h.services.BookingStatus().Enabled() {
+		go h.services.BookingStatus().Refresh(context.WithoutCancel(ctx), booking.ID)
+	}
 
 	// Update admin group message
 	adminUsername := c.Sender().Username
@@ -338,9 +501,59 @@ func (h *Handler) HandleBlockUser(c tele.Context, params string) error {
 }
 
 // notifyUserPaymentApproved sends notification to user about approved payment
-func (h *Handler) notifyUserPaymentApproved(booking *models.JobBooking) {
-	ctx := context.Background()
+func (h *Handler) notifyUserPaymentApproved(ctx context.Context, booking *models.JobBooking) {
+	h.notifyUserBookingConfirmed(ctx, booking,
+		"✅ <b>TO'LOVINGIZ TASDIQLANDI!</b>",
+		"🎉 Tabriklaymiz! Sizning to'lovingiz admin tomonidan tasdiqlandi.")
+}
+
+// parseJobLocation returns the job's map pin; the location is stored as "lat,lng"
+func parseJobLocation(job *models.Job) (*tele.Location, bool) {
+	parts := strings.Split(job.Location, ",")
+	if len(parts) != 2 {
+		return nil, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lng, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err1 != nil || err2 != nil {
+		return nil, false
+	}
+	return &tele.Location{Lat: float32(lat), Lng: float32(lng)}, true
+}
+
+// jobLocationPreview is what the public sees of the job's location: a static map image when
+// BOT_STATIC_MAP_URL is set, so the pin itself goes only to confirmed workers, otherwise the pin.
+// It is nil when the job has no location pin.
+func (h *Handler) jobLocationPreview(job *models.Job) tele.Sendable {
+	location, ok := parseJobLocation(job)
+	if !ok {
+		return nil
+	}
+	if h.cfg.Bot.StaticMapURL == "" {
+		return location
+	}
+	mapURL := strings.NewReplacer(
+		"{lat}", strconv.FormatFloat(float64(location.Lat), 'f', 6, 32),
+		"{lng}", strconv.FormatFloat(float64(location.Lng), 'f', 6, 32),
+	).Replace(h.cfg.Bot.StaticMapURL)
+	return &tele.Photo{File: tele.FromURL(mapURL), Caption: messages.FormatJobMapCaption(job)}
+}
+
+// sendJobLocation sends the job's location pin and reports whether it was sent
+func (h *Handler) sendJobLocation(ctx context.Context, userID int64, job *models.Job) bool {
+	location, ok := parseJobLocation(job)
+	if !ok {
+		return false
+	}
+	if err := h.services.Sender().SendAny(ctx, userID, location); err != nil {
+		h.log.Error("Failed to send location", logger.Error(err))
+		return false
+	}
+	return true
+}
 
+// notifyUserBookingConfirmed sends the job details, location and voucher for a confirmed booking
+func (h *Handler) notifyUserBookingConfirmed(ctx context.Context, booking *models.JobBooking, title, intro string) {
 	// Get job details
 	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
 	if err != nil {
@@ -350,10 +563,10 @@ func (h *Handler) notifyUserPaymentApproved(booking *models.JobBooking) {
 
 	// Build full job details
 	var sb strings.Builder
-	sb.WriteString("✅ <b>TO'LOVINGIZ TASDIQLANDI!</b>\n\n")
-	sb.WriteString("🎉 Tabriklaymiz! Sizning to'lovingiz admin tomonidan tasdiqlandi.\n\n")
+	sb.WriteString(title + "\n\n")
+	sb.WriteString(intro + "\n\n")
 	sb.WriteString("💼 <b>ISH MA'LUMOTLARI:</b>\n")
-	fmt.Fprintf(&sb, "📋 Tartib raqami: #%d\n", job.OrderNumber)
+	fmt.Fprintf(&sb, "📋 Tartib raqami: #%s\n", messages.JobNumber(job))
 	fmt.Fprintf(&sb, "📅 Ish kuni: %s\n", job.WorkDate)
 	fmt.Fprintf(&sb, "💰 Ish haqqi: %s\n", job.Salary)
 	fmt.Fprintf(&sb, "⏰ Ish vaqti: %s\n", job.WorkTime)
@@ -369,7 +582,7 @@ func (h *Handler) notifyUserPaymentApproved(booking *models.JobBooking) {
 		fmt.Fprintf(&sb, "🚌 Avtobuslar: %s\n", job.Buses)
 	}
 
-	fmt.Fprintf(&sb, "💳 Xizmat haqi: %s so'm\n", helper.FormatMoney(job.ServiceFee))
+	fmt.Fprintf(&sb, "💳 Xizmat haqi: %s\n", helper.Money(job.ServiceFee))
 
 	if job.AdditionalInfo != "" {
 		fmt.Fprintf(&sb, "📝 Qo'shimcha: %s\n", job.AdditionalInfo)
@@ -377,7 +590,7 @@ func (h *Handler) notifyUserPaymentApproved(booking *models.JobBooking) {
 
 	sb.WriteString("\n� <b>ISH BERUVCHI MA'LUMOTLARI:</b>\n")
 	if job.EmployerPhone != "" {
-		fmt.Fprintf(&sb, "📱 Telefon: <code>%s</code>\n", job.EmployerPhone)
+		fmt.Fprintf(&sb, "📱 Telefon: <code>%s</code>\n", messages.EmployerPhone(job.EmployerPhone, messages.AudienceConfirmedWorker))
 		sb.WriteString("(Zararuri savollar uchun ish beruvchi bilan bog'laning)\n")
 	}
 
@@ -394,36 +607,18 @@ func (h *Handler) notifyUserPaymentApproved(booking *models.JobBooking) {
 	}
 
 	// Send location as a separate message if available
-	if job.Location != "" {
-		// Parse location string (format: "lat,lng")
-		parts := strings.Split(job.Location, ",")
-		if len(parts) == 2 {
-			lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
-			lng, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-
-			if err1 == nil && err2 == nil {
-				location := &tele.Location{
-					Lat: float32(lat),
-					Lng: float32(lng),
-				}
-
-				if err := h.services.Sender().SendAny(ctx, booking.UserID, location); err != nil {
-					h.log.Error("Failed to send location", logger.Error(err))
-				} else {
-					// Send explanation message after location
-					if err := h.services.Sender().Send(ctx, booking.UserID, "📌 <b>Ishga borish uchun aniq manzil yuqorida ko'rsatilgan</b>", tele.ModeHTML); err != nil {
-						h.log.Error("Failed to send location explanation", logger.Error(err))
-					}
-				}
-			}
+	if h.sendJobLocation(ctx, booking.UserID, job) {
+		// Send explanation message after location
+		if err := h.services.Sender().Send(ctx, booking.UserID, "📌 <b>Ishga borish uchun aniq manzil yuqorida ko'rsatilgan</b>", tele.ModeHTML); err != nil {
+			h.log.Error("Failed to send location explanation", logger.Error(err))
 		}
 	}
+	// The voucher goes last so it stays at the bottom of the chat
+	h.sendBookingVoucher(ctx, booking, job)
 }
 
 // notifyUserPaymentRejected sends notification to user about rejected payment
-func (h *Handler) notifyUserPaymentRejected(booking *models.JobBooking) {
-	ctx := context.Background()
-
+func (h *Handler) notifyUserPaymentRejected(ctx context.Context, booking *models.JobBooking) {
 	// Get job details
 	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
 	if err != nil {
@@ -435,7 +630,7 @@ func (h *Handler) notifyUserPaymentRejected(booking *models.JobBooking) {
 
 Afsuski, sizning to'lov chekingiz admin tomonidan rad etildi.
 
-💼 <b>Ish:</b> №%d
+💼 <b>Ish:</b> №%s
 💬 <b>Sabab:</b> %s
 
 📝 <b>Nima qilish kerak:</b>
@@ -449,8 +644,40 @@ Afsuski, sizning to'lov chekingiz admin tomonidan rad etildi.
 • Sana bugungi kunni ko'rsatishi kerak
 
 Agar joylar to'lgan bo'lsa, keyingi ishlar e'lon qilinishini kuting.`,
-		job.OrderNumber,
+		messages.JobNumber(job),
+		booking.RejectionReason,
+	)
+
+	if err := h.services.Sender().Send(ctx, booking.UserID, message, tele.ModeHTML); err != nil {
+		h.log.Error("Failed to notify user", logger.Error(err))
+	}
+}
+
+// notifyUserPaymentRetryable tells the user the receipt was rejected but the slot is kept for a new one
+func (h *Handler) notifyUserPaymentRetryable(ctx context.Context, booking *models.JobBooking) {
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		h.log.Error("Failed to get job for notification", logger.Error(err))
+		return
+	}
+
+	message := fmt.Sprintf(`🔁 <b>TO'LOV CHEKI QABUL QILINMADI</b>
+
+Admin sizning to'lov chekingizni qabul qilmadi, lekin joyingiz saqlab qolindi.
+
+💼 <b>Ish:</b> №%s
+💬 <b>Sabab:</b> %s
+
+📸 Iltimos, <b>%s</b> gacha aniq va to'liq to'lov chekini qayta yuboring.
+Aks holda joy boshqa nomzodlarga bo'shatiladi.
+
+💡 <b>Maslahat:</b>
+• Chek aniq va o'qilishi kerak
+• Summa to'g'ri ko'rsatilgan bo'lishi kerak
+• Sana bugungi kunni ko'rsatishi kerak`,
+		messages.JobNumber(job),
 		booking.RejectionReason,
+		booking.ExpiresAt.In(config.Timezone).Format("15:04"),
 	)
 
 	if err := h.services.Sender().Send(ctx, booking.UserID, message, tele.ModeHTML); err != nil {
@@ -459,7 +686,7 @@ Agar joylar to'lgan bo'lsa, keyingi ishlar e'lon qilinishini kuting.`,
 }
 
 // notifyUserViolation sends progressive violation notifications
-func (h *Handler) notifyUserViolation(userID, jobID int64, violationCount int) {
+func (h *Handler) notifyUserViolation(ctx context.Context, userID int64, jobNumber string, violationCount int) {
 	var message string
 
 	switch violationCount {
@@ -467,7 +694,7 @@ func (h *Handler) notifyUserViolation(userID, jobID int64, violationCount int) {
 		// First strike - warning
 		message = fmt.Sprintf(`⚠️ <b>OGOHLANTIRISH</b>
 
-Sizning to'lov kvitansiyangiz №%d ish uchun soxta yoki noto'g'ri deb topildi.
+Sizning to'lov kvitansiyangiz №%s ish uchun soxta yoki noto'g'ri deb topildi.
 
 ❗️ <b>Muhim:</b>
 • Faqat haqiqiy to'lov chekini yuboring
@@ -481,13 +708,13 @@ Yana 1 marta soxta to'lov yuborilsa - 24 soat bloklanasiz.
 Yana 2 marta soxta to'lov yuborilsa - doimiy bloklanasiz!
 
 📞 Savol bo'lsa admin bilan bog'laning.`,
-			jobID,
+			jobNumber,
 		)
 	case 2:
 		// Second strike - 24h block
 		message = fmt.Sprintf(`🚫 <b>24 SOAT BLOKLANGANSIZ</b>
 
-Sizning to'lov kvitansiyangiz №%d ish uchun ikkinchi marta soxta deb topildi.
+Sizning to'lov kvitansiyangiz №%s ish uchun ikkinchi marta soxta deb topildi.
 
 ⏰ <b>Bloklash muddati:</b> 24 soat
 
@@ -500,13 +727,13 @@ Sizning to'lov kvitansiyangiz №%d ish uchun ikkinchi marta soxta deb topildi.
 Yana 1 marta soxta to'lov yuborilsa, doimiy bloklanasiz va endi ish bandlash imkoniyatiga ega bo'lmaysiz!
 
 ⏳ 24 soatdan keyin qaytadan urinib ko'rishingiz mumkin.`,
-			jobID,
+			jobNumber,
 		)
 	default:
 		// Third strike - permanent block
 		message = fmt.Sprintf(`🚫 <b>DOIMIY BLOKLANGANSIZ</b>
 
-Sizning to'lov kvitansiyangiz №%d ish uchun uchinchi marta soxta deb topildi.
+Sizning to'lov kvitansiyangiz №%s ish uchun uchinchi marta soxta deb topildi.
 
 ❌ <b>Hisobingiz doimiy bloklandi.</b>
 
@@ -520,18 +747,17 @@ Siz endi:
 📞 <b>Apellyatsiya:</b>
 Agar bu xato deb hisoblasangiz, admin bilan bog'laning.
 Ammo soxta to'lov aniq isbot bo'lsa, bloklash olib tashlanmaydi.`,
-			jobID,
+			jobNumber,
 		)
 	}
 
-	ctx := context.Background()
 	if err := h.services.Sender().Send(ctx, userID, message, tele.ModeHTML); err != nil {
 		h.log.Error("Failed to notify user about violation", logger.Error(err))
 	}
 }
 
 // notifyUserBlocked sends notification to blocked user (legacy, kept for backward compatibility)
-func (h *Handler) notifyUserBlocked(userID int64) {
+func (h *Handler) notifyUserBlocked(ctx context.Context, userID int64) {
 	message := `🚫 <b>SIZNING HISOBINGIZ BLOKLANDI</b>
 
 Afsuski, qoidabuzarlik sababli sizning hisobingiz bloklandi.
@@ -540,7 +766,6 @@ Afsuski, qoidabuzarlik sababli sizning hisobingiz bloklandi.
 
 📞 Agar bu xato deb hisoblasangiz, admin bilan bog'laning.`
 
-	ctx := context.Background()
 	if err := h.services.Sender().Send(ctx, userID, message, tele.ModeHTML); err != nil {
 		h.log.Error("Failed to notify blocked user", logger.Error(err))
 	}
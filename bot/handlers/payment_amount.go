@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/pkg/validation"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// paymentAmountMinTimeLeft is the reservation time a receipt needs left before the bot asks for the amount;
+// with less, the receipt goes straight to the admins so the question doesn't cost the user the slot
+const paymentAmountMinTimeLeft = time.Minute
+
+// receiptDeadline returns when the user's booking stops waiting for a receipt (zero = no such booking)
+func (h *Handler) receiptDeadline(ctx context.Context, userID int64) time.Time {
+	for _, status := range []models.BookingStatus{models.BookingStatusSlotReserved, models.BookingStatusPaymentRejectedRetryable} {
+		bookings, err := h.storage.Booking().GetUserBookingsByStatus(ctx, userID, status)
+		if err != nil {
+			h.log.Error("Failed to get user bookings", logger.Error(err), logger.Any("user_id", userID))
+			return time.Time{}
+		}
+		if len(bookings) > 0 {
+			return bookings[0].ExpiresAt
+		}
+	}
+	return time.Time{}
+}
+
+// askPaymentAmount holds the receipt and asks the user how much they transferred before deadline,
+// when the booking stops waiting for the receipt
+func (h *Handler) askPaymentAmount(c tele.Context, userID int64, receipt pendingReceipt, deadline time.Time) error {
+	ctx := middleware.UpdateContext(c)
+
+	if err := h.storage.User().UpdateState(ctx, userID, models.StatePaymentAmount); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return h.submitPaymentReceipt(c, receipt, 0)
+	}
+	h.setPendingReceipt(userID, receipt)
+
+	example := "10 000"
+	if bookings, err := h.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusSlotReserved); err == nil && len(bookings) > 0 {
+		if job, err := h.storage.Job().GetByID(ctx, bookings[0].JobID); err == nil && job.ServiceFee > 0 {
+			example = helper.FormatMoney(job.ServiceFee)
+		}
+	}
+	minutes := int(deadline.Sub(h.clock.Now()).Minutes())
+	return c.Send(fmt.Sprintf(messages.MsgPaymentAmountAsk, example, minutes), keyboards.PaymentAmountSkipKeyboard(), tele.ModeHTML)
+}
+
+// HandlePaymentAmountInput submits the held receipt with the amount the user typed
+func (h *Handler) HandlePaymentAmountInput(c tele.Context, user *models.User) error {
+	amount, verr := validation.ParseMoney(strings.TrimSpace(c.Text()))
+	if verr != nil {
+		return c.Send(verr.Message, keyboards.PaymentAmountSkipKeyboard())
+	}
+	return h.submitPendingReceipt(c, user.ID, amount)
+}
+
+// HandlePaymentAmountSkip submits the held receipt without an amount
+func (h *Handler) HandlePaymentAmountSkip(c tele.Context) error {
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	c.Delete()
+	return h.submitPendingReceipt(c, c.Sender().ID, 0)
+}
+
+// submitPendingReceipt leaves the amount step and submits the receipt held for it.
+// The prompt lasts no longer than the booking: once it stopped waiting for the receipt, the user is told instead.
+func (h *Handler) submitPendingReceipt(c tele.Context, userID int64, amount int) error {
+	ctx := middleware.UpdateContext(c)
+
+	receipt, ok := h.takePendingReceipt(userID)
+	if err := h.storage.User().UpdateState(ctx, userID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	if !ok {
+		// Restarted, or already submitted by an earlier tap
+		return c.Send(messages.MsgPaymentAmountGone)
+	}
+	if !h.receiptDeadline(ctx, userID).After(h.clock.Now()) {
+		h.log.Info("Booking expired while asking for the payment amount", logger.Any("user_id", userID))
+		return c.Send(messages.MsgPaymentAmountExpired, keyboards.UserMainMenuReplyKeyboard())
+	}
+	return h.submitPaymentReceipt(c, receipt, amount)
+}
+
+// paymentAmountExpired ends an amount prompt left unanswered past its flow timeout: the held receipt
+// still goes to the admins while the booking waits for it, and the user learns it ran out otherwise
+func (h *Handler) paymentAmountExpired(c tele.Context, user *models.User) error {
+	return h.submitPendingReceipt(c, user.ID, 0)
+}
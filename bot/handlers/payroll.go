@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// payrollRows lists the job's confirmed workers who weren't marked as no-shows, oldest booking first
+func (h *Handler) payrollRows(ctx context.Context, jobID int64) ([]*models.PayrollRow, error) {
+	bookings, err := h.storage.Booking().GetJobBookings(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job bookings: %w", err)
+	}
+
+	var rows []*models.PayrollRow
+	// GetJobBookings returns the newest first; the sheet lists workers in the order they booked
+	for i := len(bookings) - 1; i >= 0; i-- {
+		booking := bookings[i]
+		if booking.Status != models.BookingStatusConfirmed || booking.Attendance == models.AttendanceNoShow {
+			continue
+		}
+
+		row := &models.PayrollRow{BookingID: booking.ID, UserID: booking.UserID, Attendance: booking.Attendance}
+		if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID); err == nil {
+			row.FullName = registered.FullName
+			row.Phone = registered.Phone
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// payrollCSV renders the payroll sheet. The BOM makes Excel open the Uzbek names as UTF-8.
+func payrollCSV(job *models.Job, rows []*models.PayrollRow) ([]byte, error) {
+	hours := ""
+	if d, ok := job.WorkHours(); ok {
+		hours = strconv.FormatFloat(d.Hours(), 'f', -1, 64)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"№", "F.I.Sh", "Telefon", "Telegram ID", "Davomat", "Soat", "Booking ID"})
+	for i, row := range rows {
+		attendance := "belgilanmagan"
+		if row.Attendance == models.AttendanceAttended {
+			attendance = "keldi"
+		}
+		w.Write([]string{
+			strconv.Itoa(i + 1),
+			row.FullName,
+			row.Phone,
+			strconv.FormatInt(row.UserID, 10),
+			attendance,
+			hours,
+			strconv.FormatInt(row.BookingID, 10),
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// payrollSheet is a job's payroll CSV with its caption, ready to be sent to several chats
+type payrollSheet struct {
+	fileName string
+	data     []byte
+	caption  string
+}
+
+// document wraps the sheet in a fresh Document; a reader-backed file can only be uploaded once
+func (p *payrollSheet) document() *tele.Document {
+	return &tele.Document{
+		File:     tele.FromReader(bytes.NewReader(p.data)),
+		FileName: p.fileName,
+		MIME:     "text/csv",
+		Caption:  p.caption,
+	}
+}
+
+// buildPayrollSheet builds the payroll sheet of a job; nil when no one is on it
+func (h *Handler) buildPayrollSheet(ctx context.Context, job *models.Job) (*payrollSheet, *models.Employer, error) {
+	rows, err := h.payrollRows(ctx, job.ID)
+	if err != nil || len(rows) == 0 {
+		return nil, nil, err
+	}
+
+	var employer *models.Employer
+	if job.EmployerID != 0 {
+		employer, err = h.storage.Employer().GetByID(ctx, job.EmployerID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			h.log.Error("Failed to get employer for payroll", logger.Error(err), logger.Any("job_id", job.ID))
+		}
+	}
+
+	data, err := payrollCSV(job, rows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write payroll csv: %w", err)
+	}
+
+	return &payrollSheet{
+		fileName: fmt.Sprintf("ish_haqi_%s.csv", strings.ReplaceAll(messages.JobNumber(job), "/", "-")),
+		data:     data,
+		caption:  messages.FormatPayrollCaption(job, employer, rows),
+	}, employer, nil
+}
+
+// sendJobPayroll sends the payroll sheet of a closed job to the ops group (or the admin who
+// closed it when there is no group) and to the employer's payroll contact, if one is set
+func (h *Handler) sendJobPayroll(ctx context.Context, job *models.Job, adminID int64) {
+	log := logger.FromContext(ctx, h.log)
+
+	sheet, employer, err := h.buildPayrollSheet(ctx, job)
+	if err != nil {
+		log.Error("Failed to build payroll sheet", logger.Error(err), logger.Any("job_id", job.ID))
+		return
+	}
+	if sheet == nil {
+		log.Info("No confirmed workers for payroll sheet", logger.Any("job_id", job.ID))
+		return
+	}
+
+	chatID := h.cfg.Bot.OpsChatID()
+	if chatID == 0 {
+		chatID = adminID
+	}
+	if err := h.services.Sender().SendAny(ctx, chatID, sheet.document(), tele.ModeHTML); err != nil {
+		log.Error("Failed to send payroll sheet to admins", logger.Error(err), logger.Any("job_id", job.ID))
+	}
+
+	if employer == nil || employer.ContactChatID == 0 {
+		return
+	}
+	if err := h.services.Sender().SendAny(ctx, employer.ContactChatID, sheet.document(), tele.ModeHTML); err != nil {
+		log.Error("Failed to send payroll sheet to employer", logger.Error(err),
+			logger.Any("job_id", job.ID), logger.Any("employer_id", employer.ID))
+		msg := fmt.Sprintf(messages.MsgPayrollEmployerFailed, messages.JobNumber(job), employer.Name)
+		if err := h.services.Sender().Send(ctx, chatID, msg); err != nil {
+			log.Error("Failed to report payroll delivery failure", logger.Error(err))
+		}
+		return
+	}
+
+	log.Info("Payroll sheet sent to employer", logger.Any("job_id", job.ID), logger.Any("employer_id", employer.ID))
+}
+
+// HandlePayrollExport sends the payroll sheet of a job to the admin who asked (payroll_export_<jobID>),
+// e.g. again after correcting attendance marks
+func (h *Handler) HandlePayrollExport(c tele.Context, params string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	job, err := h.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		h.log.Error("Failed to get job", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish topilmadi."})
+	}
+
+	sheet, _, err := h.buildPayrollSheet(ctx, job)
+	if err != nil {
+		h.log.Error("Failed to build payroll sheet", logger.Error(err), logger.Any("job_id", job.ID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	if sheet == nil {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgPayrollEmpty, ShowAlert: true})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Send(sheet.document(), tele.ModeHTML)
+}
+
+// HandleEditEmployerContact asks the admin for the chat that gets the employer's payroll sheets
+func (h *Handler) HandleEditEmployerContact(c tele.Context, jobIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	employer, err := h.getJobEmployer(ctx, jobID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Ish beruvchi topilmadi."})
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateEditingJobEmployerContact); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+	h.setEditingJobID(c.Sender().ID, jobID)
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	current := "yo'q"
+	if employer.ContactChatID != 0 {
+		current = strconv.FormatInt(employer.ContactChatID, 10)
+	}
+	return c.Send(messages.MsgEnterEmployerContact+"\n\nJoriy qiymat: "+current, keyboards.CancelEditKeyboard(jobID))
+}
+
+// handleEmployerContactInput saves the payroll chat typed by the admin ("0" clears it) and shows the employer again.
+// The bot must be able to see the chat, so a typo or a chat that never started the bot is caught here.
+func (h *Handler) handleEmployerContactInput(c tele.Context, job *models.Job, text string) error {
+	ctx := middleware.UpdateContext(c)
+
+	chatID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil {
+		return c.Send(messages.MsgEmployerContactInvalid)
+	}
+	if chatID != 0 {
+		if _, err := c.Bot().ChatByID(chatID); err != nil {
+			h.log.Warn("Payroll contact chat is not reachable", logger.Error(err), logger.Any("chat_id", chatID))
+			return c.Send(messages.MsgEmployerContactUnreachable)
+		}
+	}
+
+	employer, err := h.getJobEmployer(ctx, job.ID)
+	if err != nil {
+		return c.Send(messages.MsgError)
+	}
+
+	employer.ContactChatID = chatID
+	if err := h.storage.Employer().Update(ctx, employer); err != nil {
+		h.log.Error("Failed to update employer contact", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	h.clearEditingJobID(c.Sender().ID)
+
+	return h.showJobEmployer(c, job.ID, false)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// receiptAlbumWait is how long the first photo of an album waits for the rest;
+	// Telegram delivers the photos of one album within a second or so of each other
+	receiptAlbumWait = 2 * time.Second
+
+	// receiptAlbumMemory is how long a submitted album is remembered, so a late photo is not submitted again
+	receiptAlbumMemory = time.Minute
+)
+
+// receiptPhoto is one photo of an album sent as a payment receipt
+type receiptPhoto struct {
+	msgID  int
+	fileID string
+}
+
+// receiptAlbums collects the photos of a receipt album so the album is submitted once.
+// Every photo arrives as its own update; the first one's handler waits and submits them all.
+type receiptAlbums struct {
+	mu        sync.Mutex
+	pending   map[string][]receiptPhoto
+	submitted map[string]time.Time
+}
+
+func newReceiptAlbums() *receiptAlbums {
+	return &receiptAlbums{
+		pending:   make(map[string][]receiptPhoto),
+		submitted: make(map[string]time.Time),
+	}
+}
+
+// add records a photo and reports whether it is the first of its album, whose handler submits the album
+func (a *receiptAlbums) add(key string, photo receiptPhoto, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for k, at := range a.submitted {
+		if now.Sub(at) >= receiptAlbumMemory {
+			delete(a.submitted, k)
+		}
+	}
+	if _, ok := a.submitted[key]; ok {
+		return false
+	}
+
+	photos, ok := a.pending[key]
+	a.pending[key] = append(photos, photo)
+	return !ok
+}
+
+// take returns the album's photos in the order they were sent and marks it submitted
+func (a *receiptAlbums) take(key string, now time.Time) []receiptPhoto {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	photos := a.pending[key]
+	delete(a.pending, key)
+	a.submitted[key] = now
+
+	sort.Slice(photos, func(i, j int) bool { return photos[i].msgID < photos[j].msgID })
+	return photos
+}
+
+// handleReceiptAlbumPhoto collects a photo of an album receipt; only the album's first photo submits
+func (h *Handler) handleReceiptAlbumPhoto(c tele.Context, fileID string) error {
+	msg := c.Message()
+	key := fmt.Sprintf("%d:%s", c.Sender().ID, msg.AlbumID)
+
+	if !h.receiptAlbums.add(key, receiptPhoto{msgID: msg.ID, fileID: fileID}, time.Now()) {
+		return nil
+	}
+
+	time.Sleep(receiptAlbumWait)
+
+	photos := h.receiptAlbums.take(key, time.Now())
+	fileIDs := make([]string, len(photos))
+	for i, photo := range photos {
+		fileIDs[i] = photo.fileID
+	}
+	return h.HandlePaymentReceiptSubmission(c, fileIDs, false, int64(photos[0].msgID))
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// reengageReportLimit is how many past campaigns /reengage shows
+const reengageReportLimit = 5
+
+// HandleReengageCommand shows recent campaign results, or with a day count starts a campaign
+// for registered users who haven't booked in that many days (/reengage [days])
+func (h *Handler) HandleReengageCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	ctx := middleware.UpdateContext(c)
+
+	payload := strings.TrimSpace(c.Message().Payload)
+	if payload == "" {
+		campaigns, err := h.services.Reengage().GetReports(ctx, reengageReportLimit)
+		if err != nil {
+			h.log.Error("Failed to get re-engagement campaigns", logger.Error(err))
+			return c.Send(messages.MsgError)
+		}
+		return c.Send(messages.FormatReengageReports(campaigns), tele.ModeHTML)
+	}
+
+	days, err := strconv.Atoi(payload)
+	if err != nil || days < 1 || days > 365 {
+		return c.Send(messages.MsgReengageBadDays)
+	}
+
+	if err := c.Send(messages.MsgReengageStarted); err != nil {
+		h.log.Error("Failed to confirm re-engagement start", logger.Error(err))
+	}
+
+	// The queue paces the sends, so a big campaign takes a while
+	go h.runReengageCampaign(context.WithoutCancel(ctx), days, c.Sender().ID)
+	return nil
+}
+
+// runReengageCampaign runs a campaign started by an admin and reports the outcome to them
+func (h *Handler) runReengageCampaign(ctx context.Context, days int, adminID int64) {
+	campaign, err := h.services.Reengage().RunCampaign(ctx, days, adminID)
+
+	var msg string
+	switch {
+	case errors.Is(err, service.ErrNoOpenJobs):
+		msg = messages.MsgReengageNoJobs
+	case errors.Is(err, service.ErrNoInactiveUsers):
+		msg = messages.MsgReengageNoUsers
+	case errors.Is(err, service.ErrCampaignRunning):
+		msg = messages.MsgReengageBusy
+	case err != nil:
+		h.log.Error("Failed to run re-engagement campaign", logger.Error(err), logger.Any("admin_id", adminID))
+		msg = messages.MsgError
+	default:
+		msg = messages.FormatReengageResult(campaign)
+	}
+
+	if err := h.services.Sender().Send(ctx, adminID, msg, tele.ModeHTML); err != nil {
+		h.log.Error("Failed to report re-engagement campaign", logger.Error(err))
+	}
+}
+
+// HandleReengageOptOut turns marketing messages off from a campaign message (reengage_optout)
+func (h *Handler) HandleReengageOptOut(c tele.Context) error {
+	return h.setReengageOptOut(c, true)
+}
+
+// HandleReengageOptIn turns marketing messages back on (reengage_optin)
+func (h *Handler) HandleReengageOptIn(c tele.Context) error {
+	return h.setReengageOptOut(c, false)
+}
+
+// setReengageOptOut stores the choice and swaps the button so it can be undone
+func (h *Handler) setReengageOptOut(c tele.Context, optOut bool) error {
+	ctx := middleware.UpdateContext(c)
+
+	if err := h.storage.User().SetMarketingOptOut(ctx, c.Sender().ID, optOut); err != nil {
+		h.log.Error("Failed to update marketing opt-out", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	text, markup := messages.MsgMarketingOptedIn, (*tele.ReplyMarkup)(nil)
+	if optOut {
+		text, markup = messages.MsgMarketingOptedOut, keyboards.ReengageOptInKeyboard()
+	}
+	if err := c.Respond(&tele.CallbackResponse{Text: text}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	// Opting back in drops the buttons; the listed jobs may be gone by now
+	if _, err := c.Bot().EditReplyMarkup(c.Message(), markup); err != nil && !errors.Is(err, tele.ErrMessageNotModified) {
+		h.log.Error("Failed to update re-engagement buttons", logger.Error(err))
+	}
+	return nil
+}
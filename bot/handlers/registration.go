@@ -2,22 +2,24 @@ package handlers
 
 import (
 	"context"
-	"os"
-	"path/filepath"
+	"errors"
+	"strconv"
 	"strings"
 	"time"
 
+	"telegram-bot-starter/bot/middleware"
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/pkg/keyboards"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
 
 	tele "gopkg.in/telebot.v4"
 )
 
 // HandleRegistrationStart handles the start of registration flow
 func (h *Handler) HandleRegistrationStart(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Get services from service manager
@@ -31,8 +33,11 @@ func (h *Handler) HandleRegistrationStart(c tele.Context) error {
 		return senderService.Reply(c, messages.MsgError)
 	}
 
-	// If registered, show main menu
+	// If registered, show main menu (after the current offer is accepted)
 	if isRegistered {
+		if prompted, err := h.promptPendingOffer(c, userID, 0); prompted {
+			return err
+		}
 		registeredUser, err := regService.GetRegisteredUser(ctx, userID)
 		if err != nil {
 			h.log.Error("Failed to get registered user", logger.Error(err))
@@ -72,30 +77,45 @@ func (h *Handler) HandleRegistrationStart(c tele.Context) error {
 	return h.showPublicOffer(c)
 }
 
-// showPublicOffer displays the public offer and accept/decline buttons
+// showPublicOffer displays the current public offer and accept/decline buttons
 func (h *Handler) showPublicOffer(c tele.Context) error {
-	// Load public offer text
-	absolutePath, err := os.Getwd()
-	if err != nil {
-		h.log.Error("Failed to get working directory", logger.Error(err))
-		return h.services.Sender().Reply(c, messages.MsgError)
-	}
-	offerPath := filepath.Join(absolutePath, "docs", "public_offer.txt")
-	summary, err := h.services.Registration().LoadPublicOffer(offerPath)
+	ctx := middleware.UpdateContext(c)
+	offer, err := h.services.Registration().CurrentOffer(ctx)
 	if err != nil {
+		if errors.Is(err, service.ErrNoPublicOffer) {
+			return h.services.Sender().Reply(c, messages.MsgOfferMissing)
+		}
 		h.log.Error("Failed to load public offer", logger.Error(err))
-		// Use fallback summary
+		return h.services.Sender().Reply(c, messages.MsgError)
 	}
 
-	return h.services.Sender().Reply(c, summary, keyboards.PublicOfferKeyboard(), tele.ModeHTML)
+	return h.services.Sender().Reply(c, offer.Content, keyboards.PublicOfferKeyboard(offer.Version))
 }
 
-// HandleAcceptOffer handles the accept offer callback
+// HandleAcceptOffer handles the accept offer callback of messages sent before offers were versioned
 func (h *Handler) HandleAcceptOffer(c tele.Context) error {
-	ctx := context.Background()
+	return h.acceptRegistrationOffer(c, 0)
+}
+
+// HandleAcceptOfferVersion handles the accept offer callback (reg_accept_offer_<version>)
+func (h *Handler) HandleAcceptOfferVersion(c tele.Context, versionStr string) error {
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return h.services.Sender().Respond(c, &tele.CallbackResponse{Text: "Xatolik yuz berdi"})
+	}
+	return h.acceptRegistrationOffer(c, version)
+}
+
+// acceptRegistrationOffer logs the consent to the offer version the user saw and continues registration
+func (h *Handler) acceptRegistrationOffer(c tele.Context, version int) error {
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
-	result, err := h.services.Registration().ProcessPublicOfferResponse(ctx, userID, true)
+	result, err := h.services.Registration().ProcessPublicOfferResponse(ctx, userID, version, true)
+	if errors.Is(err, service.ErrOfferOutdated) {
+		h.services.Sender().Respond(c, &tele.CallbackResponse{Text: messages.MsgOfferOutdated, ShowAlert: true})
+		return h.showPublicOffer(c)
+	}
 	if err != nil {
 		h.log.Error("Failed to process offer acceptance", logger.Error(err))
 		return h.services.Sender().Respond(c, &tele.CallbackResponse{Text: "Xatolik yuz berdi"})
@@ -116,10 +136,10 @@ func (h *Handler) HandleAcceptOffer(c tele.Context) error {
 
 // HandleDeclineOffer handles the decline offer callback
 func (h *Handler) HandleDeclineOffer(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
-	result, err := h.services.Registration().ProcessPublicOfferResponse(ctx, userID, false)
+	result, err := h.services.Registration().ProcessPublicOfferResponse(ctx, userID, 0, false)
 	if err != nil {
 		h.log.Error("Failed to process offer decline", logger.Error(err))
 		return h.services.Sender().Respond(c, &tele.CallbackResponse{Text: "Xatolik yuz berdi"})
@@ -137,7 +157,7 @@ func (h *Handler) HandleDeclineOffer(c tele.Context) error {
 
 // HandleContinueRegistration continues the registration from where user left off
 func (h *Handler) HandleContinueRegistration(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	draft, err := h.services.Registration().GetOrCreateDraft(ctx, userID)
@@ -159,7 +179,7 @@ func (h *Handler) HandleContinueRegistration(c tele.Context) error {
 
 // HandleRestartRegistration restarts the registration from beginning
 func (h *Handler) HandleRestartRegistration(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	_, err := h.services.Registration().RestartRegistration(ctx, userID)
@@ -176,7 +196,7 @@ func (h *Handler) HandleRestartRegistration(c tele.Context) error {
 
 // HandleRegistrationTextInput handles text input during registration
 func (h *Handler) HandleRegistrationTextInput(c tele.Context, state models.RegistrationState) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 	text := strings.TrimSpace(c.Text())
 
@@ -198,6 +218,15 @@ func (h *Handler) HandleRegistrationTextInput(c tele.Context, state models.Regis
 	case models.RegStateBodyParams:
 		return h.processBodyParams(ctx, c, userID, text)
 
+	case models.RegStateGender:
+		return h.processGender(ctx, c, userID, text)
+
+	case models.RegStateCity:
+		return h.processCity(ctx, c, userID, text)
+
+	case models.RegStatePassportPhoto:
+		return h.services.Sender().Reply(c, messages.MsgEnterPassportPhoto, keyboards.RegistrationCancelKeyboard())
+
 	default:
 		return nil
 	}
@@ -210,7 +239,7 @@ func (h *Handler) HandleCancelText(c tele.Context) error {
 
 // HandleRegistrationContact handles contact sharing during registration
 func (h *Handler) HandleRegistrationContact(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 	contact := c.Message().Contact
 
@@ -261,7 +290,7 @@ func (h *Handler) showRegistrationConfirmation(ctx context.Context, c tele.Conte
 
 // HandleConfirmRegistration handles the confirmation callback
 func (h *Handler) HandleConfirmRegistration(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Check if there's a pending job ID before completing registration
@@ -286,6 +315,9 @@ func (h *Handler) HandleConfirmRegistration(c tele.Context) error {
 	}
 
 	if !result.Success {
+		if result.Duplicate != nil {
+			go h.notifyAdminsDuplicatePhone(context.WithoutCancel(ctx), result.Draft, result.Duplicate)
+		}
 		return h.services.Sender().Respond(c, &tele.CallbackResponse{Text: result.ErrorMessage, ShowAlert: true})
 	}
 
@@ -321,12 +353,12 @@ func (h *Handler) HandleConfirmRegistration(c tele.Context) error {
 // HandleEditRegistration shows edit field selection
 func (h *Handler) HandleEditRegistration(c tele.Context) error {
 	h.services.Sender().Respond(c, &tele.CallbackResponse{Text: "Tahrirlash"})
-	return h.services.Sender().EditMessage(c, messages.MsgSelectEditField, keyboards.RegistrationEditFieldKeyboard())
+	return h.services.Sender().EditMessage(c, messages.MsgSelectEditField, keyboards.RegistrationEditFieldKeyboard(h.services.Registration().Fields()))
 }
 
 // HandleEditField handles edit field selection
 func (h *Handler) HandleEditField(c tele.Context, field models.EditField) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	result, err := h.services.Registration().GoToEditState(ctx, userID, field)
@@ -346,7 +378,7 @@ func (h *Handler) HandleEditField(c tele.Context, field models.EditField) error
 
 // HandleBackToConfirm returns to confirmation screen
 func (h *Handler) HandleBackToConfirm(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	// Get draft
@@ -367,7 +399,7 @@ func (h *Handler) HandleBackToConfirm(c tele.Context) error {
 
 // HandleCancelRegistration cancels the registration
 func (h *Handler) HandleCancelRegistration(c tele.Context) error {
-	ctx := context.Background()
+	ctx := middleware.UpdateContext(c)
 	userID := c.Sender().ID
 
 	err := h.services.Registration().CancelRegistration(ctx, userID)
@@ -478,10 +510,71 @@ func (h *Handler) processBodyParams(ctx context.Context, c tele.Context, userID
 	// Update state
 	h.storage.User().UpdateState(ctx, userID, models.UserState(result.NextState))
 
-	// Always show confirmation after body params (no passport photo step)
-	// Remove any keyboard first
-	h.services.Sender().RemoveKeyboard(c)
-	return h.showRegistrationConfirmation(ctx, c, userID)
+	return h.continueRegistration(ctx, c, userID, result.NextState)
+}
+
+// processGender handles gender input
+func (h *Handler) processGender(ctx context.Context, c tele.Context, userID int64, text string) error {
+	result, err := h.services.Registration().ProcessGender(ctx, userID, text)
+	if err != nil {
+		h.log.Error("Failed to process gender", logger.Error(err))
+		return h.services.Sender().Reply(c, messages.MsgError)
+	}
+
+	if !result.Success {
+		return h.services.Sender().Reply(c, result.ErrorMessage+"\n\n"+messages.MsgEnterGender, keyboards.GenderKeyboard())
+	}
+
+	h.storage.User().UpdateState(ctx, userID, models.UserState(result.NextState))
+
+	return h.continueRegistration(ctx, c, userID, result.NextState)
+}
+
+// processCity handles city input
+func (h *Handler) processCity(ctx context.Context, c tele.Context, userID int64, text string) error {
+	result, err := h.services.Registration().ProcessCity(ctx, userID, text)
+	if err != nil {
+		h.log.Error("Failed to process city", logger.Error(err))
+		return h.services.Sender().Reply(c, messages.MsgError)
+	}
+
+	if !result.Success {
+		return h.services.Sender().Reply(c, result.ErrorMessage+"\n\n"+messages.MsgEnterCity, keyboards.RegistrationCancelKeyboard())
+	}
+
+	h.storage.User().UpdateState(ctx, userID, models.UserState(result.NextState))
+
+	return h.continueRegistration(ctx, c, userID, result.NextState)
+}
+
+// HandleRegistrationPassportPhoto handles the passport photo step
+func (h *Handler) HandleRegistrationPassportPhoto(c tele.Context, fileID string) error {
+	ctx := middleware.UpdateContext(c)
+	userID := c.Sender().ID
+
+	result, err := h.services.Registration().ProcessPassportPhoto(ctx, userID, fileID)
+	if err != nil {
+		h.log.Error("Failed to process passport photo", logger.Error(err))
+		return h.services.Sender().Reply(c, messages.MsgError)
+	}
+
+	if !result.Success {
+		return h.services.Sender().Reply(c, result.ErrorMessage, keyboards.RegistrationCancelKeyboard())
+	}
+
+	h.storage.User().UpdateState(ctx, userID, models.UserState(result.NextState))
+
+	return h.continueRegistration(ctx, c, userID, result.NextState)
+}
+
+// continueRegistration shows the confirmation summary or the prompt of the next enabled step
+func (h *Handler) continueRegistration(ctx context.Context, c tele.Context, userID int64, next models.RegistrationState) error {
+	if next == models.RegStateConfirm {
+		// Remove any keyboard first
+		h.services.Sender().RemoveKeyboard(c)
+		return h.showRegistrationConfirmation(ctx, c, userID)
+	}
+	return h.sendStatePrompt(c, next)
 }
 
 // sendStatePrompt sends the appropriate prompt for the given state
@@ -502,8 +595,17 @@ func (h *Handler) sendStatePrompt(c tele.Context, state models.RegistrationState
 	case models.RegStateBodyParams:
 		return h.services.Sender().Reply(c, messages.MsgEnterBodyParams, keyboards.RegistrationCancelKeyboard())
 
+	case models.RegStateGender:
+		return h.services.Sender().Reply(c, messages.MsgEnterGender, keyboards.GenderKeyboard())
+
+	case models.RegStateCity:
+		return h.services.Sender().Reply(c, messages.MsgEnterCity, keyboards.RegistrationCancelKeyboard())
+
+	case models.RegStatePassportPhoto:
+		return h.services.Sender().Reply(c, messages.MsgEnterPassportPhoto, keyboards.RegistrationCancelKeyboard())
+
 	case models.RegStateConfirm:
-		ctx := context.Background()
+		ctx := middleware.UpdateContext(c)
 		return h.showRegistrationConfirmation(ctx, c, c.Sender().ID)
 
 	default:
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/service"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// runtimeSettingsText formats the current admin list and payment card for super admins
+func (h *Handler) runtimeSettingsText(ctx context.Context) string {
+	settings := h.services.Settings()
+	return messages.FormatRuntimeSettings(
+		settings.AdminIDs(ctx),
+		h.cfg.Bot.SuperAdminIDs,
+		settings.Card(ctx),
+		settings.Overridden(ctx),
+	)
+}
+
+// HandleRuntimeSettingsAdmins asks a super admin for the new admin list
+func (h *Handler) HandleRuntimeSettingsAdmins(c tele.Context) error {
+	return h.startRuntimeSetting(c, models.StateSettingsAdminIDs, messages.MsgEnterAdminIDs)
+}
+
+// HandleRuntimeSettingsCard asks a super admin for the new payment card
+func (h *Handler) HandleRuntimeSettingsCard(c tele.Context) error {
+	return h.startRuntimeSetting(c, models.StateSettingsCard, messages.MsgEnterCard)
+}
+
+// startRuntimeSetting moves a super admin into the input state of one setting
+func (h *Handler) startRuntimeSetting(c tele.Context, state models.UserState, prompt string) error {
+	if !h.services.Settings().IsSuperAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgNotSuperAdmin, ShowAlert: true})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, state); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Send(prompt, keyboards.RuntimeSettingsCancelKeyboard())
+}
+
+// HandleRuntimeSettingsCancel leaves the settings input without changes
+func (h *Handler) HandleRuntimeSettingsCancel(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, c.Sender().ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "❌ Bekor qilindi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	return c.Edit(messages.MsgSettingsCancelled)
+}
+
+// handleRuntimeSettingsInput saves the typed admin list or card; invalid input keeps the state for another try
+func (h *Handler) handleRuntimeSettingsInput(c tele.Context, user *models.User, text string) error {
+	if text == "" {
+		return nil
+	}
+
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+	settings := h.services.Settings()
+
+	switch user.State {
+	case models.StateSettingsAdminIDs:
+		before := settings.AdminIDs(ctx)
+		if _, err := settings.SetAdminIDs(ctx, text, adminID); err != nil {
+			if errors.Is(err, service.ErrInvalidAdminIDs) {
+				return c.Send(messages.MsgInvalidAdminIDs, keyboards.RuntimeSettingsCancelKeyboard())
+			}
+			h.log.Error("Failed to save admin list", logger.Error(err))
+			return c.Send(messages.MsgError)
+		}
+		after := settings.AdminIDs(ctx)
+		h.notifyAdminListChange(ctx, before, after)
+		go h.syncAdminCommands(context.WithoutCancel(ctx), before, after)
+		go h.RevokeStaleAdminMessages(context.WithoutCancel(ctx))
+
+	case models.StateSettingsCard:
+		number, holder, _ := strings.Cut(text, "\n")
+		if _, err := settings.SetCard(ctx, number, holder, adminID); err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidCard):
+				return c.Send(messages.MsgInvalidCard, keyboards.RuntimeSettingsCancelKeyboard())
+			case errors.Is(err, service.ErrInvalidCardOwner):
+				return c.Send(messages.MsgInvalidCardOwner, keyboards.RuntimeSettingsCancelKeyboard())
+			}
+			h.log.Error("Failed to save payment card", logger.Error(err))
+			return c.Send(messages.MsgError)
+		}
+
+	default:
+		return nil
+	}
+
+	if err := h.storage.User().UpdateState(ctx, adminID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	return c.Send(fmt.Sprintf("✅ Saqlandi.\n\n%s", h.runtimeSettingsText(ctx)), keyboards.AdminMenuReplyKeyboard(), tele.ModeHTML)
+}
+
+// notifyAdminListChange tells added admins about their new menu and removed ones that they lost access
+func (h *Handler) notifyAdminListChange(ctx context.Context, before, after []int64) {
+	for _, id := range after {
+		if slices.Contains(before, id) {
+			continue
+		}
+		if err := h.services.Sender().Send(ctx, id, messages.MsgAdminRightsGranted, keyboards.AdminMenuReplyKeyboard()); err != nil {
+			h.log.Error("Failed to notify new admin", logger.Error(err), logger.Any("admin_id", id))
+		}
+	}
+	for _, id := range before {
+		if slices.Contains(after, id) {
+			continue
+		}
+		if err := h.services.Sender().Send(ctx, id, messages.MsgAdminRightsRevoked, keyboards.RemoveReplyKeyboard()); err != nil {
+			h.log.Error("Failed to notify removed admin", logger.Error(err), logger.Any("admin_id", id))
+		}
+	}
+}
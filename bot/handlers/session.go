@@ -9,12 +9,37 @@ import (
 // In-memory session storage for job creation
 // In production, consider using Redis or database
 var (
-	tempJobs      = make(map[int64]*models.Job)
-	tempJobsMu    sync.RWMutex
-	editingJobIDs = make(map[int64]int64)
-	editingMu     sync.RWMutex
+	tempJobs   = make(map[int64]*models.Job)
+	tempJobsMu sync.RWMutex
+	// tempJobReviews marks admins who reached the review screen; answering a step then returns there
+	tempJobReviews = make(map[int64]bool)
+	editingJobIDs  = make(map[int64]int64)
+	// editingJobVersions holds the job version the admin saw when the edit started
+	editingJobVersions = make(map[int64]int)
+	editingMu          sync.RWMutex
+
+	// locationResends holds jobs whose location is being re-sent, so a double tap doesn't send twice
+	locationResends   = make(map[int64]bool)
+	locationResendsMu sync.Mutex
 )
 
+// startLocationResend marks the job as being re-sent; false if a resend is already running
+func (h *Handler) startLocationResend(jobID int64) bool {
+	locationResendsMu.Lock()
+	defer locationResendsMu.Unlock()
+	if locationResends[jobID] {
+		return false
+	}
+	locationResends[jobID] = true
+	return true
+}
+
+func (h *Handler) finishLocationResend(jobID int64) {
+	locationResendsMu.Lock()
+	defer locationResendsMu.Unlock()
+	delete(locationResends, jobID)
+}
+
 func (h *Handler) setTempJob(userID int64, job *models.Job) {
 	tempJobsMu.Lock()
 	defer tempJobsMu.Unlock()
@@ -31,12 +56,26 @@ func (h *Handler) clearTempJob(userID int64) {
 	tempJobsMu.Lock()
 	defer tempJobsMu.Unlock()
 	delete(tempJobs, userID)
+	delete(tempJobReviews, userID)
+}
+
+func (h *Handler) setJobReviewing(userID int64) {
+	tempJobsMu.Lock()
+	defer tempJobsMu.Unlock()
+	tempJobReviews[userID] = true
+}
+
+func (h *Handler) isJobReviewing(userID int64) bool {
+	tempJobsMu.RLock()
+	defer tempJobsMu.RUnlock()
+	return tempJobReviews[userID]
 }
 
 func (h *Handler) setEditingJobID(userID int64, jobID int64) {
 	editingMu.Lock()
 	defer editingMu.Unlock()
 	editingJobIDs[userID] = jobID
+	delete(editingJobVersions, userID) // set again by setEditingJobVersion when the flow needs it
 }
 
 func (h *Handler) getEditingJobID(userID int64) int64 {
@@ -45,8 +84,134 @@ func (h *Handler) getEditingJobID(userID int64) int64 {
 	return editingJobIDs[userID]
 }
 
+func (h *Handler) setEditingJobVersion(userID int64, version int) {
+	editingMu.Lock()
+	defer editingMu.Unlock()
+	editingJobVersions[userID] = version
+}
+
+func (h *Handler) getEditingJobVersion(userID int64) int {
+	editingMu.RLock()
+	defer editingMu.RUnlock()
+	return editingJobVersions[userID]
+}
+
 func (h *Handler) clearEditingJobID(userID int64) {
 	editingMu.Lock()
 	defer editingMu.Unlock()
 	delete(editingJobIDs, userID)
+	delete(editingJobVersions, userID)
+}
+
+// In-memory session storage for FAQ entries being added or edited by admins
+var (
+	tempFAQs      = make(map[int64]*models.FAQEntry)
+	tempFAQsMu    sync.RWMutex
+	editingFAQIDs = make(map[int64]int64)
+	editingFAQMu  sync.RWMutex
+)
+
+func (h *Handler) setTempFAQ(userID int64, entry *models.FAQEntry) {
+	tempFAQsMu.Lock()
+	defer tempFAQsMu.Unlock()
+	tempFAQs[userID] = entry
+}
+
+func (h *Handler) getTempFAQ(userID int64) *models.FAQEntry {
+	tempFAQsMu.RLock()
+	defer tempFAQsMu.RUnlock()
+	return tempFAQs[userID]
+}
+
+func (h *Handler) clearTempFAQ(userID int64) {
+	tempFAQsMu.Lock()
+	defer tempFAQsMu.Unlock()
+	delete(tempFAQs, userID)
+}
+
+func (h *Handler) setEditingFAQID(userID int64, entryID int64) {
+	editingFAQMu.Lock()
+	defer editingFAQMu.Unlock()
+	editingFAQIDs[userID] = entryID
+}
+
+func (h *Handler) getEditingFAQID(userID int64) int64 {
+	editingFAQMu.RLock()
+	defer editingFAQMu.RUnlock()
+	return editingFAQIDs[userID]
+}
+
+func (h *Handler) clearEditingFAQID(userID int64) {
+	editingFAQMu.Lock()
+	defer editingFAQMu.Unlock()
+	delete(editingFAQIDs, userID)
+}
+
+// In-memory session storage for offer texts typed by admins before publishing
+var (
+	tempOffers   = make(map[int64]string)
+	tempOffersMu sync.RWMutex
+)
+
+func (h *Handler) setTempOffer(userID int64, content string) {
+	tempOffersMu.Lock()
+	defer tempOffersMu.Unlock()
+	tempOffers[userID] = content
+}
+
+func (h *Handler) getTempOffer(userID int64) string {
+	tempOffersMu.RLock()
+	defer tempOffersMu.RUnlock()
+	return tempOffers[userID]
+}
+
+func (h *Handler) clearTempOffer(userID int64) {
+	tempOffersMu.Lock()
+	defer tempOffersMu.Unlock()
+	delete(tempOffers, userID)
+}
+
+// pendingReceipt is a receipt held while the user is asked for the transferred amount
+type pendingReceipt struct {
+	fileIDs    []string
+	isDocument bool
+	msgID      int64
+}
+
+// In-memory session storage for receipts waiting for their amount
+var (
+	pendingReceipts   = make(map[int64]pendingReceipt)
+	pendingReceiptsMu sync.Mutex
+)
+
+func (h *Handler) setPendingReceipt(userID int64, receipt pendingReceipt) {
+	pendingReceiptsMu.Lock()
+	defer pendingReceiptsMu.Unlock()
+	pendingReceipts[userID] = receipt
+}
+
+// takePendingReceipt returns the held receipt and forgets it, so a double tap submits once
+func (h *Handler) takePendingReceipt(userID int64) (pendingReceipt, bool) {
+	pendingReceiptsMu.Lock()
+	defer pendingReceiptsMu.Unlock()
+	receipt, ok := pendingReceipts[userID]
+	delete(pendingReceipts, userID)
+	return receipt, ok
+}
+
+func (h *Handler) clearPendingReceipt(userID int64) {
+	pendingReceiptsMu.Lock()
+	defer pendingReceiptsMu.Unlock()
+	delete(pendingReceipts, userID)
+}
+
+// ClearSession drops every in-memory flow leftover of the user.
+// Used when a stale state is reset outside of an update.
+func (h *Handler) ClearSession(userID int64) {
+	h.clearTempJob(userID)
+	h.clearEditingJobID(userID)
+	h.clearTempFAQ(userID)
+	h.clearEditingFAQID(userID)
+	h.clearTempOffer(userID)
+	h.clearPendingReceipt(userID)
 }
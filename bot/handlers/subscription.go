@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"strconv"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// promptChannelSubscription asks a user who isn't in the job channel to join before booking jobID.
+// It does nothing unless BOT_REQUIRE_SUBSCRIPTION is on, and reports whether the user was prompted.
+func (h *Handler) promptChannelSubscription(c tele.Context, userID, jobID int64) (bool, error) {
+	if !h.cfg.Bot.RequireSubscription || h.isChannelMember(c, userID) {
+		return false, nil
+	}
+	return true, c.Send(messages.MsgSubscriptionRequired,
+		keyboards.SubscriptionKeyboard(h.cfg.Bot.ChannelURL, jobID), tele.ModeHTML)
+}
+
+// isChannelMember checks the user's status in the job channel with getChatMember.
+// A failed check lets the user through, so a Telegram hiccup or a missing admin right doesn't stop bookings.
+func (h *Handler) isChannelMember(c tele.Context, userID int64) bool {
+	member, err := c.Bot().ChatMemberOf(&tele.Chat{ID: h.cfg.Bot.ChannelID}, &tele.User{ID: userID})
+	if err != nil {
+		h.log.Error("Failed to check channel subscription", logger.Error(err), logger.Any("user_id", userID))
+		return true
+	}
+
+	switch member.Role {
+	case tele.Creator, tele.Administrator, tele.Member:
+		return true
+	case tele.Restricted:
+		return member.Member
+	default:
+		return false
+	}
+}
+
+// HandleSubscriptionCheck re-checks the subscription and continues with the job (sub_check_<jobID>)
+func (h *Handler) HandleSubscriptionCheck(c tele.Context, params string) error {
+	jobID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ish ID"})
+	}
+
+	userID := c.Sender().ID
+	if !h.isChannelMember(c, userID) {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgSubscriptionMissing, ShowAlert: true})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Rahmat!"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+	if err := c.Delete(); err != nil && !isMessageGone(err) {
+		h.log.Error("Failed to delete subscription prompt", logger.Error(err))
+	}
+
+	ctx := middleware.UpdateContext(c)
+	user, err := h.storage.User().GetByID(ctx, userID)
+	if err != nil {
+		h.log.Error("Failed to get user", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	return h.HandleJobBookingStart(c, user, jobID)
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// supportThreadRef finds the thread in the header of a support message sent to admins ("✉️ MUROJAAT #12").
+// It is anchored so a user quoting a thread number in their text can't redirect the reply.
+var supportThreadRef = regexp.MustCompile(`^✉️ MUROJAAT #(\d+)`)
+
+// HandleSupportStart opens the "✉️ Adminga yozish" flow, or shows the user's open thread.
+// A user has at most one open thread; while it is open, their plain messages go to it.
+func (h *Handler) HandleSupportStart(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+	userID := c.Sender().ID
+
+	thread, err := h.storage.Support().GetOpenByUser(ctx, userID)
+	if err == nil {
+		return c.Send(fmt.Sprintf(messages.MsgSupportThreadOpen, thread.ID), keyboards.SupportThreadKeyboard(thread.ID))
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		h.log.Error("Failed to get open support thread", logger.Error(err), logger.Any("user_id", userID))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := h.storage.User().UpdateState(ctx, userID, models.StateSupportMessage); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+	return c.Send(messages.MsgSupportAsk, keyboards.ReplyCancelKeyboard())
+}
+
+// HandleSupportInput opens a thread with the user's message and relays it to the admins
+func (h *Handler) HandleSupportInput(c tele.Context, user *models.User) error {
+	ctx := middleware.UpdateContext(c)
+
+	text := strings.TrimSpace(c.Text())
+	if text == "" {
+		return c.Send(messages.MsgSupportTextOnly)
+	}
+
+	thread, err := h.storage.Support().Create(ctx, user.ID)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		// Opened from another update in the meantime; keep to the one thread
+		thread, err = h.storage.Support().GetOpenByUser(ctx, user.ID)
+	}
+	if err != nil {
+		h.log.Error("Failed to open support thread", logger.Error(err), logger.Any("user_id", user.ID))
+		return c.Send(messages.MsgError)
+	}
+
+	if err := h.storage.User().UpdateState(ctx, user.ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+
+	if !h.sendToSupport(ctx, thread, c.Sender(), text) {
+		return c.Send(messages.MsgSupportUnavailable, keyboards.UserMainMenuReplyKeyboard())
+	}
+
+	h.log.Info("Support thread opened", logger.Any("thread_id", thread.ID), logger.Any("user_id", user.ID))
+	return c.Send(fmt.Sprintf(messages.MsgSupportSent, thread.ID), keyboards.UserMainMenuReplyKeyboard())
+}
+
+// HandleSupportCancel leaves the support flow without opening a thread
+func (h *Handler) HandleSupportCancel(c tele.Context, user *models.User) error {
+	ctx := middleware.UpdateContext(c)
+	if err := h.storage.User().UpdateState(ctx, user.ID, models.StateIdle); err != nil {
+		h.log.Error("Failed to update user state", logger.Error(err))
+	}
+	return c.Send(messages.MsgSupportCancelled, keyboards.UserMainMenuReplyKeyboard())
+}
+
+// relayToOpenSupportThread sends an idle user's free text to their open thread.
+// It reports whether the user had one; users without one are left to the caller.
+func (h *Handler) relayToOpenSupportThread(c tele.Context, user *models.User) (bool, error) {
+	ctx := middleware.UpdateContext(c)
+
+	thread, err := h.storage.Support().GetOpenByUser(ctx, user.ID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			h.log.Error("Failed to get open support thread", logger.Error(err), logger.Any("user_id", user.ID))
+		}
+		return false, nil
+	}
+
+	if !h.sendToSupport(ctx, thread, c.Sender(), strings.TrimSpace(c.Text())) {
+		return true, c.Send(messages.MsgSupportUnavailable)
+	}
+	return true, c.Send(fmt.Sprintf(messages.MsgSupportForwarded, thread.ID))
+}
+
+// sendToSupport delivers a user's message to the ops group, or to every admin when no group is configured.
+// It reports whether anyone got it.
+func (h *Handler) sendToSupport(ctx context.Context, thread *models.SupportThread, sender *tele.User, text string) bool {
+	name := strings.TrimSpace(sender.FirstName + " " + sender.LastName)
+	msg := messages.FormatSupportMessage(thread.ID, name, sender.Username, sender.ID, text)
+	return h.notifySupportChats(ctx, msg, keyboards.SupportThreadKeyboard(thread.ID), tele.ModeHTML)
+}
+
+// notifySupportChats sends to the ops group, falling back to each admin, and reports whether anyone got it
+func (h *Handler) notifySupportChats(ctx context.Context, msg string, opts ...any) bool {
+	if groupID := h.cfg.Bot.OpsChatID(); groupID != 0 {
+		if err := h.services.Sender().Send(ctx, groupID, msg, opts...); err != nil {
+			h.log.Error("Failed to send support message to ops group", logger.Error(err))
+			return false
+		}
+		return true
+	}
+
+	delivered := false
+	for _, adminID := range h.services.Settings().AdminIDs(ctx) {
+		if err := h.services.Sender().Send(ctx, adminID, msg, opts...); err != nil {
+			h.log.Error("Failed to send support message to admin", logger.Error(err), logger.Any("admin_id", adminID))
+			continue
+		}
+		delivered = true
+	}
+	return delivered
+}
+
+// supportThreadID returns the thread an admin's reply is about, or 0 when the message is not
+// an admin's reply to a support message in the ops group or in the admin's private chat
+func (h *Handler) supportThreadID(c tele.Context) int64 {
+	match := supportThreadRef.FindStringSubmatch(h.adminReplyText(c, h.cfg.Bot.OpsChatID()))
+	if match == nil {
+		return 0
+	}
+	threadID, _ := strconv.ParseInt(match[1], 10, 64)
+	return threadID
+}
+
+// HandleSupportReply sends an admin's reply to a support message back to the thread's user
+func (h *Handler) HandleSupportReply(c tele.Context, threadID int64) error {
+	ctx := middleware.UpdateContext(c)
+
+	thread, err := h.storage.Support().GetByID(ctx, threadID)
+	if err != nil {
+		h.log.Error("Failed to get support thread", logger.Error(err), logger.Any("thread_id", threadID))
+		return c.Reply(messages.MsgError)
+	}
+	if !thread.IsOpen() {
+		return c.Reply(fmt.Sprintf(messages.MsgSupportNotOpen, thread.ID))
+	}
+
+	if err := h.services.Sender().Send(ctx, thread.UserID, messages.FormatSupportReply(thread.ID, c.Text()), tele.ModeHTML); err != nil {
+		return c.Reply(messages.MsgAdminRelayFailed)
+	}
+
+	h.log.Info("Support reply relayed to user",
+		logger.Any("admin_id", c.Sender().ID),
+		logger.Any("thread_id", thread.ID),
+		logger.Any("user_id", thread.UserID),
+	)
+	return c.Reply(messages.MsgAdminRelaySent)
+}
+
+// HandleSupportClose closes a thread from the admins' message or the user's thread status (support_close_<id>)
+func (h *Handler) HandleSupportClose(c tele.Context, params string) error {
+	threadID, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	thread, err := h.storage.Support().GetByID(ctx, threadID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Murojaat topilmadi"})
+	}
+
+	senderID := c.Sender().ID
+	byAdmin := h.IsAdmin(senderID)
+	if !byAdmin && senderID != thread.UserID {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda bu amal uchun huquq yo'q"})
+	}
+
+	closed, err := h.storage.Support().Close(ctx, thread.ID, senderID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: messages.MsgError})
+	}
+
+	if _, err := c.Bot().EditReplyMarkup(c.Message(), nil); err != nil && !isMessageGone(err) {
+		h.log.Error("Failed to remove support close button", logger.Error(err))
+	}
+	if !closed {
+		return c.Respond(&tele.CallbackResponse{Text: "ℹ️ Murojaat allaqachon yopilgan"})
+	}
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	h.log.Info("Support thread closed", logger.Any("thread_id", thread.ID), logger.Any("closed_by", senderID))
+
+	closedMsg := fmt.Sprintf(messages.MsgSupportClosed, thread.ID)
+	if byAdmin {
+		// The admins see who closed it; the user gets the same notice as when closing it themselves
+		if c.Chat().ID != thread.UserID {
+			if err := h.services.Sender().Send(ctx, thread.UserID, closedMsg); err != nil {
+				h.log.Error("Failed to notify user about closed support thread", logger.Error(err))
+			}
+		}
+		admin := c.Sender().FirstName
+		if c.Sender().Username != "" {
+			admin = "@" + c.Sender().Username
+		}
+		return c.Send(fmt.Sprintf(messages.MsgSupportClosedNote, thread.ID, admin))
+	}
+
+	h.notifySupportChats(ctx, fmt.Sprintf(messages.MsgSupportClosedNote, thread.ID, "foydalanuvchi"))
+	return c.Send(closedMsg)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// unknownTextWindow is how long an unrecognized message counts towards the hint
+const unknownTextWindow = 10 * time.Minute
+
+// unknownText counts the unrecognized messages each idle user sent in a row
+type unknownText struct {
+	mu     sync.Mutex
+	counts map[int64]int
+	last   map[int64]time.Time
+}
+
+func newUnknownText() *unknownText {
+	return &unknownText{counts: make(map[int64]int), last: make(map[int64]time.Time)}
+}
+
+// hit records an unrecognized message and reports whether it is the limit-th in a row,
+// which starts the count over
+func (u *unknownText) hit(userID int64, limit int, now time.Time) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for id, at := range u.last {
+		if now.Sub(at) >= unknownTextWindow {
+			delete(u.counts, id)
+			delete(u.last, id)
+		}
+	}
+
+	u.counts[userID]++
+	u.last[userID] = now
+	if u.counts[userID] < limit {
+		return false
+	}
+	delete(u.counts, userID)
+	delete(u.last, userID)
+	return true
+}
+
+// handleUnknownText answers text an idle user sent that no button or flow matched. Every
+// BOT_UNKNOWN_TEXT_HINT-th such message gets "tushunmadim" with the main menu again, so a
+// user whose keyboard was lost is never stuck; the others are ignored as before.
+func (h *Handler) handleUnknownText(c tele.Context) error {
+	limit := h.cfg.Bot.UnknownTextHint
+	if limit == 0 || !h.unknownText.hit(c.Sender().ID, limit, time.Now()) {
+		return nil
+	}
+
+	ctx := middleware.UpdateContext(c)
+	regUser, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, c.Sender().ID)
+	if err != nil || regUser == nil || !regUser.IsActive {
+		return c.Send(messages.MsgUnknownTextUnregistered)
+	}
+	return c.Send(messages.MsgUnknownText, keyboards.UserMainMenuReplyKeyboard())
+}
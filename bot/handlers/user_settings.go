@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// showUserSettings shows the worker's notification preferences, editing the message when opened from a button
+func (h *Handler) showUserSettings(c tele.Context, edit bool) error {
+	ctx := middleware.UpdateContext(c)
+	sender := c.Sender()
+
+	user, err := h.storage.User().GetOrCreateUser(ctx, sender.ID, sender.Username, sender.FirstName, sender.LastName)
+	if err != nil {
+		h.log.Error("Failed to get/create user", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	if edit {
+		err := c.Edit(messages.MsgSettings, keyboards.UserSettingsKeyboard(user), tele.ModeHTML)
+		if err == nil || errors.Is(err, tele.ErrMessageNotModified) {
+			return nil
+		}
+	}
+	return c.Send(messages.MsgSettings, keyboards.UserSettingsKeyboard(user), tele.ModeHTML)
+}
+
+// HandleMarketingToggle switches campaign and broadcast messages off or back on (user_notify_marketing)
+func (h *Handler) HandleMarketingToggle(c tele.Context) error {
+	ctx := middleware.UpdateContext(c)
+
+	user, err := h.storage.User().GetByID(ctx, c.Sender().ID)
+	if err != nil {
+		h.log.Error("Failed to get user", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	user.MarketingOptOut = !user.MarketingOptOut
+	if err := h.storage.User().SetMarketingOptOut(ctx, user.ID, user.MarketingOptOut); err != nil {
+		h.log.Error("Failed to update marketing opt-out", logger.Error(err))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi."})
+	}
+
+	text := messages.MsgMarketingOptedIn
+	if user.MarketingOptOut {
+		text = messages.MsgMarketingOptedOut
+	}
+	if err := c.Respond(&tele.CallbackResponse{Text: text}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	if err := c.Edit(messages.MsgSettings, keyboards.UserSettingsKeyboard(user), tele.ModeHTML); err != nil && !errors.Is(err, tele.ErrMessageNotModified) {
+		h.log.Error("Failed to update settings message", logger.Error(err))
+	}
+	return nil
+}
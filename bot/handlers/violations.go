@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// HandleViolationsCommand shows a user's violation history: /violations <user_id>
+func (h *Handler) HandleViolationsCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(c.Message().Payload), 10, 64)
+	if err != nil {
+		return c.Send("ℹ️ Foydalanish: /violations <user_id>\n\nUser ID ro'yxatdan o'tganlar ro'yxatida ko'rsatilgan.")
+	}
+
+	return h.showUserViolations(c, userID, false)
+}
+
+// HandleUserViolations opens the violation history from the payment review card
+func (h *Handler) HandleUserViolations(c tele.Context, userIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri user ID."})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	// The review card is a photo with approval buttons, so the history goes in a new message
+	return h.showUserViolations(c, userID, false)
+}
+
+// HandleForgiveViolation deletes one violation and refreshes the history
+func (h *Handler) HandleForgiveViolation(c tele.Context, violationIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	violationID, err := strconv.ParseInt(violationIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	violation, err := h.services.Payment().ForgiveViolation(ctx, violationID, c.Sender().ID)
+	if err != nil {
+		h.log.Error("Failed to forgive violation", logger.Error(err), logger.Any("violation_id", violationID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "✅ Kechirildi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return h.showUserViolations(c, violation.UserID, true)
+}
+
+// HandleEscalateBlock blocks the user permanently and refreshes the history
+func (h *Handler) HandleEscalateBlock(c tele.Context, userIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri user ID."})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	if err := h.services.Payment().BlockUserPermanently(ctx, userID, c.Sender().ID); err != nil {
+		h.log.Error("Failed to block user permanently", logger.Error(err), logger.Any("user_id", userID))
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Xatolik yuz berdi"})
+	}
+
+	if err := c.Respond(&tele.CallbackResponse{Text: "🚫 Doimiy bloklandi"}); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	return h.showUserViolations(c, userID, true)
+}
+
+// HandleViolationReceipt sends the receipt photo of the booking behind a violation
+func (h *Handler) HandleViolationReceipt(c tele.Context, violationIDStr string) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Sizda admin huquqi yo'q."})
+	}
+
+	violationID, err := strconv.ParseInt(violationIDStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Noto'g'ri ID"})
+	}
+
+	ctx := middleware.UpdateContext(c)
+	violation, err := h.storage.User().GetViolationByID(ctx, violationID)
+	if err != nil || violation.BookingID == nil {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Chek topilmadi"})
+	}
+
+	booking, err := h.storage.Booking().GetByID(ctx, *violation.BookingID)
+	if err != nil || booking.PaymentReceiptFileID == "" {
+		return c.Respond(&tele.CallbackResponse{Text: "❌ Chek topilmadi"})
+	}
+
+	if err := c.Respond(); err != nil {
+		h.log.Error("Failed to respond to callback", logger.Error(err))
+	}
+
+	if booking.PaymentReceiptIsDocument {
+		return c.Send(&tele.Document{File: tele.File{FileID: booking.PaymentReceiptFileID}})
+	}
+
+	fileIDs := booking.ReceiptFileIDs()
+	if len(fileIDs) == 1 {
+		return c.Send(&tele.Photo{File: tele.File{FileID: fileIDs[0]}})
+	}
+
+	album := make(tele.Album, len(fileIDs))
+	for i, fileID := range fileIDs {
+		album[i] = &tele.Photo{File: tele.File{FileID: fileID}}
+	}
+	return c.SendAlbum(album)
+}
+
+// showUserViolations renders the violation history, editing the callback message or sending a new one
+func (h *Handler) showUserViolations(c tele.Context, userID int64, edit bool) error {
+	ctx := middleware.UpdateContext(c)
+
+	violations, err := h.storage.User().GetViolations(ctx, userID)
+	if err != nil {
+		h.log.Error("Failed to get violations", logger.Error(err), logger.Any("user_id", userID))
+		return c.Send(messages.MsgError)
+	}
+
+	block, err := h.storage.User().GetBlockStatus(ctx, userID)
+	if err != nil {
+		h.log.Error("Failed to get block status", logger.Error(err), logger.Any("user_id", userID))
+	}
+
+	// Resolve job and receipt for each violation; a few lookups per user is fine here
+	jobs := make(map[int64]*models.Job)
+	details := make([]*models.ViolationDetail, 0, len(violations))
+	for _, v := range violations {
+		d := &models.ViolationDetail{UserViolation: v}
+		if v.BookingID != nil {
+			if booking, err := h.storage.Booking().GetByID(ctx, *v.BookingID); err == nil {
+				d.ReceiptFileID = booking.PaymentReceiptFileID
+				job, ok := jobs[booking.JobID]
+				if !ok {
+					if job, err = h.storage.Job().GetByID(ctx, booking.JobID); err == nil {
+						jobs[booking.JobID] = job
+					}
+				}
+				if job != nil {
+					d.JobNumber = messages.JobNumber(job)
+				}
+			}
+		}
+		details = append(details, d)
+	}
+
+	msg := messages.FormatUserViolations(userID, h.violationUserName(c, userID), details, block)
+	menu := keyboards.UserViolationsKeyboard(userID, details, block != nil && block.IsPermanent())
+	if edit {
+		return c.Edit(msg, menu, tele.ModeHTML)
+	}
+	return c.Send(msg, menu, tele.ModeHTML)
+}
+
+// violationUserName prefers the registered full name, falling back to the Telegram name
+func (h *Handler) violationUserName(c tele.Context, userID int64) string {
+	ctx := middleware.UpdateContext(c)
+
+	if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, userID); err == nil && registered != nil {
+		return registered.FullName
+	}
+	if user, err := h.storage.User().GetByID(ctx, userID); err == nil {
+		if user.Username != "" {
+			return "@" + user.Username
+		}
+		return user.FirstName
+	}
+	return ""
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/middleware"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	"github.com/skip2/go-qrcode"
+	tele "gopkg.in/telebot.v4"
+)
+
+// voucherQRSize is the width and height in pixels of the voucher QR code photo
+const voucherQRSize = 512
+
+// HandleVerifyCommand checks a worker's voucher code on site: /verify <code>
+func (h *Handler) HandleVerifyCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	code := models.NormalizeVoucherCode(c.Message().Payload)
+	if code == "" {
+		return c.Send("ℹ️ Foydalanish: /verify <kod>\n\nMasalan: /verify K7M2-Q9XA")
+	}
+
+	ctx := middleware.UpdateContext(c)
+	voucher, err := h.storage.Voucher().GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Send("❌ <b>KOD TOPILMADI</b>\n\nBunday yo'llanma berilmagan. Ishchini ishga qo'ymang va admin bilan bog'laning.", tele.ModeHTML)
+		}
+		h.log.Error("Failed to get voucher", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	booking, err := h.storage.Booking().GetByID(ctx, voucher.BookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking for voucher", logger.Error(err), logger.Any("booking_id", voucher.BookingID))
+		return c.Send(messages.MsgError)
+	}
+
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		h.log.Error("Failed to get job for voucher", logger.Error(err), logger.Any("job_id", booking.JobID))
+		return c.Send(messages.MsgError)
+	}
+
+	// Only a confirmed booking counts as an on-site check
+	firstCheck := voucher.VerifiedAt == nil
+	if firstCheck && booking.Status == models.BookingStatusConfirmed {
+		if err := h.storage.Voucher().MarkVerified(ctx, voucher.ID, c.Sender().ID); err != nil {
+			h.log.Error("Failed to mark voucher verified", logger.Error(err), logger.Any("voucher_id", voucher.ID))
+		}
+	}
+
+	var fullName, phone string
+	if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID); err == nil && registered != nil {
+		fullName = registered.FullName
+		phone = registered.Phone
+	}
+
+	h.log.Info("Voucher verified",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("admin_id", c.Sender().ID),
+		logger.Bool("first_check", firstCheck),
+	)
+
+	return c.Send(messages.FormatVoucherVerification(voucher, booking, job, fullName, phone, firstCheck), tele.ModeHTML)
+}
+
+// HandleCheckInCommand marks a worker as arrived by voucher code: /checkin <code>
+func (h *Handler) HandleCheckInCommand(c tele.Context) error {
+	if !h.IsAdmin(c.Sender().ID) {
+		return c.Send("❌ Sizda admin huquqi yo'q.")
+	}
+
+	code := models.NormalizeVoucherCode(c.Message().Payload)
+	if code == "" {
+		return c.Send("ℹ️ Foydalanish: /checkin <kod>\n\nYoki ishchining QR kodini telefon kamerasi bilan skanerlang, yoki QR rasmini botga forward qiling.")
+	}
+
+	return h.checkInByCode(c, code)
+}
+
+// checkInByCode marks a confirmed booking as attended; used by /checkin, the QR deep link and forwarded QR photos
+func (h *Handler) checkInByCode(c tele.Context, code string) error {
+	ctx := middleware.UpdateContext(c)
+	adminID := c.Sender().ID
+
+	voucher, err := h.storage.Voucher().GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Send("❌ <b>KOD TOPILMADI</b>\n\nBunday yo'llanma berilmagan. Ishchini ishga qo'ymang va admin bilan bog'laning.", tele.ModeHTML)
+		}
+		h.log.Error("Failed to get voucher", logger.Error(err))
+		return c.Send(messages.MsgError)
+	}
+
+	booking, err := h.storage.Booking().GetByID(ctx, voucher.BookingID)
+	if err != nil {
+		h.log.Error("Failed to get booking for check-in", logger.Error(err), logger.Any("booking_id", voucher.BookingID))
+		return c.Send(messages.MsgError)
+	}
+
+	job, err := h.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		h.log.Error("Failed to get job for check-in", logger.Error(err), logger.Any("job_id", booking.JobID))
+		return c.Send(messages.MsgError)
+	}
+
+	var fullName, phone string
+	if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID); err == nil && registered != nil {
+		fullName = registered.FullName
+		phone = registered.Phone
+	}
+
+	// The voucher card keeps its original first-check details, so render it before marking
+	firstCheck := voucher.VerifiedAt == nil
+	msg := messages.FormatVoucherVerification(voucher, booking, job, fullName, phone, firstCheck)
+
+	if booking.Status != models.BookingStatusConfirmed {
+		return c.Send(msg+"\n\n❌ Yozilish tasdiqlanmagan, kelish belgilanmadi.", tele.ModeHTML)
+	}
+
+	alreadyCheckedIn := booking.Attendance == models.AttendanceAttended
+	if !alreadyCheckedIn {
+		if err := h.storage.Booking().SetAttendance(ctx, booking.ID, models.AttendanceAttended); err != nil {
+			h.log.Error("Failed to set attendance", logger.Error(err), logger.Any("booking_id", booking.ID))
+			return c.Send(messages.MsgError)
+		}
+	}
+	if firstCheck {
+		if err := h.storage.Voucher().MarkVerified(ctx, voucher.ID, adminID); err != nil {
+			h.log.Error("Failed to mark voucher verified", logger.Error(err), logger.Any("voucher_id", voucher.ID))
+		}
+	}
+
+	if alreadyCheckedIn {
+		msg += "\n\nℹ️ Ishchi allaqachon keldi deb belgilangan."
+	} else {
+		msg += "\n\n🟢 <b>Ishchi keldi deb belgilandi.</b>"
+		go func(ctx context.Context) {
+			if err := h.services.Sender().Send(ctx, booking.UserID, fmt.Sprintf("✅ Ish joyiga kelganingiz qayd etildi (ish №%s). Omad!", messages.JobNumber(job))); err != nil {
+				h.log.Error("Failed to notify worker about check-in", logger.Error(err), logger.Any("user_id", booking.UserID))
+			}
+		}(context.WithoutCancel(ctx))
+	}
+
+	if rel, err := h.storage.WorkerRating().GetReliability(ctx, booking.UserID); err == nil {
+		msg += "\n📈 Ishonchlilik: " + messages.FormatWorkerReliability(rel)
+	}
+
+	h.log.Info("Worker checked in",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("job_id", job.ID),
+		logger.Any("admin_id", adminID),
+		logger.Bool("already_checked_in", alreadyCheckedIn),
+	)
+
+	return c.Send(msg, tele.ModeHTML)
+}
+
+// sendBookingVoucher issues the booking's voucher and sends it to the worker,
+// as a QR code photo when BOT_CHECKIN_QR is on
+func (h *Handler) sendBookingVoucher(ctx context.Context, booking *models.JobBooking, job *models.Job) {
+	voucher, err := h.services.Booking().IssueVoucher(ctx, booking.ID)
+	if err != nil {
+		h.log.Error("Failed to issue voucher", logger.Error(err), logger.Any("booking_id", booking.ID))
+		return
+	}
+
+	var fullName string
+	if registered, err := h.storage.Registration().GetRegisteredUserByUserID(ctx, booking.UserID); err == nil && registered != nil {
+		fullName = registered.FullName
+	}
+
+	msg := messages.FormatBookingVoucher(voucher, job, fullName)
+
+	if png := h.voucherQR(voucher); png != nil {
+		photo := &tele.Photo{File: tele.FromReader(bytes.NewReader(png)), Caption: msg}
+		if err := h.services.Sender().SendAny(ctx, booking.UserID, photo, tele.ModeHTML); err == nil {
+			return
+		}
+		// Fall back to the text voucher; the code alone is enough for /checkin
+	}
+
+	if err := h.services.Sender().Send(ctx, booking.UserID, msg, tele.ModeHTML); err != nil {
+		h.log.Error("Failed to send voucher", logger.Error(err), logger.Any("booking_id", booking.ID))
+	}
+}
+
+// voucherQR renders the voucher's QR code as a PNG, or returns nil when QR codes are disabled.
+// With BOT_USERNAME set the QR holds a deep link, so scanning it with a phone camera opens /start checkin_<code>.
+func (h *Handler) voucherQR(voucher *models.BookingVoucher) []byte {
+	if !h.cfg.Bot.CheckInQR {
+		return nil
+	}
+
+	data := voucher.Code
+	if h.cfg.Bot.Username != "" {
+		data = "https://t.me/" + h.cfg.Bot.Username + "?start=" + models.CheckInPayload + voucher.Code
+	}
+	png, err := qrcode.Encode(data, qrcode.Medium, voucherQRSize)
+	if err != nil {
+		h.log.Error("Failed to render voucher QR code", logger.Error(err), logger.Any("booking_id", voucher.BookingID))
+		return nil
+	}
+	return png
+}
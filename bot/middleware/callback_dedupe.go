@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// callbackKey identifies one button of one user
+type callbackKey struct {
+	userID int64
+	data   string
+}
+
+// CallbackDedupe drops repeated taps on the same inline button.
+//
+// Fast double taps on "✅ Ha, yozilaman" or the payment approval buttons reach
+// the bot as separate updates and can both start a flow before the handlers'
+// own idempotency checks see the first one's result. The second tap within
+// the window is answered with a "processing" toast and never reaches the handler.
+type CallbackDedupe struct {
+	window time.Duration
+	log    logger.LoggerI
+
+	mu        sync.Mutex
+	seen      map[callbackKey]time.Time // when the tap that was let through arrived
+	lastPrune time.Time
+}
+
+// NewCallbackDedupe creates a dedupe cache; a window of 0 disables it
+func NewCallbackDedupe(window time.Duration, log logger.LoggerI) *CallbackDedupe {
+	return &CallbackDedupe{
+		window: window,
+		log:    log,
+		seen:   make(map[callbackKey]time.Time),
+	}
+}
+
+// Middleware returns a telebot middleware that drops duplicate callbacks
+func (d *CallbackDedupe) Middleware() tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			cb := c.Callback()
+			if d.window <= 0 || cb == nil || c.Sender() == nil {
+				return next(c)
+			}
+
+			key := callbackKey{userID: c.Sender().ID, data: cb.Unique + "|" + cb.Data}
+			if !d.first(key, time.Now()) {
+				d.log.Info("Duplicate callback dropped",
+					logger.Any("user_id", key.userID),
+					logger.String("callback", key.data),
+				)
+				return c.Respond(&tele.CallbackResponse{Text: messages.MsgCallbackProcessing})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// first records the tap and reports whether it is the first one within the window
+func (d *CallbackDedupe) first(key callbackKey, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Entries only matter for one window, so drop old ones about once per window
+	if now.Sub(d.lastPrune) > d.window {
+		for k, t := range d.seen {
+			if now.Sub(t) >= d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastPrune = now
+	}
+
+	if t, ok := d.seen[key]; ok && now.Sub(t) < d.window {
+		return false
+	}
+	d.seen[key] = now
+	return true
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// ctxKey is the tele.Context key holding the per-update context.Context
+const ctxKey = "update_ctx"
+
+// ContextMiddleware gives every update its own context with a deadline.
+//
+// The context derives from base, so cancelling base on shutdown aborts
+// outstanding storage calls instead of letting them run past bot.Stop().
+func ContextMiddleware(base context.Context, timeout time.Duration) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			ctx, cancel := context.WithTimeout(base, timeout)
+			defer cancel()
+
+			c.Set(ctxKey, ctx)
+			return next(c)
+		}
+	}
+}
+
+// UpdateContext returns the context created for the current update,
+// or context.Background() if the context middleware didn't run.
+//
+// The context is cancelled when the handler returns; goroutines that
+// outlive the handler should wrap it with context.WithoutCancel.
+func UpdateContext(c tele.Context) context.Context {
+	if ctx, ok := c.Get(ctxKey).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
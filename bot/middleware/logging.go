@@ -1,34 +1,67 @@
 package middleware
 
 import (
-	"fmt"
+	"time"
 
 	"telegram-bot-starter/pkg/logger"
 
 	tele "gopkg.in/telebot.v4"
 )
 
-// LoggingMiddleware logs all incoming updates
+// LoggingMiddleware assigns every update a correlation ID and logs handler entry/exit.
+//
+// The ID is added to the update's context (see UpdateContext); handlers pass
+// that context to storage so repository logs carry the same ID.
 func LoggingMiddleware(log logger.LoggerI) tele.MiddlewareFunc {
 	return func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) error {
-			user := c.Sender()
-			var username string
-			if user != nil {
-				if user.Username != "" {
-					username = "@" + user.Username
-				} else {
-					username = user.FirstName
-				}
+			id := logger.NewCorrelationID()
+			c.Set(logger.CorrelationIDKey, id)
+			c.Set(ctxKey, logger.WithCorrelationID(UpdateContext(c), id))
+
+			fields := updateFields(c, id)
+			log.Debug("Update received", fields...)
+
+			start := time.Now()
+			err := next(c)
+			fields = append(fields, logger.Any("duration", time.Since(start).String()))
+			if ctxErr := UpdateContext(c).Err(); ctxErr != nil {
+				// Deadline hit or shutdown cancelled the update
+				fields = append(fields, logger.String("ctx_err", ctxErr.Error()))
 			}
 
-			if c.Message() != nil {
-				log.Info(fmt.Sprintf("Message from %s (ID: %d): %s", username, user.ID, c.Text()))
-			} else if c.Callback() != nil {
-				log.Info(fmt.Sprintf("Callback from %s (ID: %d): %s", username, user.ID, c.Callback().Data))
+			if err != nil {
+				log.Error("Update failed", append(fields, logger.String("outcome", "error"), logger.Error(err))...)
+				return err
 			}
 
-			return next(c)
+			log.Info("Update handled", append(fields, logger.String("outcome", "ok"))...)
+			return nil
 		}
 	}
 }
+
+// updateFields describes the update for log entries
+func updateFields(c tele.Context, id string) []logger.Field {
+	fields := []logger.Field{
+		logger.String(logger.CorrelationIDKey, id),
+		logger.Int("update_id", c.Update().ID),
+	}
+
+	if sender := c.Sender(); sender != nil {
+		fields = append(fields, logger.Any("user_id", sender.ID), logger.String("username", sender.Username))
+	}
+
+	switch {
+	case c.Callback() != nil:
+		fields = append(fields, logger.String("type", "callback"), logger.String("data", c.Callback().Data))
+	case c.Message() != nil:
+		text := c.Message().Text
+		if len(text) > 100 {
+			text = text[:100] + "..."
+		}
+		fields = append(fields, logger.String("type", "message"), logger.String("text", text))
+	}
+
+	return fields
+}
@@ -19,11 +19,11 @@ type userBucket struct {
 
 // RateLimiter provides per-user rate limiting for Telegram bot updates.
 type RateLimiter struct {
-	maxRequests int           // max requests allowed within the window
-	window      time.Duration // sliding window duration
-	burstMax    int           // max requests in burst window (anti-spam)
-	burstWindow time.Duration // short burst window duration
-	adminIDs    []int64       // admin user IDs exempt from limiting
+	maxRequests int                     // max requests allowed within the window
+	window      time.Duration           // sliding window duration
+	burstMax    int                     // max requests in burst window (anti-spam)
+	burstWindow time.Duration           // short burst window duration
+	isAdmin     func(userID int64) bool // admins are exempt from limiting
 	log         logger.LoggerI
 
 	mu      sync.RWMutex
@@ -33,7 +33,8 @@ type RateLimiter struct {
 }
 
 // NewRateLimiter creates a rate limiter from config.
-func NewRateLimiter(cfg *config.Config, log logger.LoggerI) *RateLimiter {
+// isAdmin is asked on every update, so admins added at runtime are exempt too.
+func NewRateLimiter(cfg *config.Config, log logger.LoggerI, isAdmin func(userID int64) bool) *RateLimiter {
 	maxReq := cfg.Bot.RateLimitMaxRequests
 	if maxReq <= 0 {
 		maxReq = 30 // default: 30 requests
@@ -48,7 +49,7 @@ func NewRateLimiter(cfg *config.Config, log logger.LoggerI) *RateLimiter {
 		window:      window,
 		burstMax:    3,               // max 3 requests per burst window
 		burstWindow: 3 * time.Second, // 3-second burst window
-		adminIDs:    cfg.Bot.AdminIDs,
+		isAdmin:     isAdmin,
 		log:         log,
 		buckets:     make(map[int64]*userBucket),
 		stopCleanup: make(chan struct{}),
@@ -155,16 +156,6 @@ func (rl *RateLimiter) getBucket(userID int64) *userBucket {
 	return b
 }
 
-// isAdmin checks whether the given user ID is in the admin list.
-func (rl *RateLimiter) isAdmin(userID int64) bool {
-	for _, id := range rl.adminIDs {
-		if id == userID {
-			return true
-		}
-	}
-	return false
-}
-
 // cleanupLoop periodically removes buckets for users with no recent activity.
 func (rl *RateLimiter) cleanupLoop() {
 	ticker := time.NewTicker(5 * time.Minute)
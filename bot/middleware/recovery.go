@@ -1,20 +1,38 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
 	"runtime/debug"
+	"sync"
+	"time"
 
 	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
 
 	tele "gopkg.in/telebot.v4"
 )
 
+const (
+	// panicReportCooldown keeps a panic that repeats on every update from flooding the report chat
+	panicReportCooldown = time.Minute
+	// Report parts are trimmed to stay under Telegram's 4096 character limit
+	panicReportUpdateLimit = 1000
+	panicReportStackLimit  = 2500
+)
+
 // RecoveryMiddleware catches panics in handlers, logs the stack trace,
 // and returns an error instead of crashing the bot's polling goroutine.
 //
 // Without this, a panic in any handler kills the polling loop silently —
 // the container stays alive but the bot stops responding to messages.
-func RecoveryMiddleware(log logger.LoggerI) tele.MiddlewareFunc {
+//
+// The user gets MsgError, and reportChatID (0 disables) gets the update and a
+// trimmed stack, at most once a minute per panic value.
+func RecoveryMiddleware(log logger.LoggerI, reportChatID int64) tele.MiddlewareFunc {
+	reports := &panicReports{last: make(map[string]time.Time)}
+
 	return func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) (err error) {
 			defer func() {
@@ -42,6 +60,7 @@ func RecoveryMiddleware(log logger.LoggerI) tele.MiddlewareFunc {
 					}
 
 					log.Error(fmt.Sprintf("PANIC RECOVERED: %v", r),
+						logger.Any(logger.CorrelationIDKey, c.Get(logger.CorrelationIDKey)),
 						logger.Any("user_id", userID),
 						logger.Any("username", username),
 						logger.Any("callback_data", callbackData),
@@ -49,6 +68,11 @@ func RecoveryMiddleware(log logger.LoggerI) tele.MiddlewareFunc {
 						logger.Any("stack_trace", stack),
 					)
 
+					replyPanic(c, log)
+					if reportChatID != 0 && reports.allow(fmt.Sprint(r), time.Now()) {
+						reportPanic(c, log, reportChatID, r, stack)
+					}
+
 					// Return error so telebot's OnError handler can also process it
 					err = fmt.Errorf("panic recovered: %v", r)
 				}
@@ -58,3 +82,69 @@ func RecoveryMiddleware(log logger.LoggerI) tele.MiddlewareFunc {
 		}
 	}
 }
+
+// panicReports remembers when each panic value was last reported
+type panicReports struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// allow reports whether the panic may be reported now and records the report
+func (p *panicReports) allow(key string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for k, at := range p.last {
+		if now.Sub(at) >= panicReportCooldown {
+			delete(p.last, k)
+		}
+	}
+	if _, ok := p.last[key]; ok {
+		return false
+	}
+	p.last[key] = now
+	return true
+}
+
+// replyPanic tells the user the action failed: a toast for button taps, a message in private chats
+func replyPanic(c tele.Context, log logger.LoggerI) {
+	var err error
+	switch {
+	case c.Callback() != nil:
+		err = c.Respond(&tele.CallbackResponse{Text: messages.MsgError, ShowAlert: true})
+	case c.Chat() != nil && c.Chat().Type == tele.ChatPrivate:
+		err = c.Send(messages.MsgError)
+	}
+	if err != nil {
+		log.Error("Failed to tell the user about a recovered panic", logger.Error(err))
+	}
+}
+
+// reportPanic sends the panic, the update payload and the top of the stack to the report chat
+func reportPanic(c tele.Context, log logger.LoggerI, chatID int64, r any, stack string) {
+	update, err := json.MarshalIndent(c.Update(), "", " ")
+	if err != nil {
+		update = []byte(err.Error())
+	}
+
+	msg := fmt.Sprintf("🚨 <b>PANIC</b>: %s\n\n🔗 %v\n\n<b>Update:</b>\n<pre>%s</pre>\n\n<b>Stack:</b>\n<pre>%s</pre>",
+		html.EscapeString(truncate(fmt.Sprint(r), 200)),
+		c.Get(logger.CorrelationIDKey),
+		html.EscapeString(truncate(string(update), panicReportUpdateLimit)),
+		html.EscapeString(truncate(stack, panicReportStackLimit)),
+	)
+	if _, err := c.Bot().Send(tele.ChatID(chatID), msg, tele.ModeHTML); err != nil {
+		log.Error("Failed to send panic report", logger.Error(err), logger.Any("chat_id", chatID))
+	}
+}
+
+// truncate cuts s to at most limit bytes without splitting a UTF-8 character
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && s[limit]&0xC0 == 0x80 {
+		limit--
+	}
+	return s[:limit] + "…"
+}
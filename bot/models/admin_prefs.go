@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// NotificationCategory is a kind of admin notification that can be toggled
+type NotificationCategory string
+
+const (
+	NotifyNewJobs     NotificationCategory = "new_jobs"
+	NotifyPayments    NotificationCategory = "payments"
+	NotifyExpirations NotificationCategory = "expirations"
+	NotifyDailyDigest NotificationCategory = "daily_digest"
+	NotifyProfileEdit NotificationCategory = "profile_changes"
+)
+
+// NotificationCategories lists all categories in display order
+var NotificationCategories = []NotificationCategory{
+	NotifyNewJobs,
+	NotifyPayments,
+	NotifyExpirations,
+	NotifyDailyDigest,
+	NotifyProfileEdit,
+}
+
+// AdminNotificationPrefs holds which notifications an admin wants to receive
+type AdminNotificationPrefs struct {
+	AdminID     int64     `json:"admin_id"`
+	NewJobs     bool      `json:"new_jobs"`
+	Payments    bool      `json:"payments"`
+	Expirations bool      `json:"expirations"`
+	DailyDigest bool      `json:"daily_digest"`
+	ProfileEdit bool      `json:"profile_changes"` // name/phone edits by workers with upcoming jobs
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DefaultAdminNotificationPrefs returns the preferences used when an admin has not changed anything.
+// Expirations are off by default since they were not sent before preferences existed.
+func DefaultAdminNotificationPrefs(adminID int64) *AdminNotificationPrefs {
+	return &AdminNotificationPrefs{
+		AdminID:     adminID,
+		NewJobs:     true,
+		Payments:    true,
+		Expirations: false,
+		DailyDigest: true,
+		ProfileEdit: true,
+	}
+}
+
+// IsEnabled reports whether the given category is enabled
+func (p *AdminNotificationPrefs) IsEnabled(category NotificationCategory) bool {
+	switch category {
+	case NotifyNewJobs:
+		return p.NewJobs
+	case NotifyPayments:
+		return p.Payments
+	case NotifyExpirations:
+		return p.Expirations
+	case NotifyDailyDigest:
+		return p.DailyDigest
+	case NotifyProfileEdit:
+		return p.ProfileEdit
+	default:
+		return false
+	}
+}
+
+// Toggle flips the given category and reports whether it was a known category
+func (p *AdminNotificationPrefs) Toggle(category NotificationCategory) bool {
+	switch category {
+	case NotifyNewJobs:
+		p.NewJobs = !p.NewJobs
+	case NotifyPayments:
+		p.Payments = !p.Payments
+	case NotifyExpirations:
+		p.Expirations = !p.Expirations
+	case NotifyDailyDigest:
+		p.DailyDigest = !p.DailyDigest
+	case NotifyProfileEdit:
+		p.ProfileEdit = !p.ProfileEdit
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AuditAction identifies what an audit log entry records
+type AuditAction string
+
+const (
+	AuditActionAutoUnblock AuditAction = "auto_unblock" // Temporary block expired and was lifted by the unblock worker
+	AuditActionSlotRepair  AuditAction = "slot_repair"  // Job slot counters were recomputed from bookings by the slot check worker
+	AuditActionSettings    AuditAction = "settings"     // A super admin changed a runtime setting (admin list, payment card)
+	AuditActionUserBlock   AuditAction = "user_block"   // An admin blocked a user (fake receipt or permanent block)
+)
+
+// AuditEntry is one row of the audit log
+type AuditEntry struct {
+	ID           int64       `json:"id"`
+	ActorID      *int64      `json:"actor_id,omitempty"` // nil = system (background workers)
+	Action       AuditAction `json:"action"`
+	TargetUserID *int64      `json:"target_user_id,omitempty"`
+	Details      string      `json:"details"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// AdminActivity is one admin's work within a period (/activity report)
+type AdminActivity struct {
+	AdminID     int64
+	JobsCreated int
+	Approved    int           // Receipts approved
+	Rejected    int           // Receipts rejected, including rejections the user could resend
+	AvgReview   time.Duration // Average time from receipt to review; 0 when no review could be timed
+	Blocks      int           // Users blocked, from the audit log
+}
+
+// Reviewed is the number of receipts the admin decided on
+func (a *AdminActivity) Reviewed() int {
+	return a.Approved + a.Rejected
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// BackupRun is the outcome of one backup started with /backup. The last successful and the last
+// failed run are kept in bot_settings (SettingBackupLastSuccess, SettingBackupLastFailure).
+type BackupRun struct {
+	AdminID   int64         `json:"admin_id"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Size      int64         `json:"size"`     // Bytes; 0 when the backup system didn't report it
+	Location  string        `json:"location"` // Dump file, or what the backup webhook answered with
+	Error     string        `json:"error,omitempty"`
+}
+
+// BackupFile is a dump found in BACKUP_DIR
+type BackupFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// BackupStatus is what /backup_status shows
+type BackupStatus struct {
+	LastSuccess *BackupRun  // Last successful /backup; nil if none
+	LastFailure *BackupRun  // Last failed /backup; nil if none
+	NewestFile  *BackupFile // Newest dump in BACKUP_DIR, taken by /backup or the cron script; nil if none
+	DirError    string      // Why BACKUP_DIR couldn't be read
+	MaxAge      time.Duration
+}
+
+// LastSuccessAt returns when the newest known backup finished (zero if there is none)
+func (s *BackupStatus) LastSuccessAt() time.Time {
+	var at time.Time
+	if s.LastSuccess != nil {
+		at = s.LastSuccess.StartedAt.Add(s.LastSuccess.Duration)
+	}
+	if s.NewestFile != nil && s.NewestFile.ModTime.After(at) {
+		at = s.NewestFile.ModTime
+	}
+	return at
+}
+
+// Stale reports whether the newest backup is older than MaxAge, or there is none
+func (s *BackupStatus) Stale(now time.Time) bool {
+	at := s.LastSuccessAt()
+	return at.IsZero() || now.Sub(at) > s.MaxAge
+}
@@ -15,6 +15,21 @@ const (
 	BookingStatusRejected         BookingStatus = "REJECTED"          // Admin rejected payment
 	BookingStatusExpired          BookingStatus = "EXPIRED"           // 3-minute timer ran out
 	BookingStatusCancelledByUser  BookingStatus = "CANCELLED_BY_USER" // User cancelled before payment
+
+	// Admin cancelled the whole job; paid bookings get a refund record
+	BookingStatusCancelledByAdmin BookingStatus = "CANCELLED_BY_ADMIN"
+
+	// Receipt rejected but the slot is still held while the user resends a clearer one
+	BookingStatusPaymentRejectedRetryable BookingStatus = "PAYMENT_REJECTED_RETRYABLE"
+)
+
+// AttendanceStatus records whether a confirmed worker showed up on the work day
+type AttendanceStatus string
+
+const (
+	AttendanceUnmarked AttendanceStatus = ""         // Admin has not marked attendance yet
+	AttendanceAttended AttendanceStatus = "ATTENDED" // Worker came to work
+	AttendanceNoShow   AttendanceStatus = "NO_SHOW"  // Worker did not come
 )
 
 // JobBooking represents a user's booking for a job
@@ -27,9 +42,15 @@ type JobBooking struct {
 	Status BookingStatus `json:"status"`
 
 	// Payment tracking
-	PaymentReceiptFileID    string `json:"payment_receipt_file_id"`        // User's payment receipt file ID
-	PaymentReceiptMsgID     int64  `json:"payment_receipt_message_id"`     // User's payment receipt message ID
-	PaymentInstructionMsgID int64  `json:"payment_instruction_message_id"` // Bot's payment instruction message ID
+	PaymentReceiptFileID       string   `json:"payment_receipt_file_id"`                  // User's payment receipt file ID
+	PaymentReceiptMsgID        int64    `json:"payment_receipt_message_id"`               // User's payment receipt message ID
+	PaymentReceiptExtraFileIDs []string `json:"payment_receipt_extra_file_ids,omitempty"` // Rest of the photos when the receipt was an album
+	PaymentReceiptIsDocument   bool     `json:"payment_receipt_is_document,omitempty"`    // Receipt was sent as a file (PDF or image), not a photo
+	PaymentInstructionMsgID    int64    `json:"payment_instruction_message_id"`           // Bot's payment instruction message ID
+	ReceiptArchiveURL          string   `json:"receipt_archive_url,omitempty"`            // Copy of the approved receipt in object storage
+	ReceiptArchiveExtraURLs    []string `json:"receipt_archive_extra_urls,omitempty"`     // Copies of the rest of the album, in PaymentReceiptExtraFileIDs order
+	PaidAmount                 int      `json:"paid_amount,omitempty"`                    // Amount the user typed with the receipt (0 = not asked or skipped)
+	StatusMessageID            int64    `json:"status_message_id,omitempty"`              // User's status message edited on every change (BOT_BOOKING_TRACKER); set by SetStatusMessageID only
 
 	// Timing (CRITICAL for expiry)
 	ReservedAt         time.Time  `json:"reserved_at"`
@@ -41,6 +62,10 @@ type JobBooking struct {
 	ReviewedByAdminID *int64     `json:"reviewed_by_admin_id,omitempty"`
 	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
 	RejectionReason   string     `json:"rejection_reason,omitempty"`
+	PaymentRejections int        `json:"payment_rejections"` // Receipts rejected with resubmission allowed
+
+	// Attendance (marked by admin after the work day)
+	Attendance AttendanceStatus `json:"attendance,omitempty"`
 
 	// Idempotency (CRITICAL for Telegram retries)
 	IdempotencyKey string `json:"idempotency_key"`
@@ -65,6 +90,10 @@ func (s BookingStatus) Display() string {
 		return "⏰ Vaqt tugadi"
 	case BookingStatusCancelledByUser:
 		return "🚫 Bekor qilindi"
+	case BookingStatusPaymentRejectedRetryable:
+		return "🔁 Chek qayta kutilmoqda"
+	case BookingStatusCancelledByAdmin:
+		return "🚫 Ish bekor qilindi"
 	default:
 		return string(s)
 	}
@@ -75,21 +104,80 @@ func (s BookingStatus) IsValid() bool {
 	switch s {
 	case BookingStatusSlotReserved, BookingStatusPaymentSubmitted,
 		BookingStatusConfirmed, BookingStatusRejected,
-		BookingStatusExpired, BookingStatusCancelledByUser:
+		BookingStatusExpired, BookingStatusCancelledByUser,
+		BookingStatusPaymentRejectedRetryable, BookingStatusCancelledByAdmin:
 		return true
 	default:
 		return false
 	}
 }
 
+// bookingStatusTransitions lists the statuses each booking status may move to.
+// A finished booking only goes back to SLOT_RESERVED when the user books the job
+// again, because the booking row is reused by its idempotency key.
+var bookingStatusTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusSlotReserved: {
+		BookingStatusPaymentSubmitted, BookingStatusExpired, BookingStatusCancelledByUser, BookingStatusCancelledByAdmin,
+		BookingStatusConfirmed, // Manual bookings are confirmed without a receipt
+	},
+	BookingStatusPaymentSubmitted: {
+		BookingStatusConfirmed, BookingStatusRejected, BookingStatusPaymentRejectedRetryable, BookingStatusCancelledByAdmin,
+	},
+	BookingStatusPaymentRejectedRetryable: {
+		BookingStatusPaymentSubmitted, BookingStatusExpired, BookingStatusCancelledByUser, BookingStatusCancelledByAdmin,
+	},
+	BookingStatusConfirmed:        {BookingStatusCancelledByAdmin},
+	BookingStatusRejected:         {BookingStatusSlotReserved},
+	BookingStatusExpired:          {BookingStatusSlotReserved},
+	BookingStatusCancelledByUser:  {BookingStatusSlotReserved},
+	BookingStatusCancelledByAdmin: {BookingStatusSlotReserved},
+}
+
+// CanTransitionTo checks if a booking in this status may move to next.
+// The empty status stands for a booking that doesn't exist yet, which can only be reserved.
+func (s BookingStatus) CanTransitionTo(next BookingStatus) bool {
+	if s == "" {
+		return next == BookingStatusSlotReserved
+	}
+	for _, allowed := range bookingStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// HoldsReservedSlot reports whether a booking in this status counts towards the job's reserved_slots
+func (s BookingStatus) HoldsReservedSlot() bool {
+	return s == BookingStatusSlotReserved || s == BookingStatusPaymentSubmitted || s == BookingStatusPaymentRejectedRetryable
+}
+
 // IsExpired checks if the booking has expired based on current time
 func (b *JobBooking) IsExpired() bool {
-	return b.Status == BookingStatusSlotReserved && time.Now().After(b.ExpiresAt)
+	return b.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt checks if the booking has expired as of now
+func (b *JobBooking) IsExpiredAt(now time.Time) bool {
+	return b.AwaitsReceipt() && now.After(b.ExpiresAt)
+}
+
+// AwaitsReceipt reports whether the slot is held until the user sends a (new) payment receipt
+func (b *JobBooking) AwaitsReceipt() bool {
+	return b.Status == BookingStatusSlotReserved || b.Status == BookingStatusPaymentRejectedRetryable
 }
 
 // CanSubmitPayment checks if payment can be submitted for this booking
 func (b *JobBooking) CanSubmitPayment() bool {
-	return b.Status == BookingStatusSlotReserved && !b.IsExpired()
+	return b.AwaitsReceipt() && !b.IsExpired()
+}
+
+// ReceiptFileIDs returns every photo of the submitted receipt, the first one first
+func (b *JobBooking) ReceiptFileIDs() []string {
+	if b.PaymentReceiptFileID == "" {
+		return nil
+	}
+	return append([]string{b.PaymentReceiptFileID}, b.PaymentReceiptExtraFileIDs...)
 }
 
 // CanBeApproved checks if booking is waiting for admin approval
@@ -99,10 +187,15 @@ func (b *JobBooking) CanBeApproved() bool {
 
 // TimeRemaining returns duration until expiry (0 if expired)
 func (b *JobBooking) TimeRemaining() time.Duration {
-	if b.Status != BookingStatusSlotReserved {
+	return b.TimeRemainingAt(time.Now())
+}
+
+// TimeRemainingAt returns duration from now until expiry (0 if expired)
+func (b *JobBooking) TimeRemainingAt(now time.Time) time.Duration {
+	if !b.AwaitsReceipt() {
 		return 0
 	}
-	remaining := time.Until(b.ExpiresAt)
+	remaining := b.ExpiresAt.Sub(now)
 	if remaining < 0 {
 		return 0
 	}
@@ -113,3 +206,22 @@ func (b *JobBooking) TimeRemaining() time.Duration {
 func GenerateIdempotencyKey(userID, jobID int64) string {
 	return fmt.Sprintf("user_%d_job_%d", userID, jobID)
 }
+
+// BookingEvent is one status change in a booking's history (booking_events)
+type BookingEvent struct {
+	ID         int64         `json:"id"`
+	BookingID  int64         `json:"booking_id"`
+	FromStatus BookingStatus `json:"from_status"` // Empty for the first reservation
+	ToStatus   BookingStatus `json:"to_status"`
+	ActorID    int64         `json:"actor_id"`       // User or admin who made the change; 0 for the bot (e.g. expiry)
+	Note       string        `json:"note,omitempty"` // Rejection reason and the like
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// BookingPeriodStats aggregates booking outcomes within a time range (used by the daily digest)
+type BookingPeriodStats struct {
+	Confirmed int // Payments confirmed in the period
+	Revenue   int // Sum of service fees for confirmed payments
+	Rejected  int // Payments rejected in the period
+	Expired   int // Reservations that expired in the period
+}
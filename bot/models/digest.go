@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// DailyDigest is the morning summary sent to admins
+type DailyDigest struct {
+	Date           time.Time           // Local date the digest is for
+	ActiveJobs     int                 // Jobs currently accepting bookings
+	TodayJobs      []*Job              // Active/full jobs whose work date is today
+	SlotsRemaining int                 // Unconfirmed slots across today's jobs
+	Yesterday      *BookingPeriodStats // Booking outcomes for the previous day
+}
+
+// UnfilledTodayJobs returns today's jobs that still need confirmed workers
+func (d *DailyDigest) UnfilledTodayJobs() []*Job {
+	var jobs []*Job
+	for _, job := range d.TodayJobs {
+		if job.ConfirmedSlots < job.RequiredWorkers {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
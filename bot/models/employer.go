@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Employer represents a company or person that posts jobs through the admins
+type Employer struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Phone         string    `json:"phone"`
+	Notes         string    `json:"notes"`
+	Rating        int       `json:"rating"`                    // 1-5, 0 means not rated yet
+	ContactChatID int64     `json:"contact_chat_id,omitempty"` // Gets a copy of the payroll sheet when a job is closed (0 = none)
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// EmployerStats aggregates an employer's job history
+type EmployerStats struct {
+	TotalJobs        int `json:"total_jobs"`
+	CompletedJobs    int `json:"completed_jobs"`
+	ConfirmedWorkers int `json:"confirmed_workers"` // Confirmed bookings across all jobs
+	Attended         int `json:"attended"`
+	NoShows          int `json:"no_shows"`
+}
+
+// NoShowRate returns the share of marked workers who did not show up (0-100)
+func (s *EmployerStats) NoShowRate() int {
+	marked := s.Attended + s.NoShows
+	if marked == 0 {
+		return 0
+	}
+	return s.NoShows * 100 / marked
+}
+
+// RatingDisplay returns the rating as stars
+func (e *Employer) RatingDisplay() string {
+	if e.Rating <= 0 {
+		return "baholanmagan"
+	}
+	stars := ""
+	for i := 0; i < e.Rating; i++ {
+		stars += "⭐"
+	}
+	return stars
+}
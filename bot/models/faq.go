@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// FAQEntry is one question and answer shown in the user help browser
+type FAQEntry struct {
+	ID        int64     `json:"id"`
+	Category  string    `json:"category"`
+	Question  string    `json:"question"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FAQCategory groups the entries that share a category name
+type FAQCategory struct {
+	Name    string
+	Entries []*FAQEntry
+}
+
+// GroupFAQByCategory groups entries by category, keeping the order in which categories first appear
+func GroupFAQByCategory(entries []*FAQEntry) []*FAQCategory {
+	var categories []*FAQCategory
+	index := make(map[string]*FAQCategory)
+	for _, e := range entries {
+		category, ok := index[e.Category]
+		if !ok {
+			category = &FAQCategory{Name: e.Category}
+			index[e.Category] = category
+			categories = append(categories, category)
+		}
+		category.Entries = append(category.Entries, e)
+	}
+	return categories
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// JobFeedback is a confirmed worker's answers about a job after its work date.
+// The row is created when the prompt is sent; answers stay nil until the worker replies.
+type JobFeedback struct {
+	ID           int64     `json:"id"`
+	BookingID    int64     `json:"booking_id"` // One feedback per booking
+	JobID        int64     `json:"job_id"`
+	UserID       int64     `json:"user_id"`
+	EmployerID   int64     `json:"employer_id"`             // 0 if the job had no employer
+	PayCorrect   *bool     `json:"pay_correct,omitempty"`   // Ish haqi to'g'ri to'landimi?
+	ConditionsOK *bool     `json:"conditions_ok,omitempty"` // Ish sharoiti yaxshimidi?
+	CreatedAt    time.Time `json:"created_at"`              // When the prompt was sent
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// FeedbackSummary aggregates worker feedback for an employer
+type FeedbackSummary struct {
+	Responses       int `json:"responses"`        // Feedbacks with at least one answer
+	PayIssues       int `json:"pay_issues"`       // "Pay was not correct" answers
+	ConditionIssues int `json:"condition_issues"` // "Conditions were bad" answers
+}
+
+// Employers are flagged once enough workers answered and a large share complained
+const (
+	feedbackFlagMinResponses = 3
+	feedbackFlagIssuePercent = 30
+)
+
+// IsFlagged reports whether the employer gets too many complaints
+func (s *FeedbackSummary) IsFlagged() bool {
+	if s.Responses < feedbackFlagMinResponses {
+		return false
+	}
+	return s.PayIssues*100/s.Responses >= feedbackFlagIssuePercent ||
+		s.ConditionIssues*100/s.Responses >= feedbackFlagIssuePercent
+}
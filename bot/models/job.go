@@ -15,8 +15,10 @@ const (
 
 // Job represents a job posting with race-safe slot management
 type Job struct {
-	ID          int64 `json:"id"`
-	OrderNumber int   `json:"order_number"`
+	ID          int64  `json:"id"`
+	OrderNumber int    `json:"order_number"`
+	OrderDay    string `json:"order_day"`  // Creation date in the app timezone (YYYY-MM-DD)
+	DayNumber   int    `json:"day_number"` // Sequence within OrderDay, starting at 1 (0 for jobs created before it existed)
 
 	// Job details
 	Salary         string `json:"salary"`          // Ish haqqi
@@ -29,6 +31,18 @@ type Job struct {
 	AdditionalInfo string `json:"additional_info"` // Qo'shimcha
 	WorkDate       string `json:"work_date"`       // Ish kuni
 	EmployerPhone  string `json:"employer_phone"`  // Ish beruvchining telefon raqami (faqat tasdiqlangan foydalanuvchilar uchun)
+	EmployerID     int64  `json:"employer_id"`     // Ish beruvchi (0 = bog'lanmagan)
+
+	// Worker requirements checked against the registered profile at booking time (0/"" = no requirement)
+	MinAge    int    `json:"min_age"`
+	MaxAge    int    `json:"max_age"`
+	MinHeight int    `json:"min_height"` // cm
+	MinWeight int    `json:"min_weight"` // kg
+	Gender    Gender `json:"gender"`     // Faqat erkaklar / faqat ayollar
+
+	// Caption variants tested against each other in the channel (models.CaptionVariant; "" = none)
+	CaptionA string `json:"caption_a"`
+	CaptionB string `json:"caption_b"`
 
 	// Slot management (CRITICAL for race conditions)
 	RequiredWorkers int `json:"required_workers"` // Total slots needed
@@ -36,12 +50,14 @@ type Job struct {
 	ConfirmedSlots  int `json:"confirmed_slots"`  // Admin-approved bookings
 
 	// Status and metadata
-	Status           JobStatus `json:"status"`
-	ChannelMessageID int64     `json:"channel_message_id"`
-	AdminMessageID   int64     `json:"admin_message_id"` // Admin job detail message ID for single-message enforcement
-	CreatedByAdminID int64     `json:"created_by_admin_id"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	Status                   JobStatus `json:"status"`
+	ChannelMessageID         int64     `json:"channel_message_id"`
+	ChannelLocationMessageID int64     `json:"channel_location_message_id"` // Location reply to the channel post; 0 when none
+	AdminMessageID           int64     `json:"admin_message_id"`            // Admin job detail message ID for single-message enforcement
+	CreatedByAdminID         int64     `json:"created_by_admin_id"`
+	Version                  int       `json:"version"` // Bumped on every edit/status change; Update compares it
+	CreatedAt                time.Time `json:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at"`
 }
 
 // Backwards compatibility aliases
@@ -86,6 +102,136 @@ func (s JobStatus) IsValid() bool {
 	}
 }
 
+// jobStatusTransitions lists the statuses each status may move to. CANCELLED is
+// final: its bookings were already cancelled and refunded.
+var jobStatusTransitions = map[JobStatus][]JobStatus{
+	JobStatusDraft:     {JobStatusActive, JobStatusCancelled},
+	JobStatusActive:    {JobStatusDraft, JobStatusFull, JobStatusCompleted, JobStatusCancelled},
+	JobStatusFull:      {JobStatusActive, JobStatusCompleted, JobStatusCancelled},
+	JobStatusCompleted: {JobStatusActive},
+	JobStatusCancelled: nil,
+}
+
+// JobStatusSources returns the statuses jobStatusTransitions lets a job move to next from,
+// in the order the statuses are declared; storage writes check the current status against them
+func JobStatusSources(next JobStatus) []JobStatus {
+	var sources []JobStatus
+	for _, from := range []JobStatus{JobStatusDraft, JobStatusActive, JobStatusFull, JobStatusCompleted, JobStatusCancelled} {
+		if from.CanTransitionTo(next) {
+			sources = append(sources, from)
+		}
+	}
+	return sources
+}
+
+// CanTransitionTo checks if the status may move to next
+func (s JobStatus) CanTransitionTo(next JobStatus) bool {
+	for _, allowed := range jobStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo checks if the job may move to next given both the status
+// graph and its slots: only an unpublished job without bookings goes back to
+// DRAFT, and a job whose slots are all confirmed cannot reopen.
+func (j *Job) CanTransitionTo(next JobStatus) bool {
+	if !j.Status.CanTransitionTo(next) {
+		return false
+	}
+	switch next {
+	case JobStatusDraft:
+		return j.ChannelMessageID == 0 && j.ReservedSlots == 0 && j.ConfirmedSlots == 0
+	case JobStatusActive:
+		return !j.IsCompletelyFull()
+	}
+	return true
+}
+
+// NextStatuses returns the statuses the job may move to right now, in the
+// order of jobStatusTransitions
+func (j *Job) NextStatuses() []JobStatus {
+	var next []JobStatus
+	for _, s := range jobStatusTransitions[j.Status] {
+		if j.CanTransitionTo(s) {
+			next = append(next, s)
+		}
+	}
+	return next
+}
+
+// SlotStatus returns the status the slot counters call for: an ACTIVE job
+// with every slot confirmed becomes FULL and a FULL one with an unconfirmed
+// slot reopens. Other statuses are never changed by slot math.
+func (j *Job) SlotStatus() JobStatus {
+	switch {
+	case j.Status == JobStatusActive && j.IsCompletelyFull():
+		return JobStatusFull
+	case j.Status == JobStatusFull && !j.IsCompletelyFull():
+		return JobStatusActive
+	}
+	return j.Status
+}
+
+// Clone returns a DRAFT copy of the job's details for another day: no ID or
+// order number, zeroed slots and no channel/admin messages.
+func (j *Job) Clone() *Job {
+	return &Job{
+		Salary:          j.Salary,
+		Food:            j.Food,
+		WorkTime:        j.WorkTime,
+		Address:         j.Address,
+		Location:        j.Location,
+		ServiceFee:      j.ServiceFee,
+		Buses:           j.Buses,
+		AdditionalInfo:  j.AdditionalInfo,
+		WorkDate:        j.WorkDate,
+		EmployerPhone:   j.EmployerPhone,
+		EmployerID:      j.EmployerID,
+		MinAge:          j.MinAge,
+		MaxAge:          j.MaxAge,
+		MinHeight:       j.MinHeight,
+		MinWeight:       j.MinWeight,
+		Gender:          j.Gender,
+		CaptionA:        j.CaptionA,
+		CaptionB:        j.CaptionB,
+		RequiredWorkers: j.RequiredWorkers,
+		Status:          JobStatusDraft,
+	}
+}
+
+// Job details confirmed workers rely on to show up at the right place and time
+const (
+	JobFieldWorkDate = "work_date"
+	JobFieldWorkTime = "work_time"
+	JobFieldAddress  = "address"
+	JobFieldLocation = "location"
+)
+
+// JobFieldChange is one changed job detail
+type JobFieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// CriticalChanges lists the details confirmed workers rely on that differ in updated
+func (j *Job) CriticalChanges(updated *Job) []JobFieldChange {
+	var changes []JobFieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, JobFieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	add(JobFieldWorkDate, j.WorkDate, updated.WorkDate)
+	add(JobFieldWorkTime, j.WorkTime, updated.WorkTime)
+	add(JobFieldAddress, j.Address, updated.Address)
+	add(JobFieldLocation, j.Location, updated.Location)
+	return changes
+}
+
 // AvailableSlots returns how many slots are still available for reservation
 func (j *Job) AvailableSlots() int {
 	occupied := j.ReservedSlots + j.ConfirmedSlots
@@ -111,3 +257,46 @@ func (j *Job) IsCompletelyFull() bool {
 func (j *Job) IsActive() bool {
 	return j.Status == JobStatusActive && !j.IsFull()
 }
+
+// SlotDrift is a job whose slot counters disagree with its bookings
+type SlotDrift struct {
+	JobID           int64
+	Job             *Job // Filled in after the repair for display; nil if it could not be loaded
+	Reserved        int  // reserved_slots stored on the job
+	Confirmed       int  // confirmed_slots stored on the job
+	ActualReserved  int  // bookings holding a slot (see BookingStatus.HoldsReservedSlot)
+	ActualConfirmed int  // CONFIRMED bookings
+}
+
+// Size returns how many slots the counters are off by in total
+func (d *SlotDrift) Size() int {
+	return abs(d.Reserved-d.ActualReserved) + abs(d.Confirmed-d.ActualConfirmed)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// JobBump is a repost of an under-subscribed job to the channel shortly before its work date
+type JobBump struct {
+	ID        int64     `json:"id"`
+	JobID     int64     `json:"job_id"`
+	MessageID int64     `json:"message_id"` // Channel message of the repost
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JobFunnel follows a job's audience from the channel post to the work day
+type JobFunnel struct {
+	LinkOpens         int // /start job_<id> deep-link opens
+	LinkUsers         int // Distinct users who opened the deep link
+	Bookings          int // Bookings created (a slot was reserved)
+	PaymentsSubmitted int // Bookings that sent a payment receipt
+	Approved          int // Bookings whose payment was approved
+	Attended          int // Approved workers marked as attended
+	NoShows           int // Approved workers marked as no-show
+
+	Captions []*JobCaptionStats // Starts per caption variant, in variant order; empty when no link carried one
+}
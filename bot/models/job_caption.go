@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CaptionVariant names one of a job's two channel captions
+type CaptionVariant string
+
+const (
+	CaptionVariantA CaptionVariant = "a"
+	CaptionVariantB CaptionVariant = "b"
+)
+
+// JobPayload prefixes the /start payload of a job's signup link: job_<id>, or job_<id>_<variant>
+// when the link belongs to a caption variant
+const JobPayload = "job_"
+
+// IsValid checks if the variant is A or B
+func (v CaptionVariant) IsValid() bool {
+	return v == CaptionVariantA || v == CaptionVariantB
+}
+
+// Display returns the variant as admins see it ("A" / "B")
+func (v CaptionVariant) Display() string {
+	return strings.ToUpper(string(v))
+}
+
+// HasCaptionTest reports whether both captions are set, so the channel posts alternate them
+// and the signup links are counted per variant
+func (j *Job) HasCaptionTest() bool {
+	return j.CaptionA != "" && j.CaptionB != ""
+}
+
+// Caption returns the caption of the variant ("" when it isn't set)
+func (j *Job) Caption(v CaptionVariant) string {
+	if v == CaptionVariantB {
+		return j.CaptionB
+	}
+	return j.CaptionA
+}
+
+// BumpCaptionVariant is the caption of the job's n-th repost (1-based). The channel post shows
+// caption A, so the reposts start with B and then alternate; without a test they all use A.
+func (j *Job) BumpCaptionVariant(n int) CaptionVariant {
+	if j.HasCaptionTest() && n%2 == 1 {
+		return CaptionVariantB
+	}
+	return CaptionVariantA
+}
+
+// SignupPayload returns the /start payload of the job's signup link; the variant is only
+// added while the captions are being tested
+func (j *Job) SignupPayload(v CaptionVariant) string {
+	if !j.HasCaptionTest() || !v.IsValid() {
+		return fmt.Sprintf("%s%d", JobPayload, j.ID)
+	}
+	return fmt.Sprintf("%s%d_%s", JobPayload, j.ID, v)
+}
+
+// ParseJobPayload reads a /start payload made by SignupPayload. The variant is "" for links
+// without one; an unknown variant is dropped rather than failing the link.
+func ParseJobPayload(payload string) (jobID int64, variant CaptionVariant, ok bool) {
+	rest, found := strings.CutPrefix(payload, JobPayload)
+	if !found {
+		return 0, "", false
+	}
+	idStr, v, _ := strings.Cut(rest, "_")
+	jobID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	if variant = CaptionVariant(v); !variant.IsValid() {
+		variant = ""
+	}
+	return jobID, variant, true
+}
+
+// JobCaptionStats counts the deep-link starts of one caption variant. A user is credited to
+// the variant whose link they opened first, so the bookings of both variants don't overlap.
+type JobCaptionStats struct {
+	Variant   CaptionVariant
+	LinkOpens int // Opens of this variant's link
+	LinkUsers int // Users whose first opened link was this variant's
+	Bookings  int // Of those users, how many booked the job
+	Approved  int // Of those users, how many had their payment approved
+}
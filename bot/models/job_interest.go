@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// JobInterest records that a user opened a job's signup link before registering
+type JobInterest struct {
+	ID        int64      `json:"id"`
+	JobID     int64      `json:"job_id"`
+	UserID    int64      `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	NudgedAt  *time.Time `json:"nudged_at,omitempty"` // When the finish-registration reminder went out
+}
+
+// JobInterestCount is a job whose signup link was opened by users who still haven't registered
+type JobInterestCount struct {
+	JobID        int64
+	Job          *Job // Filled in for display; nil if it could not be loaded
+	Unregistered int  // Interested users without an active registration
+	Nudged       int  // Of those, users already reminded
+}
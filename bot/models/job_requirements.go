@@ -0,0 +1,34 @@
+package models
+
+// Requirement is a worker requirement a job can set on the registered profile
+type Requirement string
+
+const (
+	RequirementAge    Requirement = "age"
+	RequirementHeight Requirement = "height"
+	RequirementWeight Requirement = "weight"
+	RequirementGender Requirement = "gender"
+)
+
+// HasRequirements reports whether the job sets any worker requirement
+func (j *Job) HasRequirements() bool {
+	return j.MinAge > 0 || j.MaxAge > 0 || j.MinHeight > 0 || j.MinWeight > 0 || j.Gender != GenderUnknown
+}
+
+// UnmetRequirements lists the job's requirements the worker's profile doesn't meet, in display order
+func (j *Job) UnmetRequirements(u *RegisteredUser) []Requirement {
+	var unmet []Requirement
+	if j.Gender != GenderUnknown && u.Gender != j.Gender {
+		unmet = append(unmet, RequirementGender)
+	}
+	if (j.MinAge > 0 && u.Age < j.MinAge) || (j.MaxAge > 0 && u.Age > j.MaxAge) {
+		unmet = append(unmet, RequirementAge)
+	}
+	if j.MinHeight > 0 && u.Height < j.MinHeight {
+		unmet = append(unmet, RequirementHeight)
+	}
+	if j.MinWeight > 0 && u.Weight < j.MinWeight {
+		unmet = append(unmet, RequirementWeight)
+	}
+	return unmet
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// workTimeRange finds "08:00-18:00" style ranges in a job's free-text work time ("8.00 – 17.30" and "7:00 dan 19:00 gacha" too)
+var workTimeRange = regexp.MustCompile(`(\d{1,2})[:.](\d{2})\s*(?:[-–—]|dan)\s*(\d{1,2})[:.](\d{2})`)
+
+// WorkHours returns the length of the job's shift parsed from WorkTime.
+// A shift ending before it starts runs past midnight. ok is false when WorkTime has no time range.
+func (j *Job) WorkHours() (hours time.Duration, ok bool) {
+	m := workTimeRange.FindStringSubmatch(j.WorkTime)
+	if m == nil {
+		return 0, false
+	}
+
+	clock := func(h, min string) (time.Duration, bool) {
+		hh, _ := strconv.Atoi(h)
+		mm, _ := strconv.Atoi(min)
+		if hh > 24 || mm > 59 {
+			return 0, false
+		}
+		return time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute, true
+	}
+	start, ok1 := clock(m[1], m[2])
+	end, ok2 := clock(m[3], m[4])
+	if !ok1 || !ok2 || start == end {
+		return 0, false
+	}
+	if end < start {
+		end += 24 * time.Hour
+	}
+	return end - start, true
+}
+
+// PayrollRow is one confirmed worker on a job's payroll sheet
+type PayrollRow struct {
+	BookingID  int64
+	UserID     int64
+	FullName   string
+	Phone      string
+	Attendance AttendanceStatus
+}
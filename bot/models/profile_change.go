@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Profile fields whose edits are recorded in profile_changes
+const (
+	ProfileFieldFullName = "full_name"
+	ProfileFieldPhone    = "phone"
+)
+
+// ProfileChange records one edit a registered user made to their profile
+type ProfileChange struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// PublicOffer is one published version of the user agreement (oferta)
+type PublicOffer struct {
+	ID               int64     `json:"id"`
+	Version          int       `json:"version"` // 1, 2, ... assigned on publish
+	Content          string    `json:"content"`
+	CreatedByAdminID int64     `json:"created_by_admin_id"` // 0 for the version seeded by the migration
+	PublishedAt      time.Time `json:"published_at"`
+}
+
+// OfferAcceptance is a consent log entry: which offer version a user accepted and when
+type OfferAcceptance struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Version    int       `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
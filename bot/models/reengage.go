@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ReengageCampaign is one round of open-job digests sent to registered users who stopped booking
+type ReengageCampaign struct {
+	ID           int64     `json:"id"`
+	StartedBy    int64     `json:"started_by"` // Admin who ran /reengage; 0 for the scheduled run
+	InactiveDays int       `json:"inactive_days"`
+	Jobs         int       `json:"jobs"`       // Open jobs listed in the digest
+	Recipients   int       `json:"recipients"` // Users selected
+	Sent         int       `json:"sent"`
+	Failed       int       `json:"failed"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Results since the message, filled in by ReengageRepoI.GetRecent
+	Opened int `json:"opened"` // Recipients who opened a job's signup link
+	Booked int `json:"booked"` // Recipients who booked a job
+}
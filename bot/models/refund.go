@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// RefundStatus is the state of a booking fee owed back to a worker
+type RefundStatus string
+
+const (
+	RefundPending RefundStatus = "PENDING" // Owed; an admin still has to send the money
+	RefundPaid    RefundStatus = "PAID"    // An admin sent the money back
+)
+
+// Refund records a service fee to return after an admin cancelled a job the worker had paid for
+type Refund struct {
+	ID        int64        `json:"id"`
+	BookingID int64        `json:"booking_id"`
+	JobID     int64        `json:"job_id"`
+	UserID    int64        `json:"user_id"`
+	Amount    int          `json:"amount"` // Service fee paid for the booking, in so'm
+	Status    RefundStatus `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	PaidAt    *time.Time   `json:"paid_at,omitempty"`
+	PaidBy    int64        `json:"paid_by,omitempty"` // Admin who marked it paid
+}
+
+// JobCancellation is the outcome of cancelling a job: the bookings it released and the refunds it opened
+type JobCancellation struct {
+	Job      *Job
+	Bookings []*JobBooking // Bookings moved to CANCELLED_BY_ADMIN
+	Refunds  []*Refund
+}
@@ -14,6 +14,8 @@ const (
 	RegStatePhone         RegistrationState = "reg_phone"
 	RegStateAge           RegistrationState = "reg_age"
 	RegStateBodyParams    RegistrationState = "reg_body_params"
+	RegStateGender        RegistrationState = "reg_gender"
+	RegStateCity          RegistrationState = "reg_city"
 	RegStatePassportPhoto RegistrationState = "reg_passport_photo"
 	RegStateConfirm       RegistrationState = "reg_confirm"
 	RegStateDeclined      RegistrationState = "reg_declined"
@@ -31,6 +33,8 @@ type RegistrationDraft struct {
 	Weight          int               `json:"weight" db:"weight"`
 	Height          int               `json:"height" db:"height"`
 	PassportPhotoID string            `json:"passport_photo_id" db:"passport_photo_id"`
+	City            string            `json:"city" db:"city"`
+	Gender          Gender            `json:"gender" db:"gender"`
 	PendingJobID    *int64            `json:"pending_job_id" db:"pending_job_id"` // Job to redirect to after registration
 	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time         `json:"updated_at" db:"updated_at"`
@@ -48,8 +52,17 @@ func NewRegistrationDraft(userID int64) *RegistrationDraft {
 	}
 }
 
-// IsComplete checks if all required fields are filled
-func (d *RegistrationDraft) IsComplete() bool {
+// IsComplete checks if all required fields are filled, including the optional steps that are enabled
+func (d *RegistrationDraft) IsComplete(fields RegistrationFields) bool {
+	if fields.Gender && d.Gender == GenderUnknown {
+		return false
+	}
+	if fields.City && d.City == "" {
+		return false
+	}
+	if fields.PassportPhoto && d.PassportPhotoID == "" {
+		return false
+	}
 	return d.FullName != "" &&
 		d.Phone != "" &&
 		d.Age > 0 &&
@@ -57,6 +70,39 @@ func (d *RegistrationDraft) IsComplete() bool {
 		d.Height > 0
 }
 
+// RegistrationFields lists which optional registration steps are enabled
+type RegistrationFields struct {
+	Gender        bool
+	City          bool
+	PassportPhoto bool
+}
+
+// RegistrationSteps returns the data-entry states in the order they are asked
+func RegistrationSteps(fields RegistrationFields) []RegistrationState {
+	steps := []RegistrationState{RegStateFullName, RegStatePhone, RegStateAge, RegStateBodyParams}
+	if fields.Gender {
+		steps = append(steps, RegStateGender)
+	}
+	if fields.City {
+		steps = append(steps, RegStateCity)
+	}
+	if fields.PassportPhoto {
+		steps = append(steps, RegStatePassportPhoto)
+	}
+	return steps
+}
+
+// NextRegistrationStep returns the step after current, or RegStateConfirm after the last one
+func NextRegistrationStep(fields RegistrationFields, current RegistrationState) RegistrationState {
+	steps := RegistrationSteps(fields)
+	for i, step := range steps {
+		if step == current && i+1 < len(steps) {
+			return steps[i+1]
+		}
+	}
+	return RegStateConfirm
+}
+
 // RegisteredUser represents a fully registered user with all required data
 type RegisteredUser struct {
 	ID              int64     `json:"id" db:"id"`
@@ -67,11 +113,34 @@ type RegisteredUser struct {
 	Weight          int       `json:"weight" db:"weight"`
 	Height          int       `json:"height" db:"height"`
 	PassportPhotoID string    `json:"passport_photo_id" db:"passport_photo_id"`
+	City            string    `json:"city" db:"city"`
+	Gender          Gender    `json:"gender" db:"gender"`
 	IsActive        bool      `json:"is_active" db:"is_active"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Gender is the worker's gender; GenderUnknown when the profile doesn't say
+type Gender string
+
+const (
+	GenderUnknown Gender = ""
+	GenderMale    Gender = "male"
+	GenderFemale  Gender = "female"
+)
+
+// Display returns the display text for the gender
+func (g Gender) Display() string {
+	switch g {
+	case GenderMale:
+		return "Erkak"
+	case GenderFemale:
+		return "Ayol"
+	default:
+		return "Ko'rsatilmagan"
+	}
+}
+
 // EditField represents which field the user wants to edit during confirmation
 type EditField string
 
@@ -80,6 +149,9 @@ const (
 	EditFieldPhone      EditField = "phone"
 	EditFieldAge        EditField = "age"
 	EditFieldBodyParams EditField = "body_params"
+	EditFieldGender     EditField = "gender"
+	EditFieldCity       EditField = "city"
+	EditFieldPassport   EditField = "passport_photo"
 )
 
 // RegistrationStateFromString converts a string to RegistrationState
@@ -95,6 +167,10 @@ func RegistrationStateFromString(s string) RegistrationState {
 		return RegStateAge
 	case "reg_body_params":
 		return RegStateBodyParams
+	case "reg_gender":
+		return RegStateGender
+	case "reg_city":
+		return RegStateCity
 	case "reg_passport_photo":
 		return RegStatePassportPhoto
 	case "reg_confirm":
@@ -116,6 +192,8 @@ func IsRegistrationState(state UserState) bool {
 		regState == RegStatePhone ||
 		regState == RegStateAge ||
 		regState == RegStateBodyParams ||
+		regState == RegStateGender ||
+		regState == RegStateCity ||
 		regState == RegStatePassportPhoto ||
 		regState == RegStateConfirm
 }
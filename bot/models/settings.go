@@ -0,0 +1,19 @@
+package models
+
+// Keys of the bot_settings table. A missing key means the env value is in effect.
+const (
+	SettingAdminIDs       = "admin_ids"        // Comma-separated admin user IDs (BOT_ADMIN_IDS)
+	SettingCardNumber     = "card_number"      // Payment card number (CARD_NUMBER)
+	SettingCardHolderName = "card_holder_name" // Payment card holder (CARD_HOLDER_NAME)
+
+	// Kept by the bot itself, not editable from "⚙️ Sozlamalar"
+	SettingChannelIndexMessageID = "channel_index_message_id" // Pinned "Bugungi ishlar" message in the channel
+	SettingBackupLastSuccess     = "backup_last_success"      // JSON BackupRun of the last successful /backup
+	SettingBackupLastFailure     = "backup_last_failure"      // JSON BackupRun of the last failed /backup
+)
+
+// PaymentCard is the card workers pay the booking fee to
+type PaymentCard struct {
+	Number     string
+	HolderName string
+}
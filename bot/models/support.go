@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// SupportThreadStatus is the state of a user's support thread
+type SupportThreadStatus string
+
+const (
+	SupportThreadOpen   SupportThreadStatus = "OPEN"
+	SupportThreadClosed SupportThreadStatus = "CLOSED"
+)
+
+// SupportThread is a user's conversation with the admins, relayed by the bot
+type SupportThread struct {
+	ID        int64               `json:"id"`
+	UserID    int64               `json:"user_id"`
+	Status    SupportThreadStatus `json:"status"`
+	CreatedAt time.Time           `json:"created_at"`
+	ClosedAt  *time.Time          `json:"closed_at,omitempty"`
+	ClosedBy  int64               `json:"closed_by,omitempty"` // Admin or the user who closed it
+}
+
+// IsOpen reports whether messages are still relayed in the thread
+func (t *SupportThread) IsOpen() bool {
+	return t.Status == SupportThreadOpen
+}
@@ -4,13 +4,17 @@ import "time"
 
 // User represents a Telegram user in the system
 type User struct {
-	ID        int64     `json:"id"`
-	Username  string    `json:"username"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	State     UserState `json:"state"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             int64     `json:"id"`
+	Username       string    `json:"username"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	State          UserState `json:"state"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	StateUpdatedAt time.Time `json:"state_updated_at"` // When State last changed; flows expire from here
+
+	// MarketingOptOut stops campaigns and other broadcasts; transactional messages still arrive
+	MarketingOptOut bool `json:"marketing_opt_out"`
 }
 
 // UserViolation represents a user violation record
@@ -23,6 +27,13 @@ type UserViolation struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+// ViolationDetail is a violation with the job and receipt it refers to, for admin views
+type ViolationDetail struct {
+	*UserViolation
+	JobNumber     string // formatted job number, "" when the booking or job no longer exists
+	ReceiptFileID string // Payment receipt photo of the booking ("" if none)
+}
+
 // BlockedUser represents a blocked user
 type BlockedUser struct {
 	UserID           int64      `json:"user_id"`
@@ -34,6 +45,11 @@ type BlockedUser struct {
 	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
+// IsPermanent reports whether the block has no end date
+func (b *BlockedUser) IsPermanent() bool {
+	return b.BlockedUntil == nil
+}
+
 // UserState represents the current state of a user in the conversation flow
 type UserState string
 
@@ -54,39 +70,70 @@ const (
 	StateCreatingJobIshKuni       UserState = "creating_job_ish_kuni"
 	StateCreatingJobKerakli       UserState = "creating_job_kerakli"
 	StateCreatingJobEmployerPhone UserState = "creating_job_employer_phone"
+	StateCreatingJobEmployerName  UserState = "creating_job_employer_name"
+	StateCreatingJobReview        UserState = "creating_job_review" // All fields entered, waiting for save or per-field edits
 
 	// Job editing states
-	StateEditingJobIshHaqqi      UserState = "editing_job_ish_haqqi"
-	StateEditingJobOvqat         UserState = "editing_job_ovqat"
-	StateEditingJobVaqt          UserState = "editing_job_vaqt"
-	StateEditingJobManzil        UserState = "editing_job_manzil"
-	StateEditingJobLocation      UserState = "editing_job_location"
-	StateEditingJobXizmatHaqqi   UserState = "editing_job_xizmat_haqqi"
-	StateEditingJobAvtobuslar    UserState = "editing_job_avtobuslar"
-	StateEditingJobIshTavsifi    UserState = "editing_job_ish_tavsifi"
-	StateEditingJobIshKuni       UserState = "editing_job_ish_kuni"
-	StateEditingJobKerakli       UserState = "editing_job_kerakli"
-	StateEditingJobConfirmed     UserState = "editing_job_confirmed"
-	StateEditingJobEmployerPhone UserState = "editing_job_employer_phone"
+	StateEditingJobIshHaqqi        UserState = "editing_job_ish_haqqi"
+	StateEditingJobOvqat           UserState = "editing_job_ovqat"
+	StateEditingJobVaqt            UserState = "editing_job_vaqt"
+	StateEditingJobManzil          UserState = "editing_job_manzil"
+	StateEditingJobLocation        UserState = "editing_job_location"
+	StateEditingJobXizmatHaqqi     UserState = "editing_job_xizmat_haqqi"
+	StateEditingJobAvtobuslar      UserState = "editing_job_avtobuslar"
+	StateEditingJobIshTavsifi      UserState = "editing_job_ish_tavsifi"
+	StateEditingJobIshKuni         UserState = "editing_job_ish_kuni"
+	StateEditingJobKerakli         UserState = "editing_job_kerakli"
+	StateEditingJobConfirmed       UserState = "editing_job_confirmed"
+	StateEditingJobEmployerPhone   UserState = "editing_job_employer_phone"
+	StateEditingJobTalablar        UserState = "editing_job_talablar"
+	StateEditingJobCaptionA        UserState = "editing_job_caption_a"
+	StateEditingJobCaptionB        UserState = "editing_job_caption_b"
+	StateEditingJobEmployerNotes   UserState = "editing_job_employer_notes"
+	StateEditingJobEmployerContact UserState = "editing_job_employer_contact"
+	StateEditingJobAddWorker       UserState = "editing_job_add_worker"
+
+	// FAQ editing states (admin only)
+	StateFAQAddCategory  UserState = "faq_add_category"
+	StateFAQAddQuestion  UserState = "faq_add_question"
+	StateFAQAddAnswer    UserState = "faq_add_answer"
+	StateFAQEditCategory UserState = "faq_edit_category"
+	StateFAQEditQuestion UserState = "faq_edit_question"
+	StateFAQEditAnswer   UserState = "faq_edit_answer"
+
+	// Public offer editing state (admin only)
+	StateEditingOffer UserState = "offer_editing"
+
+	// Runtime settings states (super admin only)
+	StateSettingsAdminIDs UserState = "settings_admin_ids"
+	StateSettingsCard     UserState = "settings_card"
 
 	// Profile editing states
 	StateEditingProfileFullName   UserState = "editing_profile_full_name"
 	StateEditingProfilePhone      UserState = "editing_profile_phone"
 	StateEditingProfileAge        UserState = "editing_profile_age"
 	StateEditingProfileBodyParams UserState = "editing_profile_body_params"
+	StateEditingProfileGender     UserState = "editing_profile_gender"
+
+	// Support state: the next message opens a support thread
+	StateSupportMessage UserState = "support_message"
+
+	// Payment state: a receipt is held until the user types the transferred amount
+	StatePaymentAmount UserState = "payment_amount"
 )
 
 // NewUser creates a new User instance
 func NewUser(id int64, username, firstName, lastName string) *User {
 	now := time.Now()
 	return &User{
-		ID:        id,
-		Username:  username,
-		FirstName: firstName,
-		LastName:  lastName,
-		State:     StateIdle,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:             id,
+		Username:       username,
+		FirstName:      firstName,
+		LastName:       lastName,
+		State:          StateIdle,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		StateUpdatedAt: now,
 	}
 }
 
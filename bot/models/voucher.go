@@ -0,0 +1,65 @@
+package models
+
+import (
+	"crypto/rand"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// voucherAlphabet leaves out 0/O and 1/I so codes can be read out over the phone
+const voucherAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// VoucherCodeLength is the number of characters in a verification code
+const VoucherCodeLength = 8
+
+// CheckInPayload prefixes the /start payload encoded in a voucher's QR code
+const CheckInPayload = "checkin_"
+
+// voucherCodePattern finds a displayed code (XXXX-XXXX) inside free text such as a forwarded caption
+var voucherCodePattern = regexp.MustCompile(`\b[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{4}\b`)
+
+// BookingVoucher is the proof of a confirmed booking that a worker shows on site
+type BookingVoucher struct {
+	ID                int64      `json:"id"`
+	BookingID         int64      `json:"booking_id"` // One voucher per booking
+	Code              string     `json:"code"`       // Stored without the dash, e.g. "K7M2Q9XA"
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+	VerifiedByAdminID *int64     `json:"verified_by_admin_id,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// NewVoucherCode returns a random verification code
+func NewVoucherCode() (string, error) {
+	b := make([]byte, VoucherCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = voucherAlphabet[int(b[i])%len(voucherAlphabet)]
+	}
+	return string(b), nil
+}
+
+// NormalizeVoucherCode uppercases a typed code and drops spaces and dashes
+func NormalizeVoucherCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// ExtractVoucherCode returns the first displayed code found in text, normalized, or ""
+func ExtractVoucherCode(text string) string {
+	return NormalizeVoucherCode(voucherCodePattern.FindString(strings.ToUpper(text)))
+}
+
+// DisplayCode formats the code as XXXX-XXXX
+func (v *BookingVoucher) DisplayCode() string {
+	if len(v.Code) != VoucherCodeLength {
+		return v.Code
+	}
+	return v.Code[:4] + "-" + v.Code[4:]
+}
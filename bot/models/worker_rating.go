@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// WorkerRating is an admin's 1-5 rating of a worker for one confirmed booking
+type WorkerRating struct {
+	ID        int64     `json:"id"`
+	BookingID int64     `json:"booking_id"` // One rating per booking
+	UserID    int64     `json:"user_id"`
+	JobID     int64     `json:"job_id"`
+	AdminID   int64     `json:"admin_id"`
+	Rating    int       `json:"rating"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkerReliability aggregates a worker's ratings and attendance history
+type WorkerReliability struct {
+	RatingCount   int     `json:"rating_count"`
+	AverageRating float64 `json:"average_rating"`
+	Attended      int     `json:"attended"`
+	NoShows       int     `json:"no_shows"`
+}
+
+// Reliability score weights: showing up matters more than how well the work went
+const (
+	reliabilityAttendanceWeight = 0.6
+	reliabilityRatingWeight     = 0.4
+)
+
+// HasHistory reports whether the worker has any rating or attendance mark yet
+func (r *WorkerReliability) HasHistory() bool {
+	return r.RatingCount > 0 || r.Attended+r.NoShows > 0
+}
+
+// Score returns a 0-100 reliability score.
+// Attendance rate and normalized average rating are combined with fixed weights;
+// when only one of them is known it is used on its own.
+func (r *WorkerReliability) Score() int {
+	var total, weights float64
+
+	if marked := r.Attended + r.NoShows; marked > 0 {
+		total += reliabilityAttendanceWeight * float64(r.Attended) / float64(marked)
+		weights += reliabilityAttendanceWeight
+	}
+	if r.RatingCount > 0 {
+		total += reliabilityRatingWeight * (r.AverageRating - 1) / 4
+		weights += reliabilityRatingWeight
+	}
+
+	if weights == 0 {
+		return 0
+	}
+	return int(total/weights*100 + 0.5)
+}
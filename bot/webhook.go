@@ -0,0 +1,170 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// secretTokenHeader is the header Telegram fills with the webhook's secret_token
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// WebhookPoller receives updates over HTTP(S).
+//
+// telebot's own Webhook poller answers on every path and can't be mounted under
+// a prefix, so this poller runs its own server and only uses tele.Webhook for
+// the setWebhook parameters (URL, secret, certificate, drop_pending_updates).
+type WebhookPoller struct {
+	hook     *tele.Webhook
+	listen   string
+	path     string
+	certFile string
+	keyFile  string
+	register bool
+	log      logger.LoggerI
+
+	// Watchdog settings, see webhook_watchdog.go
+	watchdog    time.Duration
+	fallback    bool
+	pollTimeout time.Duration
+	lastUpdate  atomic.Int64 // unix nanoseconds of the last update received
+	alert       func(msg string)
+}
+
+// NewWebhookPoller builds the webhook poller from config, validating the TLS options
+func NewWebhookPoller(cfg *config.Config, log logger.LoggerI) (*WebhookPoller, error) {
+	bc := cfg.Bot
+	if bc.WebhookURL == "" {
+		return nil, errors.New("BOT_WEBHOOK_URL is required when BOT_MODE=webhook")
+	}
+	if (bc.WebhookCertFile == "") != (bc.WebhookKeyFile == "") {
+		return nil, errors.New("BOT_WEBHOOK_CERT and BOT_WEBHOOK_KEY must be set together")
+	}
+	if bc.WebhookSelfSigned && bc.WebhookCertFile == "" {
+		return nil, errors.New("BOT_WEBHOOK_SELF_SIGNED requires BOT_WEBHOOK_CERT")
+	}
+
+	path := bc.WebhookPath
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	endpoint := &tele.WebhookEndpoint{PublicURL: bc.WebhookURL}
+	if bc.WebhookSelfSigned {
+		endpoint.Cert = bc.WebhookCertFile
+	}
+
+	return &WebhookPoller{
+		hook: &tele.Webhook{
+			SecretToken: bc.WebhookSecret,
+			DropUpdates: bc.WebhookDropPending,
+			Endpoint:    endpoint,
+		},
+		listen:   fmt.Sprintf(":%d", bc.WebhookPort),
+		path:     path,
+		certFile: bc.WebhookCertFile,
+		keyFile:  bc.WebhookKeyFile,
+		register: bc.WebhookRegister,
+		log:      log,
+
+		watchdog:    bc.WebhookWatchdog,
+		fallback:    bc.WebhookFallback && bc.WebhookRegister,
+		pollTimeout: bc.Poller,
+	}, nil
+}
+
+// Register sets the webhook with Telegram when BOT_WEBHOOK_REGISTER is on. Call it before the bot
+// starts: a bot whose webhook can't be set would run without receiving any update.
+func (p *WebhookPoller) Register(b *tele.Bot) error {
+	if !p.register {
+		return nil
+	}
+	if err := b.SetWebhook(p.hook); err != nil {
+		return fmt.Errorf("failed to register webhook: %w", err)
+	}
+	p.log.Info("Webhook registered",
+		logger.String("url", p.hook.Endpoint.PublicURL),
+		logger.Bool("secret_token", p.hook.SecretToken != ""),
+		logger.Bool("custom_cert", p.hook.Endpoint.Cert != ""),
+		logger.Bool("drop_pending", p.hook.DropUpdates),
+	)
+	return nil
+}
+
+// Poll serves updates until stop is closed; the webhook is set beforehand by Register
+func (p *WebhookPoller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	p.touch()
+	if p.watchdog > 0 {
+		go p.watch(b, dest, stop)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(p.path, p.handler(dest))
+
+	srv := &http.Server{
+		Addr:              p.listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	var err error
+	if p.certFile != "" {
+		err = srv.ListenAndServeTLS(p.certFile, p.keyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		p.log.Error("Webhook server stopped", logger.Error(err))
+	}
+}
+
+// handler verifies the secret token and forwards decoded updates to the bot
+func (p *WebhookPoller) handler(dest chan tele.Update) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if p.hook.SecretToken != "" &&
+			subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(p.hook.SecretToken)) != 1 {
+			p.log.Warn("Rejected webhook request with invalid secret token",
+				logger.String("remote_addr", r.RemoteAddr),
+				logger.String("path", r.URL.Path),
+			)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update tele.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			p.log.Warn("Failed to decode webhook update", logger.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		p.touch()
+		dest <- update
+	})
+}
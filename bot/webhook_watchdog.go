@@ -0,0 +1,238 @@
+package bot
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// watchdogCheckInterval is how often the watchdog looks at the webhook, capped at the watchdog window
+	watchdogCheckInterval = time.Minute
+	// webhookProbeTimeout bounds the request checking whether the public URL answers again
+	webhookProbeTimeout = 10 * time.Second
+	// webhookRecoveryProbes is how many probes in a row must succeed before the webhook is set again
+	webhookRecoveryProbes = 3
+)
+
+// SetAlert sets where watchdog alerts go. Call it before the bot starts.
+func (p *WebhookPoller) SetAlert(alert func(msg string)) {
+	p.alert = alert
+}
+
+// touch records that an update just arrived
+func (p *WebhookPoller) touch() {
+	p.lastUpdate.Store(time.Now().UnixNano())
+}
+
+func (p *WebhookPoller) lastUpdateAt() time.Time {
+	return time.Unix(0, p.lastUpdate.Load())
+}
+
+func (p *WebhookPoller) notify(msg string) {
+	if p.alert != nil {
+		p.alert(msg)
+	}
+}
+
+// watch alerts admins when webhook updates stop arriving while Telegram has updates queued or
+// reports delivery errors, so a broken certificate or proxy doesn't leave the bot silently dead.
+// A quiet bot with an empty queue is left alone. With fallback enabled the webhook is removed and
+// updates are long-polled into dest until the public URL answers several checks in a row; the
+// webhook is then set on trial and only kept if Telegram delivers through it by the next check.
+func (p *WebhookPoller) watch(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	interval := min(watchdogCheckInterval, p.watchdog)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		alerted     bool
+		pollStop    chan struct{} // non-nil while long polling
+		pollDone    chan struct{}
+		poller      *tele.LongPoller
+		pollStarted time.Time
+		healthy     int       // probes in a row that found the public URL up
+		trialSince  time.Time // non-zero while a re-registered webhook is on trial
+	)
+
+	stopPolling := func() {
+		close(pollStop)
+		<-pollDone
+		// The last polled batch is only confirmed by the next getUpdates; confirm it now so the
+		// webhook doesn't deliver it a second time
+		if poller.LastUpdateID > 0 {
+			if _, err := b.Raw("getUpdates", map[string]any{"offset": poller.LastUpdateID + 1, "limit": 1}); err != nil {
+				p.log.Warn("Failed to confirm long-polled updates", logger.Error(err))
+			}
+		}
+		pollStop, pollDone, poller = nil, nil, nil
+	}
+
+	for {
+		select {
+		case <-stop:
+			if pollStop != nil {
+				stopPolling()
+			}
+			return
+		case <-ticker.C:
+		}
+
+		if !trialSince.IsZero() {
+			info, err := b.Webhook()
+			if err != nil {
+				p.log.Error("Webhook watchdog failed to get webhook info", logger.Error(err))
+				continue
+			}
+			// Telegram reports a delivery error, or holds updates that never reached us, since the
+			// webhook was set again: the URL answers probes but not Telegram, go back to polling
+			failed := info.ErrorUnixtime >= trialSince.Unix() ||
+				(info.PendingUpdates > 0 && p.lastUpdateAt().Before(trialSince))
+			if failed {
+				p.log.Warn("Re-registered webhook still not delivering, back to long polling",
+					logger.Int("pending_updates", info.PendingUpdates),
+					logger.String("last_error", info.ErrorMessage))
+				if err := b.RemoveWebhook(false); err != nil {
+					p.log.Error("Failed to remove webhook for long polling fallback", logger.Error(err))
+					continue
+				}
+				trialSince = time.Time{}
+				healthy = 0
+				pollStop, pollDone, poller = p.startPolling(b, dest)
+				continue
+			}
+			trialSince = time.Time{}
+			p.touch()
+			alerted = false
+			p.log.Info("Webhook re-registered after long polling fallback",
+				logger.String("url", p.hook.Endpoint.PublicURL),
+				logger.String("polled_for", time.Since(pollStarted).Round(time.Second).String()))
+			p.notify(messages.FormatWebhookRecoveredAlert(time.Since(pollStarted)))
+			continue
+		}
+
+		if pollStop != nil {
+			if err := p.probe(); err != nil {
+				healthy = 0
+				p.log.Debug("Webhook URL still unreachable", logger.Error(err))
+				continue
+			}
+			healthy++
+			if healthy < webhookRecoveryProbes {
+				p.log.Debug("Webhook URL answered", logger.Int("healthy_probes", healthy))
+				continue
+			}
+			stopPolling()
+			if err := b.SetWebhook(p.hook); err != nil {
+				p.log.Error("Failed to re-register webhook, staying on long polling", logger.Error(err))
+				healthy = 0
+				pollStop, pollDone, poller = p.startPolling(b, dest)
+				continue
+			}
+			trialSince = time.Now()
+			continue
+		}
+
+		lastUpdate := p.lastUpdateAt()
+		if time.Since(lastUpdate) < p.watchdog {
+			alerted = false
+			continue
+		}
+		if alerted {
+			continue
+		}
+
+		info, err := b.Webhook()
+		if err != nil {
+			p.log.Error("Webhook watchdog failed to get webhook info", logger.Error(err))
+			continue
+		}
+		var errorAt time.Time
+		if info.ErrorUnixtime > 0 {
+			errorAt = time.Unix(info.ErrorUnixtime, 0)
+		}
+		if info.PendingUpdates == 0 && !errorAt.After(lastUpdate) {
+			// Nobody wrote to the bot, nothing is wrong
+			continue
+		}
+
+		alerted = true
+		p.log.Error("Webhook stalled",
+			logger.String("last_update", lastUpdate.Format(time.RFC3339)),
+			logger.Int("pending_updates", info.PendingUpdates),
+			logger.String("last_error", info.ErrorMessage),
+			logger.Bool("fallback", p.fallback))
+
+		if p.fallback {
+			if err := b.RemoveWebhook(false); err != nil {
+				p.log.Error("Failed to remove webhook for long polling fallback", logger.Error(err))
+				p.notify(messages.FormatWebhookStalledAlert(lastUpdate, info.PendingUpdates, info.ErrorMessage, errorAt, false))
+				continue
+			}
+			pollStop, pollDone, poller = p.startPolling(b, dest)
+			pollStarted = time.Now()
+			healthy = 0
+		}
+		p.notify(messages.FormatWebhookStalledAlert(lastUpdate, info.PendingUpdates, info.ErrorMessage, errorAt, pollStop != nil))
+	}
+}
+
+// startPolling long-polls updates into dest until the returned stop channel is closed;
+// done is closed once the poller has returned
+func (p *WebhookPoller) startPolling(b *tele.Bot, dest chan tele.Update) (stop, done chan struct{}, poller *tele.LongPoller) {
+	stop = make(chan struct{})
+	done = make(chan struct{})
+	poller = &tele.LongPoller{Timeout: p.pollTimeout}
+
+	go func() {
+		defer close(done)
+		poller.Poll(b, dest, stop)
+	}()
+	p.log.Warn("Webhook stalled, switched to long polling", logger.String("timeout", p.pollTimeout.String()))
+	return stop, done, poller
+}
+
+// probe checks that the public webhook URL answers over TLS. Any HTTP response counts, the
+// handler itself rejects GET with 405; what matters is that the certificate, DNS and proxy work.
+func (p *WebhookPoller) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.hook.Endpoint.PublicURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.probeClient().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("webhook URL answered %s", resp.Status)
+	}
+	return nil
+}
+
+// probeClient trusts the uploaded certificate when the webhook uses a self-signed one
+func (p *WebhookPoller) probeClient() *http.Client {
+	if p.hook.Endpoint.Cert == "" {
+		return http.DefaultClient
+	}
+	pem, err := os.ReadFile(p.hook.Endpoint.Cert)
+	if err != nil {
+		p.log.Warn("Failed to read webhook certificate for probing", logger.Error(err))
+		return http.DefaultClient
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(pem)
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+}
@@ -0,0 +1,76 @@
+// Command encryptpii rewrites the phones and passport photo IDs already stored in registration
+// drafts and registered users so they match the encryption setting. Rows written after
+// DB_ENCRYPTION_KEY was set are encrypted anyway; run this once after enabling the key so older
+// rows are encrypted and findable by phone_hash too.
+//
+// With -decrypt it turns every encrypted value back into plaintext, which is the step before
+// removing the key or rolling back the registration encryption migration. Both directions skip
+// rows that are already in the requested form, so an interrupted run can simply be restarted.
+// Run it from the repository root with the bot's configuration (the migrations are read from ./migrations).
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+	"telegram-bot-starter/storage/postgres"
+	"telegram-bot-starter/storage/sqlite"
+)
+
+func main() {
+	decrypt := flag.Bool("decrypt", false, "store every value as plaintext again instead of encrypting it")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		panic("Failed to load configuration: " + err.Error())
+	}
+	log := logger.NewLogger("encryptpii", cfg.App.LogLevel)
+
+	failed := run(cfg, log, !*decrypt)
+	_ = logger.Cleanup(log)
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run opens the configured store and rewrites the personal data, reporting whether it failed
+func run(cfg *config.Config, log logger.LoggerI, encrypt bool) (failed bool) {
+	if cfg.Database.EncryptionKey == "" && cfg.Database.EncryptionKeyFile == "" {
+		// Decrypting needs the key as well, to read the encrypted values
+		log.Error("encryptpii needs DB_ENCRYPTION_KEY or DB_ENCRYPTION_KEY_FILE")
+		return true
+	}
+	ctx := context.Background()
+
+	var store storage.StorageI
+	var err error
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		store, err = sqlite.NewSQLite(ctx, cfg, log)
+	default:
+		store, err = postgres.NewPostgres(ctx, cfg, log)
+	}
+	if err != nil {
+		log.Error("Failed to initialize storage", logger.Error(err))
+		return true
+	}
+	defer store.CloseDB()
+
+	mode := "encrypt"
+	if !encrypt {
+		mode = "decrypt"
+	}
+	changed, err := store.Registration().RecryptPersonalData(ctx, encrypt)
+	if err != nil {
+		log.Error("Failed to rewrite personal data", logger.Error(err), logger.String("mode", mode), logger.Int("changed", changed))
+		return true
+	}
+
+	log.Info("Personal data rewritten", logger.String("mode", mode), logger.Int("changed", changed))
+	return false
+}
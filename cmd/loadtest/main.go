@@ -0,0 +1,288 @@
+// Command loadtest fires simulated "Band qilish" taps at one job through the booking service,
+// the path a channel post with thousands of subscribers hits when it goes out, and reports
+// over-booking, slot counter drift and idempotency violations together with the latencies.
+//
+// Every simulated user taps -taps times at once, like an impatient double tap, and all users
+// start together. It runs in a scratch database next to DB_NAME that is dropped at the end, so
+// it never touches real data; `make loadtest` starts a throwaway Postgres container for it.
+// Run it from the repository root (the migrations are read from ./migrations).
+//
+//	go run ./cmd/loadtest -users 2000 -slots 30 -taps 3
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/service"
+	"telegram-bot-starter/storage"
+	"telegram-bot-starter/storage/postgres"
+)
+
+// firstUserID keeps the simulated users clear of the job creator
+const firstUserID = 1000
+
+// attempt is the outcome of one ConfirmBooking call
+type attempt struct {
+	userID  int64
+	booking *models.JobBooking
+	err     error
+	took    time.Duration
+}
+
+func main() {
+	users := flag.Int("users", 500, "simulated users tapping the job")
+	slots := flag.Int("slots", 20, "required workers of the test job")
+	taps := flag.Int("taps", 2, "concurrent taps per user")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		panic("Failed to load configuration: " + err.Error())
+	}
+	log := logger.NewLogger("loadtest", cfg.App.LogLevel)
+
+	failed := run(cfg, log, *users, *slots, *taps)
+	_ = logger.Cleanup(log)
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run prepares the scratch database, fires the taps and reports whether an invariant broke
+func run(cfg *config.Config, log logger.LoggerI, users, slots, taps int) (failed bool) {
+	if cfg.Database.Driver != config.DriverPostgres {
+		log.Error("loadtest needs STORAGE_DRIVER=postgres")
+		return true
+	}
+	if users < 1 || slots < 1 || taps < 1 {
+		log.Error("-users, -slots and -taps must be positive")
+		return true
+	}
+	ctx := context.Background()
+
+	scratch := *cfg
+	scratch.Database.DBName = fmt.Sprintf("%s_loadtest_%d", cfg.Database.DBName, time.Now().Unix())
+	scratch.Database.ReadDSN = ""
+	scratch.Database.QueryStatsInterval = 0
+
+	if err := postgres.CreateDatabase(ctx, cfg, scratch.Database.DBName, log); err != nil {
+		log.Error("Failed to create scratch database", logger.Error(err))
+		return true
+	}
+	defer func() {
+		if err := postgres.DropDatabase(ctx, cfg, scratch.Database.DBName); err != nil {
+			log.Error("Failed to drop scratch database", logger.Error(err), logger.String("database", scratch.Database.DBName))
+		}
+	}()
+
+	store, err := postgres.NewPostgres(ctx, &scratch, log)
+	if err != nil {
+		log.Error("Failed to initialize storage", logger.Error(err))
+		return true
+	}
+	defer store.CloseDB()
+
+	job, err := seed(ctx, store, users, slots)
+	if err != nil {
+		log.Error("Failed to create the test job and users", logger.Error(err))
+		return true
+	}
+
+	// The service logs every booking; only the report is of interest here
+	quiet := logger.NewLogger("loadtest", logger.LevelError)
+	bookings := service.NewBookingService(scratch, quiet, store, nil, service.SystemClock{}, service.DefaultIDGen{})
+
+	log.Info(fmt.Sprintf("Firing %d taps: %d users × %d taps at a job with %d slots", users*taps, users, taps, slots))
+	start := time.Now()
+	attempts := fire(ctx, bookings, job.ID, users, taps)
+	elapsed := time.Since(start)
+
+	violations, err := verify(ctx, store, job.ID, slots, attempts)
+	if err != nil {
+		log.Error("Failed to verify the outcome", logger.Error(err))
+		return true
+	}
+	report(log, attempts, elapsed)
+
+	for _, v := range violations {
+		log.Error("VIOLATION " + v)
+	}
+	if len(violations) == 0 {
+		log.Info("No over-booking, drift or idempotency violations")
+	}
+	return len(violations) > 0
+}
+
+// seed creates the job creator, the simulated users and an active job without requirements
+func seed(ctx context.Context, store storage.StorageI, users, slots int) (*models.Job, error) {
+	const adminID = 1
+	if err := store.User().Create(ctx, models.NewUser(adminID, "", "Admin", "")); err != nil {
+		return nil, err
+	}
+	for i := range users {
+		id := int64(firstUserID + i)
+		if err := store.User().Create(ctx, models.NewUser(id, "", fmt.Sprintf("Load %d", id), "")); err != nil {
+			return nil, err
+		}
+	}
+	return store.Job().Create(ctx, &models.Job{
+		Salary:           "100 000",
+		WorkTime:         "09:00-18:00",
+		Address:          "Load test",
+		ServiceFee:       10000,
+		WorkDate:         time.Now().Format("02.01.2006"),
+		Status:           models.JobStatusActive,
+		RequiredWorkers:  slots,
+		CreatedByAdminID: adminID,
+	})
+}
+
+// fire releases every tap at once and collects the outcomes
+func fire(ctx context.Context, bookings service.BookingService, jobID int64, users, taps int) []attempt {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		attempts = make([]attempt, 0, users*taps)
+		ready    = make(chan struct{})
+	)
+	for i := range users {
+		userID := int64(firstUserID + i)
+		for range taps {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-ready
+				start := time.Now()
+				booking, err := bookings.ConfirmBooking(ctx, userID, jobID)
+				a := attempt{userID: userID, booking: booking, err: err, took: time.Since(start)}
+				mu.Lock()
+				attempts = append(attempts, a)
+				mu.Unlock()
+			}()
+		}
+	}
+	close(ready)
+	wg.Wait()
+	return attempts
+}
+
+// verify checks the job and its bookings against the slots and the taps; it returns one line
+// per broken invariant
+func verify(ctx context.Context, store storage.StorageI, jobID int64, slots int, attempts []attempt) ([]string, error) {
+	var violations []string
+
+	job, err := store.Job().GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if taken := job.ReservedSlots + job.ConfirmedSlots; taken > slots {
+		violations = append(violations, fmt.Sprintf("job counters hold %d slots of %d", taken, slots))
+	}
+
+	drifts, err := store.Job().GetSlotDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range drifts {
+		if d.JobID == jobID {
+			violations = append(violations, fmt.Sprintf("counters say %d reserved / %d confirmed, bookings say %d / %d",
+				d.Reserved, d.Confirmed, d.ActualReserved, d.ActualConfirmed))
+		}
+	}
+
+	rows, err := store.Booking().GetJobBookings(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	perUser := make(map[int64]int)
+	for _, b := range rows {
+		perUser[b.UserID]++
+	}
+	if len(perUser) > slots {
+		violations = append(violations, fmt.Sprintf("%d users hold a booking for %d slots", len(perUser), slots))
+	}
+	for userID, n := range perUser {
+		if n > 1 {
+			violations = append(violations, fmt.Sprintf("user %d has %d bookings", userID, n))
+		}
+	}
+
+	// Every tap of a user that got a booking must see that same booking
+	returned := make(map[int64]map[int64]bool)
+	for _, a := range attempts {
+		if a.booking == nil {
+			continue
+		}
+		if returned[a.userID] == nil {
+			returned[a.userID] = make(map[int64]bool)
+		}
+		returned[a.userID][a.booking.ID] = true
+	}
+	for userID, ids := range returned {
+		if len(ids) > 1 {
+			violations = append(violations, fmt.Sprintf("user %d was handed %d different bookings", userID, len(ids)))
+		}
+	}
+
+	return violations, nil
+}
+
+// report logs how the taps ended and how long they took
+func report(log logger.LoggerI, attempts []attempt, elapsed time.Duration) {
+	outcomes := make(map[string]int)
+	durations := make([]time.Duration, len(attempts))
+	for i, a := range attempts {
+		outcomes[outcome(a)]++
+		durations[i] = a.took
+	}
+	slices.Sort(durations)
+
+	names := make([]string, 0, len(outcomes))
+	for name := range outcomes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		log.Info(fmt.Sprintf("%6d  %s", outcomes[name], name))
+	}
+
+	percentile := func(p int) string {
+		return durations[(len(durations)-1)*p/100].Round(time.Millisecond).String()
+	}
+	log.Info("Latency",
+		logger.Any("total", elapsed.Round(time.Millisecond).String()),
+		logger.Any("p50", percentile(50)),
+		logger.Any("p95", percentile(95)),
+		logger.Any("p99", percentile(99)),
+		logger.Any("max", percentile(100)),
+	)
+}
+
+// outcome names the result of a tap; service errors carry counts and durations, which are
+// masked so equal outcomes group together
+func outcome(a attempt) string {
+	switch {
+	case a.err == nil:
+		return "booked"
+	case a.booking != nil:
+		return "duplicate tap: " + maskDigits(a.err.Error())
+	default:
+		return "refused: " + maskDigits(a.err.Error())
+	}
+}
+
+var digits = regexp.MustCompile(`[0-9]+`)
+
+func maskDigits(s string) string {
+	return digits.ReplaceAllString(s, "N")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,8 +14,12 @@ import (
 	"telegram-bot-starter/bot/handlers"
 	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/objectstore"
 	"telegram-bot-starter/service"
+	"telegram-bot-starter/storage"
+	"telegram-bot-starter/storage/cache"
 	"telegram-bot-starter/storage/postgres"
+	"telegram-bot-starter/storage/sqlite"
 
 	tele "gopkg.in/telebot.v4"
 )
@@ -30,37 +36,57 @@ func main() {
 	defer func() {
 		_ = logger.Cleanup(log)
 	}()
+	if cfg.App.SentryDSN != "" {
+		if err := logger.EnableErrorTracker(log, cfg.App.SentryDSN, cfg.App.Environment); err != nil {
+			log.Fatal("Failed to enable error tracker: " + err.Error())
+		}
+	}
 	log.Info("Starting Telegram Bot...")
+	log.Info("Effective configuration:\n  " + strings.Join(cfg.Summary(), "\n  "))
+	// /backup runs pg_dump itself unless a webhook takes the backups
+	if cfg.Backup.Dir != "" && cfg.Backup.WebhookURL == "" {
+		if _, err := exec.LookPath(cfg.Backup.PgDump); err != nil {
+			log.Warn("pg_dump not found, /backup will fail until it is installed or BACKUP_PG_DUMP points to it",
+				logger.String("pg_dump", cfg.Backup.PgDump), logger.Error(err))
+		}
+	}
 
 	// Initialize storage layer
 	ctx := context.Background()
-	store, err := postgres.NewPostgres(ctx, cfg, log)
+	var store storage.StorageI
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		log.Warn("Using SQLite storage, intended for local development only")
+		store, err = sqlite.NewSQLite(ctx, cfg, log)
+	default:
+		store, err = postgres.NewPostgres(ctx, cfg, log)
+	}
 	if err != nil {
 		log.Fatal("Failed to initialize storage: " + err.Error())
 	}
 	defer store.CloseDB()
+	store = cache.New(store, cfg.Database.JobCacheTTL)
 	log.Info("Storage layer initialized")
 
 	// Create bot instance with appropriate poller based on mode
 	var botSettings tele.Settings
+	var webhookPoller *bot.WebhookPoller
 
-	if cfg.Bot.Mode == "webhook" {
+	if cfg.Bot.Mode == config.ModeWebhook {
 		// Webhook mode for production
 		log.Info("Starting bot in WEBHOOK mode")
 
-		if cfg.Bot.WebhookURL == "" {
-			log.Fatal("BOT_WEBHOOK_URL is required when BOT_MODE=webhook")
+		webhookPoller, err = bot.NewWebhookPoller(cfg, log)
+		if err != nil {
+			log.Fatal("Invalid webhook configuration: " + err.Error())
 		}
 
 		botSettings = tele.Settings{
-			Token: cfg.Bot.Token,
-			Poller: &tele.Webhook{
-				IgnoreSetWebhook: true,
-				Listen:           fmt.Sprintf(":%d", cfg.Bot.WebhookPort),
-				Endpoint:         &tele.WebhookEndpoint{PublicURL: cfg.Bot.WebhookURL},
-			},
+			Token:  cfg.Bot.Token,
+			Poller: webhookPoller,
 		}
-		log.Info(fmt.Sprintf("Webhook configured: %s (listening on %d)", cfg.Bot.WebhookURL, cfg.Bot.WebhookPort))
+		log.Info(fmt.Sprintf("Webhook configured: %s (listening on %d, path %s, tls=%t)",
+			cfg.Bot.WebhookURL, cfg.Bot.WebhookPort, cfg.Bot.WebhookPath, cfg.Bot.WebhookCertFile != ""))
 	} else {
 		// Long polling mode for local development
 		log.Info("Starting bot in LONG POLLING mode")
@@ -75,24 +101,121 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to create bot: " + err.Error())
 	}
+	if webhookPoller != nil {
+		if err := webhookPoller.Register(telegramBot); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+	// Everything that sends messages goes through api, so sandbox mode can redirect it
+	var api service.BotAPI = telegramBot
+	if cfg.Sandbox.Enabled {
+		api = service.NewSandboxBot(telegramBot, cfg, log)
+		log.Warn("SANDBOX MODE: channel posts, group and user notifications go to the sandbox chats")
+	}
+
 	// Initialize bot services
-	services := service.NewServiceManager(*cfg, log, store, telegramBot)
+	services := service.NewServiceManager(*cfg, log, store, api)
+	// The expiry worker starts with the others below; /expiry reports on it
+	expiryWorker := service.NewExpiryWorker(cfg, store, log, api, services.Settings(), services.ChannelPosts(), services.BookingStatus(), service.SystemClock{})
 	// Initialize handler
 	params := handlers.NewHandlerParams{
 		Logger:   log,
 		Storage:  store,
-		Bot:      telegramBot,
+		Bot:      api,
 		Cfg:      cfg,
 		Services: services,
+		Expiry:   expiryWorker,
+		Clock:    service.SystemClock{},
 	}
 	handler := handlers.NewHandler(params)
 
+	// Update contexts derive from updatesCtx; cancelling it on shutdown aborts in-flight handlers
+	updatesCtx, cancelUpdates := context.WithCancel(context.Background())
+	defer cancelUpdates()
+
+	// The webhook watchdog reports stalled delivery to the ops chat
+	if webhookPoller != nil {
+		webhookPoller.SetAlert(func(msg string) { handler.NotifyOps(updatesCtx, msg) })
+	}
+
 	// Set up routes (includes rate limiter middleware)
-	rateLimiter := bot.RegisterRoutes(telegramBot, handler, log, cfg)
-	// Initialize and start expiry worker
-	expiryWorker := service.NewExpiryWorker(store, log, telegramBot)
+	rateLimiter := bot.RegisterRoutes(updatesCtx, telegramBot, handler, log, cfg)
+
+	// Publish the command menus: user commands everywhere, admin commands in admins' chats
+	go handler.SetupCommands(updatesCtx)
+
+	// Drop job messages of users removed from the admin list while the bot was down,
+	// then optionally bring the remaining ones up to date with this version
+	go func() {
+		handler.RevokeStaleAdminMessages(updatesCtx)
+		if cfg.Bot.RefreshAdminMessages {
+			handler.RefreshAdminMessages(updatesCtx)
+		}
+	}()
+
+	// Start expiry worker
 	go expiryWorker.Start()
 
+	// Initialize and start payment countdown updater
+	countdownWorker := service.NewCountdownWorker(cfg, store, log, api, services.Settings(), service.SystemClock{})
+	go countdownWorker.Start()
+
+	// Initialize and start daily digest worker
+	digestWorker := service.NewDigestWorker(cfg, store, log, api, services.Settings())
+	go digestWorker.Start()
+
+	// Initialize and start worker feedback prompter
+	feedbackWorker := service.NewFeedbackWorker(store, log, api)
+	go feedbackWorker.Start()
+
+	// Initialize and start finish-registration reminders for interested users
+	jobInterestWorker := service.NewJobInterestWorker(store, log, api)
+	go jobInterestWorker.Start()
+
+	// Initialize and start daily re-engagement campaigns
+	reengageWorker := service.NewReengageWorker(cfg, log, services.Reengage())
+	go reengageWorker.Start()
+
+	// Initialize and start the pinned channel index updater
+	channelIndexWorker := service.NewChannelIndexWorker(cfg, log, services.ChannelIndex())
+	go channelIndexWorker.Start()
+
+	// Initialize and start reposts of under-subscribed jobs near their work date
+	jobBumpWorker := service.NewJobBumpWorker(cfg, store, log, api)
+	go jobBumpWorker.Start()
+
+	// Initialize and start expired block remover
+	unblockWorker := service.NewUnblockWorker(store, log, api)
+	go unblockWorker.Start()
+
+	// Initialize and start abandoned flow remover
+	stateResetWorker := service.NewStateResetWorker(cfg, store, log, api, services.Settings(), handler.ClearSession)
+	go stateResetWorker.Start()
+
+	// Initialize and start nightly slot counter reconciliation
+	slotCheckWorker := service.NewSlotCheckWorker(cfg, store, log, api, services.Sender(), services.Settings())
+	go slotCheckWorker.Start()
+
+	// Initialize and start nightly cleanup of old data
+	retentionWorker := service.NewRetentionWorker(cfg, store, log)
+	go retentionWorker.Start()
+
+	// Initialize and start payment review reminders
+	paymentSLAWorker := service.NewPaymentSLAWorker(cfg, store, log, api, services.Settings())
+	go paymentSLAWorker.Start()
+
+	// Initialize and start receipt archiving to S3-compatible storage
+	var receiptStore service.ReceiptStore
+	if a := cfg.Archive; a.Enabled() {
+		s3, err := objectstore.NewS3(a.Endpoint, a.Region, a.Bucket, a.AccessKey, a.SecretKey, a.PublicURL)
+		if err != nil {
+			log.Fatal("Failed to create receipt archive client: " + err.Error())
+		}
+		receiptStore = s3
+	}
+	receiptArchiveWorker := service.NewReceiptArchiveWorker(cfg, store, log, telegramBot, receiptStore)
+	go receiptArchiveWorker.Start()
+
 	log.Info("Bot started successfully! Press Ctrl+C to stop.")
 
 	// Graceful shutdown
@@ -107,8 +230,22 @@ func main() {
 
 	log.Info("Shutting down bot...")
 
-	// Stop expiry worker
+	// Stop background workers
 	expiryWorker.Stop()
+	countdownWorker.Stop()
+	digestWorker.Stop()
+	feedbackWorker.Stop()
+	jobInterestWorker.Stop()
+	reengageWorker.Stop()
+	channelIndexWorker.Stop()
+	jobBumpWorker.Stop()
+	services.ChannelPosts().Stop()
+	unblockWorker.Stop()
+	stateResetWorker.Stop()
+	slotCheckWorker.Stop()
+	retentionWorker.Stop()
+	paymentSLAWorker.Stop()
+	receiptArchiveWorker.Stop()
 
 	// Stop rate limiter cleanup goroutine
 	rateLimiter.Stop()
@@ -117,7 +254,8 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Stop the bot
+	// Cancel outstanding update contexts, then stop the bot
+	cancelUpdates()
 	telegramBot.Stop()
 
 	// Wait for context or timeout
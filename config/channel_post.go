@@ -0,0 +1,30 @@
+package config
+
+import "fmt"
+
+// Channel post layouts (CHANNEL_POST_STYLE)
+const (
+	// ChannelPostRich adds a capacity bar and a countdown to the work date
+	ChannelPostRich = "rich"
+	// ChannelPostPlain is the minimal text-only layout
+	ChannelPostPlain = "plain"
+)
+
+// ChannelPost decides how job posts look in the channel.
+// Load sets it from CHANNEL_POST_STYLE and CHANNEL_POST_HASHTAGS.
+var ChannelPost = ChannelPostConfig{Style: ChannelPostRich}
+
+// ChannelPostConfig is the channel post display setting
+type ChannelPostConfig struct {
+	Style    string // ChannelPostRich or ChannelPostPlain
+	Hashtags bool   // append hashtags for the date, gender, food and transport so posts can be searched
+}
+
+// SetChannelPost validates and applies the channel post display setting
+func SetChannelPost(style string, hashtags bool) error {
+	if style != ChannelPostRich && style != ChannelPostPlain {
+		return fmt.Errorf("unsupported CHANNEL_POST_STYLE %q (expected %q or %q)", style, ChannelPostRich, ChannelPostPlain)
+	}
+	ChannelPost = ChannelPostConfig{Style: style, Hashtags: hashtags}
+	return nil
+}
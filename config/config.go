@@ -7,15 +7,22 @@ import (
 	"strings"
 	"time"
 
+	"telegram-bot-starter/pkg/fieldcrypt"
+
 	"github.com/joho/godotenv"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Bot      BotConfig
-	Database DatabaseConfig
-	App      AppConfig
-	Payment  PaymentConfig
+	Bot          BotConfig
+	Database     DatabaseConfig
+	App          AppConfig
+	Payment      PaymentConfig
+	Registration RegistrationConfig
+	Booking      BookingConfig
+	Sandbox      SandboxConfig
+	Archive      ArchiveConfig
+	Backup       BackupConfig
 }
 
 // BotConfig contains Telegram bot specific configuration
@@ -24,42 +31,210 @@ type BotConfig struct {
 	Verbose      bool
 	Poller       time.Duration
 	ChannelID    int64
-	AdminIDs     []int64
-	AdminGroupID int64 // Admin group for payment approvals
+	AdminIDs     []int64 // Initial admin list; "⚙️ Sozlamalar" can override it at runtime
+	AdminGroupID int64   // Admin group for payment approvals
 	Username     string
+	// Admins allowed to change runtime settings; always admins, whatever the stored list says
+	SuperAdminIDs []int64
+	// Optional split of the admin group; each falls back to AdminGroupID when unset
+	PaymentsGroupID int64 // Group that receives payment receipts for approval
+	OpsGroupID      int64 // Group that receives new job notifications and digests
+	ErrorChatID     int64 // Chat that receives handler panic reports (0 = the ops group)
 	// Webhook configuration
-	Mode        string // "webhook" or "polling"
-	WebhookURL  string // Public URL for webhook (e.g., https://example.com/webhook)
-	WebhookPort int    // Port for webhook server
+	Mode               string // ModeWebhook or ModePolling
+	WebhookURL         string // Public URL for webhook (e.g., https://example.com/webhook)
+	WebhookPort        int    // Port for webhook server
+	WebhookPath        string // Local path (or path prefix ending in "/") that accepts updates (default: "/")
+	WebhookSecret      string // Secret token Telegram must send in X-Telegram-Bot-Api-Secret-Token
+	WebhookCertFile    string // TLS certificate for serving HTTPS directly (optional)
+	WebhookKeyFile     string // TLS private key for serving HTTPS directly (optional)
+	WebhookSelfSigned  bool   // Upload WebhookCertFile to Telegram when registering the webhook
+	WebhookRegister    bool   // Call setWebhook on startup (default: true)
+	WebhookDropPending bool   // Drop updates queued while the bot was down when registering
+	// Alert admins when no update arrived for this long and Telegram reports queued updates or
+	// delivery errors (0 disables)
+	WebhookWatchdog time.Duration
+	// Switch to long polling while the webhook is stalled, re-registering it once the public URL answers again
+	WebhookFallback bool
 	// Rate limiter configuration
 	RateLimitMaxRequests int           // Max requests per window (default: 30)
 	RateLimitWindow      time.Duration // Sliding window duration (default: 60s)
+	// Per-update context deadline
+	UpdateTimeout time.Duration // Max time a handler may spend on one update (default: 30s)
+	// Double-tap protection for inline buttons
+	CallbackDedupeWindow time.Duration // Drop repeated taps on the same button within this window (default: 2s, 0 disables)
+	// Daily digest configuration
+	DigestHour    int  // Local hour (0-23) when the morning digest is sent (default: 8)
+	DigestToGroup bool // Send the digest to the admin group instead of each admin
+	// Check-in QR codes
+	CheckInQR bool // Send vouchers as QR code photos rendered by the bot itself (false sends text vouchers only)
+	// Location previews
+	StaticMapURL string // Static map image service; {lat} and {lng} are replaced (empty shows the location pin publicly)
+	// Channel discussion group auto-replies
+	DiscussionGroupID   int64 // Discussion group linked to the channel (0 = detect by forwarded channel posts)
+	DiscussionAutoReply bool  // Answer job questions under channel posts with the signup link and FAQ
+	// Abandoned multi-step flows
+	StaleStateTTL    time.Duration // Reset users stuck in a flow this long back to idle (0 disables)
+	StaleStateNotify bool          // Tell the user their flow was cancelled
+	// Nightly slot counter reconciliation
+	SlotCheckHour  int // Local hour (0-23) when slot counters are recomputed from bookings (default: 3)
+	SlotDriftAlert int // Alert admins when the corrected slots add up to more than this (default: 2)
+	// Unrecognized text from idle users
+	UnknownTextHint int // Show the main menu and a "tushunmadim" hint after this many unrecognized messages in a row (default: 2, 0 disables)
+	// Reopened jobs
+	ReopenNotice bool // Reply to the channel post when raising required workers reopens a FULL job (default: true)
+	// Payment review reminders
+	PaymentSLA time.Duration // Remind admins about receipts waiting longer than this, repeating at the same pace (0 disables)
+	// Re-engagement campaigns
+	ReengageDays int // Send open jobs to registered users who haven't booked for this many days (0 disables the daily run)
+	ReengageHour int // Local hour (0-23) of the daily re-engagement run (default: 11)
+	// Pinned "Bugungi ishlar" index in the channel
+	ChannelIndex         bool          // Keep a pinned list of open jobs in the channel (needs the pin permission)
+	ChannelIndexInterval time.Duration // How often the index is re-checked besides publish/close events (default: 5m)
+	// Reposting under-subscribed jobs near their work date
+	BumpBefore   time.Duration // Repost published jobs with free places this long before work starts (0 disables)
+	BumpMax      int           // Reposts per job (default: 1)
+	BumpInterval time.Duration // Minimum time between two reposts of one job (default: 3h)
+	// Channel job posts
+	ChannelPostDelay  time.Duration // Slot changes within this window share one edit of the job's channel post (default: 3s)
+	ChannelEditWindow time.Duration // Channel post edits of a job this soon after the last one are batched into one (0 sends each; default: 2s)
+	// Expiry worker releasing unpaid reservations
+	ExpiryInterval  time.Duration // How often expired reservations are looked for (default: 10s)
+	ExpiryBatchSize int           // Reservations released per transaction (default: 50)
+	// Admin job messages
+	RefreshAdminMessages bool // Re-render the stored admin messages of open jobs on startup (default: false)
+	// Admin job list
+	JobListPageSize int // Jobs per page of "📋 Ishlar ro'yxati" (default: 20)
+	// Channel subscription required for booking
+	RequireSubscription bool   // Registered users must be channel members to book (the bot must be a channel admin)
+	ChannelURL          string // Public or invite link of the channel for the "A'zo bo'lish" button
+	// Live booking status message in the user's chat
+	BookingTracker bool // Keep one status message per booking and edit it on every change (default: false)
+	// Nightly cleanup of old data
+	RetentionDays int // Delete finished unpaid bookings, registration drafts and admin messages of closed jobs older than this (0 disables)
+	RetentionHour int // Local hour (0-23) of the nightly cleanup (default: 4)
 }
 
 // DatabaseConfig contains database configuration
 type DatabaseConfig struct {
+	Driver         string // "postgres" or "sqlite"
+	SQLitePath     string // Database file path (only used when Driver is "sqlite")
 	Host           string
 	Port           int
 	User           string
 	Password       string
 	DBName         string
 	MaxConnections int
+	JobCacheTTL    time.Duration // How long job reads are cached in process; 0 disables the cache
+	// Connection pool (PostgreSQL only)
+	MinConnections    int           // Connections kept open when idle (default: a third of MaxConnections)
+	HealthCheckPeriod time.Duration // How often idle connections are checked (default: 1m)
+	StatementTimeout  time.Duration // statement_timeout of every connection (default: 30s, 0 = none)
+	LockTimeout       time.Duration // lock_timeout of every connection (default: 10s, 0 = none)
+	ConnectWait       time.Duration // Keep retrying the first connection this long, e.g. while Docker starts the database (default: 1m, 0 = fail at once)
+	// Read replica (PostgreSQL only)
+	ReadDSN string // Connection string of a streaming replica serving lag-tolerant reads (empty = all queries go to the primary)
+	// Query tracing (PostgreSQL only)
+	SlowQuery          time.Duration // Log statements running at least this long (default: 200ms, 0 disables)
+	QueryStatsInterval time.Duration // Log per-repository query latencies this often (default: 0, disabled)
+	// Encryption of phones and passport photo IDs in the registration tables
+	EncryptionKey     string // Base64 32-byte AES key (empty = stored in plaintext)
+	EncryptionKeyFile string // File holding the key instead, e.g. a secret mounted from a KMS or secret manager
 }
 
 // AppConfig contains general application configuration
 type AppConfig struct {
 	Environment string
 	LogLevel    string
+	Timezone    string // IANA zone for user-facing dates and times (default: Asia/Tashkent)
+	SentryDSN   string // Sentry-compatible error tracker receiving Error and Fatal log entries (empty disables)
+
+	JobNumberFormat string // "global" (default) or "daily" numbering in job labels
+	JobNumberPrefix string // Optional prefix shown before every job number
+
+	ChannelPostStyle    string // "rich" (default) or "plain" channel job posts
+	ChannelPostHashtags bool   // Append search hashtags to channel job posts
+
+	CurrencyLabel string // Written after every amount, e.g. "so'm" (default) or "UZS"
 }
 
 // PaymentConfig contains payment specific configuration
 type PaymentConfig struct {
 	CardNumber     string
 	CardHolderName string
+
+	// Rejected receipts the user may replace while keeping the slot (0 = every rejection releases the slot)
+	ResubmitAttempts int
+	// How long the slot stays held after a retryable rejection
+	ResubmitWindow time.Duration
+
+	// Ask the user to type the transferred amount when the receipt caption has none
+	AskAmount bool
+}
+
+// RegistrationConfig controls the optional registration steps
+type RegistrationConfig struct {
+	AskGender        bool // Ask for the gender after weight/height
+	AskCity          bool // Ask for the city after weight/height (and gender)
+	AskPassportPhoto bool // Ask for a passport photo before the confirmation summary
+}
+
+// BookingConfig contains booking limits
+type BookingConfig struct {
+	// Allow one active booking per job for each registered phone, across Telegram accounts
+	OnePerPhone bool
+	// Bookings a user may have in progress (reserved or under review) on different jobs at once
+	MaxActive int
+}
+
+// SandboxConfig redirects outgoing traffic so admins can rehearse flows on production data.
+// Channel posts go to ChannelID, group notifications to GroupID, and messages meant for
+// users end up in GroupID too; everything sent is watermarked.
+type SandboxConfig struct {
+	Enabled   bool
+	ChannelID int64 // Test channel that receives job posts
+	GroupID   int64 // Test admin group that receives payments, ops messages and redirected user notifications
+}
+
+// ArchiveConfig points the receipt archive at an S3-compatible bucket (AWS S3, MinIO, R2, ...).
+// Approved payment receipts are copied there; an empty Bucket turns archiving off.
+type ArchiveConfig struct {
+	Endpoint  string // e.g. https://s3.eu-central-1.amazonaws.com
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Prefix    string        // Object key prefix, e.g. "receipts/"
+	PublicURL string        // Base of the URL recorded on the booking; defaults to Endpoint/Bucket
+	Interval  time.Duration // How often the worker looks for new approved receipts
+}
+
+// Enabled reports whether receipts are archived
+func (a ArchiveConfig) Enabled() bool {
+	return a.Bucket != ""
+}
+
+// BackupConfig sets up the super admins' /backup and /backup_status commands. With WebhookURL set,
+// /backup asks the external backup system for a backup; otherwise the bot runs pg_dump into Dir.
+// /backup_status also looks for the newest dump in Dir, so pointing it at the directory of
+// scripts/backup_postgres.sh makes the cron backups count as well.
+type BackupConfig struct {
+	Dir          string        // Backup directory; /backup writes to its "manual" subdirectory
+	WebhookURL   string        // POSTed to instead of running pg_dump
+	WebhookToken string        // Sent as "Authorization: Bearer <token>" to WebhookURL
+	PgDump       string        // pg_dump binary, e.g. /usr/bin/pg_dump
+	Timeout      time.Duration // Max duration of one /backup run
+	MaxAge       time.Duration // /backup_status warns when the last successful backup is older
+}
+
+// Enabled reports whether /backup can take backups
+func (b BackupConfig) Enabled() bool {
+	return b.Dir != "" || b.WebhookURL != ""
 }
 
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
+	envErrors = nil
 
 	if err := godotenv.Load("/app/.env"); err != nil {
 		if err := godotenv.Load(".env"); err != nil {
@@ -75,39 +250,181 @@ func Load() (*Config, error) {
 			Poller:               getEnvAsDuration("BOT_POLLER", 10*time.Second),
 			ChannelID:            getEnvAsInt64("BOT_CHANNEL_ID", 0),
 			AdminIDs:             getEnvAsInt64Slice("BOT_ADMIN_IDS", nil),
+			SuperAdminIDs:        getEnvAsInt64Slice("BOT_SUPER_ADMIN_IDS", nil),
 			AdminGroupID:         getEnvAsInt64("BOT_ADMIN_GROUP_ID", 0),
+			PaymentsGroupID:      getEnvAsInt64("BOT_PAYMENTS_GROUP_ID", 0),
+			OpsGroupID:           getEnvAsInt64("BOT_OPS_GROUP_ID", 0),
+			ErrorChatID:          getEnvAsInt64("BOT_ERROR_CHAT_ID", 0),
 			Username:             getEnv("BOT_USERNAME", ""),
-			Mode:                 getEnv("BOT_MODE", "polling"),
+			Mode:                 strings.ToLower(getEnv("BOT_MODE", ModePolling)),
 			WebhookURL:           getEnv("BOT_WEBHOOK_URL", ""),
 			WebhookPort:          getEnvAsInt("BOT_WEBHOOK_PORT", 8443),
+			WebhookPath:          getEnv("BOT_WEBHOOK_PATH", "/"),
+			WebhookSecret:        getEnv("BOT_WEBHOOK_SECRET", ""),
+			WebhookCertFile:      getEnv("BOT_WEBHOOK_CERT", ""),
+			WebhookKeyFile:       getEnv("BOT_WEBHOOK_KEY", ""),
+			WebhookSelfSigned:    getEnvAsBool("BOT_WEBHOOK_SELF_SIGNED", false),
+			WebhookRegister:      getEnvAsBool("BOT_WEBHOOK_REGISTER", true),
+			WebhookDropPending:   getEnvAsBool("BOT_WEBHOOK_DROP_PENDING", false),
+			WebhookWatchdog:      getEnvAsDuration("BOT_WEBHOOK_WATCHDOG", 15*time.Minute),
+			WebhookFallback:      getEnvAsBool("BOT_WEBHOOK_FALLBACK_POLLING", false),
 			RateLimitMaxRequests: getEnvAsInt("BOT_RATE_LIMIT_MAX", 30),
 			RateLimitWindow:      getEnvAsDuration("BOT_RATE_LIMIT_WINDOW", 60*time.Second),
+			UpdateTimeout:        getEnvAsDuration("BOT_UPDATE_TIMEOUT", 30*time.Second),
+			CallbackDedupeWindow: getEnvAsDuration("BOT_CALLBACK_DEDUPE_WINDOW", 2*time.Second),
+			DigestHour:           getEnvAsInt("BOT_DIGEST_HOUR", 8),
+			DigestToGroup:        getEnvAsBool("BOT_DIGEST_TO_GROUP", false),
+			CheckInQR:            getEnvAsBool("BOT_CHECKIN_QR", true),
+			StaticMapURL:         getEnv("BOT_STATIC_MAP_URL", ""),
+			DiscussionGroupID:    getEnvAsInt64("BOT_DISCUSSION_GROUP_ID", 0),
+			DiscussionAutoReply:  getEnvAsBool("BOT_DISCUSSION_AUTO_REPLY", false),
+			StaleStateTTL:        getEnvAsDuration("BOT_STALE_STATE_TTL", 48*time.Hour),
+			StaleStateNotify:     getEnvAsBool("BOT_STALE_STATE_NOTIFY", true),
+			SlotCheckHour:        getEnvAsInt("BOT_SLOT_CHECK_HOUR", 3),
+			SlotDriftAlert:       getEnvAsInt("BOT_SLOT_DRIFT_ALERT", 2),
+			UnknownTextHint:      getEnvAsInt("BOT_UNKNOWN_TEXT_HINT", 2),
+			ReopenNotice:         getEnvAsBool("BOT_REOPEN_NOTICE", true),
+			PaymentSLA:           getEnvAsDuration("BOT_PAYMENT_SLA", 15*time.Minute),
+			ReengageDays:         getEnvAsInt("BOT_REENGAGE_DAYS", 0),
+			ReengageHour:         getEnvAsInt("BOT_REENGAGE_HOUR", 11),
+			ChannelIndex:         getEnvAsBool("BOT_CHANNEL_INDEX", false),
+			ChannelIndexInterval: getEnvAsDuration("BOT_CHANNEL_INDEX_INTERVAL", 5*time.Minute),
+			BumpBefore:           getEnvAsDuration("BOT_BUMP_BEFORE", 0),
+			BumpMax:              getEnvAsInt("BOT_BUMP_MAX", 1),
+			BumpInterval:         getEnvAsDuration("BOT_BUMP_INTERVAL", 3*time.Hour),
+			ChannelPostDelay:     getEnvAsDuration("BOT_CHANNEL_POST_DELAY", 3*time.Second),
+			ChannelEditWindow:    getEnvAsDuration("BOT_CHANNEL_EDIT_WINDOW", 2*time.Second),
+			ExpiryInterval:       getEnvAsDuration("BOT_EXPIRY_INTERVAL", 10*time.Second),
+			ExpiryBatchSize:      getEnvAsInt("BOT_EXPIRY_BATCH_SIZE", 50),
+			RefreshAdminMessages: getEnvAsBool("BOT_REFRESH_ADMIN_MESSAGES", false),
+			JobListPageSize:      getEnvAsInt("BOT_JOB_LIST_PAGE_SIZE", 20),
+			RequireSubscription:  getEnvAsBool("BOT_REQUIRE_SUBSCRIPTION", false),
+			ChannelURL:           getEnv("BOT_CHANNEL_URL", ""),
+			BookingTracker:       getEnvAsBool("BOT_BOOKING_TRACKER", false),
+			RetentionDays:        getEnvAsInt("BOT_RETENTION_DAYS", 0),
+			RetentionHour:        getEnvAsInt("BOT_RETENTION_HOUR", 4),
 		},
 		Database: DatabaseConfig{
+			Driver:         getEnv("STORAGE_DRIVER", DriverPostgres),
+			SQLitePath:     getEnv("SQLITE_PATH", "ishchi_bot.db"),
 			Host:           getEnv("DB_HOST", "localhost"),
 			Port:           getEnvAsInt("DB_PORT", 5432),
 			User:           getEnv("DB_USER", "postgres"),
 			Password:       getEnv("DB_PASSWORD", ""),
 			DBName:         getEnv("DB_NAME", "telegram_bot"),
 			MaxConnections: getEnvAsInt("DB_MAX_CONNECTIONS", 25),
+			JobCacheTTL:    getEnvAsDuration("DB_JOB_CACHE_TTL", 5*time.Second),
+
+			MinConnections:    getEnvAsInt("DB_MIN_CONNECTIONS", -1),
+			HealthCheckPeriod: getEnvAsDuration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+			StatementTimeout:  getEnvAsDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+			LockTimeout:       getEnvAsDuration("DB_LOCK_TIMEOUT", 10*time.Second),
+			ConnectWait:       getEnvAsDuration("DB_CONNECT_WAIT", time.Minute),
+
+			ReadDSN: getEnv("DB_READ_DSN", ""),
+
+			SlowQuery:          getEnvAsDuration("DB_SLOW_QUERY", 200*time.Millisecond),
+			QueryStatsInterval: getEnvAsDuration("DB_QUERY_STATS_INTERVAL", 0),
+
+			EncryptionKey:     getEnv("DB_ENCRYPTION_KEY", ""),
+			EncryptionKeyFile: getEnv("DB_ENCRYPTION_KEY_FILE", ""),
 		},
 		App: AppConfig{
 			Environment: getEnv("APP_ENV", "development"),
 			LogLevel:    getEnv("LOG_LEVEL", "info"),
+			Timezone:    getEnv("APP_TIMEZONE", DefaultTimezone),
+			SentryDSN:   getEnv("SENTRY_DSN", ""),
+
+			JobNumberFormat: getEnv("JOB_NUMBER_FORMAT", JobNumberGlobal),
+			JobNumberPrefix: getEnv("JOB_NUMBER_PREFIX", ""),
+
+			ChannelPostStyle:    strings.ToLower(getEnv("CHANNEL_POST_STYLE", ChannelPostRich)),
+			ChannelPostHashtags: getEnvAsBool("CHANNEL_POST_HASHTAGS", false),
+
+			CurrencyLabel: getEnv("CURRENCY_LABEL", DefaultCurrency),
 		},
 		Payment: PaymentConfig{
 			CardNumber:     getEnv("CARD_NUMBER", "8600 0000 0000 0000"),
 			CardHolderName: getEnv("CARD_HOLDER_NAME", "ADMIN NAME"),
+
+			ResubmitAttempts: getEnvAsInt("PAYMENT_RESUBMIT_ATTEMPTS", 2),
+			ResubmitWindow:   getEnvAsDuration("PAYMENT_RESUBMIT_WINDOW", 10*time.Minute),
+			AskAmount:        getEnvAsBool("PAYMENT_ASK_AMOUNT", false),
+		},
+		Registration: RegistrationConfig{
+			AskGender:        getEnvAsBool("REGISTRATION_ASK_GENDER", false),
+			AskCity:          getEnvAsBool("REGISTRATION_ASK_CITY", false),
+			AskPassportPhoto: getEnvAsBool("REGISTRATION_ASK_PASSPORT_PHOTO", false),
 		},
+		Booking: BookingConfig{
+			OnePerPhone: getEnvAsBool("BOOKING_ONE_PER_PHONE", false),
+			MaxActive:   getEnvAsInt("BOOKING_MAX_ACTIVE", 1),
+		},
+		Sandbox: SandboxConfig{
+			Enabled:   getEnvAsBool("SANDBOX_MODE", false),
+			ChannelID: getEnvAsInt64("SANDBOX_CHANNEL_ID", 0),
+			GroupID:   getEnvAsInt64("SANDBOX_GROUP_ID", 0),
+		},
+		Archive: ArchiveConfig{
+			Endpoint:  getEnv("ARCHIVE_S3_ENDPOINT", ""),
+			Region:    getEnv("ARCHIVE_S3_REGION", "us-east-1"),
+			Bucket:    getEnv("ARCHIVE_S3_BUCKET", ""),
+			AccessKey: getEnv("ARCHIVE_S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("ARCHIVE_S3_SECRET_KEY", ""),
+			Prefix:    getEnv("ARCHIVE_S3_PREFIX", "receipts/"),
+			PublicURL: getEnv("ARCHIVE_PUBLIC_URL", ""),
+			Interval:  getEnvAsDuration("ARCHIVE_INTERVAL", 5*time.Minute),
+		},
+		Backup: BackupConfig{
+			Dir:          getEnv("BACKUP_DIR", ""),
+			WebhookURL:   getEnv("BACKUP_WEBHOOK_URL", ""),
+			WebhookToken: getEnv("BACKUP_WEBHOOK_TOKEN", ""),
+			PgDump:       getEnv("BACKUP_PG_DUMP", "pg_dump"),
+			Timeout:      getEnvAsDuration("BACKUP_TIMEOUT", 30*time.Minute),
+			MaxAge:       getEnvAsDuration("BACKUP_MAX_AGE", 26*time.Hour),
+		},
+	}
+
+	if len(cfg.Bot.SuperAdminIDs) == 0 {
+		cfg.Bot.SuperAdminIDs = cfg.Bot.AdminIDs
+	}
+	if cfg.Database.MinConnections < 0 {
+		cfg.Database.MinConnections = cfg.Database.MaxConnections / 3
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Sandbox.Enabled {
+		cfg.Bot.applySandbox(cfg.Sandbox)
 	}
 
-	if cfg.Bot.Token == "" {
-		return nil, fmt.Errorf("BOT_TOKEN environment variable is required")
+	if err := SetTimezone(cfg.App.Timezone); err != nil {
+		return nil, err
+	}
+
+	if err := SetJobNumbering(cfg.App.JobNumberFormat, cfg.App.JobNumberPrefix); err != nil {
+		return nil, err
+	}
+	if err := SetChannelPost(cfg.App.ChannelPostStyle, cfg.App.ChannelPostHashtags); err != nil {
+		return nil, err
+	}
+	if err := SetCurrency(cfg.App.CurrencyLabel); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
+// envErrors collects variables that were set but could not be parsed; Validate reports them
+var envErrors []error
+
+// invalidEnv records a malformed variable; the default is used until Validate rejects the config
+func invalidEnv(key, value, kind string) {
+	envErrors = append(envErrors, fmt.Errorf("%s=%q is not a valid %s", key, value, kind))
+}
+
 // Helper functions to read environment variables with defaults
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -118,34 +435,54 @@ func getEnv(key, defaultValue string) string {
 
 func getEnvAsInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
-	if value, err := strconv.Atoi(valueStr); err == nil {
-		return value
+	if valueStr == "" {
+		return defaultValue
 	}
-	return defaultValue
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		invalidEnv(key, valueStr, "integer")
+		return defaultValue
+	}
+	return value
 }
 
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := os.Getenv(key)
-	if value, err := strconv.ParseBool(valueStr); err == nil {
-		return value
+	if valueStr == "" {
+		return defaultValue
 	}
-	return defaultValue
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		invalidEnv(key, valueStr, "boolean")
+		return defaultValue
+	}
+	return value
 }
 
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := os.Getenv(key)
-	if value, err := time.ParseDuration(valueStr); err == nil {
-		return value
+	if valueStr == "" {
+		return defaultValue
 	}
-	return defaultValue
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		invalidEnv(key, valueStr, "duration (e.g. 90s, 15m, 2h)")
+		return defaultValue
+	}
+	return value
 }
 
 func getEnvAsInt64(key string, defaultValue int64) int64 {
 	valueStr := os.Getenv(key)
-	if value, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
-		return value
+	if valueStr == "" {
+		return defaultValue
 	}
-	return defaultValue
+	value, err := strconv.ParseInt(valueStr, 10, 64)
+	if err != nil {
+		invalidEnv(key, valueStr, "integer")
+		return defaultValue
+	}
+	return value
 }
 
 func getEnvAsInt64Slice(key string, defaultValue []int64) []int64 {
@@ -158,13 +495,75 @@ func getEnvAsInt64Slice(key string, defaultValue []int64) []int64 {
 	result := make([]int64, 0, len(parts))
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
-		if value, err := strconv.ParseInt(part, 10, 64); err == nil {
-			result = append(result, value)
+		if part == "" {
+			continue
 		}
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			invalidEnv(key, part, "integer ID")
+			continue
+		}
+		result = append(result, value)
 	}
 	return result
 }
 
+// applySandbox points the channel and every admin group at the sandbox chats
+func (b *BotConfig) applySandbox(sb SandboxConfig) {
+	b.ChannelID = sb.ChannelID
+	b.AdminGroupID = sb.GroupID
+	b.PaymentsGroupID = 0 // fall back to AdminGroupID
+	b.OpsGroupID = 0
+	b.ErrorChatID = 0
+	b.DiscussionGroupID = 0
+	b.DiscussionAutoReply = false // the real discussion group is linked to the real channel
+}
+
+// PaymentsChatID returns the group for payment approvals, falling back to the single admin group
+func (b BotConfig) PaymentsChatID() int64 {
+	if b.PaymentsGroupID != 0 {
+		return b.PaymentsGroupID
+	}
+	return b.AdminGroupID
+}
+
+// OpsChatID returns the group for operational messages, falling back to the single admin group
+func (b BotConfig) OpsChatID() int64 {
+	if b.OpsGroupID != 0 {
+		return b.OpsGroupID
+	}
+	return b.AdminGroupID
+}
+
+// ErrorReportChatID returns the chat for panic reports, falling back to the ops group (0 = no reports)
+func (b BotConfig) ErrorReportChatID() int64 {
+	if b.ErrorChatID != 0 {
+		return b.ErrorChatID
+	}
+	return b.OpsChatID()
+}
+
+// Cipher returns the cipher for the encrypted registration columns, or nil when no key is configured
+func (d *DatabaseConfig) Cipher() (*fieldcrypt.Cipher, error) {
+	encoded := d.EncryptionKey
+	if d.EncryptionKeyFile != "" {
+		data, err := os.ReadFile(d.EncryptionKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DB_ENCRYPTION_KEY_FILE: %w", err)
+		}
+		encoded = string(data)
+	}
+	if encoded == "" {
+		return nil, nil
+	}
+
+	key, err := fieldcrypt.ParseKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return fieldcrypt.New(key)
+}
+
 // DSN returns the PostgreSQL connection string
 func (d *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
@@ -1,11 +1,13 @@
 package config
 
-import "time"
+// Supported storage drivers (STORAGE_DRIVER)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
 
-// Timezone is the application timezone (Uzbekistan, UTC+5)
-var Timezone = time.FixedZone("Asia/Tashkent", 5*60*60)
-
-// NowLocal returns current time in the configured timezone
-func NowLocal() time.Time {
-	return time.Now().In(Timezone)
-}
+// Supported update delivery modes (BOT_MODE)
+const (
+	ModePolling = "polling"
+	ModeWebhook = "webhook"
+)
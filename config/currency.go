@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCurrency is the label written after amounts unless CURRENCY_LABEL says otherwise
+const DefaultCurrency = "so'm"
+
+// Currency is the label shown after every amount in messages, e.g. "10 000 so'm".
+// Load sets it from CURRENCY_LABEL.
+var Currency = DefaultCurrency
+
+// SetCurrency validates and applies the currency label
+func SetCurrency(label string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return fmt.Errorf("CURRENCY_LABEL must not be empty")
+	}
+	Currency = label
+	return nil
+}
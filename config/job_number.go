@@ -0,0 +1,30 @@
+package config
+
+import "fmt"
+
+// Job number display modes (JOB_NUMBER_FORMAT)
+const (
+	// JobNumberGlobal shows the ever-growing order number: №1042
+	JobNumberGlobal = "global"
+	// JobNumberDaily restarts at 1 every day and shows the creation date: №2024-06-18/#3
+	JobNumberDaily = "daily"
+)
+
+// JobNumbering decides how job numbers are shown in the channel, admin views and user messages.
+// Load sets it from JOB_NUMBER_FORMAT and JOB_NUMBER_PREFIX.
+var JobNumbering = JobNumberConfig{Format: JobNumberGlobal}
+
+// JobNumberConfig is the job number display setting
+type JobNumberConfig struct {
+	Format string // JobNumberGlobal or JobNumberDaily
+	Prefix string // prepended to every number, e.g. "T-" → №T-1042
+}
+
+// SetJobNumbering validates and applies the job number display setting
+func SetJobNumbering(format, prefix string) error {
+	if format != JobNumberGlobal && format != JobNumberDaily {
+		return fmt.Errorf("unsupported JOB_NUMBER_FORMAT %q (expected %q or %q)", format, JobNumberGlobal, JobNumberDaily)
+	}
+	JobNumbering = JobNumberConfig{Format: format, Prefix: prefix}
+	return nil
+}
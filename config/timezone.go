@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	// Embedded zone database so APP_TIMEZONE works on hosts without tzdata
+	_ "time/tzdata"
+)
+
+// DefaultTimezone is used when APP_TIMEZONE is not set
+const DefaultTimezone = "Asia/Tashkent"
+
+// Timezone is the application timezone for every user-facing date and time.
+// Load sets it from APP_TIMEZONE; storage keeps UTC and converts with In(Timezone).
+var Timezone = time.FixedZone(DefaultTimezone, 5*60*60)
+
+// now is the time source behind NowLocal; tests replace it with SetClock
+var now = time.Now
+
+// NowLocal returns current time in the configured timezone
+func NowLocal() time.Time {
+	return now().In(Timezone)
+}
+
+// SetClock replaces the time source behind NowLocal and returns a function restoring the previous one
+func SetClock(fn func() time.Time) (restore func()) {
+	prev := now
+	now = fn
+	return func() { now = prev }
+}
+
+// SetTimezone loads an IANA zone name (e.g. "Asia/Tashkent") and makes it the application timezone
+func SetTimezone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid APP_TIMEZONE %q: %w", name, err)
+	}
+	Timezone = loc
+	return nil
+}
@@ -0,0 +1,2 @@
+-- Rollback: Drop admin_notification_prefs table
+DROP TABLE IF EXISTS admin_notification_prefs;
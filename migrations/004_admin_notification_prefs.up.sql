@@ -0,0 +1,16 @@
+-- ============================================
+-- Admin Notification Preferences Table
+-- One row per admin; missing row means defaults
+-- ============================================
+CREATE TABLE IF NOT EXISTS admin_notification_prefs (
+    admin_id BIGINT PRIMARY KEY,
+    new_jobs BOOLEAN NOT NULL DEFAULT TRUE,
+    payments BOOLEAN NOT NULL DEFAULT TRUE,
+    expirations BOOLEAN NOT NULL DEFAULT FALSE,
+    daily_digest BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_admin_notification_prefs_updated_at BEFORE UPDATE ON admin_notification_prefs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Rollback: Remove attendance, employer link and employers table
+ALTER TABLE job_bookings DROP COLUMN IF EXISTS attendance;
+
+DROP INDEX IF EXISTS idx_jobs_employer_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS employer_id;
+
+DROP TABLE IF EXISTS employers;
@@ -0,0 +1,24 @@
+-- ============================================
+-- Employers Table
+-- Jobs used to store only a free-text employer phone
+-- ============================================
+CREATE TABLE IF NOT EXISTS employers (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL UNIQUE,
+    notes TEXT,
+    rating INTEGER NOT NULL DEFAULT 0 CHECK (rating >= 0 AND rating <= 5), -- 0 = not rated
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_employers_updated_at BEFORE UPDATE ON employers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Link jobs to employers
+ALTER TABLE jobs ADD COLUMN employer_id BIGINT REFERENCES employers(id) ON DELETE SET NULL;
+CREATE INDEX idx_jobs_employer_id ON jobs(employer_id) WHERE employer_id IS NOT NULL;
+
+-- Attendance marked by admins after the work day (NULL = not marked)
+ALTER TABLE job_bookings ADD COLUMN attendance VARCHAR(20)
+    CHECK (attendance IN ('ATTENDED', 'NO_SHOW'));
@@ -0,0 +1,2 @@
+-- Rollback: Drop worker_ratings table
+DROP TABLE IF EXISTS worker_ratings;
@@ -0,0 +1,20 @@
+-- ============================================
+-- Worker Ratings Table
+-- Admins rate workers after marking attendance; one rating per booking
+-- ============================================
+CREATE TABLE IF NOT EXISTS worker_ratings (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    admin_id BIGINT NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating >= 1 AND rating <= 5),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_worker_ratings_user_id ON worker_ratings(user_id);
+CREATE INDEX idx_worker_ratings_job_id ON worker_ratings(job_id);
+
+CREATE TRIGGER update_worker_ratings_updated_at BEFORE UPDATE ON worker_ratings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Rollback: Drop job_feedback table
+DROP TABLE IF EXISTS job_feedback;
@@ -0,0 +1,21 @@
+-- ============================================
+-- Job Feedback Table
+-- Created when the prompt is sent to a confirmed worker after the work date;
+-- answers are NULL until the worker replies
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_feedback (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    employer_id BIGINT REFERENCES employers(id) ON DELETE SET NULL,
+    pay_correct BOOLEAN,
+    conditions_ok BOOLEAN,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_job_feedback_employer_id ON job_feedback(employer_id) WHERE employer_id IS NOT NULL;
+
+CREATE TRIGGER update_job_feedback_updated_at BEFORE UPDATE ON job_feedback
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Rollback: Drop audit_log table
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,16 @@
+-- ============================================
+-- Audit Log Table
+-- Append-only record of admin and system actions;
+-- actor_id is NULL for background workers
+-- ============================================
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT,
+    action VARCHAR(50) NOT NULL,
+    target_user_id BIGINT,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_target_user_id ON audit_log(target_user_id) WHERE target_user_id IS NOT NULL;
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
@@ -0,0 +1,2 @@
+-- Rollback: Drop booking_vouchers table
+DROP TABLE IF EXISTS booking_vouchers;
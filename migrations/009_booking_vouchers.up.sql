@@ -0,0 +1,12 @@
+-- ============================================
+-- Booking Vouchers Table
+-- Issued when a payment is approved; admins check the code on site with /verify
+-- ============================================
+CREATE TABLE IF NOT EXISTS booking_vouchers (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    verified_at TIMESTAMP,
+    verified_by_admin_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
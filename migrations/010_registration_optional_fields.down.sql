@@ -0,0 +1,5 @@
+-- Rollback: Drop optional registration fields
+ALTER TABLE registered_users ALTER COLUMN passport_photo_id DROP DEFAULT;
+ALTER TABLE registered_users DROP COLUMN IF EXISTS city;
+
+ALTER TABLE registration_drafts DROP COLUMN IF EXISTS city;
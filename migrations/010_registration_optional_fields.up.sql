@@ -0,0 +1,9 @@
+-- ============================================
+-- Optional registration fields
+-- City is asked only when REGISTRATION_ASK_CITY=true; the passport photo only
+-- when REGISTRATION_ASK_PASSPORT_PHOTO=true, so both default to ''
+-- ============================================
+ALTER TABLE registration_drafts ADD COLUMN IF NOT EXISTS city VARCHAR(100);
+
+ALTER TABLE registered_users ADD COLUMN IF NOT EXISTS city VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE registered_users ALTER COLUMN passport_photo_id SET DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_registered_users_phone_active;
+CREATE INDEX IF NOT EXISTS idx_registered_users_phone ON registered_users(phone);
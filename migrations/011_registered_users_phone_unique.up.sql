@@ -0,0 +1,15 @@
+-- ============================================
+-- One active registration per phone number
+-- Existing duplicates keep the earliest registration active; the later ones are
+-- deactivated so admins can merge them from the bot
+-- ============================================
+UPDATE registered_users r
+SET is_active = FALSE
+WHERE r.is_active
+  AND EXISTS (
+      SELECT 1 FROM registered_users o
+      WHERE o.phone = r.phone AND o.is_active AND o.id < r.id
+  );
+
+DROP INDEX IF EXISTS idx_registered_users_phone;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registered_users_phone_active ON registered_users(phone) WHERE is_active;
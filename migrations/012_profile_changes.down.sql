@@ -0,0 +1,2 @@
+ALTER TABLE admin_notification_prefs DROP COLUMN IF EXISTS profile_changes;
+DROP TABLE IF EXISTS profile_changes;
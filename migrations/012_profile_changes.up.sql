@@ -0,0 +1,17 @@
+-- ============================================
+-- Profile Changes Table
+-- Old and new value of every name/phone edit made from the profile;
+-- employers reach workers through this data
+-- ============================================
+CREATE TABLE IF NOT EXISTS profile_changes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_changes_user_id ON profile_changes(user_id);
+
+ALTER TABLE admin_notification_prefs ADD COLUMN IF NOT EXISTS profile_changes BOOLEAN NOT NULL DEFAULT TRUE;
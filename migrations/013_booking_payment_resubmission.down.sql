@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_job_bookings_expiry;
+CREATE INDEX idx_job_bookings_expiry ON job_bookings(expires_at, status)
+    WHERE status = 'SLOT_RESERVED';
+
+ALTER TABLE job_bookings DROP COLUMN IF EXISTS payment_rejections;
@@ -0,0 +1,6 @@
+-- ============================================
+-- Job Version
+-- Bumped by every job edit and status change; Update only succeeds when the
+-- caller's version still matches, so concurrent admin edits can't overwrite each other
+-- ============================================
+ALTER TABLE jobs ADD COLUMN version INT NOT NULL DEFAULT 1;
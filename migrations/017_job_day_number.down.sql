@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_order_day;
+ALTER TABLE jobs DROP COLUMN IF EXISTS day_number;
+ALTER TABLE jobs DROP COLUMN IF EXISTS order_day;
@@ -0,0 +1,20 @@
+-- ============================================
+-- Per-day Job Numbers
+-- order_day is the creation date in the app timezone; day_number restarts at 1
+-- each day and backs JOB_NUMBER_FORMAT=daily labels like 2024-06-18/#3
+-- ============================================
+ALTER TABLE jobs ADD COLUMN order_day VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN day_number INT NOT NULL DEFAULT 0;
+
+-- Existing jobs are numbered by their stored creation date
+UPDATE jobs j
+SET order_day = n.order_day, day_number = n.day_number
+FROM (
+    SELECT id,
+           TO_CHAR(created_at, 'YYYY-MM-DD') AS order_day,
+           ROW_NUMBER() OVER (PARTITION BY created_at::date ORDER BY created_at, id) AS day_number
+    FROM jobs
+) n
+WHERE j.id = n.id;
+
+CREATE INDEX idx_jobs_order_day ON jobs(order_day);
@@ -0,0 +1,5 @@
+-- ============================================
+-- Stale State Lookup
+-- Lets the state reset worker find users stuck in a flow without scanning idle users
+-- ============================================
+CREATE INDEX idx_users_stale_state ON users(state_updated_at) WHERE state <> 'idle';
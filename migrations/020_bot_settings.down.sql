@@ -0,0 +1,2 @@
+-- Rollback: Drop bot_settings table
+DROP TABLE IF EXISTS bot_settings;
@@ -0,0 +1,15 @@
+-- ============================================
+-- Bot Settings Table
+-- Runtime overrides of env settings (admin list, payment card) changed by
+-- super admins from "⚙️ Sozlamalar"; a missing key means the env value is used
+-- ============================================
+CREATE TABLE IF NOT EXISTS bot_settings (
+    key VARCHAR(64) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_bot_settings_updated_at BEFORE UPDATE ON bot_settings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- ============================================
+-- Receipt Archive
+-- URL of the approved payment receipt copied to object storage, so audits
+-- don't depend on Telegram file IDs staying valid
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN receipt_archive_url TEXT;
+
+-- Lets the archive worker find approved receipts that still need copying
+CREATE INDEX idx_job_bookings_receipt_unarchived ON job_bookings(confirmed_at)
+    WHERE receipt_archive_url IS NULL AND payment_receipt_file_id IS NOT NULL AND confirmed_at IS NOT NULL;
@@ -0,0 +1,13 @@
+-- ============================================
+-- Job Link Starts Table
+-- One row per /start job_<id> deep-link open; the first step of the
+-- per-job conversion funnel shown to admins
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_link_starts (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_job_link_starts_job_id ON job_link_starts(job_id);
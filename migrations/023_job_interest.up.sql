@@ -0,0 +1,15 @@
+-- ============================================
+-- Job Interest Table
+-- Users who opened a job's signup deep link while not registered; they get
+-- one reminder to finish registration and are counted per job for admins
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_interest (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    nudged_at TIMESTAMP,
+    UNIQUE (job_id, user_id)
+);
+
+CREATE INDEX idx_job_interest_pending ON job_interest(created_at) WHERE nudged_at IS NULL;
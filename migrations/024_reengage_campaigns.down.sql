@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_job_link_starts_user_id;
+DROP TABLE IF EXISTS reengage_opt_outs;
+DROP TABLE IF EXISTS reengage_recipients;
+DROP TABLE IF EXISTS reengage_campaigns;
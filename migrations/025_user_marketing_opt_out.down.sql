@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reengage_opt_outs (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO reengage_opt_outs (user_id)
+SELECT id FROM users WHERE marketing_opt_out;
+
+ALTER TABLE users DROP COLUMN IF EXISTS marketing_opt_out;
@@ -0,0 +1,12 @@
+-- ============================================
+-- User Notification Preferences
+-- Workers may turn off marketing messages (re-engagement campaigns and other
+-- broadcasts); transactional messages such as payment results and reminders
+-- are always sent. Replaces the campaign-only reengage_opt_outs table.
+-- ============================================
+ALTER TABLE users ADD COLUMN IF NOT EXISTS marketing_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE users SET marketing_opt_out = TRUE
+WHERE id IN (SELECT user_id FROM reengage_opt_outs);
+
+DROP TABLE IF EXISTS reengage_opt_outs;
@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN IF EXISTS payment_receipt_extra_file_ids;
@@ -0,0 +1,7 @@
+-- ============================================
+-- Receipt Albums
+-- A receipt sent as an album (e.g. two screenshots of one transfer) is one
+-- submission. The first photo stays in payment_receipt_file_id; the rest are
+-- kept here, comma-separated, so admins see the whole album.
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN IF NOT EXISTS payment_receipt_extra_file_ids TEXT;
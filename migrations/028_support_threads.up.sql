@@ -0,0 +1,15 @@
+-- ============================================
+-- Support Threads
+-- A user's conversation with the admins via "✉️ Adminga yozish". Messages
+-- are relayed through the admin group; a user has at most one open thread.
+-- ============================================
+CREATE TABLE IF NOT EXISTS support_threads (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'OPEN',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP,
+    closed_by BIGINT
+);
+
+CREATE UNIQUE INDEX idx_support_threads_open_user ON support_threads(user_id) WHERE status = 'OPEN';
@@ -0,0 +1,19 @@
+-- ============================================
+-- Refunds
+-- Service fees owed back to workers whose paid booking was cancelled with
+-- the job ("🚫 Ishni bekor qilish"). No foreign keys: the record must
+-- outlive the job and its bookings if they are deleted later.
+-- ============================================
+CREATE TABLE IF NOT EXISTS refunds (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL UNIQUE,
+    job_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    amount INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP,
+    paid_by BIGINT
+);
+
+CREATE INDEX idx_refunds_pending ON refunds(created_at) WHERE status = 'PENDING';
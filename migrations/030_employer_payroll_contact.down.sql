@@ -0,0 +1 @@
+ALTER TABLE employers DROP COLUMN IF EXISTS contact_chat_id;
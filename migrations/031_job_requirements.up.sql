@@ -0,0 +1,19 @@
+-- ============================================
+-- Job Requirements
+-- Optional worker requirements checked against the registered profile when
+-- a user books the job (0 = no requirement). An admin can let a particular
+-- user through anyway; that waiver is kept per job and user so it survives
+-- the user booking the job again.
+-- ============================================
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS min_age INT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS max_age INT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS min_height INT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS min_weight INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS job_requirement_waivers (
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    waived_by_admin_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, user_id)
+);
@@ -0,0 +1,6 @@
+-- Rollback: Drop gender columns
+ALTER TABLE jobs DROP COLUMN IF EXISTS gender;
+
+ALTER TABLE registered_users DROP COLUMN IF EXISTS gender;
+
+ALTER TABLE registration_drafts DROP COLUMN IF EXISTS gender;
@@ -0,0 +1,11 @@
+-- ============================================
+-- Gender
+-- Asked during registration only when REGISTRATION_ASK_GENDER=true, so it
+-- defaults to '' (unknown). A job's gender requirement ('' = anyone) is
+-- screened at booking like the other job requirements.
+-- ============================================
+ALTER TABLE registration_drafts ADD COLUMN IF NOT EXISTS gender VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE registered_users ADD COLUMN IF NOT EXISTS gender VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS gender VARCHAR(10) NOT NULL DEFAULT '';
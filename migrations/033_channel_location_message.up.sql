@@ -0,0 +1,7 @@
+-- ============================================
+-- Channel location message
+-- The map preview or location pin posted as a reply to a job's channel post.
+-- Kept so it can be deleted when the job closes or its post is removed
+-- (0 = none).
+-- ============================================
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS channel_location_message_id BIGINT NOT NULL DEFAULT 0;
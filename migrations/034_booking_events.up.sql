@@ -0,0 +1,17 @@
+-- ============================================
+-- Booking events
+-- Every booking status change with who made it, so admins can see how a
+-- booking got where it is (/booking <id>). actor_id is the user or admin;
+-- 0 when the bot did it (e.g. the expiry worker).
+-- ============================================
+CREATE TABLE IF NOT EXISTS booking_events (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES job_bookings(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL DEFAULT '',
+    to_status VARCHAR(50) NOT NULL,
+    actor_id BIGINT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_booking_events_booking ON booking_events(booking_id, id);
@@ -0,0 +1,13 @@
+-- ============================================
+-- Job Bumps Table
+-- One row per repost of an under-subscribed job to the channel shortly
+-- before its work date; limits the reposts per job and spaces them out
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_bumps (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_job_bumps_job_id ON job_bumps(job_id);
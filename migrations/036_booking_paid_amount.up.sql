@@ -0,0 +1,7 @@
+-- ============================================
+-- Paid Amount
+-- The amount the user typed when submitting the receipt (PAYMENT_ASK_AMOUNT);
+-- the admin card highlights it when it differs from the job's service fee.
+-- 0 = not asked or skipped.
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN IF NOT EXISTS paid_amount INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN IF EXISTS status_message_id;
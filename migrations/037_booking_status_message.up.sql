@@ -0,0 +1,6 @@
+-- ============================================
+-- Booking Status Message
+-- The user's status message for the booking (BOT_BOOKING_TRACKER),
+-- edited as the booking moves from reservation to the admin's decision.
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN IF NOT EXISTS status_message_id BIGINT;
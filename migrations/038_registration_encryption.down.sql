@@ -0,0 +1,9 @@
+-- Decrypt the rows first (cmd/encryptpii -decrypt): encrypted values don't fit the old columns
+DROP INDEX IF EXISTS idx_registered_users_phone_hash_active;
+DROP INDEX IF EXISTS idx_registered_users_phone_hash;
+ALTER TABLE registered_users DROP COLUMN IF EXISTS phone_hash;
+
+ALTER TABLE registration_drafts ALTER COLUMN passport_photo_id TYPE VARCHAR(255);
+ALTER TABLE registration_drafts ALTER COLUMN phone TYPE VARCHAR(50);
+ALTER TABLE registered_users ALTER COLUMN passport_photo_id TYPE VARCHAR(255);
+ALTER TABLE registered_users ALTER COLUMN phone TYPE VARCHAR(50);
@@ -0,0 +1,17 @@
+-- ============================================
+-- Encrypted Registration Data
+-- With DB_ENCRYPTION_KEY set, phones and passport photo IDs are stored
+-- AES-GCM encrypted ("enc1:..."), which is longer than the plaintext.
+-- phone_hash is a keyed hash of the phone so encrypted phones can still be
+-- looked up and kept unique among active registrations; it stays NULL for
+-- plaintext rows, which are matched on phone as before.
+-- ============================================
+ALTER TABLE registered_users ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE registered_users ALTER COLUMN passport_photo_id TYPE TEXT;
+ALTER TABLE registration_drafts ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE registration_drafts ALTER COLUMN passport_photo_id TYPE TEXT;
+
+ALTER TABLE registered_users ADD COLUMN IF NOT EXISTS phone_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_registered_users_phone_hash ON registered_users(phone_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registered_users_phone_hash_active ON registered_users(phone_hash) WHERE is_active;
@@ -0,0 +1,4 @@
+-- Rollback: Drop caption variant columns
+ALTER TABLE job_link_starts DROP COLUMN IF EXISTS variant;
+ALTER TABLE jobs DROP COLUMN IF EXISTS caption_b;
+ALTER TABLE jobs DROP COLUMN IF EXISTS caption_a;
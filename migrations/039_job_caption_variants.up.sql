@@ -0,0 +1,11 @@
+-- ============================================
+-- Job Caption Variants
+-- Two optional captions an admin can test against each other: the channel
+-- post shows caption A and the reposts alternate B and A. Each signup link
+-- carries its variant, so deep-link starts are counted per caption.
+-- ('' = no caption / a link without a variant)
+-- ============================================
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS caption_a TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS caption_b TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE job_link_starts ADD COLUMN IF NOT EXISTS variant VARCHAR(1) NOT NULL DEFAULT '';
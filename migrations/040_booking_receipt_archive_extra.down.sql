@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN IF EXISTS receipt_archive_extra_urls;
@@ -0,0 +1,6 @@
+-- ============================================
+-- Receipt Archive Albums
+-- URLs of the archived copies of a receipt album's other photos, newline-separated;
+-- receipt_archive_url keeps the first photo's copy
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN IF NOT EXISTS receipt_archive_extra_urls TEXT;
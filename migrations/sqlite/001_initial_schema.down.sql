@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS admin_job_messages;
+DROP TABLE IF EXISTS blocked_users;
+DROP TABLE IF EXISTS user_violations;
+DROP TABLE IF EXISTS registered_users;
+DROP TABLE IF EXISTS registration_drafts;
+DROP TABLE IF EXISTS job_bookings;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS users;
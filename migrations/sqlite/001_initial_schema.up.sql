@@ -0,0 +1,209 @@
+-- ============================================
+-- SQLite Schema for Ishchi Bot (local development)
+-- Mirrors the PostgreSQL migrations in ../ up to 003_add_job_location
+-- ============================================
+
+-- ============================================
+-- Users Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY,
+    username TEXT,
+    first_name TEXT NOT NULL,
+    last_name TEXT,
+    state TEXT NOT NULL DEFAULT 'idle',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_users_username ON users(username);
+CREATE INDEX idx_users_state ON users(state);
+
+CREATE TRIGGER update_users_updated_at AFTER UPDATE ON users
+    WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- ============================================
+-- Jobs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- No sequences in SQLite: order numbers are assigned as MAX(order_number) + 1, starting at 1000
+    order_number INTEGER NOT NULL UNIQUE,
+
+    -- Job details
+    salary TEXT NOT NULL,
+    food TEXT,
+    work_time TEXT NOT NULL,
+    address TEXT NOT NULL,
+    location TEXT,
+    service_fee INTEGER NOT NULL,
+    buses TEXT,
+    additional_info TEXT,
+    work_date TEXT NOT NULL,
+    employer_phone TEXT,
+
+    -- Capacity management
+    required_workers INTEGER NOT NULL CHECK (required_workers > 0),
+    reserved_slots INTEGER NOT NULL DEFAULT 0 CHECK (reserved_slots >= 0),
+    confirmed_slots INTEGER NOT NULL DEFAULT 0 CHECK (confirmed_slots >= 0),
+
+    -- Status: DRAFT, ACTIVE, FULL, COMPLETED, CANCELLED
+    status TEXT NOT NULL DEFAULT 'DRAFT',
+
+    -- Telegram channel integration
+    channel_message_id INTEGER,
+    admin_message_id INTEGER,
+
+    -- Admin who created the job
+    created_by_admin_id INTEGER NOT NULL REFERENCES users(id),
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Critical constraint: prevent overbooking
+    CONSTRAINT check_slots_not_exceed_required CHECK (
+        reserved_slots + confirmed_slots <= required_workers
+    )
+);
+
+CREATE INDEX idx_jobs_status ON jobs(status);
+CREATE INDEX idx_jobs_created_at ON jobs(created_at DESC);
+CREATE INDEX idx_jobs_work_date ON jobs(work_date);
+
+CREATE TRIGGER update_jobs_updated_at AFTER UPDATE ON jobs
+    WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE jobs SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- ============================================
+-- Job Bookings Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_bookings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- State: SLOT_RESERVED, PAYMENT_SUBMITTED, CONFIRMED, REJECTED, EXPIRED, CANCELLED_BY_USER
+    status TEXT NOT NULL DEFAULT 'SLOT_RESERVED',
+
+    -- Payment tracking
+    payment_receipt_file_id TEXT,
+    payment_receipt_message_id INTEGER,
+    payment_instruction_message_id INTEGER,
+
+    -- Timing
+    reserved_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    payment_submitted_at TIMESTAMP,
+    confirmed_at TIMESTAMP,
+
+    -- Admin review
+    reviewed_by_admin_id INTEGER REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    rejection_reason TEXT,
+
+    -- Idempotency
+    idempotency_key TEXT UNIQUE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Only one active booking per user per job
+    CONSTRAINT unique_user_job_booking UNIQUE(job_id, user_id)
+);
+
+CREATE INDEX idx_job_bookings_job_id ON job_bookings(job_id);
+CREATE INDEX idx_job_bookings_user_id ON job_bookings(user_id);
+CREATE INDEX idx_job_bookings_status ON job_bookings(status);
+CREATE INDEX idx_job_bookings_expiry ON job_bookings(expires_at, status)
+    WHERE status = 'SLOT_RESERVED';
+
+CREATE TRIGGER update_job_bookings_updated_at AFTER UPDATE ON job_bookings
+    WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE job_bookings SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- ============================================
+-- Registration Tables
+-- ============================================
+CREATE TABLE IF NOT EXISTS registration_drafts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    state TEXT NOT NULL DEFAULT 'reg_public_offer',
+    previous_state TEXT NOT NULL DEFAULT 'reg_idle',
+    pending_job_id INTEGER REFERENCES jobs(id) ON DELETE SET NULL,
+    full_name TEXT,
+    phone TEXT,
+    age INTEGER,
+    weight INTEGER,
+    height INTEGER,
+    passport_photo_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_registration_drafts_state ON registration_drafts(state);
+
+CREATE TABLE IF NOT EXISTS registered_users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    full_name TEXT NOT NULL,
+    phone TEXT NOT NULL,
+    age INTEGER NOT NULL,
+    weight INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    passport_photo_id TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_registered_users_phone ON registered_users(phone);
+CREATE INDEX idx_registered_users_is_active ON registered_users(is_active);
+
+-- ============================================
+-- User Violations and Blocking Tables
+-- ============================================
+CREATE TABLE IF NOT EXISTS user_violations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    violation_type TEXT NOT NULL,
+    booking_id INTEGER REFERENCES job_bookings(id) ON DELETE SET NULL,
+    admin_id INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_violations_user_id ON user_violations(user_id);
+
+CREATE TABLE IF NOT EXISTS blocked_users (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    blocked_until TIMESTAMP, -- NULL means permanent block
+    total_violations INTEGER NOT NULL DEFAULT 0,
+    blocked_by_admin_id INTEGER,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- Admin Job Messages Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS admin_job_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    admin_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_admin_job_message UNIQUE(job_id, admin_id)
+);
+
+CREATE INDEX idx_admin_job_messages_admin_id ON admin_job_messages(admin_id);
@@ -0,0 +1,12 @@
+-- ============================================
+-- Admin Notification Preferences Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS admin_notification_prefs (
+    admin_id INTEGER PRIMARY KEY,
+    new_jobs BOOLEAN NOT NULL DEFAULT 1,
+    payments BOOLEAN NOT NULL DEFAULT 1,
+    expirations BOOLEAN NOT NULL DEFAULT 0,
+    daily_digest BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+ALTER TABLE job_bookings DROP COLUMN attendance;
+
+DROP INDEX IF EXISTS idx_jobs_employer_id;
+ALTER TABLE jobs DROP COLUMN employer_id;
+
+DROP TRIGGER IF EXISTS update_employers_updated_at;
+DROP TABLE IF EXISTS employers;
@@ -0,0 +1,27 @@
+-- ============================================
+-- Employers Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS employers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    phone TEXT NOT NULL UNIQUE,
+    notes TEXT,
+    rating INTEGER NOT NULL DEFAULT 0 CHECK (rating >= 0 AND rating <= 5), -- 0 = not rated
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_employers_updated_at AFTER UPDATE ON employers
+    WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE employers SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- Link jobs to employers.
+-- No REFERENCES clause: SQLite cannot drop a column that is part of a foreign key,
+-- which would make the down migration impossible. Employers are never deleted.
+ALTER TABLE jobs ADD COLUMN employer_id INTEGER;
+CREATE INDEX idx_jobs_employer_id ON jobs(employer_id) WHERE employer_id IS NOT NULL;
+
+-- Attendance marked by admins after the work day (NULL = not marked)
+ALTER TABLE job_bookings ADD COLUMN attendance TEXT;
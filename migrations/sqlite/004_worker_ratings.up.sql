@@ -0,0 +1,16 @@
+-- ============================================
+-- Worker Ratings Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS worker_ratings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    admin_id INTEGER NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating >= 1 AND rating <= 5),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_worker_ratings_user_id ON worker_ratings(user_id);
+CREATE INDEX idx_worker_ratings_job_id ON worker_ratings(job_id);
@@ -0,0 +1,16 @@
+-- ============================================
+-- Job Feedback Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    employer_id INTEGER REFERENCES employers(id) ON DELETE SET NULL,
+    pay_correct BOOLEAN,
+    conditions_ok BOOLEAN,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_feedback_employer_id ON job_feedback(employer_id) WHERE employer_id IS NOT NULL;
@@ -0,0 +1,14 @@
+-- ============================================
+-- Audit Log Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id INTEGER,
+    action TEXT NOT NULL,
+    target_user_id INTEGER,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_target_user_id ON audit_log(target_user_id) WHERE target_user_id IS NOT NULL;
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
@@ -0,0 +1,11 @@
+-- ============================================
+-- Booking Vouchers Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS booking_vouchers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES job_bookings(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    verified_at TIMESTAMP,
+    verified_by_admin_id INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
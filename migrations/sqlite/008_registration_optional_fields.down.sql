@@ -0,0 +1,4 @@
+-- Rollback: Drop optional registration fields
+ALTER TABLE registered_users DROP COLUMN city;
+
+ALTER TABLE registration_drafts DROP COLUMN city;
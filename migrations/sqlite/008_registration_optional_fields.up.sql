@@ -0,0 +1,6 @@
+-- ============================================
+-- Optional registration fields
+-- ============================================
+ALTER TABLE registration_drafts ADD COLUMN city TEXT;
+
+ALTER TABLE registered_users ADD COLUMN city TEXT NOT NULL DEFAULT '';
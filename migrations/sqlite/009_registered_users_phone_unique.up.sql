@@ -0,0 +1,14 @@
+-- ============================================
+-- One active registration per phone number
+-- Existing duplicates keep the earliest registration active
+-- ============================================
+UPDATE registered_users
+SET is_active = 0
+WHERE is_active = 1
+  AND EXISTS (
+      SELECT 1 FROM registered_users o
+      WHERE o.phone = registered_users.phone AND o.is_active = 1 AND o.id < registered_users.id
+  );
+
+DROP INDEX IF EXISTS idx_registered_users_phone;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registered_users_phone_active ON registered_users(phone) WHERE is_active = 1;
@@ -0,0 +1,2 @@
+ALTER TABLE admin_notification_prefs DROP COLUMN profile_changes;
+DROP TABLE IF EXISTS profile_changes;
@@ -0,0 +1,15 @@
+-- ============================================
+-- Profile Changes Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS profile_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field TEXT NOT NULL,
+    old_value TEXT NOT NULL DEFAULT '',
+    new_value TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_profile_changes_user_id ON profile_changes(user_id);
+
+ALTER TABLE admin_notification_prefs ADD COLUMN profile_changes BOOLEAN NOT NULL DEFAULT 1;
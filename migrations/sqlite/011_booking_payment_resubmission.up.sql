@@ -0,0 +1,11 @@
+-- ============================================
+-- Payment Resubmission
+-- A rejected receipt can be resent while the slot stays held
+-- (PAYMENT_REJECTED_RETRYABLE); payment_rejections counts those rounds
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN payment_rejections INTEGER NOT NULL DEFAULT 0;
+
+-- The expiry worker also releases retryable bookings whose resubmission window ran out
+DROP INDEX IF EXISTS idx_job_bookings_expiry;
+CREATE INDEX idx_job_bookings_expiry ON job_bookings(expires_at, status)
+    WHERE status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE');
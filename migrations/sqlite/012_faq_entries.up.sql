@@ -0,0 +1,24 @@
+-- ============================================
+-- FAQ Entries Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS faq_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    category TEXT NOT NULL,
+    question TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO faq_entries (category, question, answer) VALUES
+    ('Umumiy', 'Bot nima uchun kerak?', 'Bu bot orqali siz kunlik ish topishingiz va ish uchun yozilishingiz mumkin. Ish e''lonlari kanalimizda chiqadi.'),
+    ('Umumiy', 'Qanday ishlaydi?', '1️⃣ Avval ro''yxatdan o''ting
+2️⃣ Kanalimizdan ish e''lonlarini ko''ring
+3️⃣ Yoqqan ishga yoziling
+4️⃣ To''lov qiling
+5️⃣ Admin tasdiqlashini kuting
+6️⃣ Ish ma''lumotlarini oling'),
+    ('Umumiy', 'Qaysi buyruqlar bor?', '/start - Botni ishga tushirish
+/help - Yordam'),
+    ('Profil', 'Profilimni qanday o''zgartiraman?', 'Profilingizni ko''rish va tahrirlash uchun "👤 Profil" tugmasini bosing.'),
+    ('Aloqa', 'Savollarim bo''lsa kimga yozaman?', 'Savollar bo''lsa @ArzonBepul bilan bog''laning.');
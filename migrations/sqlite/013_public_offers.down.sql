@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS offer_acceptances;
+DROP TABLE IF EXISTS public_offers;
@@ -0,0 +1,57 @@
+-- ============================================
+-- Public Offer Versions & Consent Log
+-- ============================================
+CREATE TABLE IF NOT EXISTS public_offers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    version INTEGER NOT NULL UNIQUE,
+    content TEXT NOT NULL,
+    created_by_admin_id INTEGER,
+    published_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS offer_acceptances (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    accepted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, version)
+);
+
+INSERT INTO public_offers (version, content) VALUES (1, 'FOYDALANUVCHI OFERTASI
+
+BAROKOT ISH XIZMATLARIDAN FOYDALANISH SHARTLARI
+
+1. UMUMIY QOIDALAR
+
+1.1. BAROKOT ISH — ish beruvchilar va kunlik ishchilarni bog''lovchi vositachi platformadir. BAROKOT ISH ish beruvchi hisoblanmaydi.
+
+1.2. Botdan foydalanish orqali siz ushbu ofertani qabul qilganingizni tasdiqlaysiz.
+
+2. TO''LOV VA MAJBURIYATLAR
+
+2.1. Xizmat haqi va ish shartlari har bir e''londa alohida ko''rsatiladi. Nomzod ishga yozilishdan oldin to''lovni amalga oshiradi.
+
+2.2. Soxta check yuborish platformadan chetlashtirishga olib keladi.
+
+2.3. To''lovdan keyin belgilangan vaqtda ishga chiqish majburiy. Sababsiz chiqmaslik blokirovkaga sabab bo''ladi.
+
+2.4. Faqat 18 yoshdan oshgan shaxslar ishga qabul qilinadi.
+
+3. JAVOBGARLIK
+
+3.1. Ish haqi, ish sharoiti va kelishuvlar uchun faqat ish beruvchi javobgar.
+
+3.2. BAROKOT ISH nomzodning shaxsiy buyumlari yoki jarohatlar uchun javobgar emas.
+
+3.3. Ish joyida xavfsizlik qoidalariga rioya qilish majburiy.
+
+4. YAKUNIY QOIDALAR
+
+4.1. Oferta shartlari bir tomonlama yangilanishi mumkin.
+
+4.2. Botdan foydalanish barcha shartlarga rozilik hisoblanadi.
+
+Davom etish uchun quyidagi tugmalardan birini tanlang.');
+
+INSERT OR IGNORE INTO offer_acceptances (user_id, version, accepted_at)
+SELECT user_id, 1, created_at FROM registered_users;
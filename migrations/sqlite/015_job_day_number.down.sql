@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_order_day;
+ALTER TABLE jobs DROP COLUMN day_number;
+ALTER TABLE jobs DROP COLUMN order_day;
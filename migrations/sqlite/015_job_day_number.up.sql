@@ -0,0 +1,17 @@
+-- ============================================
+-- Per-day Job Numbers
+-- ============================================
+ALTER TABLE jobs ADD COLUMN order_day TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN day_number INTEGER NOT NULL DEFAULT 0;
+
+-- Existing jobs are numbered by their stored creation date
+UPDATE jobs
+SET order_day = date(created_at),
+    day_number = (
+        SELECT COUNT(*) FROM jobs AS earlier
+        WHERE date(earlier.created_at) = date(jobs.created_at)
+          AND (earlier.created_at < jobs.created_at
+               OR (earlier.created_at = jobs.created_at AND earlier.id <= jobs.id))
+    );
+
+CREATE INDEX idx_jobs_order_day ON jobs(order_day);
@@ -0,0 +1,8 @@
+-- ============================================
+-- User State Timestamps
+-- state_updated_at records when the conversation state last changed so
+-- multi-step flows can expire after a period of inactivity
+-- ============================================
+ALTER TABLE users ADD COLUMN state_updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+UPDATE users SET state_updated_at = updated_at;
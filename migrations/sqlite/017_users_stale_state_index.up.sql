@@ -0,0 +1,4 @@
+-- ============================================
+-- Stale State Lookup
+-- ============================================
+CREATE INDEX idx_users_stale_state ON users(state_updated_at) WHERE state <> 'idle';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_job_bookings_receipt_unarchived;
+
+ALTER TABLE job_bookings DROP COLUMN receipt_archive_url;
@@ -0,0 +1,13 @@
+-- ============================================
+-- Job Link Starts Table
+-- One row per /start job_<id> deep-link open; the first step of the
+-- per-job conversion funnel shown to admins
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_link_starts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_link_starts_job_id ON job_link_starts(job_id);
@@ -0,0 +1,33 @@
+-- ============================================
+-- Re-engagement Campaigns
+-- Open-job digests sent to registered users who haven't booked for a while.
+-- Recipients are kept to measure link opens and bookings after the message
+-- and so a user isn't messaged again too soon.
+-- ============================================
+CREATE TABLE IF NOT EXISTS reengage_campaigns (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    started_by INTEGER NOT NULL DEFAULT 0,
+    inactive_days INTEGER NOT NULL,
+    jobs INTEGER NOT NULL DEFAULT 0,
+    recipients INTEGER NOT NULL DEFAULT 0,
+    sent INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS reengage_recipients (
+    campaign_id INTEGER NOT NULL REFERENCES reengage_campaigns(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (campaign_id, user_id)
+);
+
+CREATE INDEX idx_reengage_recipients_user_id ON reengage_recipients(user_id, sent_at);
+
+-- Users who asked not to get these digests
+CREATE TABLE IF NOT EXISTS reengage_opt_outs (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_link_starts_user_id ON job_link_starts(user_id, created_at);
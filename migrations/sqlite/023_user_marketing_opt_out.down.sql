@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reengage_opt_outs (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO reengage_opt_outs (user_id)
+SELECT id FROM users WHERE marketing_opt_out = 1;
+
+ALTER TABLE users DROP COLUMN marketing_opt_out;
@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN payment_receipt_extra_file_ids;
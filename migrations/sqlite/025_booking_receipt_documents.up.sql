@@ -0,0 +1,6 @@
+-- ============================================
+-- Document Receipts
+-- Some banks export receipts as PDF. A receipt sent as a file keeps its file
+-- ID in payment_receipt_file_id; this flag tells it apart from a photo.
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN payment_receipt_is_document BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+-- ============================================
+-- Refunds
+-- Service fees owed back to workers whose paid booking was cancelled with
+-- the job ("🚫 Ishni bekor qilish"). No foreign keys: the record must
+-- outlive the job and its bookings if they are deleted later.
+-- ============================================
+CREATE TABLE IF NOT EXISTS refunds (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL UNIQUE,
+    job_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    amount INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paid_at TIMESTAMP,
+    paid_by INTEGER
+);
+
+CREATE INDEX idx_refunds_pending ON refunds(created_at) WHERE status = 'PENDING';
@@ -0,0 +1 @@
+ALTER TABLE employers DROP COLUMN contact_chat_id;
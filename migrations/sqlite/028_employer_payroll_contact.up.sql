@@ -0,0 +1,7 @@
+-- ============================================
+-- Employer Payroll Contact
+-- When a job is closed the bot sends a payroll sheet of its confirmed workers
+-- to the ops group and, if set, to this chat (the employer's accountant or
+-- their group with the bot in it). 0 means the employer gets no copy.
+-- ============================================
+ALTER TABLE employers ADD COLUMN contact_chat_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS job_requirement_waivers;
+
+ALTER TABLE jobs DROP COLUMN min_weight;
+ALTER TABLE jobs DROP COLUMN min_height;
+ALTER TABLE jobs DROP COLUMN max_age;
+ALTER TABLE jobs DROP COLUMN min_age;
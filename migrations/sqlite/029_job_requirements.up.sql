@@ -0,0 +1,19 @@
+-- ============================================
+-- Job Requirements
+-- Optional worker requirements checked against the registered profile when
+-- a user books the job (0 = no requirement). An admin can let a particular
+-- user through anyway; that waiver is kept per job and user so it survives
+-- the user booking the job again.
+-- ============================================
+ALTER TABLE jobs ADD COLUMN min_age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN max_age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN min_height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN min_weight INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS job_requirement_waivers (
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    waived_by_admin_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_id, user_id)
+);
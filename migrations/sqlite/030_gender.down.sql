@@ -0,0 +1,6 @@
+-- Rollback: Drop gender columns
+ALTER TABLE jobs DROP COLUMN gender;
+
+ALTER TABLE registered_users DROP COLUMN gender;
+
+ALTER TABLE registration_drafts DROP COLUMN gender;
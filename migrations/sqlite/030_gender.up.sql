@@ -0,0 +1,8 @@
+-- ============================================
+-- Gender
+-- ============================================
+ALTER TABLE registration_drafts ADD COLUMN gender TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE registered_users ADD COLUMN gender TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE jobs ADD COLUMN gender TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Rollback: Drop channel location message column
+ALTER TABLE jobs DROP COLUMN channel_location_message_id;
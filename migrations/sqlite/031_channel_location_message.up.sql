@@ -0,0 +1,4 @@
+-- ============================================
+-- Channel location message
+-- ============================================
+ALTER TABLE jobs ADD COLUMN channel_location_message_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- ============================================
+-- Booking events
+-- ============================================
+CREATE TABLE IF NOT EXISTS booking_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL REFERENCES job_bookings(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL DEFAULT '',
+    to_status VARCHAR(50) NOT NULL,
+    actor_id INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_events_booking ON booking_events(booking_id, id);
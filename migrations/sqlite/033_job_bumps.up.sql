@@ -0,0 +1,13 @@
+-- ============================================
+-- Job Bumps Table
+-- One row per repost of an under-subscribed job to the channel shortly
+-- before its work date
+-- ============================================
+CREATE TABLE IF NOT EXISTS job_bumps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    message_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_bumps_job_id ON job_bumps(job_id);
@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN paid_amount;
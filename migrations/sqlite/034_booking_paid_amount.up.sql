@@ -0,0 +1,4 @@
+-- ============================================
+-- Paid Amount
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN paid_amount INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE job_bookings DROP COLUMN status_message_id;
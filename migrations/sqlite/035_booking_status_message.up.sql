@@ -0,0 +1,4 @@
+-- ============================================
+-- Booking Status Message
+-- ============================================
+ALTER TABLE job_bookings ADD COLUMN status_message_id INTEGER;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_registered_users_phone_hash_active;
+DROP INDEX IF EXISTS idx_registered_users_phone_hash;
+ALTER TABLE registered_users DROP COLUMN phone_hash;
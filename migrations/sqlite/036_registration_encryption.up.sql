@@ -0,0 +1,9 @@
+-- ============================================
+-- Encrypted Registration Data
+-- Keyed hash of the phone for lookups of encrypted phones (DB_ENCRYPTION_KEY);
+-- NULL for plaintext rows
+-- ============================================
+ALTER TABLE registered_users ADD COLUMN phone_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_registered_users_phone_hash ON registered_users(phone_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registered_users_phone_hash_active ON registered_users(phone_hash) WHERE is_active = 1;
@@ -0,0 +1,4 @@
+-- Rollback: Drop caption variant columns
+ALTER TABLE job_link_starts DROP COLUMN variant;
+ALTER TABLE jobs DROP COLUMN caption_b;
+ALTER TABLE jobs DROP COLUMN caption_a;
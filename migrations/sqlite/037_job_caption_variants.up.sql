@@ -0,0 +1,7 @@
+-- ============================================
+-- Job Caption Variants
+-- ============================================
+ALTER TABLE jobs ADD COLUMN caption_a TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN caption_b TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE job_link_starts ADD COLUMN variant TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,132 @@
+// Package fieldcrypt encrypts single database columns (phone numbers, passport photo IDs) with
+// AES-256-GCM, and derives keyed hashes so encrypted values can still be looked up by equality.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the length of the master key in bytes
+const KeySize = 32
+
+// prefix marks an encrypted value; values without it are plaintext rows written before
+// encryption was enabled and are returned as they are
+const prefix = "enc1:"
+
+// ErrNoKey is returned when an encrypted value is read without a key
+var ErrNoKey = errors.New("value is encrypted but no encryption key is configured")
+
+// Cipher encrypts and decrypts column values. A nil *Cipher means encryption is off:
+// Encrypt and Decrypt pass values through and Lookup returns "".
+type Cipher struct {
+	aead   cipher.AEAD
+	macKey []byte
+}
+
+// New creates a cipher from a 32-byte master key. The encryption and lookup keys are derived
+// from it separately, so a leaked lookup hash reveals nothing about the encryption key.
+func New(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(derive(key, "encrypt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &Cipher{aead: aead, macKey: derive(key, "lookup")}, nil
+}
+
+// ParseKey decodes a base64 master key (standard or URL alphabet, padded or not)
+func ParseKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := enc.DecodeString(s); err == nil {
+			if len(key) != KeySize {
+				return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+			}
+			return key, nil
+		}
+	}
+	return nil, errors.New("encryption key is not valid base64")
+}
+
+// derive returns HMAC-SHA256(key, label)
+func derive(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("fieldcrypt/" + label))
+	return mac.Sum(nil)
+}
+
+// Enabled reports whether values are encrypted
+func (c *Cipher) Enabled() bool {
+	return c != nil
+}
+
+// Encrypt returns the value encrypted with a random nonce; "" stays "" so empty columns stay empty
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of an Encrypt result; plaintext values pass through
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrNoKey
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(value[len(prefix):])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+	size := c.aead.NonceSize()
+	if len(sealed) < size {
+		return "", errors.New("encrypted value is too short")
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong key?): %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Lookup returns a keyed hash of the value for equality lookups and unique indexes,
+// or "" when encryption is off or the value is empty
+func (c *Cipher) Lookup(value string) string {
+	if c == nil || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.macKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted reports whether the value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
@@ -0,0 +1,144 @@
+package helper
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uzMonths maps Uzbek (latin) month names to months, including common spelling variants
+var uzMonths = map[string]time.Month{
+	"yanvar":   time.January,
+	"fevral":   time.February,
+	"mart":     time.March,
+	"aprel":    time.April,
+	"may":      time.May,
+	"iyun":     time.June,
+	"iyul":     time.July,
+	"avgust":   time.August,
+	"sentabr":  time.September,
+	"sentyabr": time.September,
+	"oktabr":   time.October,
+	"oktyabr":  time.October,
+	"noyabr":   time.November,
+	"dekabr":   time.December,
+}
+
+var (
+	numericDateRe = regexp.MustCompile(`(\d{1,2})[./-](\d{1,2})(?:[./-](\d{2,4}))?`)
+	monthDateRe   = regexp.MustCompile(`(\d{1,2})[\s-]*([a-z']+)`)
+	clockTimeRe   = regexp.MustCompile(`(\d{1,2})[:.](\d{2})`)
+)
+
+// ParseWorkDate extracts a calendar date from the free-text job work date.
+// Supported forms: "25.01.2026", "25.01", "25-yanvar", "25 yanvar", "bugun", "ertaga", "indinga".
+// Relative words and dates without a year are resolved against ref (usually the job creation time).
+// The returned time is midnight in ref's location.
+func ParseWorkDate(s string, ref time.Time) (time.Time, bool) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if text == "" {
+		return time.Time{}, false
+	}
+
+	if m := numericDateRe.FindStringSubmatch(text); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		year := 0
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			if year < 100 {
+				year += 2000
+			}
+		}
+		if d, ok := buildDate(year, time.Month(month), day, ref); ok {
+			return d, true
+		}
+	}
+
+	for _, m := range monthDateRe.FindAllStringSubmatch(text, -1) {
+		month, ok := uzMonths[m[2]]
+		if !ok {
+			continue
+		}
+		day, _ := strconv.Atoi(m[1])
+		if d, ok := buildDate(0, month, day, ref); ok {
+			return d, true
+		}
+	}
+
+	today := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location())
+	switch {
+	case strings.Contains(text, "indinga"):
+		return today.AddDate(0, 0, 2), true
+	case strings.Contains(text, "ertaga"):
+		return today.AddDate(0, 0, 1), true
+	case strings.Contains(text, "bugun"):
+		return today, true
+	}
+
+	return time.Time{}, false
+}
+
+// ParseWorkStart extracts the start time from the free-text job work time, e.g. "08:00-18:00" → 8h.
+// The result is the offset from midnight.
+func ParseWorkStart(s string) (time.Duration, bool) {
+	m := clockTimeRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	if hour > 23 || minute > 59 {
+		return 0, false
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, true
+}
+
+// buildDate validates day/month and fills in the year from ref when missing.
+// A yearless date far behind ref is assumed to be in the next year (e.g. "5-yanvar" written in December).
+func buildDate(year int, month time.Month, day int, ref time.Time) (time.Time, bool) {
+	if month < time.January || month > time.December || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	guessYear := year == 0
+	if guessYear {
+		year = ref.Year()
+	}
+
+	d := time.Date(year, month, day, 0, 0, 0, 0, ref.Location())
+	if d.Day() != day {
+		return time.Time{}, false // e.g. 31.02 rolled over
+	}
+	if guessYear && d.Before(ref.AddDate(0, -6, 0)) {
+		d = d.AddDate(1, 0, 0)
+	}
+	return d, true
+}
+
+// SameDay reports whether a and b fall on the same calendar day (in a's location)
+func SameDay(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// UzMonthName returns the Uzbek (latin) month name, e.g. "oktabr"
+func UzMonthName(m time.Month) string {
+	return uzMonthNames[m]
+}
+
+var uzMonthNames = map[time.Month]string{
+	time.January:   "yanvar",
+	time.February:  "fevral",
+	time.March:     "mart",
+	time.April:     "aprel",
+	time.May:       "may",
+	time.June:      "iyun",
+	time.July:      "iyul",
+	time.August:    "avgust",
+	time.September: "sentabr",
+	time.October:   "oktabr",
+	time.November:  "noyabr",
+	time.December:  "dekabr",
+}
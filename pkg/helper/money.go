@@ -0,0 +1,11 @@
+package helper
+
+import "telegram-bot-starter/config"
+
+// Money is an amount in so'm (or whatever CURRENCY_LABEL names); fees, refunds and revenue are whole units
+type Money int
+
+// String formats the amount with thousands separators and the currency label: 10 000 so'm
+func (m Money) String() string {
+	return FormatMoney(int(m)) + " " + config.Currency
+}
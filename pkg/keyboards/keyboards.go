@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/messages"
 
 	tele "gopkg.in/telebot.v4"
 )
@@ -97,35 +99,99 @@ func AdminMenuReplyKeyboard() *tele.ReplyMarkup {
 	btnJobList := menu.Text("📋 Ishlar ro'yxati")
 	btnUsersList := menu.Text("👥 Foydalanuvchilar")
 	btnStats := menu.Text("📊 Statistika")
+	btnSettings := menu.Text("⚙️ Sozlamalar")
+	btnFAQ := menu.Text("❓ FAQ")
 
 	menu.Reply(
 		menu.Row(btnCreateJob),
 		menu.Row(btnJobList),
 		menu.Row(btnUsersList, btnStats),
+		menu.Row(btnSettings, btnFAQ),
 	)
 
 	return menu
 }
 
-// JobListKeyboard returns keyboard with list of jobs
-func JobListKeyboard(jobs []*models.Job) *tele.ReplyMarkup {
+// notificationCategoryLabels holds button labels for admin notification categories
+var notificationCategoryLabels = map[models.NotificationCategory]string{
+	models.NotifyNewJobs:     "🆕 Yangi ishlar",
+	models.NotifyPayments:    "💳 To'lov cheklari",
+	models.NotifyExpirations: "⏰ Muddati o'tgan bronlar",
+	models.NotifyDailyDigest: "📊 Kunlik hisobot",
+	models.NotifyProfileEdit: "✏️ Profil o'zgarishlari",
+}
+
+// AdminNotificationSettingsKeyboard returns toggle buttons for admin notification preferences
+func AdminNotificationSettingsKeyboard(prefs *models.AdminNotificationPrefs) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
 	var rows []tele.Row
-	for _, job := range jobs {
-		statusIcon := "🟢"
-		switch job.Status {
-		case models.JobStatusFull:
-			statusIcon = "🔴"
-		case models.JobStatusCompleted:
-			statusIcon = "⚫"
+	for _, category := range models.NotificationCategories {
+		icon := "🔕"
+		if prefs.IsEnabled(category) {
+			icon = "🔔"
 		}
-
-		btnText := fmt.Sprintf("%s № %d - %s", statusIcon, job.OrderNumber, job.WorkDate)
-		btn := menu.Data(btnText, fmt.Sprintf("job_detail_%d", job.ID))
+		btnText := fmt.Sprintf("%s %s", icon, notificationCategoryLabels[category])
+		btn := menu.Data(btnText, fmt.Sprintf("admin_notify_toggle_%s", category))
 		rows = append(rows, menu.Row(btn))
 	}
 
+	menu.Inline(rows...)
+	return menu
+}
+
+// JobListGroup is a work date section of the admin job list
+type JobListGroup struct {
+	Title string
+	Jobs  []*models.Job
+}
+
+// JobListKeyboard returns a page of the job list under date headers, with page navigation and a toggle between active and all jobs
+func JobListKeyboard(groups []JobListGroup, showAll bool, currentPage, totalPages int) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, group := range groups {
+		rows = append(rows, menu.Row(menu.Data(group.Title, "job_list_noop")))
+		for _, job := range group.Jobs {
+			statusIcon := "🟢"
+			switch job.Status {
+			case models.JobStatusFull:
+				statusIcon = "🔴"
+			case models.JobStatusCompleted:
+				statusIcon = "⚫"
+			case models.JobStatusCancelled:
+				statusIcon = "🚫"
+			}
+
+			btnText := fmt.Sprintf("%s № %s · 👥 %d/%d bo'sh", statusIcon, messages.JobNumber(job), job.AvailableSlots(), job.RequiredWorkers)
+			btn := menu.Data(btnText, fmt.Sprintf("job_detail_%d", job.ID))
+			rows = append(rows, menu.Row(btn))
+		}
+	}
+
+	view := "active"
+	if showAll {
+		view = "all"
+	}
+	if totalPages > 1 {
+		var nav []tele.Btn
+		if currentPage > 1 {
+			nav = append(nav, menu.Data("⬅️ Oldingi", fmt.Sprintf("job_list_%s_%d", view, currentPage-1)))
+		}
+		nav = append(nav, menu.Data(fmt.Sprintf("%d/%d", currentPage, totalPages), "job_list_noop"))
+		if currentPage < totalPages {
+			nav = append(nav, menu.Data("Keyingi ➡️", fmt.Sprintf("job_list_%s_%d", view, currentPage+1)))
+		}
+		rows = append(rows, menu.Row(nav...))
+	}
+
+	btnToggle := menu.Data("📋 Barcha ishlar", "job_list_all_1")
+	if showAll {
+		btnToggle = menu.Data("🟢 Faqat faol ishlar", "job_list_active_1")
+	}
+	rows = append(rows, menu.Row(btnToggle))
+
 	// Add back button
 	rows = append(rows, menu.Row(menu.Data("⬅️ Orqaga", "admin_menu")))
 
@@ -133,6 +199,26 @@ func JobListKeyboard(jobs []*models.Job) *tele.ReplyMarkup {
 	return menu
 }
 
+// jobStatusButtons are the status-row buttons of the job detail view and their
+// callback tokens. CANCELLED is missing on purpose: it goes through its own
+// confirmation (job_cancel_).
+var jobStatusButtons = map[models.JobStatus]struct{ label, token string }{
+	models.JobStatusDraft:     {"📝 Qoralama", "draft"},
+	models.JobStatusActive:    {"🟢 Ochiq", "open"},
+	models.JobStatusFull:      {"🔴 To'ldi", "toldi"},
+	models.JobStatusCompleted: {"⚫ Yopilgan", "closed"},
+}
+
+// ParseJobStatusCallback maps a job_status_ callback token back to its status
+func ParseJobStatusCallback(token string) (models.JobStatus, bool) {
+	for status, btn := range jobStatusButtons {
+		if btn.token == token {
+			return status, true
+		}
+	}
+	return "", false
+}
+
 // JobDetailKeyboard returns keyboard for job detail view with edit options
 func JobDetailKeyboard(job *models.Job) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -150,11 +236,17 @@ func JobDetailKeyboard(job *models.Job) *tele.ReplyMarkup {
 	btnEditKerakli := menu.Data("👥 Kerakli ishchilar", fmt.Sprintf("edit_job_%d_kerakli", job.ID))
 	btnEditConfirmed := menu.Data("✅ Qabul qilingan", fmt.Sprintf("edit_job_%d_confirmed", job.ID))
 	btnEditEmployerPhone := menu.Data("📞 Ish beruvchi tel", fmt.Sprintf("edit_job_%d_employer_phone", job.ID))
-
-	// Status buttons
-	btnStatusOpen := menu.Data("🟢 Ochiq", fmt.Sprintf("job_status_%d_open", job.ID))
-	btnStatusToldi := menu.Data("🔴 To'ldi", fmt.Sprintf("job_status_%d_toldi", job.ID))
-	btnStatusClosed := menu.Data("⚫ Yopilgan", fmt.Sprintf("job_status_%d_closed", job.ID))
+	btnEditTalablar := menu.Data("🎯 Talablar", fmt.Sprintf("edit_job_%d_talablar", job.ID))
+	btnEditCaptionA := menu.Data("🅰️ Matn A", fmt.Sprintf("edit_job_%d_caption_a", job.ID))
+	btnEditCaptionB := menu.Data("🅱️ Matn B", fmt.Sprintf("edit_job_%d_caption_b", job.ID))
+
+	// Status buttons, only for the states the job can move to now
+	var statusButtons []tele.Btn
+	for _, status := range job.NextStatuses() {
+		if btn, ok := jobStatusButtons[status]; ok {
+			statusButtons = append(statusButtons, menu.Data(btn.label, fmt.Sprintf("job_status_%d_%s", job.ID, btn.token)))
+		}
+	}
 
 	// Action buttons
 	var rows []tele.Row
@@ -164,24 +256,48 @@ func JobDetailKeyboard(job *models.Job) *tele.ReplyMarkup {
 	rows = append(rows, menu.Row(btnEditAvtobuslar, btnEditIshTavsifi))
 	rows = append(rows, menu.Row(btnEditIshKuni, btnEditKerakli))
 	rows = append(rows, menu.Row(btnEditConfirmed, btnEditEmployerPhone))
-	rows = append(rows, menu.Row(btnStatusOpen, btnStatusToldi, btnStatusClosed))
+	rows = append(rows, menu.Row(btnEditTalablar))
+	rows = append(rows, menu.Row(btnEditCaptionA, btnEditCaptionB))
+	if len(statusButtons) > 0 {
+		rows = append(rows, menu.Row(statusButtons...))
+	}
 
 	// Publish or delete message buttons
 	if job.ChannelMessageID == 0 {
-		btnPublish := menu.Data("📢 Kanalga yuborish", fmt.Sprintf("publish_job_%d", job.ID))
+		btnPublish := menu.Data("📢 Kanalga yuborish", fmt.Sprintf("preview_job_%d", job.ID))
 		rows = append(rows, menu.Row(btnPublish))
 	} else {
 		btnDeleteMsg := menu.Data("🗑 Kanaldagi xabarni o'chirish", fmt.Sprintf("delete_channel_msg_%d", job.ID))
 		rows = append(rows, menu.Row(btnDeleteMsg))
 	}
 
-	// View bookings button
+	// View bookings and employer buttons
 	btnViewBookings := menu.Data("👥 Yozilganlarni ko'rish", fmt.Sprintf("view_job_bookings_%d", job.ID))
-	rows = append(rows, menu.Row(btnViewBookings))
+	if job.Status == models.JobStatusActive {
+		rows = append(rows, menu.Row(menu.Data("➕ Ishchini qo'shish", fmt.Sprintf("add_worker_%d", job.ID))))
+	}
+	if job.ConfirmedSlots > 0 {
+		rows = append(rows, menu.Row(menu.Data("📍 Lokatsiyani qayta yuborish", fmt.Sprintf("resend_location_%d", job.ID))))
+	}
+	if job.Status == models.JobStatusCompleted {
+		rows = append(rows, menu.Row(menu.Data("🧾 Ish haqi hisoboti", fmt.Sprintf("payroll_export_%d", job.ID))))
+	}
+	if job.EmployerID != 0 {
+		btnEmployer := menu.Data("🏢 Ish beruvchi", fmt.Sprintf("job_employer_%d", job.ID))
+		rows = append(rows, menu.Row(btnViewBookings, btnEmployer))
+	} else {
+		rows = append(rows, menu.Row(btnViewBookings))
+	}
 
+	btnClone := menu.Data("📄 Nusxalash", fmt.Sprintf("clone_job_%d", job.ID))
+	btnFunnel := menu.Data("📈 Voronka", fmt.Sprintf("job_funnel_%d", job.ID))
 	btnDelete := menu.Data("❌ Ishni butunlay o'chirish", fmt.Sprintf("delete_job_%d", job.ID))
 	btnBack := menu.Data("⬅️ Orqaga", "admin_job_list")
 
+	rows = append(rows, menu.Row(btnClone, btnFunnel))
+	if job.Status != models.JobStatusCancelled && job.Status != models.JobStatusCompleted {
+		rows = append(rows, menu.Row(menu.Data("🚫 Ishni bekor qilish", fmt.Sprintf("job_cancel_%d", job.ID))))
+	}
 	rows = append(rows, menu.Row(btnDelete))
 	rows = append(rows, menu.Row(btnBack))
 
@@ -190,11 +306,137 @@ func JobDetailKeyboard(job *models.Job) *tele.ReplyMarkup {
 	return menu
 }
 
-// CancelKeyboard returns a cancel button keyboard
-func CancelKeyboard() *tele.ReplyMarkup {
+// JobCancelConfirmKeyboard asks to confirm cancelling a job
+func JobCancelConfirmKeyboard(jobID int64) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
-	btnCancel := menu.Data("❌ Bekor qilish", "cancel_job_creation")
-	menu.Inline(menu.Row(btnCancel))
+
+	btnYes := menu.Data("✅ Ha, bekor qilish", fmt.Sprintf("job_cancel_confirm_%d", jobID))
+	btnNo := menu.Data("❌ Yo'q", fmt.Sprintf("job_detail_%d", jobID))
+
+	menu.Inline(menu.Row(btnYes, btnNo))
+	return menu
+}
+
+// RefundListKeyboard returns a "paid" button per pending refund
+func RefundListKeyboard(refunds []*models.Refund) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, refund := range refunds {
+		btnText := fmt.Sprintf("✅ #%d to'landi — %s", refund.ID, helper.Money(refund.Amount))
+		rows = append(rows, menu.Row(menu.Data(btnText, fmt.Sprintf("refund_paid_%d", refund.ID))))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// UserViolationsKeyboard returns receipt/forgive buttons per violation and a permanent block button
+func UserViolationsKeyboard(userID int64, details []*models.ViolationDetail, permanent bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for i, d := range details {
+		var row tele.Row
+		if d.ReceiptFileID != "" {
+			row = append(row, menu.Data(fmt.Sprintf("🧾 Chek #%d", i+1), fmt.Sprintf("violation_receipt_%d", d.ID)))
+		}
+		row = append(row, menu.Data(fmt.Sprintf("✅ Kechirish #%d", i+1), fmt.Sprintf("violation_forgive_%d", d.ID)))
+		rows = append(rows, row)
+	}
+
+	if !permanent {
+		rows = append(rows, menu.Row(menu.Data("🚫 Doimiy bloklash", fmt.Sprintf("violation_block_%d", userID))))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// DuplicateAccountKeyboard returns the admin actions for two accounts sharing one phone
+func DuplicateAccountKeyboard(oldUserID, newUserID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(
+		menu.Row(menu.Data("🔁 Yangi akkauntga o'tkazish", fmt.Sprintf("dup_merge_%d_%d", oldUserID, newUserID))),
+		menu.Row(menu.Data("❌ Rad etish", fmt.Sprintf("dup_reject_%d", newUserID))),
+		menu.Row(menu.Data("📋 Eski akkaunt qoidabuzarliklari", fmt.Sprintf("user_violations_%d", oldUserID))),
+	)
+	return menu
+}
+
+// EmployerPickKeyboard returns existing employers to choose from during job creation
+func EmployerPickKeyboard(employers []*models.Employer) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, employer := range employers {
+		btnText := fmt.Sprintf("🏢 %s (%s)", employer.Name, employer.Phone)
+		btn := menu.Data(btnText, fmt.Sprintf("job_employer_pick_%d", employer.ID))
+		rows = append(rows, menu.Row(btn))
+	}
+	rows = append(rows, menu.Row(
+		menu.Data("⬅️ Orqaga", "job_create_back"),
+		menu.Data("❌ Bekor qilish", "cancel_job_creation"),
+	))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// EmployerDetailKeyboard returns rating, notes and back buttons for the employer view of a job
+func EmployerDetailKeyboard(employer *models.Employer, jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var ratingRow tele.Row
+	for rating := 1; rating <= 5; rating++ {
+		btnText := fmt.Sprintf("%d⭐", rating)
+		if rating == employer.Rating {
+			btnText = fmt.Sprintf("✅ %d", rating)
+		}
+		ratingRow = append(ratingRow, menu.Data(btnText, fmt.Sprintf("employer_rate_%d_%d", jobID, rating)))
+	}
+
+	btnNotes := menu.Data("📝 Izoh", fmt.Sprintf("employer_notes_%d", jobID))
+	btnContact := menu.Data("📤 Hisobot chati", fmt.Sprintf("employer_contact_%d", jobID))
+	btnBack := menu.Data("⬅️ Orqaga", fmt.Sprintf("job_detail_%d", jobID))
+
+	menu.Inline(
+		ratingRow,
+		menu.Row(btnNotes, btnContact),
+		menu.Row(btnBack),
+	)
+	return menu
+}
+
+// JobFunnelKeyboard returns refresh and back buttons for a job's funnel view
+func JobFunnelKeyboard(jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnRefresh := menu.Data("🔄 Yangilash", fmt.Sprintf("job_funnel_%d", jobID))
+	btnBack := menu.Data("⬅️ Orqaga", fmt.Sprintf("job_detail_%d", jobID))
+
+	menu.Inline(
+		menu.Row(btnRefresh),
+		menu.Row(btnBack),
+	)
+	return menu
+}
+
+// FeedbackPayKeyboard returns yes/no buttons for the "was pay correct?" question
+func FeedbackPayKeyboard(bookingID int64) *tele.ReplyMarkup {
+	return feedbackYesNoKeyboard("feedback_pay", bookingID)
+}
+
+// FeedbackConditionsKeyboard returns yes/no buttons for the "were conditions okay?" question
+func FeedbackConditionsKeyboard(bookingID int64) *tele.ReplyMarkup {
+	return feedbackYesNoKeyboard("feedback_cond", bookingID)
+}
+
+func feedbackYesNoKeyboard(prefix string, bookingID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	btnYes := menu.Data("✅ Ha", fmt.Sprintf("%s_%d_yes", prefix, bookingID))
+	btnNo := menu.Data("❌ Yo'q", fmt.Sprintf("%s_%d_no", prefix, bookingID))
+	menu.Inline(menu.Row(btnYes, btnNo))
 	return menu
 }
 
@@ -210,6 +452,70 @@ func CancelOrSkipKeyboard() *tele.ReplyMarkup {
 	return menu
 }
 
+// JobCreationStepKeyboard returns the buttons under a job creation prompt:
+// skip for optional fields, back (except on the first step) and cancel
+func JobCreationStepKeyboard(canGoBack, canSkip bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	if canSkip {
+		rows = append(rows, menu.Row(menu.Data("⏭ O'tkazib yuborish", "skip_field")))
+	}
+	btnCancel := menu.Data("❌ Bekor qilish", "cancel_job_creation")
+	if canGoBack {
+		rows = append(rows, menu.Row(menu.Data("⬅️ Orqaga", "job_create_back"), btnCancel))
+	} else {
+		rows = append(rows, menu.Row(btnCancel))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// JobCreationReviewKeyboard returns per-field edit buttons and save/cancel for the job creation review
+func JobCreationReviewKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnIshHaqqi := menu.Data("💰 Ish haqqi", "job_create_edit_ish_haqqi")
+	btnOvqat := menu.Data("🍛 Ovqat", "job_create_edit_ovqat")
+	btnVaqt := menu.Data("⏰ Vaqt", "job_create_edit_vaqt")
+	btnManzil := menu.Data("📍 Manzil", "job_create_edit_manzil")
+	btnLocation := menu.Data("📌 Joylashuv", "job_create_edit_location")
+	btnXizmatHaqqi := menu.Data("🌟 Xizmat haqqi", "job_create_edit_xizmat_haqqi")
+	btnAvtobuslar := menu.Data("🚌 Avtobuslar", "job_create_edit_avtobuslar")
+	btnIshTavsifi := menu.Data("📝 Ish tavsifi", "job_create_edit_ish_tavsifi")
+	btnIshKuni := menu.Data("📅 Ish kuni", "job_create_edit_ish_kuni")
+	btnKerakli := menu.Data("👥 Kerakli ishchilar", "job_create_edit_kerakli")
+	btnEmployerPhone := menu.Data("📞 Ish beruvchi tel", "job_create_edit_employer_phone")
+
+	menu.Inline(
+		menu.Row(btnIshHaqqi, btnOvqat),
+		menu.Row(btnVaqt, btnManzil),
+		menu.Row(btnLocation, btnXizmatHaqqi),
+		menu.Row(btnAvtobuslar, btnIshTavsifi),
+		menu.Row(btnIshKuni, btnKerakli),
+		menu.Row(btnEmployerPhone),
+		menu.Row(menu.Data("💾 Saqlash", "job_create_save")),
+		menu.Row(menu.Data("❌ Bekor qilish", "cancel_job_creation")),
+	)
+	return menu
+}
+
+// AddWorkerResultsKeyboard lists registered users found by the admin's search for a job
+func AddWorkerResultsKeyboard(jobID int64, users []*models.RegisteredUser) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, u := range users {
+		btnText := fmt.Sprintf("👤 %s (%s)", u.FullName, u.Phone)
+		rows = append(rows, menu.Row(menu.Data(btnText, fmt.Sprintf("add_worker_pick_%d_%d", jobID, u.UserID))))
+	}
+	rows = append(rows, menu.Row(menu.Data("❌ Bekor qilish", fmt.Sprintf("job_detail_%d", jobID))))
+
+	menu.Inline(rows...)
+	return menu
+}
+
 // CancelEditKeyboard returns cancel button for editing with return to job detail
 func CancelEditKeyboard(jobID int64) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -218,22 +524,58 @@ func CancelEditKeyboard(jobID int64) *tele.ReplyMarkup {
 	return menu
 }
 
+// JobRefreshKeyboard reopens the latest job detail after an edit conflict
+func JobRefreshKeyboard(jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	btnRefresh := menu.Data("🔄 Yangilash", fmt.Sprintf("job_detail_%d", jobID))
+	menu.Inline(menu.Row(btnRefresh))
+	return menu
+}
+
 // JobSignupKeyboard returns keyboard with signup button for channel posts
 func JobSignupKeyboard(jobID int64, botUsername string) *tele.ReplyMarkup {
+	return signupKeyboard(fmt.Sprintf("%s%d", models.JobPayload, jobID), botUsername)
+}
+
+// JobCaptionSignupKeyboard is JobSignupKeyboard for a post showing one of the job's caption
+// variants; while the captions are tested the link carries the variant so its starts are counted apart
+func JobCaptionSignupKeyboard(job *models.Job, variant models.CaptionVariant, botUsername string) *tele.ReplyMarkup {
+	return signupKeyboard(job.SignupPayload(variant), botUsername)
+}
+
+// signupKeyboard returns the signup button opening the bot with the /start payload
+func signupKeyboard(payload, botUsername string) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
-	signupURL := fmt.Sprintf("https://t.me/%s?start=job_%d", botUsername, jobID)
+	signupURL := fmt.Sprintf("https://t.me/%s?start=%s", botUsername, payload)
 	btnSignup := menu.URL("✍️ Ishga yozilish", signupURL)
 	menu.Inline(menu.Row(btnSignup))
 	return menu
 }
 
+// JobPublishPreviewKeyboard shows the channel post's signup button (inactive here) under the
+// admin's preview, with publish and back-to-editing actions
+func JobPublishPreviewKeyboard(jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnSignup := menu.Data("✍️ Ishga yozilish", "job_preview_noop")
+	btnPublish := menu.Data("✅ Yuborish", fmt.Sprintf("publish_job_%d", jobID))
+	btnEdit := menu.Data("✏️ Tahrirlash", fmt.Sprintf("preview_job_edit_%d", jobID))
+
+	menu.Inline(
+		menu.Row(btnSignup),
+		menu.Row(btnPublish, btnEdit),
+	)
+
+	return menu
+}
+
 // ========== Registration Keyboards ==========
 
-// PublicOfferKeyboard returns accept/decline buttons for public offer
-func PublicOfferKeyboard() *tele.ReplyMarkup {
+// PublicOfferKeyboard returns accept/decline buttons for the given public offer version
+func PublicOfferKeyboard(version int) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
-	btnAccept := menu.Data("✅ Qabul qilaman", "reg_accept_offer")
+	btnAccept := menu.Data("✅ Qabul qilaman", fmt.Sprintf("reg_accept_offer_%d", version))
 	btnDecline := menu.Data("❌ Rad etaman", "reg_decline_offer")
 
 	menu.Inline(
@@ -277,8 +619,8 @@ func RegistrationConfirmKeyboard() *tele.ReplyMarkup {
 	return menu
 }
 
-// RegistrationEditFieldKeyboard returns buttons to select which field to edit
-func RegistrationEditFieldKeyboard() *tele.ReplyMarkup {
+// RegistrationEditFieldKeyboard returns buttons to select which field to edit; optional fields appear only when enabled
+func RegistrationEditFieldKeyboard(fields models.RegistrationFields) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
 	btnFullName := menu.Data("👤 Ism-familiya", "reg_edit_full_name")
@@ -287,11 +629,27 @@ func RegistrationEditFieldKeyboard() *tele.ReplyMarkup {
 	btnBody := menu.Data("📏 Vazn/Bo'y", "reg_edit_body_params")
 	btnBack := menu.Data("⬅️ Orqaga", "reg_back_to_confirm")
 
-	menu.Inline(
+	rows := []tele.Row{
 		menu.Row(btnFullName, btnPhone),
 		menu.Row(btnAge, btnBody),
-		menu.Row(btnBack),
-	)
+	}
+
+	var optional []tele.Btn
+	if fields.Gender {
+		optional = append(optional, menu.Data("🚻 Jins", "reg_edit_gender"))
+	}
+	if fields.City {
+		optional = append(optional, menu.Data("🏙 Shahar", "reg_edit_city"))
+	}
+	if fields.PassportPhoto {
+		optional = append(optional, menu.Data("🪪 Pasport", "reg_edit_passport_photo"))
+	}
+	if len(optional) > 0 {
+		rows = append(rows, menu.Row(optional...))
+	}
+
+	rows = append(rows, menu.Row(btnBack))
+	menu.Inline(rows...)
 
 	return menu
 }
@@ -315,11 +673,13 @@ func RemoveReplyKeyboard() *tele.ReplyMarkup {
 func UserMainMenuKeyboard() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
+	btnOpenJobs := menu.Data("🔎 Ochiq ishlar", "user_open_jobs")
 	btnMyJobs := menu.Data("📋 Mening ishlarim", "user_my_jobs")
 	btnProfile := menu.Data("👤 Profil", "user_profile")
 	btnHelp := menu.Data("❓ Yordam", "help")
 
 	menu.Inline(
+		menu.Row(btnOpenJobs),
 		menu.Row(btnMyJobs, btnProfile),
 		menu.Row(btnHelp),
 	)
@@ -328,13 +688,18 @@ func UserMainMenuKeyboard() *tele.ReplyMarkup {
 }
 func UserMainMenuReplyKeyboard() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
+	btnOpenJobs := menu.Text("🔎 Ochiq ishlar")
 	btnMyJobs := menu.Text("📋 Mening ishlarim")
 	btnProfile := menu.Text("👤 Profil")
 	btnHelp := menu.Text("❓ Yordam")
+	btnSettings := menu.Text("⚙️ Sozlamalar")
+	btnSupport := menu.Text("✉️ Adminga yozish")
 
 	menu.Reply(
+		menu.Row(btnOpenJobs),
 		menu.Row(btnMyJobs, btnProfile),
-		menu.Row(btnHelp),
+		menu.Row(btnHelp, btnSettings),
+		menu.Row(btnSupport),
 	)
 
 	return menu
@@ -355,6 +720,100 @@ func ContinueRegistrationKeyboard() *tele.ReplyMarkup {
 	return menu
 }
 
+// FinishRegistrationKeyboard returns the button of the finish-registration reminder for a job
+func FinishRegistrationKeyboard(jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("✅ Ro'yxatdan o'tishni tugatish", fmt.Sprintf("start_reg_job_%d", jobID))))
+	return menu
+}
+
+// AdminStatisticsKeyboard returns the drill-down buttons under the statistics message
+func AdminStatisticsKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("🔗 Qiziqqan, ro'yxatdan o'tmaganlar", "admin_job_interest")))
+	return menu
+}
+
+// UserSettingsKeyboard returns the worker's notification toggles
+func UserSettingsKeyboard(user *models.User) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	marketing := "✅ Yangi ishlar haqida takliflar: yoqilgan"
+	if user.MarketingOptOut {
+		marketing = "🔕 Yangi ishlar haqida takliflar: o'chirilgan"
+	}
+
+	menu.Inline(menu.Row(menu.Data(marketing, "user_notify_marketing")))
+	return menu
+}
+
+// ReengageKeyboard links each open job's signup and lets the user turn campaign messages off
+func ReengageKeyboard(jobs []*models.Job, botUsername string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(jobs)+1)
+	for _, job := range jobs {
+		signupURL := fmt.Sprintf("https://t.me/%s?start=job_%d", botUsername, job.ID)
+		rows = append(rows, menu.Row(menu.URL(fmt.Sprintf("✍️ №%s — %s", messages.JobNumber(job), job.WorkDate), signupURL)))
+	}
+	rows = append(rows, menu.Row(menu.Data("🔕 Bunday xabarlar kerak emas", "reengage_optout")))
+	menu.Inline(rows...)
+	return menu
+}
+
+// OpenJobsKeyboard lists open jobs for users; a button opens the job card before booking
+func OpenJobsKeyboard(jobs []*models.Job) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(jobs))
+	for _, job := range jobs {
+		btnText := fmt.Sprintf("№%s — %s · 👥 %d ta joy", messages.JobNumber(job), job.WorkDate, job.AvailableSlots())
+		rows = append(rows, menu.Row(menu.Data(btnText, fmt.Sprintf("user_job_%d", job.ID))))
+	}
+	menu.Inline(rows...)
+	return menu
+}
+
+// JobBookingKeyboard confirms a booking from the user job card; refresh re-reads the free places
+func JobBookingKeyboard(jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(
+		menu.Row(menu.Data("✅ Ha, yozilaman", fmt.Sprintf("book_confirm_%d", jobID))),
+		menu.Row(menu.Data("🔄 Yangilash", fmt.Sprintf("user_job_refresh_%d", jobID))),
+		menu.Row(menu.Data("❌ Yo'q, bekor qilish", "book_cancel")),
+	)
+	return menu
+}
+
+// PaymentAmountSkipKeyboard sends the held receipt without an amount
+func PaymentAmountSkipKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("⏭ O'tkazib yuborish", "payment_amount_skip")))
+	return menu
+}
+
+// ReengageOptInKeyboard replaces the campaign buttons after an opt-out
+func ReengageOptInKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("🔔 Xabarlarni qayta yoqish", "reengage_optin")))
+	return menu
+}
+
+// SupportThreadKeyboard closes a support thread; shown to the user and on the thread's messages to admins
+func SupportThreadKeyboard(threadID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("🔒 Murojaatni yopish", fmt.Sprintf("support_close_%d", threadID))))
+	return menu
+}
+
+// SubscriptionKeyboard links to the channel and re-checks the subscription before continuing with jobID
+func SubscriptionKeyboard(channelURL string, jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(
+		menu.Row(menu.URL("📢 A'zo bo'lish", channelURL)),
+		menu.Row(menu.Data("✅ Tekshirish", fmt.Sprintf("sub_check_%d", jobID))),
+	)
+	return menu
+}
+
 // ReplyCancelKeyboard returns a reply keyboard with only cancel button
 func ReplyCancelKeyboard() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{
@@ -377,17 +836,39 @@ func ProfileEditKeyboard() *tele.ReplyMarkup {
 	btnEditPhone := menu.Text("📞 Telefon raqami")
 	btnEditAge := menu.Text("🎂 Yosh")
 	btnEditBodyParams := menu.Text("📏 Vazn va Bo'y")
+	btnEditGender := menu.Text("🚻 Jins")
+	btnDeleteAccount := menu.Text("🗑 Hisobni o'chirish")
 	btnMainMenu := menu.Text("🏠 Asosiy menyu")
 
 	menu.Reply(
 		menu.Row(btnEditFullName, btnEditPhone),
 		menu.Row(btnEditAge, btnEditBodyParams),
+		menu.Row(btnEditGender),
+		menu.Row(btnDeleteAccount),
 		menu.Row(btnMainMenu),
 	)
 
 	return menu
 }
 
+// DeleteAccountKeyboard returns the confirmation buttons for account deletion; final is the second step
+func DeleteAccountKeyboard(final bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnConfirm := menu.Data("🗑 Ha, o'chirish", "account_delete_confirm")
+	if final {
+		btnConfirm = menu.Data("✅ Ha, butunlay o'chirish", "account_delete_final")
+	}
+	btnCancel := menu.Data("❌ Bekor qilish", "account_delete_cancel")
+
+	menu.Inline(
+		menu.Row(btnConfirm),
+		menu.Row(btnCancel),
+	)
+
+	return menu
+}
+
 // RequestPhoneKeyboard returns keyboard to request phone number
 func RequestPhoneKeyboard() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{
@@ -405,3 +886,175 @@ func RequestPhoneKeyboard() *tele.ReplyMarkup {
 
 	return menu
 }
+
+// GenderKeyboard returns reply keyboard with the gender choices, used in registration and profile edit
+func GenderKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{
+		ResizeKeyboard:  true,
+		OneTimeKeyboard: true,
+	}
+
+	btnMale := menu.Text("👨 Erkak")
+	btnFemale := menu.Text("👩 Ayol")
+	btnCancel := menu.Text("❌ Bekor qilish")
+
+	menu.Reply(
+		menu.Row(btnMale, btnFemale),
+		menu.Row(btnCancel),
+	)
+
+	return menu
+}
+
+// ========== FAQ Keyboards ==========
+
+// faqLabelMaxRunes keeps question buttons readable on phones
+const faqLabelMaxRunes = 48
+
+// faqLabel shortens a question for use as a button label
+func faqLabel(text string) string {
+	runes := []rune(text)
+	if len(runes) <= faqLabelMaxRunes {
+		return text
+	}
+	return string(runes[:faqLabelMaxRunes-1]) + "…"
+}
+
+// FAQCategoriesKeyboard lists FAQ categories; each button carries the ID of the category's first entry
+func FAQCategoriesKeyboard(categories []*models.FAQCategory) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, category := range categories {
+		btn := menu.Data("📂 "+faqLabel(category.Name), fmt.Sprintf("faq_cat_%d", category.Entries[0].ID))
+		rows = append(rows, menu.Row(btn))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// FAQQuestionsKeyboard lists the questions of one category; withBack adds a button to the category list
+func FAQQuestionsKeyboard(category *models.FAQCategory, withBack bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, entry := range category.Entries {
+		btn := menu.Data(faqLabel(entry.Question), fmt.Sprintf("faq_q_%d", entry.ID))
+		rows = append(rows, menu.Row(btn))
+	}
+	if withBack {
+		rows = append(rows, menu.Row(menu.Data("⬅️ Bo'limlar", "faq_home")))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// FAQAnswerKeyboard returns to the questions of the entry's category
+func FAQAnswerKeyboard(entryID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("⬅️ Orqaga", fmt.Sprintf("faq_cat_%d", entryID))))
+	return menu
+}
+
+// AdminFAQKeyboard lists every FAQ entry for editing
+func AdminFAQKeyboard(entries []*models.FAQEntry) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	for _, entry := range entries {
+		label := fmt.Sprintf("[%s] %s", entry.Category, entry.Question)
+		btn := menu.Data(faqLabel(label), fmt.Sprintf("faq_admin_entry_%d", entry.ID))
+		rows = append(rows, menu.Row(btn))
+	}
+	rows = append(rows, menu.Row(menu.Data("➕ Savol qo'shish", "faq_admin_add")))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// AdminFAQEntryKeyboard returns edit/delete actions for one FAQ entry
+func AdminFAQEntryKeyboard(entryID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnCategory := menu.Data("📂 Bo'lim", fmt.Sprintf("faq_admin_edit_category_%d", entryID))
+	btnQuestion := menu.Data("❓ Savol", fmt.Sprintf("faq_admin_edit_question_%d", entryID))
+	btnAnswer := menu.Data("💬 Javob", fmt.Sprintf("faq_admin_edit_answer_%d", entryID))
+	btnDelete := menu.Data("🗑 O'chirish", fmt.Sprintf("faq_admin_delete_%d", entryID))
+	btnBack := menu.Data("⬅️ Orqaga", "faq_admin")
+
+	menu.Inline(
+		menu.Row(btnCategory, btnQuestion, btnAnswer),
+		menu.Row(btnDelete),
+		menu.Row(btnBack),
+	)
+	return menu
+}
+
+// AdminFAQDeleteKeyboard asks to confirm deleting an FAQ entry
+func AdminFAQDeleteKeyboard(entryID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnYes := menu.Data("✅ Ha, o'chirish", fmt.Sprintf("faq_admin_delete_confirm_%d", entryID))
+	btnNo := menu.Data("❌ Yo'q", fmt.Sprintf("faq_admin_entry_%d", entryID))
+
+	menu.Inline(menu.Row(btnYes, btnNo))
+	return menu
+}
+
+// AdminFAQCancelKeyboard aborts FAQ input and returns to the FAQ list
+func AdminFAQCancelKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("❌ Bekor qilish", "faq_admin")))
+	return menu
+}
+
+// ========== Public Offer Keyboards ==========
+
+// OfferUpdateKeyboard asks a registered user to accept a new offer version;
+// jobID (0 = none) is the booking to continue with afterwards
+func OfferUpdateKeyboard(version int, jobID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnAccept := menu.Data("✅ Qabul qilaman", fmt.Sprintf("offer_accept_%d_%d", version, jobID))
+	btnDecline := menu.Data("❌ Rad etaman", "offer_decline")
+
+	menu.Inline(menu.Row(btnAccept, btnDecline))
+	return menu
+}
+
+// AdminOfferKeyboard starts writing a new offer version
+func AdminOfferKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("✏️ Yangi versiya", "offer_admin_new")))
+	return menu
+}
+
+// AdminOfferPreviewKeyboard publishes or discards the previewed offer text
+func AdminOfferPreviewKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	btnPublish := menu.Data("📢 E'lon qilish", "offer_admin_publish")
+	btnCancel := menu.Data("❌ Bekor qilish", "offer_admin_cancel")
+
+	menu.Inline(menu.Row(btnPublish, btnCancel))
+	return menu
+}
+
+// RuntimeSettingsKeyboard opens the super admin settings editors
+func RuntimeSettingsKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(
+		menu.Row(menu.Data("👥 Adminlarni o'zgartirish", "settings_admins")),
+		menu.Row(menu.Data("💳 Kartani o'zgartirish", "settings_card")),
+	)
+	return menu
+}
+
+// RuntimeSettingsCancelKeyboard aborts a settings change
+func RuntimeSettingsCancelKeyboard() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.Data("❌ Bekor qilish", "settings_cancel")))
+	return menu
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// CorrelationIDKey is the log field carrying the ID of the update being handled
+const CorrelationIDKey = "correlation_id"
+
+type correlationIDCtxKey struct{}
+
+// NewCorrelationID returns a short random ID for tagging one update's log lines
+func NewCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDCtxKey{}, id)
+}
+
+// CorrelationID returns the correlation ID stored in ctx, or "" if there is none
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDCtxKey{}).(string)
+	return id
+}
+
+// FromContext returns l tagged with the correlation ID from ctx, or l itself if ctx has none
+func FromContext(ctx context.Context, l LoggerI) LoggerI {
+	id := CorrelationID(ctx)
+	if id == "" {
+		return l
+	}
+	return WithFields(l, String(CorrelationIDKey, id))
+}
@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	trackerQueueSize    = 100
+	trackerFlushTimeout = 3 * time.Second
+)
+
+// trackerTags are the fields promoted to searchable tags; everything else goes to "extra"
+var trackerTags = []string{CorrelationIDKey, "user_id", "job_id", "booking_id", "admin_id"}
+
+// tracker sends events to a Sentry-compatible error tracker (Sentry, GlitchTip, ...) using the envelope
+// endpoint. No SDK is needed for that: an event is one JSON document posted with the DSN's key.
+type tracker struct {
+	endpoint    string
+	auth        string
+	dsn         string
+	environment string
+	serverName  string
+	client      *http.Client
+
+	queue chan []byte
+	wg    sync.WaitGroup // events queued but not sent yet
+}
+
+// newTracker parses dsn (https://KEY@HOST/PROJECT_ID) and starts the sender goroutine
+func newTracker(dsn, environment string) (*tracker, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User == nil {
+		return nil, fmt.Errorf("invalid error tracker DSN")
+	}
+	path := strings.TrimRight(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if projectID == "" {
+		return nil, fmt.Errorf("error tracker DSN has no project ID")
+	}
+
+	serverName, _ := os.Hostname()
+	t := &tracker{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:slash], projectID),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=telegram-bot-starter/1.0, sentry_key=%s", u.User.Username()),
+		dsn:         dsn,
+		environment: environment,
+		serverName:  serverName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan []byte, trackerQueueSize),
+	}
+	go t.run()
+	return t, nil
+}
+
+// run posts queued envelopes one at a time
+func (t *tracker) run() {
+	for envelope := range t.queue {
+		t.post(envelope)
+		t.wg.Done()
+	}
+}
+
+// post sends one envelope. Failures go to stderr: logging them would report them again.
+func (t *tracker) post(envelope []byte) {
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error tracker: %v\n", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", t.auth)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error tracker: %v\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Fprintf(os.Stderr, "error tracker: unexpected status %s\n", resp.Status)
+	}
+}
+
+// capture builds the event of one log entry and queues it; with a full queue the event is dropped
+func (t *tracker) capture(ent zapcore.Entry, fields map[string]any) {
+	envelope, err := t.envelope(ent, fields)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error tracker: %v\n", err)
+		return
+	}
+
+	// Fatal exits right after the write, so it is sent before returning
+	if ent.Level >= zapcore.FatalLevel {
+		t.post(envelope)
+		return
+	}
+
+	t.wg.Add(1)
+	select {
+	case t.queue <- envelope:
+	default:
+		t.wg.Done()
+		fmt.Fprintln(os.Stderr, "error tracker: queue full, event dropped")
+	}
+}
+
+// envelope renders the event: message, level, logger, caller, tags and the remaining fields as extra
+func (t *tracker) envelope(ent zapcore.Entry, fields map[string]any) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	eventID := hex.EncodeToString(id)
+
+	tags := map[string]string{}
+	for _, key := range trackerTags {
+		if value, ok := fields[key]; ok {
+			tags[key] = fmt.Sprint(value)
+			delete(fields, key)
+		}
+	}
+
+	if ent.Stack != "" {
+		fields["stacktrace"] = ent.Stack
+	}
+
+	level := "error"
+	if ent.Level >= zapcore.FatalLevel {
+		level = "fatal"
+	}
+
+	// The console icons only get in the way of grouping
+	message := ent.Message
+	for _, icon := range []string{ErrorIcon, DPanicIcon, PanicIcon, FatalIcon} {
+		message = strings.TrimPrefix(message, icon)
+	}
+
+	event := map[string]any{
+		"event_id":    eventID,
+		"timestamp":   ent.Time.UTC().Format(time.RFC3339Nano),
+		"level":       level,
+		"platform":    "go",
+		"logger":      ent.LoggerName,
+		"message":     map[string]string{"formatted": message},
+		"environment": t.environment,
+		"server_name": t.serverName,
+		"tags":        tags,
+		"extra":       fields,
+	}
+	if ent.Caller.Defined {
+		event["culprit"] = ent.Caller.TrimmedPath()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(map[string]string{
+		"event_id": eventID,
+		"dsn":      t.dsn,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, err
+	}
+	item, err := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for _, line := range [][]byte{header, item, payload} {
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+// flush waits for the queued events, at most trackerFlushTimeout
+func (t *tracker) flush() {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(trackerFlushTimeout):
+	}
+}
+
+// trackerCore is the zap core feeding Error and more severe entries to the tracker
+type trackerCore struct {
+	tracker *tracker
+	fields  []Field
+}
+
+func (c *trackerCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= zapcore.ErrorLevel
+}
+
+func (c *trackerCore) With(fields []Field) zapcore.Core {
+	return &trackerCore{tracker: c.tracker, fields: append(c.fields[:len(c.fields):len(c.fields)], fields...)}
+}
+
+func (c *trackerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *trackerCore) Write(ent zapcore.Entry, fields []Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	c.tracker.capture(ent, enc.Fields)
+	return nil
+}
+
+func (c *trackerCore) Sync() error {
+	c.tracker.flush()
+	return nil
+}
+
+// EnableErrorTracker forwards Error, DPanic, Panic and Fatal entries of l, with their fields, to the
+// Sentry-compatible tracker at dsn. The correlation, user, job, booking and admin IDs become tags.
+// Loggers derived from l afterwards report too; Cleanup waits for the events still queued.
+func EnableErrorTracker(l LoggerI, dsn, environment string) error {
+	v, ok := l.(*loggerImpl)
+	if !ok {
+		return fmt.Errorf("logger.EnableErrorTracker: invalid logger type")
+	}
+	t, err := newTracker(dsn, environment)
+	if err != nil {
+		return err
+	}
+	v.zap = v.zap.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &trackerCore{tracker: t})
+	}))
+	return nil
+}
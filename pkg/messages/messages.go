@@ -2,9 +2,14 @@ package messages
 
 import (
 	"fmt"
+	"html"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/helper"
 )
 
@@ -14,27 +19,20 @@ const (
 
 I'm here to help you. Use /help to see available commands.`
 
-	MsgHelp = `📖 <b>YORDAM</b>
-
-<b>Bot haqida:</b>
-Bu bot orqali siz kunlik ish topishingiz va ish uchun yozilishingiz mumkin.
-
-<b>Qanday ishlaydi?</b>
-1️⃣ Avval ro'yxatdan o'ting
-2️⃣ Kanalimizdan ish e'lonlarini ko'ring
-3️⃣ Yoqqan ishga yoziling
-4️⃣ To'lov qiling
-5️⃣ Admin tasdiqlashi kutilsin
-6️⃣ Ish ma'lumotlarini oling
-
-<b>Buyruqlar:</b>
-/start - Botni ishga tushirish
-/help - Yordam
-
-<b>Profil:</b>
-Profilingizni ko'rish va tahrirlash uchun "👤 Profil" tugmasini bosing.
-
-❓ Savollar bo'lsa @ArzonBepul bilan bog'laning.`
+	// Help FAQ messages
+	MsgFAQ         = "❓ <b>YORDAM</b>\n\nBo'limni tanlang:"
+	MsgFAQCategory = "❓ <b>%s</b>\n\nSavolni tanlang:"
+	MsgFAQAnswer   = "❓ <b>%s</b>\n\n%s"
+	MsgFAQEmpty    = "❓ Hozircha savollar qo'shilmagan.\n\nSavollar bo'lsa adminga murojaat qiling."
+
+	// Admin FAQ management messages
+	MsgAdminFAQ         = "❓ <b>FAQ BOSHQARUVI</b>\n\nSavolni tanlang yoki yangisini qo'shing:"
+	MsgAdminFAQEntry    = "📂 <b>Bo'lim:</b> %s\n\n❓ <b>Savol:</b> %s\n\n💬 <b>Javob:</b>\n%s"
+	MsgEnterFAQCategory = "📂 Bo'lim nomini kiriting:\n\nMasalan: To'lov"
+	MsgEnterFAQQuestion = "❓ Savolni kiriting:"
+	MsgEnterFAQAnswer   = "💬 Javobni kiriting:"
+	MsgFAQDeleteConfirm = "🗑 Ushbu savol o'chirilsinmi?\n\n❓ %s"
+	MsgFAQTooLong       = "❌ Matn juda uzun. Iltimos, qisqaroq yozing."
 
 	MsgAbout = `ℹ️ Bot haqida
 
@@ -42,9 +40,11 @@ Bu bot orqali kunlik ishlarni topish va yozilish mumkin.
 
 Versiya: 1.0.0`
 
-	MsgSettings = `⚙️ Sozlamalar
+	MsgSettings = `⚙️ <b>Sozlamalar</b>
+
+<b>Yangi ishlar haqida takliflar</b> — ochiq ishlar ro'yxati va boshqa e'lonlar. Ularni o'chirib qo'yishingiz mumkin.
 
-Sozlamalar tez orada qo'shiladi.`
+To'lov natijalari, eslatmalar va yozilgan ishlaringiz haqidagi xabarlar har doim keladi.`
 
 	MsgUnknownCommand = "❓ Noma'lum buyruq. Mavjud buyruqlarni ko'rish uchun /help ni bosing."
 
@@ -55,19 +55,150 @@ Sozlamalar tez orada qo'shiladi.`
 
 Ishlarni boshqarish uchun quyidagi tugmalardan foydalaning:`
 
+	MsgAdminNotificationSettings = `⚙️ <b>BILDIRISHNOMA SOZLAMALARI</b>
+
+Qaysi xabarlarni olishni xohlaysiz? Yoqish yoki o'chirish uchun tugmani bosing:`
+
 	// Job creation prompts
 	MsgEnterIshHaqqi         = "💰 Ish haqqini kiriting:\n\nMasalan: Soatiga 20 000 so'm"
 	MsgEnterOvqat            = "🍛 Ovqat haqida ma'lumot kiriting:\n\nMasalan: Tushlik bilan yoki kiritilmagan"
 	MsgEnterVaqt             = "⏰ Ish vaqtini kiriting:\n\nMasalan: 10:30 dan - kamida 5/6 soat ish"
 	MsgEnterManzil           = "📍 Manzilni kiriting:\n\nMasalan: Yunusobod Amir Temur xiyoboniga yaqin"
 	MsgEnterLocation         = "📌 Aniq joylashuvni yuboring (faqat to'lov tasdiqlangan foydalanuvchilar uchun):\n\n📍 Telegram orqali joylashuvni (location) yuboring.\n\n⚠️ Matnli xabar emas, balki Telegram location funksiyasidan foydalaning."
-	MsgEnterXizmatHaqqi      = "🌟 Xizmat haqqini kiriting (faqat raqam):\n\nMasalan: 9990"
+	MsgEnterXizmatHaqqi      = "🌟 Xizmat haqqini kiriting (faqat raqam):\n\nMasalan: 9990 yoki 9 990"
 	MsgEnterAvtobuslar       = "🚌 Avtobuslar haqida ma'lumot kiriting:\n\nMasalan: 45, 67, 89 avtobuslar"
 	MsgEnterIshTavsifi       = "📝 Ish tavsifi va talablarni kiriting:\n\nMasalan: Ish yengil, 3-4 soatlik. Kiyim: Qora kiyim talab qilinadi"
 	MsgEnterIshKuni          = "📅 Ish kunini kiriting:\n\nMasalan: Ertaga yoki 25-yanvar"
+	MsgJobClonedEnterIshKuni = "📄 Nusxa yaratildi (qoralama).\n\n📅 Yangi ish kunini kiriting yoki bekor qilib, boshqa maydonlarni tahrirlang:"
 	MsgEnterKerakliIshchilar = "👥 Kerakli ishchilar sonini kiriting:\n\nMasalan: 5"
 	MsgEnterConfirmedSlots   = "✅ Qabul qilingan ishchilar sonini kiriting:\n\nMasalan: 3\n\n⚠️ Qabul qilingan soni kerakli sondan oshmasligi kerak."
 	MsgEnterEmployerPhone    = "📞 Ish beruvchining telefon raqamini kiriting:\n\nMasalan: +998901234567 yoki 901234567\n\n⚠️ Bu raqam faqat to'lov tasdiqlangan foydalanuvchilar uchun ko'rinadi."
+	MsgEnterJobCaption       = "🆎 Kanal e'loni uchun %s matn variantini kiriting (e'lon tepasida qalin harfda chiqadi):\n\nMasalan: Ertaga 250 000 so'm, tushlik bilan!\n\nIkkala variant ham kiritilsa, kanal e'loni A ni, qayta e'lonlar navbat bilan B va A ni ko'rsatadi, havola ochganlar esa variantlar bo'yicha 📈 Voronkada sanaladi.\n• - — variantni olib tashlash"
+	MsgEnterJobRequirements  = "🎯 Ishchilarga talablarni kiriting (kerak bo'lmaganini yozmang):\n\nMasalan: erkak, yosh 18-40, bo'y 170, vazn 60\n\n• erkak / ayol — faqat erkaklar yoki faqat ayollar\n• yosh 18 — 18 va undan katta\n• yosh 0-40 — 40 gacha\n• - — barcha talablarni olib tashlash\n\n⚠️ Mos kelmagan foydalanuvchilar bu ishga yozila olmaydi."
+	MsgPickOrEnterEmployer   = "🏢 Ro'yxatdan ish beruvchini tanlang yoki yangi ish beruvchining telefon raqamini kiriting:\n\nMasalan: +998901234567 yoki 901234567\n\n⚠️ Bu raqam faqat to'lov tasdiqlangan foydalanuvchilar uchun ko'rinadi."
+	MsgEnterEmployerName     = "🏢 Yangi ish beruvchi. Ism yoki kompaniya nomini kiriting:"
+	MsgEnterEmployerNotes    = "📝 Ish beruvchi haqida izoh kiriting:"
+	MsgJobEmployerMissing    = "⚠️ Ish beruvchi ko'rsatilmagan. Telefon raqamini kiriting yoki ro'yxatdan tanlang."
+	MsgJobReviewUseButtons   = "👇 Maydonni tuzatish yoki ishni saqlash uchun pastdagi tugmalardan foydalaning."
+
+	// Manual booking (admin adds a worker to a job)
+	MsgEnterWorkerSearch   = "🔎 Ishchining ismi yoki telefon raqamini kiriting:\n\nMasalan: Aliyev yoki 901234567"
+	MsgWorkerNotFound      = "📭 Hech kim topilmadi. Boshqa ism yoki telefon raqamini kiriting:"
+	MsgPickWorker          = "👇 Ishga qo'shiladigan ishchini tanlang:"
+	MsgWorkerNoFreeSlots   = "❌ Bu ishda bo'sh joy qolmagan."
+	MsgWorkerJobNotActive  = "❌ Bu ish faol emas, ishchi qo'shib bo'lmaydi."
+	MsgWorkerAlreadyBooked = "⚠️ Bu ishchi allaqachon ushbu ishga yozilgan."
+	MsgWorkerPhoneBooked   = "⚠️ Shu telefon raqami bilan boshqa akkaunt bu ishga allaqachon yozilgan."
+
+	// Location resend to confirmed workers
+	MsgNoConfirmedWorkers = "📭 Bu ishda tasdiqlangan ishchilar yo'q."
+	MsgLocationResendBusy = "⏳ Lokatsiya hozir yuborilmoqda, biroz kuting."
+
+	// MsgCallbackProcessing answers a repeated tap on a button that is still being handled
+	MsgCallbackProcessing = "⏳ So'rovingiz bajarilmoqda..."
+
+	// Runtime settings (super admins, "⚙️ Sozlamalar")
+	MsgEnterAdminIDs      = "👥 Adminlarning Telegram ID raqamlarini vergul bilan ajratib yuboring.\n\nMasalan: 123456789, 987654321\n\nℹ️ Super adminlar ro'yxatda bo'lmasa ham admin bo'lib qoladi."
+	MsgInvalidAdminIDs    = "❌ Noto'g'ri ro'yxat. Faqat musbat ID raqamlarini vergul bilan ajratib yuboring:"
+	MsgEnterCard          = "💳 Yangi karta ma'lumotlarini ikki qatorda yuboring:\n\n8600 1234 5678 9012\nISM FAMILIYA"
+	MsgInvalidCard        = "❌ Karta raqami 16 ta raqamdan iborat bo'lishi kerak. Qaytadan yuboring:"
+	MsgInvalidCardOwner   = "❌ Karta egasining ismini ikkinchi qatorda yuboring (ko'pi bilan 64 belgi):"
+	MsgSettingsCancelled  = "❌ Sozlamani o'zgartirish bekor qilindi."
+	MsgNotSuperAdmin      = "❌ Bu sozlamani faqat super admin o'zgartira oladi."
+	MsgAdminRightsGranted = "👋 Sizga admin huquqi berildi. Admin menyusi pastda."
+	MsgAdminRightsRevoked = "ℹ️ Sizning admin huquqingiz olib tashlandi."
+	MsgAdminJobMsgRevoked = "🔒 Bu ish kartasi endi mavjud emas: admin huquqingiz olib tashlangan."
+
+	// Payment review backlog (/pending)
+	MsgNoPendingPayments = "✅ Tekshirilishi kutilayotgan to'lovlar yo'q."
+
+	// Receipts sent as an album: the photos after the first, numbered for admins
+	MsgReceiptAlbumPhoto = "📎 To'lov cheki — %d/%d-rasm (Booking #%d)"
+
+	// Amount typed after the receipt (PAYMENT_ASK_AMOUNT)
+	MsgPaymentAmountAsk = `💵 <b>Qancha summa o'tkazdingiz?</b>
+
+Summani raqam bilan yozing, masalan: <code>%s</code>
+Chek shundan keyin adminga yuboriladi.
+
+⏰ Bron yana %d daqiqa amal qiladi — shu vaqt ichida javob bering.`
+	MsgPaymentAmountGone    = "❌ Chek topilmadi. Iltimos, to'lov chekini qaytadan yuboring."
+	MsgPaymentAmountExpired = `⏰ Summani kutayotganda bron vaqti tugadi, chek adminga yuborilmadi.
+
+Iltimos, qaytadan joy band qiling.`
+
+	// Admin replies to receipt cards, relayed to the user
+	MsgAdminRelaySent      = "✅ Javob foydalanuvchiga yuborildi."
+	MsgAdminRelayFailed    = "❌ Javobni yuborib bo'lmadi: foydalanuvchi botni bloklagan bo'lishi mumkin."
+	MsgAdminRelayNoBooking = "❌ Bu chekka tegishli booking topilmadi."
+
+	// Support threads ("✉️ Adminga yozish")
+	MsgSupportAsk = `✉️ Savolingiz yoki muammoingizni bitta xabarda yozing — adminlarga yetkazaman.
+
+Javob shu chatga keladi.`
+	MsgSupportSent = `✅ Murojaatingiz #%d adminlarga yuborildi. Javob shu chatga keladi.
+
+Murojaat ochiq turganda yozgan xabarlaringiz ham adminlarga yetkaziladi.`
+	MsgSupportForwarded   = "📨 Xabaringiz murojaat #%d ga qo'shildi."
+	MsgSupportThreadOpen  = "📨 Sizda ochiq murojaat bor (#%d). Shu chatga yozgan xabarlaringiz adminlarga yetkaziladi.\n\nJavob olgan bo'lsangiz, murojaatni yoping."
+	MsgSupportCancelled   = "❌ Murojaat bekor qilindi."
+	MsgSupportClosed      = "✅ Murojaat #%d yopildi. Yangi savol bo'lsa, \"✉️ Adminga yozish\" tugmasini bosing."
+	MsgSupportClosedNote  = "🔒 Murojaat #%d yopildi: %s."
+	MsgSupportNotOpen     = "❌ Murojaat #%d yopilgan, javob yuborilmadi."
+	MsgSupportUnavailable = "❌ Hozir adminlarga xabar yuborib bo'lmadi. Keyinroq urinib ko'ring."
+	MsgSupportTextOnly    = "✍️ Iltimos, murojaatni matn ko'rinishida yozing."
+
+	// Channel subscription required for booking
+	MsgSubscriptionRequired = `📢 <b>Ishga yozilish uchun kanalimizga a'zo bo'ling</b>
+
+Barcha yangi ishlar kanalda e'lon qilinadi. A'zo bo'lgach, "✅ Tekshirish" tugmasini bosing.`
+	MsgSubscriptionMissing = "❌ Siz hali kanalga a'zo bo'lmagansiz. Avval \"📢 A'zo bo'lish\" tugmasini bosing."
+
+	// Job cancellation and refunds (/refunds)
+	MsgJobCancelConfirm = `🚫 <b>№%s ishini bekor qilasizmi?</b>
+
+Yozilgan barcha ishchilarga xabar yuboriladi, xizmat haqqini to'laganlar uchun qaytarish yozuvi ochiladi. Bu amalni ortga qaytarib bo'lmaydi.`
+	MsgJobNotCancellable = "ℹ️ Ish allaqachon bekor qilingan yoki yakunlangan."
+	MsgNoPendingRefunds  = "✅ Qaytarilishi kerak bo'lgan to'lovlar yo'q."
+
+	// Payroll sheet of a closed job
+	MsgPayrollEmpty          = "ℹ️ Bu ishda tasdiqlangan ishchilar yo'q — hisobot bo'sh."
+	MsgPayrollEmployerFailed = "⚠️ №%s ish haqi hisoboti ish beruvchiga (%s) yuborilmadi. Hisobot chatini tekshiring: bot u yerda bo'lishi kerak."
+	MsgEnterEmployerContact  = `📤 Ish yopilganda ish haqi hisoboti yuboriladigan chat ID sini kiriting.
+
+Bu ish beruvchining hisobchisi (avval botga /start yozgan bo'lishi kerak) yoki bot qo'shilgan guruh bo'lishi mumkin. O'chirish uchun 0 yuboring.`
+	MsgEmployerContactInvalid     = "❌ Chat ID raqam bo'lishi kerak, masalan: 123456789 yoki -1001234567890. O'chirish uchun 0 yuboring."
+	MsgEmployerContactUnreachable = "❌ Bot bu chatni ko'ra olmayapti. Foydalanuvchi botga /start yozganini yoki bot guruhga qo'shilganini tekshiring."
+
+	// Job requirements screened at booking, overridable from the receipt card
+	MsgApproveRequirementsUnmet = "⚠️ Ishchi bu ish talablariga mos emas. Avval «🎯 Talabdan ozod qilish» tugmasini bosing yoki chekni rad eting."
+	MsgRequirementsWaived       = "🎯 Talablardan ozod qilindi"
+
+	// Receipts sent as a file
+	MsgReceiptDocumentType     = "❌ Bu fayl turi qabul qilinmaydi. To'lov chekini rasm yoki PDF fayl ko'rinishida yuboring."
+	MsgReceiptDocumentTooLarge = "❌ Fayl juda katta (ko'pi bilan 10 MB). Chekning skrinshotini yuboring."
+
+	// One booking per phone (BOOKING_ONE_PER_PHONE)
+	MsgPhoneAlreadyBooked = "⚠️ Sizning telefon raqamingiz bilan boshqa akkaunt bu ishga allaqachon yozilgan. Bitta raqamdan faqat bitta joy band qilish mumkin."
+
+	// Channel discussion group auto-replies
+	MsgDiscussionAutoReply = `👋 Savolingiz uchun rahmat!
+
+📋 <b>Ish №%s</b> ga faqat bot orqali yoziling — quyidagi tugmani bosing.
+
+❓ <b>Ko'p so'raladigan savollar:</b>
+• <b>Qanday yozilaman?</b> Tugmani bosing, ro'yxatdan o'ting va joyni band qiling.
+• <b>Xizmat haqqi qancha?</b> E'londa ko'rsatilgan; to'lov chekini botga yuborasiz.
+• <b>Aniq manzil qayerda?</b> Joylashuv to'lov tasdiqlangandan so'ng botda yuboriladi.
+• <b>Savollar bo'lsa?</b> Botdagi ma'lumotlarni ko'ring yoki adminga yozing.`
+	MsgDiscussionJobClosed = "ℹ️ Ish №%s ga yozilish yopilgan. Yangi ishlar kanalda e'lon qilinadi — kuzatib boring!"
+
+	// Worker feedback messages
+	MsgFeedbackConditions = "🏭 Ish sharoiti yaxshi bo'ldimi?"
+	MsgFeedbackThanks     = "🙏 Fikringiz uchun rahmat! Javoblaringiz ish beruvchilarni baholashda yordam beradi."
+
+	// Block messages
+	MsgBlockExpired = "✅ Bloklash muddati tugadi. Endi yana ishlarga yozilishingiz mumkin."
 
 	// Registration messages
 	MsgRegistrationWelcome = `👋 Xush kelibsiz!
@@ -81,6 +212,28 @@ Quyidagi shartlar bilan tanishib chiqing:`
 Ishga yozilish uchun avval ro'yxatdan o'tishingiz kerak.
 Ro'yxatdan o'tish uchun /start buyrug'ini yuboring.`
 
+	// Public offer messages (offer text is plain, no HTML)
+	MsgOfferMissing  = "⚠️ Oferta hali e'lon qilinmagan. Iltimos, keyinroq urinib ko'ring."
+	MsgOfferUpdated  = "📄 Oferta yangilandi (%d-versiya)\n\nBotdan foydalanishni davom ettirish uchun yangi shartlarni qabul qiling.\n\n%s"
+	MsgOfferOutdated = "ℹ️ Oferta yana yangilandi. Iltimos, oxirgi versiya bilan tanishib chiqing."
+	MsgOfferAccepted = "✅ Oferta qabul qilindi (%d-versiya)"
+	MsgOfferDeclined = "❌ Yangi ofertani qabul qilmaguningizcha ishlarga yozila olmaysiz.\n\nQabul qilish uchun /start buyrug'ini yuboring."
+
+	// Admin public offer messages
+	MsgAdminOffer      = "📄 OFERTA\n\nJoriy versiya: %d\nE'lon qilingan: %s\n\n%s"
+	MsgAdminOfferNone  = "📄 OFERTA\n\nHali birorta versiya e'lon qilinmagan."
+	MsgEnterOfferText  = "✏️ Ofertaning yangi matnini bitta xabarda yuboring.\n\nE'lon qilingach, foydalanuvchilardan keyingi tashrifida yangi versiyani qabul qilish so'raladi."
+	MsgOfferPreview    = "👀 Yangi oferta (%d-versiya):\n\n%s"
+	MsgOfferPublished  = "✅ Oferta %d-versiyasi e'lon qilindi."
+	MsgOfferTooLong    = "❌ Matn juda uzun (ko'pi bilan %d belgi). Iltimos, qisqartiring."
+	MsgOfferDraftEmpty = "❌ Yangi matn topilmadi. Iltimos, qaytadan boshlang: /offer"
+
+	// MsgJobEditConflict is sent when another admin changed the job while it was being edited
+	MsgJobEditConflict = "⚠️ Bu ish boshqa admin tomonidan o'zgartirildi. O'zgarishingiz saqlanmadi — yangilab, qaytadan urinib ko'ring."
+
+	// MsgAdminFanOutPartial is sent to the admin whose action was broadcast to other admins
+	MsgAdminFanOutPartial = "⚠️ Yangilanish %d ta adminga yetkazilmadi (jami %d ta). Telegram cheklovi sababli bo'lishi mumkin."
+
 	MsgRegistrationContinue = `📝 Sizda tugallanmagan ro'yxatdan o'tish jarayoni mavjud.
 
 Davom ettirish yoki qaytadan boshlash uchun tanlang:`
@@ -89,6 +242,8 @@ Davom ettirish yoki qaytadan boshlash uchun tanlang:`
 
 Siz muvaffaqiyatli ro'yxatdan o'tgansiz.
 
+Tugmalar ko'rinmasa, buyruqlardan foydalaning: /profile — ma'lumotlarim, /myjobs — ishlarim, /help — yordam.
+
 Quyidagi imkoniyatlardan foydalanishingiz mumkin:`
 
 	MsgPhoneRequestManualInput = `❌ Iltimos, qo'l bilan yozmang!
@@ -103,6 +258,13 @@ Ro'yxatdan o'tish bekor qilindi.
 
 Qayta ro'yxatdan o'tish uchun /start buyrug'ini yuboring.`
 
+	MsgFlowExpired     = "⌛️ Oldingi jarayon uzoq vaqt faolsiz qolgani uchun bekor qilindi. Kerakli bo'limni qaytadan tanlang."
+	MsgStaleStateReset = "⌛️ Jarayon bekor qilindi: uzoq vaqt davomida javob bo'lmadi. Davom etish uchun kerakli bo'limni qaytadan tanlang yoki /start ni bosing."
+
+	// Unrecognized text from idle users
+	MsgUnknownText             = "🤷 Tushunmadim.\n\nPastdagi menyu tugmalaridan foydalaning yoki /help ni bosing. Adminga savol bo'lsa \"✉️ Adminga yozish\" tugmasini bosing."
+	MsgUnknownTextUnregistered = "🤷 Tushunmadim.\n\nIshlarga yozilish uchun avval ro'yxatdan o'ting: /start ni bosing."
+
 	MsgRegistrationCancelled = `❌ Ro'yxatdan o'tish bekor qilindi.
 
 Qayta boshlash uchun /start buyrug'ini yuboring.`
@@ -139,9 +301,86 @@ Masalan: 70 175
 
 ⚠️ Vazn: 30-200 kg, Bo'y: 100-250 sm`
 
+	MsgEnterGender = `🚻 Jinsingizni tanlang:
+
+Ayrim ishlar faqat erkaklar yoki faqat ayollar uchun bo'ladi.`
+
+	MsgEnterCity = `🏙 Qaysi shahar yoki tumanda yashaysiz?
+
+Masalan: Chilonzor tumani`
+
 	MsgEnterPassportPhoto = `📸 Pasport rasmingizni yuboring:
 
 ⚠️ Faqat rasm formatida yuboring (fayl emas)`
+
+	// Shown as a callback alert, so it stays under 200 characters
+	MsgPhoneAlreadyRegistered = `❌ Bu telefon raqam boshqa Telegram akkauntda ro'yxatdan o'tgan.
+
+Adminlarga xabar berildi, tez orada ko'rib chiqishadi. Yoki "Tahrirlash" orqali boshqa raqam kiriting.`
+
+	MsgPhoneTaken = `❌ Bu telefon raqam boshqa foydalanuvchiga tegishli.
+
+Boshqa raqam kiriting yoki admin bilan bog'laning.`
+
+	MsgDuplicateMerged = `✅ Admin telefon raqamingizni ushbu akkauntga o'tkazdi.
+
+Siz ro'yxatdan o'tdingiz! Endi ishlarni ko'rishingiz va ishga yozilishingiz mumkin.`
+
+	MsgDuplicateRejected = `❌ Admin bu telefon raqamni akkauntingizga o'tkazmadi.
+
+Iltimos, o'zingizning boshqa raqamingizni yuboring yoki admin bilan bog'laning.`
+
+	MsgDeleteAccountWarning = `🗑 <b>Hisobni o'chirish</b>
+
+Hisobingiz o'chirilsa:
+• Ism, telefon, yosh va boshqa ma'lumotlaringiz o'chiriladi
+• To'lov cheklaringiz ishlar tarixidan olib tashlanadi
+• Ishga yozilish uchun qaytadan ro'yxatdan o'tishingiz kerak bo'ladi
+
+ℹ️ Qoidabuzarliklar va bloklar saqlanib qoladi.
+
+Davom etasizmi?`
+
+	MsgDeleteAccountFinal = `⚠️ <b>Oxirgi tasdiqlash</b>
+
+Bu amalni qaytarib bo'lmaydi. Hisobingizni butunlay o'chirasizmi?`
+
+	MsgDeleteAccountActiveBookings = `❌ Hisobni hozir o'chirib bo'lmaydi.
+
+Sizda faol ishlar bor (band qilingan, to'lov kutilayotgan yoki tasdiqlangan). Ular yakunlangach qayta urinib ko'ring.`
+
+	MsgAccountDeleted = `✅ Hisobingiz o'chirildi.
+
+Shaxsiy ma'lumotlaringiz tizimdan olib tashlandi. Qaytadan ro'yxatdan o'tish uchun /start ni bosing.`
+
+	MsgDeleteAccountCancelled = "❌ Bekor qilindi. Hisobingiz saqlanib qoldi."
+
+	MsgAccountDeactivatedDuplicate = `ℹ️ Telefon raqamingiz boshqa Telegram akkauntga o'tkazildi, shu sababli bu akkaunt faolsizlantirildi.
+
+Agar bu xato bo'lsa, admin bilan bog'laning.`
+
+	// Re-engagement campaign messages
+	MsgReengageStarted = "📣 Kampaniya boshlandi. Yuborish tugagach natijasini yuboraman."
+	MsgReengageNoJobs  = "ℹ️ Hozir ochiq ishlar yo'q — kampaniya yuborilmadi."
+	MsgReengageNoUsers = "ℹ️ Bu muddatda ishga yozilmagan foydalanuvchilar topilmadi."
+	MsgReengageBusy    = "⏳ Boshqa kampaniya hozir yuborilmoqda. Tugagach qayta urinib ko'ring."
+	MsgReengageBadDays = "❌ Kunlar soni 1 dan 365 gacha bo'lishi kerak. Masalan: /reengage 14"
+
+	// Worker notification preferences (⚙️ Sozlamalar)
+	MsgMarketingOptedOut = "🔕 Endi bunday xabarlar yuborilmaydi. ⚙️ Sozlamalar orqali qayta yoqishingiz mumkin."
+	MsgMarketingOptedIn  = "🔔 Yangi ishlar haqida takliflar qayta yoqildi."
+
+	// Open jobs browsed inside the bot (🔎 Ochiq ishlar)
+	MsgOpenJobs       = "🔎 <b>OCHIQ ISHLAR</b>\n\nBatafsil ko'rish va yozilish uchun ishni tanlang 👇"
+	MsgNoOpenJobs     = "📭 Hozircha ochiq ishlar yo'q. Yangi ishlar kanalda e'lon qilinadi."
+	MsgJobCardCurrent = "✅ Ma'lumotlar yangi"
+	MsgJobCardUpdated = "🔄 Yangilandi"
+
+	// Database backups (/backup, /backup_status; super admins only)
+	MsgSuperAdminOnly = "❌ Bu buyruq faqat super adminlar uchun."
+	MsgBackupDisabled = "ℹ️ Zaxira nusxa sozlanmagan: BACKUP_DIR yoki BACKUP_WEBHOOK_URL ni belgilang."
+	MsgBackupStarted  = "⏳ Zaxira nusxa olinmoqda... Tugagach natijasini yuboraman."
+	MsgBackupRunning  = "⏳ Zaxira nusxa allaqachon olinmoqda. Tugashini kuting."
 )
 
 // FormatWelcomeRegistered formats welcome message for registered user
@@ -149,13 +388,41 @@ func FormatWelcomeRegistered(fullName string) string {
 	return fmt.Sprintf(MsgWelcomeRegistered, fullName)
 }
 
+// JobNumber formats a job's number per config.JobNumbering, without the leading "№".
+// Jobs created before per-day numbering fall back to the global order number.
+func JobNumber(job *models.Job) string {
+	n := config.JobNumbering
+	if n.Format == config.JobNumberDaily && job.DayNumber > 0 && job.OrderDay != "" {
+		return fmt.Sprintf("%s%s/#%d", n.Prefix, job.OrderDay, job.DayNumber)
+	}
+	return fmt.Sprintf("%s%d", n.Prefix, job.OrderNumber)
+}
+
+// FormatJobForChannel formats the public channel post. It never includes the employer phone.
+// config.ChannelPost picks the rich or plain layout and whether hashtags are appended.
 func FormatJobForChannel(job *models.Job) string {
 	var sb strings.Builder
+	rich := config.ChannelPost.Style == config.ChannelPostRich
+	workDate, hasWorkDate := helper.ParseWorkDate(job.WorkDate, job.CreatedAt.In(config.Timezone))
+
+	if job.Status == models.JobStatusCancelled {
+		sb.WriteString("🚫 <b>ISH BEKOR QILINDI</b>\n\n")
+	}
+
+	// The post shows caption A; the reposts alternate the variants (Job.BumpCaptionVariant)
+	if job.CaptionA != "" {
+		fmt.Fprintf(&sb, "<b>%s</b>\n\n", html.EscapeString(job.CaptionA))
+	}
 
 	// Header with Order Number
-	fmt.Fprintf(&sb, "📋 №%d\n\n", job.OrderNumber)
+	fmt.Fprintf(&sb, "📋 №%s\n\n", JobNumber(job))
 	// Main Details
 	fmt.Fprintf(&sb, "📅Sana: %s\n", job.WorkDate)
+	if rich && hasWorkDate && (job.Status == models.JobStatusActive || job.Status == models.JobStatusFull) {
+		if countdown := workDateCountdown(workDate, time.Now().In(config.Timezone)); countdown != "" {
+			sb.WriteString(countdown + "\n")
+		}
+	}
 	fmt.Fprintf(&sb, "💰Maosh: %s\n", job.Salary)
 	fmt.Fprintf(&sb, "⏰Ish vaqti: %s\n", job.WorkTime)
 
@@ -173,8 +440,12 @@ func FormatJobForChannel(job *models.Job) string {
 		fmt.Fprintf(&sb, "🚌Avtobuslar: %s\n", job.Buses)
 	}
 
+	if requirements := FormatJobRequirements(job); requirements != "" {
+		fmt.Fprintf(&sb, "🎯Talablar: %s\n", requirements)
+	}
+
 	// Money matters
-	fmt.Fprintf(&sb, "💳Xizmat haqqi: %s so'm\n", helper.FormatMoney(job.ServiceFee))
+	fmt.Fprintf(&sb, "💳Xizmat haqqi: %s\n", helper.Money(job.ServiceFee))
 	if job.AdditionalInfo != "" {
 		fmt.Fprintf(&sb, "📝Batafsil: %s \n\n", job.AdditionalInfo)
 	}
@@ -189,6 +460,9 @@ func FormatJobForChannel(job *models.Job) string {
 	case models.JobStatusCompleted:
 		statusEmoji = "⚫"
 		statusText = "YOPILGAN"
+	case models.JobStatusCancelled:
+		statusEmoji = "🚫"
+		statusText = "BEKOR QILINDI"
 	}
 
 	// Visual Capacity Bar
@@ -198,27 +472,168 @@ func FormatJobForChannel(job *models.Job) string {
 		"👥 Ishchilar: %d/%d (Bo‘sh: %d ta)\n",
 		job.ConfirmedSlots,
 		job.RequiredWorkers,
-		job.RequiredWorkers-job.ConfirmedSlots,
+		job.AvailableSlots(),
 	)
+	if rich && job.RequiredWorkers > 0 {
+		fmt.Fprintf(&sb, "%s %d%%\n", capacityBar(job.ConfirmedSlots, job.RequiredWorkers),
+			min(job.ConfirmedSlots, job.RequiredWorkers)*100/job.RequiredWorkers)
+	}
+	// Places held by unpaid reservations come back if the payment doesn't arrive
+	if job.ReservedSlots > 0 && job.Status == models.JobStatusActive {
+		fmt.Fprintf(&sb, "⏳ To‘lov kutilmoqda: %d ta\n", job.ReservedSlots)
+	}
+
+	if config.ChannelPost.Hashtags {
+		var tags []string
+		if hasWorkDate {
+			tags = append(tags, fmt.Sprintf("#%d%s", workDate.Day(), helper.UzMonthName(workDate.Month())))
+		}
+		switch job.Gender {
+		case models.GenderMale:
+			tags = append(tags, "#erkaklar")
+		case models.GenderFemale:
+			tags = append(tags, "#ayollar")
+		}
+		if job.Food != "" {
+			tags = append(tags, "#ovqatli")
+		}
+		if job.Buses != "" {
+			tags = append(tags, "#transport")
+		}
+		if len(tags) > 0 {
+			sb.WriteString("\n" + strings.Join(tags, " "))
+		}
+	}
 	return sb.String()
 }
 
+// channelBarCells is the width of the channel post capacity bar
+const channelBarCells = 10
+
+// capacityBar draws confirmed places out of total as ▰▰▰▱▱▱▱▱▱▱. A started job shows at least one
+// filled cell and a job with a free place never looks full.
+func capacityBar(confirmed, total int) string {
+	filled := min(confirmed, total) * channelBarCells / total
+	if confirmed > 0 && filled == 0 {
+		filled = 1
+	}
+	if confirmed < total && filled == channelBarCells {
+		filled = channelBarCells - 1
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", channelBarCells-filled)
+}
+
+// workDateCountdown tells how far the work date is from now; empty once the day has passed
+func workDateCountdown(workDate, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, workDate.Location())
+	days := int(workDate.Sub(today).Hours() / 24)
+	switch {
+	case days < 0:
+		return ""
+	case days == 0:
+		return "🔥 Bugun!"
+	case days == 1:
+		return "⚡ Ertaga"
+	default:
+		return fmt.Sprintf("⏳ Ishgacha %d kun qoldi", days)
+	}
+}
+
+// FormatJobMapCaption captions the static map preview of a job's location
+func FormatJobMapCaption(job *models.Job) string {
+	return fmt.Sprintf("📍 №%s — %s", JobNumber(job), job.Address)
+}
+
+// FormatSlotsReopened formats the channel follow-up posted when a FULL job gets more places
+func FormatSlotsReopened(job *models.Job) string {
+	return fmt.Sprintf("🔔 <b>Yana joylar ochildi!</b>\n\n📋 №%s — %s\n👥 Bo'sh joylar: %d ta\n\n👇 Yozilish uchun tugmani bosing.",
+		JobNumber(job), job.WorkDate, job.AvailableSlots())
+}
+
+// FormatJobBump formats the channel repost of a job that still has free places close to its work date.
+// It replies to the original post, which carries the full details, and leads with the variant's caption when set.
+func FormatJobBump(job *models.Job, variant models.CaptionVariant) string {
+	headline := "⏰ <b>Joylar hali bor!</b>"
+	if caption := job.Caption(variant); caption != "" {
+		headline = "<b>" + html.EscapeString(caption) + "</b>"
+	}
+	return fmt.Sprintf("%s\n\n📋 №%s — %s, %s\n💰 Maosh: %s\n📍 Manzil: %s\n👥 Bo'sh joylar: <b>%d</b> ta\n\n👆 Batafsil — yuqoridagi e'londa. Yozilish uchun tugmani bosing.",
+		headline, JobNumber(job), job.WorkDate, job.WorkTime, job.Salary, job.Address, job.AvailableSlots())
+}
+
+// PhoneAudience is who reads a message that mentions the employer phone
+type PhoneAudience int
+
+const (
+	// AudienceShared is anything that may reach non-admins: the channel, groups, workers before approval
+	AudienceShared PhoneAudience = iota
+	// AudienceAdmin is an admin in private chat
+	AudienceAdmin
+	// AudienceConfirmedWorker is a worker whose payment was approved
+	AudienceConfirmedWorker
+)
+
+// EmployerPhone decides who sees the employer phone: admins in private chat and confirmed
+// workers get it in full, everyone else a masked form such as +99890***4567
+func EmployerPhone(phone string, audience PhoneAudience) string {
+	if phone == "" {
+		return ""
+	}
+	if audience == AudienceAdmin || audience == AudienceConfirmedWorker {
+		return phone
+	}
+	return MaskPhone(phone)
+}
+
+// MaskPhone keeps the country and operator code and the last 4 digits: +998901234567 → +99890***4567
+func MaskPhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < 9 {
+		return "***"
+	}
+
+	masked := digits[:len(digits)-7] + "***" + digits[len(digits)-4:]
+	if strings.HasPrefix(strings.TrimSpace(phone), "+") {
+		masked = "+" + masked
+	}
+	return masked
+}
+
 // FormatJobDetailAdmin formats a job for admin detail view
 func FormatJobDetailAdmin(job *models.Job) string {
+	return formatJobDetailAdmin(job, AudienceAdmin)
+}
+
+// FormatJobDetailForGroup formats the admin job detail for a group chat, with the employer phone masked
+func FormatJobDetailForGroup(job *models.Job) string {
+	return formatJobDetailAdmin(job, AudienceShared)
+}
+
+func formatJobDetailAdmin(job *models.Job, audience PhoneAudience) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("<b>№ %d</b>\n\n", job.OrderNumber))
+	sb.WriteString(fmt.Sprintf("<b>№ %s</b>\n\n", JobNumber(job)))
 	sb.WriteString(fmt.Sprintf("💰 <b>Ish haqqi:</b> %s\n", job.Salary))
 	sb.WriteString(fmt.Sprintf("🍛 <b>Ovqat:</b> %s\n", valueOrEmpty(job.Food)))
 	sb.WriteString(fmt.Sprintf("⏰ <b>Vaqt:</b> %s\n", job.WorkTime))
 	sb.WriteString(fmt.Sprintf("📍 <b>Manzil:</b> %s\n", job.Address))
 	sb.WriteString(fmt.Sprintf("📌 <b>Aniq joylashuv:</b> %s\n", valueOrEmpty(job.Location)))
-	sb.WriteString(fmt.Sprintf("🌟 <b>Xizmat haqqi:</b> %s so'm\n", helper.FormatMoney(job.ServiceFee)))
+	sb.WriteString(fmt.Sprintf("🌟 <b>Xizmat haqqi:</b> %s\n", helper.Money(job.ServiceFee)))
 	sb.WriteString(fmt.Sprintf("🚌 <b>Avtobuslar:</b> %s\n", valueOrEmpty(job.Buses)))
 	sb.WriteString(fmt.Sprintf("📝 <b>Ish tavsifi:</b> %s\n", valueOrEmpty(job.AdditionalInfo)))
 	sb.WriteString(fmt.Sprintf("📅 <b>Ish kuni:</b> %s\n", job.WorkDate))
 	sb.WriteString(fmt.Sprintf("👥 <b>Ishchilar:</b> %d/%d\n", job.ConfirmedSlots, job.RequiredWorkers))
-	sb.WriteString(fmt.Sprintf("📞 <b>Ish beruvchi telefon:</b> %s\n", valueOrEmpty(job.EmployerPhone)))
+	sb.WriteString(fmt.Sprintf("🎯 <b>Talablar:</b> %s\n", valueOrEmpty(FormatJobRequirements(job))))
+	sb.WriteString(fmt.Sprintf("📞 <b>Ish beruvchi telefon:</b> %s\n", valueOrEmpty(EmployerPhone(job.EmployerPhone, audience))))
+	if job.CaptionA != "" || job.CaptionB != "" {
+		sb.WriteString(fmt.Sprintf("🅰️ <b>Matn A:</b> %s\n", valueOrEmpty(html.EscapeString(job.CaptionA))))
+		sb.WriteString(fmt.Sprintf("🅱️ <b>Matn B:</b> %s\n", valueOrEmpty(html.EscapeString(job.CaptionB))))
+	}
 	sb.WriteString(fmt.Sprintf("\n<b>Status:</b> %s\n", job.Status.Display()))
 
 	if job.ChannelMessageID != 0 {
@@ -230,6 +645,452 @@ func FormatJobDetailAdmin(job *models.Job) string {
 	return sb.String()
 }
 
+// FormatJobCreationStep prefixes a job creation prompt with the step counter and, when the field
+// already has a value (going back or editing from the review), shows it
+func FormatJobCreationStep(step, total int, prompt, current string) string {
+	text := fmt.Sprintf("📝 %d/%d\n\n%s", step, total, prompt)
+	if current != "" {
+		text += "\n\nJoriy qiymat: " + current
+	}
+	return text
+}
+
+// FormatJobCreationReview shows the drafted job before it is saved
+func FormatJobCreationReview(job *models.Job) string {
+	var sb strings.Builder
+
+	sb.WriteString("👀 <b>ISHNI TEKSHIRING</b>\n\n")
+	sb.WriteString(fmt.Sprintf("💰 <b>Ish haqqi:</b> %s\n", job.Salary))
+	sb.WriteString(fmt.Sprintf("🍛 <b>Ovqat:</b> %s\n", valueOrEmpty(job.Food)))
+	sb.WriteString(fmt.Sprintf("⏰ <b>Vaqt:</b> %s\n", job.WorkTime))
+	sb.WriteString(fmt.Sprintf("📍 <b>Manzil:</b> %s\n", job.Address))
+	sb.WriteString(fmt.Sprintf("📌 <b>Aniq joylashuv:</b> %s\n", valueOrEmpty(job.Location)))
+	sb.WriteString(fmt.Sprintf("🌟 <b>Xizmat haqqi:</b> %s\n", helper.Money(job.ServiceFee)))
+	sb.WriteString(fmt.Sprintf("🚌 <b>Avtobuslar:</b> %s\n", valueOrEmpty(job.Buses)))
+	sb.WriteString(fmt.Sprintf("📝 <b>Ish tavsifi:</b> %s\n", valueOrEmpty(job.AdditionalInfo)))
+	sb.WriteString(fmt.Sprintf("📅 <b>Ish kuni:</b> %s\n", job.WorkDate))
+	sb.WriteString(fmt.Sprintf("👥 <b>Kerakli ishchilar:</b> %d\n", job.RequiredWorkers))
+	sb.WriteString(fmt.Sprintf("📞 <b>Ish beruvchi telefon:</b> %s\n", valueOrEmpty(EmployerPhone(job.EmployerPhone, AudienceAdmin))))
+	sb.WriteString("\nHammasi to'g'rimi? Maydonni tuzatish uchun uning tugmasini bosing yoki <b>💾 Saqlash</b>ni bosing.")
+
+	return sb.String()
+}
+
+// FormatEmployerDetail formats an employer card with job history for admins
+func FormatEmployerDetail(employer *models.Employer, stats *models.EmployerStats, feedback *models.FeedbackSummary, jobs []*models.Job) string {
+	var sb strings.Builder
+
+	sb.WriteString("🏢 <b>ISH BERUVCHI</b>\n\n")
+	if feedback.IsFlagged() {
+		sb.WriteString("⚠️ <b>Ishchilardan shikoyatlar ko'p!</b>\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("👤 <b>Nomi:</b> %s\n", employer.Name))
+	sb.WriteString(fmt.Sprintf("📞 <b>Telefon:</b> %s\n", EmployerPhone(employer.Phone, AudienceAdmin)))
+	sb.WriteString(fmt.Sprintf("⭐ <b>Reyting:</b> %s\n", employer.RatingDisplay()))
+	sb.WriteString(fmt.Sprintf("📝 <b>Izoh:</b> %s\n", valueOrEmpty(employer.Notes)))
+	if employer.ContactChatID != 0 {
+		sb.WriteString(fmt.Sprintf("📤 <b>Hisobot chati:</b> <code>%d</code>\n", employer.ContactChatID))
+	}
+
+	sb.WriteString("\n📊 <b>Statistika:</b>\n")
+	sb.WriteString(fmt.Sprintf("• Jami ishlar: %d ta (yakunlangan: %d ta)\n", stats.TotalJobs, stats.CompletedJobs))
+	sb.WriteString(fmt.Sprintf("• Tasdiqlangan ishchilar: %d ta\n", stats.ConfirmedWorkers))
+	sb.WriteString(fmt.Sprintf("• Kelgan: %d ta, kelmagan: %d ta (%d%%)\n", stats.Attended, stats.NoShows, stats.NoShowRate()))
+
+	sb.WriteString("\n💬 <b>Ishchilar fikri:</b>\n")
+	if feedback.Responses == 0 {
+		sb.WriteString("• Hali javob yo'q\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("• Javoblar: %d ta\n", feedback.Responses))
+		sb.WriteString(fmt.Sprintf("• Ish haqi muammosi: %d ta\n", feedback.PayIssues))
+		sb.WriteString(fmt.Sprintf("• Sharoit muammosi: %d ta\n", feedback.ConditionIssues))
+	}
+
+	if len(jobs) > 0 {
+		sb.WriteString("\n📋 <b>Oxirgi ishlar:</b>\n")
+		for _, job := range jobs {
+			sb.WriteString(fmt.Sprintf("• № %s — %s — %s — %d/%d\n",
+				JobNumber(job), job.WorkDate, job.Status.Display(), job.ConfirmedSlots, job.RequiredWorkers))
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatJobFunnel formats a job's conversion funnel; each step shows its share of the step before
+func FormatJobFunnel(job *models.Job, f *models.JobFunnel) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("📈 <b>ISH №%s — VORONKA</b>\n\n", JobNumber(job)))
+	sb.WriteString(fmt.Sprintf("📅 Ish kuni: %s\n", job.WorkDate))
+	sb.WriteString(fmt.Sprintf("👥 Joylar: %d/%d\n\n", job.ConfirmedSlots, job.RequiredWorkers))
+
+	sb.WriteString("👁 Kanal ko'rishlari: kanal statistikasida (bot ko'ra olmaydi)\n")
+	sb.WriteString(fmt.Sprintf("🔗 Havolani ochganlar: %d kishi (%d marta)\n", f.LinkUsers, f.LinkOpens))
+	sb.WriteString(fmt.Sprintf("📝 Band qilganlar: %d ta%s\n", f.Bookings, funnelShare(f.Bookings, f.LinkUsers)))
+	sb.WriteString(fmt.Sprintf("💳 Chek yuborganlar: %d ta%s\n", f.PaymentsSubmitted, funnelShare(f.PaymentsSubmitted, f.Bookings)))
+	sb.WriteString(fmt.Sprintf("✅ Tasdiqlanganlar: %d ta%s\n", f.Approved, funnelShare(f.Approved, f.PaymentsSubmitted)))
+	sb.WriteString(fmt.Sprintf("🙋 Ishga kelganlar: %d ta%s\n", f.Attended, funnelShare(f.Attended, f.Approved)))
+	if f.NoShows > 0 {
+		sb.WriteString(fmt.Sprintf("🚷 Kelmaganlar: %d ta\n", f.NoShows))
+	}
+	if unmarked := f.Approved - f.Attended - f.NoShows; unmarked > 0 {
+		sb.WriteString(fmt.Sprintf("❔ Davomat belgilanmagan: %d ta\n", unmarked))
+	}
+
+	if len(f.Captions) > 0 {
+		sb.WriteString("\n🆎 <b>Matn variantlari</b> (kishi birinchi ochgan havolasi bo'yicha):\n")
+		for _, cs := range f.Captions {
+			sb.WriteString(fmt.Sprintf("\n<b>%s</b>", cs.Variant.Display()))
+			if caption := job.Caption(cs.Variant); caption != "" {
+				sb.WriteString(": " + html.EscapeString(caption))
+			}
+			sb.WriteString(fmt.Sprintf("\n🔗 %d kishi (%d marta) → 📝 %d ta%s → ✅ %d ta%s\n",
+				cs.LinkUsers, cs.LinkOpens,
+				cs.Bookings, funnelShare(cs.Bookings, cs.LinkUsers),
+				cs.Approved, funnelShare(cs.Approved, cs.LinkUsers)))
+		}
+	}
+
+	return sb.String()
+}
+
+// funnelShare returns " (n%)" for part out of whole, or "" when whole is 0
+func funnelShare(part, whole int) string {
+	if whole == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d%%)", part*100/whole)
+}
+
+// FormatUserViolations formats a user's violation history for admins
+func FormatUserViolations(userID int64, name string, details []*models.ViolationDetail, block *models.BlockedUser) string {
+	var sb strings.Builder
+
+	sb.WriteString("📋 <b>QOIDABUZARLIKLAR</b>\n\n")
+	sb.WriteString(fmt.Sprintf("👤 <b>Foydalanuvchi:</b> %s (<code>%d</code>)\n", valueOrEmpty(name), userID))
+
+	switch {
+	case block == nil:
+		sb.WriteString("🟢 <b>Holat:</b> bloklanmagan\n")
+	case block.IsPermanent():
+		sb.WriteString("🚫 <b>Holat:</b> doimiy bloklangan\n")
+	default:
+		sb.WriteString(fmt.Sprintf("⏳ <b>Holat:</b> %s gacha bloklangan\n",
+			block.BlockedUntil.In(config.Timezone).Format("02.01.2006 15:04")))
+	}
+
+	if len(details) == 0 {
+		sb.WriteString("\n✅ Qoidabuzarliklar yo'q.")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<b>Jami:</b> %d ta\n", len(details)))
+	for i, d := range details {
+		sb.WriteString(fmt.Sprintf("\n<b>%d.</b> %s\n", i+1, d.CreatedAt.In(config.Timezone).Format("02.01.2006 15:04")))
+		if d.JobNumber != "" {
+			sb.WriteString(fmt.Sprintf("   💼 Ish: №%s\n", d.JobNumber))
+		}
+		if d.AdminID != nil {
+			sb.WriteString(fmt.Sprintf("   👮 Admin: <code>%d</code>\n", *d.AdminID))
+		}
+		sb.WriteString(fmt.Sprintf("   ⚠️ Turi: %s\n", d.ViolationType))
+	}
+
+	return sb.String()
+}
+
+// FormatDuplicatePhoneAlert formats the admin card for a registration that hit an already used phone
+func FormatDuplicatePhoneAlert(existing *models.RegisteredUser, draft *models.RegistrationDraft, block *models.BlockedUser) string {
+	var sb strings.Builder
+
+	sb.WriteString("⚠️ <b>TAKRORIY TELEFON RAQAM</b>\n\n")
+	fmt.Fprintf(&sb, "📞 Raqam: %s\n\n", draft.Phone)
+
+	sb.WriteString("<b>Mavjud akkaunt:</b>\n")
+	fmt.Fprintf(&sb, "👤 %s (<code>%d</code>)\n", valueOrEmpty(existing.FullName), existing.UserID)
+	fmt.Fprintf(&sb, "📅 Ro'yxatdan o'tgan: %s\n", existing.CreatedAt.In(config.Timezone).Format("02.01.2006"))
+	switch {
+	case block == nil:
+	case block.IsPermanent():
+		sb.WriteString("🚫 Doimiy bloklangan\n")
+	case block.BlockedUntil.After(time.Now()):
+		fmt.Fprintf(&sb, "⏳ %s gacha bloklangan\n", block.BlockedUntil.In(config.Timezone).Format("02.01.2006 15:04"))
+	}
+
+	sb.WriteString("\n<b>Yangi akkaunt:</b>\n")
+	fmt.Fprintf(&sb, "👤 %s (<code>%d</code>)\n", valueOrEmpty(draft.FullName), draft.UserID)
+	fmt.Fprintf(&sb, "🎂 Yosh: %d | Vazn: %d kg | Bo'y: %d sm\n\n", draft.Age, draft.Weight, draft.Height)
+
+	sb.WriteString("🔁 <b>O'tkazish</b> — eski akkaunt faolsizlantiriladi, yangisi ro'yxatdan o'tadi (blok ham o'tadi).\n")
+	sb.WriteString("❌ <b>Rad etish</b> — yangi akkauntdan boshqa raqam so'raladi.")
+
+	return sb.String()
+}
+
+// FormatProfileChangeAlert formats the admin notice about a worker editing contact data before a job
+func FormatProfileChangeAlert(user *models.RegisteredUser, changes []*models.ProfileChange, jobs []*models.Job) string {
+	var sb strings.Builder
+
+	sb.WriteString("✏️ <b>PROFIL O'ZGARDI</b>\n\n")
+	fmt.Fprintf(&sb, "👤 %s (<code>%d</code>)\n\n", valueOrEmpty(user.FullName), user.UserID)
+
+	for _, ch := range changes {
+		label := "👤 Ism familiya"
+		if ch.Field == models.ProfileFieldPhone {
+			label = "📞 Telefon"
+		}
+		fmt.Fprintf(&sb, "%s: %s → <b>%s</b>\n", label, valueOrEmpty(ch.OldValue), valueOrEmpty(ch.NewValue))
+	}
+
+	sb.WriteString("\n📋 <b>Tasdiqlangan ishlari:</b>\n")
+	for _, job := range jobs {
+		fmt.Fprintf(&sb, "• №%s — %s, %s\n", JobNumber(job), job.WorkDate, job.WorkTime)
+	}
+
+	sb.WriteString("\nℹ️ Kerak bo'lsa, ish beruvchiga yangi ma'lumotlarni yetkazing.")
+
+	return sb.String()
+}
+
+// FormatFeedbackPrompt formats the first feedback question sent after the work date
+func FormatFeedbackPrompt(job *models.Job) string {
+	return fmt.Sprintf(`📝 <b>Ish №%s haqida fikringiz</b>
+
+📅 Ish kuni: %s
+📍 Manzil: %s
+
+💰 Ish haqi to'g'ri to'landimi?`, JobNumber(job), job.WorkDate, job.Address)
+}
+
+// channelIndexMaxJobs keeps the pinned index well under Telegram's message length limit
+const channelIndexMaxJobs = 40
+
+// FormatChannelIndex formats the pinned "Bugungi ishlar" message: open jobs with their free places and signup links
+func FormatChannelIndex(jobs []*models.Job, botUsername string) string {
+	var sb strings.Builder
+
+	sb.WriteString("📌 <b>BUGUNGI ISHLAR</b>\n\n")
+	if len(jobs) == 0 {
+		sb.WriteString("Hozircha ochiq ishlar yo'q. Yangi ishlar shu kanalda e'lon qilinadi.")
+		return sb.String()
+	}
+	for i, job := range jobs {
+		if i == channelIndexMaxJobs {
+			fmt.Fprintf(&sb, "… va yana %d ta ish\n", len(jobs)-channelIndexMaxJobs)
+			break
+		}
+		fmt.Fprintf(&sb, "• <a href=\"https://t.me/%s?start=job_%d\">№%s</a> — %s, %s — 👥 %d ta joy\n",
+			botUsername, job.ID, JobNumber(job), job.WorkDate, job.Salary, job.AvailableSlots())
+	}
+	sb.WriteString("\nYozilish uchun ish raqamini bosing 👆")
+
+	return sb.String()
+}
+
+// FormatJobInterestNudge reminds a user who opened a job's signup link to finish registering
+func FormatJobInterestNudge(job *models.Job) string {
+	return fmt.Sprintf(`👋 Siz <b>№%s</b> raqamli ishga qiziqqan edingiz, lekin ro'yxatdan o'tish tugallanmadi.
+
+💰 %s
+📅 %s
+📍 %s
+
+Ro'yxatdan o'tish bir necha daqiqa oladi — tugatsangiz, shu ishga yozilishingiz mumkin.`,
+		JobNumber(job), job.Salary, job.WorkDate, job.Address)
+}
+
+// FormatJobListTitle is the text above the admin job list; count covers every page of the view
+func FormatJobListTitle(count int, showAll bool) string {
+	view := "faol"
+	if showAll {
+		view = "barchasi"
+	}
+	if count == 0 {
+		return "📋 <b>Ishlar ro'yxati</b> (faol)\n\nFaol ishlar yo'q."
+	}
+
+	return fmt.Sprintf("📋 <b>Ishlar ro'yxati</b> (%s): %d ta\n\nYangi ishlar birinchi sahifada, har sahifa ish kuni bo'yicha; 👥 bo'sh joylar / kerakli ishchilar.", view, count)
+}
+
+// FormatJobInterestCounts lists jobs whose signup link was opened by users who haven't registered
+func FormatJobInterestCounts(counts []*models.JobInterestCount) string {
+	var sb strings.Builder
+
+	sb.WriteString("🔗 <b>QIZIQQAN, RO'YXATDAN O'TMAGANLAR</b>\n\n")
+	if len(counts) == 0 {
+		sb.WriteString("Hozircha yo'q.")
+		return sb.String()
+	}
+
+	for _, c := range counts {
+		if c.Job != nil {
+			sb.WriteString(fmt.Sprintf("• № %s — %s — %s\n", JobNumber(c.Job), c.Job.WorkDate, c.Job.Status.Display()))
+		} else {
+			sb.WriteString(fmt.Sprintf("• Ish ID %d\n", c.JobID))
+		}
+		sb.WriteString(fmt.Sprintf("   👤 %d kishi, eslatma yuborilgan: %d\n", c.Unregistered, c.Nudged))
+	}
+	sb.WriteString("\nHavolani ochib, ro'yxatdan o'tmaganlarga 1 soatdan keyin bir marta eslatma yuboriladi.")
+
+	return sb.String()
+}
+
+// FormatReengageDigest is the campaign message to a registered user who hasn't booked for a while
+func FormatReengageDigest(jobs []*models.Job) string {
+	var sb strings.Builder
+
+	sb.WriteString("👋 Anchadan beri ishga yozilmadingiz. Hozir ochiq ishlar:\n\n")
+	for _, job := range jobs {
+		fmt.Fprintf(&sb, "• <b>№%s</b> — %s\n   💰 %s\n   📍 %s\n", JobNumber(job), job.WorkDate, job.Salary, job.Address)
+	}
+	sb.WriteString("\nYozilish uchun ishni tanlang 👇")
+
+	return sb.String()
+}
+
+// FormatReengageResult reports a finished campaign to the admin who started it
+func FormatReengageResult(c *models.ReengageCampaign) string {
+	return fmt.Sprintf("✅ <b>Kampaniya #%d yuborildi</b>\n\n📤 Yuborildi: %d/%d\n❌ Xato: %d\n💼 Ishlar: %d\n\nNatijalar: /reengage",
+		c.ID, c.Sent, c.Recipients, c.Failed, c.Jobs)
+}
+
+// FormatReengageReports lists recent re-engagement campaigns with their results
+func FormatReengageReports(campaigns []*models.ReengageCampaign) string {
+	var sb strings.Builder
+
+	sb.WriteString("📣 <b>QAYTA JALB QILISH KAMPANIYALARI</b>\n\n")
+	if len(campaigns) == 0 {
+		sb.WriteString("Hozircha kampaniya o'tkazilmagan.\n")
+	}
+	for _, c := range campaigns {
+		started := "avtomatik"
+		if c.StartedBy != 0 {
+			started = fmt.Sprintf("admin %d", c.StartedBy)
+		}
+		fmt.Fprintf(&sb, "<b>#%d</b> — %s (%s)\n", c.ID, c.CreatedAt.In(config.Timezone).Format("02.01.2006 15:04"), started)
+		fmt.Fprintf(&sb, "   %d kundan beri yozilmaganlar, %d ta ish\n", c.InactiveDays, c.Jobs)
+		fmt.Fprintf(&sb, "   📤 Yuborildi: %d/%d, xato: %d\n", c.Sent, c.Recipients, c.Failed)
+		fmt.Fprintf(&sb, "   🔗 Havolani ochdi: %d, ✍️ yozildi: %d\n\n", c.Opened, c.Booked)
+	}
+	sb.WriteString("Yangi kampaniya: <code>/reengage 14</code> — 14 kundan beri ishga yozilmaganlarga ochiq ishlar yuboriladi.")
+
+	return sb.String()
+}
+
+// FormatWorkerReliability formats a worker's reliability for admin cards
+func FormatWorkerReliability(rel *models.WorkerReliability) string {
+	if !rel.HasHistory() {
+		return "🆕 Yangi ishchi (tarix yo'q)"
+	}
+
+	text := fmt.Sprintf("%d%%", rel.Score())
+	if rel.RatingCount > 0 {
+		text += fmt.Sprintf(" (⭐ %.1f, %d ta baho", rel.AverageRating, rel.RatingCount)
+	} else {
+		text += " (baholanmagan"
+	}
+	return text + fmt.Sprintf("; kelgan: %d, kelmagan: %d)", rel.Attended, rel.NoShows)
+}
+
+// FormatSharedPhoneWarning lists other accounts registered with the worker's phone; empty when there are none
+func FormatSharedPhoneWarning(otherUserIDs []int64) string {
+	if len(otherUserIDs) == 0 {
+		return ""
+	}
+	ids := make([]string, len(otherUserIDs))
+	for i, id := range otherUserIDs {
+		ids[i] = fmt.Sprintf("<code>%d</code>", id)
+	}
+	return "⚠️ Shu telefon boshqa akkauntlarda ham bor: " + strings.Join(ids, ", ") + "\n"
+}
+
+// FormatJobRequirements lists a job's worker requirements on one line; empty when it has none
+func FormatJobRequirements(job *models.Job) string {
+	var parts []string
+	for _, r := range []models.Requirement{models.RequirementGender, models.RequirementAge, models.RequirementHeight, models.RequirementWeight} {
+		if text := requirementText(job, r); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func requirementText(job *models.Job, r models.Requirement) string {
+	switch r {
+	case models.RequirementGender:
+		switch job.Gender {
+		case models.GenderMale:
+			return "Faqat erkaklar"
+		case models.GenderFemale:
+			return "Faqat ayollar"
+		}
+	case models.RequirementAge:
+		switch {
+		case job.MinAge > 0 && job.MaxAge > 0:
+			return fmt.Sprintf("%d–%d yosh", job.MinAge, job.MaxAge)
+		case job.MinAge > 0:
+			return fmt.Sprintf("%d yoshdan", job.MinAge)
+		case job.MaxAge > 0:
+			return fmt.Sprintf("%d yoshgacha", job.MaxAge)
+		}
+	case models.RequirementHeight:
+		if job.MinHeight > 0 {
+			return fmt.Sprintf("bo'yi %d sm dan", job.MinHeight)
+		}
+	case models.RequirementWeight:
+		if job.MinWeight > 0 {
+			return fmt.Sprintf("vazni %d kg dan", job.MinWeight)
+		}
+	}
+	return ""
+}
+
+// requirementMismatches lists each unmet requirement next to the worker's own value
+func requirementMismatches(job *models.Job, unmet []models.Requirement, user *models.RegisteredUser, whose string) string {
+	var sb strings.Builder
+	for _, r := range unmet {
+		var value string
+		switch r {
+		case models.RequirementGender:
+			value = strings.ToLower(user.Gender.Display())
+		case models.RequirementAge:
+			value = fmt.Sprintf("%d yosh", user.Age)
+		case models.RequirementHeight:
+			value = fmt.Sprintf("%d sm", user.Height)
+		case models.RequirementWeight:
+			value = fmt.Sprintf("%d kg", user.Weight)
+		}
+		fmt.Fprintf(&sb, "• %s — %s %s\n", requirementText(job, r), whose, value)
+	}
+	return sb.String()
+}
+
+// FormatRequirementsNotMet explains to a user why their profile keeps them from booking the job
+func FormatRequirementsNotMet(job *models.Job, unmet []models.Requirement, user *models.RegisteredUser) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "⚠️ <b>Bu ishga yozila olmaysiz</b>\n\n№%s talablari: %s\n\nProfilingiz mos kelmadi:\n", JobNumber(job), FormatJobRequirements(job))
+	sb.WriteString(requirementMismatches(job, unmet, user, "sizda"))
+	if user.Gender == models.GenderUnknown && slices.Contains(unmet, models.RequirementGender) {
+		sb.WriteString("\nJinsingizni 👤 Profil → 🚻 Jins orqali ko'rsating.\n")
+	}
+	sb.WriteString("\nProfilingizdagi ma'lumot eskirgan bo'lsa, uni yangilab qayta urinib ko'ring. Savolingiz bo'lsa, admin bilan bog'laning.")
+	return sb.String()
+}
+
+// FormatRequirementMismatch warns the payment reviewers that the worker misses the job's requirements;
+// empty when the worker meets them
+func FormatRequirementMismatch(job *models.Job, unmet []models.Requirement, user *models.RegisteredUser, waived bool) string {
+	if len(unmet) == 0 {
+		return ""
+	}
+	title := "⚠️ <b>Talablarga mos emas:</b>\n"
+	if waived {
+		title = "🎯 <b>Talablardan ozod qilingan:</b>\n"
+	}
+	return title + requirementMismatches(job, unmet, user, "ishchida")
+}
+
 func valueOrEmpty(s string) string {
 	if s == "" {
 		return "—"
@@ -256,30 +1117,134 @@ func FormatJobDetailUser(job *models.Job) string {
 	msg := fmt.Sprintf(`
 <b>ISH HAQIDA MA'LUMOT</b>
 
-📋 <b>№:</b> %d
+📋 <b>№:</b> %s
 💰 <b>Ish haqqi:</b> %s
 🍛 <b>Ovqat:</b> %s
 ⏰ <b>Vaqt:</b> %s
 📍 <b>Manzil:</b> %s
-🌟 <b>Xizmat haqqi:</b> %s so'm
+🌟 <b>Xizmat haqqi:</b> %s
 📅 <b>Ish kuni:</b> %s
-
-👥 <b>Bo'sh joylar:</b> %d
+%s%s
+👥 <b>Bo'sh joylar:</b> %d%s
 
 Ishga yozilishni tasdiqlaysizmi?
 `,
-		job.OrderNumber,
+		JobNumber(job),
 		job.Salary,
 		helper.ValueOrDefault(job.Food, "ko'rsatilmagan"),
 		job.WorkTime,
 		job.Address,
-		helper.FormatMoney(job.ServiceFee),
+		helper.Money(job.ServiceFee),
 		job.WorkDate,
+		userRequirementsLine(job),
+		userExtrasLines(job),
 		job.AvailableSlots(),
+		userReservedNote(job),
 	)
 	return msg
 }
+
+// userExtrasLines are the optional buses and details lines of the user job card
+func userExtrasLines(job *models.Job) string {
+	var sb strings.Builder
+	if job.Buses != "" {
+		fmt.Fprintf(&sb, "🚌 <b>Avtobuslar:</b> %s\n", job.Buses)
+	}
+	if job.AdditionalInfo != "" {
+		fmt.Fprintf(&sb, "📝 <b>Batafsil:</b> %s\n", job.AdditionalInfo)
+	}
+	return sb.String()
+}
+
+// userReservedNote tells how many places unpaid reservations hold; they come back if the payment doesn't arrive
+func userReservedNote(job *models.Job) string {
+	if job.ReservedSlots == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (yana %d ta joy to'lov kutmoqda)", job.ReservedSlots)
+}
+
+// userRequirementsLine is the requirements line of the user job card; empty when the job has none
+func userRequirementsLine(job *models.Job) string {
+	if !job.HasRequirements() {
+		return ""
+	}
+	return fmt.Sprintf("🎯 <b>Talablar:</b> %s\n", FormatJobRequirements(job))
+}
+
+// FormatAdminRelay formats an admin's reply to a receipt card for the user; the admin's text is escaped
+func FormatAdminRelay(job *models.Job, bookingID int64, text string) string {
+	return fmt.Sprintf(`💬 <b>ADMIN XABARI</b>
+📋 Ish #%s · Booking #%d
+
+%s`, JobNumber(job), bookingID, html.EscapeString(text))
+}
+
+// FormatSupportMessage formats a user's support message for admins; replies to it are routed back to the user
+func FormatSupportMessage(threadID int64, name, username string, userID int64, text string) string {
+	if username == "" {
+		username = "—"
+	} else {
+		username = "@" + username
+	}
+	return fmt.Sprintf(`✉️ <b>MUROJAAT #%d</b>
+👤 %s (%s, ID: <code>%d</code>)
+
+%s
+
+↩️ <i>Javob berish uchun shu xabarga reply qiling.</i>`, threadID, html.EscapeString(name), username, userID, html.EscapeString(text))
+}
+
+// FormatSupportReply formats an admin's answer in a support thread for the user
+func FormatSupportReply(threadID int64, text string) string {
+	return fmt.Sprintf(`💬 <b>ADMIN JAVOBI</b> (murojaat #%d)
+
+%s`, threadID, html.EscapeString(text))
+}
+
+// FormatPaidAmount shows the amount the user typed on the admin receipt card and flags one that differs
+// from the service fee; empty when the user gave no amount
+func FormatPaidAmount(paid, fee int) string {
+	if paid <= 0 {
+		return ""
+	}
+	line := fmt.Sprintf("💵 <b>To'langan summa:</b> %s", helper.Money(paid))
+	if paid == fee {
+		return line + " ✅\n"
+	}
+	diff := paid - fee
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	return fmt.Sprintf("%s\n⚠️ <b>Summa farq qiladi: %s%s</b> (xizmat haqqi %s)\n",
+		line, sign, helper.Money(diff), helper.Money(fee))
+}
+
+// FormatReceiptPhotoCount notes under the admin receipt caption that more photos follow
+func FormatReceiptPhotoCount(total int) string {
+	if total <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d ta rasm)", total)
+}
+
 func FormatPaymentInstructions(job *models.Job, cardNumber, cardHolderName string) string {
+	return formatPaymentInstructions(job, cardNumber, cardHolderName, "⏰ Vaqt: 3 daqiqa")
+}
+
+// FormatPaymentCountdown re-renders the payment instructions with the time left on the reservation
+func FormatPaymentCountdown(job *models.Job, cardNumber, cardHolderName string, remaining time.Duration) string {
+	seconds := int(remaining.Round(time.Second).Seconds())
+	timeLine := fmt.Sprintf("⏳ Qolgan vaqt: <b>%d:%02d</b>", seconds/60, seconds%60)
+	if remaining <= 30*time.Second {
+		timeLine += "\n⚠️ Tezroq to'lov chekini yuboring, aks holda joy bo'shatiladi!"
+	}
+	return formatPaymentInstructions(job, cardNumber, cardHolderName, timeLine)
+}
+
+func formatPaymentInstructions(job *models.Job, cardNumber, cardHolderName, timeLine string) string {
 	msg := fmt.Sprintf(`
 ✅ <b>JOY BAND QILINDI!</b>
 
@@ -289,11 +1254,594 @@ Sizga 3 daqiqa vaqt berildi. Iltimos, quyidagi ma'lumotlarga to'lovni amalga osh
 💳 Karta: <code>%s</code>
 👤 Ism: %s
 
-<b>To'lov summasi:</b> %s so'm (Xizmat haqqi)
+<b>To'lov summasi:</b> %s (Xizmat haqqi)
 
-⏰ Vaqt: 3 daqiqa
+%s
 
 To'lov chekini yuboring (screenshot):
-`, cardNumber, cardHolderName, helper.FormatMoney(job.ServiceFee))
+`, cardNumber, cardHolderName, helper.Money(job.ServiceFee), timeLine)
 	return msg
 }
+
+// FormatSlotDriftAlert formats the admin alert sent after the nightly slot check corrected many slots
+func FormatSlotDriftAlert(fixed []*models.SlotDrift, total int) string {
+	var sb strings.Builder
+
+	sb.WriteString("⚠️ <b>JOYLAR HISOBI TUZATILDI</b>\n\n")
+	fmt.Fprintf(&sb, "Tungi tekshiruv %d ta ishda jami <b>%d</b> ta joy farqini topdi va tuzatdi:\n\n", len(fixed), total)
+	for _, d := range fixed {
+		label := fmt.Sprintf("ID %d", d.JobID)
+		if d.Job != nil {
+			label = "№" + JobNumber(d.Job)
+		}
+		fmt.Fprintf(&sb, "• %s — band: %d → %d, tasdiqlangan: %d → %d\n",
+			label, d.Reserved, d.ActualReserved, d.Confirmed, d.ActualConfirmed)
+	}
+	sb.WriteString("\nℹ️ Farq tez-tez chiqsa, loglarda \"Slot counters corrected\" yozuvlarini tekshiring.")
+
+	return sb.String()
+}
+
+// FormatJobChangedNotice tells a confirmed worker which job details an admin changed
+func FormatJobChangedNotice(job *models.Job, changes []models.JobFieldChange) string {
+	var sb strings.Builder
+
+	sb.WriteString("📢 <b>ISH MA'LUMOTLARI O'ZGARDI</b>\n\n")
+	fmt.Fprintf(&sb, "Siz yozilgan №%s ishda quyidagilar o'zgardi:\n\n", JobNumber(job))
+
+	for _, ch := range changes {
+		switch ch.Field {
+		case models.JobFieldWorkDate:
+			fmt.Fprintf(&sb, "📅 Ish kuni: <s>%s</s> → <b>%s</b>\n", valueOrEmpty(ch.Old), valueOrEmpty(ch.New))
+		case models.JobFieldWorkTime:
+			fmt.Fprintf(&sb, "⏰ Ish vaqti: <s>%s</s> → <b>%s</b>\n", valueOrEmpty(ch.Old), valueOrEmpty(ch.New))
+		case models.JobFieldAddress:
+			fmt.Fprintf(&sb, "📍 Manzil: <s>%s</s> → <b>%s</b>\n", valueOrEmpty(ch.Old), valueOrEmpty(ch.New))
+		case models.JobFieldLocation:
+			sb.WriteString("🗺 Lokatsiya yangilandi (pastda)\n")
+		}
+	}
+
+	sb.WriteString("\nIltimos, yangi ma'lumotlarga e'tibor bering. Savollar bo'lsa, admin bilan bog'laning.")
+	return sb.String()
+}
+
+// FormatRuntimeSettings formats the settings super admins can change without a restart.
+// overridden lists the keys stored in bot_settings; the rest still use the env value.
+func FormatRuntimeSettings(adminIDs []int64, superAdminIDs []int64, card models.PaymentCard, overridden map[string]bool) string {
+	var sb strings.Builder
+
+	source := func(key string) string {
+		if !overridden[key] {
+			return " <i>(.env)</i>"
+		}
+		return ""
+	}
+
+	sb.WriteString("🛠 <b>BOT SOZLAMALARI</b>\n\n")
+	fmt.Fprintf(&sb, "👥 Adminlar%s:\n", source(models.SettingAdminIDs))
+	for _, id := range adminIDs {
+		if slices.Contains(superAdminIDs, id) {
+			fmt.Fprintf(&sb, "• <code>%d</code> ⭐️\n", id)
+		} else {
+			fmt.Fprintf(&sb, "• <code>%d</code>\n", id)
+		}
+	}
+	fmt.Fprintf(&sb, "\n💳 Karta%s: <code>%s</code>\n", source(models.SettingCardNumber), valueOrEmpty(card.Number))
+	fmt.Fprintf(&sb, "👤 Karta egasi%s: %s\n\n", source(models.SettingCardHolderName), valueOrEmpty(card.HolderName))
+	sb.WriteString("⭐️ — super admin (BOT_SUPER_ADMIN_IDS), har doim admin")
+
+	return sb.String()
+}
+
+// FormatLocationReminder formats the address/time reminder sent with the re-sent location pin.
+// Only confirmed workers get it, so the employer phone is shown in full.
+func FormatLocationReminder(job *models.Job) string {
+	var sb strings.Builder
+
+	sb.WriteString("📍 <b>ISH JOYI ESLATMASI</b>\n\n")
+	fmt.Fprintf(&sb, "📋 Ish: №%s\n", JobNumber(job))
+	fmt.Fprintf(&sb, "📅 Ish kuni: %s\n", job.WorkDate)
+	fmt.Fprintf(&sb, "⏰ Ish vaqti: %s\n", job.WorkTime)
+	fmt.Fprintf(&sb, "📍 Manzil: %s\n", job.Address)
+	if job.EmployerPhone != "" {
+		fmt.Fprintf(&sb, "📱 Ish beruvchi: <code>%s</code>\n", EmployerPhone(job.EmployerPhone, AudienceConfirmedWorker))
+	}
+	sb.WriteString("\nIltimos, belgilangan vaqtda yetib keling!")
+
+	return sb.String()
+}
+
+// FormatJobCancelledNotice tells a worker that the job they booked was cancelled; refund is nil for unpaid bookings
+func FormatJobCancelledNotice(job *models.Job, refund *models.Refund) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🚫 <b>Ish bekor qilindi</b>\n\n📋 №%s — %s\n📍 %s\n\nAfsuski, ish beruvchi bilan bog'liq sabablarga ko'ra bu ish bekor qilindi. Ishga bormang.",
+		JobNumber(job), html.EscapeString(job.WorkDate), html.EscapeString(job.Address))
+	if refund != nil {
+		fmt.Fprintf(&sb, "\n\n💸 To'lagan %s xizmat haqqingiz qaytariladi. Admin tez orada siz bilan bog'lanadi.",
+			helper.Money(refund.Amount))
+	}
+	return sb.String()
+}
+
+// FormatJobCancelResult reports to the admin how many workers were told about the cancellation
+func FormatJobCancelResult(job *models.Job, sent, total int, refunds []*models.Refund) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🚫 №%s bekor qilindi.\n\n👥 Xabar yuborildi: %d/%d ta ishchiga", JobNumber(job), sent, total)
+	if len(refunds) > 0 {
+		sum := 0
+		for _, refund := range refunds {
+			sum += refund.Amount
+		}
+		fmt.Fprintf(&sb, "\n💸 Qaytarilishi kerak: %d ta, jami %s — /refunds", len(refunds), helper.Money(sum))
+	}
+	return sb.String()
+}
+
+// FormatRefundList formats the pending refunds with the worker's name and phone for the transfer.
+// total is the whole backlog, which may be longer than the refunds shown.
+func FormatRefundList(refunds []*models.Refund, total int, users map[int64]*models.RegisteredUser) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "💸 <b>QAYTARILISHI KERAK: %d ta</b>\n", total)
+	for _, refund := range refunds {
+		fmt.Fprintf(&sb, "\n<b>#%d</b> — %s (ish #%d, booking #%d)\n", refund.ID, helper.Money(refund.Amount), refund.JobID, refund.BookingID)
+		if user, ok := users[refund.UserID]; ok {
+			fmt.Fprintf(&sb, "👤 %s, 📱 %s\n", html.EscapeString(user.FullName), html.EscapeString(user.Phone))
+		} else {
+			fmt.Fprintf(&sb, "👤 ID: <code>%d</code>\n", refund.UserID)
+		}
+		fmt.Fprintf(&sb, "🕐 %s\n", refund.CreatedAt.In(config.Timezone).Format("02.01.2006 15:04"))
+	}
+	if total > len(refunds) {
+		fmt.Fprintf(&sb, "\n… yana %d ta. To'langanlarini belgilang, keyin /refunds ni qayta yuboring.", total-len(refunds))
+	}
+	return sb.String()
+}
+
+// FormatRefundPaidNotice tells a worker that the fee of a cancelled job was sent back
+func FormatRefundPaidNotice(refund *models.Refund) string {
+	return fmt.Sprintf("✅ Bekor qilingan ish uchun %s xizmat haqqingiz qaytarildi.", helper.Money(refund.Amount))
+}
+
+// FormatBookingHistory formats a booking's status changes for admins (/booking <id>)
+func FormatBookingHistory(booking *models.JobBooking, job *models.Job, events []*models.BookingEvent) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📜 <b>BOOKING #%d TARIXI</b>\n\n", booking.ID)
+	if job != nil {
+		fmt.Fprintf(&sb, "💼 Ish №%s, %s\n", JobNumber(job), html.EscapeString(job.WorkDate))
+	}
+	fmt.Fprintf(&sb, "👤 Foydalanuvchi ID: <code>%d</code>\n", booking.UserID)
+	fmt.Fprintf(&sb, "📊 Hozirgi holat: %s\n", booking.Status.Display())
+
+	if len(events) == 0 {
+		sb.WriteString("\n📭 O'zgarishlar yozilmagan.")
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	for _, e := range events {
+		from := "🆕"
+		if e.FromStatus != "" {
+			from = e.FromStatus.Display()
+		}
+		var actor string
+		switch e.ActorID {
+		case 0:
+			actor = "🤖 bot"
+		case booking.UserID:
+			actor = "👤 ishchi"
+		default:
+			actor = fmt.Sprintf("👮 admin <code>%d</code>", e.ActorID)
+		}
+		fmt.Fprintf(&sb, "🕐 %s — %s → %s (%s)\n", e.CreatedAt.In(config.Timezone).Format("02.01.2006 15:04"), from, e.ToStatus.Display(), actor)
+		if e.Note != "" {
+			fmt.Fprintf(&sb, "   💬 %s\n", html.EscapeString(e.Note))
+		}
+	}
+	return sb.String()
+}
+
+// FormatBookingStatus renders the user's live status message of a booking (BOT_BOOKING_TRACKER):
+// the steps done so far and what the user should do or expect next
+func FormatBookingStatus(booking *models.JobBooking, job *models.Job) string {
+	clock := func(t time.Time) string { return t.In(config.Timezone).Format("15:04") }
+
+	var sb strings.Builder
+	sb.WriteString("📍 <b>BANDLOV HOLATI</b>\n\n")
+	if job != nil {
+		fmt.Fprintf(&sb, "💼 Ish №%s, %s\n", JobNumber(job), html.EscapeString(job.WorkDate))
+	}
+	fmt.Fprintf(&sb, "📊 Holat: <b>%s</b>\n\n", booking.Status.Display())
+
+	fmt.Fprintf(&sb, "✅ Joy band qilindi — %s\n", clock(booking.ReservedAt))
+
+	switch {
+	case booking.AwaitsReceipt():
+		fmt.Fprintf(&sb, "⏳ To'lov cheki kutilmoqda — %s gacha\n", clock(booking.ExpiresAt))
+	case booking.PaymentSubmittedAt != nil:
+		fmt.Fprintf(&sb, "✅ To'lov cheki yuborildi — %s\n", clock(*booking.PaymentSubmittedAt))
+	default:
+		sb.WriteString("▫️ To'lov cheki yuborilmadi\n")
+	}
+
+	switch booking.Status {
+	case models.BookingStatusPaymentSubmitted:
+		sb.WriteString("⏳ Admin tekshirmoqda\n")
+	case models.BookingStatusConfirmed:
+		if booking.ConfirmedAt != nil {
+			fmt.Fprintf(&sb, "✅ To'lov tasdiqlandi — %s\n", clock(*booking.ConfirmedAt))
+		} else {
+			sb.WriteString("✅ To'lov tasdiqlandi\n")
+		}
+	case models.BookingStatusRejected, models.BookingStatusPaymentRejectedRetryable:
+		if booking.ReviewedAt != nil {
+			fmt.Fprintf(&sb, "❌ To'lov cheki rad etildi — %s\n", clock(*booking.ReviewedAt))
+		} else {
+			sb.WriteString("❌ To'lov cheki rad etildi\n")
+		}
+	case models.BookingStatusExpired:
+		sb.WriteString("⏰ Vaqt tugadi, joy bo'shatildi\n")
+	case models.BookingStatusCancelledByAdmin, models.BookingStatusCancelledByUser:
+		sb.WriteString("🚫 Bandlov bekor qilindi\n")
+	}
+
+	sb.WriteString("\n")
+	switch booking.Status {
+	case models.BookingStatusSlotReserved:
+		fmt.Fprintf(&sb, "📸 To'lov chekini <b>%s</b> gacha yuboring, aks holda joy bo'shatiladi.", clock(booking.ExpiresAt))
+	case models.BookingStatusPaymentRejectedRetryable:
+		fmt.Fprintf(&sb, "💬 Sabab: %s\n📸 Aniq va to'liq chekni <b>%s</b> gacha qayta yuboring, joyingiz shu vaqtgacha saqlanadi.",
+			html.EscapeString(booking.RejectionReason), clock(booking.ExpiresAt))
+	case models.BookingStatusPaymentSubmitted:
+		sb.WriteString("⏰ Admin odatda 10-15 daqiqa ichida tekshiradi. Natija shu xabarda ko'rinadi.")
+	case models.BookingStatusConfirmed:
+		sb.WriteString("📌 Ish manzili va tafsilotlari alohida xabarda yuborildi.")
+	case models.BookingStatusRejected:
+		fmt.Fprintf(&sb, "💬 Sabab: %s\nAgar joylar qolgan bo'lsa, kanal orqali qaytadan yozilishingiz mumkin.", html.EscapeString(booking.RejectionReason))
+	case models.BookingStatusExpired:
+		sb.WriteString("Yana yozilish uchun kanal orqali ishga qaytadan o'tishingiz mumkin.")
+	case models.BookingStatusCancelledByAdmin:
+		sb.WriteString("Ish admin tomonidan bekor qilindi. Xizmat haqqini to'lagan bo'lsangiz, u qaytariladi.")
+	}
+
+	fmt.Fprintf(&sb, "\n\n🕐 Yangilandi: %s", config.NowLocal().Format("15:04"))
+	return sb.String()
+}
+
+// FormatActiveBookingLimit tells a user which of their bookings on other jobs block a new one.
+// awaitingReceipt means one of them still waits for its receipt; otherwise limit of them are in progress.
+func FormatActiveBookingLimit(limit int, awaitingReceipt bool, blocking []*models.JobBooking, jobs map[int64]*models.Job) string {
+	var sb strings.Builder
+	if awaitingReceipt {
+		sb.WriteString("⚠️ Sizda to'lov cheki kutilayotgan boshqa bandlov bor. Avval uning chekini yuboring yoki bandlov vaqti tugashini kuting:\n\n")
+	} else if limit == 1 {
+		sb.WriteString("⚠️ Bir vaqtda faqat bitta ishga yozilish mumkin. Quyidagi bandlovingiz yakunlanishini kuting:\n\n")
+	} else {
+		fmt.Fprintf(&sb, "⚠️ Bir vaqtda ko'pi bilan %d ta ishga yozilish mumkin. Quyidagi bandlovlaringizdan biri yakunlanishini kuting:\n\n", limit)
+	}
+
+	for _, b := range blocking {
+		if job, ok := jobs[b.JobID]; ok {
+			fmt.Fprintf(&sb, "• №%s — %s — %s\n", JobNumber(job), html.EscapeString(job.WorkDate), b.Status.Display())
+		} else {
+			fmt.Fprintf(&sb, "• Ish #%d — %s\n", b.JobID, b.Status.Display())
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// FormatPayrollCaption summarizes a job's payroll sheet for the admins and the employer
+func FormatPayrollCaption(job *models.Job, employer *models.Employer, rows []*models.PayrollRow) string {
+	attended := 0
+	for _, row := range rows {
+		if row.Attendance == models.AttendanceAttended {
+			attended++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🧾 <b>Ish haqi hisoboti</b> — №%s\n\n", JobNumber(job))
+	if employer != nil {
+		fmt.Fprintf(&sb, "🏢 %s\n", html.EscapeString(employer.Name))
+	}
+	fmt.Fprintf(&sb, "📅 %s\n", html.EscapeString(job.WorkDate))
+	if hours, ok := job.WorkHours(); ok {
+		fmt.Fprintf(&sb, "⏰ %s (%s soat)\n", html.EscapeString(job.WorkTime), strconv.FormatFloat(hours.Hours(), 'f', -1, 64))
+	} else {
+		fmt.Fprintf(&sb, "⏰ %s\n", html.EscapeString(job.WorkTime))
+	}
+	fmt.Fprintf(&sb, "💰 %s\n", html.EscapeString(job.Salary))
+	fmt.Fprintf(&sb, "👥 Ishchilar: %d ta (keldi: %d, belgilanmagan: %d)", len(rows), attended, len(rows)-attended)
+	return sb.String()
+}
+
+// FormatLocationResendResult reports to the admin how many workers got the location
+func FormatLocationResendResult(job *models.Job, sent, total int) string {
+	if sent == total {
+		return fmt.Sprintf("✅ №%s: lokatsiya %d ta ishchiga yuborildi.", JobNumber(job), sent)
+	}
+	return fmt.Sprintf("⚠️ №%s: lokatsiya %d/%d ta ishchiga yuborildi (qolganlari botni bloklagan bo'lishi mumkin).", JobNumber(job), sent, total)
+}
+
+// FormatPaymentSLAReminder formats the reminder about receipts waiting for review.
+// level counts reminders sent for the current backlog; the tone escalates with it.
+func FormatPaymentSLAReminder(level, waiting, overdue int, sla, oldest time.Duration) string {
+	var sb strings.Builder
+
+	switch {
+	case level <= 1:
+		sb.WriteString("⏰ <b>TO'LOVLAR TEKSHIRILISHINI KUTMOQDA</b>\n\n")
+	case level == 2:
+		sb.WriteString("⚠️ <b>TO'LOVLAR HALI HAM KUTMOQDA</b> (2-eslatma)\n\n")
+	default:
+		fmt.Fprintf(&sb, "🚨 <b>TO'LOVLAR UZOQ KUTMOQDA!</b> (%d-eslatma)\n\n", level)
+	}
+
+	fmt.Fprintf(&sb, "📩 Kutilayotgan cheklar: <b>%d</b> ta\n", waiting)
+	fmt.Fprintf(&sb, "⏳ %d daqiqadan ortiq kutayotganlar: <b>%d</b> ta\n", int(sla.Minutes()), overdue)
+	fmt.Fprintf(&sb, "🕰 Eng eskisi: %d daqiqa oldin yuborilgan\n\n", int(oldest.Minutes()))
+	sb.WriteString("👉 /pending — kutilayotgan to'lovlar ro'yxati")
+
+	return sb.String()
+}
+
+// FormatAdminName labels an admin in reports by first name and username
+func FormatAdminName(user *models.User) string {
+	name := html.EscapeString(user.FirstName)
+	if name == "" {
+		name = fmt.Sprintf("ID %d", user.ID)
+	}
+	if user.Username != "" {
+		name += " (@" + html.EscapeString(user.Username) + ")"
+	}
+	return name
+}
+
+// FormatAdminActivity formats the per-admin report (/activity) for the period starting at from.
+// names holds FormatAdminName labels; admins missing from it are shown by ID.
+func FormatAdminActivity(activity []*models.AdminActivity, from time.Time, names map[int64]string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "👥 <b>ADMINLAR FAOLIYATI</b> — %s dan beri\n", from.Format("02.01.2006"))
+
+	if len(activity) == 0 {
+		sb.WriteString("\nBu davrda adminlar faoliyati qayd etilmagan.")
+		return sb.String()
+	}
+
+	totalReviewed := 0
+	for _, a := range activity {
+		totalReviewed += a.Reviewed()
+	}
+
+	for _, a := range activity {
+		name, ok := names[a.AdminID]
+		if !ok {
+			name = fmt.Sprintf("ID <code>%d</code>", a.AdminID)
+		}
+		fmt.Fprintf(&sb, "\n👤 <b>%s</b>\n", name)
+		fmt.Fprintf(&sb, "• Yaratilgan ishlar: %d\n", a.JobsCreated)
+		fmt.Fprintf(&sb, "• Tekshirilgan cheklar: %d (✅ %d, ❌ %d)", a.Reviewed(), a.Approved, a.Rejected)
+		if totalReviewed > 0 && a.Reviewed() > 0 {
+			fmt.Fprintf(&sb, " — %d%%", a.Reviewed()*100/totalReviewed)
+		}
+		sb.WriteString("\n")
+		if a.AvgReview > 0 {
+			fmt.Fprintf(&sb, "• O'rtacha tekshirish vaqti: %s\n", formatReviewLatency(a.AvgReview))
+		}
+		fmt.Fprintf(&sb, "• Bloklar: %d\n", a.Blocks)
+	}
+
+	fmt.Fprintf(&sb, "\n📊 Jami tekshirilgan cheklar: <b>%d</b>", totalReviewed)
+	return sb.String()
+}
+
+// formatReviewLatency shows a review time in minutes, or hours and minutes once it passes an hour
+func formatReviewLatency(d time.Duration) string {
+	if d < time.Minute {
+		return "1 daqiqadan kam"
+	}
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d daqiqa", minutes)
+	}
+	return fmt.Sprintf("%d soat %d daqiqa", minutes/60, minutes%60)
+}
+
+// FormatDailyDigest formats the morning summary for admins
+func FormatDailyDigest(d *models.DailyDigest) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "☀️ <b>KUNLIK HISOBOT — %s</b>\n\n", d.Date.Format("02.01.2006"))
+
+	sb.WriteString("💼 <b>Bugungi ishlar:</b>\n")
+	fmt.Fprintf(&sb, "• Faol ishlar: <b>%d</b>\n", d.ActiveJobs)
+	fmt.Fprintf(&sb, "• Bugun bo'ladigan ishlar: <b>%d</b>\n", len(d.TodayJobs))
+	fmt.Fprintf(&sb, "• Bo'sh joylar: <b>%d</b>\n\n", d.SlotsRemaining)
+
+	sb.WriteString("📋 <b>Kecha:</b>\n")
+	fmt.Fprintf(&sb, "• Tasdiqlangan to'lovlar: <b>%d</b>\n", d.Yesterday.Confirmed)
+	fmt.Fprintf(&sb, "• Tushum: <b>%s</b>\n", helper.Money(d.Yesterday.Revenue))
+	fmt.Fprintf(&sb, "• Muddati o'tgan bronlar: <b>%d</b>\n", d.Yesterday.Expired)
+	fmt.Fprintf(&sb, "• Rad etilgan to'lovlar: <b>%d</b>\n", d.Yesterday.Rejected)
+
+	unfilled := d.UnfilledTodayJobs()
+	if len(unfilled) > 0 {
+		sb.WriteString("\n⚠️ <b>Bugun to'lmagan ishlar:</b>\n")
+		for _, job := range unfilled {
+			fmt.Fprintf(&sb, "• №%s — %d/%d ishchi, %s\n", JobNumber(job), job.ConfirmedSlots, job.RequiredWorkers, job.WorkTime)
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatBookingVoucher formats the voucher a confirmed worker shows on site
+func FormatBookingVoucher(voucher *models.BookingVoucher, job *models.Job, fullName string) string {
+	var sb strings.Builder
+
+	sb.WriteString("🎫 <b>ISHGA YO'LLANMA</b>\n\n")
+	fmt.Fprintf(&sb, "📋 Ish: №%s\n", JobNumber(job))
+	fmt.Fprintf(&sb, "👤 Ishchi: %s\n", valueOrEmpty(fullName))
+	fmt.Fprintf(&sb, "📅 Sana: %s\n", job.WorkDate)
+	fmt.Fprintf(&sb, "📍 Manzil: %s\n\n", job.Address)
+	fmt.Fprintf(&sb, "🔐 Tasdiqlash kodi: <code>%s</code>\n\n", voucher.DisplayCode())
+	sb.WriteString("ℹ️ Ish joyida ushbu xabarni ko'rsating. Kodni boshqalarga bermang.")
+
+	return sb.String()
+}
+
+// FormatVoucherVerification formats the /verify result for admins.
+// firstCheck is false when the code was already verified before.
+func FormatVoucherVerification(voucher *models.BookingVoucher, booking *models.JobBooking, job *models.Job, fullName, phone string, firstCheck bool) string {
+	var sb strings.Builder
+
+	if booking.Status == models.BookingStatusConfirmed {
+		sb.WriteString("✅ <b>KOD HAQIQIY</b>\n\n")
+	} else {
+		sb.WriteString("⚠️ <b>KOD HAQIQIY, LEKIN YOZILISH FAOL EMAS</b>\n\n")
+	}
+
+	fmt.Fprintf(&sb, "🔐 Kod: <code>%s</code>\n", voucher.DisplayCode())
+	fmt.Fprintf(&sb, "📋 Ish: №%s\n", JobNumber(job))
+	fmt.Fprintf(&sb, "📅 Sana: %s\n", job.WorkDate)
+	fmt.Fprintf(&sb, "📍 Manzil: %s\n\n", job.Address)
+	fmt.Fprintf(&sb, "👤 Ishchi: %s (<code>%d</code>)\n", valueOrEmpty(fullName), booking.UserID)
+	if phone != "" {
+		fmt.Fprintf(&sb, "📱 Telefon: %s\n", phone)
+	}
+	fmt.Fprintf(&sb, "📊 Holat: %s\n", booking.Status.Display())
+
+	if !firstCheck && voucher.VerifiedAt != nil {
+		fmt.Fprintf(&sb, "\n⚠️ Bu kod avval %s da tekshirilgan", voucher.VerifiedAt.In(config.Timezone).Format("02.01.2006 15:04"))
+		if voucher.VerifiedByAdminID != nil {
+			fmt.Fprintf(&sb, " (admin <code>%d</code>)", *voucher.VerifiedByAdminID)
+		}
+		sb.WriteString(".")
+	}
+
+	return sb.String()
+}
+
+// FormatWebhookStalledAlert formats the ops alert sent when webhook updates stopped arriving.
+// errorAt is zero when Telegram reported no delivery error; fallback tells whether the bot
+// switched to long polling meanwhile
+func FormatWebhookStalledAlert(lastUpdate time.Time, pending int, lastError string, errorAt time.Time, fallback bool) string {
+	var sb strings.Builder
+
+	sb.WriteString("🚨 <b>WEBHOOK ISHLAMAYAPTI</b>\n\n")
+	fmt.Fprintf(&sb, "Oxirgi yangilanish: %s (%s oldin)\n",
+		lastUpdate.In(config.Timezone).Format("02.01.2006 15:04"), time.Since(lastUpdate).Round(time.Minute))
+	fmt.Fprintf(&sb, "Telegram navbatida: <b>%d</b> ta yangilanish\n", pending)
+	if !errorAt.IsZero() {
+		fmt.Fprintf(&sb, "Oxirgi xato (%s): <code>%s</code>\n",
+			errorAt.In(config.Timezone).Format("15:04"), html.EscapeString(lastError))
+	}
+
+	if fallback {
+		sb.WriteString("\n🔄 Bot vaqtincha long polling rejimiga o'tdi. Webhook manzili yana javob berganda webhook qayta ulanadi.")
+	} else {
+		sb.WriteString("\nℹ️ Sertifikat (TLS), domen va proksi sozlamalarini tekshiring. Bot hozir foydalanuvchilarga javob bermayapti.")
+	}
+
+	return sb.String()
+}
+
+// FormatWebhookRecoveredAlert formats the ops notice sent when the webhook was re-registered
+// after running on long polling for the given time
+func FormatWebhookRecoveredAlert(polledFor time.Duration) string {
+	return fmt.Sprintf("✅ <b>WEBHOOK TIKLANDI</b>\n\nWebhook manzili yana javob bermoqda, bot webhook rejimiga qaytdi (long polling: %s).",
+		polledFor.Round(time.Minute))
+}
+
+// FormatBackupResult formats the outcome of /backup
+func FormatBackupResult(run *models.BackupRun) string {
+	var sb strings.Builder
+
+	if run.Error != "" {
+		sb.WriteString("❌ <b>ZAXIRA NUSXA OLINMADI</b>\n\n")
+		fmt.Fprintf(&sb, "⏱ Davomiyligi: %s\n", run.Duration.Round(time.Second))
+		fmt.Fprintf(&sb, "Xato: <code>%s</code>", html.EscapeString(run.Error))
+		return sb.String()
+	}
+
+	sb.WriteString("✅ <b>ZAXIRA NUSXA OLINDI</b>\n\n")
+	if run.Size > 0 {
+		fmt.Fprintf(&sb, "📦 Hajmi: <b>%s</b>\n", formatFileSize(run.Size))
+	}
+	fmt.Fprintf(&sb, "⏱ Davomiyligi: %s\n", run.Duration.Round(time.Second))
+	if run.Location != "" {
+		fmt.Fprintf(&sb, "📁 <code>%s</code>", html.EscapeString(run.Location))
+	}
+	return sb.String()
+}
+
+// FormatBackupStatus formats /backup_status: when the newest backup was taken, with a warning
+// when it is older than BACKUP_MAX_AGE, and the details behind it
+func FormatBackupStatus(status *models.BackupStatus, now time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("💾 <b>ZAXIRA NUSXALAR HOLATI</b>\n\n")
+	if at := status.LastSuccessAt(); at.IsZero() {
+		sb.WriteString("🚨 Hali birorta ham muvaffaqiyatli zaxira nusxa topilmadi.\n")
+	} else {
+		fmt.Fprintf(&sb, "Oxirgi muvaffaqiyatli nusxa: <b>%s</b> (%s oldin)\n",
+			at.In(config.Timezone).Format("02.01.2006 15:04"), formatBackupAge(now.Sub(at)))
+		if status.Stale(now) {
+			fmt.Fprintf(&sb, "🚨 Oxirgi nusxa %s dan eski — zaxira nusxa olish to'xtab qolgan bo'lishi mumkin!\n",
+				formatBackupAge(status.MaxAge))
+		}
+	}
+
+	sb.WriteString("\n<b>/backup orqali:</b>\n")
+	if run := status.LastSuccess; run != nil {
+		fmt.Fprintf(&sb, "• Oxirgi: %s", run.StartedAt.In(config.Timezone).Format("02.01.2006 15:04"))
+		if run.Size > 0 {
+			fmt.Fprintf(&sb, ", %s", formatFileSize(run.Size))
+		}
+		fmt.Fprintf(&sb, ", %s\n", run.Duration.Round(time.Second))
+	} else {
+		sb.WriteString("• Hali olinmagan\n")
+	}
+	// An old failure that a later success made irrelevant is not worth showing
+	if run := status.LastFailure; run != nil && (status.LastSuccess == nil || run.StartedAt.After(status.LastSuccess.StartedAt)) {
+		fmt.Fprintf(&sb, "• ❌ Xato (%s): <code>%s</code>\n",
+			run.StartedAt.In(config.Timezone).Format("02.01.2006 15:04"), html.EscapeString(run.Error))
+	}
+
+	if f := status.NewestFile; f != nil {
+		sb.WriteString("\n<b>Eng yangi fayl:</b>\n")
+		fmt.Fprintf(&sb, "• <code>%s</code>\n", html.EscapeString(f.Path))
+		fmt.Fprintf(&sb, "• %s, %s\n", f.ModTime.In(config.Timezone).Format("02.01.2006 15:04"), formatFileSize(f.Size))
+	}
+	if status.DirError != "" {
+		fmt.Fprintf(&sb, "\n⚠️ Zaxira papkasini o'qib bo'lmadi: <code>%s</code>\n", html.EscapeString(status.DirError))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// formatFileSize shows a byte count in KB, MB or GB
+func formatFileSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// formatBackupAge shows how old a backup is in minutes, hours or days
+func formatBackupAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%d daqiqa", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d soat", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d kun", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,159 @@
+// Package objectstore uploads files to S3-compatible storage (AWS S3, MinIO, Cloudflare R2, ...).
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3 puts objects into one bucket using path-style URLs (endpoint/bucket/key), which every
+// S3-compatible service accepts. Requests are signed with AWS Signature Version 4.
+type S3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+}
+
+// NewS3 creates a client for bucket. publicURL, when set, is the base of the URLs returned by
+// ObjectURL (e.g. a CDN in front of the bucket); otherwise the path-style URL is used.
+func NewS3(endpoint, region, bucket, accessKey, secretKey, publicURL string) (*S3, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	return &S3{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		client:    &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// Put uploads body under key, replacing any object already there
+func (s *S3) Put(ctx context.Context, key, contentType string, body []byte) error {
+	target := *s.endpoint
+	target.Path = s.objectPath(key)
+	target.RawPath = uriEncode(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build S3 request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: %s: %s", key, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// ObjectURL returns the URL recorded for key
+func (s *S3) ObjectURL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + uriEncode(key)
+	}
+	u := *s.endpoint
+	u.Path = s.objectPath(key)
+	u.RawPath = uriEncode(u.Path)
+	return u.String()
+}
+
+func (s *S3) objectPath(key string) string {
+	return strings.TrimRight(s.endpoint.Path, "/") + "/" + s.bucket + "/" + key
+}
+
+// sign adds the SigV4 headers for a request without query parameters
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path),
+		"", // No query string
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// uriEncode percent-encodes everything except unreserved characters and slashes, as SigV4 requires for paths
+func uriEncode(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/':
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
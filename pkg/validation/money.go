@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/helper"
+)
+
+// maxMoneyDigits keeps parsed amounts far from int overflow; no job pays a trillion so'm
+const maxMoneyDigits = 12
+
+var (
+	// amountPattern finds a number in free text, with separators between digit groups: 20000, 20 000, 20,000, 20.000
+	amountPattern = regexp.MustCompile(`\d+(?:[ ,.'\x{00A0}]\d+)*`)
+	// plainPattern is an amount written without separators
+	plainPattern = regexp.MustCompile(`^\d+$`)
+	// groupedPattern is a correctly grouped amount: 1-3 digits, then groups of exactly 3
+	groupedPattern = regexp.MustCompile(`^\d{1,3}(?:[ ,.'\x{00A0}]\d{3})+$`)
+	// decimalPattern is a short decimal such as 1.5 or 2,5 (e.g. "1.5 mln"), left as written
+	decimalPattern = regexp.MustCompile(`^\d+[.,]\d{1,2}$`)
+	// currencySuffix is the currency written after an amount
+	currencySuffix = regexp.MustCompile(`(?i)\s*(so'm|so‘m|som|sum|сўм|сум)\.?$`)
+)
+
+// ParseMoney parses an amount in so'm, accepting thousand separators and a trailing currency
+// (so'm and its spellings, or the configured CURRENCY_LABEL).
+// Accepts formats: 9990, 9 990, 9,990, 9.990, 9 990 so'm
+func ParseMoney(input string) (int, *ValidationError) {
+	input = strings.TrimSpace(currencySuffix.ReplaceAllString(strings.TrimSpace(input), ""))
+	if n := len(input) - len(config.Currency); n >= 0 && strings.EqualFold(input[n:], config.Currency) {
+		input = strings.TrimSpace(input[:n])
+	}
+
+	if input == "" {
+		return 0, NewValidationError("money", "❌ Summani kiriting. Masalan: 9990 yoki 9 990")
+	}
+
+	if !plainPattern.MatchString(input) && !groupedPattern.MatchString(input) {
+		return 0, NewValidationError("money", "❌ Summa noto'g'ri yozilgan. Faqat raqam kiriting, minglar orasida bo'sh joy bo'lishi mumkin.\n\nMasalan: 9990, 9 990 yoki 150 000")
+	}
+
+	digits := stripSeparators(input)
+	if len(digits) > maxMoneyDigits {
+		return 0, NewValidationError("money", "❌ Summa juda katta")
+	}
+
+	amount, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, NewValidationError("money", "❌ Summa noto'g'ri yozilgan. Masalan: 9990 yoki 9 990")
+	}
+	return amount, nil
+}
+
+// ValidateSalary checks the free-text salary and normalizes the amounts in it to "20 000" form.
+// Text without amounts ("Kelishiladi") is fine; an amount must be grouped by thousands correctly.
+func ValidateSalary(salary string) (string, *ValidationError) {
+	salary = strings.TrimSpace(salary)
+
+	if salary == "" {
+		return "", NewValidationError("salary", "❌ Ish haqqini kiriting. Masalan: Soatiga 20 000 so'm")
+	}
+
+	var bad string
+	normalized := amountPattern.ReplaceAllStringFunc(salary, func(amount string) string {
+		if decimalPattern.MatchString(amount) {
+			return amount
+		}
+		value, err := ParseMoney(amount)
+		if err != nil {
+			if bad == "" {
+				bad = amount
+			}
+			return amount
+		}
+		return helper.FormatMoney(value)
+	})
+	if bad != "" {
+		return "", NewValidationError("salary", "❌ Summa noto'g'ri yozilgan: «"+bad+"».\n\nMinglarni 3 xonadan ajrating. Masalan: 20 000 yoki 150000")
+	}
+
+	return normalized, nil
+}
+
+// stripSeparators drops the thousand separators from a grouped amount
+func stripSeparators(amount string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, amount)
+}
@@ -1,9 +1,12 @@
 package validation
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"telegram-bot-starter/bot/models"
 )
 
 // ValidationError represents a validation error with a user-friendly message
@@ -68,6 +71,27 @@ func ValidateFullName(name string) *ValidationError {
 	return nil
 }
 
+// ValidateCity validates the city (or district) input
+// Requirements: 2-50 characters, letters, spaces and dashes only
+func ValidateCity(city string) *ValidationError {
+	city = strings.TrimSpace(city)
+
+	if len([]rune(city)) < 2 {
+		return NewValidationError("city", "❌ Shahar nomi juda qisqa")
+	}
+
+	if len([]rune(city)) > 50 {
+		return NewValidationError("city", "❌ Shahar nomi juda uzun")
+	}
+
+	validCityRegex := regexp.MustCompile(`^[\p{L}\s\-'.ʻʼ‘’]+$`)
+	if !validCityRegex.MatchString(city) {
+		return NewValidationError("city", "❌ Shahar nomida faqat harflar bo'lishi kerak")
+	}
+
+	return nil
+}
+
 // ValidateAge validates the age input
 // Requirements: between 16 and 65
 func ValidateAge(ageStr string) (int, *ValidationError) {
@@ -362,3 +386,113 @@ func NormalizePhone(phone string) string {
 	// Default: add + if not present
 	return "+" + phoneDigits
 }
+
+var requirementClauseRegex = regexp.MustCompile(`^(yosh|bo'y|boy|vazn)\s*:?\s*(\d+)\s*(?:\+|[-–]\s*(\d+))?\s*(?:yosh|sm|cm|kg)?$`)
+
+// JobRequirements are the worker requirements of a job as typed by an admin (zero values = no requirement)
+type JobRequirements struct {
+	MinAge    int
+	MaxAge    int
+	MinHeight int
+	MinWeight int
+	Gender    models.Gender
+}
+
+// ParseJobRequirements parses a job's worker requirements typed by an admin, one clause per comma or line.
+// Expected format: "erkak, yosh 18-40, bo'y 170, vazn 60"; "yosh 18" means 18 and older, "yosh 0-40" up to 40,
+// "erkak"/"ayol" hires only men/women. "-" clears every requirement.
+func ParseJobRequirements(input string) (JobRequirements, *ValidationError) {
+	var req JobRequirements
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "-" {
+		return req, nil
+	}
+	input = strings.NewReplacer("‘", "'", "’", "'", "ʻ", "'", "ʼ", "'", "`", "'").Replace(input)
+
+	invalid := NewValidationError("requirements", "❌ Talablarni tushunmadim\nMasalan: erkak, yosh 18-40, bo'y 170, vazn 60")
+	clauses := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '\n' || r == ';' })
+	if len(clauses) == 0 {
+		return JobRequirements{}, invalid
+	}
+
+	for _, clause := range clauses {
+		clause = strings.TrimSpace(clause)
+		if gender, err := ParseGender(clause); err == nil {
+			req.Gender = gender
+			continue
+		}
+		m := requirementClauseRegex.FindStringSubmatch(clause)
+		if m == nil {
+			return JobRequirements{}, invalid
+		}
+		value := atoi(m[2])
+		switch m[1] {
+		case "yosh":
+			req.MinAge, req.MaxAge = value, 0
+			if m[3] != "" {
+				req.MaxAge = atoi(m[3])
+			}
+			if (req.MinAge != 0 && (req.MinAge < 16 || req.MinAge > 65)) || (req.MaxAge != 0 && (req.MaxAge < 16 || req.MaxAge > 65)) {
+				return JobRequirements{}, NewValidationError("requirements", "❌ Yosh 16 dan 65 gacha bo'lishi kerak")
+			}
+			if req.MaxAge != 0 && req.MinAge > req.MaxAge {
+				return JobRequirements{}, NewValidationError("requirements", "❌ Eng kichik yosh eng kattasidan oshmasligi kerak")
+			}
+		case "bo'y", "boy":
+			if m[3] != "" || value < 100 || value > 250 {
+				return JobRequirements{}, NewValidationError("requirements", "❌ Bo'y 100 sm dan 250 sm gacha bitta son bo'lishi kerak")
+			}
+			req.MinHeight = value
+		case "vazn":
+			if m[3] != "" || value < 30 || value > 200 {
+				return JobRequirements{}, NewValidationError("requirements", "❌ Vazn 30 kg dan 200 kg gacha bitta son bo'lishi kerak")
+			}
+			req.MinWeight = value
+		}
+	}
+
+	return req, nil
+}
+
+// MaxJobCaptionLength caps a channel caption variant so it stays a one-line hook above the post
+const MaxJobCaptionLength = 200
+
+// ParseJobCaption validates a caption variant typed by an admin; "-" clears it
+func ParseJobCaption(input string) (string, *ValidationError) {
+	input = strings.TrimSpace(input)
+	if input == "-" {
+		return "", nil
+	}
+	if input == "" {
+		return "", NewValidationError("caption", "❌ Matn bo'sh bo'lmasligi kerak")
+	}
+	if len([]rune(input)) > MaxJobCaptionLength {
+		return "", NewValidationError("caption", fmt.Sprintf("❌ Matn %d belgidan oshmasligi kerak", MaxJobCaptionLength))
+	}
+	return input, nil
+}
+
+// ParseGender parses a gender answer: the registration keyboard buttons or a typed "erkak"/"ayol"
+// (plural and "faqat ..." forms are accepted too)
+func ParseGender(input string) (models.Gender, *ValidationError) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	input = strings.TrimLeftFunc(input, func(r rune) bool { return !unicode.IsLetter(r) })
+	input = strings.TrimSpace(strings.TrimPrefix(input, "faqat "))
+
+	switch input {
+	case "erkak", "erkaklar":
+		return models.GenderMale, nil
+	case "ayol", "ayollar":
+		return models.GenderFemale, nil
+	}
+	return models.GenderUnknown, NewValidationError("gender", "❌ Iltimos, tugmalardan birini tanlang: Erkak yoki Ayol")
+}
+
+// atoi converts a string of digits already matched by a regex
+func atoi(s string) int {
+	n := 0
+	for _, r := range s {
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
@@ -0,0 +1,425 @@
+// Command genmocks writes moq-style mocks for every interface declared in the
+// package in the current directory. It only needs the standard library, so it
+// runs from `go generate` without extra tools:
+//
+//	//go:generate go run ../scripts/genmocks -out mocks
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type iface struct {
+	name    string
+	methods []method
+}
+
+// source is one parsed file; imports maps the local name to the import path
+type source struct {
+	imports map[string]string
+}
+
+type generator struct {
+	modPath  string
+	pkgName  string
+	pkgPath  string
+	types    map[string]bool
+	ifaces   map[string]*ast.InterfaceType
+	files    map[*ast.InterfaceType]*source
+	used     map[string]string // import path -> local name
+	resolved map[string]*iface
+}
+
+func main() {
+	out := flag.String("out", "mocks", "output directory, relative to the package")
+	flag.Parse()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := load(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(g.ifaces))
+	for name := range g.ifaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := os.MkdirAll(filepath.Join(dir, *out), 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range names {
+		g.used = map[string]string{"sync": "sync", g.pkgPath: g.pkgName}
+		g.resolved = map[string]*iface{}
+		src, err := g.render(g.resolve(name))
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		file := filepath.Join(dir, *out, snake(name)+"_mock.go")
+		if err := os.WriteFile(file, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// load parses the non-test files of dir and collects its exported interfaces
+func load(dir string) (*generator, error) {
+	modPath, modDir, err := findModule(dir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(modDir, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	g := &generator{
+		modPath:  modPath,
+		pkgPath:  filepath.ToSlash(filepath.Join(modPath, rel)),
+		types:    map[string]bool{},
+		ifaces:   map[string]*ast.InterfaceType{},
+		files:    map[*ast.InterfaceType]*source{},
+		resolved: map[string]*iface{},
+	}
+	for name, pkg := range pkgs {
+		g.pkgName = name
+		for _, f := range pkg.Files {
+			src := &source{imports: map[string]string{}}
+			for _, imp := range f.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				local := filepath.Base(path)
+				if strings.HasPrefix(local, "telebot.") {
+					local = "telebot"
+				}
+				if imp.Name != nil {
+					local = imp.Name.Name
+				}
+				src.imports[local] = path
+			}
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					g.types[ts.Name.Name] = true
+					if it, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.IsExported() {
+						g.ifaces[ts.Name.Name] = it
+						g.files[it] = src
+					}
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+func findModule(dir string) (string, string, error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return strings.TrimSpace(rest), d, nil
+				}
+			}
+			return "", "", fmt.Errorf("no module line in %s/go.mod", d)
+		}
+		if filepath.Dir(d) == d {
+			return "", "", fmt.Errorf("go.mod not found above %s", dir)
+		}
+	}
+}
+
+// resolve flattens an interface, including the methods of embedded local interfaces
+func (g *generator) resolve(name string) *iface {
+	if it, ok := g.resolved[name]; ok {
+		return it
+	}
+	decl := g.ifaces[name]
+	src := g.files[decl]
+	out := &iface{name: name}
+	for _, field := range decl.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			if id, ok := field.Type.(*ast.Ident); ok && g.ifaces[id.Name] != nil {
+				out.methods = append(out.methods, g.resolve(id.Name).methods...)
+				continue
+			}
+			log.Fatalf("%s: unsupported embedded type", name)
+		}
+		m := method{name: field.Names[0].Name}
+		if ft.Params != nil {
+			i := 0
+			for _, p := range ft.Params.List {
+				typ := p.Type
+				variadic := false
+				if el, ok := typ.(*ast.Ellipsis); ok {
+					typ, variadic = el.Elt, true
+				}
+				names := p.Names
+				if len(names) == 0 {
+					names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i+1))}
+				}
+				for _, n := range names {
+					m.params = append(m.params, param{name: n.Name, typ: g.expr(typ, src), variadic: variadic})
+					i++
+				}
+			}
+		}
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				n := len(r.Names)
+				if n == 0 {
+					n = 1
+				}
+				for range n {
+					m.results = append(m.results, g.expr(r.Type, src))
+				}
+			}
+		}
+		out.methods = append(out.methods, m)
+	}
+	sort.Slice(out.methods, func(i, j int) bool { return out.methods[i].name < out.methods[j].name })
+	g.resolved[name] = out
+	return out
+}
+
+// expr prints a type expression as seen from the mocks package
+func (g *generator) expr(e ast.Expr, src *source) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] {
+			return g.pkgName + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		if path, ok := src.imports[pkg]; ok {
+			g.used[path] = pkg
+		}
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.expr(t.X, src)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + g.expr(t.Elt, src)
+		}
+		return "[" + t.Len.(*ast.BasicLit).Value + "]" + g.expr(t.Elt, src)
+	case *ast.MapType:
+		return "map[" + g.expr(t.Key, src) + "]" + g.expr(t.Value, src)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.expr(t.Value, src)
+		case ast.RECV:
+			return "<-chan " + g.expr(t.Value, src)
+		}
+		return "chan " + g.expr(t.Value, src)
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	case *ast.StructType:
+		if len(t.Fields.List) == 0 {
+			return "struct{}"
+		}
+	}
+	log.Fatalf("unsupported type expression %T", e)
+	return ""
+}
+
+func (g *generator) render(it *iface) ([]byte, error) {
+	var b bytes.Buffer
+	mock := it.name + "Mock"
+	qual := g.pkgName + "." + it.name
+
+	fmt.Fprintf(&b, "// Ensure, that %s does implement %s.\n", mock, qual)
+	fmt.Fprintf(&b, "// If this is not the case, regenerate this file with genmocks.\n")
+	fmt.Fprintf(&b, "var _ %s = &%s{}\n\n", qual, mock)
+
+	fmt.Fprintf(&b, "// %s is a mock implementation of %s.\n", mock, qual)
+	fmt.Fprintf(&b, "type %s struct {\n", mock)
+	for _, m := range it.methods {
+		fmt.Fprintf(&b, "\t// %sFunc mocks the %s method.\n", m.name, m.name)
+		fmt.Fprintf(&b, "\t%sFunc func(%s) %s\n\n", m.name, signature(m.params), results(m.results))
+	}
+	fmt.Fprintf(&b, "\t// calls tracks calls to the methods.\n\tcalls struct {\n")
+	for _, m := range it.methods {
+		fmt.Fprintf(&b, "\t\t// %s holds details about calls to the %s method.\n", m.name, m.name)
+		fmt.Fprintf(&b, "\t\t%s []%s\n", m.name, callStruct(m.params))
+	}
+	fmt.Fprintf(&b, "\t}\n")
+	for _, m := range it.methods {
+		fmt.Fprintf(&b, "\tlock%s sync.RWMutex\n", m.name)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	for _, m := range it.methods {
+		fmt.Fprintf(&b, "// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(&b, "func (mock *%s) %s(%s) %s {\n", mock, m.name, signature(m.params), results(m.results))
+		fmt.Fprintf(&b, "\tif mock.%sFunc == nil {\n", m.name)
+		fmt.Fprintf(&b, "\t\tpanic(\"%s.%sFunc: method is nil but %s.%s was just called\")\n\t}\n", mock, m.name, it.name, m.name)
+		fmt.Fprintf(&b, "\tcallInfo := %s{\n", callStruct(m.params))
+		for _, p := range m.params {
+			fmt.Fprintf(&b, "\t\t%s: %s,\n", export(p.name), p.name)
+		}
+		fmt.Fprintf(&b, "\t}\n")
+		fmt.Fprintf(&b, "\tmock.lock%s.Lock()\n", m.name)
+		fmt.Fprintf(&b, "\tmock.calls.%s = append(mock.calls.%s, callInfo)\n", m.name, m.name)
+		fmt.Fprintf(&b, "\tmock.lock%s.Unlock()\n", m.name)
+		args := make([]string, len(m.params))
+		for i, p := range m.params {
+			args[i] = p.name
+			if p.variadic {
+				args[i] += "..."
+			}
+		}
+		call := fmt.Sprintf("mock.%sFunc(%s)", m.name, strings.Join(args, ", "))
+		if len(m.results) == 0 {
+			fmt.Fprintf(&b, "\t%s\n}\n\n", call)
+		} else {
+			fmt.Fprintf(&b, "\treturn %s\n}\n\n", call)
+		}
+
+		fmt.Fprintf(&b, "// %sCalls gets all the calls that were made to %s.\n", m.name, m.name)
+		fmt.Fprintf(&b, "// Check the length with:\n//\n//\tlen(mocked%s.%sCalls())\n", it.name, m.name)
+		fmt.Fprintf(&b, "func (mock *%s) %sCalls() []%s {\n", mock, m.name, callStruct(m.params))
+		fmt.Fprintf(&b, "\tvar calls []%s\n", callStruct(m.params))
+		fmt.Fprintf(&b, "\tmock.lock%s.RLock()\n\tcalls = mock.calls.%s\n\tmock.lock%s.RUnlock()\n\treturn calls\n}\n\n", m.name, m.name, m.name)
+	}
+
+	code := b.Bytes()
+
+	var head bytes.Buffer
+	fmt.Fprintf(&head, "// Code generated by genmocks; DO NOT EDIT.\n\npackage mocks\n\nimport (\n")
+	paths := make([]string, 0, len(g.used))
+	for path := range g.used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// standard library first, then everything else, as goimports groups them
+	sort.SliceStable(paths, func(i, j int) bool { return g.isStd(paths[i]) && !g.isStd(paths[j]) })
+	for i, path := range paths {
+		if i > 0 && g.isStd(paths[i-1]) && !g.isStd(path) {
+			head.WriteString("\n")
+		}
+		if filepath.Base(path) == g.used[path] {
+			fmt.Fprintf(&head, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&head, "\t%s %q\n", g.used[path], path)
+		}
+	}
+	fmt.Fprintf(&head, ")\n\n")
+	head.Write(code)
+	return format.Source(head.Bytes())
+}
+
+func signature(params []param) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		typ := p.typ
+		if p.variadic {
+			typ = "..." + typ
+		}
+		parts[i] = p.name + " " + typ
+	}
+	return strings.Join(parts, ", ")
+}
+
+func results(rs []string) string {
+	switch len(rs) {
+	case 0:
+		return ""
+	case 1:
+		return rs[0]
+	}
+	return "(" + strings.Join(rs, ", ") + ")"
+}
+
+func callStruct(params []param) string {
+	if len(params) == 0 {
+		return "struct {\n}"
+	}
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, p := range params {
+		typ := p.typ
+		if p.variadic {
+			typ = "[]" + typ
+		}
+		fmt.Fprintf(&b, "\t// %s is the %s argument value.\n\t%s %s\n", export(p.name), p.name, export(p.name), typ)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func export(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// snake turns an interface name into a file name: BotAPI -> bot_api, IDGen -> id_gen
+func snake(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) {
+			prevLower := unicode.IsLower(r[i-1])
+			nextLower := i+1 < len(r) && unicode.IsLower(r[i+1])
+			if prevLower || (unicode.IsUpper(r[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// isStd reports whether path belongs to the standard library
+func (g *generator) isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".") && first != strings.Split(g.modPath, "/")[0]
+}
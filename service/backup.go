@@ -0,0 +1,377 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const (
+	// backupManualDir is the BACKUP_DIR subdirectory /backup dumps go to, next to the cron script's hourly/daily/weekly
+	backupManualDir = "manual"
+	// backupKeepManual is how many /backup dumps are kept; older ones are deleted after a successful run
+	backupKeepManual = 10
+	// backupFilePrefix and backupFileSuffix match the dumps of scripts/backup_postgres.sh as well
+	backupFilePrefix = "ishchi_backup_"
+	backupFileSuffix = ".sql.gz"
+	// backupWebhookMaxResponse caps how much of the webhook's answer is read
+	backupWebhookMaxResponse = 64 << 10
+	// backupStderrMax caps how much pg_dump error output ends up in the error
+	backupStderrMax = 2 << 10
+	// backupDumpHeader and backupDumpTrailer open and close every plain pg_dump; a dump cut short
+	// by a crash or a full disk lacks the trailer
+	backupDumpHeader  = "-- PostgreSQL database dump"
+	backupDumpTrailer = "-- PostgreSQL database dump complete"
+	// backupVerifyWindow is how much of the dump's start and end is searched for them
+	backupVerifyWindow = 4 << 10
+)
+
+// Backup errors
+var (
+	ErrBackupDisabled = errors.New("backups are not configured")
+	ErrBackupRunning  = errors.New("a backup is already running")
+)
+
+// BackupService takes database backups for the super admins' /backup and reports on them for
+// /backup_status, so a backup job that stopped working doesn't go unnoticed (BACKUP_* config)
+type BackupService interface {
+	// Enabled reports whether BACKUP_DIR or BACKUP_WEBHOOK_URL is set
+	Enabled() bool
+	// Run takes a backup (pg_dump into BACKUP_DIR, or a call to BACKUP_WEBHOOK_URL) and records the
+	// outcome. The returned run is filled in on failure as well; ErrBackupRunning if one is in progress.
+	Run(ctx context.Context, adminID int64) (*models.BackupRun, error)
+	// Status returns the last recorded runs and the newest dump in BACKUP_DIR
+	Status(ctx context.Context) (*models.BackupStatus, error)
+}
+
+type backupService struct {
+	cfg     config.BackupConfig
+	db      config.DatabaseConfig
+	log     logger.LoggerI
+	storage storage.StorageI
+	clock   Clock
+	client  *http.Client
+
+	// running allows one backup at a time
+	running sync.Mutex
+}
+
+// NewBackupService creates the backup service
+func NewBackupService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, clock Clock) BackupService {
+	return &backupService{
+		cfg:     cfg.Backup,
+		db:      cfg.Database,
+		log:     log,
+		storage: storage,
+		clock:   clock,
+		client:  &http.Client{},
+	}
+}
+
+// Enabled reports whether backups are configured
+func (s *backupService) Enabled() bool {
+	return s.cfg.Enabled()
+}
+
+// Run takes a backup and stores its outcome in bot_settings
+func (s *backupService) Run(ctx context.Context, adminID int64) (*models.BackupRun, error) {
+	if !s.Enabled() {
+		return nil, ErrBackupDisabled
+	}
+	if !s.running.TryLock() {
+		return nil, ErrBackupRunning
+	}
+	defer s.running.Unlock()
+
+	log := logger.FromContext(ctx, s.log)
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	run := &models.BackupRun{AdminID: adminID, StartedAt: s.clock.Now()}
+	var err error
+	if s.cfg.WebhookURL != "" {
+		err = s.callWebhook(ctx, run)
+	} else {
+		err = s.dump(ctx, run)
+	}
+	run.Duration = s.clock.Now().Sub(run.StartedAt)
+
+	key := models.SettingBackupLastSuccess
+	if err != nil {
+		run.Error = err.Error()
+		key = models.SettingBackupLastFailure
+		log.Error("Backup failed", logger.Error(err), logger.Any("admin_id", adminID), logger.Any("duration", run.Duration))
+	} else {
+		log.Info("Backup completed",
+			logger.Any("admin_id", adminID),
+			logger.Any("duration", run.Duration),
+			logger.Any("size", run.Size),
+			logger.String("location", run.Location),
+		)
+	}
+
+	// Recorded even when the request's context is gone, so /backup_status sees every outcome
+	if value, merr := json.Marshal(run); merr == nil {
+		if serr := s.storage.Settings().Set(context.WithoutCancel(ctx), key, string(value), adminID); serr != nil {
+			log.Error("Failed to record backup outcome", logger.Error(serr))
+		}
+	}
+	return run, err
+}
+
+// dump runs pg_dump into a gzipped file in BACKUP_DIR/manual
+func (s *backupService) dump(ctx context.Context, run *models.BackupRun) error {
+	dir := filepath.Join(s.cfg.Dir, backupManualDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := backupFilePrefix + "manual_" + run.StartedAt.In(config.Timezone).Format("20060102_150405") + backupFileSuffix
+	path := filepath.Join(dir, name)
+	partial := path + ".partial"
+
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	// Removing the partial file is a no-op once it was renamed
+	defer os.Remove(partial)
+
+	gz := gzip.NewWriter(file)
+	var stderr bytes.Buffer
+
+	// The password goes through the environment so it doesn't show up in the process list
+	cmd := exec.CommandContext(ctx, s.cfg.PgDump,
+		"-h", s.db.Host,
+		"-p", strconv.Itoa(s.db.Port),
+		"-U", s.db.User,
+		"-d", s.db.DBName,
+	)
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+s.db.Password)
+	cmd.Stdout = gz
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	gzErr := gz.Close()
+	closeErr := file.Close()
+	if runErr != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			if len(msg) > backupStderrMax {
+				msg = msg[:backupStderrMax]
+			}
+			return fmt.Errorf("pg_dump failed: %w: %s", runErr, msg)
+		}
+		return fmt.Errorf("pg_dump failed: %w", runErr)
+	}
+	if err := errors.Join(gzErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := verifyDump(partial); err != nil {
+		return fmt.Errorf("backup check failed: %w", err)
+	}
+
+	if err := os.Rename(partial, path); err != nil {
+		return fmt.Errorf("failed to finish backup file: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat backup file: %w", err)
+	}
+	run.Location = path
+	run.Size = info.Size()
+
+	s.pruneManual(ctx, dir)
+	return nil
+}
+
+// verifyDump reads the written dump back, like verify_backup in scripts/backup_postgres.sh: the gzip
+// stream must decompress with a valid checksum (gzip -t) and hold a pg_dump from header to trailer
+func verifyDump(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("gzip test failed: %w", err)
+	}
+	head := make([]byte, backupVerifyWindow)
+	n, err := io.ReadFull(gz, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("gzip test failed: %w", err)
+	}
+	if !bytes.Contains(head[:n], []byte(backupDumpHeader)) {
+		return errors.New("no PostgreSQL dump header")
+	}
+	// The checksum is only compared once the whole stream is read
+	tail := &tailWriter{buf: head[:n], max: backupVerifyWindow}
+	if _, err := io.Copy(tail, gz); err != nil {
+		return fmt.Errorf("gzip test failed: %w", err)
+	}
+	if !bytes.Contains(tail.buf, []byte(backupDumpTrailer)) {
+		return errors.New("dump is incomplete")
+	}
+	return nil
+}
+
+// tailWriter keeps the last max bytes written to it
+type tailWriter struct {
+	buf []byte
+	max int
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.max {
+		w.buf = append(w.buf[:0], w.buf[len(w.buf)-w.max:]...)
+	}
+	return len(p), nil
+}
+
+// pruneManual deletes all but the newest backupKeepManual dumps taken with /backup
+func (s *backupService) pruneManual(ctx context.Context, dir string) {
+	files, err := findBackups(dir)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Failed to list backups for cleanup", logger.Error(err))
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime.After(files[j].ModTime) })
+	for _, f := range files[min(len(files), backupKeepManual):] {
+		if err := os.Remove(f.Path); err != nil {
+			logger.FromContext(ctx, s.log).Error("Failed to delete old backup", logger.Error(err), logger.String("path", f.Path))
+		}
+	}
+}
+
+// backupWebhookRequest is POSTed to BACKUP_WEBHOOK_URL
+type backupWebhookRequest struct {
+	Source      string    `json:"source"`
+	RequestedBy int64     `json:"requested_by"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// backupWebhookResponse is the optional JSON answer of the backup system
+type backupWebhookResponse struct {
+	Size     int64  `json:"size"`
+	Location string `json:"location"`
+}
+
+// callWebhook asks the external backup system for a backup and waits for its answer;
+// any 2xx status counts as success
+func (s *backupService) callWebhook(ctx context.Context, run *models.BackupRun) error {
+	body, err := json.Marshal(backupWebhookRequest{Source: "ishchi-bot", RequestedBy: run.AdminID, RequestedAt: run.StartedAt})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.WebhookToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.WebhookToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("backup webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	answer, _ := io.ReadAll(io.LimitReader(resp.Body, backupWebhookMaxResponse))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(answer))
+		if len(msg) > backupStderrMax {
+			msg = msg[:backupStderrMax]
+		}
+		return fmt.Errorf("backup webhook answered %s: %s", resp.Status, msg)
+	}
+
+	var parsed backupWebhookResponse
+	if json.Unmarshal(answer, &parsed) == nil {
+		run.Size = parsed.Size
+		run.Location = parsed.Location
+	}
+	return nil
+}
+
+// Status reads the recorded runs and looks for the newest dump in BACKUP_DIR
+func (s *backupService) Status(ctx context.Context) (*models.BackupStatus, error) {
+	stored, err := s.storage.Settings().GetAll(storage.WithPrimary(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup history: %w", err)
+	}
+
+	status := &models.BackupStatus{MaxAge: s.cfg.MaxAge}
+	status.LastSuccess = s.decodeRun(ctx, stored[models.SettingBackupLastSuccess])
+	status.LastFailure = s.decodeRun(ctx, stored[models.SettingBackupLastFailure])
+
+	if s.cfg.Dir != "" {
+		files, err := findBackups(s.cfg.Dir)
+		if err != nil {
+			status.DirError = err.Error()
+		}
+		for _, f := range files {
+			if status.NewestFile == nil || f.ModTime.After(status.NewestFile.ModTime) {
+				status.NewestFile = f
+			}
+		}
+	}
+	return status, nil
+}
+
+// decodeRun parses a stored BackupRun; a missing or broken value is nil
+func (s *backupService) decodeRun(ctx context.Context, value string) *models.BackupRun {
+	if value == "" {
+		return nil
+	}
+	var run models.BackupRun
+	if err := json.Unmarshal([]byte(value), &run); err != nil {
+		logger.FromContext(ctx, s.log).Error("Stored backup run is invalid", logger.Error(err))
+		return nil
+	}
+	return &run
+}
+
+// findBackups lists the finished dumps under dir, including its subdirectories
+func findBackups(dir string) ([]*models.BackupFile, error) {
+	var files []*models.BackupFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() || !strings.HasPrefix(name, backupFilePrefix) || !strings.HasSuffix(name, backupFileSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, &models.BackupFile{Path: path, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
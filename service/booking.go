@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"telegram-bot-starter/bot/models"
@@ -11,28 +13,84 @@ import (
 	"telegram-bot-starter/storage"
 )
 
+// Errors returned by CreateManualBooking
+var (
+	ErrJobNotBookable = errors.New("job is not accepting bookings")
+	ErrNoFreeSlots    = errors.New("no free slots")
+	ErrAlreadyBooked  = errors.New("user already has a booking for this job")
+)
+
+// ErrPhoneAlreadyBooked is returned by ConfirmBooking and CreateManualBooking when BOOKING_ONE_PER_PHONE
+// is on and another account registered with the same phone already holds a booking on the job
+var ErrPhoneAlreadyBooked = errors.New("phone already has a booking for this job")
+
+// ActiveBookingLimitError is returned by ConfirmBooking when the user's bookings on other jobs block a new one:
+// either BOOKING_MAX_ACTIVE of them are still in progress, or one is still waiting for its receipt
+type ActiveBookingLimitError struct {
+	Limit           int
+	AwaitingReceipt bool                 // A reserved booking elsewhere still waits for its receipt
+	Blocking        []*models.JobBooking // The bookings in the way
+}
+
+func (e *ActiveBookingLimitError) Error() string {
+	jobIDs := make([]string, len(e.Blocking))
+	for i, b := range e.Blocking {
+		jobIDs[i] = fmt.Sprintf("#%d", b.JobID)
+	}
+	if e.AwaitingReceipt {
+		return fmt.Sprintf("another booking awaits its receipt (Job %s)", strings.Join(jobIDs, ", "))
+	}
+	return fmt.Sprintf("active booking limit of %d reached (Jobs %s)", e.Limit, strings.Join(jobIDs, ", "))
+}
+
+// RequirementsNotMetError is returned by ConfirmBooking when the user's registered profile misses
+// the job's age/height/weight requirements and no admin waived them for the user
+type RequirementsNotMetError struct {
+	Job     *models.Job
+	Profile *models.RegisteredUser
+	Unmet   []models.Requirement
+}
+
+func (e *RequirementsNotMetError) Error() string {
+	unmet := make([]string, len(e.Unmet))
+	for i, r := range e.Unmet {
+		unmet[i] = string(r)
+	}
+	return fmt.Sprintf("job #%d requirements not met: %s", e.Job.ID, strings.Join(unmet, ", "))
+}
+
+// ErrJobNotCancellable is returned by CancelJob for jobs that are already cancelled or completed
+var ErrJobNotCancellable = errors.New("job is already cancelled or completed")
+
 // BookingService handles booking-related business logic
 type BookingService interface {
 	ConfirmBooking(ctx context.Context, userID, jobID int64) (*models.JobBooking, error)
 	GetBookingWithStatus(ctx context.Context, userID int64, status models.BookingStatus) (*models.JobBooking, error)
 	CheckIdempotency(ctx context.Context, userID, jobID int64) (*models.JobBooking, error)
 	ExpireBooking(ctx context.Context, booking *models.JobBooking) error
+	IssueVoucher(ctx context.Context, bookingID int64) (*models.BookingVoucher, error)
+	CreateManualBooking(ctx context.Context, jobID, userID, adminID int64) (*models.JobBooking, *models.Job, error)
+	CancelJob(ctx context.Context, jobID, adminID int64) (*models.JobCancellation, error)
 }
 
 type bookingService struct {
 	cfg     config.Config
 	log     logger.LoggerI
 	storage storage.StorageI
-	manager ServiceManagerI
+	posts   JobPostUpdater
+	clock   Clock
+	ids     IDGen
 }
 
 // NewBookingService creates a new booking service
-func NewBookingService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, manager ServiceManagerI) BookingService {
+func NewBookingService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, posts JobPostUpdater, clock Clock, ids IDGen) BookingService {
 	return &bookingService{
 		cfg:     cfg,
 		log:     log,
 		storage: storage,
-		manager: manager,
+		posts:   posts,
+		clock:   clock,
+		ids:     ids,
 	}
 }
 
@@ -46,11 +104,12 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, userID, jobID int64
 	}
 
 	if block != nil {
+		now := s.clock.Now()
 		s.log.Info("Block check for user",
 			logger.Any("user_id", userID),
 			logger.Any("blocked_until", block.BlockedUntil),
 			logger.Any("total_violations", block.TotalViolations),
-			logger.Any("current_time", time.Now()),
+			logger.Any("current_time", now),
 		)
 
 		if block.BlockedUntil == nil {
@@ -59,10 +118,9 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, userID, jobID int64
 			return nil, fmt.Errorf("❌ Siz doimiy bloklangansiz.\n\nSabab: %s\n\nQo'shimcha ma'lumot uchun admin bilan bog'laning.", block.Reason)
 		}
 
-		now := time.Now()
 		if now.Before(*block.BlockedUntil) {
 			// Temporary block still active
-			remaining := time.Until(*block.BlockedUntil)
+			remaining := block.BlockedUntil.Sub(now)
 			hours := int(remaining.Hours())
 			minutes := int(remaining.Minutes()) % 60
 			s.log.Warn("User is temporarily blocked",
@@ -85,34 +143,34 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, userID, jobID int64
 	}
 
 	// Check idempotency
-	idempotencyKey := models.GenerateIdempotencyKey(userID, jobID)
+	idempotencyKey := s.ids.IdempotencyKey(userID, jobID)
 	existingBooking, _ := s.storage.Booking().GetByIdempotencyKey(ctx, nil, idempotencyKey)
 	if existingBooking != nil {
-		if existingBooking.Status == models.BookingStatusSlotReserved && !existingBooking.IsExpired() {
-			return existingBooking, fmt.Errorf("booking already exists with %d seconds remaining", int(existingBooking.TimeRemaining().Seconds()))
+		if existingBooking.Status == models.BookingStatusSlotReserved && !existingBooking.IsExpiredAt(s.clock.Now()) {
+			return existingBooking, fmt.Errorf("booking already exists with %d seconds remaining", int(existingBooking.TimeRemainingAt(s.clock.Now()).Seconds()))
 		}
 		if existingBooking.Status == models.BookingStatusPaymentSubmitted {
 			return existingBooking, fmt.Errorf("payment is being reviewed")
 		}
+		if existingBooking.Status == models.BookingStatusPaymentRejectedRetryable && !existingBooking.IsExpiredAt(s.clock.Now()) {
+			return existingBooking, fmt.Errorf("payment resubmission pending")
+		}
 		if existingBooking.Status == models.BookingStatusConfirmed {
 			return existingBooking, fmt.Errorf("booking already confirmed")
 		}
 	}
 
-	// Check if user has ANY other active booking (Reserved or PaymentSubmitted)
-	// User can only have one pending booking at a time
-	reservedBookings, err := s.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusSlotReserved)
-	if err == nil {
-		for _, b := range reservedBookings {
-			if !b.IsExpired() && b.JobID != jobID {
-				return nil, fmt.Errorf("you have another active booking (Job #%d)", b.JobID)
-			}
-		}
+	if err := s.checkActiveBookings(ctx, userID, jobID); err != nil {
+		return nil, err
 	}
 
-	submittedBookings, err := s.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusPaymentSubmitted)
-	if err == nil && len(submittedBookings) > 0 {
-		return nil, fmt.Errorf("you have a payment under review for another job (Job #%d)", submittedBookings[0].JobID)
+	if err := s.checkRequirements(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	phone, err := s.limitedPhone(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Start transaction
@@ -144,13 +202,31 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, userID, jobID int64
 		return nil, fmt.Errorf("all slots are full")
 	}
 
+	// Checked under the job lock so two accounts can't book the same job at once
+	if err := s.checkPhoneLimit(ctx, tx, jobID, userID, phone); err != nil {
+		return nil, err
+	}
+
+	// A finished booking is reused; one still holding its slot waits for the expiry worker to release it
+	prev, err := s.storage.Booking().GetByIdempotencyKey(ctx, tx, idempotencyKey)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check existing booking: %w", err)
+	}
+	var prevStatus models.BookingStatus
+	if prev != nil {
+		prevStatus = prev.Status
+		if !prevStatus.CanTransitionTo(models.BookingStatusSlotReserved) {
+			return nil, &BookingTransitionError{BookingID: prev.ID, From: prevStatus, To: models.BookingStatusSlotReserved}
+		}
+	}
+
 	// Atomically increment reserved_slots
 	if err := s.storage.Job().IncrementReservedSlots(ctx, tx, jobID); err != nil {
 		return nil, fmt.Errorf("failed to reserve slot: %w", err)
 	}
 
 	// Create booking
-	now := time.Now()
+	now := s.clock.Now()
 	expiresAt := now.Add(3 * time.Minute)
 
 	booking := &models.JobBooking{
@@ -166,6 +242,9 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, userID, jobID int64
 	if err := s.storage.Booking().Create(ctx, tx, booking); err != nil {
 		return nil, fmt.Errorf("failed to create booking: %w", err)
 	}
+	if err := transitionBooking(ctx, s.storage, tx, booking.ID, prevStatus, booking.Status, userID, ""); err != nil {
+		return nil, err
+	}
 
 	// Commit transaction
 	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
@@ -199,7 +278,7 @@ func (s *bookingService) GetBookingWithStatus(ctx context.Context, userID int64,
 
 // CheckIdempotency checks if user already has a booking for this job
 func (s *bookingService) CheckIdempotency(ctx context.Context, userID, jobID int64) (*models.JobBooking, error) {
-	idempotencyKey := models.GenerateIdempotencyKey(userID, jobID)
+	idempotencyKey := s.ids.IdempotencyKey(userID, jobID)
 	return s.storage.Booking().GetByIdempotencyKey(ctx, nil, idempotencyKey)
 }
 
@@ -213,10 +292,18 @@ func (s *bookingService) ExpireBooking(ctx context.Context, booking *models.JobB
 	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
 	defer s.storage.Transaction().Rollback(ctx, tx)
 
-	booking.Status = models.BookingStatusExpired
-	if err := s.storage.Booking().Update(ctx, tx, booking); err != nil {
+	current, err := s.storage.Booking().GetByIDForUpdate(ctx, tx, booking.ID)
+	if err != nil {
+		return fmt.Errorf("failed to lock booking: %w", err)
+	}
+	if err := transitionBooking(ctx, s.storage, tx, booking.ID, current.Status, models.BookingStatusExpired, 0, ""); err != nil {
+		return err
+	}
+
+	if err := s.storage.Booking().MarkAsExpired(ctx, tx, booking.ID); err != nil {
 		return fmt.Errorf("failed to update booking: %w", err)
 	}
+	booking.Status = models.BookingStatusExpired
 
 	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -224,3 +311,361 @@ func (s *bookingService) ExpireBooking(ctx context.Context, booking *models.JobB
 
 	return nil
 }
+
+// CreateManualBooking books a worker onto a job on an admin's behalf (e.g. after a phone call).
+// The booking is CONFIRMED straight away without payment; slot limits are enforced in one transaction.
+func (s *bookingService) CreateManualBooking(ctx context.Context, jobID, userID, adminID int64) (*models.JobBooking, *models.Job, error) {
+	phone, err := s.limitedPhone(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := s.storage.Transaction().Begin(ctx)
+	if err != nil {
+		s.log.Error("Failed to begin transaction", logger.Error(err))
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
+	defer s.storage.Transaction().Rollback(ctx, tx)
+
+	job, err := s.storage.Job().GetByIDForUpdate(ctx, tx, jobID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock job: %w", err)
+	}
+	if job.Status != models.JobStatusActive {
+		return nil, nil, ErrJobNotBookable
+	}
+
+	idempotencyKey := s.ids.IdempotencyKey(userID, jobID)
+	existing, err := s.storage.Booking().GetByIdempotencyKey(ctx, tx, idempotencyKey)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, fmt.Errorf("failed to check existing booking: %w", err)
+	}
+	if existing != nil && (existing.AwaitsReceipt() || existing.Status == models.BookingStatusPaymentSubmitted ||
+		existing.Status == models.BookingStatusConfirmed) {
+		return existing, job, ErrAlreadyBooked
+	}
+	var prevStatus models.BookingStatus
+	if existing != nil {
+		prevStatus = existing.Status
+	}
+	if err := s.checkPhoneLimit(ctx, tx, jobID, userID, phone); err != nil {
+		return nil, job, err
+	}
+
+	// Fails when reserved + confirmed already reach required_workers
+	if err := s.storage.Job().IncrementReservedSlots(ctx, tx, jobID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrNoFreeSlots
+		}
+		return nil, nil, fmt.Errorf("failed to reserve slot: %w", err)
+	}
+
+	now := s.clock.Now()
+	booking := &models.JobBooking{
+		UserID:         userID,
+		JobID:          jobID,
+		Status:         models.BookingStatusSlotReserved,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      now,
+		ReservedAt:     now,
+		ExpiresAt:      now,
+	}
+	if err := s.storage.Booking().Create(ctx, tx, booking); err != nil {
+		return nil, nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+	if err := transitionBooking(ctx, s.storage, tx, booking.ID, prevStatus, models.BookingStatusSlotReserved, adminID, ""); err != nil {
+		return nil, nil, err
+	}
+	if err := transitionBooking(ctx, s.storage, tx, booking.ID, models.BookingStatusSlotReserved, models.BookingStatusConfirmed, adminID, ""); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.storage.Booking().MarkAsConfirmed(ctx, tx, booking.ID, adminID); err != nil {
+		return nil, nil, fmt.Errorf("failed to confirm booking: %w", err)
+	}
+	if err := s.storage.Job().MoveReservedToConfirmed(ctx, tx, jobID); err != nil {
+		return nil, nil, fmt.Errorf("failed to move slot: %w", err)
+	}
+
+	job, err = s.storage.Job().GetByIDForUpdate(ctx, tx, jobID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.Status == models.JobStatusActive && job.SlotStatus() == models.JobStatusFull {
+		if err := s.storage.Job().UpdateStatusInTx(ctx, tx, job.ID, models.JobStatusFull); err != nil {
+			s.log.Error("Failed to update job status to FULL", logger.Error(err))
+		} else {
+			job.Status = models.JobStatusFull
+		}
+	}
+
+	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	booking.Status = models.BookingStatusConfirmed
+	booking.ConfirmedAt = &now
+	booking.ReviewedByAdminID = &adminID
+	booking.ReviewedAt = &now
+
+	s.log.Info("Manual booking created",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("job_id", jobID),
+		logger.Any("user_id", userID),
+		logger.Any("admin_id", adminID),
+	)
+
+	if s.posts != nil {
+		go s.posts.UpdateChannelJobPost(context.WithoutCancel(ctx), job)
+		go s.posts.UpdateAdminJobPost(context.WithoutCancel(ctx), job)
+	}
+
+	return booking, job, nil
+}
+
+// CancelJob cancels a job on an admin's behalf in one transaction: every reserved, submitted or
+// confirmed booking becomes CANCELLED_BY_ADMIN and each booking that was paid for gets a pending refund.
+// Notifying the workers is left to the caller, which gets the cancelled bookings and refunds back.
+func (s *bookingService) CancelJob(ctx context.Context, jobID, adminID int64) (*models.JobCancellation, error) {
+	bookings, err := s.storage.Booking().GetJobBookings(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job bookings: %w", err)
+	}
+
+	tx, err := s.storage.Transaction().Begin(ctx)
+	if err != nil {
+		s.log.Error("Failed to begin transaction", logger.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
+	defer s.storage.Transaction().Rollback(ctx, tx)
+
+	job, err := s.storage.Job().GetByIDForUpdate(ctx, tx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job: %w", err)
+	}
+	if job.Status == models.JobStatusCancelled || job.Status == models.JobStatusCompleted {
+		return nil, ErrJobNotCancellable
+	}
+
+	result := &models.JobCancellation{Job: job}
+	for _, listed := range bookings {
+		// Re-read under the lock: a payment may have been approved or a reservation expired since the listing
+		booking, err := s.storage.Booking().GetByIDForUpdate(ctx, tx, listed.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock booking %d: %w", listed.ID, err)
+		}
+
+		paid := booking.Status == models.BookingStatusConfirmed || booking.Status == models.BookingStatusPaymentSubmitted
+		if !paid && !booking.AwaitsReceipt() {
+			continue
+		}
+
+		if err := transitionBooking(ctx, s.storage, tx, booking.ID, booking.Status, models.BookingStatusCancelledByAdmin, adminID, ""); err != nil {
+			return nil, err
+		}
+		if err := s.storage.Booking().UpdateStatus(ctx, tx, booking.ID, models.BookingStatusCancelledByAdmin); err != nil {
+			return nil, fmt.Errorf("failed to cancel booking %d: %w", booking.ID, err)
+		}
+		booking.Status = models.BookingStatusCancelledByAdmin
+		result.Bookings = append(result.Bookings, booking)
+
+		// Manual bookings never paid the fee, so they have nothing to get back
+		if !paid || booking.PaymentReceiptFileID == "" {
+			continue
+		}
+		refund := &models.Refund{
+			BookingID: booking.ID,
+			JobID:     job.ID,
+			UserID:    booking.UserID,
+			Amount:    job.ServiceFee,
+		}
+		if err := s.storage.Refund().Create(ctx, tx, refund); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to create refund for booking %d: %w", booking.ID, err)
+		}
+		result.Refunds = append(result.Refunds, refund)
+	}
+
+	if err := s.storage.Job().UpdateStatusInTx(ctx, tx, job.ID, models.JobStatusCancelled); err != nil {
+		return nil, fmt.Errorf("failed to update job status: %w", err)
+	}
+	if _, err := s.storage.Job().RecountSlots(ctx, tx, job.ID); err != nil {
+		return nil, fmt.Errorf("failed to recount slots: %w", err)
+	}
+
+	job, err = s.storage.Job().GetByIDForUpdate(ctx, tx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	result.Job = job
+
+	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.Info("Job cancelled by admin",
+		logger.Any("job_id", jobID),
+		logger.Any("admin_id", adminID),
+		logger.Any("bookings", len(result.Bookings)),
+		logger.Any("refunds", len(result.Refunds)),
+	)
+
+	return result, nil
+}
+
+// voucherCodeAttempts bounds retries when a random code collides with an existing one
+const voucherCodeAttempts = 5
+
+// IssueVoucher returns the booking's voucher, creating it with a fresh code on first call
+func (s *bookingService) IssueVoucher(ctx context.Context, bookingID int64) (*models.BookingVoucher, error) {
+	voucher, err := s.storage.Voucher().GetByBookingID(ctx, bookingID)
+	if err == nil {
+		return voucher, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+
+	for range voucherCodeAttempts {
+		code, err := models.NewVoucherCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate voucher code: %w", err)
+		}
+
+		voucher = &models.BookingVoucher{BookingID: bookingID, Code: code}
+		err = s.storage.Voucher().Create(ctx, voucher)
+		if err == nil {
+			s.log.Info("Voucher issued", logger.Any("booking_id", bookingID))
+			return voucher, nil
+		}
+		if !errors.Is(err, storage.ErrAlreadyExists) {
+			return nil, fmt.Errorf("failed to create voucher: %w", err)
+		}
+
+		// Either the code collided or a concurrent call already issued one
+		if existing, err := s.storage.Voucher().GetByBookingID(ctx, bookingID); err == nil {
+			return existing, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate a unique voucher code for booking %d", bookingID)
+}
+
+// checkActiveBookings enforces BOOKING_MAX_ACTIVE over the user's bookings on other jobs that are
+// reserved, waiting for a resent receipt or under review. Whatever the limit, only one of them may wait
+// for a receipt at a time: a receipt photo goes to the user's reserved booking, not to a chosen job.
+func (s *bookingService) checkActiveBookings(ctx context.Context, userID, jobID int64) error {
+	now := s.clock.Now()
+	var active []*models.JobBooking
+	for _, status := range []models.BookingStatus{
+		models.BookingStatusSlotReserved,
+		models.BookingStatusPaymentRejectedRetryable,
+		models.BookingStatusPaymentSubmitted,
+	} {
+		bookings, err := s.storage.Booking().GetUserBookingsByStatus(ctx, userID, status)
+		if err != nil {
+			return fmt.Errorf("failed to get %s bookings: %w", status, err)
+		}
+		for _, b := range bookings {
+			if b.JobID == jobID || b.IsExpiredAt(now) {
+				continue
+			}
+			if b.AwaitsReceipt() {
+				return &ActiveBookingLimitError{Limit: s.cfg.Booking.MaxActive, AwaitingReceipt: true, Blocking: []*models.JobBooking{b}}
+			}
+			active = append(active, b)
+		}
+	}
+
+	if len(active) >= s.cfg.Booking.MaxActive {
+		return &ActiveBookingLimitError{Limit: s.cfg.Booking.MaxActive, Blocking: active}
+	}
+	return nil
+}
+
+// checkRequirements screens the user's registered profile against the job's requirements.
+// Admin waivers let a user through; users without a registered profile can't be screened and pass.
+func (s *bookingService) checkRequirements(ctx context.Context, userID, jobID int64) error {
+	job, err := s.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if !job.HasRequirements() {
+		return nil
+	}
+
+	registered, err := s.storage.Registration().GetRegisteredUserByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get registered user: %w", err)
+	}
+
+	unmet := job.UnmetRequirements(registered)
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	waived, err := s.storage.Job().IsRequirementWaived(ctx, jobID, userID)
+	if err != nil {
+		return err
+	}
+	if waived {
+		s.log.Info("Job requirements waived for user", logger.Any("job_id", jobID), logger.Any("user_id", userID))
+		return nil
+	}
+
+	s.log.Info("Booking refused: job requirements not met",
+		logger.Any("job_id", jobID),
+		logger.Any("user_id", userID),
+		logger.Any("unmet", unmet),
+	)
+	return &RequirementsNotMetError{Job: job, Profile: registered, Unmet: unmet}
+}
+
+// limitedPhone returns the user's registered phone when BOOKING_ONE_PER_PHONE is on.
+// It is empty when the limit is off or the user has no registration.
+func (s *bookingService) limitedPhone(ctx context.Context, userID int64) (string, error) {
+	if !s.cfg.Booking.OnePerPhone {
+		return "", nil
+	}
+
+	registered, err := s.storage.Registration().GetRegisteredUserByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get registered user: %w", err)
+	}
+	return registered.Phone, nil
+}
+
+// checkPhoneLimit fails with ErrPhoneAlreadyBooked when another account with the phone holds an active booking on the job
+func (s *bookingService) checkPhoneLimit(ctx context.Context, tx any, jobID, userID int64, phone string) error {
+	if phone == "" {
+		return nil
+	}
+
+	other, err := s.storage.Booking().GetActiveByJobAndPhone(ctx, tx, jobID, phone, userID, s.clock.Now())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check phone bookings: %w", err)
+	}
+
+	s.log.Warn("Booking refused: phone already booked on the job",
+		logger.Any("job_id", jobID),
+		logger.Any("user_id", userID),
+		logger.Any("other_user_id", other.UserID),
+		logger.Any("other_booking_id", other.ID),
+	)
+	return ErrPhoneAlreadyBooked
+}
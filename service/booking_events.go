@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// ErrInvalidBookingTransition matches every *BookingTransitionError
+var ErrInvalidBookingTransition = errors.New("invalid booking status transition")
+
+// BookingTransitionError is returned when a booking would skip its state machine
+// (models.BookingStatus.CanTransitionTo), e.g. re-reserving a hold the expiry
+// worker has not released yet or approving an expired booking
+type BookingTransitionError struct {
+	BookingID int64
+	From      models.BookingStatus
+	To        models.BookingStatus
+}
+
+func (e *BookingTransitionError) Error() string {
+	from := string(e.From)
+	if from == "" {
+		from = "NEW"
+	}
+	return fmt.Sprintf("booking #%d cannot move from %s to %s", e.BookingID, from, e.To)
+}
+
+// Is makes errors.Is(err, ErrInvalidBookingTransition) match
+func (e *BookingTransitionError) Is(target error) bool {
+	return target == ErrInvalidBookingTransition
+}
+
+// transitionBooking checks that a booking in status from may move to to and records the change
+// in its history within tx. The caller writes the new status in the same transaction, with the
+// booking row locked so from is current.
+func transitionBooking(ctx context.Context, st storage.StorageI, tx any, bookingID int64, from, to models.BookingStatus, actorID int64, note string) error {
+	if !from.CanTransitionTo(to) {
+		return &BookingTransitionError{BookingID: bookingID, From: from, To: to}
+	}
+
+	event := &models.BookingEvent{
+		BookingID:  bookingID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actorID,
+		Note:       note,
+	}
+	if err := st.Booking().AddEvent(ctx, tx, event); err != nil {
+		return fmt.Errorf("failed to record booking event: %w", err)
+	}
+	return nil
+}
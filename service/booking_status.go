@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// BookingStatusService keeps one status message per booking in the user's chat and edits it as the
+// booking moves on (BOT_BOOKING_TRACKER). The message ID is kept in the booking's status_message_id.
+type BookingStatusService interface {
+	// Enabled reports whether BOT_BOOKING_TRACKER is on; the other methods do nothing when it is off
+	Enabled() bool
+	// Refresh edits the booking's status message in place, or sends one if it has none or it is gone.
+	// Edits don't notify, so it suits changes the user gets another message about.
+	Refresh(ctx context.Context, bookingID int64)
+	// Repost replaces the status message with a new one at the bottom of the chat, which notifies the user
+	Repost(ctx context.Context, bookingID int64)
+}
+
+type bookingStatusService struct {
+	log     logger.LoggerI
+	storage storage.StorageI
+	bot     BotAPI
+	enabled bool
+
+	// mu serializes updates so two changes of a booking can't both send a new message
+	mu sync.Mutex
+}
+
+// NewBookingStatusService creates the booking status tracker
+func NewBookingStatusService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, bot BotAPI) BookingStatusService {
+	return &bookingStatusService{
+		log:     log,
+		storage: storage,
+		bot:     bot,
+		enabled: cfg.Bot.BookingTracker,
+	}
+}
+
+// Enabled reports whether the tracker is on
+func (s *bookingStatusService) Enabled() bool {
+	return s.enabled
+}
+
+// Refresh edits the status message, falling back to a new one
+func (s *bookingStatusService) Refresh(ctx context.Context, bookingID int64) {
+	if err := s.update(ctx, bookingID, false); err != nil {
+		s.log.Error("Failed to refresh booking status message", logger.Error(err), logger.Any("booking_id", bookingID))
+	}
+}
+
+// Repost deletes the status message and sends a new one
+func (s *bookingStatusService) Repost(ctx context.Context, bookingID int64) {
+	if err := s.update(ctx, bookingID, true); err != nil {
+		s.log.Error("Failed to repost booking status message", logger.Error(err), logger.Any("booking_id", bookingID))
+	}
+}
+
+// update re-reads the booking, so callers need not pass the state they just wrote, and renders it
+func (s *bookingStatusService) update(ctx context.Context, bookingID int64, repost bool) error {
+	if !s.enabled {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The change was just committed, so don't read it from a lagging replica
+	ctx = storage.WithPrimary(ctx)
+	booking, err := s.storage.Booking().GetByID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to get booking: %w", err)
+	}
+	job, err := s.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	text := messages.FormatBookingStatus(booking, job)
+
+	if booking.StatusMessageID != 0 {
+		msg := &tele.Message{ID: int(booking.StatusMessageID), Chat: &tele.Chat{ID: booking.UserID}}
+		if repost {
+			// An old message that can't be deleted any more (48h) just stays behind
+			if err := s.bot.Delete(msg); err != nil {
+				s.log.Warn("Failed to delete old booking status message", logger.Error(err), logger.Any("booking_id", booking.ID))
+			}
+		} else {
+			_, err := s.bot.Edit(msg, text, tele.ModeHTML)
+			if err == nil || errors.Is(err, tele.ErrMessageNotModified) {
+				return nil
+			}
+			s.log.Warn("Failed to edit booking status message, sending a new one", logger.Error(err), logger.Any("booking_id", booking.ID))
+		}
+	}
+
+	sent, err := s.bot.Send(&tele.User{ID: booking.UserID}, text, tele.ModeHTML)
+	if err != nil {
+		return fmt.Errorf("failed to send status message: %w", err)
+	}
+	if err := s.storage.Booking().SetStatusMessageID(ctx, booking.ID, int64(sent.ID)); err != nil {
+		return fmt.Errorf("failed to store status message ID: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"telegram-bot-starter/bot/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+//go:generate go run ../scripts/genmocks -out mocks
+
+// BotAPI is the subset of the Telegram client used by services and workers.
+// *tele.Bot satisfies it; tests pass a mock instead of talking to Telegram.
+type BotAPI interface {
+	Send(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error)
+	Edit(msg tele.Editable, what interface{}, opts ...interface{}) (*tele.Message, error)
+	EditCaption(msg tele.Editable, caption string, opts ...interface{}) (*tele.Message, error)
+	Delete(msg tele.Editable) error
+	Pin(msg tele.Editable, opts ...interface{}) error
+	SetCommands(opts ...interface{}) error
+	DeleteCommands(opts ...interface{}) error
+}
+
+var _ BotAPI = (*tele.Bot)(nil)
+
+// JobPostUpdater refreshes the channel and admin posts of a job after its slots change
+type JobPostUpdater interface {
+	UpdateChannelJobPost(ctx context.Context, job *models.Job) error
+	UpdateAdminJobPost(ctx context.Context, job *models.Job) error
+}
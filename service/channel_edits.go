@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// channelEditTimeout is the max time for a batched channel post edit
+const channelEditTimeout = 30 * time.Second
+
+// channelEdit is a rendered channel post of a job
+type channelEdit struct {
+	jobID     int64
+	messageID int
+	text      string
+	keyboard  *tele.ReplyMarkup
+}
+
+// newChannelEdit renders the job's channel post (caption A); only ACTIVE jobs keep the signup button
+func newChannelEdit(job *models.Job, botUsername string) *channelEdit {
+	keyboard := &tele.ReplyMarkup{}
+	if job.Status == models.JobStatusActive {
+		keyboard = keyboards.JobCaptionSignupKeyboard(job, models.CaptionVariantA, botUsername)
+	}
+	return &channelEdit{
+		jobID:     job.ID,
+		messageID: int(job.ChannelMessageID),
+		text:      messages.FormatJobForChannel(job),
+		keyboard:  keyboard,
+	}
+}
+
+// sum hashes what the post would show, so an edit repeating the last one can be skipped
+func (e *channelEdit) sum() [sha256.Size]byte {
+	markup, _ := json.Marshal(e.keyboard.InlineKeyboard)
+	return sha256.Sum256(fmt.Appendf(nil, "%d\x00%s\x00%s", e.messageID, e.text, markup))
+}
+
+// channelEdits coalesces channel post edits per job. The first edit goes out at once and opens
+// a BOT_CHANNEL_EDIT_WINDOW window; edits during it replace each other and only the last one
+// is sent when the window closes, which opens the next window.
+type channelEdits struct {
+	mu      sync.Mutex
+	windows map[int64]*time.Timer       // jobs whose window is open
+	pending map[int64]*channelEdit      // latest edit waiting for its job's window to close
+	sent    map[int64][sha256.Size]byte // hash of the content each post shows
+
+	editMu sync.Mutex // Serializes edits so a batched edit can't overtake an earlier one
+}
+
+// UpdateChannelJobPost updates a job post in the channel with latest info.
+// Inside the job's edit window the edit is batched and nil is returned.
+func (s *senderService) UpdateChannelJobPost(ctx context.Context, job *models.Job) error {
+	if job.ChannelMessageID == 0 {
+		s.log.Warn("Cannot update channel message: no channel message ID", logger.Any("job_id", job.ID))
+		return fmt.Errorf("no channel message ID for job %d", job.ID)
+	}
+
+	edit := newChannelEdit(job, s.cfg.Bot.Username)
+
+	if window := s.cfg.Bot.ChannelEditWindow; window > 0 {
+		s.edits.mu.Lock()
+		if _, open := s.edits.windows[job.ID]; open {
+			s.edits.pending[job.ID] = edit
+			s.edits.mu.Unlock()
+			s.log.Debug("Channel message edit batched", logger.Any("job_id", job.ID))
+			return nil
+		}
+		s.edits.windows[job.ID] = time.AfterFunc(window, func() { s.flushChannelEdit(job.ID) })
+		s.edits.mu.Unlock()
+	}
+
+	return s.editChannelPost(ctx, edit)
+}
+
+// flushChannelEdit sends the job's batched edit when its window closes, or closes the window
+func (s *senderService) flushChannelEdit(jobID int64) {
+	s.edits.mu.Lock()
+	edit, ok := s.edits.pending[jobID]
+	delete(s.edits.pending, jobID)
+	if !ok {
+		delete(s.edits.windows, jobID)
+		s.edits.mu.Unlock()
+		return
+	}
+	s.edits.windows[jobID] = time.AfterFunc(s.cfg.Bot.ChannelEditWindow, func() { s.flushChannelEdit(jobID) })
+	s.edits.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelEditTimeout)
+	defer cancel()
+
+	// Errors are logged by editChannelPost
+	_ = s.editChannelPost(ctx, edit)
+}
+
+// editChannelPost edits the channel post unless it already shows the same content
+func (s *senderService) editChannelPost(ctx context.Context, edit *channelEdit) error {
+	s.edits.editMu.Lock()
+	defer s.edits.editMu.Unlock()
+
+	sum := edit.sum()
+	s.edits.mu.Lock()
+	unchanged := s.edits.sent[edit.jobID] == sum
+	s.edits.mu.Unlock()
+	if unchanged {
+		s.log.Debug("Channel message unchanged, edit skipped", logger.Any("job_id", edit.jobID))
+		return nil
+	}
+
+	msg := &tele.Message{
+		ID:   edit.messageID,
+		Chat: &tele.Chat{ID: s.cfg.Bot.ChannelID},
+	}
+	_, err := s.bot.Edit(msg, edit.text, edit.keyboard, tele.ModeHTML)
+	if err != nil && !errors.Is(err, tele.ErrMessageNotModified) {
+		s.edits.mu.Lock()
+		delete(s.edits.sent, edit.jobID)
+		s.edits.mu.Unlock()
+		s.log.Error("Failed to update channel message",
+			logger.Error(err),
+			logger.Any("job_id", edit.jobID),
+			logger.Any("channel_message_id", edit.messageID),
+		)
+		return fmt.Errorf("failed to update channel message: %w", err)
+	}
+
+	s.edits.mu.Lock()
+	s.edits.sent[edit.jobID] = sum
+	s.edits.mu.Unlock()
+
+	s.log.Info("Channel message updated successfully",
+		logger.Any("job_id", edit.jobID),
+		logger.Any("channel_message_id", edit.messageID),
+	)
+	return nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// ChannelIndexService keeps the pinned "Bugungi ishlar" message in the channel up to date.
+// The message ID is stored in bot_settings, so a restart keeps editing the same message.
+type ChannelIndexService interface {
+	// Notify asks for a refresh after a job was published, closed or removed; it never blocks
+	Notify()
+	// Changed fires after Notify; the channel index worker refreshes on it
+	Changed() <-chan struct{}
+	// Refresh edits the index when the open jobs changed, posting and pinning a new one if it is gone
+	Refresh(ctx context.Context) error
+}
+
+type channelIndexService struct {
+	cfg     config.Config
+	log     logger.LoggerI
+	storage storage.StorageI
+	bot     BotAPI
+
+	changed chan struct{}
+
+	mu        sync.Mutex // Serializes refreshes so two of them can't post two messages
+	messageID int        // 0 until loaded from bot_settings or posted
+	loaded    bool
+	lastText  string // Text of the last successful edit, to skip no-op edits
+}
+
+// NewChannelIndexService creates a new channel index service
+func NewChannelIndexService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, bot BotAPI) ChannelIndexService {
+	return &channelIndexService{
+		cfg:     cfg,
+		log:     log,
+		storage: storage,
+		bot:     bot,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Notify asks for a refresh; notifications arriving during a refresh collapse into one
+func (s *channelIndexService) Notify() {
+	if !s.cfg.Bot.ChannelIndex {
+		return
+	}
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Changed fires after Notify
+func (s *channelIndexService) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// Refresh renders the open jobs and updates the pinned message
+func (s *channelIndexService) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs, err := s.openJobs(ctx)
+	if err != nil {
+		return err
+	}
+	text := messages.FormatChannelIndex(jobs, s.cfg.Bot.Username)
+
+	if err := s.loadMessageID(ctx); err != nil {
+		return err
+	}
+
+	if s.messageID != 0 {
+		if text == s.lastText {
+			return nil
+		}
+		msg := &tele.Message{ID: s.messageID, Chat: &tele.Chat{ID: s.cfg.Bot.ChannelID}}
+		_, err := s.bot.Edit(msg, text, tele.ModeHTML, tele.NoPreview)
+		if err == nil || errors.Is(err, tele.ErrMessageNotModified) {
+			s.lastText = text
+			return nil
+		}
+		if !isIndexMessageGone(err) {
+			return fmt.Errorf("failed to edit channel index: %w", err)
+		}
+		s.log.Warn("Channel index message is gone, posting a new one", logger.Any("message_id", s.messageID))
+	}
+
+	return s.post(ctx, text)
+}
+
+// openJobs returns the published jobs still taking bookings, oldest first. The refresh follows
+// the change that triggered it, so it reads from the primary rather than a lagging replica.
+func (s *channelIndexService) openJobs(ctx context.Context) ([]*models.Job, error) {
+	status := models.JobStatusActive
+	all, err := s.storage.Job().GetAll(storage.WithPrimary(ctx), &status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	var jobs []*models.Job
+	for _, job := range all {
+		if job.IsActive() && job.ChannelMessageID != 0 {
+			jobs = append(jobs, job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *models.Job) int { return int(a.ID - b.ID) })
+	return jobs, nil
+}
+
+// loadMessageID reads the stored message ID once
+func (s *channelIndexService) loadMessageID(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	stored, err := s.storage.Settings().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load channel index message ID: %w", err)
+	}
+	if value := stored[models.SettingChannelIndexMessageID]; value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			s.log.Error("Stored channel index message ID is invalid, posting a new one", logger.String("value", value))
+		}
+		s.messageID = id
+	}
+	s.loaded = true
+	return nil
+}
+
+// post sends a new index, pins it without a notification and remembers its ID
+func (s *channelIndexService) post(ctx context.Context, text string) error {
+	sent, err := s.bot.Send(&tele.Chat{ID: s.cfg.Bot.ChannelID}, text, tele.ModeHTML, tele.NoPreview, tele.Silent)
+	if err != nil {
+		return fmt.Errorf("failed to post channel index: %w", err)
+	}
+	s.messageID = sent.ID
+	s.lastText = text
+
+	if err := s.storage.Settings().Set(ctx, models.SettingChannelIndexMessageID, strconv.Itoa(sent.ID), 0); err != nil {
+		s.log.Error("Failed to store channel index message ID", logger.Error(err))
+	}
+
+	if err := s.bot.Pin(sent, tele.Silent); err != nil {
+		// The index still works unpinned; the bot needs the "Pin messages" admin right
+		s.log.Error("Failed to pin channel index", logger.Error(err), logger.Any("message_id", sent.ID))
+	}
+
+	s.log.Info("Channel index posted", logger.Any("message_id", sent.ID))
+	return nil
+}
+
+// isIndexMessageGone reports whether Telegram no longer has the index message (deleted by a channel admin)
+func isIndexMessageGone(err error) bool {
+	return err.Error() == "telegram: message not found (400)" ||
+		err.Error() == "telegram: message to edit not found (400)"
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+)
+
+// channelIndexTimeout is the max time for one index refresh
+const channelIndexTimeout = 30 * time.Second
+
+// ChannelIndexWorker refreshes the pinned channel index on publish/close events and every
+// BOT_CHANNEL_INDEX_INTERVAL, which also picks up slot changes made by bookings and payments
+type ChannelIndexWorker struct {
+	cfg      *config.Config
+	log      logger.LoggerI
+	index    ChannelIndexService
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewChannelIndexWorker creates a new channel index worker
+func NewChannelIndexWorker(cfg *config.Config, log logger.LoggerI, index ChannelIndexService) *ChannelIndexWorker {
+	return &ChannelIndexWorker{
+		cfg:      cfg,
+		log:      log,
+		index:    index,
+		interval: cfg.Bot.ChannelIndexInterval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the channel index worker background process
+func (w *ChannelIndexWorker) Start() {
+	if !w.cfg.Bot.ChannelIndex {
+		w.log.Info("Channel index worker disabled (BOT_CHANNEL_INDEX not set)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Channel index worker started", logger.Any("interval", w.interval.String()))
+
+	// Bring the index up to date with whatever changed while the bot was down
+	w.safeRefresh()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeRefresh()
+		case <-w.index.Changed():
+			w.safeRefresh()
+		case <-w.stopChan:
+			w.log.Info("Channel index worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the channel index worker
+func (w *ChannelIndexWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeRefresh wraps the refresh with panic recovery
+func (w *ChannelIndexWorker) safeRefresh() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in channel index worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelIndexTimeout)
+	defer cancel()
+
+	if err := w.index.Refresh(ctx); err != nil {
+		w.log.Error("Failed to refresh channel index", logger.Error(err))
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+// channelPostTimeout is the max time for one channel post refresh
+const channelPostTimeout = 30 * time.Second
+
+// ChannelPostService keeps the slot counts of channel job posts current after reservations,
+// expiries and released payments, which don't go through the admin handlers' updateChannelMessage.
+type ChannelPostService interface {
+	// Schedule asks for the job's channel post to be refreshed after its slots changed in a
+	// committed transaction; requests within BOT_CHANNEL_POST_DELAY collapse into one edit. It never blocks.
+	Schedule(jobID int64)
+	// Stop drops the pending refreshes
+	Stop()
+}
+
+type channelPostService struct {
+	log     logger.LoggerI
+	storage storage.StorageI
+	posts   JobPostUpdater
+	index   ChannelIndexService
+	delay   time.Duration
+
+	mu      sync.Mutex
+	pending map[int64]*time.Timer
+	stopped bool
+
+	// editMu serializes edits so an older read of a job can't overwrite a newer one
+	editMu sync.Mutex
+}
+
+// NewChannelPostService creates a new channel post refresher
+func NewChannelPostService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, posts JobPostUpdater, index ChannelIndexService) ChannelPostService {
+	return &channelPostService{
+		log:     log,
+		storage: storage,
+		posts:   posts,
+		index:   index,
+		delay:   cfg.Bot.ChannelPostDelay,
+		pending: make(map[int64]*time.Timer),
+	}
+}
+
+// Schedule starts the job's debounce timer unless a refresh is already pending.
+// The pending refresh reads the job when it fires, so it also covers this change.
+func (s *channelPostService) Schedule(jobID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+	if _, ok := s.pending[jobID]; ok {
+		return
+	}
+	s.pending[jobID] = time.AfterFunc(s.delay, func() { s.refresh(jobID) })
+}
+
+// Stop cancels the pending timers
+func (s *channelPostService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	for jobID, timer := range s.pending {
+		timer.Stop()
+		delete(s.pending, jobID)
+	}
+}
+
+// refresh re-reads the job and edits its channel post
+func (s *channelPostService) refresh(jobID int64) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error("PANIC in channel post refresh recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+
+	// A change committed from here on schedules another refresh instead of being
+	// folded into this one, whose read may already be behind it
+	s.mu.Lock()
+	delete(s.pending, jobID)
+	s.mu.Unlock()
+
+	s.editMu.Lock()
+	defer s.editMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelPostTimeout)
+	defer cancel()
+
+	job, err := s.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		s.log.Error("Failed to get job for channel post refresh", logger.Error(err), logger.Any("job_id", jobID))
+		return
+	}
+	if job.ChannelMessageID == 0 {
+		return
+	}
+
+	if err := s.posts.UpdateChannelJobPost(ctx, job); err != nil {
+		// Already logged by the updater
+		return
+	}
+	s.index.Notify()
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+// Clock is the time source for expiry, reservation TTLs and review timestamps.
+// Services take it as a dependency so tests can freeze or advance time.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGen produces the keys services use to deduplicate work
+type IDGen interface {
+	// IdempotencyKey identifies a user's booking attempt for a job
+	IdempotencyKey(userID, jobID int64) string
+}
+
+// SystemClock reads the wall clock
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// DefaultIDGen derives keys deterministically from their inputs
+type DefaultIDGen struct{}
+
+// IdempotencyKey returns the user/job pair key used across retries
+func (DefaultIDGen) IdempotencyKey(userID, jobID int64) string {
+	return models.GenerateIdempotencyKey(userID, jobID)
+}
+
+// Option customizes the dependencies NewServiceManager wires into services
+type Option func(*options)
+
+type options struct {
+	clock Clock
+	ids   IDGen
+}
+
+func defaultOptions() options {
+	return options{clock: SystemClock{}, ids: DefaultIDGen{}}
+}
+
+// WithClock replaces the system clock used by the booking and payment services
+func WithClock(clock Clock) Option {
+	return func(o *options) { o.clock = clock }
+}
+
+// WithIDGen replaces the idempotency key generator used by the booking service
+func WithIDGen(ids IDGen) Option {
+	return func(o *options) { o.ids = ids }
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// countdownTimeout is the max time for one countdown round.
+	countdownTimeout = 15 * time.Second
+
+	// countdownBatchSize limits reservations handled per round.
+	countdownBatchSize = 200
+)
+
+// countdownMarks are the remaining times at which the payment message is refreshed, largest first
+var countdownMarks = []time.Duration{2 * time.Minute, time.Minute, 30 * time.Second}
+
+// CountdownWorker edits the payment instruction message of active reservations as their deadline approaches.
+// Reservations are re-read from storage every round, so countdowns resume after a restart;
+// the expired message itself is written by ExpiryWorker.
+type CountdownWorker struct {
+	cfg      *config.Config
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	settings SettingsService
+	clock    Clock
+	interval time.Duration
+	shown    map[int64]time.Duration // booking ID → last mark rendered
+	stopChan chan struct{}
+}
+
+// NewCountdownWorker creates a new payment countdown updater
+func NewCountdownWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, settings SettingsService, clock Clock) *CountdownWorker {
+	return &CountdownWorker{
+		cfg:      cfg,
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		settings: settings,
+		clock:    clock,
+		interval: 5 * time.Second,
+		shown:    make(map[int64]time.Duration),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the countdown worker background process
+func (w *CountdownWorker) Start() {
+	w.log.Info("Countdown worker started", logger.Any("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Countdown worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the countdown worker
+func (w *CountdownWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *CountdownWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in countdown worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process refreshes every reservation that has crossed a new countdown mark
+func (w *CountdownWorker) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), countdownTimeout)
+	defer cancel()
+
+	now := w.clock.Now()
+	bookings, err := w.storage.Booking().GetActiveReservations(ctx, now, countdownBatchSize)
+	if err != nil {
+		w.log.Error("Failed to get active reservations", logger.Error(err))
+		return
+	}
+
+	shown := make(map[int64]time.Duration, len(bookings))
+	jobs := make(map[int64]*models.Job)
+	card := w.settings.Card(ctx)
+
+	for _, booking := range bookings {
+		remaining := booking.TimeRemainingAt(now)
+		mark, ok := currentCountdownMark(remaining)
+		if !ok {
+			continue
+		}
+
+		// Too close to the deadline: the expiry message is about to replace this one
+		if remaining < w.interval {
+			shown[booking.ID] = mark
+			continue
+		}
+
+		if last, seen := w.shown[booking.ID]; seen && last == mark {
+			shown[booking.ID] = mark
+			continue
+		}
+
+		job, ok := jobs[booking.JobID]
+		if !ok {
+			job, err = w.storage.Job().GetByID(ctx, booking.JobID)
+			if err != nil {
+				w.log.Error("Failed to get job for countdown", logger.Error(err), logger.Any("job_id", booking.JobID))
+				continue
+			}
+			jobs[booking.JobID] = job
+		}
+
+		msg := &tele.StoredMessage{
+			MessageID: strconv.FormatInt(booking.PaymentInstructionMsgID, 10),
+			ChatID:    booking.UserID,
+		}
+		text := messages.FormatPaymentCountdown(job, card.Number, card.HolderName, remaining)
+		if _, err := w.bot.Edit(msg, text, tele.ModeHTML); err != nil {
+			w.log.Warn("Failed to update payment countdown",
+				logger.Error(err),
+				logger.Any("booking_id", booking.ID),
+				logger.Any("user_id", booking.UserID),
+			)
+		}
+
+		// Recorded even on failure so a deleted message is not retried every round
+		shown[booking.ID] = mark
+	}
+
+	// Reservations that were paid, expired or cancelled drop out here
+	w.shown = shown
+}
+
+// currentCountdownMark returns the smallest mark the remaining time has reached
+func currentCountdownMark(remaining time.Duration) (time.Duration, bool) {
+	mark, ok := time.Duration(0), false
+	for _, m := range countdownMarks {
+		if remaining <= m {
+			mark, ok = m, true
+		}
+	}
+	return mark, ok
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// digestTimeout is the max time for building and sending one digest round.
+const digestTimeout = 30 * time.Second
+
+// DigestWorker sends the morning summary to admins once a day
+type DigestWorker struct {
+	cfg      *config.Config
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	settings SettingsService
+	interval time.Duration
+	stopChan chan struct{}
+	lastSent string // Local date (YYYY-MM-DD) of the last sent digest
+}
+
+// NewDigestWorker creates a new daily digest worker
+func NewDigestWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, settings SettingsService) *DigestWorker {
+	return &DigestWorker{
+		cfg:      cfg,
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		settings: settings,
+		interval: time.Minute, // Check once a minute whether the digest hour has come
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the digest worker background process
+func (w *DigestWorker) Start() {
+	w.log.Info("Digest worker started", logger.Any("digest_hour", w.cfg.Bot.DigestHour))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeCheck()
+		case <-w.stopChan:
+			w.log.Info("Digest worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the digest worker
+func (w *DigestWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeCheck wraps check with panic recovery so a bad digest can't crash the bot
+func (w *DigestWorker) safeCheck() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in digest worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.check()
+}
+
+// check sends the digest during the configured hour, at most once per day.
+// Only the digest hour itself counts, so a restart later in the day doesn't send a stale digest.
+func (w *DigestWorker) check() {
+	now := config.NowLocal()
+	today := now.Format("2006-01-02")
+	if now.Hour() != w.cfg.Bot.DigestHour || w.lastSent == today {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), digestTimeout)
+	defer cancel()
+
+	digest, err := w.BuildDigest(ctx, now)
+	if err != nil {
+		w.log.Error("Failed to build daily digest", logger.Error(err))
+		return
+	}
+
+	w.send(ctx, messages.FormatDailyDigest(digest))
+	w.lastSent = today
+}
+
+// BuildDigest gathers the digest figures for the local day containing now
+func (w *DigestWorker) BuildDigest(ctx context.Context, now time.Time) (*models.DailyDigest, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	digest := &models.DailyDigest{Date: today}
+
+	activeJobs, err := w.storage.Job().GetCountByStatus(ctx, models.JobStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("count active jobs: %w", err)
+	}
+	digest.ActiveJobs = activeJobs
+
+	for _, status := range []models.JobStatus{models.JobStatusActive, models.JobStatusFull} {
+		jobs, err := w.storage.Job().GetAll(ctx, &status)
+		if err != nil {
+			return nil, fmt.Errorf("get %s jobs: %w", status, err)
+		}
+		for _, job := range jobs {
+			workDate, ok := helper.ParseWorkDate(job.WorkDate, job.CreatedAt.In(config.Timezone))
+			if !ok || !helper.SameDay(today, workDate) {
+				continue
+			}
+			digest.TodayJobs = append(digest.TodayJobs, job)
+			digest.SlotsRemaining += max(job.RequiredWorkers-job.ConfirmedSlots, 0)
+		}
+	}
+
+	stats, err := w.storage.Booking().GetStatsForPeriod(ctx, today.AddDate(0, 0, -1), today)
+	if err != nil {
+		return nil, fmt.Errorf("get yesterday's booking stats: %w", err)
+	}
+	digest.Yesterday = stats
+
+	return digest, nil
+}
+
+// send delivers the digest to the operations group or to each admin who kept it enabled
+func (w *DigestWorker) send(ctx context.Context, msg string) {
+	if groupID := w.cfg.Bot.OpsChatID(); w.cfg.Bot.DigestToGroup && groupID != 0 {
+		if _, err := w.bot.Send(&tele.Chat{ID: groupID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send daily digest to operations group", logger.Error(err))
+		}
+		return
+	}
+
+	for _, adminID := range w.settings.AdminIDs(ctx) {
+		prefs, err := w.storage.AdminPrefs().Get(ctx, adminID)
+		if err != nil {
+			w.log.Error("Failed to get admin notification prefs", logger.Error(err), logger.Any("admin_id", adminID))
+			continue
+		}
+		if !prefs.IsEnabled(models.NotifyDailyDigest) {
+			continue
+		}
+
+		if _, err := w.bot.Send(&tele.User{ID: adminID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send daily digest",
+				logger.Error(err),
+				logger.Any("admin_id", adminID),
+			)
+		}
+	}
+}
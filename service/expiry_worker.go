@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"runtime/debug"
 	"strconv"
+	"sync"
 	"time"
 
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
 	"telegram-bot-starter/storage"
 
 	tele "gopkg.in/telebot.v4"
@@ -19,31 +22,81 @@ const (
 	expiryDBTimeout = 10 * time.Second
 	// expiryNotifyTimeout is the max time for sending a Telegram notification.
 	expiryNotifyTimeout = 15 * time.Second
+	// expiryMaxPerTick caps the bookings released per tick so a backlog can't starve notifications.
+	expiryMaxPerTick = 500
+	// expiryMaxBackoff caps how long the worker pauses while the database is unavailable.
+	expiryMaxBackoff = 5 * time.Minute
 )
 
+// ExpiryStatus describes the expiry worker's configuration, its last pass and running totals
+type ExpiryStatus struct {
+	Interval  time.Duration
+	BatchSize int
+
+	LastRunAt      time.Time // end of the last pass; zero until the first one
+	LastCandidates int       // expired reservations claimed in the last pass
+	LastExpired    int       // reservations released in the last pass
+	LastErrors     int       // failed batches in the last pass
+	PausedUntil    time.Time // passes are skipped until then after a failed batch
+
+	Passes    uint64 // passes run since start
+	Processed uint64 // bookings released since start
+	Errors    uint64 // failed batches since start
+}
+
+// ExpiryMonitor reports what the expiry worker is doing, for the /expiry admin command
+type ExpiryMonitor interface {
+	Status() ExpiryStatus
+}
+
 // ExpiryWorker handles automatic expiration of reserved bookings
 type ExpiryWorker struct {
 	storage  storage.StorageI
 	log      logger.LoggerI
-	bot      *tele.Bot
+	bot      BotAPI
+	settings SettingsService
+	posts    ChannelPostService
+	tracker  BookingStatusService
+	clock    Clock
 	interval time.Duration
+	batch    int
 	stopChan chan struct{}
+
+	mu     sync.Mutex
+	status ExpiryStatus
+
+	// backoff grows while batches fail and the worker skips ticks until resumeAt
+	backoff  time.Duration
+	resumeAt time.Time
 }
 
-// NewExpiryWorker creates a new expiry worker
-func NewExpiryWorker(storage storage.StorageI, log logger.LoggerI, bot *tele.Bot) *ExpiryWorker {
+// NewExpiryWorker creates a new expiry worker; clock decides which reservations are past their deadline
+// posts refreshes the channel posts of the jobs whose places were released and tracker the users' status messages
+func NewExpiryWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, settings SettingsService, posts ChannelPostService, tracker BookingStatusService, clock Clock) *ExpiryWorker {
 	return &ExpiryWorker{
 		storage:  storage,
 		log:      log,
 		bot:      bot,
-		interval: 10 * time.Second, // Check every 10 seconds
+		settings: settings,
+		posts:    posts,
+		tracker:  tracker,
+		clock:    clock,
+		interval: cfg.Bot.ExpiryInterval,
+		batch:    cfg.Bot.ExpiryBatchSize,
 		stopChan: make(chan struct{}),
+		status: ExpiryStatus{
+			Interval:  cfg.Bot.ExpiryInterval,
+			BatchSize: cfg.Bot.ExpiryBatchSize,
+		},
 	}
 }
 
 // Start begins the expiry worker background process
 func (w *ExpiryWorker) Start() {
-	w.log.Info("Expiry worker started")
+	w.log.Info("Expiry worker started",
+		logger.Any("interval", w.interval.String()),
+		logger.Any("batch_size", w.batch),
+	)
 
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
@@ -67,6 +120,13 @@ func (w *ExpiryWorker) Stop() {
 	close(w.stopChan)
 }
 
+// Status returns a snapshot of the worker's last pass and totals
+func (w *ExpiryWorker) Status() ExpiryStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
 // safeProcessExpiredBookings wraps processExpiredBookings with panic recovery.
 // Without this, an unrecovered panic would crash the entire bot process
 // (container stays up, bot stops responding).
@@ -82,84 +142,142 @@ func (w *ExpiryWorker) safeProcessExpiredBookings() {
 	w.processExpiredBookings()
 }
 
-// processExpiredBookings finds and processes all expired bookings
+// processExpiredBookings releases expired bookings batch by batch, up to expiryMaxPerTick.
+// A failed batch is rolled back and the worker backs off exponentially, since the
+// usual cause is the database being unavailable.
 func (w *ExpiryWorker) processExpiredBookings() {
-	ctx, cancel := context.WithTimeout(context.Background(), expiryDBTimeout)
-	defer cancel()
-
-	expiredBookings, err := w.storage.Booking().GetExpiredBookings(ctx, 100)
-	if err != nil {
-		w.log.Error("Failed to get expired bookings", logger.Error(err))
+	if time.Now().Before(w.resumeAt) {
+		w.log.Debug("Expiry pass skipped while backing off", logger.Any("resume_at", w.resumeAt))
 		return
 	}
 
-	if len(expiredBookings) == 0 {
-		return
-	}
-
-	w.log.Info("Processing expired bookings", logger.Any("count", len(expiredBookings)))
+	started := time.Now()
+	candidates, released, failed := 0, 0, 0
+	for released < expiryMaxPerTick {
+		limit := min(w.batch, expiryMaxPerTick-released)
+		batch, claimed, err := w.releaseBatch(limit)
+		candidates += claimed
+		if err != nil {
+			failed++
+			w.backOff(err)
+			break
+		}
+		w.backoff = 0
 
-	// Process each expired booking with its own timeout
-	for _, booking := range expiredBookings {
-		if err := w.processExpiredBooking(booking); err != nil {
-			w.log.Error("Failed to process expired booking",
-				logger.Error(err),
+		for _, booking := range batch {
+			w.log.Info("Released expired booking",
 				logger.Any("booking_id", booking.ID),
 				logger.Any("user_id", booking.UserID),
 				logger.Any("job_id", booking.JobID),
 			)
-			continue
+			w.posts.Schedule(booking.JobID)
+			// Notification is best-effort — don't fail the expiry if it doesn't work
+			w.notifyUserExpiredSafe(booking)
 		}
 
-		w.log.Info("Released expired booking",
-			logger.Any("booking_id", booking.ID),
-			logger.Any("user_id", booking.UserID),
-			logger.Any("job_id", booking.JobID),
-		)
+		released += len(batch)
+		if len(batch) < limit {
+			break
+		}
+	}
+
+	w.recordPass(candidates, released, failed)
+
+	fields := []logger.Field{
+		logger.Any("candidates", candidates),
+		logger.Any("expired", released),
+		logger.Any("errors", failed),
+		logger.Any("duration", time.Since(started).String()),
+	}
+	if candidates > 0 || failed > 0 {
+		w.log.Info("Expiry pass finished", fields...)
+	} else {
+		w.log.Debug("Expiry pass finished", fields...)
 	}
 }
 
-// processExpiredBooking releases a single expired booking
-func (w *ExpiryWorker) processExpiredBooking(booking *models.JobBooking) error {
-	// Use a dedicated context with timeout for the DB transaction
+// recordPass stores the outcome of a pass for Status
+func (w *ExpiryWorker) recordPass(candidates, released, failed int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.status.LastRunAt = time.Now()
+	w.status.LastCandidates = candidates
+	w.status.LastExpired = released
+	w.status.LastErrors = failed
+	w.status.PausedUntil = w.resumeAt
+	w.status.Passes++
+	w.status.Processed += uint64(released)
+	w.status.Errors += uint64(failed)
+}
+
+// backOff doubles the pause before the next pass, starting at one tick
+func (w *ExpiryWorker) backOff(err error) {
+	if w.backoff == 0 {
+		w.backoff = w.interval
+	} else {
+		w.backoff = min(w.backoff*2, expiryMaxBackoff)
+	}
+	w.resumeAt = time.Now().Add(w.backoff)
+
+	w.log.Error("Expiry batch failed, backing off",
+		logger.Error(err),
+		logger.Any("backoff", w.backoff.String()),
+	)
+}
+
+// releaseBatch claims up to limit expired bookings in one transaction, marks them
+// expired and frees their slots. The claimed rows stay locked until commit.
+// It also returns how many bookings were claimed, which a failed batch rolls back.
+func (w *ExpiryWorker) releaseBatch(limit int) ([]*models.JobBooking, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), expiryDBTimeout)
 	defer cancel()
 
 	// Start transaction
 	tx, err := w.storage.Transaction().Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("begin tx: %w", err)
+		return nil, 0, fmt.Errorf("begin tx: %w", err)
 	}
 
 	// Always rollback on failure — calling Rollback after Commit is a harmless no-op in pgx.
-	// The previous code had a bug: commit failure skipped rollback, leaking the connection.
 	defer func() {
 		if rbErr := w.storage.Transaction().Rollback(ctx, tx); rbErr != nil {
 			// Ignore "tx is closed" errors (expected after successful commit)
-			w.log.Debug("Rollback after processExpiredBooking (expected if committed)",
+			w.log.Debug("Rollback after releaseBatch (expected if committed)",
 				logger.Error(rbErr))
 		}
 	}()
 
-	// Mark booking as expired
-	if err := w.storage.Booking().MarkAsExpired(ctx, tx, booking.ID); err != nil {
-		return fmt.Errorf("mark expired: %w", err)
+	batch, err := w.storage.Booking().GetExpiredBookings(ctx, tx, w.clock.Now(), limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("claim expired bookings: %w", err)
+	}
+	if len(batch) == 0 {
+		return nil, 0, nil
 	}
 
-	// Release the reserved slot (decrement reserved_slots)
-	if err := w.storage.Job().DecrementReservedSlots(ctx, tx, booking.JobID); err != nil {
-		return fmt.Errorf("decrement slots: %w", err)
+	for _, booking := range batch {
+		if err := transitionBooking(ctx, w.storage, tx, booking.ID, booking.Status, models.BookingStatusExpired, 0, ""); err != nil {
+			return nil, len(batch), fmt.Errorf("expire booking %d: %w", booking.ID, err)
+		}
+
+		// Mark booking as expired
+		if err := w.storage.Booking().MarkAsExpired(ctx, tx, booking.ID); err != nil {
+			return nil, len(batch), fmt.Errorf("mark booking %d expired: %w", booking.ID, err)
+		}
+
+		// Release the reserved slot (decrement reserved_slots)
+		if err := w.storage.Job().DecrementReservedSlots(ctx, tx, booking.JobID); err != nil {
+			return nil, len(batch), fmt.Errorf("decrement slots for booking %d: %w", booking.ID, err)
+		}
 	}
 
 	// Commit transaction
 	if err := w.storage.Transaction().Commit(ctx, tx); err != nil {
-		return fmt.Errorf("commit: %w", err)
+		return nil, len(batch), fmt.Errorf("commit: %w", err)
 	}
 
-	// Notification is best-effort — don't fail the expiry if it doesn't work
-	w.notifyUserExpiredSafe(booking)
-
-	return nil
+	return batch, len(batch), nil
 }
 
 // notifyUserExpiredSafe wraps notifyUserExpired with a timeout so a hung
@@ -177,6 +295,8 @@ func (w *ExpiryWorker) notifyUserExpiredSafe(booking *models.JobBooking) {
 			}
 		}()
 		w.notifyUserExpired(booking)
+		w.refreshStatusMessage(booking)
+		w.notifyAdminsExpired(booking)
 	}()
 
 	select {
@@ -202,19 +322,24 @@ func (w *ExpiryWorker) notifyUserExpired(booking *models.JobBooking) {
 		return
 	}
 
+	reason := "3 daqiqa ichida to'lov qilmadingiz"
+	if booking.Status == models.BookingStatusPaymentRejectedRetryable {
+		reason = "belgilangan vaqt ichida to'lov chekini qayta yubormadingiz"
+	}
+
 	// Try to delete or edit the original payment instruction message
 	if booking.PaymentInstructionMsgID != 0 {
 		expiredMsg := fmt.Sprintf(`
 ⏰ <b>VAQT TUGADI</b>
 
-Sizning band qilgan joyingiz muddati tugadi, chunki 3 daqiqa ichida to'lov qilmadingiz.
+Sizning band qilgan joyingiz muddati tugadi, chunki %s.
 
-📋 <b>Ish:</b> №%d
+📋 <b>Ish:</b> №%s
 💰 %s
 📅 %s
 
 Yana yozilish uchun kanal orqali ishga qaytadan o'tishingiz mumkin.
-`, job.OrderNumber, job.Salary, job.WorkDate)
+`, reason, messages.JobNumber(job), job.Salary, job.WorkDate)
 
 		msg := &tele.StoredMessage{
 			MessageID: strconv.FormatInt(booking.PaymentInstructionMsgID, 10),
@@ -245,14 +370,14 @@ Yana yozilish uchun kanal orqali ishga qaytadan o'tishingiz mumkin.
 		msg := fmt.Sprintf(`
 ⏰ <b>VAQT TUGADI</b>
 
-Sizning №%d raqamli ishga band qilgan joyingiz muddati tugadi, chunki 3 daqiqa ichida to'lov qilmadingiz.
+Sizning №%s raqamli ishga band qilgan joyingiz muddati tugadi, chunki %s.
 
 📋 <b>Ish:</b>
 💰 %s
 📅 %s
 
 Yana yozilish uchun kanal orqali ishga qaytadan o'tishingiz mumkin.
-`, job.OrderNumber, job.Salary, job.WorkDate)
+`, messages.JobNumber(job), reason, job.Salary, job.WorkDate)
 
 		recipient := &tele.User{ID: booking.UserID}
 		if _, err := w.bot.Send(recipient, msg, tele.ModeHTML); err != nil {
@@ -264,3 +389,53 @@ Yana yozilish uchun kanal orqali ishga qaytadan o'tishingiz mumkin.
 		}
 	}
 }
+
+// refreshStatusMessage marks the booking expired on the user's status message (BOT_BOOKING_TRACKER)
+func (w *ExpiryWorker) refreshStatusMessage(booking *models.JobBooking) {
+	if !w.tracker.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), expiryDBTimeout)
+	defer cancel()
+
+	w.tracker.Refresh(ctx, booking.ID)
+}
+
+// notifyAdminsExpired tells admins who enabled expiration notifications that a booking was released
+func (w *ExpiryWorker) notifyAdminsExpired(booking *models.JobBooking) {
+	ctx, cancel := context.WithTimeout(context.Background(), expiryDBTimeout)
+	defer cancel()
+
+	job, err := w.storage.Job().GetByID(ctx, booking.JobID)
+	if err != nil {
+		w.log.Error("Failed to get job for admin expiry notification", logger.Error(err))
+		return
+	}
+
+	msg := fmt.Sprintf(`⏰ <b>BRON MUDDATI TUGADI</b>
+
+📋 <b>Ish:</b> №%s
+🆔 <b>Foydalanuvchi ID:</b> <code>%d</code>
+📋 <b>Booking ID:</b> #%d
+
+👥 Bo'sh joylar: %d`, messages.JobNumber(job), booking.UserID, booking.ID, job.AvailableSlots())
+
+	for _, adminID := range w.settings.AdminIDs(ctx) {
+		prefs, err := w.storage.AdminPrefs().Get(ctx, adminID)
+		if err != nil {
+			w.log.Error("Failed to get admin notification prefs", logger.Error(err), logger.Any("admin_id", adminID))
+			continue
+		}
+		if !prefs.IsEnabled(models.NotifyExpirations) {
+			continue
+		}
+
+		if _, err := w.bot.Send(&tele.User{ID: adminID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send admin expiry notification",
+				logger.Error(err),
+				logger.Any("admin_id", adminID),
+				logger.Any("booking_id", booking.ID),
+			)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// feedbackTimeout is the max time for one feedback round.
+	feedbackTimeout = time.Minute
+
+	// feedbackBatchSize limits prompts handled per round.
+	feedbackBatchSize = 50
+
+	// feedbackLookback bounds how old a confirmation can be to still get a prompt.
+	feedbackLookback = 30 * 24 * time.Hour
+
+	// Prompts are only sent during the day (local time).
+	feedbackFromHour = 9
+	feedbackToHour   = 21
+)
+
+// FeedbackWorker asks confirmed workers about the job once its work date has passed
+type FeedbackWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewFeedbackWorker creates a new worker feedback prompter
+func NewFeedbackWorker(storage storage.StorageI, log logger.LoggerI, bot BotAPI) *FeedbackWorker {
+	return &FeedbackWorker{
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		interval: 15 * time.Minute,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the feedback worker background process
+func (w *FeedbackWorker) Start() {
+	w.log.Info("Feedback worker started", logger.Any("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Feedback worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the feedback worker
+func (w *FeedbackWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *FeedbackWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in feedback worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process sends prompts for confirmed bookings whose work date is over
+func (w *FeedbackWorker) process() {
+	now := config.NowLocal()
+	if now.Hour() < feedbackFromHour || now.Hour() >= feedbackToHour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), feedbackTimeout)
+	defer cancel()
+
+	bookings, err := w.storage.JobFeedback().GetBookingsAwaitingPrompt(ctx, now.Add(-feedbackLookback), feedbackBatchSize)
+	if err != nil {
+		w.log.Error("Failed to get bookings awaiting feedback", logger.Error(err))
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	jobs := make(map[int64]*models.Job)
+
+	for _, booking := range bookings {
+		job, ok := jobs[booking.JobID]
+		if !ok {
+			job, err = w.storage.Job().GetByID(ctx, booking.JobID)
+			if err != nil {
+				w.log.Error("Failed to get job for feedback", logger.Error(err), logger.Any("job_id", booking.JobID))
+				continue
+			}
+			jobs[booking.JobID] = job
+		}
+
+		if !workIsOver(job, today) {
+			continue
+		}
+
+		w.prompt(ctx, booking, job)
+	}
+}
+
+// workIsOver reports whether the job's work date is before today.
+// Free-text dates that can't be parsed fall back to the job being marked completed.
+func workIsOver(job *models.Job, today time.Time) bool {
+	if job.Status == models.JobStatusCancelled {
+		return false
+	}
+	workDate, ok := helper.ParseWorkDate(job.WorkDate, job.CreatedAt.In(config.Timezone))
+	if !ok {
+		return job.Status == models.JobStatusCompleted
+	}
+	return workDate.Before(today)
+}
+
+// prompt records the feedback row first, so a worker who blocked the bot is not retried forever
+func (w *FeedbackWorker) prompt(ctx context.Context, booking *models.JobBooking, job *models.Job) {
+	feedback := &models.JobFeedback{
+		BookingID:  booking.ID,
+		JobID:      job.ID,
+		UserID:     booking.UserID,
+		EmployerID: job.EmployerID,
+	}
+	if err := w.storage.JobFeedback().Create(ctx, feedback); err != nil {
+		if !errors.Is(err, storage.ErrAlreadyExists) {
+			w.log.Error("Failed to create job feedback", logger.Error(err), logger.Any("booking_id", booking.ID))
+		}
+		return
+	}
+
+	_, err := w.bot.Send(&tele.User{ID: booking.UserID}, messages.FormatFeedbackPrompt(job),
+		keyboards.FeedbackPayKeyboard(booking.ID), tele.ModeHTML)
+	if err != nil {
+		w.log.Error("Failed to send feedback prompt",
+			logger.Error(err),
+			logger.Any("user_id", booking.UserID),
+			logger.Any("booking_id", booking.ID),
+		)
+		return
+	}
+
+	w.log.Info("Feedback prompt sent",
+		logger.Any("booking_id", booking.ID),
+		logger.Any("job_id", job.ID),
+	)
+}
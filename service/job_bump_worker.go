@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/helper"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// jobBumpTimeout is the max time for one repost round.
+	jobBumpTimeout = time.Minute
+
+	// Reposts are only made during the day (local time); a night post is buried by morning.
+	jobBumpFromHour = 7
+	jobBumpToHour   = 22
+)
+
+// JobBumpWorker reposts published jobs that still have free places shortly before work starts.
+//
+// The repost replies to the original channel post, so readers get back to the full details
+// with one tap. A job is reposted at most BOT_BUMP_MAX times, BOT_BUMP_INTERVAL apart; each
+// repost deletes the previous one so the channel keeps a single reminder per job.
+type JobBumpWorker struct {
+	cfg      *config.Config
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewJobBumpWorker creates a new job repost worker
+func NewJobBumpWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI) *JobBumpWorker {
+	return &JobBumpWorker{
+		cfg:      cfg,
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		interval: 10 * time.Minute,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the job repost worker background process
+func (w *JobBumpWorker) Start() {
+	if w.cfg.Bot.BumpBefore <= 0 {
+		w.log.Info("Job bump worker disabled (BOT_BUMP_BEFORE not set)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Job bump worker started",
+		logger.Any("before", w.cfg.Bot.BumpBefore.String()),
+		logger.Any("max", w.cfg.Bot.BumpMax),
+		logger.Any("interval", w.cfg.Bot.BumpInterval.String()),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Job bump worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the job repost worker
+func (w *JobBumpWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *JobBumpWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in job bump worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process reposts every published ACTIVE job with free places whose work starts within BOT_BUMP_BEFORE
+func (w *JobBumpWorker) process() {
+	now := config.NowLocal()
+	if now.Hour() < jobBumpFromHour || now.Hour() >= jobBumpToHour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobBumpTimeout)
+	defer cancel()
+
+	status := models.JobStatusActive
+	jobs, err := w.storage.Job().GetAll(ctx, &status)
+	if err != nil {
+		w.log.Error("Failed to get active jobs for bumping", logger.Error(err))
+		return
+	}
+
+	for _, job := range jobs {
+		if job.ChannelMessageID == 0 || job.AvailableSlots() <= 0 {
+			continue
+		}
+		start, ok := workStart(job)
+		if !ok || now.After(start) || start.Sub(now) > w.cfg.Bot.BumpBefore {
+			continue
+		}
+
+		bumps, err := w.storage.Job().GetBumps(ctx, job.ID)
+		if err != nil {
+			w.log.Error("Failed to get job bumps", logger.Error(err), logger.Any("job_id", job.ID))
+			continue
+		}
+		if len(bumps) >= w.cfg.Bot.BumpMax {
+			continue
+		}
+		var last *models.JobBump
+		if len(bumps) > 0 {
+			last = bumps[len(bumps)-1]
+			if now.Sub(last.CreatedAt) < w.cfg.Bot.BumpInterval {
+				continue
+			}
+		}
+
+		w.bump(ctx, job, last, len(bumps)+1)
+	}
+}
+
+// workStart is when the job's work begins: the parsed work date at the start of the work time,
+// or midnight of the work date when the time can't be read
+func workStart(job *models.Job) (time.Time, bool) {
+	workDate, ok := helper.ParseWorkDate(job.WorkDate, job.CreatedAt.In(config.Timezone))
+	if !ok {
+		return time.Time{}, false
+	}
+	offset, _ := helper.ParseWorkStart(job.WorkTime)
+	return workDate.Add(offset), true
+}
+
+// bump posts the reminder as a reply to the job's channel post and replaces the previous one.
+// While the job's captions are tested the reposts alternate them, starting with B.
+func (w *JobBumpWorker) bump(ctx context.Context, job *models.Job, last *models.JobBump, n int) {
+	variant := job.BumpCaptionVariant(n)
+	channel := &tele.Chat{ID: w.cfg.Bot.ChannelID}
+	opts := &tele.SendOptions{
+		ReplyTo:     &tele.Message{ID: int(job.ChannelMessageID)},
+		ReplyMarkup: keyboards.JobCaptionSignupKeyboard(job, variant, w.cfg.Bot.Username),
+		ParseMode:   tele.ModeHTML,
+	}
+	msg, err := w.bot.Send(channel, messages.FormatJobBump(job, variant), opts)
+	if err != nil {
+		w.log.Error("Failed to repost job to channel", logger.Error(err), logger.Any("job_id", job.ID))
+		return
+	}
+
+	if err := w.storage.Job().AddBump(ctx, &models.JobBump{JobID: job.ID, MessageID: int64(msg.ID)}); err != nil {
+		w.log.Error("Failed to record job bump", logger.Error(err), logger.Any("job_id", job.ID))
+	}
+
+	if last != nil {
+		if err := w.bot.Delete(&tele.Message{ID: int(last.MessageID), Chat: channel}); err != nil {
+			w.log.Warn("Failed to delete previous job repost", logger.Error(err), logger.Any("job_id", job.ID))
+		}
+	}
+
+	w.log.Info("Job reposted to channel",
+		logger.Any("job_id", job.ID),
+		logger.Any("bump", n),
+		logger.String("caption", string(variant)),
+		logger.Int("available_slots", job.AvailableSlots()),
+	)
+}
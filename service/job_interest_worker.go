@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// jobInterestTimeout is the max time for one nudge round.
+	jobInterestTimeout = time.Minute
+
+	// jobInterestBatchSize limits interests handled per round.
+	jobInterestBatchSize = 50
+
+	// jobInterestNudgeDelay is how long after opening the signup link the reminder goes out.
+	jobInterestNudgeDelay = time.Hour
+
+	// jobInterestLookback bounds how old an interest can be to still get a reminder.
+	jobInterestLookback = 24 * time.Hour
+
+	// Reminders are only sent during the day (local time).
+	jobInterestFromHour = 9
+	jobInterestToHour   = 21
+)
+
+// JobInterestWorker reminds users who opened a job's signup link but never finished registering
+type JobInterestWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewJobInterestWorker creates a new finish-registration reminder worker
+func NewJobInterestWorker(storage storage.StorageI, log logger.LoggerI, bot BotAPI) *JobInterestWorker {
+	return &JobInterestWorker{
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		interval: 5 * time.Minute,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the job interest worker background process
+func (w *JobInterestWorker) Start() {
+	w.log.Info("Job interest worker started", logger.Any("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Job interest worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the job interest worker
+func (w *JobInterestWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *JobInterestWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in job interest worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process reminds each unregistered user once, about the first job they showed interest in
+func (w *JobInterestWorker) process() {
+	now := config.NowLocal()
+	if now.Hour() < jobInterestFromHour || now.Hour() >= jobInterestToHour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobInterestTimeout)
+	defer cancel()
+
+	due, err := w.storage.JobInterest().GetDueNudges(ctx, now.Add(-jobInterestLookback), now.Add(-jobInterestNudgeDelay), jobInterestBatchSize)
+	if err != nil {
+		w.log.Error("Failed to get due job interest nudges", logger.Error(err))
+		return
+	}
+
+	nudged := make(map[int64]bool)
+	for _, interest := range due {
+		if nudged[interest.UserID] {
+			continue
+		}
+		nudged[interest.UserID] = true
+
+		// Marked first, so a user who blocked the bot is not retried forever
+		if err := w.storage.JobInterest().MarkNudged(ctx, interest.UserID); err != nil {
+			w.log.Error("Failed to mark job interest nudged", logger.Error(err), logger.Any("user_id", interest.UserID))
+			continue
+		}
+
+		job, err := w.storage.Job().GetByID(ctx, interest.JobID)
+		if err != nil {
+			w.log.Error("Failed to get job for interest nudge", logger.Error(err), logger.Any("job_id", interest.JobID))
+			continue
+		}
+		// No point inviting them to a job that no longer takes bookings
+		if !job.IsActive() {
+			continue
+		}
+
+		_, err = w.bot.Send(&tele.User{ID: interest.UserID}, messages.FormatJobInterestNudge(job),
+			keyboards.FinishRegistrationKeyboard(job.ID), tele.ModeHTML)
+		if err != nil {
+			w.log.Error("Failed to send job interest nudge",
+				logger.Error(err),
+				logger.Any("user_id", interest.UserID),
+				logger.Any("job_id", job.ID),
+			)
+			continue
+		}
+
+		w.log.Info("Job interest nudge sent",
+			logger.Any("user_id", interest.UserID),
+			logger.Any("job_id", job.ID),
+		)
+	}
+}
@@ -0,0 +1,153 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that BackupServiceMock does implement service.BackupService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.BackupService = &BackupServiceMock{}
+
+// BackupServiceMock is a mock implementation of service.BackupService.
+type BackupServiceMock struct {
+	// EnabledFunc mocks the Enabled method.
+	EnabledFunc func() bool
+
+	// RunFunc mocks the Run method.
+	RunFunc func(ctx context.Context, adminID int64) (*models.BackupRun, error)
+
+	// StatusFunc mocks the Status method.
+	StatusFunc func(ctx context.Context) (*models.BackupStatus, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Enabled holds details about calls to the Enabled method.
+		Enabled []struct {
+		}
+		// Run holds details about calls to the Run method.
+		Run []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// Status holds details about calls to the Status method.
+		Status []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockEnabled sync.RWMutex
+	lockRun     sync.RWMutex
+	lockStatus  sync.RWMutex
+}
+
+// Enabled calls EnabledFunc.
+func (mock *BackupServiceMock) Enabled() bool {
+	if mock.EnabledFunc == nil {
+		panic("BackupServiceMock.EnabledFunc: method is nil but BackupService.Enabled was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockEnabled.Lock()
+	mock.calls.Enabled = append(mock.calls.Enabled, callInfo)
+	mock.lockEnabled.Unlock()
+	return mock.EnabledFunc()
+}
+
+// EnabledCalls gets all the calls that were made to Enabled.
+// Check the length with:
+//
+//	len(mockedBackupService.EnabledCalls())
+func (mock *BackupServiceMock) EnabledCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockEnabled.RLock()
+	calls = mock.calls.Enabled
+	mock.lockEnabled.RUnlock()
+	return calls
+}
+
+// Run calls RunFunc.
+func (mock *BackupServiceMock) Run(ctx context.Context, adminID int64) (*models.BackupRun, error) {
+	if mock.RunFunc == nil {
+		panic("BackupServiceMock.RunFunc: method is nil but BackupService.Run was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:     ctx,
+		AdminID: adminID,
+	}
+	mock.lockRun.Lock()
+	mock.calls.Run = append(mock.calls.Run, callInfo)
+	mock.lockRun.Unlock()
+	return mock.RunFunc(ctx, adminID)
+}
+
+// RunCalls gets all the calls that were made to Run.
+// Check the length with:
+//
+//	len(mockedBackupService.RunCalls())
+func (mock *BackupServiceMock) RunCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockRun.RLock()
+	calls = mock.calls.Run
+	mock.lockRun.RUnlock()
+	return calls
+}
+
+// Status calls StatusFunc.
+func (mock *BackupServiceMock) Status(ctx context.Context) (*models.BackupStatus, error) {
+	if mock.StatusFunc == nil {
+		panic("BackupServiceMock.StatusFunc: method is nil but BackupService.Status was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockStatus.Lock()
+	mock.calls.Status = append(mock.calls.Status, callInfo)
+	mock.lockStatus.Unlock()
+	return mock.StatusFunc(ctx)
+}
+
+// StatusCalls gets all the calls that were made to Status.
+// Check the length with:
+//
+//	len(mockedBackupService.StatusCalls())
+func (mock *BackupServiceMock) StatusCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockStatus.RLock()
+	calls = mock.calls.Status
+	mock.lockStatus.RUnlock()
+	return calls
+}
@@ -0,0 +1,447 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that BookingServiceMock does implement service.BookingService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.BookingService = &BookingServiceMock{}
+
+// BookingServiceMock is a mock implementation of service.BookingService.
+type BookingServiceMock struct {
+	// CancelJobFunc mocks the CancelJob method.
+	CancelJobFunc func(ctx context.Context, jobID int64, adminID int64) (*models.JobCancellation, error)
+
+	// CheckIdempotencyFunc mocks the CheckIdempotency method.
+	CheckIdempotencyFunc func(ctx context.Context, userID int64, jobID int64) (*models.JobBooking, error)
+
+	// ConfirmBookingFunc mocks the ConfirmBooking method.
+	ConfirmBookingFunc func(ctx context.Context, userID int64, jobID int64) (*models.JobBooking, error)
+
+	// CreateManualBookingFunc mocks the CreateManualBooking method.
+	CreateManualBookingFunc func(ctx context.Context, jobID int64, userID int64, adminID int64) (*models.JobBooking, *models.Job, error)
+
+	// ExpireBookingFunc mocks the ExpireBooking method.
+	ExpireBookingFunc func(ctx context.Context, booking *models.JobBooking) error
+
+	// GetBookingWithStatusFunc mocks the GetBookingWithStatus method.
+	GetBookingWithStatusFunc func(ctx context.Context, userID int64, status models.BookingStatus) (*models.JobBooking, error)
+
+	// IssueVoucherFunc mocks the IssueVoucher method.
+	IssueVoucherFunc func(ctx context.Context, bookingID int64) (*models.BookingVoucher, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CancelJob holds details about calls to the CancelJob method.
+		CancelJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// CheckIdempotency holds details about calls to the CheckIdempotency method.
+		CheckIdempotency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// JobID is the jobID argument value.
+			JobID int64
+		}
+		// ConfirmBooking holds details about calls to the ConfirmBooking method.
+		ConfirmBooking []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// JobID is the jobID argument value.
+			JobID int64
+		}
+		// CreateManualBooking holds details about calls to the CreateManualBooking method.
+		CreateManualBooking []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID int64
+			// UserID is the userID argument value.
+			UserID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// ExpireBooking holds details about calls to the ExpireBooking method.
+		ExpireBooking []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Booking is the booking argument value.
+			Booking *models.JobBooking
+		}
+		// GetBookingWithStatus holds details about calls to the GetBookingWithStatus method.
+		GetBookingWithStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Status is the status argument value.
+			Status models.BookingStatus
+		}
+		// IssueVoucher holds details about calls to the IssueVoucher method.
+		IssueVoucher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+		}
+	}
+	lockCancelJob            sync.RWMutex
+	lockCheckIdempotency     sync.RWMutex
+	lockConfirmBooking       sync.RWMutex
+	lockCreateManualBooking  sync.RWMutex
+	lockExpireBooking        sync.RWMutex
+	lockGetBookingWithStatus sync.RWMutex
+	lockIssueVoucher         sync.RWMutex
+}
+
+// CancelJob calls CancelJobFunc.
+func (mock *BookingServiceMock) CancelJob(ctx context.Context, jobID int64, adminID int64) (*models.JobCancellation, error) {
+	if mock.CancelJobFunc == nil {
+		panic("BookingServiceMock.CancelJobFunc: method is nil but BookingService.CancelJob was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// JobID is the jobID argument value.
+		JobID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:     ctx,
+		JobID:   jobID,
+		AdminID: adminID,
+	}
+	mock.lockCancelJob.Lock()
+	mock.calls.CancelJob = append(mock.calls.CancelJob, callInfo)
+	mock.lockCancelJob.Unlock()
+	return mock.CancelJobFunc(ctx, jobID, adminID)
+}
+
+// CancelJobCalls gets all the calls that were made to CancelJob.
+// Check the length with:
+//
+//	len(mockedBookingService.CancelJobCalls())
+func (mock *BookingServiceMock) CancelJobCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// JobID is the jobID argument value.
+	JobID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// JobID is the jobID argument value.
+		JobID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockCancelJob.RLock()
+	calls = mock.calls.CancelJob
+	mock.lockCancelJob.RUnlock()
+	return calls
+}
+
+// CheckIdempotency calls CheckIdempotencyFunc.
+func (mock *BookingServiceMock) CheckIdempotency(ctx context.Context, userID int64, jobID int64) (*models.JobBooking, error) {
+	if mock.CheckIdempotencyFunc == nil {
+		panic("BookingServiceMock.CheckIdempotencyFunc: method is nil but BookingService.CheckIdempotency was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		JobID:  jobID,
+	}
+	mock.lockCheckIdempotency.Lock()
+	mock.calls.CheckIdempotency = append(mock.calls.CheckIdempotency, callInfo)
+	mock.lockCheckIdempotency.Unlock()
+	return mock.CheckIdempotencyFunc(ctx, userID, jobID)
+}
+
+// CheckIdempotencyCalls gets all the calls that were made to CheckIdempotency.
+// Check the length with:
+//
+//	len(mockedBookingService.CheckIdempotencyCalls())
+func (mock *BookingServiceMock) CheckIdempotencyCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// JobID is the jobID argument value.
+	JobID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}
+	mock.lockCheckIdempotency.RLock()
+	calls = mock.calls.CheckIdempotency
+	mock.lockCheckIdempotency.RUnlock()
+	return calls
+}
+
+// ConfirmBooking calls ConfirmBookingFunc.
+func (mock *BookingServiceMock) ConfirmBooking(ctx context.Context, userID int64, jobID int64) (*models.JobBooking, error) {
+	if mock.ConfirmBookingFunc == nil {
+		panic("BookingServiceMock.ConfirmBookingFunc: method is nil but BookingService.ConfirmBooking was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		JobID:  jobID,
+	}
+	mock.lockConfirmBooking.Lock()
+	mock.calls.ConfirmBooking = append(mock.calls.ConfirmBooking, callInfo)
+	mock.lockConfirmBooking.Unlock()
+	return mock.ConfirmBookingFunc(ctx, userID, jobID)
+}
+
+// ConfirmBookingCalls gets all the calls that were made to ConfirmBooking.
+// Check the length with:
+//
+//	len(mockedBookingService.ConfirmBookingCalls())
+func (mock *BookingServiceMock) ConfirmBookingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// JobID is the jobID argument value.
+	JobID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}
+	mock.lockConfirmBooking.RLock()
+	calls = mock.calls.ConfirmBooking
+	mock.lockConfirmBooking.RUnlock()
+	return calls
+}
+
+// CreateManualBooking calls CreateManualBookingFunc.
+func (mock *BookingServiceMock) CreateManualBooking(ctx context.Context, jobID int64, userID int64, adminID int64) (*models.JobBooking, *models.Job, error) {
+	if mock.CreateManualBookingFunc == nil {
+		panic("BookingServiceMock.CreateManualBookingFunc: method is nil but BookingService.CreateManualBooking was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// JobID is the jobID argument value.
+		JobID int64
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:     ctx,
+		JobID:   jobID,
+		UserID:  userID,
+		AdminID: adminID,
+	}
+	mock.lockCreateManualBooking.Lock()
+	mock.calls.CreateManualBooking = append(mock.calls.CreateManualBooking, callInfo)
+	mock.lockCreateManualBooking.Unlock()
+	return mock.CreateManualBookingFunc(ctx, jobID, userID, adminID)
+}
+
+// CreateManualBookingCalls gets all the calls that were made to CreateManualBooking.
+// Check the length with:
+//
+//	len(mockedBookingService.CreateManualBookingCalls())
+func (mock *BookingServiceMock) CreateManualBookingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// JobID is the jobID argument value.
+	JobID int64
+	// UserID is the userID argument value.
+	UserID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// JobID is the jobID argument value.
+		JobID int64
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockCreateManualBooking.RLock()
+	calls = mock.calls.CreateManualBooking
+	mock.lockCreateManualBooking.RUnlock()
+	return calls
+}
+
+// ExpireBooking calls ExpireBookingFunc.
+func (mock *BookingServiceMock) ExpireBooking(ctx context.Context, booking *models.JobBooking) error {
+	if mock.ExpireBookingFunc == nil {
+		panic("BookingServiceMock.ExpireBookingFunc: method is nil but BookingService.ExpireBooking was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Booking is the booking argument value.
+		Booking *models.JobBooking
+	}{
+		Ctx:     ctx,
+		Booking: booking,
+	}
+	mock.lockExpireBooking.Lock()
+	mock.calls.ExpireBooking = append(mock.calls.ExpireBooking, callInfo)
+	mock.lockExpireBooking.Unlock()
+	return mock.ExpireBookingFunc(ctx, booking)
+}
+
+// ExpireBookingCalls gets all the calls that were made to ExpireBooking.
+// Check the length with:
+//
+//	len(mockedBookingService.ExpireBookingCalls())
+func (mock *BookingServiceMock) ExpireBookingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Booking is the booking argument value.
+	Booking *models.JobBooking
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Booking is the booking argument value.
+		Booking *models.JobBooking
+	}
+	mock.lockExpireBooking.RLock()
+	calls = mock.calls.ExpireBooking
+	mock.lockExpireBooking.RUnlock()
+	return calls
+}
+
+// GetBookingWithStatus calls GetBookingWithStatusFunc.
+func (mock *BookingServiceMock) GetBookingWithStatus(ctx context.Context, userID int64, status models.BookingStatus) (*models.JobBooking, error) {
+	if mock.GetBookingWithStatusFunc == nil {
+		panic("BookingServiceMock.GetBookingWithStatusFunc: method is nil but BookingService.GetBookingWithStatus was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Status is the status argument value.
+		Status models.BookingStatus
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockGetBookingWithStatus.Lock()
+	mock.calls.GetBookingWithStatus = append(mock.calls.GetBookingWithStatus, callInfo)
+	mock.lockGetBookingWithStatus.Unlock()
+	return mock.GetBookingWithStatusFunc(ctx, userID, status)
+}
+
+// GetBookingWithStatusCalls gets all the calls that were made to GetBookingWithStatus.
+// Check the length with:
+//
+//	len(mockedBookingService.GetBookingWithStatusCalls())
+func (mock *BookingServiceMock) GetBookingWithStatusCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Status is the status argument value.
+	Status models.BookingStatus
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Status is the status argument value.
+		Status models.BookingStatus
+	}
+	mock.lockGetBookingWithStatus.RLock()
+	calls = mock.calls.GetBookingWithStatus
+	mock.lockGetBookingWithStatus.RUnlock()
+	return calls
+}
+
+// IssueVoucher calls IssueVoucherFunc.
+func (mock *BookingServiceMock) IssueVoucher(ctx context.Context, bookingID int64) (*models.BookingVoucher, error) {
+	if mock.IssueVoucherFunc == nil {
+		panic("BookingServiceMock.IssueVoucherFunc: method is nil but BookingService.IssueVoucher was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+	}
+	mock.lockIssueVoucher.Lock()
+	mock.calls.IssueVoucher = append(mock.calls.IssueVoucher, callInfo)
+	mock.lockIssueVoucher.Unlock()
+	return mock.IssueVoucherFunc(ctx, bookingID)
+}
+
+// IssueVoucherCalls gets all the calls that were made to IssueVoucher.
+// Check the length with:
+//
+//	len(mockedBookingService.IssueVoucherCalls())
+func (mock *BookingServiceMock) IssueVoucherCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}
+	mock.lockIssueVoucher.RLock()
+	calls = mock.calls.IssueVoucher
+	mock.lockIssueVoucher.RUnlock()
+	return calls
+}
@@ -0,0 +1,161 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that BookingStatusServiceMock does implement service.BookingStatusService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.BookingStatusService = &BookingStatusServiceMock{}
+
+// BookingStatusServiceMock is a mock implementation of service.BookingStatusService.
+type BookingStatusServiceMock struct {
+	// EnabledFunc mocks the Enabled method.
+	EnabledFunc func() bool
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, bookingID int64)
+
+	// RepostFunc mocks the Repost method.
+	RepostFunc func(ctx context.Context, bookingID int64)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Enabled holds details about calls to the Enabled method.
+		Enabled []struct {
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+		}
+		// Repost holds details about calls to the Repost method.
+		Repost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+		}
+	}
+	lockEnabled sync.RWMutex
+	lockRefresh sync.RWMutex
+	lockRepost  sync.RWMutex
+}
+
+// Enabled calls EnabledFunc.
+func (mock *BookingStatusServiceMock) Enabled() bool {
+	if mock.EnabledFunc == nil {
+		panic("BookingStatusServiceMock.EnabledFunc: method is nil but BookingStatusService.Enabled was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockEnabled.Lock()
+	mock.calls.Enabled = append(mock.calls.Enabled, callInfo)
+	mock.lockEnabled.Unlock()
+	return mock.EnabledFunc()
+}
+
+// EnabledCalls gets all the calls that were made to Enabled.
+// Check the length with:
+//
+//	len(mockedBookingStatusService.EnabledCalls())
+func (mock *BookingStatusServiceMock) EnabledCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockEnabled.RLock()
+	calls = mock.calls.Enabled
+	mock.lockEnabled.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *BookingStatusServiceMock) Refresh(ctx context.Context, bookingID int64) {
+	if mock.RefreshFunc == nil {
+		panic("BookingStatusServiceMock.RefreshFunc: method is nil but BookingStatusService.Refresh was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	mock.RefreshFunc(ctx, bookingID)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedBookingStatusService.RefreshCalls())
+func (mock *BookingStatusServiceMock) RefreshCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// Repost calls RepostFunc.
+func (mock *BookingStatusServiceMock) Repost(ctx context.Context, bookingID int64) {
+	if mock.RepostFunc == nil {
+		panic("BookingStatusServiceMock.RepostFunc: method is nil but BookingStatusService.Repost was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+	}
+	mock.lockRepost.Lock()
+	mock.calls.Repost = append(mock.calls.Repost, callInfo)
+	mock.lockRepost.Unlock()
+	mock.RepostFunc(ctx, bookingID)
+}
+
+// RepostCalls gets all the calls that were made to Repost.
+// Check the length with:
+//
+//	len(mockedBookingStatusService.RepostCalls())
+func (mock *BookingStatusServiceMock) RepostCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+	}
+	mock.lockRepost.RLock()
+	calls = mock.calls.Repost
+	mock.lockRepost.RUnlock()
+	return calls
+}
@@ -0,0 +1,392 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+
+	tele "gopkg.in/telebot.v4"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that BotAPIMock does implement service.BotAPI.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.BotAPI = &BotAPIMock{}
+
+// BotAPIMock is a mock implementation of service.BotAPI.
+type BotAPIMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(msg tele.Editable) error
+
+	// DeleteCommandsFunc mocks the DeleteCommands method.
+	DeleteCommandsFunc func(opts ...interface{}) error
+
+	// EditFunc mocks the Edit method.
+	EditFunc func(msg tele.Editable, what interface{}, opts ...interface{}) (*tele.Message, error)
+
+	// EditCaptionFunc mocks the EditCaption method.
+	EditCaptionFunc func(msg tele.Editable, caption string, opts ...interface{}) (*tele.Message, error)
+
+	// PinFunc mocks the Pin method.
+	PinFunc func(msg tele.Editable, opts ...interface{}) error
+
+	// SendFunc mocks the Send method.
+	SendFunc func(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error)
+
+	// SetCommandsFunc mocks the SetCommands method.
+	SetCommandsFunc func(opts ...interface{}) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Msg is the msg argument value.
+			Msg tele.Editable
+		}
+		// DeleteCommands holds details about calls to the DeleteCommands method.
+		DeleteCommands []struct {
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+		// Edit holds details about calls to the Edit method.
+		Edit []struct {
+			// Msg is the msg argument value.
+			Msg tele.Editable
+			// What is the what argument value.
+			What interface{}
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+		// EditCaption holds details about calls to the EditCaption method.
+		EditCaption []struct {
+			// Msg is the msg argument value.
+			Msg tele.Editable
+			// Caption is the caption argument value.
+			Caption string
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+		// Pin holds details about calls to the Pin method.
+		Pin []struct {
+			// Msg is the msg argument value.
+			Msg tele.Editable
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// To is the to argument value.
+			To tele.Recipient
+			// What is the what argument value.
+			What interface{}
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+		// SetCommands holds details about calls to the SetCommands method.
+		SetCommands []struct {
+			// Opts is the opts argument value.
+			Opts []interface{}
+		}
+	}
+	lockDelete         sync.RWMutex
+	lockDeleteCommands sync.RWMutex
+	lockEdit           sync.RWMutex
+	lockEditCaption    sync.RWMutex
+	lockPin            sync.RWMutex
+	lockSend           sync.RWMutex
+	lockSetCommands    sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *BotAPIMock) Delete(msg tele.Editable) error {
+	if mock.DeleteFunc == nil {
+		panic("BotAPIMock.DeleteFunc: method is nil but BotAPI.Delete was just called")
+	}
+	callInfo := struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+	}{
+		Msg: msg,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(msg)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedBotAPI.DeleteCalls())
+func (mock *BotAPIMock) DeleteCalls() []struct {
+	// Msg is the msg argument value.
+	Msg tele.Editable
+} {
+	var calls []struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteCommands calls DeleteCommandsFunc.
+func (mock *BotAPIMock) DeleteCommands(opts ...interface{}) error {
+	if mock.DeleteCommandsFunc == nil {
+		panic("BotAPIMock.DeleteCommandsFunc: method is nil but BotAPI.DeleteCommands was just called")
+	}
+	callInfo := struct {
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		Opts: opts,
+	}
+	mock.lockDeleteCommands.Lock()
+	mock.calls.DeleteCommands = append(mock.calls.DeleteCommands, callInfo)
+	mock.lockDeleteCommands.Unlock()
+	return mock.DeleteCommandsFunc(opts...)
+}
+
+// DeleteCommandsCalls gets all the calls that were made to DeleteCommands.
+// Check the length with:
+//
+//	len(mockedBotAPI.DeleteCommandsCalls())
+func (mock *BotAPIMock) DeleteCommandsCalls() []struct {
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockDeleteCommands.RLock()
+	calls = mock.calls.DeleteCommands
+	mock.lockDeleteCommands.RUnlock()
+	return calls
+}
+
+// Edit calls EditFunc.
+func (mock *BotAPIMock) Edit(msg tele.Editable, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	if mock.EditFunc == nil {
+		panic("BotAPIMock.EditFunc: method is nil but BotAPI.Edit was just called")
+	}
+	callInfo := struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// What is the what argument value.
+		What interface{}
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		Msg:  msg,
+		What: what,
+		Opts: opts,
+	}
+	mock.lockEdit.Lock()
+	mock.calls.Edit = append(mock.calls.Edit, callInfo)
+	mock.lockEdit.Unlock()
+	return mock.EditFunc(msg, what, opts...)
+}
+
+// EditCalls gets all the calls that were made to Edit.
+// Check the length with:
+//
+//	len(mockedBotAPI.EditCalls())
+func (mock *BotAPIMock) EditCalls() []struct {
+	// Msg is the msg argument value.
+	Msg tele.Editable
+	// What is the what argument value.
+	What interface{}
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// What is the what argument value.
+		What interface{}
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockEdit.RLock()
+	calls = mock.calls.Edit
+	mock.lockEdit.RUnlock()
+	return calls
+}
+
+// EditCaption calls EditCaptionFunc.
+func (mock *BotAPIMock) EditCaption(msg tele.Editable, caption string, opts ...interface{}) (*tele.Message, error) {
+	if mock.EditCaptionFunc == nil {
+		panic("BotAPIMock.EditCaptionFunc: method is nil but BotAPI.EditCaption was just called")
+	}
+	callInfo := struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// Caption is the caption argument value.
+		Caption string
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		Msg:     msg,
+		Caption: caption,
+		Opts:    opts,
+	}
+	mock.lockEditCaption.Lock()
+	mock.calls.EditCaption = append(mock.calls.EditCaption, callInfo)
+	mock.lockEditCaption.Unlock()
+	return mock.EditCaptionFunc(msg, caption, opts...)
+}
+
+// EditCaptionCalls gets all the calls that were made to EditCaption.
+// Check the length with:
+//
+//	len(mockedBotAPI.EditCaptionCalls())
+func (mock *BotAPIMock) EditCaptionCalls() []struct {
+	// Msg is the msg argument value.
+	Msg tele.Editable
+	// Caption is the caption argument value.
+	Caption string
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// Caption is the caption argument value.
+		Caption string
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockEditCaption.RLock()
+	calls = mock.calls.EditCaption
+	mock.lockEditCaption.RUnlock()
+	return calls
+}
+
+// Pin calls PinFunc.
+func (mock *BotAPIMock) Pin(msg tele.Editable, opts ...interface{}) error {
+	if mock.PinFunc == nil {
+		panic("BotAPIMock.PinFunc: method is nil but BotAPI.Pin was just called")
+	}
+	callInfo := struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		Msg:  msg,
+		Opts: opts,
+	}
+	mock.lockPin.Lock()
+	mock.calls.Pin = append(mock.calls.Pin, callInfo)
+	mock.lockPin.Unlock()
+	return mock.PinFunc(msg, opts...)
+}
+
+// PinCalls gets all the calls that were made to Pin.
+// Check the length with:
+//
+//	len(mockedBotAPI.PinCalls())
+func (mock *BotAPIMock) PinCalls() []struct {
+	// Msg is the msg argument value.
+	Msg tele.Editable
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// Msg is the msg argument value.
+		Msg tele.Editable
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockPin.RLock()
+	calls = mock.calls.Pin
+	mock.lockPin.RUnlock()
+	return calls
+}
+
+// Send calls SendFunc.
+func (mock *BotAPIMock) Send(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	if mock.SendFunc == nil {
+		panic("BotAPIMock.SendFunc: method is nil but BotAPI.Send was just called")
+	}
+	callInfo := struct {
+		// To is the to argument value.
+		To tele.Recipient
+		// What is the what argument value.
+		What interface{}
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		To:   to,
+		What: what,
+		Opts: opts,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(to, what, opts...)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedBotAPI.SendCalls())
+func (mock *BotAPIMock) SendCalls() []struct {
+	// To is the to argument value.
+	To tele.Recipient
+	// What is the what argument value.
+	What interface{}
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// To is the to argument value.
+		To tele.Recipient
+		// What is the what argument value.
+		What interface{}
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
+
+// SetCommands calls SetCommandsFunc.
+func (mock *BotAPIMock) SetCommands(opts ...interface{}) error {
+	if mock.SetCommandsFunc == nil {
+		panic("BotAPIMock.SetCommandsFunc: method is nil but BotAPI.SetCommands was just called")
+	}
+	callInfo := struct {
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}{
+		Opts: opts,
+	}
+	mock.lockSetCommands.Lock()
+	mock.calls.SetCommands = append(mock.calls.SetCommands, callInfo)
+	mock.lockSetCommands.Unlock()
+	return mock.SetCommandsFunc(opts...)
+}
+
+// SetCommandsCalls gets all the calls that were made to SetCommands.
+// Check the length with:
+//
+//	len(mockedBotAPI.SetCommandsCalls())
+func (mock *BotAPIMock) SetCommandsCalls() []struct {
+	// Opts is the opts argument value.
+	Opts []interface{}
+} {
+	var calls []struct {
+		// Opts is the opts argument value.
+		Opts []interface{}
+	}
+	mock.lockSetCommands.RLock()
+	calls = mock.calls.SetCommands
+	mock.lockSetCommands.RUnlock()
+	return calls
+}
@@ -0,0 +1,133 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ChannelIndexServiceMock does implement service.ChannelIndexService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ChannelIndexService = &ChannelIndexServiceMock{}
+
+// ChannelIndexServiceMock is a mock implementation of service.ChannelIndexService.
+type ChannelIndexServiceMock struct {
+	// ChangedFunc mocks the Changed method.
+	ChangedFunc func() <-chan struct{}
+
+	// NotifyFunc mocks the Notify method.
+	NotifyFunc func()
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Changed holds details about calls to the Changed method.
+		Changed []struct {
+		}
+		// Notify holds details about calls to the Notify method.
+		Notify []struct {
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockChanged sync.RWMutex
+	lockNotify  sync.RWMutex
+	lockRefresh sync.RWMutex
+}
+
+// Changed calls ChangedFunc.
+func (mock *ChannelIndexServiceMock) Changed() <-chan struct{} {
+	if mock.ChangedFunc == nil {
+		panic("ChannelIndexServiceMock.ChangedFunc: method is nil but ChannelIndexService.Changed was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockChanged.Lock()
+	mock.calls.Changed = append(mock.calls.Changed, callInfo)
+	mock.lockChanged.Unlock()
+	return mock.ChangedFunc()
+}
+
+// ChangedCalls gets all the calls that were made to Changed.
+// Check the length with:
+//
+//	len(mockedChannelIndexService.ChangedCalls())
+func (mock *ChannelIndexServiceMock) ChangedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockChanged.RLock()
+	calls = mock.calls.Changed
+	mock.lockChanged.RUnlock()
+	return calls
+}
+
+// Notify calls NotifyFunc.
+func (mock *ChannelIndexServiceMock) Notify() {
+	if mock.NotifyFunc == nil {
+		panic("ChannelIndexServiceMock.NotifyFunc: method is nil but ChannelIndexService.Notify was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockNotify.Lock()
+	mock.calls.Notify = append(mock.calls.Notify, callInfo)
+	mock.lockNotify.Unlock()
+	mock.NotifyFunc()
+}
+
+// NotifyCalls gets all the calls that were made to Notify.
+// Check the length with:
+//
+//	len(mockedChannelIndexService.NotifyCalls())
+func (mock *ChannelIndexServiceMock) NotifyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNotify.RLock()
+	calls = mock.calls.Notify
+	mock.lockNotify.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *ChannelIndexServiceMock) Refresh(ctx context.Context) error {
+	if mock.RefreshFunc == nil {
+		panic("ChannelIndexServiceMock.RefreshFunc: method is nil but ChannelIndexService.Refresh was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	return mock.RefreshFunc(ctx)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedChannelIndexService.RefreshCalls())
+func (mock *ChannelIndexServiceMock) RefreshCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
@@ -0,0 +1,98 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ChannelPostServiceMock does implement service.ChannelPostService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ChannelPostService = &ChannelPostServiceMock{}
+
+// ChannelPostServiceMock is a mock implementation of service.ChannelPostService.
+type ChannelPostServiceMock struct {
+	// ScheduleFunc mocks the Schedule method.
+	ScheduleFunc func(jobID int64)
+
+	// StopFunc mocks the Stop method.
+	StopFunc func()
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Schedule holds details about calls to the Schedule method.
+		Schedule []struct {
+			// JobID is the jobID argument value.
+			JobID int64
+		}
+		// Stop holds details about calls to the Stop method.
+		Stop []struct {
+		}
+	}
+	lockSchedule sync.RWMutex
+	lockStop     sync.RWMutex
+}
+
+// Schedule calls ScheduleFunc.
+func (mock *ChannelPostServiceMock) Schedule(jobID int64) {
+	if mock.ScheduleFunc == nil {
+		panic("ChannelPostServiceMock.ScheduleFunc: method is nil but ChannelPostService.Schedule was just called")
+	}
+	callInfo := struct {
+		// JobID is the jobID argument value.
+		JobID int64
+	}{
+		JobID: jobID,
+	}
+	mock.lockSchedule.Lock()
+	mock.calls.Schedule = append(mock.calls.Schedule, callInfo)
+	mock.lockSchedule.Unlock()
+	mock.ScheduleFunc(jobID)
+}
+
+// ScheduleCalls gets all the calls that were made to Schedule.
+// Check the length with:
+//
+//	len(mockedChannelPostService.ScheduleCalls())
+func (mock *ChannelPostServiceMock) ScheduleCalls() []struct {
+	// JobID is the jobID argument value.
+	JobID int64
+} {
+	var calls []struct {
+		// JobID is the jobID argument value.
+		JobID int64
+	}
+	mock.lockSchedule.RLock()
+	calls = mock.calls.Schedule
+	mock.lockSchedule.RUnlock()
+	return calls
+}
+
+// Stop calls StopFunc.
+func (mock *ChannelPostServiceMock) Stop() {
+	if mock.StopFunc == nil {
+		panic("ChannelPostServiceMock.StopFunc: method is nil but ChannelPostService.Stop was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockStop.Lock()
+	mock.calls.Stop = append(mock.calls.Stop, callInfo)
+	mock.lockStop.Unlock()
+	mock.StopFunc()
+}
+
+// StopCalls gets all the calls that were made to Stop.
+// Check the length with:
+//
+//	len(mockedChannelPostService.StopCalls())
+func (mock *ChannelPostServiceMock) StopCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockStop.RLock()
+	calls = mock.calls.Stop
+	mock.lockStop.RUnlock()
+	return calls
+}
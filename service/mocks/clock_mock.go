@@ -0,0 +1,55 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ClockMock does implement service.Clock.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.Clock = &ClockMock{}
+
+// ClockMock is a mock implementation of service.Clock.
+type ClockMock struct {
+	// NowFunc mocks the Now method.
+	NowFunc func() time.Time
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Now holds details about calls to the Now method.
+		Now []struct {
+		}
+	}
+	lockNow sync.RWMutex
+}
+
+// Now calls NowFunc.
+func (mock *ClockMock) Now() time.Time {
+	if mock.NowFunc == nil {
+		panic("ClockMock.NowFunc: method is nil but Clock.Now was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockNow.Lock()
+	mock.calls.Now = append(mock.calls.Now, callInfo)
+	mock.lockNow.Unlock()
+	return mock.NowFunc()
+}
+
+// NowCalls gets all the calls that were made to Now.
+// Check the length with:
+//
+//	len(mockedClock.NowCalls())
+func (mock *ClockMock) NowCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNow.RLock()
+	calls = mock.calls.Now
+	mock.lockNow.RUnlock()
+	return calls
+}
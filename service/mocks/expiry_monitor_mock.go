@@ -0,0 +1,54 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ExpiryMonitorMock does implement service.ExpiryMonitor.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ExpiryMonitor = &ExpiryMonitorMock{}
+
+// ExpiryMonitorMock is a mock implementation of service.ExpiryMonitor.
+type ExpiryMonitorMock struct {
+	// StatusFunc mocks the Status method.
+	StatusFunc func() service.ExpiryStatus
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Status holds details about calls to the Status method.
+		Status []struct {
+		}
+	}
+	lockStatus sync.RWMutex
+}
+
+// Status calls StatusFunc.
+func (mock *ExpiryMonitorMock) Status() service.ExpiryStatus {
+	if mock.StatusFunc == nil {
+		panic("ExpiryMonitorMock.StatusFunc: method is nil but ExpiryMonitor.Status was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockStatus.Lock()
+	mock.calls.Status = append(mock.calls.Status, callInfo)
+	mock.lockStatus.Unlock()
+	return mock.StatusFunc()
+}
+
+// StatusCalls gets all the calls that were made to Status.
+// Check the length with:
+//
+//	len(mockedExpiryMonitor.StatusCalls())
+func (mock *ExpiryMonitorMock) StatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockStatus.RLock()
+	calls = mock.calls.Status
+	mock.lockStatus.RUnlock()
+	return calls
+}
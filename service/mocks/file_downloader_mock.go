@@ -0,0 +1,66 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"io"
+	"sync"
+
+	tele "gopkg.in/telebot.v4"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that FileDownloaderMock does implement service.FileDownloader.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.FileDownloader = &FileDownloaderMock{}
+
+// FileDownloaderMock is a mock implementation of service.FileDownloader.
+type FileDownloaderMock struct {
+	// FileFunc mocks the File method.
+	FileFunc func(file *tele.File) (io.ReadCloser, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// File holds details about calls to the File method.
+		File []struct {
+			// File is the file argument value.
+			File *tele.File
+		}
+	}
+	lockFile sync.RWMutex
+}
+
+// File calls FileFunc.
+func (mock *FileDownloaderMock) File(file *tele.File) (io.ReadCloser, error) {
+	if mock.FileFunc == nil {
+		panic("FileDownloaderMock.FileFunc: method is nil but FileDownloader.File was just called")
+	}
+	callInfo := struct {
+		// File is the file argument value.
+		File *tele.File
+	}{
+		File: file,
+	}
+	mock.lockFile.Lock()
+	mock.calls.File = append(mock.calls.File, callInfo)
+	mock.lockFile.Unlock()
+	return mock.FileFunc(file)
+}
+
+// FileCalls gets all the calls that were made to File.
+// Check the length with:
+//
+//	len(mockedFileDownloader.FileCalls())
+func (mock *FileDownloaderMock) FileCalls() []struct {
+	// File is the file argument value.
+	File *tele.File
+} {
+	var calls []struct {
+		// File is the file argument value.
+		File *tele.File
+	}
+	mock.lockFile.RLock()
+	calls = mock.calls.File
+	mock.lockFile.RUnlock()
+	return calls
+}
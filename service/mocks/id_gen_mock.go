@@ -0,0 +1,73 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that IDGenMock does implement service.IDGen.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.IDGen = &IDGenMock{}
+
+// IDGenMock is a mock implementation of service.IDGen.
+type IDGenMock struct {
+	// IdempotencyKeyFunc mocks the IdempotencyKey method.
+	IdempotencyKeyFunc func(userID int64, jobID int64) string
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IdempotencyKey holds details about calls to the IdempotencyKey method.
+		IdempotencyKey []struct {
+			// UserID is the userID argument value.
+			UserID int64
+			// JobID is the jobID argument value.
+			JobID int64
+		}
+	}
+	lockIdempotencyKey sync.RWMutex
+}
+
+// IdempotencyKey calls IdempotencyKeyFunc.
+func (mock *IDGenMock) IdempotencyKey(userID int64, jobID int64) string {
+	if mock.IdempotencyKeyFunc == nil {
+		panic("IDGenMock.IdempotencyKeyFunc: method is nil but IDGen.IdempotencyKey was just called")
+	}
+	callInfo := struct {
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}{
+		UserID: userID,
+		JobID:  jobID,
+	}
+	mock.lockIdempotencyKey.Lock()
+	mock.calls.IdempotencyKey = append(mock.calls.IdempotencyKey, callInfo)
+	mock.lockIdempotencyKey.Unlock()
+	return mock.IdempotencyKeyFunc(userID, jobID)
+}
+
+// IdempotencyKeyCalls gets all the calls that were made to IdempotencyKey.
+// Check the length with:
+//
+//	len(mockedIDGen.IdempotencyKeyCalls())
+func (mock *IDGenMock) IdempotencyKeyCalls() []struct {
+	// UserID is the userID argument value.
+	UserID int64
+	// JobID is the jobID argument value.
+	JobID int64
+} {
+	var calls []struct {
+		// UserID is the userID argument value.
+		UserID int64
+		// JobID is the jobID argument value.
+		JobID int64
+	}
+	mock.lockIdempotencyKey.RLock()
+	calls = mock.calls.IdempotencyKey
+	mock.lockIdempotencyKey.RUnlock()
+	return calls
+}
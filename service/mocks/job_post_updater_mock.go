@@ -0,0 +1,128 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that JobPostUpdaterMock does implement service.JobPostUpdater.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.JobPostUpdater = &JobPostUpdaterMock{}
+
+// JobPostUpdaterMock is a mock implementation of service.JobPostUpdater.
+type JobPostUpdaterMock struct {
+	// UpdateAdminJobPostFunc mocks the UpdateAdminJobPost method.
+	UpdateAdminJobPostFunc func(ctx context.Context, job *models.Job) error
+
+	// UpdateChannelJobPostFunc mocks the UpdateChannelJobPost method.
+	UpdateChannelJobPostFunc func(ctx context.Context, job *models.Job) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateAdminJobPost holds details about calls to the UpdateAdminJobPost method.
+		UpdateAdminJobPost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.Job
+		}
+		// UpdateChannelJobPost holds details about calls to the UpdateChannelJobPost method.
+		UpdateChannelJobPost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.Job
+		}
+	}
+	lockUpdateAdminJobPost   sync.RWMutex
+	lockUpdateChannelJobPost sync.RWMutex
+}
+
+// UpdateAdminJobPost calls UpdateAdminJobPostFunc.
+func (mock *JobPostUpdaterMock) UpdateAdminJobPost(ctx context.Context, job *models.Job) error {
+	if mock.UpdateAdminJobPostFunc == nil {
+		panic("JobPostUpdaterMock.UpdateAdminJobPostFunc: method is nil but JobPostUpdater.UpdateAdminJobPost was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	mock.lockUpdateAdminJobPost.Lock()
+	mock.calls.UpdateAdminJobPost = append(mock.calls.UpdateAdminJobPost, callInfo)
+	mock.lockUpdateAdminJobPost.Unlock()
+	return mock.UpdateAdminJobPostFunc(ctx, job)
+}
+
+// UpdateAdminJobPostCalls gets all the calls that were made to UpdateAdminJobPost.
+// Check the length with:
+//
+//	len(mockedJobPostUpdater.UpdateAdminJobPostCalls())
+func (mock *JobPostUpdaterMock) UpdateAdminJobPostCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Job is the job argument value.
+	Job *models.Job
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}
+	mock.lockUpdateAdminJobPost.RLock()
+	calls = mock.calls.UpdateAdminJobPost
+	mock.lockUpdateAdminJobPost.RUnlock()
+	return calls
+}
+
+// UpdateChannelJobPost calls UpdateChannelJobPostFunc.
+func (mock *JobPostUpdaterMock) UpdateChannelJobPost(ctx context.Context, job *models.Job) error {
+	if mock.UpdateChannelJobPostFunc == nil {
+		panic("JobPostUpdaterMock.UpdateChannelJobPostFunc: method is nil but JobPostUpdater.UpdateChannelJobPost was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	mock.lockUpdateChannelJobPost.Lock()
+	mock.calls.UpdateChannelJobPost = append(mock.calls.UpdateChannelJobPost, callInfo)
+	mock.lockUpdateChannelJobPost.Unlock()
+	return mock.UpdateChannelJobPostFunc(ctx, job)
+}
+
+// UpdateChannelJobPostCalls gets all the calls that were made to UpdateChannelJobPost.
+// Check the length with:
+//
+//	len(mockedJobPostUpdater.UpdateChannelJobPostCalls())
+func (mock *JobPostUpdaterMock) UpdateChannelJobPostCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Job is the job argument value.
+	Job *models.Job
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}
+	mock.lockUpdateChannelJobPost.RLock()
+	calls = mock.calls.UpdateChannelJobPost
+	mock.lockUpdateChannelJobPost.RUnlock()
+	return calls
+}
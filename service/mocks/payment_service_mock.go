@@ -0,0 +1,439 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that PaymentServiceMock does implement service.PaymentService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.PaymentService = &PaymentServiceMock{}
+
+// PaymentServiceMock is a mock implementation of service.PaymentService.
+type PaymentServiceMock struct {
+	// ApprovePaymentFunc mocks the ApprovePayment method.
+	ApprovePaymentFunc func(ctx context.Context, bookingID int64, adminID int64) (*models.JobBooking, error)
+
+	// BlockUserAndRejectPaymentFunc mocks the BlockUserAndRejectPayment method.
+	BlockUserAndRejectPaymentFunc func(ctx context.Context, bookingID int64, userID int64, adminID int64) (*models.JobBooking, error)
+
+	// BlockUserPermanentlyFunc mocks the BlockUserPermanently method.
+	BlockUserPermanentlyFunc func(ctx context.Context, userID int64, adminID int64) error
+
+	// ForgiveViolationFunc mocks the ForgiveViolation method.
+	ForgiveViolationFunc func(ctx context.Context, violationID int64, adminID int64) (*models.UserViolation, error)
+
+	// RejectPaymentFunc mocks the RejectPayment method.
+	RejectPaymentFunc func(ctx context.Context, bookingID int64, adminID int64, reason string) (*models.JobBooking, error)
+
+	// SubmitPaymentFunc mocks the SubmitPayment method.
+	SubmitPaymentFunc func(ctx context.Context, userID int64, fileIDs []string, isDocument bool, msgID int64, paidAmount int) (*models.JobBooking, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ApprovePayment holds details about calls to the ApprovePayment method.
+		ApprovePayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// BlockUserAndRejectPayment holds details about calls to the BlockUserAndRejectPayment method.
+		BlockUserAndRejectPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+			// UserID is the userID argument value.
+			UserID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// BlockUserPermanently holds details about calls to the BlockUserPermanently method.
+		BlockUserPermanently []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// ForgiveViolation holds details about calls to the ForgiveViolation method.
+		ForgiveViolation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ViolationID is the violationID argument value.
+			ViolationID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// RejectPayment holds details about calls to the RejectPayment method.
+		RejectPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookingID is the bookingID argument value.
+			BookingID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+			// Reason is the reason argument value.
+			Reason string
+		}
+		// SubmitPayment holds details about calls to the SubmitPayment method.
+		SubmitPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// FileIDs is the fileIDs argument value.
+			FileIDs []string
+			// IsDocument is the isDocument argument value.
+			IsDocument bool
+			// MsgID is the msgID argument value.
+			MsgID int64
+			// PaidAmount is the paidAmount argument value.
+			PaidAmount int
+		}
+	}
+	lockApprovePayment            sync.RWMutex
+	lockBlockUserAndRejectPayment sync.RWMutex
+	lockBlockUserPermanently      sync.RWMutex
+	lockForgiveViolation          sync.RWMutex
+	lockRejectPayment             sync.RWMutex
+	lockSubmitPayment             sync.RWMutex
+}
+
+// ApprovePayment calls ApprovePaymentFunc.
+func (mock *PaymentServiceMock) ApprovePayment(ctx context.Context, bookingID int64, adminID int64) (*models.JobBooking, error) {
+	if mock.ApprovePaymentFunc == nil {
+		panic("PaymentServiceMock.ApprovePaymentFunc: method is nil but PaymentService.ApprovePayment was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+		AdminID:   adminID,
+	}
+	mock.lockApprovePayment.Lock()
+	mock.calls.ApprovePayment = append(mock.calls.ApprovePayment, callInfo)
+	mock.lockApprovePayment.Unlock()
+	return mock.ApprovePaymentFunc(ctx, bookingID, adminID)
+}
+
+// ApprovePaymentCalls gets all the calls that were made to ApprovePayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.ApprovePaymentCalls())
+func (mock *PaymentServiceMock) ApprovePaymentCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockApprovePayment.RLock()
+	calls = mock.calls.ApprovePayment
+	mock.lockApprovePayment.RUnlock()
+	return calls
+}
+
+// BlockUserAndRejectPayment calls BlockUserAndRejectPaymentFunc.
+func (mock *PaymentServiceMock) BlockUserAndRejectPayment(ctx context.Context, bookingID int64, userID int64, adminID int64) (*models.JobBooking, error) {
+	if mock.BlockUserAndRejectPaymentFunc == nil {
+		panic("PaymentServiceMock.BlockUserAndRejectPaymentFunc: method is nil but PaymentService.BlockUserAndRejectPayment was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+		UserID:    userID,
+		AdminID:   adminID,
+	}
+	mock.lockBlockUserAndRejectPayment.Lock()
+	mock.calls.BlockUserAndRejectPayment = append(mock.calls.BlockUserAndRejectPayment, callInfo)
+	mock.lockBlockUserAndRejectPayment.Unlock()
+	return mock.BlockUserAndRejectPaymentFunc(ctx, bookingID, userID, adminID)
+}
+
+// BlockUserAndRejectPaymentCalls gets all the calls that were made to BlockUserAndRejectPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.BlockUserAndRejectPaymentCalls())
+func (mock *PaymentServiceMock) BlockUserAndRejectPaymentCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+	// UserID is the userID argument value.
+	UserID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockBlockUserAndRejectPayment.RLock()
+	calls = mock.calls.BlockUserAndRejectPayment
+	mock.lockBlockUserAndRejectPayment.RUnlock()
+	return calls
+}
+
+// BlockUserPermanently calls BlockUserPermanentlyFunc.
+func (mock *PaymentServiceMock) BlockUserPermanently(ctx context.Context, userID int64, adminID int64) error {
+	if mock.BlockUserPermanentlyFunc == nil {
+		panic("PaymentServiceMock.BlockUserPermanentlyFunc: method is nil but PaymentService.BlockUserPermanently was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		AdminID: adminID,
+	}
+	mock.lockBlockUserPermanently.Lock()
+	mock.calls.BlockUserPermanently = append(mock.calls.BlockUserPermanently, callInfo)
+	mock.lockBlockUserPermanently.Unlock()
+	return mock.BlockUserPermanentlyFunc(ctx, userID, adminID)
+}
+
+// BlockUserPermanentlyCalls gets all the calls that were made to BlockUserPermanently.
+// Check the length with:
+//
+//	len(mockedPaymentService.BlockUserPermanentlyCalls())
+func (mock *PaymentServiceMock) BlockUserPermanentlyCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockBlockUserPermanently.RLock()
+	calls = mock.calls.BlockUserPermanently
+	mock.lockBlockUserPermanently.RUnlock()
+	return calls
+}
+
+// ForgiveViolation calls ForgiveViolationFunc.
+func (mock *PaymentServiceMock) ForgiveViolation(ctx context.Context, violationID int64, adminID int64) (*models.UserViolation, error) {
+	if mock.ForgiveViolationFunc == nil {
+		panic("PaymentServiceMock.ForgiveViolationFunc: method is nil but PaymentService.ForgiveViolation was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ViolationID is the violationID argument value.
+		ViolationID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:         ctx,
+		ViolationID: violationID,
+		AdminID:     adminID,
+	}
+	mock.lockForgiveViolation.Lock()
+	mock.calls.ForgiveViolation = append(mock.calls.ForgiveViolation, callInfo)
+	mock.lockForgiveViolation.Unlock()
+	return mock.ForgiveViolationFunc(ctx, violationID, adminID)
+}
+
+// ForgiveViolationCalls gets all the calls that were made to ForgiveViolation.
+// Check the length with:
+//
+//	len(mockedPaymentService.ForgiveViolationCalls())
+func (mock *PaymentServiceMock) ForgiveViolationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ViolationID is the violationID argument value.
+	ViolationID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ViolationID is the violationID argument value.
+		ViolationID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockForgiveViolation.RLock()
+	calls = mock.calls.ForgiveViolation
+	mock.lockForgiveViolation.RUnlock()
+	return calls
+}
+
+// RejectPayment calls RejectPaymentFunc.
+func (mock *PaymentServiceMock) RejectPayment(ctx context.Context, bookingID int64, adminID int64, reason string) (*models.JobBooking, error) {
+	if mock.RejectPaymentFunc == nil {
+		panic("PaymentServiceMock.RejectPaymentFunc: method is nil but PaymentService.RejectPayment was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+		// Reason is the reason argument value.
+		Reason string
+	}{
+		Ctx:       ctx,
+		BookingID: bookingID,
+		AdminID:   adminID,
+		Reason:    reason,
+	}
+	mock.lockRejectPayment.Lock()
+	mock.calls.RejectPayment = append(mock.calls.RejectPayment, callInfo)
+	mock.lockRejectPayment.Unlock()
+	return mock.RejectPaymentFunc(ctx, bookingID, adminID, reason)
+}
+
+// RejectPaymentCalls gets all the calls that were made to RejectPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.RejectPaymentCalls())
+func (mock *PaymentServiceMock) RejectPaymentCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// BookingID is the bookingID argument value.
+	BookingID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+	// Reason is the reason argument value.
+	Reason string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// BookingID is the bookingID argument value.
+		BookingID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+		// Reason is the reason argument value.
+		Reason string
+	}
+	mock.lockRejectPayment.RLock()
+	calls = mock.calls.RejectPayment
+	mock.lockRejectPayment.RUnlock()
+	return calls
+}
+
+// SubmitPayment calls SubmitPaymentFunc.
+func (mock *PaymentServiceMock) SubmitPayment(ctx context.Context, userID int64, fileIDs []string, isDocument bool, msgID int64, paidAmount int) (*models.JobBooking, error) {
+	if mock.SubmitPaymentFunc == nil {
+		panic("PaymentServiceMock.SubmitPaymentFunc: method is nil but PaymentService.SubmitPayment was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// FileIDs is the fileIDs argument value.
+		FileIDs []string
+		// IsDocument is the isDocument argument value.
+		IsDocument bool
+		// MsgID is the msgID argument value.
+		MsgID int64
+		// PaidAmount is the paidAmount argument value.
+		PaidAmount int
+	}{
+		Ctx:        ctx,
+		UserID:     userID,
+		FileIDs:    fileIDs,
+		IsDocument: isDocument,
+		MsgID:      msgID,
+		PaidAmount: paidAmount,
+	}
+	mock.lockSubmitPayment.Lock()
+	mock.calls.SubmitPayment = append(mock.calls.SubmitPayment, callInfo)
+	mock.lockSubmitPayment.Unlock()
+	return mock.SubmitPaymentFunc(ctx, userID, fileIDs, isDocument, msgID, paidAmount)
+}
+
+// SubmitPaymentCalls gets all the calls that were made to SubmitPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.SubmitPaymentCalls())
+func (mock *PaymentServiceMock) SubmitPaymentCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// FileIDs is the fileIDs argument value.
+	FileIDs []string
+	// IsDocument is the isDocument argument value.
+	IsDocument bool
+	// MsgID is the msgID argument value.
+	MsgID int64
+	// PaidAmount is the paidAmount argument value.
+	PaidAmount int
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// FileIDs is the fileIDs argument value.
+		FileIDs []string
+		// IsDocument is the isDocument argument value.
+		IsDocument bool
+		// MsgID is the msgID argument value.
+		MsgID int64
+		// PaidAmount is the paidAmount argument value.
+		PaidAmount int
+	}
+	mock.lockSubmitPayment.RLock()
+	calls = mock.calls.SubmitPayment
+	mock.lockSubmitPayment.RUnlock()
+	return calls
+}
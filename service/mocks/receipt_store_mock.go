@@ -0,0 +1,136 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ReceiptStoreMock does implement service.ReceiptStore.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ReceiptStore = &ReceiptStoreMock{}
+
+// ReceiptStoreMock is a mock implementation of service.ReceiptStore.
+type ReceiptStoreMock struct {
+	// ObjectURLFunc mocks the ObjectURL method.
+	ObjectURLFunc func(key string) string
+
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, key string, contentType string, body []byte) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ObjectURL holds details about calls to the ObjectURL method.
+		ObjectURL []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// Put holds details about calls to the Put method.
+		Put []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// ContentType is the contentType argument value.
+			ContentType string
+			// Body is the body argument value.
+			Body []byte
+		}
+	}
+	lockObjectURL sync.RWMutex
+	lockPut       sync.RWMutex
+}
+
+// ObjectURL calls ObjectURLFunc.
+func (mock *ReceiptStoreMock) ObjectURL(key string) string {
+	if mock.ObjectURLFunc == nil {
+		panic("ReceiptStoreMock.ObjectURLFunc: method is nil but ReceiptStore.ObjectURL was just called")
+	}
+	callInfo := struct {
+		// Key is the key argument value.
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockObjectURL.Lock()
+	mock.calls.ObjectURL = append(mock.calls.ObjectURL, callInfo)
+	mock.lockObjectURL.Unlock()
+	return mock.ObjectURLFunc(key)
+}
+
+// ObjectURLCalls gets all the calls that were made to ObjectURL.
+// Check the length with:
+//
+//	len(mockedReceiptStore.ObjectURLCalls())
+func (mock *ReceiptStoreMock) ObjectURLCalls() []struct {
+	// Key is the key argument value.
+	Key string
+} {
+	var calls []struct {
+		// Key is the key argument value.
+		Key string
+	}
+	mock.lockObjectURL.RLock()
+	calls = mock.calls.ObjectURL
+	mock.lockObjectURL.RUnlock()
+	return calls
+}
+
+// Put calls PutFunc.
+func (mock *ReceiptStoreMock) Put(ctx context.Context, key string, contentType string, body []byte) error {
+	if mock.PutFunc == nil {
+		panic("ReceiptStoreMock.PutFunc: method is nil but ReceiptStore.Put was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Key is the key argument value.
+		Key string
+		// ContentType is the contentType argument value.
+		ContentType string
+		// Body is the body argument value.
+		Body []byte
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		ContentType: contentType,
+		Body:        body,
+	}
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	return mock.PutFunc(ctx, key, contentType, body)
+}
+
+// PutCalls gets all the calls that were made to Put.
+// Check the length with:
+//
+//	len(mockedReceiptStore.PutCalls())
+func (mock *ReceiptStoreMock) PutCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Key is the key argument value.
+	Key string
+	// ContentType is the contentType argument value.
+	ContentType string
+	// Body is the body argument value.
+	Body []byte
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Key is the key argument value.
+		Key string
+		// ContentType is the contentType argument value.
+		ContentType string
+		// Body is the body argument value.
+		Body []byte
+	}
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
+	return calls
+}
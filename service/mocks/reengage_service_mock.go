@@ -0,0 +1,137 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ReengageServiceMock does implement service.ReengageService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ReengageService = &ReengageServiceMock{}
+
+// ReengageServiceMock is a mock implementation of service.ReengageService.
+type ReengageServiceMock struct {
+	// GetReportsFunc mocks the GetReports method.
+	GetReportsFunc func(ctx context.Context, limit int) ([]*models.ReengageCampaign, error)
+
+	// RunCampaignFunc mocks the RunCampaign method.
+	RunCampaignFunc func(ctx context.Context, inactiveDays int, startedBy int64) (*models.ReengageCampaign, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetReports holds details about calls to the GetReports method.
+		GetReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// RunCampaign holds details about calls to the RunCampaign method.
+		RunCampaign []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InactiveDays is the inactiveDays argument value.
+			InactiveDays int
+			// StartedBy is the startedBy argument value.
+			StartedBy int64
+		}
+	}
+	lockGetReports  sync.RWMutex
+	lockRunCampaign sync.RWMutex
+}
+
+// GetReports calls GetReportsFunc.
+func (mock *ReengageServiceMock) GetReports(ctx context.Context, limit int) ([]*models.ReengageCampaign, error) {
+	if mock.GetReportsFunc == nil {
+		panic("ReengageServiceMock.GetReportsFunc: method is nil but ReengageService.GetReports was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Limit is the limit argument value.
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetReports.Lock()
+	mock.calls.GetReports = append(mock.calls.GetReports, callInfo)
+	mock.lockGetReports.Unlock()
+	return mock.GetReportsFunc(ctx, limit)
+}
+
+// GetReportsCalls gets all the calls that were made to GetReports.
+// Check the length with:
+//
+//	len(mockedReengageService.GetReportsCalls())
+func (mock *ReengageServiceMock) GetReportsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Limit is the limit argument value.
+	Limit int
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Limit is the limit argument value.
+		Limit int
+	}
+	mock.lockGetReports.RLock()
+	calls = mock.calls.GetReports
+	mock.lockGetReports.RUnlock()
+	return calls
+}
+
+// RunCampaign calls RunCampaignFunc.
+func (mock *ReengageServiceMock) RunCampaign(ctx context.Context, inactiveDays int, startedBy int64) (*models.ReengageCampaign, error) {
+	if mock.RunCampaignFunc == nil {
+		panic("ReengageServiceMock.RunCampaignFunc: method is nil but ReengageService.RunCampaign was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// InactiveDays is the inactiveDays argument value.
+		InactiveDays int
+		// StartedBy is the startedBy argument value.
+		StartedBy int64
+	}{
+		Ctx:          ctx,
+		InactiveDays: inactiveDays,
+		StartedBy:    startedBy,
+	}
+	mock.lockRunCampaign.Lock()
+	mock.calls.RunCampaign = append(mock.calls.RunCampaign, callInfo)
+	mock.lockRunCampaign.Unlock()
+	return mock.RunCampaignFunc(ctx, inactiveDays, startedBy)
+}
+
+// RunCampaignCalls gets all the calls that were made to RunCampaign.
+// Check the length with:
+//
+//	len(mockedReengageService.RunCampaignCalls())
+func (mock *ReengageServiceMock) RunCampaignCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// InactiveDays is the inactiveDays argument value.
+	InactiveDays int
+	// StartedBy is the startedBy argument value.
+	StartedBy int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// InactiveDays is the inactiveDays argument value.
+		InactiveDays int
+		// StartedBy is the startedBy argument value.
+		StartedBy int64
+	}
+	mock.lockRunCampaign.RLock()
+	calls = mock.calls.RunCampaign
+	mock.lockRunCampaign.RUnlock()
+	return calls
+}
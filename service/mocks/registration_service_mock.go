@@ -0,0 +1,1463 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that RegistrationServiceMock does implement service.RegistrationService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.RegistrationService = &RegistrationServiceMock{}
+
+// RegistrationServiceMock is a mock implementation of service.RegistrationService.
+type RegistrationServiceMock struct {
+	// AcceptOfferFunc mocks the AcceptOffer method.
+	AcceptOfferFunc func(ctx context.Context, userID int64, version int) (*models.PublicOffer, error)
+
+	// CancelRegistrationFunc mocks the CancelRegistration method.
+	CancelRegistrationFunc func(ctx context.Context, userID int64) error
+
+	// CheckUserRegistrationStatusFunc mocks the CheckUserRegistrationStatus method.
+	CheckUserRegistrationStatusFunc func(ctx context.Context, userID int64) (bool, bool, *models.RegistrationDraft, error)
+
+	// ConfirmRegistrationFunc mocks the ConfirmRegistration method.
+	ConfirmRegistrationFunc func(ctx context.Context, userID int64) (*service.RegistrationResult, error)
+
+	// CurrentOfferFunc mocks the CurrentOffer method.
+	CurrentOfferFunc func(ctx context.Context) (*models.PublicOffer, error)
+
+	// DeleteAccountFunc mocks the DeleteAccount method.
+	DeleteAccountFunc func(ctx context.Context, userID int64) error
+
+	// FieldsFunc mocks the Fields method.
+	FieldsFunc func() models.RegistrationFields
+
+	// FormatRegistrationSummaryFunc mocks the FormatRegistrationSummary method.
+	FormatRegistrationSummaryFunc func(draft *models.RegistrationDraft) string
+
+	// GetOrCreateDraftFunc mocks the GetOrCreateDraft method.
+	GetOrCreateDraftFunc func(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+
+	// GetRegisteredUserFunc mocks the GetRegisteredUser method.
+	GetRegisteredUserFunc func(ctx context.Context, userID int64) (*models.RegisteredUser, error)
+
+	// GoToEditStateFunc mocks the GoToEditState method.
+	GoToEditStateFunc func(ctx context.Context, userID int64, field models.EditField) (*service.RegistrationResult, error)
+
+	// MergeDuplicateAccountFunc mocks the MergeDuplicateAccount method.
+	MergeDuplicateAccountFunc func(ctx context.Context, oldUserID int64, newUserID int64, adminID int64) error
+
+	// PendingOfferFunc mocks the PendingOffer method.
+	PendingOfferFunc func(ctx context.Context, userID int64) (*models.PublicOffer, error)
+
+	// ProcessAgeFunc mocks the ProcessAge method.
+	ProcessAgeFunc func(ctx context.Context, userID int64, ageStr string) (*service.RegistrationResult, error)
+
+	// ProcessBodyParamsFunc mocks the ProcessBodyParams method.
+	ProcessBodyParamsFunc func(ctx context.Context, userID int64, input string) (*service.RegistrationResult, error)
+
+	// ProcessCityFunc mocks the ProcessCity method.
+	ProcessCityFunc func(ctx context.Context, userID int64, city string) (*service.RegistrationResult, error)
+
+	// ProcessFullNameFunc mocks the ProcessFullName method.
+	ProcessFullNameFunc func(ctx context.Context, userID int64, name string) (*service.RegistrationResult, error)
+
+	// ProcessGenderFunc mocks the ProcessGender method.
+	ProcessGenderFunc func(ctx context.Context, userID int64, input string) (*service.RegistrationResult, error)
+
+	// ProcessPassportPhotoFunc mocks the ProcessPassportPhoto method.
+	ProcessPassportPhotoFunc func(ctx context.Context, userID int64, fileID string) (*service.RegistrationResult, error)
+
+	// ProcessPhoneFunc mocks the ProcessPhone method.
+	ProcessPhoneFunc func(ctx context.Context, userID int64, phone string) (*service.RegistrationResult, error)
+
+	// ProcessPublicOfferResponseFunc mocks the ProcessPublicOfferResponse method.
+	ProcessPublicOfferResponseFunc func(ctx context.Context, userID int64, version int, accepted bool) (*service.RegistrationResult, error)
+
+	// PublishOfferFunc mocks the PublishOffer method.
+	PublishOfferFunc func(ctx context.Context, content string, adminID int64) (*models.PublicOffer, error)
+
+	// RecordProfileChangeFunc mocks the RecordProfileChange method.
+	RecordProfileChangeFunc func(ctx context.Context, userID int64, field string, oldValue string, newValue string) ([]*models.Job, error)
+
+	// RestartRegistrationFunc mocks the RestartRegistration method.
+	RestartRegistrationFunc func(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+
+	// StartRegistrationFunc mocks the StartRegistration method.
+	StartRegistrationFunc func(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AcceptOffer holds details about calls to the AcceptOffer method.
+		AcceptOffer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Version is the version argument value.
+			Version int
+		}
+		// CancelRegistration holds details about calls to the CancelRegistration method.
+		CancelRegistration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// CheckUserRegistrationStatus holds details about calls to the CheckUserRegistrationStatus method.
+		CheckUserRegistrationStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// ConfirmRegistration holds details about calls to the ConfirmRegistration method.
+		ConfirmRegistration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// CurrentOffer holds details about calls to the CurrentOffer method.
+		CurrentOffer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// DeleteAccount holds details about calls to the DeleteAccount method.
+		DeleteAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// Fields holds details about calls to the Fields method.
+		Fields []struct {
+		}
+		// FormatRegistrationSummary holds details about calls to the FormatRegistrationSummary method.
+		FormatRegistrationSummary []struct {
+			// Draft is the draft argument value.
+			Draft *models.RegistrationDraft
+		}
+		// GetOrCreateDraft holds details about calls to the GetOrCreateDraft method.
+		GetOrCreateDraft []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetRegisteredUser holds details about calls to the GetRegisteredUser method.
+		GetRegisteredUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GoToEditState holds details about calls to the GoToEditState method.
+		GoToEditState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Field is the field argument value.
+			Field models.EditField
+		}
+		// MergeDuplicateAccount holds details about calls to the MergeDuplicateAccount method.
+		MergeDuplicateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OldUserID is the oldUserID argument value.
+			OldUserID int64
+			// NewUserID is the newUserID argument value.
+			NewUserID int64
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// PendingOffer holds details about calls to the PendingOffer method.
+		PendingOffer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// ProcessAge holds details about calls to the ProcessAge method.
+		ProcessAge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// AgeStr is the ageStr argument value.
+			AgeStr string
+		}
+		// ProcessBodyParams holds details about calls to the ProcessBodyParams method.
+		ProcessBodyParams []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Input is the input argument value.
+			Input string
+		}
+		// ProcessCity holds details about calls to the ProcessCity method.
+		ProcessCity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// City is the city argument value.
+			City string
+		}
+		// ProcessFullName holds details about calls to the ProcessFullName method.
+		ProcessFullName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Name is the name argument value.
+			Name string
+		}
+		// ProcessGender holds details about calls to the ProcessGender method.
+		ProcessGender []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Input is the input argument value.
+			Input string
+		}
+		// ProcessPassportPhoto holds details about calls to the ProcessPassportPhoto method.
+		ProcessPassportPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// FileID is the fileID argument value.
+			FileID string
+		}
+		// ProcessPhone holds details about calls to the ProcessPhone method.
+		ProcessPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// ProcessPublicOfferResponse holds details about calls to the ProcessPublicOfferResponse method.
+		ProcessPublicOfferResponse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Version is the version argument value.
+			Version int
+			// Accepted is the accepted argument value.
+			Accepted bool
+		}
+		// PublishOffer holds details about calls to the PublishOffer method.
+		PublishOffer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content string
+			// AdminID is the adminID argument value.
+			AdminID int64
+		}
+		// RecordProfileChange holds details about calls to the RecordProfileChange method.
+		RecordProfileChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Field is the field argument value.
+			Field string
+			// OldValue is the oldValue argument value.
+			OldValue string
+			// NewValue is the newValue argument value.
+			NewValue string
+		}
+		// RestartRegistration holds details about calls to the RestartRegistration method.
+		RestartRegistration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// StartRegistration holds details about calls to the StartRegistration method.
+		StartRegistration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+	}
+	lockAcceptOffer                 sync.RWMutex
+	lockCancelRegistration          sync.RWMutex
+	lockCheckUserRegistrationStatus sync.RWMutex
+	lockConfirmRegistration         sync.RWMutex
+	lockCurrentOffer                sync.RWMutex
+	lockDeleteAccount               sync.RWMutex
+	lockFields                      sync.RWMutex
+	lockFormatRegistrationSummary   sync.RWMutex
+	lockGetOrCreateDraft            sync.RWMutex
+	lockGetRegisteredUser           sync.RWMutex
+	lockGoToEditState               sync.RWMutex
+	lockMergeDuplicateAccount       sync.RWMutex
+	lockPendingOffer                sync.RWMutex
+	lockProcessAge                  sync.RWMutex
+	lockProcessBodyParams           sync.RWMutex
+	lockProcessCity                 sync.RWMutex
+	lockProcessFullName             sync.RWMutex
+	lockProcessGender               sync.RWMutex
+	lockProcessPassportPhoto        sync.RWMutex
+	lockProcessPhone                sync.RWMutex
+	lockProcessPublicOfferResponse  sync.RWMutex
+	lockPublishOffer                sync.RWMutex
+	lockRecordProfileChange         sync.RWMutex
+	lockRestartRegistration         sync.RWMutex
+	lockStartRegistration           sync.RWMutex
+}
+
+// AcceptOffer calls AcceptOfferFunc.
+func (mock *RegistrationServiceMock) AcceptOffer(ctx context.Context, userID int64, version int) (*models.PublicOffer, error) {
+	if mock.AcceptOfferFunc == nil {
+		panic("RegistrationServiceMock.AcceptOfferFunc: method is nil but RegistrationService.AcceptOffer was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Version is the version argument value.
+		Version int
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		Version: version,
+	}
+	mock.lockAcceptOffer.Lock()
+	mock.calls.AcceptOffer = append(mock.calls.AcceptOffer, callInfo)
+	mock.lockAcceptOffer.Unlock()
+	return mock.AcceptOfferFunc(ctx, userID, version)
+}
+
+// AcceptOfferCalls gets all the calls that were made to AcceptOffer.
+// Check the length with:
+//
+//	len(mockedRegistrationService.AcceptOfferCalls())
+func (mock *RegistrationServiceMock) AcceptOfferCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Version is the version argument value.
+	Version int
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Version is the version argument value.
+		Version int
+	}
+	mock.lockAcceptOffer.RLock()
+	calls = mock.calls.AcceptOffer
+	mock.lockAcceptOffer.RUnlock()
+	return calls
+}
+
+// CancelRegistration calls CancelRegistrationFunc.
+func (mock *RegistrationServiceMock) CancelRegistration(ctx context.Context, userID int64) error {
+	if mock.CancelRegistrationFunc == nil {
+		panic("RegistrationServiceMock.CancelRegistrationFunc: method is nil but RegistrationService.CancelRegistration was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCancelRegistration.Lock()
+	mock.calls.CancelRegistration = append(mock.calls.CancelRegistration, callInfo)
+	mock.lockCancelRegistration.Unlock()
+	return mock.CancelRegistrationFunc(ctx, userID)
+}
+
+// CancelRegistrationCalls gets all the calls that were made to CancelRegistration.
+// Check the length with:
+//
+//	len(mockedRegistrationService.CancelRegistrationCalls())
+func (mock *RegistrationServiceMock) CancelRegistrationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockCancelRegistration.RLock()
+	calls = mock.calls.CancelRegistration
+	mock.lockCancelRegistration.RUnlock()
+	return calls
+}
+
+// CheckUserRegistrationStatus calls CheckUserRegistrationStatusFunc.
+func (mock *RegistrationServiceMock) CheckUserRegistrationStatus(ctx context.Context, userID int64) (bool, bool, *models.RegistrationDraft, error) {
+	if mock.CheckUserRegistrationStatusFunc == nil {
+		panic("RegistrationServiceMock.CheckUserRegistrationStatusFunc: method is nil but RegistrationService.CheckUserRegistrationStatus was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCheckUserRegistrationStatus.Lock()
+	mock.calls.CheckUserRegistrationStatus = append(mock.calls.CheckUserRegistrationStatus, callInfo)
+	mock.lockCheckUserRegistrationStatus.Unlock()
+	return mock.CheckUserRegistrationStatusFunc(ctx, userID)
+}
+
+// CheckUserRegistrationStatusCalls gets all the calls that were made to CheckUserRegistrationStatus.
+// Check the length with:
+//
+//	len(mockedRegistrationService.CheckUserRegistrationStatusCalls())
+func (mock *RegistrationServiceMock) CheckUserRegistrationStatusCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockCheckUserRegistrationStatus.RLock()
+	calls = mock.calls.CheckUserRegistrationStatus
+	mock.lockCheckUserRegistrationStatus.RUnlock()
+	return calls
+}
+
+// ConfirmRegistration calls ConfirmRegistrationFunc.
+func (mock *RegistrationServiceMock) ConfirmRegistration(ctx context.Context, userID int64) (*service.RegistrationResult, error) {
+	if mock.ConfirmRegistrationFunc == nil {
+		panic("RegistrationServiceMock.ConfirmRegistrationFunc: method is nil but RegistrationService.ConfirmRegistration was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockConfirmRegistration.Lock()
+	mock.calls.ConfirmRegistration = append(mock.calls.ConfirmRegistration, callInfo)
+	mock.lockConfirmRegistration.Unlock()
+	return mock.ConfirmRegistrationFunc(ctx, userID)
+}
+
+// ConfirmRegistrationCalls gets all the calls that were made to ConfirmRegistration.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ConfirmRegistrationCalls())
+func (mock *RegistrationServiceMock) ConfirmRegistrationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockConfirmRegistration.RLock()
+	calls = mock.calls.ConfirmRegistration
+	mock.lockConfirmRegistration.RUnlock()
+	return calls
+}
+
+// CurrentOffer calls CurrentOfferFunc.
+func (mock *RegistrationServiceMock) CurrentOffer(ctx context.Context) (*models.PublicOffer, error) {
+	if mock.CurrentOfferFunc == nil {
+		panic("RegistrationServiceMock.CurrentOfferFunc: method is nil but RegistrationService.CurrentOffer was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCurrentOffer.Lock()
+	mock.calls.CurrentOffer = append(mock.calls.CurrentOffer, callInfo)
+	mock.lockCurrentOffer.Unlock()
+	return mock.CurrentOfferFunc(ctx)
+}
+
+// CurrentOfferCalls gets all the calls that were made to CurrentOffer.
+// Check the length with:
+//
+//	len(mockedRegistrationService.CurrentOfferCalls())
+func (mock *RegistrationServiceMock) CurrentOfferCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockCurrentOffer.RLock()
+	calls = mock.calls.CurrentOffer
+	mock.lockCurrentOffer.RUnlock()
+	return calls
+}
+
+// DeleteAccount calls DeleteAccountFunc.
+func (mock *RegistrationServiceMock) DeleteAccount(ctx context.Context, userID int64) error {
+	if mock.DeleteAccountFunc == nil {
+		panic("RegistrationServiceMock.DeleteAccountFunc: method is nil but RegistrationService.DeleteAccount was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAccount.Lock()
+	mock.calls.DeleteAccount = append(mock.calls.DeleteAccount, callInfo)
+	mock.lockDeleteAccount.Unlock()
+	return mock.DeleteAccountFunc(ctx, userID)
+}
+
+// DeleteAccountCalls gets all the calls that were made to DeleteAccount.
+// Check the length with:
+//
+//	len(mockedRegistrationService.DeleteAccountCalls())
+func (mock *RegistrationServiceMock) DeleteAccountCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockDeleteAccount.RLock()
+	calls = mock.calls.DeleteAccount
+	mock.lockDeleteAccount.RUnlock()
+	return calls
+}
+
+// Fields calls FieldsFunc.
+func (mock *RegistrationServiceMock) Fields() models.RegistrationFields {
+	if mock.FieldsFunc == nil {
+		panic("RegistrationServiceMock.FieldsFunc: method is nil but RegistrationService.Fields was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFields.Lock()
+	mock.calls.Fields = append(mock.calls.Fields, callInfo)
+	mock.lockFields.Unlock()
+	return mock.FieldsFunc()
+}
+
+// FieldsCalls gets all the calls that were made to Fields.
+// Check the length with:
+//
+//	len(mockedRegistrationService.FieldsCalls())
+func (mock *RegistrationServiceMock) FieldsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFields.RLock()
+	calls = mock.calls.Fields
+	mock.lockFields.RUnlock()
+	return calls
+}
+
+// FormatRegistrationSummary calls FormatRegistrationSummaryFunc.
+func (mock *RegistrationServiceMock) FormatRegistrationSummary(draft *models.RegistrationDraft) string {
+	if mock.FormatRegistrationSummaryFunc == nil {
+		panic("RegistrationServiceMock.FormatRegistrationSummaryFunc: method is nil but RegistrationService.FormatRegistrationSummary was just called")
+	}
+	callInfo := struct {
+		// Draft is the draft argument value.
+		Draft *models.RegistrationDraft
+	}{
+		Draft: draft,
+	}
+	mock.lockFormatRegistrationSummary.Lock()
+	mock.calls.FormatRegistrationSummary = append(mock.calls.FormatRegistrationSummary, callInfo)
+	mock.lockFormatRegistrationSummary.Unlock()
+	return mock.FormatRegistrationSummaryFunc(draft)
+}
+
+// FormatRegistrationSummaryCalls gets all the calls that were made to FormatRegistrationSummary.
+// Check the length with:
+//
+//	len(mockedRegistrationService.FormatRegistrationSummaryCalls())
+func (mock *RegistrationServiceMock) FormatRegistrationSummaryCalls() []struct {
+	// Draft is the draft argument value.
+	Draft *models.RegistrationDraft
+} {
+	var calls []struct {
+		// Draft is the draft argument value.
+		Draft *models.RegistrationDraft
+	}
+	mock.lockFormatRegistrationSummary.RLock()
+	calls = mock.calls.FormatRegistrationSummary
+	mock.lockFormatRegistrationSummary.RUnlock()
+	return calls
+}
+
+// GetOrCreateDraft calls GetOrCreateDraftFunc.
+func (mock *RegistrationServiceMock) GetOrCreateDraft(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+	if mock.GetOrCreateDraftFunc == nil {
+		panic("RegistrationServiceMock.GetOrCreateDraftFunc: method is nil but RegistrationService.GetOrCreateDraft was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetOrCreateDraft.Lock()
+	mock.calls.GetOrCreateDraft = append(mock.calls.GetOrCreateDraft, callInfo)
+	mock.lockGetOrCreateDraft.Unlock()
+	return mock.GetOrCreateDraftFunc(ctx, userID)
+}
+
+// GetOrCreateDraftCalls gets all the calls that were made to GetOrCreateDraft.
+// Check the length with:
+//
+//	len(mockedRegistrationService.GetOrCreateDraftCalls())
+func (mock *RegistrationServiceMock) GetOrCreateDraftCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockGetOrCreateDraft.RLock()
+	calls = mock.calls.GetOrCreateDraft
+	mock.lockGetOrCreateDraft.RUnlock()
+	return calls
+}
+
+// GetRegisteredUser calls GetRegisteredUserFunc.
+func (mock *RegistrationServiceMock) GetRegisteredUser(ctx context.Context, userID int64) (*models.RegisteredUser, error) {
+	if mock.GetRegisteredUserFunc == nil {
+		panic("RegistrationServiceMock.GetRegisteredUserFunc: method is nil but RegistrationService.GetRegisteredUser was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetRegisteredUser.Lock()
+	mock.calls.GetRegisteredUser = append(mock.calls.GetRegisteredUser, callInfo)
+	mock.lockGetRegisteredUser.Unlock()
+	return mock.GetRegisteredUserFunc(ctx, userID)
+}
+
+// GetRegisteredUserCalls gets all the calls that were made to GetRegisteredUser.
+// Check the length with:
+//
+//	len(mockedRegistrationService.GetRegisteredUserCalls())
+func (mock *RegistrationServiceMock) GetRegisteredUserCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockGetRegisteredUser.RLock()
+	calls = mock.calls.GetRegisteredUser
+	mock.lockGetRegisteredUser.RUnlock()
+	return calls
+}
+
+// GoToEditState calls GoToEditStateFunc.
+func (mock *RegistrationServiceMock) GoToEditState(ctx context.Context, userID int64, field models.EditField) (*service.RegistrationResult, error) {
+	if mock.GoToEditStateFunc == nil {
+		panic("RegistrationServiceMock.GoToEditStateFunc: method is nil but RegistrationService.GoToEditState was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Field is the field argument value.
+		Field models.EditField
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Field:  field,
+	}
+	mock.lockGoToEditState.Lock()
+	mock.calls.GoToEditState = append(mock.calls.GoToEditState, callInfo)
+	mock.lockGoToEditState.Unlock()
+	return mock.GoToEditStateFunc(ctx, userID, field)
+}
+
+// GoToEditStateCalls gets all the calls that were made to GoToEditState.
+// Check the length with:
+//
+//	len(mockedRegistrationService.GoToEditStateCalls())
+func (mock *RegistrationServiceMock) GoToEditStateCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Field is the field argument value.
+	Field models.EditField
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Field is the field argument value.
+		Field models.EditField
+	}
+	mock.lockGoToEditState.RLock()
+	calls = mock.calls.GoToEditState
+	mock.lockGoToEditState.RUnlock()
+	return calls
+}
+
+// MergeDuplicateAccount calls MergeDuplicateAccountFunc.
+func (mock *RegistrationServiceMock) MergeDuplicateAccount(ctx context.Context, oldUserID int64, newUserID int64, adminID int64) error {
+	if mock.MergeDuplicateAccountFunc == nil {
+		panic("RegistrationServiceMock.MergeDuplicateAccountFunc: method is nil but RegistrationService.MergeDuplicateAccount was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// OldUserID is the oldUserID argument value.
+		OldUserID int64
+		// NewUserID is the newUserID argument value.
+		NewUserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:       ctx,
+		OldUserID: oldUserID,
+		NewUserID: newUserID,
+		AdminID:   adminID,
+	}
+	mock.lockMergeDuplicateAccount.Lock()
+	mock.calls.MergeDuplicateAccount = append(mock.calls.MergeDuplicateAccount, callInfo)
+	mock.lockMergeDuplicateAccount.Unlock()
+	return mock.MergeDuplicateAccountFunc(ctx, oldUserID, newUserID, adminID)
+}
+
+// MergeDuplicateAccountCalls gets all the calls that were made to MergeDuplicateAccount.
+// Check the length with:
+//
+//	len(mockedRegistrationService.MergeDuplicateAccountCalls())
+func (mock *RegistrationServiceMock) MergeDuplicateAccountCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// OldUserID is the oldUserID argument value.
+	OldUserID int64
+	// NewUserID is the newUserID argument value.
+	NewUserID int64
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// OldUserID is the oldUserID argument value.
+		OldUserID int64
+		// NewUserID is the newUserID argument value.
+		NewUserID int64
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockMergeDuplicateAccount.RLock()
+	calls = mock.calls.MergeDuplicateAccount
+	mock.lockMergeDuplicateAccount.RUnlock()
+	return calls
+}
+
+// PendingOffer calls PendingOfferFunc.
+func (mock *RegistrationServiceMock) PendingOffer(ctx context.Context, userID int64) (*models.PublicOffer, error) {
+	if mock.PendingOfferFunc == nil {
+		panic("RegistrationServiceMock.PendingOfferFunc: method is nil but RegistrationService.PendingOffer was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockPendingOffer.Lock()
+	mock.calls.PendingOffer = append(mock.calls.PendingOffer, callInfo)
+	mock.lockPendingOffer.Unlock()
+	return mock.PendingOfferFunc(ctx, userID)
+}
+
+// PendingOfferCalls gets all the calls that were made to PendingOffer.
+// Check the length with:
+//
+//	len(mockedRegistrationService.PendingOfferCalls())
+func (mock *RegistrationServiceMock) PendingOfferCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockPendingOffer.RLock()
+	calls = mock.calls.PendingOffer
+	mock.lockPendingOffer.RUnlock()
+	return calls
+}
+
+// ProcessAge calls ProcessAgeFunc.
+func (mock *RegistrationServiceMock) ProcessAge(ctx context.Context, userID int64, ageStr string) (*service.RegistrationResult, error) {
+	if mock.ProcessAgeFunc == nil {
+		panic("RegistrationServiceMock.ProcessAgeFunc: method is nil but RegistrationService.ProcessAge was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// AgeStr is the ageStr argument value.
+		AgeStr string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		AgeStr: ageStr,
+	}
+	mock.lockProcessAge.Lock()
+	mock.calls.ProcessAge = append(mock.calls.ProcessAge, callInfo)
+	mock.lockProcessAge.Unlock()
+	return mock.ProcessAgeFunc(ctx, userID, ageStr)
+}
+
+// ProcessAgeCalls gets all the calls that were made to ProcessAge.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessAgeCalls())
+func (mock *RegistrationServiceMock) ProcessAgeCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// AgeStr is the ageStr argument value.
+	AgeStr string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// AgeStr is the ageStr argument value.
+		AgeStr string
+	}
+	mock.lockProcessAge.RLock()
+	calls = mock.calls.ProcessAge
+	mock.lockProcessAge.RUnlock()
+	return calls
+}
+
+// ProcessBodyParams calls ProcessBodyParamsFunc.
+func (mock *RegistrationServiceMock) ProcessBodyParams(ctx context.Context, userID int64, input string) (*service.RegistrationResult, error) {
+	if mock.ProcessBodyParamsFunc == nil {
+		panic("RegistrationServiceMock.ProcessBodyParamsFunc: method is nil but RegistrationService.ProcessBodyParams was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Input is the input argument value.
+		Input string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Input:  input,
+	}
+	mock.lockProcessBodyParams.Lock()
+	mock.calls.ProcessBodyParams = append(mock.calls.ProcessBodyParams, callInfo)
+	mock.lockProcessBodyParams.Unlock()
+	return mock.ProcessBodyParamsFunc(ctx, userID, input)
+}
+
+// ProcessBodyParamsCalls gets all the calls that were made to ProcessBodyParams.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessBodyParamsCalls())
+func (mock *RegistrationServiceMock) ProcessBodyParamsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Input is the input argument value.
+	Input string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Input is the input argument value.
+		Input string
+	}
+	mock.lockProcessBodyParams.RLock()
+	calls = mock.calls.ProcessBodyParams
+	mock.lockProcessBodyParams.RUnlock()
+	return calls
+}
+
+// ProcessCity calls ProcessCityFunc.
+func (mock *RegistrationServiceMock) ProcessCity(ctx context.Context, userID int64, city string) (*service.RegistrationResult, error) {
+	if mock.ProcessCityFunc == nil {
+		panic("RegistrationServiceMock.ProcessCityFunc: method is nil but RegistrationService.ProcessCity was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// City is the city argument value.
+		City string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		City:   city,
+	}
+	mock.lockProcessCity.Lock()
+	mock.calls.ProcessCity = append(mock.calls.ProcessCity, callInfo)
+	mock.lockProcessCity.Unlock()
+	return mock.ProcessCityFunc(ctx, userID, city)
+}
+
+// ProcessCityCalls gets all the calls that were made to ProcessCity.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessCityCalls())
+func (mock *RegistrationServiceMock) ProcessCityCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// City is the city argument value.
+	City string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// City is the city argument value.
+		City string
+	}
+	mock.lockProcessCity.RLock()
+	calls = mock.calls.ProcessCity
+	mock.lockProcessCity.RUnlock()
+	return calls
+}
+
+// ProcessFullName calls ProcessFullNameFunc.
+func (mock *RegistrationServiceMock) ProcessFullName(ctx context.Context, userID int64, name string) (*service.RegistrationResult, error) {
+	if mock.ProcessFullNameFunc == nil {
+		panic("RegistrationServiceMock.ProcessFullNameFunc: method is nil but RegistrationService.ProcessFullName was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Name is the name argument value.
+		Name string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Name:   name,
+	}
+	mock.lockProcessFullName.Lock()
+	mock.calls.ProcessFullName = append(mock.calls.ProcessFullName, callInfo)
+	mock.lockProcessFullName.Unlock()
+	return mock.ProcessFullNameFunc(ctx, userID, name)
+}
+
+// ProcessFullNameCalls gets all the calls that were made to ProcessFullName.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessFullNameCalls())
+func (mock *RegistrationServiceMock) ProcessFullNameCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Name is the name argument value.
+	Name string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Name is the name argument value.
+		Name string
+	}
+	mock.lockProcessFullName.RLock()
+	calls = mock.calls.ProcessFullName
+	mock.lockProcessFullName.RUnlock()
+	return calls
+}
+
+// ProcessGender calls ProcessGenderFunc.
+func (mock *RegistrationServiceMock) ProcessGender(ctx context.Context, userID int64, input string) (*service.RegistrationResult, error) {
+	if mock.ProcessGenderFunc == nil {
+		panic("RegistrationServiceMock.ProcessGenderFunc: method is nil but RegistrationService.ProcessGender was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Input is the input argument value.
+		Input string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Input:  input,
+	}
+	mock.lockProcessGender.Lock()
+	mock.calls.ProcessGender = append(mock.calls.ProcessGender, callInfo)
+	mock.lockProcessGender.Unlock()
+	return mock.ProcessGenderFunc(ctx, userID, input)
+}
+
+// ProcessGenderCalls gets all the calls that were made to ProcessGender.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessGenderCalls())
+func (mock *RegistrationServiceMock) ProcessGenderCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Input is the input argument value.
+	Input string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Input is the input argument value.
+		Input string
+	}
+	mock.lockProcessGender.RLock()
+	calls = mock.calls.ProcessGender
+	mock.lockProcessGender.RUnlock()
+	return calls
+}
+
+// ProcessPassportPhoto calls ProcessPassportPhotoFunc.
+func (mock *RegistrationServiceMock) ProcessPassportPhoto(ctx context.Context, userID int64, fileID string) (*service.RegistrationResult, error) {
+	if mock.ProcessPassportPhotoFunc == nil {
+		panic("RegistrationServiceMock.ProcessPassportPhotoFunc: method is nil but RegistrationService.ProcessPassportPhoto was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// FileID is the fileID argument value.
+		FileID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		FileID: fileID,
+	}
+	mock.lockProcessPassportPhoto.Lock()
+	mock.calls.ProcessPassportPhoto = append(mock.calls.ProcessPassportPhoto, callInfo)
+	mock.lockProcessPassportPhoto.Unlock()
+	return mock.ProcessPassportPhotoFunc(ctx, userID, fileID)
+}
+
+// ProcessPassportPhotoCalls gets all the calls that were made to ProcessPassportPhoto.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessPassportPhotoCalls())
+func (mock *RegistrationServiceMock) ProcessPassportPhotoCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// FileID is the fileID argument value.
+	FileID string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// FileID is the fileID argument value.
+		FileID string
+	}
+	mock.lockProcessPassportPhoto.RLock()
+	calls = mock.calls.ProcessPassportPhoto
+	mock.lockProcessPassportPhoto.RUnlock()
+	return calls
+}
+
+// ProcessPhone calls ProcessPhoneFunc.
+func (mock *RegistrationServiceMock) ProcessPhone(ctx context.Context, userID int64, phone string) (*service.RegistrationResult, error) {
+	if mock.ProcessPhoneFunc == nil {
+		panic("RegistrationServiceMock.ProcessPhoneFunc: method is nil but RegistrationService.ProcessPhone was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Phone is the phone argument value.
+		Phone string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Phone:  phone,
+	}
+	mock.lockProcessPhone.Lock()
+	mock.calls.ProcessPhone = append(mock.calls.ProcessPhone, callInfo)
+	mock.lockProcessPhone.Unlock()
+	return mock.ProcessPhoneFunc(ctx, userID, phone)
+}
+
+// ProcessPhoneCalls gets all the calls that were made to ProcessPhone.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessPhoneCalls())
+func (mock *RegistrationServiceMock) ProcessPhoneCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Phone is the phone argument value.
+	Phone string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Phone is the phone argument value.
+		Phone string
+	}
+	mock.lockProcessPhone.RLock()
+	calls = mock.calls.ProcessPhone
+	mock.lockProcessPhone.RUnlock()
+	return calls
+}
+
+// ProcessPublicOfferResponse calls ProcessPublicOfferResponseFunc.
+func (mock *RegistrationServiceMock) ProcessPublicOfferResponse(ctx context.Context, userID int64, version int, accepted bool) (*service.RegistrationResult, error) {
+	if mock.ProcessPublicOfferResponseFunc == nil {
+		panic("RegistrationServiceMock.ProcessPublicOfferResponseFunc: method is nil but RegistrationService.ProcessPublicOfferResponse was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Version is the version argument value.
+		Version int
+		// Accepted is the accepted argument value.
+		Accepted bool
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Version:  version,
+		Accepted: accepted,
+	}
+	mock.lockProcessPublicOfferResponse.Lock()
+	mock.calls.ProcessPublicOfferResponse = append(mock.calls.ProcessPublicOfferResponse, callInfo)
+	mock.lockProcessPublicOfferResponse.Unlock()
+	return mock.ProcessPublicOfferResponseFunc(ctx, userID, version, accepted)
+}
+
+// ProcessPublicOfferResponseCalls gets all the calls that were made to ProcessPublicOfferResponse.
+// Check the length with:
+//
+//	len(mockedRegistrationService.ProcessPublicOfferResponseCalls())
+func (mock *RegistrationServiceMock) ProcessPublicOfferResponseCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Version is the version argument value.
+	Version int
+	// Accepted is the accepted argument value.
+	Accepted bool
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Version is the version argument value.
+		Version int
+		// Accepted is the accepted argument value.
+		Accepted bool
+	}
+	mock.lockProcessPublicOfferResponse.RLock()
+	calls = mock.calls.ProcessPublicOfferResponse
+	mock.lockProcessPublicOfferResponse.RUnlock()
+	return calls
+}
+
+// PublishOffer calls PublishOfferFunc.
+func (mock *RegistrationServiceMock) PublishOffer(ctx context.Context, content string, adminID int64) (*models.PublicOffer, error) {
+	if mock.PublishOfferFunc == nil {
+		panic("RegistrationServiceMock.PublishOfferFunc: method is nil but RegistrationService.PublishOffer was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Content is the content argument value.
+		Content string
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}{
+		Ctx:     ctx,
+		Content: content,
+		AdminID: adminID,
+	}
+	mock.lockPublishOffer.Lock()
+	mock.calls.PublishOffer = append(mock.calls.PublishOffer, callInfo)
+	mock.lockPublishOffer.Unlock()
+	return mock.PublishOfferFunc(ctx, content, adminID)
+}
+
+// PublishOfferCalls gets all the calls that were made to PublishOffer.
+// Check the length with:
+//
+//	len(mockedRegistrationService.PublishOfferCalls())
+func (mock *RegistrationServiceMock) PublishOfferCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Content is the content argument value.
+	Content string
+	// AdminID is the adminID argument value.
+	AdminID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Content is the content argument value.
+		Content string
+		// AdminID is the adminID argument value.
+		AdminID int64
+	}
+	mock.lockPublishOffer.RLock()
+	calls = mock.calls.PublishOffer
+	mock.lockPublishOffer.RUnlock()
+	return calls
+}
+
+// RecordProfileChange calls RecordProfileChangeFunc.
+func (mock *RegistrationServiceMock) RecordProfileChange(ctx context.Context, userID int64, field string, oldValue string, newValue string) ([]*models.Job, error) {
+	if mock.RecordProfileChangeFunc == nil {
+		panic("RegistrationServiceMock.RecordProfileChangeFunc: method is nil but RegistrationService.RecordProfileChange was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Field is the field argument value.
+		Field string
+		// OldValue is the oldValue argument value.
+		OldValue string
+		// NewValue is the newValue argument value.
+		NewValue string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+	mock.lockRecordProfileChange.Lock()
+	mock.calls.RecordProfileChange = append(mock.calls.RecordProfileChange, callInfo)
+	mock.lockRecordProfileChange.Unlock()
+	return mock.RecordProfileChangeFunc(ctx, userID, field, oldValue, newValue)
+}
+
+// RecordProfileChangeCalls gets all the calls that were made to RecordProfileChange.
+// Check the length with:
+//
+//	len(mockedRegistrationService.RecordProfileChangeCalls())
+func (mock *RegistrationServiceMock) RecordProfileChangeCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+	// Field is the field argument value.
+	Field string
+	// OldValue is the oldValue argument value.
+	OldValue string
+	// NewValue is the newValue argument value.
+	NewValue string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+		// Field is the field argument value.
+		Field string
+		// OldValue is the oldValue argument value.
+		OldValue string
+		// NewValue is the newValue argument value.
+		NewValue string
+	}
+	mock.lockRecordProfileChange.RLock()
+	calls = mock.calls.RecordProfileChange
+	mock.lockRecordProfileChange.RUnlock()
+	return calls
+}
+
+// RestartRegistration calls RestartRegistrationFunc.
+func (mock *RegistrationServiceMock) RestartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+	if mock.RestartRegistrationFunc == nil {
+		panic("RegistrationServiceMock.RestartRegistrationFunc: method is nil but RegistrationService.RestartRegistration was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRestartRegistration.Lock()
+	mock.calls.RestartRegistration = append(mock.calls.RestartRegistration, callInfo)
+	mock.lockRestartRegistration.Unlock()
+	return mock.RestartRegistrationFunc(ctx, userID)
+}
+
+// RestartRegistrationCalls gets all the calls that were made to RestartRegistration.
+// Check the length with:
+//
+//	len(mockedRegistrationService.RestartRegistrationCalls())
+func (mock *RegistrationServiceMock) RestartRegistrationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockRestartRegistration.RLock()
+	calls = mock.calls.RestartRegistration
+	mock.lockRestartRegistration.RUnlock()
+	return calls
+}
+
+// StartRegistration calls StartRegistrationFunc.
+func (mock *RegistrationServiceMock) StartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+	if mock.StartRegistrationFunc == nil {
+		panic("RegistrationServiceMock.StartRegistrationFunc: method is nil but RegistrationService.StartRegistration was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockStartRegistration.Lock()
+	mock.calls.StartRegistration = append(mock.calls.StartRegistration, callInfo)
+	mock.lockStartRegistration.Unlock()
+	return mock.StartRegistrationFunc(ctx, userID)
+}
+
+// StartRegistrationCalls gets all the calls that were made to StartRegistration.
+// Check the length with:
+//
+//	len(mockedRegistrationService.StartRegistrationCalls())
+func (mock *RegistrationServiceMock) StartRegistrationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockStartRegistration.RLock()
+	calls = mock.calls.StartRegistration
+	mock.lockStartRegistration.RUnlock()
+	return calls
+}
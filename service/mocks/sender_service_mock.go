@@ -0,0 +1,864 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	tele "gopkg.in/telebot.v4"
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that SenderServiceMock does implement service.SenderService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.SenderService = &SenderServiceMock{}
+
+// SenderServiceMock is a mock implementation of service.SenderService.
+type SenderServiceMock struct {
+	// DeleteMessageFunc mocks the DeleteMessage method.
+	DeleteMessageFunc func(c tele.Context) error
+
+	// DeliverFunc mocks the Deliver method.
+	DeliverFunc func(ctx context.Context, reqs []*service.MessageRequest) []service.MessageResponse
+
+	// EditFunc mocks the Edit method.
+	EditFunc func(ctx context.Context, chatID int64, messageID int, message string, opts ...any) error
+
+	// EditCaptionFunc mocks the EditCaption method.
+	EditCaptionFunc func(msg *tele.Message, caption string, opts ...any) error
+
+	// EditMessageFunc mocks the EditMessage method.
+	EditMessageFunc func(c tele.Context, message string, opts ...any) error
+
+	// RemoveKeyboardFunc mocks the RemoveKeyboard method.
+	RemoveKeyboardFunc func(c tele.Context) error
+
+	// ReplyFunc mocks the Reply method.
+	ReplyFunc func(c tele.Context, message string, opts ...any) error
+
+	// ReplyWithPhotoFunc mocks the ReplyWithPhoto method.
+	ReplyWithPhotoFunc func(c tele.Context, photo *tele.Photo, opts ...any) error
+
+	// RespondFunc mocks the Respond method.
+	RespondFunc func(c tele.Context, response *tele.CallbackResponse) error
+
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, chatID int64, message string, opts ...any) error
+
+	// SendAnyFunc mocks the SendAny method.
+	SendAnyFunc func(ctx context.Context, chatID int64, what any, opts ...any) error
+
+	// SendPhotoFunc mocks the SendPhoto method.
+	SendPhotoFunc func(ctx context.Context, chatID int64, photo *tele.Photo, opts ...any) error
+
+	// UpdateAdminJobPostFunc mocks the UpdateAdminJobPost method.
+	UpdateAdminJobPostFunc func(ctx context.Context, job *models.Job) error
+
+	// UpdateChannelJobPostFunc mocks the UpdateChannelJobPost method.
+	UpdateChannelJobPostFunc func(ctx context.Context, job *models.Job) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteMessage holds details about calls to the DeleteMessage method.
+		DeleteMessage []struct {
+			// C is the c argument value.
+			C tele.Context
+		}
+		// Deliver holds details about calls to the Deliver method.
+		Deliver []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Reqs is the reqs argument value.
+			Reqs []*service.MessageRequest
+		}
+		// Edit holds details about calls to the Edit method.
+		Edit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ChatID is the chatID argument value.
+			ChatID int64
+			// MessageID is the messageID argument value.
+			MessageID int
+			// Message is the message argument value.
+			Message string
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// EditCaption holds details about calls to the EditCaption method.
+		EditCaption []struct {
+			// Msg is the msg argument value.
+			Msg *tele.Message
+			// Caption is the caption argument value.
+			Caption string
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// EditMessage holds details about calls to the EditMessage method.
+		EditMessage []struct {
+			// C is the c argument value.
+			C tele.Context
+			// Message is the message argument value.
+			Message string
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// RemoveKeyboard holds details about calls to the RemoveKeyboard method.
+		RemoveKeyboard []struct {
+			// C is the c argument value.
+			C tele.Context
+		}
+		// Reply holds details about calls to the Reply method.
+		Reply []struct {
+			// C is the c argument value.
+			C tele.Context
+			// Message is the message argument value.
+			Message string
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// ReplyWithPhoto holds details about calls to the ReplyWithPhoto method.
+		ReplyWithPhoto []struct {
+			// C is the c argument value.
+			C tele.Context
+			// Photo is the photo argument value.
+			Photo *tele.Photo
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// Respond holds details about calls to the Respond method.
+		Respond []struct {
+			// C is the c argument value.
+			C tele.Context
+			// Response is the response argument value.
+			Response *tele.CallbackResponse
+		}
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ChatID is the chatID argument value.
+			ChatID int64
+			// Message is the message argument value.
+			Message string
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// SendAny holds details about calls to the SendAny method.
+		SendAny []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ChatID is the chatID argument value.
+			ChatID int64
+			// What is the what argument value.
+			What any
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// SendPhoto holds details about calls to the SendPhoto method.
+		SendPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ChatID is the chatID argument value.
+			ChatID int64
+			// Photo is the photo argument value.
+			Photo *tele.Photo
+			// Opts is the opts argument value.
+			Opts []any
+		}
+		// UpdateAdminJobPost holds details about calls to the UpdateAdminJobPost method.
+		UpdateAdminJobPost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.Job
+		}
+		// UpdateChannelJobPost holds details about calls to the UpdateChannelJobPost method.
+		UpdateChannelJobPost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.Job
+		}
+	}
+	lockDeleteMessage        sync.RWMutex
+	lockDeliver              sync.RWMutex
+	lockEdit                 sync.RWMutex
+	lockEditCaption          sync.RWMutex
+	lockEditMessage          sync.RWMutex
+	lockRemoveKeyboard       sync.RWMutex
+	lockReply                sync.RWMutex
+	lockReplyWithPhoto       sync.RWMutex
+	lockRespond              sync.RWMutex
+	lockSend                 sync.RWMutex
+	lockSendAny              sync.RWMutex
+	lockSendPhoto            sync.RWMutex
+	lockUpdateAdminJobPost   sync.RWMutex
+	lockUpdateChannelJobPost sync.RWMutex
+}
+
+// DeleteMessage calls DeleteMessageFunc.
+func (mock *SenderServiceMock) DeleteMessage(c tele.Context) error {
+	if mock.DeleteMessageFunc == nil {
+		panic("SenderServiceMock.DeleteMessageFunc: method is nil but SenderService.DeleteMessage was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+	}{
+		C: c,
+	}
+	mock.lockDeleteMessage.Lock()
+	mock.calls.DeleteMessage = append(mock.calls.DeleteMessage, callInfo)
+	mock.lockDeleteMessage.Unlock()
+	return mock.DeleteMessageFunc(c)
+}
+
+// DeleteMessageCalls gets all the calls that were made to DeleteMessage.
+// Check the length with:
+//
+//	len(mockedSenderService.DeleteMessageCalls())
+func (mock *SenderServiceMock) DeleteMessageCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+	}
+	mock.lockDeleteMessage.RLock()
+	calls = mock.calls.DeleteMessage
+	mock.lockDeleteMessage.RUnlock()
+	return calls
+}
+
+// Deliver calls DeliverFunc.
+func (mock *SenderServiceMock) Deliver(ctx context.Context, reqs []*service.MessageRequest) []service.MessageResponse {
+	if mock.DeliverFunc == nil {
+		panic("SenderServiceMock.DeliverFunc: method is nil but SenderService.Deliver was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Reqs is the reqs argument value.
+		Reqs []*service.MessageRequest
+	}{
+		Ctx:  ctx,
+		Reqs: reqs,
+	}
+	mock.lockDeliver.Lock()
+	mock.calls.Deliver = append(mock.calls.Deliver, callInfo)
+	mock.lockDeliver.Unlock()
+	return mock.DeliverFunc(ctx, reqs)
+}
+
+// DeliverCalls gets all the calls that were made to Deliver.
+// Check the length with:
+//
+//	len(mockedSenderService.DeliverCalls())
+func (mock *SenderServiceMock) DeliverCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Reqs is the reqs argument value.
+	Reqs []*service.MessageRequest
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Reqs is the reqs argument value.
+		Reqs []*service.MessageRequest
+	}
+	mock.lockDeliver.RLock()
+	calls = mock.calls.Deliver
+	mock.lockDeliver.RUnlock()
+	return calls
+}
+
+// Edit calls EditFunc.
+func (mock *SenderServiceMock) Edit(ctx context.Context, chatID int64, messageID int, message string, opts ...any) error {
+	if mock.EditFunc == nil {
+		panic("SenderServiceMock.EditFunc: method is nil but SenderService.Edit was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// MessageID is the messageID argument value.
+		MessageID int
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		Ctx:       ctx,
+		ChatID:    chatID,
+		MessageID: messageID,
+		Message:   message,
+		Opts:      opts,
+	}
+	mock.lockEdit.Lock()
+	mock.calls.Edit = append(mock.calls.Edit, callInfo)
+	mock.lockEdit.Unlock()
+	return mock.EditFunc(ctx, chatID, messageID, message, opts...)
+}
+
+// EditCalls gets all the calls that were made to Edit.
+// Check the length with:
+//
+//	len(mockedSenderService.EditCalls())
+func (mock *SenderServiceMock) EditCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ChatID is the chatID argument value.
+	ChatID int64
+	// MessageID is the messageID argument value.
+	MessageID int
+	// Message is the message argument value.
+	Message string
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// MessageID is the messageID argument value.
+		MessageID int
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockEdit.RLock()
+	calls = mock.calls.Edit
+	mock.lockEdit.RUnlock()
+	return calls
+}
+
+// EditCaption calls EditCaptionFunc.
+func (mock *SenderServiceMock) EditCaption(msg *tele.Message, caption string, opts ...any) error {
+	if mock.EditCaptionFunc == nil {
+		panic("SenderServiceMock.EditCaptionFunc: method is nil but SenderService.EditCaption was just called")
+	}
+	callInfo := struct {
+		// Msg is the msg argument value.
+		Msg *tele.Message
+		// Caption is the caption argument value.
+		Caption string
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		Msg:     msg,
+		Caption: caption,
+		Opts:    opts,
+	}
+	mock.lockEditCaption.Lock()
+	mock.calls.EditCaption = append(mock.calls.EditCaption, callInfo)
+	mock.lockEditCaption.Unlock()
+	return mock.EditCaptionFunc(msg, caption, opts...)
+}
+
+// EditCaptionCalls gets all the calls that were made to EditCaption.
+// Check the length with:
+//
+//	len(mockedSenderService.EditCaptionCalls())
+func (mock *SenderServiceMock) EditCaptionCalls() []struct {
+	// Msg is the msg argument value.
+	Msg *tele.Message
+	// Caption is the caption argument value.
+	Caption string
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// Msg is the msg argument value.
+		Msg *tele.Message
+		// Caption is the caption argument value.
+		Caption string
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockEditCaption.RLock()
+	calls = mock.calls.EditCaption
+	mock.lockEditCaption.RUnlock()
+	return calls
+}
+
+// EditMessage calls EditMessageFunc.
+func (mock *SenderServiceMock) EditMessage(c tele.Context, message string, opts ...any) error {
+	if mock.EditMessageFunc == nil {
+		panic("SenderServiceMock.EditMessageFunc: method is nil but SenderService.EditMessage was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		C:       c,
+		Message: message,
+		Opts:    opts,
+	}
+	mock.lockEditMessage.Lock()
+	mock.calls.EditMessage = append(mock.calls.EditMessage, callInfo)
+	mock.lockEditMessage.Unlock()
+	return mock.EditMessageFunc(c, message, opts...)
+}
+
+// EditMessageCalls gets all the calls that were made to EditMessage.
+// Check the length with:
+//
+//	len(mockedSenderService.EditMessageCalls())
+func (mock *SenderServiceMock) EditMessageCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+	// Message is the message argument value.
+	Message string
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockEditMessage.RLock()
+	calls = mock.calls.EditMessage
+	mock.lockEditMessage.RUnlock()
+	return calls
+}
+
+// RemoveKeyboard calls RemoveKeyboardFunc.
+func (mock *SenderServiceMock) RemoveKeyboard(c tele.Context) error {
+	if mock.RemoveKeyboardFunc == nil {
+		panic("SenderServiceMock.RemoveKeyboardFunc: method is nil but SenderService.RemoveKeyboard was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+	}{
+		C: c,
+	}
+	mock.lockRemoveKeyboard.Lock()
+	mock.calls.RemoveKeyboard = append(mock.calls.RemoveKeyboard, callInfo)
+	mock.lockRemoveKeyboard.Unlock()
+	return mock.RemoveKeyboardFunc(c)
+}
+
+// RemoveKeyboardCalls gets all the calls that were made to RemoveKeyboard.
+// Check the length with:
+//
+//	len(mockedSenderService.RemoveKeyboardCalls())
+func (mock *SenderServiceMock) RemoveKeyboardCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+	}
+	mock.lockRemoveKeyboard.RLock()
+	calls = mock.calls.RemoveKeyboard
+	mock.lockRemoveKeyboard.RUnlock()
+	return calls
+}
+
+// Reply calls ReplyFunc.
+func (mock *SenderServiceMock) Reply(c tele.Context, message string, opts ...any) error {
+	if mock.ReplyFunc == nil {
+		panic("SenderServiceMock.ReplyFunc: method is nil but SenderService.Reply was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		C:       c,
+		Message: message,
+		Opts:    opts,
+	}
+	mock.lockReply.Lock()
+	mock.calls.Reply = append(mock.calls.Reply, callInfo)
+	mock.lockReply.Unlock()
+	return mock.ReplyFunc(c, message, opts...)
+}
+
+// ReplyCalls gets all the calls that were made to Reply.
+// Check the length with:
+//
+//	len(mockedSenderService.ReplyCalls())
+func (mock *SenderServiceMock) ReplyCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+	// Message is the message argument value.
+	Message string
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockReply.RLock()
+	calls = mock.calls.Reply
+	mock.lockReply.RUnlock()
+	return calls
+}
+
+// ReplyWithPhoto calls ReplyWithPhotoFunc.
+func (mock *SenderServiceMock) ReplyWithPhoto(c tele.Context, photo *tele.Photo, opts ...any) error {
+	if mock.ReplyWithPhotoFunc == nil {
+		panic("SenderServiceMock.ReplyWithPhotoFunc: method is nil but SenderService.ReplyWithPhoto was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+		// Photo is the photo argument value.
+		Photo *tele.Photo
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		C:     c,
+		Photo: photo,
+		Opts:  opts,
+	}
+	mock.lockReplyWithPhoto.Lock()
+	mock.calls.ReplyWithPhoto = append(mock.calls.ReplyWithPhoto, callInfo)
+	mock.lockReplyWithPhoto.Unlock()
+	return mock.ReplyWithPhotoFunc(c, photo, opts...)
+}
+
+// ReplyWithPhotoCalls gets all the calls that were made to ReplyWithPhoto.
+// Check the length with:
+//
+//	len(mockedSenderService.ReplyWithPhotoCalls())
+func (mock *SenderServiceMock) ReplyWithPhotoCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+	// Photo is the photo argument value.
+	Photo *tele.Photo
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+		// Photo is the photo argument value.
+		Photo *tele.Photo
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockReplyWithPhoto.RLock()
+	calls = mock.calls.ReplyWithPhoto
+	mock.lockReplyWithPhoto.RUnlock()
+	return calls
+}
+
+// Respond calls RespondFunc.
+func (mock *SenderServiceMock) Respond(c tele.Context, response *tele.CallbackResponse) error {
+	if mock.RespondFunc == nil {
+		panic("SenderServiceMock.RespondFunc: method is nil but SenderService.Respond was just called")
+	}
+	callInfo := struct {
+		// C is the c argument value.
+		C tele.Context
+		// Response is the response argument value.
+		Response *tele.CallbackResponse
+	}{
+		C:        c,
+		Response: response,
+	}
+	mock.lockRespond.Lock()
+	mock.calls.Respond = append(mock.calls.Respond, callInfo)
+	mock.lockRespond.Unlock()
+	return mock.RespondFunc(c, response)
+}
+
+// RespondCalls gets all the calls that were made to Respond.
+// Check the length with:
+//
+//	len(mockedSenderService.RespondCalls())
+func (mock *SenderServiceMock) RespondCalls() []struct {
+	// C is the c argument value.
+	C tele.Context
+	// Response is the response argument value.
+	Response *tele.CallbackResponse
+} {
+	var calls []struct {
+		// C is the c argument value.
+		C tele.Context
+		// Response is the response argument value.
+		Response *tele.CallbackResponse
+	}
+	mock.lockRespond.RLock()
+	calls = mock.calls.Respond
+	mock.lockRespond.RUnlock()
+	return calls
+}
+
+// Send calls SendFunc.
+func (mock *SenderServiceMock) Send(ctx context.Context, chatID int64, message string, opts ...any) error {
+	if mock.SendFunc == nil {
+		panic("SenderServiceMock.SendFunc: method is nil but SenderService.Send was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		Ctx:     ctx,
+		ChatID:  chatID,
+		Message: message,
+		Opts:    opts,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(ctx, chatID, message, opts...)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedSenderService.SendCalls())
+func (mock *SenderServiceMock) SendCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ChatID is the chatID argument value.
+	ChatID int64
+	// Message is the message argument value.
+	Message string
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// Message is the message argument value.
+		Message string
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
+
+// SendAny calls SendAnyFunc.
+func (mock *SenderServiceMock) SendAny(ctx context.Context, chatID int64, what any, opts ...any) error {
+	if mock.SendAnyFunc == nil {
+		panic("SenderServiceMock.SendAnyFunc: method is nil but SenderService.SendAny was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// What is the what argument value.
+		What any
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		Ctx:    ctx,
+		ChatID: chatID,
+		What:   what,
+		Opts:   opts,
+	}
+	mock.lockSendAny.Lock()
+	mock.calls.SendAny = append(mock.calls.SendAny, callInfo)
+	mock.lockSendAny.Unlock()
+	return mock.SendAnyFunc(ctx, chatID, what, opts...)
+}
+
+// SendAnyCalls gets all the calls that were made to SendAny.
+// Check the length with:
+//
+//	len(mockedSenderService.SendAnyCalls())
+func (mock *SenderServiceMock) SendAnyCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ChatID is the chatID argument value.
+	ChatID int64
+	// What is the what argument value.
+	What any
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// What is the what argument value.
+		What any
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockSendAny.RLock()
+	calls = mock.calls.SendAny
+	mock.lockSendAny.RUnlock()
+	return calls
+}
+
+// SendPhoto calls SendPhotoFunc.
+func (mock *SenderServiceMock) SendPhoto(ctx context.Context, chatID int64, photo *tele.Photo, opts ...any) error {
+	if mock.SendPhotoFunc == nil {
+		panic("SenderServiceMock.SendPhotoFunc: method is nil but SenderService.SendPhoto was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// Photo is the photo argument value.
+		Photo *tele.Photo
+		// Opts is the opts argument value.
+		Opts []any
+	}{
+		Ctx:    ctx,
+		ChatID: chatID,
+		Photo:  photo,
+		Opts:   opts,
+	}
+	mock.lockSendPhoto.Lock()
+	mock.calls.SendPhoto = append(mock.calls.SendPhoto, callInfo)
+	mock.lockSendPhoto.Unlock()
+	return mock.SendPhotoFunc(ctx, chatID, photo, opts...)
+}
+
+// SendPhotoCalls gets all the calls that were made to SendPhoto.
+// Check the length with:
+//
+//	len(mockedSenderService.SendPhotoCalls())
+func (mock *SenderServiceMock) SendPhotoCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ChatID is the chatID argument value.
+	ChatID int64
+	// Photo is the photo argument value.
+	Photo *tele.Photo
+	// Opts is the opts argument value.
+	Opts []any
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ChatID is the chatID argument value.
+		ChatID int64
+		// Photo is the photo argument value.
+		Photo *tele.Photo
+		// Opts is the opts argument value.
+		Opts []any
+	}
+	mock.lockSendPhoto.RLock()
+	calls = mock.calls.SendPhoto
+	mock.lockSendPhoto.RUnlock()
+	return calls
+}
+
+// UpdateAdminJobPost calls UpdateAdminJobPostFunc.
+func (mock *SenderServiceMock) UpdateAdminJobPost(ctx context.Context, job *models.Job) error {
+	if mock.UpdateAdminJobPostFunc == nil {
+		panic("SenderServiceMock.UpdateAdminJobPostFunc: method is nil but SenderService.UpdateAdminJobPost was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	mock.lockUpdateAdminJobPost.Lock()
+	mock.calls.UpdateAdminJobPost = append(mock.calls.UpdateAdminJobPost, callInfo)
+	mock.lockUpdateAdminJobPost.Unlock()
+	return mock.UpdateAdminJobPostFunc(ctx, job)
+}
+
+// UpdateAdminJobPostCalls gets all the calls that were made to UpdateAdminJobPost.
+// Check the length with:
+//
+//	len(mockedSenderService.UpdateAdminJobPostCalls())
+func (mock *SenderServiceMock) UpdateAdminJobPostCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Job is the job argument value.
+	Job *models.Job
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}
+	mock.lockUpdateAdminJobPost.RLock()
+	calls = mock.calls.UpdateAdminJobPost
+	mock.lockUpdateAdminJobPost.RUnlock()
+	return calls
+}
+
+// UpdateChannelJobPost calls UpdateChannelJobPostFunc.
+func (mock *SenderServiceMock) UpdateChannelJobPost(ctx context.Context, job *models.Job) error {
+	if mock.UpdateChannelJobPostFunc == nil {
+		panic("SenderServiceMock.UpdateChannelJobPostFunc: method is nil but SenderService.UpdateChannelJobPost was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	mock.lockUpdateChannelJobPost.Lock()
+	mock.calls.UpdateChannelJobPost = append(mock.calls.UpdateChannelJobPost, callInfo)
+	mock.lockUpdateChannelJobPost.Unlock()
+	return mock.UpdateChannelJobPostFunc(ctx, job)
+}
+
+// UpdateChannelJobPostCalls gets all the calls that were made to UpdateChannelJobPost.
+// Check the length with:
+//
+//	len(mockedSenderService.UpdateChannelJobPostCalls())
+func (mock *SenderServiceMock) UpdateChannelJobPostCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Job is the job argument value.
+	Job *models.Job
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Job is the job argument value.
+		Job *models.Job
+	}
+	mock.lockUpdateChannelJobPost.RLock()
+	calls = mock.calls.UpdateChannelJobPost
+	mock.lockUpdateChannelJobPost.RUnlock()
+	return calls
+}
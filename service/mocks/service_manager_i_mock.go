@@ -0,0 +1,360 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"sync"
+
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that ServiceManagerIMock does implement service.ServiceManagerI.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.ServiceManagerI = &ServiceManagerIMock{}
+
+// ServiceManagerIMock is a mock implementation of service.ServiceManagerI.
+type ServiceManagerIMock struct {
+	// BackupFunc mocks the Backup method.
+	BackupFunc func() service.BackupService
+
+	// BookingFunc mocks the Booking method.
+	BookingFunc func() service.BookingService
+
+	// BookingStatusFunc mocks the BookingStatus method.
+	BookingStatusFunc func() service.BookingStatusService
+
+	// ChannelIndexFunc mocks the ChannelIndex method.
+	ChannelIndexFunc func() service.ChannelIndexService
+
+	// ChannelPostsFunc mocks the ChannelPosts method.
+	ChannelPostsFunc func() service.ChannelPostService
+
+	// PaymentFunc mocks the Payment method.
+	PaymentFunc func() service.PaymentService
+
+	// ReengageFunc mocks the Reengage method.
+	ReengageFunc func() service.ReengageService
+
+	// RegistrationFunc mocks the Registration method.
+	RegistrationFunc func() service.RegistrationService
+
+	// SenderFunc mocks the Sender method.
+	SenderFunc func() service.SenderService
+
+	// SettingsFunc mocks the Settings method.
+	SettingsFunc func() service.SettingsService
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Backup holds details about calls to the Backup method.
+		Backup []struct {
+		}
+		// Booking holds details about calls to the Booking method.
+		Booking []struct {
+		}
+		// BookingStatus holds details about calls to the BookingStatus method.
+		BookingStatus []struct {
+		}
+		// ChannelIndex holds details about calls to the ChannelIndex method.
+		ChannelIndex []struct {
+		}
+		// ChannelPosts holds details about calls to the ChannelPosts method.
+		ChannelPosts []struct {
+		}
+		// Payment holds details about calls to the Payment method.
+		Payment []struct {
+		}
+		// Reengage holds details about calls to the Reengage method.
+		Reengage []struct {
+		}
+		// Registration holds details about calls to the Registration method.
+		Registration []struct {
+		}
+		// Sender holds details about calls to the Sender method.
+		Sender []struct {
+		}
+		// Settings holds details about calls to the Settings method.
+		Settings []struct {
+		}
+	}
+	lockBackup        sync.RWMutex
+	lockBooking       sync.RWMutex
+	lockBookingStatus sync.RWMutex
+	lockChannelIndex  sync.RWMutex
+	lockChannelPosts  sync.RWMutex
+	lockPayment       sync.RWMutex
+	lockReengage      sync.RWMutex
+	lockRegistration  sync.RWMutex
+	lockSender        sync.RWMutex
+	lockSettings      sync.RWMutex
+}
+
+// Backup calls BackupFunc.
+func (mock *ServiceManagerIMock) Backup() service.BackupService {
+	if mock.BackupFunc == nil {
+		panic("ServiceManagerIMock.BackupFunc: method is nil but ServiceManagerI.Backup was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBackup.Lock()
+	mock.calls.Backup = append(mock.calls.Backup, callInfo)
+	mock.lockBackup.Unlock()
+	return mock.BackupFunc()
+}
+
+// BackupCalls gets all the calls that were made to Backup.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.BackupCalls())
+func (mock *ServiceManagerIMock) BackupCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBackup.RLock()
+	calls = mock.calls.Backup
+	mock.lockBackup.RUnlock()
+	return calls
+}
+
+// Booking calls BookingFunc.
+func (mock *ServiceManagerIMock) Booking() service.BookingService {
+	if mock.BookingFunc == nil {
+		panic("ServiceManagerIMock.BookingFunc: method is nil but ServiceManagerI.Booking was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBooking.Lock()
+	mock.calls.Booking = append(mock.calls.Booking, callInfo)
+	mock.lockBooking.Unlock()
+	return mock.BookingFunc()
+}
+
+// BookingCalls gets all the calls that were made to Booking.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.BookingCalls())
+func (mock *ServiceManagerIMock) BookingCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBooking.RLock()
+	calls = mock.calls.Booking
+	mock.lockBooking.RUnlock()
+	return calls
+}
+
+// BookingStatus calls BookingStatusFunc.
+func (mock *ServiceManagerIMock) BookingStatus() service.BookingStatusService {
+	if mock.BookingStatusFunc == nil {
+		panic("ServiceManagerIMock.BookingStatusFunc: method is nil but ServiceManagerI.BookingStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBookingStatus.Lock()
+	mock.calls.BookingStatus = append(mock.calls.BookingStatus, callInfo)
+	mock.lockBookingStatus.Unlock()
+	return mock.BookingStatusFunc()
+}
+
+// BookingStatusCalls gets all the calls that were made to BookingStatus.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.BookingStatusCalls())
+func (mock *ServiceManagerIMock) BookingStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBookingStatus.RLock()
+	calls = mock.calls.BookingStatus
+	mock.lockBookingStatus.RUnlock()
+	return calls
+}
+
+// ChannelIndex calls ChannelIndexFunc.
+func (mock *ServiceManagerIMock) ChannelIndex() service.ChannelIndexService {
+	if mock.ChannelIndexFunc == nil {
+		panic("ServiceManagerIMock.ChannelIndexFunc: method is nil but ServiceManagerI.ChannelIndex was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockChannelIndex.Lock()
+	mock.calls.ChannelIndex = append(mock.calls.ChannelIndex, callInfo)
+	mock.lockChannelIndex.Unlock()
+	return mock.ChannelIndexFunc()
+}
+
+// ChannelIndexCalls gets all the calls that were made to ChannelIndex.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.ChannelIndexCalls())
+func (mock *ServiceManagerIMock) ChannelIndexCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockChannelIndex.RLock()
+	calls = mock.calls.ChannelIndex
+	mock.lockChannelIndex.RUnlock()
+	return calls
+}
+
+// ChannelPosts calls ChannelPostsFunc.
+func (mock *ServiceManagerIMock) ChannelPosts() service.ChannelPostService {
+	if mock.ChannelPostsFunc == nil {
+		panic("ServiceManagerIMock.ChannelPostsFunc: method is nil but ServiceManagerI.ChannelPosts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockChannelPosts.Lock()
+	mock.calls.ChannelPosts = append(mock.calls.ChannelPosts, callInfo)
+	mock.lockChannelPosts.Unlock()
+	return mock.ChannelPostsFunc()
+}
+
+// ChannelPostsCalls gets all the calls that were made to ChannelPosts.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.ChannelPostsCalls())
+func (mock *ServiceManagerIMock) ChannelPostsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockChannelPosts.RLock()
+	calls = mock.calls.ChannelPosts
+	mock.lockChannelPosts.RUnlock()
+	return calls
+}
+
+// Payment calls PaymentFunc.
+func (mock *ServiceManagerIMock) Payment() service.PaymentService {
+	if mock.PaymentFunc == nil {
+		panic("ServiceManagerIMock.PaymentFunc: method is nil but ServiceManagerI.Payment was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockPayment.Lock()
+	mock.calls.Payment = append(mock.calls.Payment, callInfo)
+	mock.lockPayment.Unlock()
+	return mock.PaymentFunc()
+}
+
+// PaymentCalls gets all the calls that were made to Payment.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.PaymentCalls())
+func (mock *ServiceManagerIMock) PaymentCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPayment.RLock()
+	calls = mock.calls.Payment
+	mock.lockPayment.RUnlock()
+	return calls
+}
+
+// Reengage calls ReengageFunc.
+func (mock *ServiceManagerIMock) Reengage() service.ReengageService {
+	if mock.ReengageFunc == nil {
+		panic("ServiceManagerIMock.ReengageFunc: method is nil but ServiceManagerI.Reengage was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockReengage.Lock()
+	mock.calls.Reengage = append(mock.calls.Reengage, callInfo)
+	mock.lockReengage.Unlock()
+	return mock.ReengageFunc()
+}
+
+// ReengageCalls gets all the calls that were made to Reengage.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.ReengageCalls())
+func (mock *ServiceManagerIMock) ReengageCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReengage.RLock()
+	calls = mock.calls.Reengage
+	mock.lockReengage.RUnlock()
+	return calls
+}
+
+// Registration calls RegistrationFunc.
+func (mock *ServiceManagerIMock) Registration() service.RegistrationService {
+	if mock.RegistrationFunc == nil {
+		panic("ServiceManagerIMock.RegistrationFunc: method is nil but ServiceManagerI.Registration was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRegistration.Lock()
+	mock.calls.Registration = append(mock.calls.Registration, callInfo)
+	mock.lockRegistration.Unlock()
+	return mock.RegistrationFunc()
+}
+
+// RegistrationCalls gets all the calls that were made to Registration.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.RegistrationCalls())
+func (mock *ServiceManagerIMock) RegistrationCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRegistration.RLock()
+	calls = mock.calls.Registration
+	mock.lockRegistration.RUnlock()
+	return calls
+}
+
+// Sender calls SenderFunc.
+func (mock *ServiceManagerIMock) Sender() service.SenderService {
+	if mock.SenderFunc == nil {
+		panic("ServiceManagerIMock.SenderFunc: method is nil but ServiceManagerI.Sender was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockSender.Lock()
+	mock.calls.Sender = append(mock.calls.Sender, callInfo)
+	mock.lockSender.Unlock()
+	return mock.SenderFunc()
+}
+
+// SenderCalls gets all the calls that were made to Sender.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.SenderCalls())
+func (mock *ServiceManagerIMock) SenderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSender.RLock()
+	calls = mock.calls.Sender
+	mock.lockSender.RUnlock()
+	return calls
+}
+
+// Settings calls SettingsFunc.
+func (mock *ServiceManagerIMock) Settings() service.SettingsService {
+	if mock.SettingsFunc == nil {
+		panic("ServiceManagerIMock.SettingsFunc: method is nil but ServiceManagerI.Settings was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockSettings.Lock()
+	mock.calls.Settings = append(mock.calls.Settings, callInfo)
+	mock.lockSettings.Unlock()
+	return mock.SettingsFunc()
+}
+
+// SettingsCalls gets all the calls that were made to Settings.
+// Check the length with:
+//
+//	len(mockedServiceManagerI.SettingsCalls())
+func (mock *ServiceManagerIMock) SettingsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSettings.RLock()
+	calls = mock.calls.Settings
+	mock.lockSettings.RUnlock()
+	return calls
+}
@@ -0,0 +1,418 @@
+// Code generated by genmocks; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/service"
+)
+
+// Ensure, that SettingsServiceMock does implement service.SettingsService.
+// If this is not the case, regenerate this file with genmocks.
+var _ service.SettingsService = &SettingsServiceMock{}
+
+// SettingsServiceMock is a mock implementation of service.SettingsService.
+type SettingsServiceMock struct {
+	// AdminIDsFunc mocks the AdminIDs method.
+	AdminIDsFunc func(ctx context.Context) []int64
+
+	// CardFunc mocks the Card method.
+	CardFunc func(ctx context.Context) models.PaymentCard
+
+	// InvalidateFunc mocks the Invalidate method.
+	InvalidateFunc func()
+
+	// IsAdminFunc mocks the IsAdmin method.
+	IsAdminFunc func(ctx context.Context, userID int64) bool
+
+	// IsSuperAdminFunc mocks the IsSuperAdmin method.
+	IsSuperAdminFunc func(userID int64) bool
+
+	// OverriddenFunc mocks the Overridden method.
+	OverriddenFunc func(ctx context.Context) map[string]bool
+
+	// SetAdminIDsFunc mocks the SetAdminIDs method.
+	SetAdminIDsFunc func(ctx context.Context, input string, updatedBy int64) ([]int64, error)
+
+	// SetCardFunc mocks the SetCard method.
+	SetCardFunc func(ctx context.Context, number string, holderName string, updatedBy int64) (models.PaymentCard, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AdminIDs holds details about calls to the AdminIDs method.
+		AdminIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Card holds details about calls to the Card method.
+		Card []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Invalidate holds details about calls to the Invalidate method.
+		Invalidate []struct {
+		}
+		// IsAdmin holds details about calls to the IsAdmin method.
+		IsAdmin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// IsSuperAdmin holds details about calls to the IsSuperAdmin method.
+		IsSuperAdmin []struct {
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// Overridden holds details about calls to the Overridden method.
+		Overridden []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SetAdminIDs holds details about calls to the SetAdminIDs method.
+		SetAdminIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input string
+			// UpdatedBy is the updatedBy argument value.
+			UpdatedBy int64
+		}
+		// SetCard holds details about calls to the SetCard method.
+		SetCard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Number is the number argument value.
+			Number string
+			// HolderName is the holderName argument value.
+			HolderName string
+			// UpdatedBy is the updatedBy argument value.
+			UpdatedBy int64
+		}
+	}
+	lockAdminIDs     sync.RWMutex
+	lockCard         sync.RWMutex
+	lockInvalidate   sync.RWMutex
+	lockIsAdmin      sync.RWMutex
+	lockIsSuperAdmin sync.RWMutex
+	lockOverridden   sync.RWMutex
+	lockSetAdminIDs  sync.RWMutex
+	lockSetCard      sync.RWMutex
+}
+
+// AdminIDs calls AdminIDsFunc.
+func (mock *SettingsServiceMock) AdminIDs(ctx context.Context) []int64 {
+	if mock.AdminIDsFunc == nil {
+		panic("SettingsServiceMock.AdminIDsFunc: method is nil but SettingsService.AdminIDs was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockAdminIDs.Lock()
+	mock.calls.AdminIDs = append(mock.calls.AdminIDs, callInfo)
+	mock.lockAdminIDs.Unlock()
+	return mock.AdminIDsFunc(ctx)
+}
+
+// AdminIDsCalls gets all the calls that were made to AdminIDs.
+// Check the length with:
+//
+//	len(mockedSettingsService.AdminIDsCalls())
+func (mock *SettingsServiceMock) AdminIDsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockAdminIDs.RLock()
+	calls = mock.calls.AdminIDs
+	mock.lockAdminIDs.RUnlock()
+	return calls
+}
+
+// Card calls CardFunc.
+func (mock *SettingsServiceMock) Card(ctx context.Context) models.PaymentCard {
+	if mock.CardFunc == nil {
+		panic("SettingsServiceMock.CardFunc: method is nil but SettingsService.Card was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCard.Lock()
+	mock.calls.Card = append(mock.calls.Card, callInfo)
+	mock.lockCard.Unlock()
+	return mock.CardFunc(ctx)
+}
+
+// CardCalls gets all the calls that were made to Card.
+// Check the length with:
+//
+//	len(mockedSettingsService.CardCalls())
+func (mock *SettingsServiceMock) CardCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockCard.RLock()
+	calls = mock.calls.Card
+	mock.lockCard.RUnlock()
+	return calls
+}
+
+// Invalidate calls InvalidateFunc.
+func (mock *SettingsServiceMock) Invalidate() {
+	if mock.InvalidateFunc == nil {
+		panic("SettingsServiceMock.InvalidateFunc: method is nil but SettingsService.Invalidate was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockInvalidate.Lock()
+	mock.calls.Invalidate = append(mock.calls.Invalidate, callInfo)
+	mock.lockInvalidate.Unlock()
+	mock.InvalidateFunc()
+}
+
+// InvalidateCalls gets all the calls that were made to Invalidate.
+// Check the length with:
+//
+//	len(mockedSettingsService.InvalidateCalls())
+func (mock *SettingsServiceMock) InvalidateCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockInvalidate.RLock()
+	calls = mock.calls.Invalidate
+	mock.lockInvalidate.RUnlock()
+	return calls
+}
+
+// IsAdmin calls IsAdminFunc.
+func (mock *SettingsServiceMock) IsAdmin(ctx context.Context, userID int64) bool {
+	if mock.IsAdminFunc == nil {
+		panic("SettingsServiceMock.IsAdminFunc: method is nil but SettingsService.IsAdmin was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIsAdmin.Lock()
+	mock.calls.IsAdmin = append(mock.calls.IsAdmin, callInfo)
+	mock.lockIsAdmin.Unlock()
+	return mock.IsAdminFunc(ctx, userID)
+}
+
+// IsAdminCalls gets all the calls that were made to IsAdmin.
+// Check the length with:
+//
+//	len(mockedSettingsService.IsAdminCalls())
+func (mock *SettingsServiceMock) IsAdminCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockIsAdmin.RLock()
+	calls = mock.calls.IsAdmin
+	mock.lockIsAdmin.RUnlock()
+	return calls
+}
+
+// IsSuperAdmin calls IsSuperAdminFunc.
+func (mock *SettingsServiceMock) IsSuperAdmin(userID int64) bool {
+	if mock.IsSuperAdminFunc == nil {
+		panic("SettingsServiceMock.IsSuperAdminFunc: method is nil but SettingsService.IsSuperAdmin was just called")
+	}
+	callInfo := struct {
+		// UserID is the userID argument value.
+		UserID int64
+	}{
+		UserID: userID,
+	}
+	mock.lockIsSuperAdmin.Lock()
+	mock.calls.IsSuperAdmin = append(mock.calls.IsSuperAdmin, callInfo)
+	mock.lockIsSuperAdmin.Unlock()
+	return mock.IsSuperAdminFunc(userID)
+}
+
+// IsSuperAdminCalls gets all the calls that were made to IsSuperAdmin.
+// Check the length with:
+//
+//	len(mockedSettingsService.IsSuperAdminCalls())
+func (mock *SettingsServiceMock) IsSuperAdminCalls() []struct {
+	// UserID is the userID argument value.
+	UserID int64
+} {
+	var calls []struct {
+		// UserID is the userID argument value.
+		UserID int64
+	}
+	mock.lockIsSuperAdmin.RLock()
+	calls = mock.calls.IsSuperAdmin
+	mock.lockIsSuperAdmin.RUnlock()
+	return calls
+}
+
+// Overridden calls OverriddenFunc.
+func (mock *SettingsServiceMock) Overridden(ctx context.Context) map[string]bool {
+	if mock.OverriddenFunc == nil {
+		panic("SettingsServiceMock.OverriddenFunc: method is nil but SettingsService.Overridden was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockOverridden.Lock()
+	mock.calls.Overridden = append(mock.calls.Overridden, callInfo)
+	mock.lockOverridden.Unlock()
+	return mock.OverriddenFunc(ctx)
+}
+
+// OverriddenCalls gets all the calls that were made to Overridden.
+// Check the length with:
+//
+//	len(mockedSettingsService.OverriddenCalls())
+func (mock *SettingsServiceMock) OverriddenCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockOverridden.RLock()
+	calls = mock.calls.Overridden
+	mock.lockOverridden.RUnlock()
+	return calls
+}
+
+// SetAdminIDs calls SetAdminIDsFunc.
+func (mock *SettingsServiceMock) SetAdminIDs(ctx context.Context, input string, updatedBy int64) ([]int64, error) {
+	if mock.SetAdminIDsFunc == nil {
+		panic("SettingsServiceMock.SetAdminIDsFunc: method is nil but SettingsService.SetAdminIDs was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Input is the input argument value.
+		Input string
+		// UpdatedBy is the updatedBy argument value.
+		UpdatedBy int64
+	}{
+		Ctx:       ctx,
+		Input:     input,
+		UpdatedBy: updatedBy,
+	}
+	mock.lockSetAdminIDs.Lock()
+	mock.calls.SetAdminIDs = append(mock.calls.SetAdminIDs, callInfo)
+	mock.lockSetAdminIDs.Unlock()
+	return mock.SetAdminIDsFunc(ctx, input, updatedBy)
+}
+
+// SetAdminIDsCalls gets all the calls that were made to SetAdminIDs.
+// Check the length with:
+//
+//	len(mockedSettingsService.SetAdminIDsCalls())
+func (mock *SettingsServiceMock) SetAdminIDsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Input is the input argument value.
+	Input string
+	// UpdatedBy is the updatedBy argument value.
+	UpdatedBy int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Input is the input argument value.
+		Input string
+		// UpdatedBy is the updatedBy argument value.
+		UpdatedBy int64
+	}
+	mock.lockSetAdminIDs.RLock()
+	calls = mock.calls.SetAdminIDs
+	mock.lockSetAdminIDs.RUnlock()
+	return calls
+}
+
+// SetCard calls SetCardFunc.
+func (mock *SettingsServiceMock) SetCard(ctx context.Context, number string, holderName string, updatedBy int64) (models.PaymentCard, error) {
+	if mock.SetCardFunc == nil {
+		panic("SettingsServiceMock.SetCardFunc: method is nil but SettingsService.SetCard was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Number is the number argument value.
+		Number string
+		// HolderName is the holderName argument value.
+		HolderName string
+		// UpdatedBy is the updatedBy argument value.
+		UpdatedBy int64
+	}{
+		Ctx:        ctx,
+		Number:     number,
+		HolderName: holderName,
+		UpdatedBy:  updatedBy,
+	}
+	mock.lockSetCard.Lock()
+	mock.calls.SetCard = append(mock.calls.SetCard, callInfo)
+	mock.lockSetCard.Unlock()
+	return mock.SetCardFunc(ctx, number, holderName, updatedBy)
+}
+
+// SetCardCalls gets all the calls that were made to SetCard.
+// Check the length with:
+//
+//	len(mockedSettingsService.SetCardCalls())
+func (mock *SettingsServiceMock) SetCardCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Number is the number argument value.
+	Number string
+	// HolderName is the holderName argument value.
+	HolderName string
+	// UpdatedBy is the updatedBy argument value.
+	UpdatedBy int64
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Number is the number argument value.
+		Number string
+		// HolderName is the holderName argument value.
+		HolderName string
+		// UpdatedBy is the updatedBy argument value.
+		UpdatedBy int64
+	}
+	mock.lockSetCard.RLock()
+	calls = mock.calls.SetCard
+	mock.lockSetCard.RUnlock()
+	return calls
+}
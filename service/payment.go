@@ -13,37 +13,54 @@ import (
 
 // PaymentService handles payment-related business logic
 type PaymentService interface {
-	SubmitPayment(ctx context.Context, userID int64, photoFileID string, msgID int64) (*models.JobBooking, error)
+	SubmitPayment(ctx context.Context, userID int64, fileIDs []string, isDocument bool, msgID int64, paidAmount int) (*models.JobBooking, error)
 	ApprovePayment(ctx context.Context, bookingID, adminID int64) (*models.JobBooking, error)
 	RejectPayment(ctx context.Context, bookingID, adminID int64, reason string) (*models.JobBooking, error)
 	BlockUserAndRejectPayment(ctx context.Context, bookingID, userID, adminID int64) (*models.JobBooking, error)
+	ForgiveViolation(ctx context.Context, violationID, adminID int64) (*models.UserViolation, error)
+	BlockUserPermanently(ctx context.Context, userID, adminID int64) error
 }
 
 type paymentService struct {
 	cfg     config.Config
 	log     logger.LoggerI
 	storage storage.StorageI
-	manager ServiceManagerI
+	posts   JobPostUpdater
+	clock   Clock
 }
 
 // NewPaymentService creates a new payment service
-func NewPaymentService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, manager ServiceManagerI) PaymentService {
+func NewPaymentService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, posts JobPostUpdater, clock Clock) PaymentService {
 	return &paymentService{
 		cfg:     cfg,
 		log:     log,
 		storage: storage,
-		manager: manager,
+		posts:   posts,
+		clock:   clock,
 	}
 }
 
-// SubmitPayment handles payment receipt submission
-func (s *paymentService) SubmitPayment(ctx context.Context, userID int64, photoFileID string, msgID int64) (*models.JobBooking, error) {
-	// Find user's most recent SLOT_RESERVED booking
+// SubmitPayment handles payment receipt submission. fileIDs has more than one photo when the receipt was an album;
+// isDocument marks a receipt sent as a file (e.g. a bank's PDF export); paidAmount is what the user said they
+// transferred (0 = not given).
+func (s *paymentService) SubmitPayment(ctx context.Context, userID int64, fileIDs []string, isDocument bool, msgID int64, paidAmount int) (*models.JobBooking, error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("no receipt photo")
+	}
+
+	// Find user's most recent SLOT_RESERVED booking, or one whose receipt may be resent
 	bookings, err := s.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusSlotReserved)
 	if err != nil {
 		s.log.Error("Failed to get user bookings", logger.Error(err))
 		return nil, fmt.Errorf("failed to get bookings: %w", err)
 	}
+	if len(bookings) == 0 {
+		bookings, err = s.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusPaymentRejectedRetryable)
+		if err != nil {
+			s.log.Error("Failed to get user bookings", logger.Error(err))
+			return nil, fmt.Errorf("failed to get bookings: %w", err)
+		}
+	}
 
 	if len(bookings) == 0 {
 		return nil, fmt.Errorf("no pending booking found")
@@ -52,7 +69,7 @@ func (s *paymentService) SubmitPayment(ctx context.Context, userID int64, photoF
 	booking := bookings[0]
 
 	// Check if booking has expired
-	if time.Now().After(booking.ExpiresAt) {
+	if booking.IsExpiredAt(s.clock.Now()) {
 		return nil, fmt.Errorf("booking has expired")
 	}
 
@@ -66,13 +83,30 @@ func (s *paymentService) SubmitPayment(ctx context.Context, userID int64, photoF
 	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
 	defer s.storage.Transaction().Rollback(ctx, tx)
 
+	// Re-read under the lock: an admin or the expiry worker may have moved the booking since the lookup
+	locked, err := s.storage.Booking().GetByIDForUpdate(ctx, tx, booking.ID)
+	if err != nil {
+		s.log.Error("Failed to lock booking", logger.Error(err))
+		return nil, fmt.Errorf("failed to lock booking: %w", err)
+	}
+	if err := transitionBooking(ctx, s.storage, tx, booking.ID, locked.Status, models.BookingStatusPaymentSubmitted, userID, ""); err != nil {
+		return nil, err
+	}
+
 	// Update booking with payment info
-	now := time.Now()
+	now := s.clock.Now()
 	booking.Status = models.BookingStatusPaymentSubmitted
-	booking.PaymentReceiptFileID = photoFileID
+	booking.PaymentReceiptFileID = fileIDs[0]
+	booking.PaymentReceiptExtraFileIDs = fileIDs[1:]
+	booking.PaymentReceiptIsDocument = isDocument
 	booking.PaymentReceiptMsgID = msgID
+	booking.PaidAmount = paidAmount
 	booking.PaymentSubmittedAt = &now
 
+	if err := s.storage.Booking().UpdateStatus(ctx, tx, booking.ID, booking.Status); err != nil {
+		s.log.Error("Failed to update booking status", logger.Error(err))
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	}
 	if err := s.storage.Booking().Update(ctx, tx, booking); err != nil {
 		s.log.Error("Failed to update booking", logger.Error(err))
 		return nil, fmt.Errorf("failed to update booking: %w", err)
@@ -116,13 +150,21 @@ func (s *paymentService) ApprovePayment(ctx context.Context, bookingID, adminID
 		return nil, fmt.Errorf("payment already processed: %s", booking.Status)
 	}
 
+	if err := transitionBooking(ctx, s.storage, tx, bookingID, booking.Status, models.BookingStatusConfirmed, adminID, ""); err != nil {
+		return nil, err
+	}
+
 	// Update booking status to CONFIRMED
-	now := time.Now()
+	now := s.clock.Now()
 	booking.Status = models.BookingStatusConfirmed
 	booking.ConfirmedAt = &now
 	booking.ReviewedByAdminID = &adminID
 	booking.ReviewedAt = &now
 
+	if err := s.storage.Booking().UpdateStatus(ctx, tx, booking.ID, booking.Status); err != nil {
+		s.log.Error("Failed to update booking status", logger.Error(err))
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	}
 	if err := s.storage.Booking().Update(ctx, tx, booking); err != nil {
 		s.log.Error("Failed to update booking", logger.Error(err))
 		return nil, fmt.Errorf("failed to update booking: %w", err)
@@ -142,7 +184,7 @@ func (s *paymentService) ApprovePayment(ctx context.Context, bookingID, adminID
 	}
 
 	// Check if job is now full and update status within transaction
-	if job.IsCompletelyFull() && job.Status != models.JobStatusFull {
+	if job.Status == models.JobStatusActive && job.SlotStatus() == models.JobStatusFull {
 		if err := s.storage.Job().UpdateStatusInTx(ctx, tx, job.ID, models.JobStatusFull); err != nil {
 			s.log.Error("Failed to update job status to FULL", logger.Error(err))
 			// Don't return error, just log it
@@ -164,15 +206,16 @@ func (s *paymentService) ApprovePayment(ctx context.Context, bookingID, adminID
 	)
 
 	// Update channel and admin messages after successful commit
-	if s.manager != nil {
-		go s.manager.Sender().UpdateChannelJobPost(context.Background(), job)
-		go s.manager.Sender().UpdateAdminJobPost(context.Background(), job)
+	if s.posts != nil {
+		go s.posts.UpdateChannelJobPost(context.WithoutCancel(ctx), job)
+		go s.posts.UpdateAdminJobPost(context.WithoutCancel(ctx), job)
 	}
 
 	return booking, nil
 }
 
-// RejectPayment rejects a payment and releases the slot
+// RejectPayment rejects a payment. While the booking has resubmission attempts left the slot stays
+// held and the booking becomes PAYMENT_REJECTED_RETRYABLE; otherwise it is REJECTED and the slot released.
 func (s *paymentService) RejectPayment(ctx context.Context, bookingID, adminID int64, reason string) (*models.JobBooking, error) {
 	// Start transaction
 	tx, err := s.storage.Transaction().Begin(ctx)
@@ -196,13 +239,56 @@ func (s *paymentService) RejectPayment(ctx context.Context, bookingID, adminID i
 		return nil, fmt.Errorf("payment already processed: %s", booking.Status)
 	}
 
+	now := s.clock.Now()
+
+	// Keep the slot and let the user resend a clearer receipt
+	if booking.PaymentRejections < s.cfg.Payment.ResubmitAttempts {
+		if err := transitionBooking(ctx, s.storage, tx, bookingID, booking.Status, models.BookingStatusPaymentRejectedRetryable, adminID, reason); err != nil {
+			return nil, err
+		}
+
+		expiresAt := now.Add(s.cfg.Payment.ResubmitWindow)
+		if err := s.storage.Booking().MarkAsRetryable(ctx, tx, bookingID, adminID, reason, expiresAt); err != nil {
+			s.log.Error("Failed to mark booking retryable", logger.Error(err))
+			return nil, fmt.Errorf("failed to update booking: %w", err)
+		}
+
+		if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
+			s.log.Error("Failed to commit transaction", logger.Error(err))
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+
+		booking.Status = models.BookingStatusPaymentRejectedRetryable
+		booking.ReviewedByAdminID = &adminID
+		booking.ReviewedAt = &now
+		booking.RejectionReason = reason
+		booking.ExpiresAt = expiresAt
+		booking.PaymentRejections++
+
+		s.log.Info("Payment rejected, resubmission allowed",
+			logger.Any("booking_id", bookingID),
+			logger.Any("admin_id", adminID),
+			logger.Any("attempt", booking.PaymentRejections),
+			logger.Any("expires_at", expiresAt),
+		)
+
+		return booking, nil
+	}
+
+	if err := transitionBooking(ctx, s.storage, tx, bookingID, booking.Status, models.BookingStatusRejected, adminID, reason); err != nil {
+		return nil, err
+	}
+
 	// Update booking status to REJECTED
-	now := time.Now()
 	booking.Status = models.BookingStatusRejected
 	booking.ReviewedByAdminID = &adminID
 	booking.ReviewedAt = &now
 	booking.RejectionReason = reason
 
+	if err := s.storage.Booking().UpdateStatus(ctx, tx, booking.ID, booking.Status); err != nil {
+		s.log.Error("Failed to update booking status", logger.Error(err))
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	}
 	if err := s.storage.Booking().Update(ctx, tx, booking); err != nil {
 		s.log.Error("Failed to update booking", logger.Error(err))
 		return nil, fmt.Errorf("failed to update booking: %w", err)
@@ -249,12 +335,21 @@ func (s *paymentService) BlockUserAndRejectPayment(ctx context.Context, bookingI
 
 	// Reject booking if not already processed
 	if booking.Status == models.BookingStatusPaymentSubmitted {
-		now := time.Now()
+		reason := "Soxta to'lov kvitansiyasi"
+		if err := transitionBooking(ctx, s.storage, tx, bookingID, booking.Status, models.BookingStatusRejected, adminID, reason); err != nil {
+			return nil, err
+		}
+
+		now := s.clock.Now()
 		booking.Status = models.BookingStatusRejected
 		booking.ReviewedByAdminID = &adminID
 		booking.ReviewedAt = &now
-		booking.RejectionReason = "Soxta to'lov kvitansiyasi"
+		booking.RejectionReason = reason
 
+		if err := s.storage.Booking().UpdateStatus(ctx, tx, booking.ID, booking.Status); err != nil {
+			s.log.Error("Failed to update booking status", logger.Error(err))
+			return nil, fmt.Errorf("failed to update booking status: %w", err)
+		}
 		if err := s.storage.Booking().Update(ctx, tx, booking); err != nil {
 			s.log.Error("Failed to update booking", logger.Error(err))
 			return nil, fmt.Errorf("failed to update booking: %w", err)
@@ -295,7 +390,7 @@ func (s *paymentService) BlockUserAndRejectPayment(ctx context.Context, bookingI
 		reason = "⚠️ Ogohlantirish: Soxta to'lov kvitansiyasi yuborildi"
 		// No block, just warning
 	case 2:
-		t := time.Now().Add(24 * time.Hour)
+		t := s.clock.Now().Add(24 * time.Hour)
 		blockedUntil = &t
 		reason = "⚠️ Ikkinchi marta soxta to'lov! 24 soat bron qilish taqiqlangan"
 	default: // 3 or more
@@ -344,6 +439,123 @@ func (s *paymentService) BlockUserAndRejectPayment(ctx context.Context, bookingI
 		logger.Any("violation_count", violationCount),
 		logger.Any("blocked_until", blockedUntil),
 	)
+	if violationCount >= 2 {
+		s.auditBlock(ctx, adminID, userID, reason)
+	}
 
 	return booking, nil
 }
+
+// ForgiveViolation removes a violation and relaxes the user's block to match the remaining count.
+// Below two violations the block is lifted, mirroring the progressive rules in BlockUserAndRejectPayment.
+func (s *paymentService) ForgiveViolation(ctx context.Context, violationID, adminID int64) (*models.UserViolation, error) {
+	violation, err := s.storage.User().GetViolationByID(ctx, violationID)
+	if err != nil {
+		return nil, fmt.Errorf("violation not found: %w", err)
+	}
+
+	tx, err := s.storage.Transaction().Begin(ctx)
+	if err != nil {
+		s.log.Error("Failed to start transaction", logger.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
+	defer s.storage.Transaction().Rollback(ctx, tx)
+
+	if err := s.storage.User().DeleteViolation(ctx, tx, violationID); err != nil {
+		return nil, fmt.Errorf("failed to delete violation: %w", err)
+	}
+
+	remaining, err := s.storage.User().GetViolationCount(ctx, tx, violation.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get violation count: %w", err)
+	}
+
+	block, err := s.storage.User().GetBlockStatus(ctx, violation.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block status: %w", err)
+	}
+	if block != nil && remaining >= 2 {
+		block.TotalViolations = remaining
+		if err := s.storage.User().BlockUser(ctx, tx, block); err != nil {
+			return nil, fmt.Errorf("failed to update block: %w", err)
+		}
+	}
+
+	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
+		s.log.Error("Failed to commit transaction", logger.Error(err))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if block != nil && remaining < 2 {
+		if err := s.storage.User().UnblockUser(ctx, violation.UserID); err != nil {
+			return nil, fmt.Errorf("failed to unblock user: %w", err)
+		}
+	}
+
+	s.log.Info("Violation forgiven",
+		logger.Any("violation_id", violationID),
+		logger.Any("user_id", violation.UserID),
+		logger.Any("admin_id", adminID),
+		logger.Any("remaining_violations", remaining),
+	)
+
+	return violation, nil
+}
+
+// BlockUserPermanently escalates a user to a permanent block regardless of the violation count
+func (s *paymentService) BlockUserPermanently(ctx context.Context, userID, adminID int64) error {
+	tx, err := s.storage.Transaction().Begin(ctx)
+	if err != nil {
+		s.log.Error("Failed to start transaction", logger.Error(err))
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Always rollback on exit — Rollback after Commit is a harmless no-op in pgx.
+	defer s.storage.Transaction().Rollback(ctx, tx)
+
+	count, err := s.storage.User().GetViolationCount(ctx, tx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get violation count: %w", err)
+	}
+
+	block := &models.BlockedUser{
+		UserID:           userID,
+		BlockedUntil:     nil, // permanent
+		TotalViolations:  count,
+		BlockedByAdminID: adminID,
+		Reason:           "🚫 Admin tomonidan doimiy bloklandi",
+	}
+	if err := s.storage.User().BlockUser(ctx, tx, block); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	if err := s.storage.Transaction().Commit(ctx, tx); err != nil {
+		s.log.Error("Failed to commit transaction", logger.Error(err))
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.Warn("User permanently blocked by admin",
+		logger.Any("user_id", userID),
+		logger.Any("admin_id", adminID),
+		logger.Any("violation_count", count),
+	)
+	s.auditBlock(ctx, adminID, userID, block.Reason)
+
+	return nil
+}
+
+// auditBlock records a block an admin issued (counted in the admin activity report); failures are logged,
+// the block itself already happened
+func (s *paymentService) auditBlock(ctx context.Context, adminID, userID int64, details string) {
+	entry := &models.AuditEntry{
+		ActorID:      &adminID,
+		Action:       models.AuditActionUserBlock,
+		TargetUserID: &userID,
+		Details:      details,
+	}
+	if err := s.storage.Audit().Create(ctx, entry); err != nil {
+		logger.FromContext(ctx, s.log).Error("Failed to record user block", logger.Error(err))
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// paymentSLATimeout is the max time for one backlog check.
+const paymentSLATimeout = 30 * time.Second
+
+// PaymentSLAWorker reminds admins about payment receipts left unreviewed.
+//
+// Once any PAYMENT_SUBMITTED booking has waited longer than BOT_PAYMENT_SLA,
+// the payments group (or each admin without one) gets a reminder. It repeats
+// every BOT_PAYMENT_SLA with a sharper tone until no receipt is overdue.
+type PaymentSLAWorker struct {
+	cfg      *config.Config
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	settings SettingsService
+	sla      time.Duration
+	interval time.Duration
+	stopChan chan struct{}
+
+	level     int       // Reminders sent for the current backlog; 0 when nothing is overdue
+	lastAlert time.Time // When the last reminder went out
+}
+
+// NewPaymentSLAWorker creates a new payment review reminder worker
+func NewPaymentSLAWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, settings SettingsService) *PaymentSLAWorker {
+	return &PaymentSLAWorker{
+		cfg:      cfg,
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		settings: settings,
+		sla:      cfg.Bot.PaymentSLA,
+		interval: time.Minute, // Receipts are checked once a minute
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the payment SLA worker background process
+func (w *PaymentSLAWorker) Start() {
+	if w.sla <= 0 {
+		w.log.Info("Payment SLA worker disabled (BOT_PAYMENT_SLA=0)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Payment SLA worker started", logger.Any("sla", w.sla.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Payment SLA worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the payment SLA worker
+func (w *PaymentSLAWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *PaymentSLAWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in payment SLA worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process sends a reminder when receipts are overdue and the previous one is at least an SLA old
+func (w *PaymentSLAWorker) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), paymentSLATimeout)
+	defer cancel()
+
+	pending, err := w.storage.Booking().GetPendingApprovals(ctx)
+	if err != nil {
+		w.log.Error("Failed to get pending approvals", logger.Error(err))
+		return
+	}
+
+	now := time.Now()
+	overdue, oldest := w.overdue(pending, now)
+	if overdue == 0 {
+		w.level = 0
+		return
+	}
+	if w.level > 0 && now.Sub(w.lastAlert) < w.sla {
+		return
+	}
+
+	w.level++
+	w.lastAlert = now
+
+	w.log.Warn("Payment receipts waiting past SLA",
+		logger.Int("waiting", len(pending)),
+		logger.Int("overdue", overdue),
+		logger.String("oldest", oldest.Round(time.Minute).String()),
+		logger.Int("reminder", w.level),
+	)
+
+	w.remind(messages.FormatPaymentSLAReminder(w.level, len(pending), overdue, w.sla, oldest))
+}
+
+// overdue counts receipts submitted more than an SLA ago and returns the longest wait
+func (w *PaymentSLAWorker) overdue(pending []*models.JobBooking, now time.Time) (int, time.Duration) {
+	count := 0
+	var oldest time.Duration
+	for _, b := range pending {
+		if b.PaymentSubmittedAt == nil {
+			continue
+		}
+		wait := now.Sub(*b.PaymentSubmittedAt)
+		if wait > w.sla {
+			count++
+		}
+		oldest = max(oldest, wait)
+	}
+	return count, oldest
+}
+
+// remind sends to the payments group, or to each admin who kept payment notifications on
+func (w *PaymentSLAWorker) remind(msg string) {
+	if groupID := w.cfg.Bot.PaymentsChatID(); groupID != 0 {
+		if _, err := w.bot.Send(&tele.Chat{ID: groupID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send payment SLA reminder to group", logger.Error(err))
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), paymentSLATimeout)
+	defer cancel()
+
+	for _, adminID := range w.settings.AdminIDs(ctx) {
+		prefs, err := w.storage.AdminPrefs().Get(ctx, adminID)
+		if err == nil && !prefs.IsEnabled(models.NotifyPayments) {
+			continue
+		}
+		if _, err := w.bot.Send(&tele.User{ID: adminID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send payment SLA reminder", logger.Error(err), logger.Any("admin_id", adminID))
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// receiptArchiveTimeout is the max time for one archive batch
+	receiptArchiveTimeout = 2 * time.Minute
+	// receiptArchiveBatch is how many receipts one tick archives
+	receiptArchiveBatch = 20
+	// receiptArchiveMaxSize caps a downloaded receipt; Telegram photos are far smaller
+	receiptArchiveMaxSize = 20 << 20
+	// receiptArchiveMaxAttempts stops retrying a receipt that keeps failing until the next restart
+	receiptArchiveMaxAttempts = 5
+)
+
+// ReceiptStore keeps archived receipts; *objectstore.S3 implements it
+type ReceiptStore interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	ObjectURL(key string) string
+}
+
+// FileDownloader fetches files from Telegram; *tele.Bot implements it
+type FileDownloader interface {
+	File(file *tele.File) (io.ReadCloser, error)
+}
+
+var _ FileDownloader = (*tele.Bot)(nil)
+
+// ReceiptArchiveWorker copies approved payment receipts to object storage.
+//
+// Telegram file IDs stop working when the bot token changes, so every file of
+// a confirmed booking's receipt is downloaded and stored as
+// <ARCHIVE_S3_PREFIX>booking-<id>.<ext>, the other photos of an album as
+// booking-<id>-<n>.<ext>; the object URLs are then recorded on the booking for audits.
+type ReceiptArchiveWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	files    FileDownloader
+	store    ReceiptStore
+	prefix   string
+	interval time.Duration
+	stopChan chan struct{}
+
+	failures map[int64]int // Failed attempts per booking since start
+}
+
+// NewReceiptArchiveWorker creates a new receipt archive worker; a nil store disables it
+func NewReceiptArchiveWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, files FileDownloader, store ReceiptStore) *ReceiptArchiveWorker {
+	return &ReceiptArchiveWorker{
+		storage:  storage,
+		log:      log,
+		files:    files,
+		store:    store,
+		prefix:   cfg.Archive.Prefix,
+		interval: cfg.Archive.Interval,
+		stopChan: make(chan struct{}),
+		failures: make(map[int64]int),
+	}
+}
+
+// Start begins the receipt archive worker background process
+func (w *ReceiptArchiveWorker) Start() {
+	if w.store == nil {
+		w.log.Info("Receipt archive worker disabled (ARCHIVE_S3_BUCKET not set)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Receipt archive worker started", logger.Any("interval", w.interval.String()))
+
+	// Catch up on receipts approved while the bot was down
+	w.safeProcess()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Receipt archive worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the receipt archive worker
+func (w *ReceiptArchiveWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *ReceiptArchiveWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in receipt archive worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process archives the next batch of confirmed receipts
+func (w *ReceiptArchiveWorker) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), receiptArchiveTimeout)
+	defer cancel()
+
+	// Fetch extra rows so receipts given up on don't starve the rest of the batch
+	bookings, err := w.storage.Booking().GetUnarchivedReceipts(ctx, receiptArchiveBatch+len(w.failures))
+	if err != nil {
+		w.log.Error("Failed to get unarchived receipts", logger.Error(err))
+		return
+	}
+
+	archived := 0
+	for _, booking := range bookings {
+		if archived >= receiptArchiveBatch || ctx.Err() != nil {
+			break
+		}
+		if w.failures[booking.ID] >= receiptArchiveMaxAttempts {
+			continue
+		}
+
+		urls, err := w.archiveAll(ctx, booking.ID, booking.ReceiptFileIDs())
+		if err != nil {
+			w.failures[booking.ID]++
+			w.log.Error("Failed to archive payment receipt",
+				logger.Any("booking_id", booking.ID),
+				logger.Any("attempt", w.failures[booking.ID]),
+				logger.Error(err),
+			)
+			continue
+		}
+
+		if err := w.storage.Booking().SetReceiptArchiveURLs(ctx, booking.ID, urls); err != nil {
+			w.log.Error("Failed to record receipt archive URL",
+				logger.Any("booking_id", booking.ID),
+				logger.Error(err),
+			)
+			continue
+		}
+		delete(w.failures, booking.ID)
+		archived++
+	}
+
+	if archived > 0 {
+		w.log.Info("Archived payment receipts", logger.Any("count", archived))
+	}
+}
+
+// archiveAll archives every file of a receipt and returns their URLs in the same order. The booking
+// only counts as archived once all of them are stored, so a failed album photo is retried with the rest.
+func (w *ReceiptArchiveWorker) archiveAll(ctx context.Context, bookingID int64, fileIDs []string) ([]string, error) {
+	urls := make([]string, len(fileIDs))
+	for i, fileID := range fileIDs {
+		name := fmt.Sprintf("booking-%d", bookingID)
+		if i > 0 {
+			name = fmt.Sprintf("booking-%d-%d", bookingID, i+1)
+		}
+		url, err := w.archive(ctx, name, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("file %d of %d: %w", i+1, len(fileIDs), err)
+		}
+		urls[i] = url
+	}
+	return urls, nil
+}
+
+// archive downloads one receipt file from Telegram, uploads it under name and returns its URL
+func (w *ReceiptArchiveWorker) archive(ctx context.Context, name, fileID string) (string, error) {
+	reader, err := w.files.File(&tele.File{FileID: fileID})
+	if err != nil {
+		return "", fmt.Errorf("failed to download receipt: %w", err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(io.LimitReader(reader, receiptArchiveMaxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read receipt: %w", err)
+	}
+	if len(body) > receiptArchiveMaxSize {
+		return "", fmt.Errorf("receipt is larger than %d bytes", receiptArchiveMaxSize)
+	}
+
+	contentType := http.DetectContentType(body)
+	key := w.prefix + name + receiptExtension(contentType)
+	if err := w.store.Put(ctx, key, contentType, body); err != nil {
+		return "", err
+	}
+	return w.store.ObjectURL(key), nil
+}
+
+// receiptExtension picks the file extension for a sniffed content type
+func receiptExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "application/pdf":
+		return ".pdf"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// reengageMaxJobs caps the jobs listed in one campaign message
+	reengageMaxJobs = 8
+	// reengageMaxRecipients caps one campaign; the rest are reached by the next run
+	reengageMaxRecipients = 1000
+)
+
+// Re-engagement errors
+var (
+	ErrNoOpenJobs          = errors.New("no open jobs to advertise")
+	ErrNoInactiveUsers     = errors.New("no inactive users")
+	ErrCampaignRunning     = errors.New("a re-engagement campaign is already running")
+	ErrInvalidReengageDays = errors.New("invalid inactivity period")
+)
+
+// ReengageService sends open jobs to registered users who stopped booking.
+// A user gets at most one campaign message per inactivity period and none after opting out of marketing messages.
+type ReengageService interface {
+	// RunCampaign messages users without bookings for inactiveDays; startedBy is 0 for the daily run
+	RunCampaign(ctx context.Context, inactiveDays int, startedBy int64) (*models.ReengageCampaign, error)
+	// GetReports returns the newest campaigns with their opens and bookings
+	GetReports(ctx context.Context, limit int) ([]*models.ReengageCampaign, error)
+}
+
+type reengageService struct {
+	cfg     config.Config
+	log     logger.LoggerI
+	storage storage.StorageI
+	sender  SenderService
+	clock   Clock
+
+	// running keeps a manual and the daily campaign from picking the same users
+	running sync.Mutex
+}
+
+// NewReengageService creates a new re-engagement campaign service
+func NewReengageService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, sender SenderService, clock Clock) ReengageService {
+	return &reengageService{
+		cfg:     cfg,
+		log:     log,
+		storage: storage,
+		sender:  sender,
+		clock:   clock,
+	}
+}
+
+// RunCampaign picks the inactive users, sends them the open jobs through the paced queue and records the results
+func (s *reengageService) RunCampaign(ctx context.Context, inactiveDays int, startedBy int64) (*models.ReengageCampaign, error) {
+	if inactiveDays <= 0 {
+		return nil, ErrInvalidReengageDays
+	}
+	if !s.running.TryLock() {
+		return nil, ErrCampaignRunning
+	}
+	defer s.running.Unlock()
+
+	jobs, err := s.openJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, ErrNoOpenJobs
+	}
+
+	now := s.clock.Now()
+	userIDs, err := s.storage.Reengage().GetInactiveUsers(ctx, now.Add(-time.Duration(inactiveDays)*24*time.Hour), now, reengageMaxRecipients)
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return nil, ErrNoInactiveUsers
+	}
+
+	campaign := &models.ReengageCampaign{
+		StartedBy:    startedBy,
+		InactiveDays: inactiveDays,
+		Jobs:         len(jobs),
+		Recipients:   len(userIDs),
+	}
+	if err := s.storage.Reengage().CreateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	text := messages.FormatReengageDigest(jobs)
+	markup := keyboards.ReengageKeyboard(jobs, s.cfg.Bot.Username)
+	reqs := make([]*MessageRequest, len(userIDs))
+	for i, userID := range userIDs {
+		reqs[i] = &MessageRequest{
+			ChatID:    userID,
+			Message:   text,
+			Options:   []any{markup, tele.ModeHTML},
+			Marketing: true,
+		}
+	}
+
+	for i, resp := range s.sender.Deliver(ctx, reqs) {
+		if errors.Is(resp.Error, ErrMarketingOptOut) {
+			continue
+		}
+		if !resp.Success {
+			campaign.Failed++
+			continue
+		}
+		campaign.Sent++
+		if err := s.storage.Reengage().AddRecipient(ctx, campaign.ID, userIDs[i]); err != nil {
+			s.log.Error("Failed to record campaign recipient", logger.Error(err), logger.Any("user_id", userIDs[i]))
+		}
+	}
+
+	if err := s.storage.Reengage().FinishCampaign(ctx, campaign); err != nil {
+		return campaign, err
+	}
+
+	s.log.Info("Re-engagement campaign sent",
+		logger.Any("campaign_id", campaign.ID),
+		logger.Any("sent", campaign.Sent),
+		logger.Any("failed", campaign.Failed),
+	)
+	return campaign, nil
+}
+
+// openJobs returns the newest jobs still taking bookings
+func (s *reengageService) openJobs(ctx context.Context) ([]*models.Job, error) {
+	status := models.JobStatusActive
+	all, err := s.storage.Job().GetAll(ctx, &status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	var jobs []*models.Job
+	for _, job := range all {
+		if len(jobs) == reengageMaxJobs {
+			break
+		}
+		if job.IsActive() {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// GetReports returns the newest campaigns with their opens and bookings
+func (s *reengageService) GetReports(ctx context.Context, limit int) ([]*models.ReengageCampaign, error) {
+	return s.storage.Reengage().GetRecent(ctx, limit)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+)
+
+// reengageTimeout is the max time for one campaign; 1000 paced sends take well under a minute
+const reengageTimeout = 15 * time.Minute
+
+// ReengageWorker runs the daily re-engagement campaign at BOT_REENGAGE_HOUR
+type ReengageWorker struct {
+	cfg      *config.Config
+	log      logger.LoggerI
+	reengage ReengageService
+	interval time.Duration
+	stopChan chan struct{}
+	lastRun  string // Local date (YYYY-MM-DD) of the last campaign run
+}
+
+// NewReengageWorker creates a new daily re-engagement worker
+func NewReengageWorker(cfg *config.Config, log logger.LoggerI, reengage ReengageService) *ReengageWorker {
+	return &ReengageWorker{
+		cfg:      cfg,
+		log:      log,
+		reengage: reengage,
+		interval: time.Minute, // Check once a minute whether the campaign hour has come
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the re-engagement worker background process
+func (w *ReengageWorker) Start() {
+	if w.cfg.Bot.ReengageDays <= 0 {
+		w.log.Info("Re-engagement worker disabled (BOT_REENGAGE_DAYS not set)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Re-engagement worker started",
+		logger.Any("inactive_days", w.cfg.Bot.ReengageDays),
+		logger.Any("hour", w.cfg.Bot.ReengageHour),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeCheck()
+		case <-w.stopChan:
+			w.log.Info("Re-engagement worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the re-engagement worker
+func (w *ReengageWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeCheck wraps check with panic recovery
+func (w *ReengageWorker) safeCheck() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in re-engagement worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.check()
+}
+
+// check runs the campaign during the configured hour, at most once per day.
+// Users already messaged within BOT_REENGAGE_DAYS are skipped, so daily runs don't repeat themselves.
+func (w *ReengageWorker) check() {
+	now := config.NowLocal()
+	today := now.Format("2006-01-02")
+	if now.Hour() != w.cfg.Bot.ReengageHour || w.lastRun == today {
+		return
+	}
+	w.lastRun = today
+
+	ctx, cancel := context.WithTimeout(context.Background(), reengageTimeout)
+	defer cancel()
+
+	_, err := w.reengage.RunCampaign(ctx, w.cfg.Bot.ReengageDays, 0)
+	switch {
+	case errors.Is(err, ErrNoOpenJobs), errors.Is(err, ErrNoInactiveUsers):
+		w.log.Info("Re-engagement campaign skipped", logger.Any("reason", err.Error()))
+	case err != nil:
+		w.log.Error("Failed to run re-engagement campaign", logger.Error(err))
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -16,21 +15,56 @@ import (
 	"telegram-bot-starter/storage"
 )
 
+// ErrActiveBookings is returned when an account can't be deleted while bookings are still in progress
+var ErrActiveBookings = errors.New("user has active bookings")
+
+// Public offer errors
+var (
+	ErrNoPublicOffer = errors.New("no public offer published")
+	ErrOfferOutdated = errors.New("public offer version is outdated")
+)
+
 // RegistrationService handles registration business logic
-type RegistrationService struct {
+type RegistrationService interface {
+	Fields() models.RegistrationFields
+	CheckUserRegistrationStatus(ctx context.Context, userID int64) (isRegistered bool, hasDraft bool, draft *models.RegistrationDraft, err error)
+	StartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+	GetOrCreateDraft(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+	CurrentOffer(ctx context.Context) (*models.PublicOffer, error)
+	PendingOffer(ctx context.Context, userID int64) (*models.PublicOffer, error)
+	AcceptOffer(ctx context.Context, userID int64, version int) (*models.PublicOffer, error)
+	PublishOffer(ctx context.Context, content string, adminID int64) (*models.PublicOffer, error)
+	ProcessPublicOfferResponse(ctx context.Context, userID int64, version int, accepted bool) (*RegistrationResult, error)
+	ProcessFullName(ctx context.Context, userID int64, name string) (*RegistrationResult, error)
+	ProcessPhone(ctx context.Context, userID int64, phone string) (*RegistrationResult, error)
+	ProcessAge(ctx context.Context, userID int64, ageStr string) (*RegistrationResult, error)
+	ProcessBodyParams(ctx context.Context, userID int64, input string) (*RegistrationResult, error)
+	ProcessGender(ctx context.Context, userID int64, input string) (*RegistrationResult, error)
+	ProcessCity(ctx context.Context, userID int64, city string) (*RegistrationResult, error)
+	ProcessPassportPhoto(ctx context.Context, userID int64, fileID string) (*RegistrationResult, error)
+	FormatRegistrationSummary(draft *models.RegistrationDraft) string
+	ConfirmRegistration(ctx context.Context, userID int64) (*RegistrationResult, error)
+	MergeDuplicateAccount(ctx context.Context, oldUserID, newUserID, adminID int64) error
+	DeleteAccount(ctx context.Context, userID int64) error
+	RecordProfileChange(ctx context.Context, userID int64, field, oldValue, newValue string) ([]*models.Job, error)
+	CancelRegistration(ctx context.Context, userID int64) error
+	GoToEditState(ctx context.Context, userID int64, field models.EditField) (*RegistrationResult, error)
+	RestartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error)
+	GetRegisteredUser(ctx context.Context, userID int64) (*models.RegisteredUser, error)
+}
+
+type registrationService struct {
 	cfg     config.Config
 	log     logger.LoggerI
 	storage storage.StorageI
-	service ServiceManagerI
 }
 
 // NewRegistrationService creates a new registration service
-func NewRegistrationService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, service ServiceManagerI) RegistrationService {
-	return RegistrationService{
+func NewRegistrationService(cfg config.Config, log logger.LoggerI, storage storage.StorageI) RegistrationService {
+	return registrationService{
 		cfg:     cfg,
 		log:     log,
 		storage: storage,
-		service: service,
 	}
 }
 
@@ -41,10 +75,20 @@ type RegistrationResult struct {
 	Message      string
 	ErrorMessage string
 	Draft        *models.RegistrationDraft
+	Duplicate    *models.RegisteredUser // active account already holding the draft's phone
+}
+
+// Fields returns the optional registration steps enabled in config
+func (s registrationService) Fields() models.RegistrationFields {
+	return models.RegistrationFields{
+		Gender:        s.cfg.Registration.AskGender,
+		City:          s.cfg.Registration.AskCity,
+		PassportPhoto: s.cfg.Registration.AskPassportPhoto,
+	}
 }
 
 // CheckUserRegistrationStatus checks if user is registered and returns appropriate action
-func (s RegistrationService) CheckUserRegistrationStatus(ctx context.Context, userID int64) (isRegistered bool, hasDraft bool, draft *models.RegistrationDraft, err error) {
+func (s registrationService) CheckUserRegistrationStatus(ctx context.Context, userID int64) (isRegistered bool, hasDraft bool, draft *models.RegistrationDraft, err error) {
 	s.log.Info("!!!Check User Registration Status", logger.Any("user_id", userID))
 
 	// Check if user is fully registered
@@ -75,7 +119,7 @@ func (s RegistrationService) CheckUserRegistrationStatus(ctx context.Context, us
 }
 
 // StartRegistration creates a new registration draft for the user
-func (s RegistrationService) StartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+func (s registrationService) StartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
 	s.log.Info("!!!Start Registration", logger.Any("user_id", userID))
 
 	// First, ensure user exists in users table
@@ -105,7 +149,7 @@ func (s RegistrationService) StartRegistration(ctx context.Context, userID int64
 }
 
 // GetOrCreateDraft gets existing draft or creates a new one
-func (s RegistrationService) GetOrCreateDraft(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+func (s registrationService) GetOrCreateDraft(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -116,25 +160,77 @@ func (s RegistrationService) GetOrCreateDraft(ctx context.Context, userID int64)
 	return draft, nil
 }
 
-// LoadPublicOffer loads the public offer text from file
-func (s RegistrationService) LoadPublicOffer(filePath string) (string, error) {
-	content, err := os.ReadFile(filePath)
+// CurrentOffer returns the latest published public offer
+func (s registrationService) CurrentOffer(ctx context.Context) (*models.PublicOffer, error) {
+	offer, err := s.storage.PublicOffer().GetLatest(ctx)
 	if err != nil {
-		s.log.Error("Failed to read public offer file", logger.Error(err))
-		return "", fmt.Errorf("failed to read public offer: %w", err)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNoPublicOffer
+		}
+		return nil, err
 	}
-	return string(content), nil
+	return offer, nil
 }
 
-// // GeneratePublicOfferSummary generates a summary of the public offer
-// func (s RegistrationService) GeneratePublicOfferSummary(fullText string) string {
-// 	summary := ``
+// PendingOffer returns the current offer if the user has not accepted it yet, nil otherwise.
+// Nothing is pending while no offer is published.
+func (s registrationService) PendingOffer(ctx context.Context, userID int64) (*models.PublicOffer, error) {
+	offer, err := s.CurrentOffer(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoPublicOffer) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-// 	return summary
-// }
+	accepted, err := s.storage.PublicOffer().GetAcceptedVersion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if accepted >= offer.Version {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// AcceptOffer records the user's consent to the given offer version (0 = the current one).
+// Returns ErrOfferOutdated if a newer version was published after the user saw it.
+func (s registrationService) AcceptOffer(ctx context.Context, userID int64, version int) (*models.PublicOffer, error) {
+	offer, err := s.CurrentOffer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && version != offer.Version {
+		return offer, ErrOfferOutdated
+	}
+
+	acceptance := &models.OfferAcceptance{UserID: userID, Version: offer.Version}
+	if err := s.storage.PublicOffer().RecordAcceptance(ctx, acceptance); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Public offer accepted",
+		logger.Any("user_id", userID),
+		logger.Any("version", offer.Version))
+	return offer, nil
+}
+
+// PublishOffer stores a new offer version; users are asked to accept it on their next visit
+func (s registrationService) PublishOffer(ctx context.Context, content string, adminID int64) (*models.PublicOffer, error) {
+	offer := &models.PublicOffer{Content: content, CreatedByAdminID: adminID}
+	if err := s.storage.PublicOffer().Publish(ctx, offer); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Public offer published",
+		logger.Any("version", offer.Version),
+		logger.Any("admin_id", adminID))
+	return offer, nil
+}
 
 // ProcessPublicOfferResponse handles accept/decline response
-func (s RegistrationService) ProcessPublicOfferResponse(ctx context.Context, userID int64, accepted bool) (*RegistrationResult, error) {
+// On acceptance the consent to the given version is logged (see AcceptOffer).
+func (s registrationService) ProcessPublicOfferResponse(ctx context.Context, userID int64, version int, accepted bool) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -154,7 +250,11 @@ func (s RegistrationService) ProcessPublicOfferResponse(ctx context.Context, use
 		}, nil
 	}
 
-	// User accepted, move to next state
+	// User accepted: log the consent, then move to next state
+	if _, err := s.AcceptOffer(ctx, userID, version); err != nil {
+		return nil, err
+	}
+
 	draft.State = models.RegStateFullName
 	draft.UpdatedAt = time.Now()
 	err = s.storage.Registration().UpdateDraft(ctx, draft)
@@ -171,7 +271,7 @@ func (s RegistrationService) ProcessPublicOfferResponse(ctx context.Context, use
 }
 
 // ProcessFullName validates and saves the full name
-func (s RegistrationService) ProcessFullName(ctx context.Context, userID int64, name string) (*RegistrationResult, error) {
+func (s registrationService) ProcessFullName(ctx context.Context, userID int64, name string) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -226,7 +326,7 @@ func (s RegistrationService) ProcessFullName(ctx context.Context, userID int64,
 }
 
 // ProcessPhone validates and saves the phone number from Telegram contact
-func (s RegistrationService) ProcessPhone(ctx context.Context, userID int64, phone string) (*RegistrationResult, error) {
+func (s registrationService) ProcessPhone(ctx context.Context, userID int64, phone string) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -280,7 +380,7 @@ func (s RegistrationService) ProcessPhone(ctx context.Context, userID int64, pho
 }
 
 // ProcessAge validates and saves the age
-func (s RegistrationService) ProcessAge(ctx context.Context, userID int64, ageStr string) (*RegistrationResult, error) {
+func (s registrationService) ProcessAge(ctx context.Context, userID int64, ageStr string) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -334,7 +434,7 @@ func (s RegistrationService) ProcessAge(ctx context.Context, userID int64, ageSt
 }
 
 // ProcessBodyParams validates and saves weight and height
-func (s RegistrationService) ProcessBodyParams(ctx context.Context, userID int64, input string) (*RegistrationResult, error) {
+func (s registrationService) ProcessBodyParams(ctx context.Context, userID int64, input string) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -355,10 +455,13 @@ func (s RegistrationService) ProcessBodyParams(ctx context.Context, userID int64
 	draft.Weight = weight
 	draft.Height = height
 
-	// Always go to confirmation after body params (skip passport photo)
-	draft.State = models.RegStateConfirm
+	// If we were editing from confirmation, go back to confirmation;
+	// otherwise continue with the optional steps (gender, city, passport photo) that are enabled
 	if draft.PreviousState == models.RegStateConfirm {
+		draft.State = models.RegStateConfirm
 		draft.PreviousState = models.RegStateIdle
+	} else {
+		draft.State = models.NextRegistrationStep(s.Fields(), models.RegStateBodyParams)
 	}
 
 	draft.UpdatedAt = time.Now()
@@ -368,17 +471,97 @@ func (s RegistrationService) ProcessBodyParams(ctx context.Context, userID int64
 		return nil, err
 	}
 
-	// Always return confirmation state
 	return &RegistrationResult{
 		Success:   true,
-		NextState: models.RegStateConfirm,
+		NextState: draft.State,
+		Message:   "✅ Ma'lumotlar saqlandi",
+		Draft:     draft,
+	}, nil
+}
+
+// ProcessGender validates and saves the gender
+func (s registrationService) ProcessGender(ctx context.Context, userID int64, input string) (*RegistrationResult, error) {
+	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	gender, validationErr := validation.ParseGender(input)
+	if validationErr != nil {
+		return &RegistrationResult{
+			Success:      false,
+			NextState:    models.RegStateGender,
+			ErrorMessage: validationErr.Message,
+			Draft:        draft,
+		}, nil
+	}
+
+	draft.Gender = gender
+
+	if draft.PreviousState == models.RegStateConfirm {
+		draft.State = models.RegStateConfirm
+		draft.PreviousState = models.RegStateIdle
+	} else {
+		draft.State = models.NextRegistrationStep(s.Fields(), models.RegStateGender)
+	}
+
+	draft.UpdatedAt = time.Now()
+
+	err = s.storage.Registration().UpdateDraft(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistrationResult{
+		Success:   true,
+		NextState: draft.State,
+		Message:   "✅ Ma'lumotlar saqlandi",
+		Draft:     draft,
+	}, nil
+}
+
+// ProcessCity validates and saves the city
+func (s registrationService) ProcessCity(ctx context.Context, userID int64, city string) (*RegistrationResult, error) {
+	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErr := validation.ValidateCity(city); validationErr != nil {
+		return &RegistrationResult{
+			Success:      false,
+			NextState:    models.RegStateCity,
+			ErrorMessage: validationErr.Message,
+			Draft:        draft,
+		}, nil
+	}
+
+	draft.City = validation.NormalizeFullName(city)
+
+	if draft.PreviousState == models.RegStateConfirm {
+		draft.State = models.RegStateConfirm
+		draft.PreviousState = models.RegStateIdle
+	} else {
+		draft.State = models.NextRegistrationStep(s.Fields(), models.RegStateCity)
+	}
+
+	draft.UpdatedAt = time.Now()
+
+	err = s.storage.Registration().UpdateDraft(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistrationResult{
+		Success:   true,
+		NextState: draft.State,
 		Message:   "✅ Ma'lumotlar saqlandi",
 		Draft:     draft,
 	}, nil
 }
 
 // ProcessPassportPhoto saves the passport photo file ID
-func (s RegistrationService) ProcessPassportPhoto(ctx context.Context, userID int64, fileID string) (*RegistrationResult, error) {
+func (s registrationService) ProcessPassportPhoto(ctx context.Context, userID int64, fileID string) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -396,7 +579,7 @@ func (s RegistrationService) ProcessPassportPhoto(ctx context.Context, userID in
 	// Save
 	draft.PassportPhotoID = fileID
 
-	// Always go to confirmation after photo (whether editing or first time)
+	// Passport photo is the last step, so always go to confirmation (whether editing or first time)
 	draft.State = models.RegStateConfirm
 	draft.PreviousState = models.RegStateIdle
 	draft.UpdatedAt = time.Now()
@@ -415,7 +598,7 @@ func (s RegistrationService) ProcessPassportPhoto(ctx context.Context, userID in
 }
 
 // FormatRegistrationSummary creates a summary of all registration data
-func (s RegistrationService) FormatRegistrationSummary(draft *models.RegistrationDraft) string {
+func (s registrationService) FormatRegistrationSummary(draft *models.RegistrationDraft) string {
 	var sb strings.Builder
 
 	fmt.Fprintf(&sb, "📋 <b>Ro'yxatdan o'tish ma'lumotlari</b>\n\n")
@@ -424,13 +607,27 @@ func (s RegistrationService) FormatRegistrationSummary(draft *models.Registratio
 	fmt.Fprintf(&sb, "🎂 Yosh: %d\n", draft.Age)
 	fmt.Fprintf(&sb, "⚖️ Vazn: %d kg\n", draft.Weight)
 	fmt.Fprintf(&sb, "📏 Bo'y: %d sm\n", draft.Height)
-	fmt.Fprintf(&sb, "Ma'lumotlar to'g'ri bo'lsa \"✅ Tasdiqlash\" tugmasini bosing.")
+	fields := s.Fields()
+	if fields.Gender {
+		fmt.Fprintf(&sb, "🚻 Jins: %s\n", draft.Gender.Display())
+	}
+	if fields.City {
+		fmt.Fprintf(&sb, "🏙 Shahar/tuman: %s\n", draft.City)
+	}
+	if fields.PassportPhoto {
+		if draft.PassportPhotoID != "" {
+			sb.WriteString("🪪 Pasport rasmi: ✅ yuborilgan\n")
+		} else {
+			sb.WriteString("🪪 Pasport rasmi: ❌ yuborilmagan\n")
+		}
+	}
+	fmt.Fprintf(&sb, "\nMa'lumotlar to'g'ri bo'lsa \"✅ Tasdiqlash\" tugmasini bosing.")
 
 	return sb.String()
 }
 
 // ConfirmRegistration completes the registration
-func (s RegistrationService) ConfirmRegistration(ctx context.Context, userID int64) (*RegistrationResult, error) {
+func (s registrationService) ConfirmRegistration(ctx context.Context, userID int64) (*RegistrationResult, error) {
 	s.log.Info("!!!Confirm Registration", logger.Any("user_id", userID))
 
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
@@ -440,7 +637,7 @@ func (s RegistrationService) ConfirmRegistration(ctx context.Context, userID int
 	}
 
 	// Verify draft is complete
-	if !draft.IsComplete() {
+	if !draft.IsComplete(s.Fields()) {
 		s.log.Warn("Registration draft is incomplete", logger.Any("user_id", userID))
 		return &RegistrationResult{
 			Success:      false,
@@ -452,6 +649,26 @@ func (s RegistrationService) ConfirmRegistration(ctx context.Context, userID int
 
 	// Complete registration (moves from draft to registered_users)
 	err = s.storage.Registration().CompleteRegistration(ctx, userID)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		// The phone belongs to another active account; admins decide which one keeps it
+		existing, lookupErr := s.storage.Registration().GetActiveRegisteredUserByPhone(ctx, draft.Phone)
+		if lookupErr != nil {
+			s.log.Error("Failed to find account holding the phone", logger.Error(lookupErr), logger.Any("user_id", userID))
+			return nil, err
+		}
+
+		s.log.Warn("Duplicate phone at registration",
+			logger.Any("user_id", userID),
+			logger.Any("existing_user_id", existing.UserID),
+		)
+		return &RegistrationResult{
+			Success:      false,
+			NextState:    models.RegStateConfirm,
+			ErrorMessage: messages.MsgPhoneAlreadyRegistered,
+			Draft:        draft,
+			Duplicate:    existing,
+		}, nil
+	}
 	if err != nil {
 		s.log.Error("???Failed to complete registration", logger.Error(err), logger.Any("user_id", userID))
 		return nil, err
@@ -466,13 +683,170 @@ func (s RegistrationService) ConfirmRegistration(ctx context.Context, userID int
 	}, nil
 }
 
+// MergeDuplicateAccount moves a phone from an old account to the new account registering with it.
+// The old registration is deactivated, the new draft is completed and an active block carries over.
+func (s registrationService) MergeDuplicateAccount(ctx context.Context, oldUserID, newUserID, adminID int64) error {
+	if err := s.storage.Registration().SetRegisteredUserActive(ctx, oldUserID, false); err != nil {
+		return fmt.Errorf("failed to deactivate old account: %w", err)
+	}
+
+	if err := s.storage.Registration().CompleteRegistration(ctx, newUserID); err != nil {
+		// Give the phone back to the old account rather than leaving it with no owner
+		if restoreErr := s.storage.Registration().SetRegisteredUserActive(ctx, oldUserID, true); restoreErr != nil {
+			s.log.Error("Failed to reactivate old account after failed merge",
+				logger.Error(restoreErr),
+				logger.Any("old_user_id", oldUserID),
+			)
+		}
+		return fmt.Errorf("failed to complete registration: %w", err)
+	}
+
+	// The registration is done; a failed block copy is logged, not rolled back
+	if err := s.carryOverBlock(ctx, oldUserID, newUserID, adminID); err != nil {
+		s.log.Error("Failed to carry over block to merged account",
+			logger.Error(err),
+			logger.Any("old_user_id", oldUserID),
+			logger.Any("new_user_id", newUserID),
+		)
+	}
+
+	s.log.Info("Duplicate account merged",
+		logger.Any("old_user_id", oldUserID),
+		logger.Any("new_user_id", newUserID),
+		logger.Any("admin_id", adminID),
+	)
+	return nil
+}
+
+// carryOverBlock copies a still-active block so a second account can't be used to get around it
+func (s registrationService) carryOverBlock(ctx context.Context, fromUserID, toUserID, adminID int64) error {
+	block, err := s.storage.User().GetBlockStatus(ctx, fromUserID)
+	if err != nil {
+		return err
+	}
+	if block == nil || (!block.IsPermanent() && !block.BlockedUntil.After(time.Now())) {
+		return nil
+	}
+
+	tx, err := s.storage.Transaction().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer s.storage.Transaction().Rollback(ctx, tx)
+
+	err = s.storage.User().BlockUser(ctx, tx, &models.BlockedUser{
+		UserID:           toUserID,
+		BlockedUntil:     block.BlockedUntil,
+		TotalViolations:  block.TotalViolations,
+		BlockedByAdminID: adminID,
+		Reason:           "🔁 Eski akkauntdan o'tkazilgan blok",
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Transaction().Commit(ctx, tx)
+}
+
+// DeleteAccount removes a user's personal data at their request.
+// The registration and draft are deleted, the Telegram profile and booking receipts are
+// cleared; bookings, violations and blocks stay keyed by user ID for job history and so a
+// deleted account can't be used to shed a block.
+func (s registrationService) DeleteAccount(ctx context.Context, userID int64) error {
+	bookings, err := s.storage.Booking().GetUserBookings(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user bookings: %w", err)
+	}
+
+	for _, booking := range bookings {
+		if booking.AwaitsReceipt() || booking.Status == models.BookingStatusPaymentSubmitted {
+			return ErrActiveBookings
+		}
+	}
+
+	upcoming, err := s.upcomingConfirmedJobs(ctx, bookings)
+	if err != nil {
+		return err
+	}
+	if len(upcoming) > 0 {
+		return ErrActiveBookings
+	}
+
+	if err := s.storage.Booking().AnonymizeUserBookings(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.storage.ProfileChange().DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.storage.Registration().DeleteDraft(ctx, userID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	if err := s.storage.User().Anonymize(ctx, userID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	// Deleted last so a failure above leaves the account registered and the user can retry
+	if err := s.storage.Registration().DeleteRegisteredUser(ctx, userID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to delete registered user: %w", err)
+	}
+
+	s.log.Info("User account deleted", logger.Any("user_id", userID))
+	return nil
+}
+
+// RecordProfileChange stores a name or phone edit and returns the unfinished jobs the user is
+// confirmed for; employers reach workers with this data, so admins hear about those edits.
+func (s registrationService) RecordProfileChange(ctx context.Context, userID int64, field, oldValue, newValue string) ([]*models.Job, error) {
+	err := s.storage.ProfileChange().Create(ctx, &models.ProfileChange{
+		UserID:   userID,
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.storage.Booking().GetUserBookingsByStatus(ctx, userID, models.BookingStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmed bookings: %w", err)
+	}
+
+	return s.upcomingConfirmedJobs(ctx, bookings)
+}
+
+// upcomingConfirmedJobs returns the jobs behind confirmed bookings that aren't completed or cancelled yet
+func (s registrationService) upcomingConfirmedJobs(ctx context.Context, bookings []*models.JobBooking) ([]*models.Job, error) {
+	var jobs []*models.Job
+	for _, booking := range bookings {
+		if booking.Status != models.BookingStatusConfirmed {
+			continue
+		}
+
+		job, err := s.storage.Job().GetByID(ctx, booking.JobID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		if job.Status != models.JobStatusCompleted && job.Status != models.JobStatusCancelled {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
 // CancelRegistration cancels the registration and deletes the draft
-func (s RegistrationService) CancelRegistration(ctx context.Context, userID int64) error {
+func (s registrationService) CancelRegistration(ctx context.Context, userID int64) error {
 	return s.storage.Registration().DeleteDraft(ctx, userID)
 }
 
 // GoToEditState sets the draft state to a specific field for editing
-func (s RegistrationService) GoToEditState(ctx context.Context, userID int64, field models.EditField) (*RegistrationResult, error) {
+func (s registrationService) GoToEditState(ctx context.Context, userID int64, field models.EditField) (*RegistrationResult, error) {
 	draft, err := s.storage.Registration().GetDraftByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -494,6 +868,15 @@ func (s RegistrationService) GoToEditState(ctx context.Context, userID int64, fi
 	case models.EditFieldBodyParams:
 		nextState = models.RegStateBodyParams
 		message = "✏️ Vazn va bo'yingizni qayta kiriting (masalan: 70 175):"
+	case models.EditFieldGender:
+		nextState = models.RegStateGender
+		message = "✏️ Jinsingizni qayta tanlang:"
+	case models.EditFieldCity:
+		nextState = models.RegStateCity
+		message = "✏️ Shahar yoki tumaningizni qayta kiriting:"
+	case models.EditFieldPassport:
+		nextState = models.RegStatePassportPhoto
+		message = "✏️ Pasport rasmini qayta yuboring:"
 	default:
 		return nil, fmt.Errorf("unknown edit field: %s", field)
 	}
@@ -517,7 +900,7 @@ func (s RegistrationService) GoToEditState(ctx context.Context, userID int64, fi
 }
 
 // RestartRegistration deletes the current draft and starts fresh
-func (s RegistrationService) RestartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+func (s registrationService) RestartRegistration(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
 	// Delete existing draft
 	_ = s.storage.Registration().DeleteDraft(ctx, userID)
 
@@ -526,6 +909,6 @@ func (s RegistrationService) RestartRegistration(ctx context.Context, userID int
 }
 
 // GetRegisteredUser returns the full registered user data
-func (s RegistrationService) GetRegisteredUser(ctx context.Context, userID int64) (*models.RegisteredUser, error) {
+func (s registrationService) GetRegisteredUser(ctx context.Context, userID int64) (*models.RegisteredUser, error) {
 	return s.storage.Registration().GetRegisteredUserByUserID(ctx, userID)
 }
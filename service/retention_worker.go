@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const (
+	// retentionTimeout is the max time for one cleanup round.
+	retentionTimeout = 5 * time.Minute
+
+	// retentionBatchSize limits the bookings deleted per statement, so a large
+	// first cleanup doesn't hold locks on job_bookings for long.
+	retentionBatchSize = 500
+)
+
+// RetentionWorker deletes data nobody needs after BOT_RETENTION_DAYS, once a night:
+// unpaid bookings that ended (expired, rejected, cancelled by the user) with their
+// history, abandoned registration drafts and the admin messages of closed jobs.
+//
+// Confirmed and admin-cancelled bookings stay, since payments, refunds, ratings
+// and the statistics are built on them.
+type RetentionWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	days     int
+	hour     int
+	interval time.Duration
+	stopChan chan struct{}
+	lastRun  string // Local date (YYYY-MM-DD) of the last cleanup
+}
+
+// NewRetentionWorker creates a new old data remover
+func NewRetentionWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI) *RetentionWorker {
+	return &RetentionWorker{
+		storage:  storage,
+		log:      log,
+		days:     cfg.Bot.RetentionDays,
+		hour:     cfg.Bot.RetentionHour,
+		interval: time.Minute, // Check once a minute whether the cleanup hour has come
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the retention worker background process
+func (w *RetentionWorker) Start() {
+	if w.days <= 0 {
+		w.log.Info("Retention worker disabled (BOT_RETENTION_DAYS=0)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("Retention worker started",
+		logger.Int("days", w.days),
+		logger.Int("hour", w.hour),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeCheck()
+		case <-w.stopChan:
+			w.log.Info("Retention worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the retention worker
+func (w *RetentionWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeCheck wraps check with panic recovery
+func (w *RetentionWorker) safeCheck() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in retention worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.check()
+}
+
+// check cleans up during the configured hour, at most once per day
+func (w *RetentionWorker) check() {
+	now := config.NowLocal()
+	today := now.Format(time.DateOnly)
+	if now.Hour() != w.hour || w.lastRun == today {
+		return
+	}
+	w.lastRun = today
+
+	ctx, cancel := context.WithTimeout(context.Background(), retentionTimeout)
+	defer cancel()
+
+	w.cleanup(ctx, now.AddDate(0, 0, -w.days))
+}
+
+// cleanup deletes what was last changed before the cutoff; a failed step doesn't stop the others
+func (w *RetentionWorker) cleanup(ctx context.Context, before time.Time) {
+	bookings := 0
+	for {
+		n, err := w.storage.Booking().DeleteClosedBefore(ctx, before, retentionBatchSize)
+		if err != nil {
+			w.log.Error("Failed to delete old bookings", logger.Error(err))
+			break
+		}
+		bookings += n
+		if n < retentionBatchSize {
+			break
+		}
+	}
+
+	drafts, err := w.storage.Registration().DeleteDraftsBefore(ctx, before)
+	if err != nil {
+		w.log.Error("Failed to delete old registration drafts", logger.Error(err))
+	}
+
+	adminMessages, err := w.storage.AdminMessage().DeleteForClosedJobsBefore(ctx, before)
+	if err != nil {
+		w.log.Error("Failed to delete admin messages of old jobs", logger.Error(err))
+	}
+
+	w.log.Info("Old data cleaned up",
+		logger.String("before", before.Format(time.DateOnly)),
+		logger.Int("bookings", bookings),
+		logger.Int("drafts", drafts),
+		logger.Int("admin_messages", adminMessages),
+	)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// sandboxMark starts every message sent in sandbox mode
+const sandboxMark = "🧪 SANDBOX"
+
+// SandboxBot wraps the Telegram client in SANDBOX_MODE.
+//
+// The config already points the channel and admin groups at the sandbox
+// chats; SandboxBot catches the rest: anything addressed to a user who is not
+// an admin is delivered to the sandbox group instead, and all text and
+// captions are watermarked so rehearsal output can't be mistaken for real.
+type SandboxBot struct {
+	bot     BotAPI
+	log     logger.LoggerI
+	groupID int64
+	allowed []int64 // Chats that still get messages directly: sandbox chats and admins
+}
+
+var _ BotAPI = (*SandboxBot)(nil)
+
+// NewSandboxBot creates the sandbox decorator around bot
+func NewSandboxBot(bot BotAPI, cfg *config.Config, log logger.LoggerI) *SandboxBot {
+	allowed := append([]int64{cfg.Sandbox.ChannelID, cfg.Sandbox.GroupID}, cfg.Bot.AdminIDs...)
+	return &SandboxBot{
+		bot:     bot,
+		log:     log,
+		groupID: cfg.Sandbox.GroupID,
+		allowed: allowed,
+	}
+}
+
+// Send delivers to sandbox chats and admins as is, and redirects everything else to the sandbox group
+func (s *SandboxBot) Send(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	chatID, err := strconv.ParseInt(to.Recipient(), 10, 64)
+	if err != nil || slices.Contains(s.allowed, chatID) {
+		return s.bot.Send(to, s.watermark(what, ""), opts...)
+	}
+
+	s.log.Info("Sandbox: message redirected", logger.Any("chat_id", chatID))
+	header := fmt.Sprintf("%s → %d", sandboxMark, chatID)
+
+	group := &tele.Chat{ID: s.groupID}
+	switch what.(type) {
+	case string, *tele.Photo, *tele.Document:
+		return s.bot.Send(group, s.watermark(what, header), opts...)
+	default:
+		// Locations and the like can't carry a caption, so the header goes first
+		if _, err := s.bot.Send(group, header); err != nil {
+			return nil, err
+		}
+		return s.bot.Send(group, what, opts...)
+	}
+}
+
+// Edit watermarks the new text. Edits of messages that were redirected fail,
+// since the stored message ID belongs to the sandbox group.
+func (s *SandboxBot) Edit(msg tele.Editable, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	return s.bot.Edit(msg, s.watermark(what, ""), opts...)
+}
+
+// EditCaption watermarks the new caption
+func (s *SandboxBot) EditCaption(msg tele.Editable, caption string, opts ...interface{}) (*tele.Message, error) {
+	return s.bot.EditCaption(msg, sandboxMark+"\n"+caption, opts...)
+}
+
+// Delete passes through
+func (s *SandboxBot) Delete(msg tele.Editable) error {
+	return s.bot.Delete(msg)
+}
+
+// Pin passes through; the channel is already the sandbox channel
+func (s *SandboxBot) Pin(msg tele.Editable, opts ...interface{}) error {
+	return s.bot.Pin(msg, opts...)
+}
+
+// SetCommands passes through; command menus are not messages
+func (s *SandboxBot) SetCommands(opts ...interface{}) error {
+	return s.bot.SetCommands(opts...)
+}
+
+// DeleteCommands passes through
+func (s *SandboxBot) DeleteCommands(opts ...interface{}) error {
+	return s.bot.DeleteCommands(opts...)
+}
+
+// watermark prefixes text, photo and document captions with the sandbox mark, or with header when given
+func (s *SandboxBot) watermark(what interface{}, header string) interface{} {
+	if header == "" {
+		header = sandboxMark
+	}
+	switch v := what.(type) {
+	case string:
+		return header + "\n\n" + v
+	case *tele.Photo:
+		photo := *v
+		photo.Caption = header + "\n\n" + v.Caption
+		return &photo
+	case *tele.Document:
+		doc := *v
+		doc.Caption = header + "\n\n" + v.Caption
+		return &doc
+	}
+	return what
+}
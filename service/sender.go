@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/config"
@@ -20,10 +24,15 @@ type MessageRequest struct {
 	Message   string
 	Options   []any // ReplyMarkup, ParseMode, etc.
 	Photo     *tele.Photo
+	Location  *tele.Location // Sent as a map pin instead of Message
 	IsEdit    bool
-	MessageID int // For editing existing messages
+	MessageID int  // For editing existing messages
+	Marketing bool // Campaign or broadcast; skipped for users who opted out of marketing messages
 }
 
+// ErrMarketingOptOut is the response error of a marketing request to a user who opted out
+var ErrMarketingOptOut = errors.New("recipient opted out of marketing messages")
+
 // MessageResponse represents the result of sending a message
 type MessageResponse struct {
 	Success   bool
@@ -32,33 +41,55 @@ type MessageResponse struct {
 }
 
 // SenderService handles all message sending operations
-// This centralizes message sending for future queue implementation
-type SenderService struct {
+// Fan-out to many chats goes through Deliver, which queues and paces the sends
+type SenderService interface {
+	JobPostUpdater
+
+	Send(ctx context.Context, chatID int64, message string, opts ...any) error
+	SendPhoto(ctx context.Context, chatID int64, photo *tele.Photo, opts ...any) error
+	SendAny(ctx context.Context, chatID int64, what any, opts ...any) error
+	EditCaption(msg *tele.Message, caption string, opts ...any) error
+	Edit(ctx context.Context, chatID int64, messageID int, message string, opts ...any) error
+	Reply(c tele.Context, message string, opts ...any) error
+	ReplyWithPhoto(c tele.Context, photo *tele.Photo, opts ...any) error
+	EditMessage(c tele.Context, message string, opts ...any) error
+	Respond(c tele.Context, response *tele.CallbackResponse) error
+	RemoveKeyboard(c tele.Context) error
+	DeleteMessage(c tele.Context) error
+	Deliver(ctx context.Context, reqs []*MessageRequest) []MessageResponse
+}
+
+type senderService struct {
 	cfg     config.Config
 	log     logger.LoggerI
-	bot     *tele.Bot
-	service ServiceManagerI
+	bot     BotAPI
 	storage storage.StorageI
 
-	// Queue settings (for future implementation)
-	useQueue bool
-	// queue    chan *MessageRequest
+	// queue paces fan-out sends; started on first Deliver
+	queue     chan *queuedRequest
+	queueOnce sync.Once
+
+	// edits batches channel post edits per job (see channel_edits.go)
+	edits channelEdits
 }
 
 // NewSenderService creates a new sender service
-func NewSenderService(cfg config.Config, log logger.LoggerI, bot *tele.Bot, storage storage.StorageI, service ServiceManagerI) *SenderService {
-	return &SenderService{
-		cfg:      cfg,
-		log:      log,
-		bot:      bot,
-		storage:  storage,
-		service:  service,
-		useQueue: false, // Will be enabled when queue is implemented
+func NewSenderService(cfg config.Config, log logger.LoggerI, bot BotAPI, storage storage.StorageI) SenderService {
+	return &senderService{
+		cfg:     cfg,
+		log:     log,
+		bot:     bot,
+		storage: storage,
+		edits: channelEdits{
+			windows: make(map[int64]*time.Timer),
+			pending: make(map[int64]*channelEdit),
+			sent:    make(map[int64][sha256.Size]byte),
+		},
 	}
 }
 
 // Send sends a message to a user
-func (s *SenderService) Send(ctx context.Context, chatID int64, message string, opts ...any) error {
+func (s *senderService) Send(ctx context.Context, chatID int64, message string, opts ...any) error {
 	chat := &tele.Chat{ID: chatID}
 	_, err := s.bot.Send(chat, message, opts...)
 	if err != nil {
@@ -70,7 +101,7 @@ func (s *SenderService) Send(ctx context.Context, chatID int64, message string,
 }
 
 // SendPhoto sends a photo to a user
-func (s *SenderService) SendPhoto(ctx context.Context, chatID int64, photo *tele.Photo, opts ...any) error {
+func (s *senderService) SendPhoto(ctx context.Context, chatID int64, photo *tele.Photo, opts ...any) error {
 	chat := &tele.Chat{ID: chatID}
 	_, err := s.bot.Send(chat, photo, opts...)
 	if err != nil {
@@ -82,7 +113,7 @@ func (s *SenderService) SendPhoto(ctx context.Context, chatID int64, photo *tele
 }
 
 // SendAny sends any Sendable (location, venue, etc.) to a chat
-func (s *SenderService) SendAny(ctx context.Context, chatID int64, what any, opts ...any) error {
+func (s *senderService) SendAny(ctx context.Context, chatID int64, what any, opts ...any) error {
 	chat := &tele.Chat{ID: chatID}
 	_, err := s.bot.Send(chat, what, opts...)
 	if err != nil {
@@ -94,7 +125,7 @@ func (s *SenderService) SendAny(ctx context.Context, chatID int64, what any, opt
 }
 
 // EditCaption edits the caption of a photo message
-func (s *SenderService) EditCaption(msg *tele.Message, caption string, opts ...any) error {
+func (s *senderService) EditCaption(msg *tele.Message, caption string, opts ...any) error {
 	_, err := s.bot.EditCaption(msg, caption, opts...)
 	if err != nil {
 		s.log.Error("Failed to edit caption", logger.Error(err), logger.Any("message_id", msg.ID))
@@ -104,7 +135,7 @@ func (s *SenderService) EditCaption(msg *tele.Message, caption string, opts ...a
 }
 
 // Edit edits an existing message
-func (s *SenderService) Edit(ctx context.Context, chatID int64, messageID int, message string, opts ...any) error {
+func (s *senderService) Edit(ctx context.Context, chatID int64, messageID int, message string, opts ...any) error {
 	msg := &tele.Message{
 		ID:   messageID,
 		Chat: &tele.Chat{ID: chatID},
@@ -120,82 +151,39 @@ func (s *SenderService) Edit(ctx context.Context, chatID int64, messageID int, m
 }
 
 // Reply sends a reply using telebot context (for immediate responses)
-func (s *SenderService) Reply(c tele.Context, message string, opts ...any) error {
+func (s *senderService) Reply(c tele.Context, message string, opts ...any) error {
 	// For immediate context-based replies, we don't need queue
 	// This is used for user-initiated actions where immediate response is expected
 	return c.Send(message, opts...)
 }
 
 // ReplyWithPhoto sends a photo reply using telebot context
-func (s *SenderService) ReplyWithPhoto(c tele.Context, photo *tele.Photo, opts ...any) error {
+func (s *senderService) ReplyWithPhoto(c tele.Context, photo *tele.Photo, opts ...any) error {
 	return c.Send(photo, opts...)
 }
 
 // EditMessage edits the message in callback context
-func (s *SenderService) EditMessage(c tele.Context, message string, opts ...any) error {
+func (s *senderService) EditMessage(c tele.Context, message string, opts ...any) error {
 	return c.Edit(message, opts...)
 }
 
 // Respond responds to a callback query
-func (s *SenderService) Respond(c tele.Context, response *tele.CallbackResponse) error {
+func (s *senderService) Respond(c tele.Context, response *tele.CallbackResponse) error {
 	return c.Respond(response)
 }
 
 // RemoveKeyboard removes reply keyboard by sending a message with RemoveKeyboard option
-func (s *SenderService) RemoveKeyboard(c tele.Context) error {
+func (s *senderService) RemoveKeyboard(c tele.Context) error {
 	return c.Send("\u200B", &tele.ReplyMarkup{RemoveKeyboard: true})
 }
 
 // DeleteMessage deletes the message in callback context
-func (s *SenderService) DeleteMessage(c tele.Context) error {
+func (s *senderService) DeleteMessage(c tele.Context) error {
 	return c.Delete()
 }
 
-// UpdateChannelJobPost updates a job post in the channel with latest info
-func (s *SenderService) UpdateChannelJobPost(ctx context.Context, job *models.Job) error {
-	if job.ChannelMessageID == 0 {
-		s.log.Warn("Cannot update channel message: no channel message ID", logger.Any("job_id", job.ID))
-		return fmt.Errorf("no channel message ID for job %d", job.ID)
-	}
-
-	msg := &tele.Message{
-		ID:   int(job.ChannelMessageID),
-		Chat: &tele.Chat{ID: s.cfg.Bot.ChannelID},
-	}
-
-	channelMsg := messages.FormatJobForChannel(job)
-
-	// Only show signup button if job is ACTIVE
-	var keyboard *tele.ReplyMarkup
-	if job.Status == models.JobStatusActive {
-		keyboard = keyboards.JobSignupKeyboard(job.ID, s.cfg.Bot.Username)
-	} else {
-		// Remove buttons for non-active jobs (FULL, COMPLETED, CANCELLED, DRAFT)
-		keyboard = &tele.ReplyMarkup{}
-	}
-
-	_, err := s.bot.Edit(msg, channelMsg, keyboard, tele.ModeHTML)
-	if err != nil {
-		s.log.Error("Failed to update channel message",
-			logger.Error(err),
-			logger.Any("job_id", job.ID),
-			logger.Any("channel_message_id", job.ChannelMessageID),
-		)
-		return fmt.Errorf("failed to update channel message: %w", err)
-	}
-
-	s.log.Info("Channel message updated successfully",
-		logger.Any("job_id", job.ID),
-		logger.Any("confirmed_slots", job.ConfirmedSlots),
-		logger.Any("required_workers", job.RequiredWorkers),
-		logger.Any("status", job.Status),
-	)
-
-	return nil
-}
-
 // UpdateAdminJobPost updates all admin job detail messages (broadcasts to all admins)
-func (s *SenderService) UpdateAdminJobPost(ctx context.Context, job *models.Job) error {
+func (s *senderService) UpdateAdminJobPost(ctx context.Context, job *models.Job) error {
 	// Get all admin messages for this job
 	adminMessages, err := s.storage.AdminMessage().GetAllByJobID(ctx, job.ID)
 	if err != nil {
@@ -253,39 +241,132 @@ func (s *SenderService) UpdateAdminJobPost(ctx context.Context, job *models.Job)
 	return nil
 }
 
-// ============ Queue Implementation (Future) ============
-
-// EnableQueue enables queue-based message sending
-// func (s *SenderService) EnableQueue(bufferSize int) {
-//     s.mu.Lock()
-//     defer s.mu.Unlock()
-//
-//     s.useQueue = true
-//     s.queue = make(chan *MessageRequest, bufferSize)
-//
-//     // Start queue processor
-//     go s.processQueue()
-// }
-
-// processQueue processes messages from the queue with rate limiting
-// func (s *SenderService) processQueue() {
-//     // Telegram limit: 30 messages per second to different chats
-//     // 1 message per second to same chat
-//     ticker := time.NewTicker(35 * time.Millisecond) // ~28 messages per second
-//     defer ticker.Stop()
-//
-//     for req := range s.queue {
-//         <-ticker.C
-//         s.sendDirect(req)
-//     }
-// }
-
-// enqueue adds a message request to the queue
-// func (s *SenderService) enqueue(req *MessageRequest) error {
-//     select {
-//     case s.queue <- req:
-//         return nil
-//     default:
-//         return errors.New("message queue is full")
-//     }
-// }
+// ============ Fan-out Queue ============
+
+const (
+	// queueInterval paces queued sends below Telegram's ~30 messages/second limit
+	queueInterval = 35 * time.Millisecond
+	// queueBufferSize bounds how many requests can wait before Deliver blocks
+	queueBufferSize = 256
+	// floodMaxRetries is how many times a request is retried after a 429
+	floodMaxRetries = 3
+	// floodMaxWait caps the retry_after Telegram asks us to honour
+	floodMaxWait = time.Minute
+)
+
+type queuedRequest struct {
+	req  *MessageRequest
+	done chan MessageResponse
+}
+
+// Deliver sends requests through the paced queue and waits for all of them.
+// Flood-wait errors pause the queue for the requested time and retry the send;
+// responses are returned in request order so callers can report partial failures.
+func (s *senderService) Deliver(ctx context.Context, reqs []*MessageRequest) []MessageResponse {
+	s.queueOnce.Do(func() {
+		s.queue = make(chan *queuedRequest, queueBufferSize)
+		go s.processQueue()
+	})
+
+	pending := make([]*queuedRequest, len(reqs))
+	for i, req := range reqs {
+		pending[i] = &queuedRequest{req: req, done: make(chan MessageResponse, 1)}
+		if req.Marketing && s.optedOut(ctx, req.ChatID) {
+			pending[i].done <- MessageResponse{Error: ErrMarketingOptOut}
+			continue
+		}
+		select {
+		case s.queue <- pending[i]:
+		case <-ctx.Done():
+			pending[i].done <- MessageResponse{Error: ctx.Err()}
+		}
+	}
+
+	responses := make([]MessageResponse, len(reqs))
+	for i, q := range pending {
+		select {
+		case responses[i] = <-q.done:
+		case <-ctx.Done():
+			responses[i] = MessageResponse{Error: ctx.Err()}
+		}
+	}
+	return responses
+}
+
+// optedOut reports whether the user turned marketing messages off; the preference is read
+// when the request is queued, so it applies to a campaign that is already running
+func (s *senderService) optedOut(ctx context.Context, userID int64) bool {
+	user, err := s.storage.User().GetByID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.log.Error("Failed to check marketing opt-out", logger.Error(err), logger.Any("user_id", userID))
+		}
+		return false
+	}
+	return user.MarketingOptOut
+}
+
+// processQueue sends queued requests one at a time at the paced rate
+func (s *senderService) processQueue() {
+	ticker := time.NewTicker(queueInterval)
+	defer ticker.Stop()
+
+	for q := range s.queue {
+		<-ticker.C
+		q.done <- s.sendWithRetry(q.req)
+	}
+}
+
+// sendWithRetry performs a request, sleeping through flood-wait responses
+func (s *senderService) sendWithRetry(req *MessageRequest) MessageResponse {
+	for attempt := 0; ; attempt++ {
+		msg, err := s.sendDirect(req)
+		if err == nil {
+			resp := MessageResponse{Success: true}
+			if msg != nil {
+				resp.MessageID = msg.ID
+			}
+			return resp
+		}
+
+		var flood tele.FloodError
+		if !errors.As(err, &flood) || attempt >= floodMaxRetries {
+			return MessageResponse{Error: err}
+		}
+
+		wait := time.Duration(flood.RetryAfter) * time.Second
+		if wait <= 0 {
+			wait = time.Second
+		}
+		if wait > floodMaxWait {
+			wait = floodMaxWait
+		}
+		s.log.Warn("Telegram flood wait, pausing send queue",
+			logger.Any("chat_id", req.ChatID),
+			logger.Any("retry_after", wait.String()),
+			logger.Any("attempt", attempt+1),
+		)
+		time.Sleep(wait)
+	}
+}
+
+// sendDirect performs a single send or edit without queueing
+func (s *senderService) sendDirect(req *MessageRequest) (*tele.Message, error) {
+	chat := &tele.Chat{ID: req.ChatID}
+	var what any = req.Message
+	if req.Photo != nil {
+		what = req.Photo
+	}
+	if req.Location != nil {
+		what = req.Location
+	}
+
+	if req.IsEdit {
+		msg, err := s.bot.Edit(&tele.Message{ID: req.MessageID, Chat: chat}, what, req.Options...)
+		if errors.Is(err, tele.ErrMessageNotModified) {
+			return &tele.Message{ID: req.MessageID, Chat: chat}, nil
+		}
+		return msg, err
+	}
+	return s.bot.Send(chat, what, req.Options...)
+}
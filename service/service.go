@@ -4,36 +4,61 @@ import (
 	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/storage"
-
-	tele "gopkg.in/telebot.v4"
 )
 
 // ServiceManagerI defines the main service interface
 type ServiceManagerI interface {
 	Registration() RegistrationService
-	Sender() *SenderService
+	Sender() SenderService
 	Booking() BookingService
 	Payment() PaymentService
+	Settings() SettingsService
+	Reengage() ReengageService
+	ChannelIndex() ChannelIndexService
+	ChannelPosts() ChannelPostService
+	BookingStatus() BookingStatusService
+	Backup() BackupService
 }
 
 // ServiceManager holds all service instances
 type ServiceManager struct {
 	registrationService RegistrationService
-	senderService       *SenderService
+	senderService       SenderService
 	bookingService      BookingService
 	paymentService      PaymentService
+	settingsService     SettingsService
+	reengageService     ReengageService
+	channelIndex        ChannelIndexService
+	channelPosts        ChannelPostService
+	bookingStatus       BookingStatusService
+	backup              BackupService
 }
 
-// NewServiceManager initializes and returns a new ServiceManager
-func NewServiceManager(cfg config.Config, log logger.LoggerI, storage storage.StorageI, bot *tele.Bot) *ServiceManager {
-	services := &ServiceManager{}
+// NewServiceManager initializes and returns a new ServiceManager.
+// Each service receives only the dependencies it uses; the sender doubles as the
+// JobPostUpdater for booking and payment. Options override the clock and ID
+// generator (defaults: wall clock, deterministic keys).
+func NewServiceManager(cfg config.Config, log logger.LoggerI, storage storage.StorageI, bot BotAPI, opts ...Option) *ServiceManager {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-	services.registrationService = NewRegistrationService(cfg, log, storage, services)
-	services.senderService = NewSenderService(cfg, log, bot, storage, services)
-	services.bookingService = NewBookingService(cfg, log, storage, services)
-	services.paymentService = NewPaymentService(cfg, log, storage, services)
+	sender := NewSenderService(cfg, log, bot, storage)
+	channelIndex := NewChannelIndexService(cfg, log, storage, bot)
 
-	return services
+	return &ServiceManager{
+		registrationService: NewRegistrationService(cfg, log, storage),
+		senderService:       sender,
+		bookingService:      NewBookingService(cfg, log, storage, sender, o.clock, o.ids),
+		paymentService:      NewPaymentService(cfg, log, storage, sender, o.clock),
+		settingsService:     NewSettingsService(cfg, log, storage, o.clock),
+		reengageService:     NewReengageService(cfg, log, storage, sender, o.clock),
+		channelIndex:        channelIndex,
+		channelPosts:        NewChannelPostService(cfg, log, storage, sender, channelIndex),
+		bookingStatus:       NewBookingStatusService(cfg, log, storage, bot),
+		backup:              NewBackupService(cfg, log, storage, o.clock),
+	}
 }
 
 // Registration returns the registration service
@@ -42,7 +67,7 @@ func (s *ServiceManager) Registration() RegistrationService {
 }
 
 // Sender returns the sender service
-func (s *ServiceManager) Sender() *SenderService {
+func (s *ServiceManager) Sender() SenderService {
 	return s.senderService
 }
 
@@ -55,3 +80,33 @@ func (s *ServiceManager) Booking() BookingService {
 func (s *ServiceManager) Payment() PaymentService {
 	return s.paymentService
 }
+
+// Settings returns the runtime settings service
+func (s *ServiceManager) Settings() SettingsService {
+	return s.settingsService
+}
+
+// Reengage returns the re-engagement campaign service
+func (s *ServiceManager) Reengage() ReengageService {
+	return s.reengageService
+}
+
+// ChannelIndex returns the pinned channel index service
+func (s *ServiceManager) ChannelIndex() ChannelIndexService {
+	return s.channelIndex
+}
+
+// ChannelPosts returns the debounced channel post refresher
+func (s *ServiceManager) ChannelPosts() ChannelPostService {
+	return s.channelPosts
+}
+
+// BookingStatus returns the users' live booking status tracker
+func (s *ServiceManager) BookingStatus() BookingStatusService {
+	return s.bookingStatus
+}
+
+// Backup returns the database backup service
+func (s *ServiceManager) Backup() BackupService {
+	return s.backup
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+// settingsCacheTTL bounds how long a change made by another bot instance takes to show up
+const settingsCacheTTL = time.Minute
+
+// cardHolderMaxLen keeps the holder name readable on the payment instructions
+const cardHolderMaxLen = 64
+
+// Settings validation errors
+var (
+	ErrInvalidAdminIDs  = errors.New("invalid admin ID list")
+	ErrInvalidCard      = errors.New("invalid card number")
+	ErrInvalidCardOwner = errors.New("invalid card holder name")
+)
+
+// SettingsService serves the settings super admins can change at runtime.
+// Values come from bot_settings, falling back to the env config for keys never changed.
+type SettingsService interface {
+	// AdminIDs returns the current admins; super admins are always included
+	AdminIDs(ctx context.Context) []int64
+	IsAdmin(ctx context.Context, userID int64) bool
+	IsSuperAdmin(userID int64) bool
+	// Card returns the card workers pay the booking fee to
+	Card(ctx context.Context) models.PaymentCard
+	// Overridden reports which keys are stored in bot_settings rather than taken from env
+	Overridden(ctx context.Context) map[string]bool
+	// SetAdminIDs parses a comma or space separated ID list and stores it
+	SetAdminIDs(ctx context.Context, input string, updatedBy int64) ([]int64, error)
+	SetCard(ctx context.Context, number, holderName string, updatedBy int64) (models.PaymentCard, error)
+	// Invalidate drops the cached values so the next read goes to storage
+	Invalidate()
+}
+
+// settingsSnapshot is one load of the effective settings
+type settingsSnapshot struct {
+	adminIDs   []int64
+	card       models.PaymentCard
+	overridden map[string]bool
+	loadedAt   time.Time
+}
+
+type settingsService struct {
+	cfg     config.Config
+	log     logger.LoggerI
+	storage storage.StorageI
+	clock   Clock
+
+	mu     sync.RWMutex
+	cached *settingsSnapshot
+}
+
+// NewSettingsService creates a new runtime settings service
+func NewSettingsService(cfg config.Config, log logger.LoggerI, storage storage.StorageI, clock Clock) SettingsService {
+	return &settingsService{
+		cfg:     cfg,
+		log:     log,
+		storage: storage,
+		clock:   clock,
+	}
+}
+
+// AdminIDs returns the current admins; super admins are always included
+func (s *settingsService) AdminIDs(ctx context.Context) []int64 {
+	ids := slices.Clone(s.load(ctx).adminIDs)
+	for _, id := range s.cfg.Bot.SuperAdminIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// IsAdmin reports whether the user is in the current admin list
+func (s *settingsService) IsAdmin(ctx context.Context, userID int64) bool {
+	return s.IsSuperAdmin(userID) || slices.Contains(s.load(ctx).adminIDs, userID)
+}
+
+// IsSuperAdmin reports whether the user may change runtime settings (BOT_SUPER_ADMIN_IDS)
+func (s *settingsService) IsSuperAdmin(userID int64) bool {
+	return slices.Contains(s.cfg.Bot.SuperAdminIDs, userID)
+}
+
+// Card returns the card workers pay the booking fee to
+func (s *settingsService) Card(ctx context.Context) models.PaymentCard {
+	return s.load(ctx).card
+}
+
+// SetAdminIDs parses a comma or space separated ID list and stores it
+func (s *settingsService) SetAdminIDs(ctx context.Context, input string, updatedBy int64) ([]int64, error) {
+	ids, err := parseAdminIDs(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Settings().Set(ctx, models.SettingAdminIDs, formatAdminIDs(ids), updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to save admin IDs: %w", err)
+	}
+	s.Invalidate()
+	s.audit(ctx, updatedBy, fmt.Sprintf("%s=%s", models.SettingAdminIDs, formatAdminIDs(ids)))
+
+	logger.FromContext(ctx, s.log).Info("Admin list changed",
+		logger.Any("admin_ids", ids),
+		logger.Any("updated_by", updatedBy),
+	)
+	return ids, nil
+}
+
+// SetCard validates and stores the payment card
+func (s *settingsService) SetCard(ctx context.Context, number, holderName string, updatedBy int64) (models.PaymentCard, error) {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(number) != 16 || strings.Trim(number, "0123456789") != "" {
+		return models.PaymentCard{}, ErrInvalidCard
+	}
+	holderName = strings.TrimSpace(holderName)
+	if holderName == "" || utf8.RuneCountInString(holderName) > cardHolderMaxLen {
+		return models.PaymentCard{}, ErrInvalidCardOwner
+	}
+
+	settings := s.storage.Settings()
+	if err := settings.Set(ctx, models.SettingCardNumber, number, updatedBy); err != nil {
+		return models.PaymentCard{}, fmt.Errorf("failed to save card number: %w", err)
+	}
+	if err := settings.Set(ctx, models.SettingCardHolderName, holderName, updatedBy); err != nil {
+		return models.PaymentCard{}, fmt.Errorf("failed to save card holder name: %w", err)
+	}
+	s.Invalidate()
+	s.audit(ctx, updatedBy, fmt.Sprintf("%s=****%s %s=%s",
+		models.SettingCardNumber, number[len(number)-4:], models.SettingCardHolderName, holderName))
+
+	logger.FromContext(ctx, s.log).Info("Payment card changed", logger.Any("updated_by", updatedBy))
+	return models.PaymentCard{Number: number, HolderName: holderName}, nil
+}
+
+// Overridden reports which keys are stored in bot_settings rather than taken from env
+func (s *settingsService) Overridden(ctx context.Context) map[string]bool {
+	return maps.Clone(s.load(ctx).overridden)
+}
+
+// Invalidate drops the cached values so the next read goes to storage
+func (s *settingsService) Invalidate() {
+	s.mu.Lock()
+	s.cached = nil
+	s.mu.Unlock()
+}
+
+// load returns the cached settings, reloading them from storage once they are older than settingsCacheTTL.
+// When storage fails the last good values are kept, or the env config is used if there are none.
+func (s *settingsService) load(ctx context.Context) *settingsSnapshot {
+	now := s.clock.Now()
+
+	s.mu.RLock()
+	cached := s.cached
+	s.mu.RUnlock()
+	if cached != nil && now.Sub(cached.loadedAt) < settingsCacheTTL {
+		return cached
+	}
+
+	stored, err := s.storage.Settings().GetAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Failed to load bot settings, using previous values", logger.Error(err))
+		if cached != nil {
+			return cached
+		}
+		return s.snapshot(nil, now)
+	}
+
+	snap := s.snapshot(stored, now)
+	s.mu.Lock()
+	s.cached = snap
+	s.mu.Unlock()
+	return snap
+}
+
+// snapshot applies the stored values over the env config
+func (s *settingsService) snapshot(stored map[string]string, now time.Time) *settingsSnapshot {
+	snap := &settingsSnapshot{
+		adminIDs: s.cfg.Bot.AdminIDs,
+		card: models.PaymentCard{
+			Number:     s.cfg.Payment.CardNumber,
+			HolderName: s.cfg.Payment.CardHolderName,
+		},
+		overridden: make(map[string]bool),
+		loadedAt:   now,
+	}
+
+	if value, ok := stored[models.SettingAdminIDs]; ok {
+		if ids, err := parseAdminIDs(value); err == nil {
+			snap.adminIDs = ids
+			snap.overridden[models.SettingAdminIDs] = true
+		} else {
+			s.log.Error("Stored admin list is invalid, using BOT_ADMIN_IDS", logger.String("value", value))
+		}
+	}
+	if value, ok := stored[models.SettingCardNumber]; ok {
+		snap.card.Number = value
+		snap.overridden[models.SettingCardNumber] = true
+	}
+	if value, ok := stored[models.SettingCardHolderName]; ok {
+		snap.card.HolderName = value
+		snap.overridden[models.SettingCardHolderName] = true
+	}
+	return snap
+}
+
+// audit records a settings change; failures are logged, the change itself already happened
+func (s *settingsService) audit(ctx context.Context, actorID int64, details string) {
+	entry := &models.AuditEntry{
+		ActorID: &actorID,
+		Action:  models.AuditActionSettings,
+		Details: details,
+	}
+	if err := s.storage.Audit().Create(ctx, entry); err != nil {
+		logger.FromContext(ctx, s.log).Error("Failed to record settings change", logger.Error(err))
+	}
+}
+
+// parseAdminIDs reads a comma or space separated list of positive user IDs, dropping duplicates
+func parseAdminIDs(input string) ([]int64, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, ErrInvalidAdminIDs
+	}
+
+	ids := make([]int64, 0, len(fields))
+	for _, field := range fields {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAdminIDs, field)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// formatAdminIDs is the stored form of an admin list, the same as BOT_ADMIN_IDS
+func formatAdminIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
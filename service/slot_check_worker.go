@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// slotCheckTimeout is the max time for one reconciliation round.
+const slotCheckTimeout = 2 * time.Minute
+
+// SlotCheckWorker recomputes job slot counters from bookings once a night.
+//
+// reserved_slots and confirmed_slots are maintained incrementally, so a
+// crash between a booking change and its counter update, or a bug in one of
+// the rejection/expiry paths, leaves them off for good. The worker repairs
+// them in one transaction and alerts admins when the drift is large.
+type SlotCheckWorker struct {
+	cfg      *config.Config
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	posts    JobPostUpdater
+	settings SettingsService
+	interval time.Duration
+	stopChan chan struct{}
+	lastRun  string // Local date (YYYY-MM-DD) of the last reconciliation
+}
+
+// NewSlotCheckWorker creates a new slot counter reconciliation worker
+func NewSlotCheckWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, posts JobPostUpdater, settings SettingsService) *SlotCheckWorker {
+	return &SlotCheckWorker{
+		cfg:      cfg,
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		posts:    posts,
+		settings: settings,
+		interval: time.Minute, // Check once a minute whether the reconciliation hour has come
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the slot check worker background process
+func (w *SlotCheckWorker) Start() {
+	w.log.Info("Slot check worker started", logger.Any("check_hour", w.cfg.Bot.SlotCheckHour))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeCheck()
+		case <-w.stopChan:
+			w.log.Info("Slot check worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the slot check worker
+func (w *SlotCheckWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeCheck wraps check with panic recovery
+func (w *SlotCheckWorker) safeCheck() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in slot check worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.check()
+}
+
+// check reconciles during the configured hour, at most once per day
+func (w *SlotCheckWorker) check() {
+	now := config.NowLocal()
+	today := now.Format(time.DateOnly)
+	if now.Hour() != w.cfg.Bot.SlotCheckHour || w.lastRun == today {
+		return
+	}
+	w.lastRun = today
+
+	ctx, cancel := context.WithTimeout(context.Background(), slotCheckTimeout)
+	defer cancel()
+
+	fixed, err := w.Reconcile(ctx)
+	if err != nil {
+		w.log.Error("Slot reconciliation failed", logger.Error(err))
+		return
+	}
+
+	total := 0
+	for _, d := range fixed {
+		total += d.Size()
+	}
+	w.log.Info("Slot reconciliation finished",
+		logger.Int("jobs_fixed", len(fixed)),
+		logger.Int("total_drift", total),
+	)
+
+	if total > w.cfg.Bot.SlotDriftAlert {
+		w.alert(ctx, fixed, total)
+	}
+}
+
+// Reconcile repairs every job whose slot counters disagree with its bookings
+// and returns the corrections that were made.
+func (w *SlotCheckWorker) Reconcile(ctx context.Context) ([]*models.SlotDrift, error) {
+	candidates, err := w.storage.Job().GetSlotDrifts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find slot drifts: %w", err)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	fixed, err := w.repair(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range fixed {
+		w.log.Warn("Slot counters corrected",
+			logger.Any("job_id", d.JobID),
+			logger.String("reserved", fmt.Sprintf("%d -> %d", d.Reserved, d.ActualReserved)),
+			logger.String("confirmed", fmt.Sprintf("%d -> %d", d.Confirmed, d.ActualConfirmed)),
+		)
+
+		entry := &models.AuditEntry{
+			Action: models.AuditActionSlotRepair,
+			Details: fmt.Sprintf("job_id=%d reserved=%d->%d confirmed=%d->%d",
+				d.JobID, d.Reserved, d.ActualReserved, d.Confirmed, d.ActualConfirmed),
+		}
+		if err := w.storage.Audit().Create(ctx, entry); err != nil {
+			w.log.Error("Failed to record slot repair", logger.Error(err), logger.Any("job_id", d.JobID))
+		}
+
+		d.Job = w.refreshPosts(ctx, d.JobID)
+	}
+
+	return fixed, nil
+}
+
+// repair recounts the candidate jobs in one transaction. A candidate may have
+// been fixed by a concurrent booking change since it was listed; only real
+// corrections are returned.
+func (w *SlotCheckWorker) repair(ctx context.Context, candidates []*models.SlotDrift) ([]*models.SlotDrift, error) {
+	tx, err := w.storage.Transaction().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if rbErr := w.storage.Transaction().Rollback(ctx, tx); rbErr != nil {
+			w.log.Debug("Rollback after slot repair (expected if committed)", logger.Error(rbErr))
+		}
+	}()
+
+	var fixed []*models.SlotDrift
+	for _, c := range candidates {
+		d, err := w.storage.Job().RecountSlots(ctx, tx, c.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("recount job %d: %w", c.JobID, err)
+		}
+		if d.Size() == 0 {
+			continue
+		}
+
+		if err := w.syncStatus(ctx, tx, d.JobID); err != nil {
+			return nil, fmt.Errorf("sync status of job %d: %w", d.JobID, err)
+		}
+
+		fixed = append(fixed, d)
+	}
+
+	if err := w.storage.Transaction().Commit(ctx, tx); err != nil {
+		return nil, fmt.Errorf("commit slot repair: %w", err)
+	}
+	return fixed, nil
+}
+
+// syncStatus flips ACTIVE/FULL to match the corrected confirmed count
+func (w *SlotCheckWorker) syncStatus(ctx context.Context, tx any, jobID int64) error {
+	job, err := w.storage.Job().GetByIDForUpdate(ctx, tx, jobID)
+	if err != nil {
+		return err
+	}
+
+	if next := job.SlotStatus(); next != job.Status {
+		return w.storage.Job().UpdateStatusInTx(ctx, tx, jobID, next)
+	}
+	return nil
+}
+
+// refreshPosts updates the channel and admin posts so they show the corrected slots.
+// It returns the repaired job, or nil if it could not be loaded.
+func (w *SlotCheckWorker) refreshPosts(ctx context.Context, jobID int64) *models.Job {
+	job, err := w.storage.Job().GetByID(ctx, jobID)
+	if err != nil {
+		w.log.Error("Failed to get job after slot repair", logger.Error(err), logger.Any("job_id", jobID))
+		return nil
+	}
+	if err := w.posts.UpdateChannelJobPost(ctx, job); err != nil {
+		w.log.Error("Failed to update channel post after slot repair", logger.Error(err), logger.Any("job_id", jobID))
+	}
+	if err := w.posts.UpdateAdminJobPost(ctx, job); err != nil {
+		w.log.Error("Failed to update admin post after slot repair", logger.Error(err), logger.Any("job_id", jobID))
+	}
+	return job
+}
+
+// alert tells admins that the counters had drifted further than BOT_SLOT_DRIFT_ALERT allows
+func (w *SlotCheckWorker) alert(ctx context.Context, fixed []*models.SlotDrift, total int) {
+	msg := messages.FormatSlotDriftAlert(fixed, total)
+	for _, adminID := range w.settings.AdminIDs(ctx) {
+		if _, err := w.bot.Send(&tele.User{ID: adminID}, msg, tele.ModeHTML); err != nil {
+			w.log.Error("Failed to send slot drift alert", logger.Error(err), logger.Any("admin_id", adminID))
+		}
+	}
+}
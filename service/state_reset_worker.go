@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/keyboards"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// stateResetTimeout is the max time for one reset round.
+	stateResetTimeout = time.Minute
+
+	// stateResetBatchSize limits users reset per round.
+	stateResetBatchSize = 100
+
+	// The worker looks for stale states every quarter of the TTL, within these bounds.
+	stateResetMinInterval = time.Minute
+	stateResetMaxInterval = 15 * time.Minute
+)
+
+// StateResetWorker moves users who abandoned a multi-step flow back to idle.
+//
+// Flows also expire lazily on the user's next message (see bot/fsm), but a
+// user who never writes again would keep the state, their registration draft
+// and the admin's in-memory temp job forever.
+type StateResetWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	ttl      time.Duration
+	notify   bool
+	settings SettingsService
+	// clearSession drops the handler's in-memory session data (temp jobs, FAQ entries, ...)
+	clearSession func(userID int64)
+	interval     time.Duration
+	stopChan     chan struct{}
+}
+
+// NewStateResetWorker creates a new stale state remover.
+// clearSession may be nil when no in-memory session data needs dropping.
+func NewStateResetWorker(cfg *config.Config, storage storage.StorageI, log logger.LoggerI, bot BotAPI, settings SettingsService, clearSession func(userID int64)) *StateResetWorker {
+	return &StateResetWorker{
+		storage:      storage,
+		log:          log,
+		bot:          bot,
+		ttl:          cfg.Bot.StaleStateTTL,
+		notify:       cfg.Bot.StaleStateNotify,
+		settings:     settings,
+		clearSession: clearSession,
+		interval:     max(min(cfg.Bot.StaleStateTTL/4, stateResetMaxInterval), stateResetMinInterval),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins the state reset worker background process
+func (w *StateResetWorker) Start() {
+	if w.ttl <= 0 {
+		w.log.Info("State reset worker disabled (BOT_STALE_STATE_TTL=0)")
+		<-w.stopChan
+		return
+	}
+
+	w.log.Info("State reset worker started",
+		logger.Any("ttl", w.ttl.String()),
+		logger.Any("interval", w.interval.String()),
+	)
+
+	// Reset states that went stale while the bot was down
+	w.safeProcess()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("State reset worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the state reset worker
+func (w *StateResetWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *StateResetWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in state reset worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process resets users whose state has not changed for longer than the TTL
+func (w *StateResetWorker) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), stateResetTimeout)
+	defer cancel()
+
+	before := time.Now().Add(-w.ttl)
+	users, err := w.storage.User().GetStaleStates(ctx, before, stateResetBatchSize)
+	if err != nil {
+		w.log.Error("Failed to get stale user states", logger.Error(err))
+		return
+	}
+
+	for _, user := range users {
+		w.reset(ctx, user, before)
+	}
+}
+
+// reset moves one user back to idle, drops what the abandoned flow left behind and tells the user
+func (w *StateResetWorker) reset(ctx context.Context, user *models.User, before time.Time) {
+	// Re-checked in storage: the user may have answered since the list was read
+	reset, err := w.storage.User().ResetStaleState(ctx, user.ID, user.State, before)
+	if err != nil {
+		w.log.Error("Failed to reset stale state", logger.Error(err), logger.Any("user_id", user.ID))
+		return
+	}
+	if !reset {
+		return
+	}
+
+	if models.IsRegistrationState(user.State) {
+		if err := w.storage.Registration().DeleteDraft(ctx, user.ID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			w.log.Error("Failed to delete abandoned registration draft", logger.Error(err), logger.Any("user_id", user.ID))
+		}
+	}
+	if w.clearSession != nil {
+		w.clearSession(user.ID)
+	}
+
+	if w.notify {
+		if _, err := w.bot.Send(&tele.User{ID: user.ID}, messages.MsgStaleStateReset, w.keyboardFor(ctx, user)); err != nil {
+			w.log.Error("Failed to notify user about state reset", logger.Error(err), logger.Any("user_id", user.ID))
+		}
+	}
+
+	w.log.Info("Stale state reset",
+		logger.Any("user_id", user.ID),
+		logger.String("state", string(user.State)),
+		logger.String("idle_for", time.Since(user.StateUpdatedAt).Round(time.Minute).String()),
+	)
+}
+
+// keyboardFor returns the menu the user should land on after the reset
+func (w *StateResetWorker) keyboardFor(ctx context.Context, user *models.User) *tele.ReplyMarkup {
+	switch {
+	case w.settings.IsAdmin(ctx, user.ID):
+		return keyboards.AdminMenuReplyKeyboard()
+	case strings.HasPrefix(string(user.State), "editing_profile_"):
+		return keyboards.UserMainMenuReplyKeyboard()
+	default:
+		return keyboards.RemoveReplyKeyboard()
+	}
+}
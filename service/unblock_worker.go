@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/messages"
+	"telegram-bot-starter/storage"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	// unblockTimeout is the max time for one unblock round.
+	unblockTimeout = time.Minute
+
+	// unblockBatchSize limits blocks lifted per round.
+	unblockBatchSize = 100
+)
+
+// UnblockWorker lifts temporary blocks once they expire and tells the user.
+//
+// Without it a block only disappears when the user next tries to book, so the
+// blocked count stays inflated and the user is never told they can come back.
+type UnblockWorker struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	bot      BotAPI
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewUnblockWorker creates a new expired block remover
+func NewUnblockWorker(storage storage.StorageI, log logger.LoggerI, bot BotAPI) *UnblockWorker {
+	return &UnblockWorker{
+		storage:  storage,
+		log:      log,
+		bot:      bot,
+		interval: 5 * time.Minute,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the unblock worker background process
+func (w *UnblockWorker) Start() {
+	w.log.Info("Unblock worker started", logger.Any("interval", w.interval.String()))
+
+	// Lift blocks that expired while the bot was down
+	w.safeProcess()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.safeProcess()
+		case <-w.stopChan:
+			w.log.Info("Unblock worker stopped")
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the unblock worker
+func (w *UnblockWorker) Stop() {
+	close(w.stopChan)
+}
+
+// safeProcess wraps process with panic recovery
+func (w *UnblockWorker) safeProcess() {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("PANIC in unblock worker recovered",
+				logger.Any("panic", fmt.Sprintf("%v", r)),
+				logger.Any("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	w.process()
+}
+
+// process removes expired temporary blocks
+func (w *UnblockWorker) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), unblockTimeout)
+	defer cancel()
+
+	now := time.Now()
+	blocks, err := w.storage.User().GetExpiredBlocks(ctx, now, unblockBatchSize)
+	if err != nil {
+		w.log.Error("Failed to get expired blocks", logger.Error(err))
+		return
+	}
+
+	for _, block := range blocks {
+		w.unblock(ctx, block, now)
+	}
+}
+
+// unblock lifts one block, records it in the audit log and notifies the user
+func (w *UnblockWorker) unblock(ctx context.Context, block *models.BlockedUser, now time.Time) {
+	// Re-checked in storage: an admin may have escalated the block since it was listed
+	removed, err := w.storage.User().UnblockIfExpired(ctx, block.UserID, now)
+	if err != nil {
+		w.log.Error("Failed to lift expired block", logger.Error(err), logger.Any("user_id", block.UserID))
+		return
+	}
+	if !removed {
+		return
+	}
+
+	userID := block.UserID
+	entry := &models.AuditEntry{
+		Action:       models.AuditActionAutoUnblock,
+		TargetUserID: &userID,
+		Details: fmt.Sprintf("blocked_until=%s total_violations=%d",
+			block.BlockedUntil.Format(time.RFC3339), block.TotalViolations),
+	}
+	if err := w.storage.Audit().Create(ctx, entry); err != nil {
+		w.log.Error("Failed to record auto-unblock", logger.Error(err), logger.Any("user_id", userID))
+	}
+
+	if _, err := w.bot.Send(&tele.User{ID: userID}, messages.MsgBlockExpired); err != nil {
+		w.log.Error("Failed to notify user about block expiry", logger.Error(err), logger.Any("user_id", userID))
+	}
+
+	w.log.Info("Expired block lifted",
+		logger.Any("user_id", userID),
+		logger.Any("total_violations", block.TotalViolations),
+	)
+}
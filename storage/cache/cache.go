@@ -0,0 +1,92 @@
+// Package cache wraps a storage.StorageI with in-process read caches for hot rows.
+// Jobs are re-read on nearly every callback while a post is live in the channel;
+// caching them for a few seconds keeps signup surges off the database.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/storage"
+)
+
+// Store decorates a storage.StorageI; repositories it does not override pass through
+type Store struct {
+	storage.StorageI
+
+	jobs *jobRepo
+	tx   *transactionManager
+}
+
+// New wraps inner with a job cache whose entries live for ttl.
+// A non-positive ttl disables caching and returns inner unchanged.
+func New(inner storage.StorageI, ttl time.Duration) storage.StorageI {
+	if ttl <= 0 {
+		return inner
+	}
+
+	jobs := newJobRepo(inner.Job(), ttl)
+	tx := &transactionManager{inner: inner.Transaction(), jobs: jobs, pending: make(map[any][]int64)}
+	jobs.tx = tx
+
+	return &Store{StorageI: inner, jobs: jobs, tx: tx}
+}
+
+// Job returns the caching job repository
+func (s *Store) Job() storage.JobRepoI {
+	return s.jobs
+}
+
+// Transaction returns the transaction manager
+func (s *Store) Transaction() storage.TransactionI {
+	return s.tx
+}
+
+// transactionManager re-invalidates jobs written inside a transaction once it ends,
+// so a read racing the write cannot leave the pre-commit row in the cache.
+type transactionManager struct {
+	inner storage.TransactionI
+	jobs  *jobRepo
+
+	mu      sync.Mutex
+	pending map[any][]int64 // tx -> job IDs written with it
+}
+
+// Begin starts a transaction on the wrapped store
+func (tm *transactionManager) Begin(ctx context.Context) (any, error) {
+	return tm.inner.Begin(ctx)
+}
+
+// Commit commits the transaction and drops the jobs it wrote from the cache
+func (tm *transactionManager) Commit(ctx context.Context, tx any) error {
+	defer tm.flush(tx)
+	return tm.inner.Commit(ctx, tx)
+}
+
+// Rollback rolls back the transaction and drops the jobs it wrote from the cache
+func (tm *transactionManager) Rollback(ctx context.Context, tx any) error {
+	defer tm.flush(tx)
+	return tm.inner.Rollback(ctx, tx)
+}
+
+// track records that tx wrote the job with the given ID
+func (tm *transactionManager) track(tx any, jobID int64) {
+	if tx == nil {
+		return
+	}
+	tm.mu.Lock()
+	tm.pending[tx] = append(tm.pending[tx], jobID)
+	tm.mu.Unlock()
+}
+
+func (tm *transactionManager) flush(tx any) {
+	tm.mu.Lock()
+	ids := tm.pending[tx]
+	delete(tm.pending, tx)
+	tm.mu.Unlock()
+
+	for _, id := range ids {
+		tm.jobs.invalidate(id)
+	}
+}
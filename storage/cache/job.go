@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type jobEntry struct {
+	job       models.Job
+	expiresAt time.Time
+}
+
+// jobRepo caches GetByID results and invalidates them on every job write.
+// Callers get their own copy, so mutating a returned job never touches the cache.
+type jobRepo struct {
+	storage.JobRepoI
+
+	ttl time.Duration
+	tx  *transactionManager
+
+	mu      sync.Mutex
+	entries map[int64]jobEntry
+	// generation bumps on every invalidation; a load that straddles one is not cached
+	generation uint64
+}
+
+func newJobRepo(inner storage.JobRepoI, ttl time.Duration) *jobRepo {
+	return &jobRepo{
+		JobRepoI: inner,
+		ttl:      ttl,
+		entries:  make(map[int64]jobEntry),
+	}
+}
+
+// GetByID serves a job from the cache, loading it from the wrapped repository on a miss
+func (r *jobRepo) GetByID(ctx context.Context, id int64) (*models.Job, error) {
+	r.mu.Lock()
+	if e, ok := r.entries[id]; ok && time.Now().Before(e.expiresAt) {
+		r.mu.Unlock()
+		job := e.job
+		return &job, nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+
+	job, err := r.JobRepoI.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.entries[id] = jobEntry{job: *job, expiresAt: time.Now().Add(r.ttl)}
+	}
+	r.mu.Unlock()
+
+	return job, nil
+}
+
+// invalidate drops a job from the cache
+func (r *jobRepo) invalidate(id int64) {
+	r.mu.Lock()
+	delete(r.entries, id)
+	r.generation++
+	r.mu.Unlock()
+}
+
+// written invalidates a job now and again when tx (if any) finishes
+func (r *jobRepo) written(tx any, id int64) {
+	r.invalidate(id)
+	r.tx.track(tx, id)
+}
+
+// Update updates a job and drops it from the cache
+func (r *jobRepo) Update(ctx context.Context, job *models.Job) error {
+	defer r.invalidate(job.ID)
+	return r.JobRepoI.Update(ctx, job)
+}
+
+// UpdateStatus updates the job status and drops it from the cache
+func (r *jobRepo) UpdateStatus(ctx context.Context, id int64, status models.JobStatus) error {
+	defer r.invalidate(id)
+	return r.JobRepoI.UpdateStatus(ctx, id, status)
+}
+
+// UpdateStatusInTx updates the job status within a transaction
+func (r *jobRepo) UpdateStatusInTx(ctx context.Context, tx any, id int64, status models.JobStatus) error {
+	defer r.written(tx, id)
+	return r.JobRepoI.UpdateStatusInTx(ctx, tx, id, status)
+}
+
+// Delete deletes a job and drops it from the cache
+func (r *jobRepo) Delete(ctx context.Context, id int64) error {
+	defer r.invalidate(id)
+	return r.JobRepoI.Delete(ctx, id)
+}
+
+// UpdateChannelMessageID stores the channel post ID and drops the job from the cache
+func (r *jobRepo) UpdateChannelMessageID(ctx context.Context, id int64, messageID int64) error {
+	defer r.invalidate(id)
+	return r.JobRepoI.UpdateChannelMessageID(ctx, id, messageID)
+}
+
+// UpdateChannelLocationMessageID stores the location reply ID and drops the job from the cache
+func (r *jobRepo) UpdateChannelLocationMessageID(ctx context.Context, id int64, messageID int64) error {
+	defer r.invalidate(id)
+	return r.JobRepoI.UpdateChannelLocationMessageID(ctx, id, messageID)
+}
+
+// UpdateAdminMessageID stores the admin message ID and drops the job from the cache
+func (r *jobRepo) UpdateAdminMessageID(ctx context.Context, id int64, messageID int64) error {
+	defer r.invalidate(id)
+	return r.JobRepoI.UpdateAdminMessageID(ctx, id, messageID)
+}
+
+// IncrementReservedSlots reserves a slot within a transaction
+func (r *jobRepo) IncrementReservedSlots(ctx context.Context, tx any, jobID int64) error {
+	defer r.written(tx, jobID)
+	return r.JobRepoI.IncrementReservedSlots(ctx, tx, jobID)
+}
+
+// DecrementReservedSlots releases a reserved slot within a transaction
+func (r *jobRepo) DecrementReservedSlots(ctx context.Context, tx any, jobID int64) error {
+	defer r.written(tx, jobID)
+	return r.JobRepoI.DecrementReservedSlots(ctx, tx, jobID)
+}
+
+// MoveReservedToConfirmed confirms a reserved slot within a transaction
+func (r *jobRepo) MoveReservedToConfirmed(ctx context.Context, tx any, jobID int64) error {
+	defer r.written(tx, jobID)
+	return r.JobRepoI.MoveReservedToConfirmed(ctx, tx, jobID)
+}
+
+// RecountSlots repairs the slot counters within a transaction
+func (r *jobRepo) RecountSlots(ctx context.Context, tx any, jobID int64) (*models.SlotDrift, error) {
+	defer r.written(tx, jobID)
+	return r.JobRepoI.RecountSlots(ctx, tx, jobID)
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type adminMessageRepo struct {
+	s *Store
+}
+
+// Upsert creates or updates an admin message for a job
+func (r *adminMessageRepo) Upsert(ctx context.Context, adminMsg *models.AdminJobMessage) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	key := adminMessageKey{jobID: adminMsg.JobID, adminID: adminMsg.AdminID}
+
+	if existing, ok := r.s.adminMessages[key]; ok {
+		existing.MessageID = adminMsg.MessageID
+		existing.UpdatedAt = now
+		adminMsg.ID, adminMsg.CreatedAt, adminMsg.UpdatedAt = existing.ID, existing.CreatedAt, now
+		return nil
+	}
+
+	r.s.nextAdminMessageID++
+	adminMsg.ID = r.s.nextAdminMessageID
+	adminMsg.CreatedAt = now
+	adminMsg.UpdatedAt = now
+
+	m := *adminMsg
+	r.s.adminMessages[key] = &m
+	return nil
+}
+
+// Get retrieves an admin message by job and admin ID
+func (r *adminMessageRepo) Get(ctx context.Context, jobID, adminID int64) (*models.AdminJobMessage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	m, ok := r.s.adminMessages[adminMessageKey{jobID: jobID, adminID: adminID}]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	adminMsg := *m
+	return &adminMsg, nil
+}
+
+// GetAllByJobID retrieves all admin messages for a job, oldest first
+func (r *adminMessageRepo) GetAllByJobID(ctx context.Context, jobID int64) ([]*models.AdminJobMessage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var messages []*models.AdminJobMessage
+	for key, m := range r.s.adminMessages {
+		if key.jobID == jobID {
+			adminMsg := *m
+			messages = append(messages, &adminMsg)
+		}
+	}
+
+	sort.Slice(messages, func(a, b int) bool { return messages[a].ID < messages[b].ID })
+	return messages, nil
+}
+
+// GetAll retrieves every stored admin message, oldest first
+func (r *adminMessageRepo) GetAll(ctx context.Context) ([]*models.AdminJobMessage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	messages := make([]*models.AdminJobMessage, 0, len(r.s.adminMessages))
+	for _, m := range r.s.adminMessages {
+		adminMsg := *m
+		messages = append(messages, &adminMsg)
+	}
+
+	sort.Slice(messages, func(a, b int) bool { return messages[a].ID < messages[b].ID })
+	return messages, nil
+}
+
+// Delete deletes an admin message
+func (r *adminMessageRepo) Delete(ctx context.Context, jobID, adminID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.adminMessages, adminMessageKey{jobID: jobID, adminID: adminID})
+	return nil
+}
+
+// DeleteAllByJobID deletes all admin messages for a job
+func (r *adminMessageRepo) DeleteAllByJobID(ctx context.Context, jobID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for key := range r.s.adminMessages {
+		if key.jobID == jobID {
+			delete(r.s.adminMessages, key)
+		}
+	}
+	return nil
+}
+
+// DeleteForClosedJobsBefore deletes the admin messages of jobs finished before the cutoff
+func (r *adminMessageRepo) DeleteForClosedJobsBefore(ctx context.Context, before time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	n := 0
+	for key := range r.s.adminMessages {
+		job, ok := r.s.jobs[key.jobID]
+		if !ok || !job.UpdatedAt.Before(before) {
+			continue
+		}
+		if job.Status == models.JobStatusCompleted || job.Status == models.JobStatusCancelled {
+			delete(r.s.adminMessages, key)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+type adminPrefsRepo struct {
+	s *Store
+}
+
+// Get retrieves an admin's preferences, falling back to defaults when none were saved
+func (r *adminPrefsRepo) Get(ctx context.Context, adminID int64) (*models.AdminNotificationPrefs, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.adminPrefs[adminID]
+	if !ok {
+		return models.DefaultAdminNotificationPrefs(adminID), nil
+	}
+	prefs := *p
+	return &prefs, nil
+}
+
+// Upsert creates or updates an admin's preferences
+func (r *adminPrefsRepo) Upsert(ctx context.Context, prefs *models.AdminNotificationPrefs) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	prefs.CreatedAt = now
+	if existing, ok := r.s.adminPrefs[prefs.AdminID]; ok {
+		prefs.CreatedAt = existing.CreatedAt
+	}
+	prefs.UpdatedAt = now
+
+	p := *prefs
+	r.s.adminPrefs[prefs.AdminID] = &p
+	return nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+type auditRepo struct {
+	s *Store
+}
+
+// Create appends an entry to the audit log
+func (r *auditRepo) Create(ctx context.Context, entry *models.AuditEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextAuditID++
+	entry.ID = r.s.nextAuditID
+	entry.CreatedAt = time.Now()
+
+	e := *entry
+	r.s.audit = append(r.s.audit, &e)
+	return nil
+}
+
+// GetAdminActivity counts each admin's jobs, receipt reviews and blocks in [from, to)
+func (r *auditRepo) GetAdminActivity(ctx context.Context, from, to time.Time) ([]*models.AdminActivity, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	inPeriod := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	byAdmin := make(map[int64]*models.AdminActivity)
+	timed := make(map[int64]int)
+	reviewTime := make(map[int64]time.Duration)
+	get := func(adminID int64) *models.AdminActivity {
+		a, ok := byAdmin[adminID]
+		if !ok {
+			a = &models.AdminActivity{AdminID: adminID}
+			byAdmin[adminID] = a
+		}
+		return a
+	}
+
+	for _, job := range r.s.jobs {
+		if job.CreatedByAdminID != 0 && inPeriod(job.CreatedAt) {
+			get(job.CreatedByAdminID).JobsCreated++
+		}
+	}
+	for _, b := range r.s.bookings {
+		if b.ReviewedByAdminID == nil || b.ReviewedAt == nil || b.PaymentSubmittedAt == nil || !inPeriod(*b.ReviewedAt) {
+			continue
+		}
+		a := get(*b.ReviewedByAdminID)
+		if b.ConfirmedAt != nil {
+			a.Approved++
+		} else {
+			a.Rejected++
+		}
+		if !b.ReviewedAt.Before(*b.PaymentSubmittedAt) {
+			timed[a.AdminID]++
+			reviewTime[a.AdminID] += b.ReviewedAt.Sub(*b.PaymentSubmittedAt)
+		}
+	}
+	for _, entry := range r.s.audit {
+		if entry.Action == models.AuditActionUserBlock && entry.ActorID != nil && inPeriod(entry.CreatedAt) {
+			get(*entry.ActorID).Blocks++
+		}
+	}
+
+	activity := make([]*models.AdminActivity, 0, len(byAdmin))
+	for id, a := range byAdmin {
+		if timed[id] > 0 {
+			a.AvgReview = reviewTime[id] / time.Duration(timed[id])
+		}
+		activity = append(activity, a)
+	}
+	total := func(a *models.AdminActivity) int { return a.JobsCreated + a.Reviewed() + a.Blocks }
+	sort.Slice(activity, func(i, j int) bool {
+		if total(activity[i]) != total(activity[j]) {
+			return total(activity[i]) > total(activity[j])
+		}
+		return activity[i].AdminID < activity[j].AdminID
+	})
+	return activity, nil
+}
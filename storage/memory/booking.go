@@ -0,0 +1,503 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// bookingRepo implements storage.BookingRepoI interface in memory
+type bookingRepo struct {
+	s *Store
+}
+
+// Create creates a new booking, upserting on idempotency key like the SQL backends
+func (r *bookingRepo) Create(ctx context.Context, tx any, booking *models.JobBooking) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for id, b := range r.s.bookings {
+		if b.IdempotencyKey == booking.IdempotencyKey {
+			journal(t, restoreFunc(r.s.bookings, id))
+			b.Status = booking.Status
+			b.ReservedAt = booking.ReservedAt
+			b.ExpiresAt = booking.ExpiresAt
+			b.PaymentRejections = 0
+			b.PaidAmount = 0
+			b.StatusMessageID = 0
+			b.UpdatedAt = now
+			booking.ID, booking.CreatedAt, booking.UpdatedAt = b.ID, b.CreatedAt, b.UpdatedAt
+			return nil
+		}
+		if b.JobID == booking.JobID && b.UserID == booking.UserID {
+			return fmt.Errorf("failed to create booking: %w", storage.ErrAlreadyExists) // unique_user_job_booking
+		}
+	}
+
+	r.s.nextBookingID++
+	booking.ID = r.s.nextBookingID
+	booking.CreatedAt = now
+	booking.UpdatedAt = now
+
+	b := *booking
+	journal(t, restoreFunc(r.s.bookings, booking.ID))
+	r.s.bookings[booking.ID] = &b
+	return nil
+}
+
+// GetByID retrieves a booking by ID
+func (r *bookingRepo) GetByID(ctx context.Context, id int64) (*models.JobBooking, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.bookings[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	booking := *b
+	return &booking, nil
+}
+
+// GetByIDForUpdate retrieves a booking; the lock is the transaction itself
+func (r *bookingRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.JobBooking, error) {
+	if _, err := checkTx(tx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetByUserAndJob retrieves the latest booking by user and job
+func (r *bookingRepo) GetByUserAndJob(ctx context.Context, userID, jobID int64) (*models.JobBooking, error) {
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		return b.UserID == userID && b.JobID == jobID
+	})
+	if len(bookings) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return bookings[0], nil
+}
+
+// GetByIdempotencyKey retrieves a booking by idempotency key
+func (r *bookingRepo) GetByIdempotencyKey(ctx context.Context, tx any, key string) (*models.JobBooking, error) {
+	if _, err := checkTx(tx); err != nil {
+		return nil, err
+	}
+
+	bookings := r.filter(func(b *models.JobBooking) bool { return b.IdempotencyKey == key })
+	if len(bookings) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return bookings[0], nil
+}
+
+// GetActiveByJobAndPhone finds another account's active booking on the job under the same registered phone
+func (r *bookingRepo) GetActiveByJobAndPhone(ctx context.Context, tx any, jobID int64, phone string, excludeUserID int64, now time.Time) (*models.JobBooking, error) {
+	if _, err := checkTx(tx); err != nil {
+		return nil, err
+	}
+
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		if b.JobID != jobID || b.UserID == excludeUserID {
+			return false
+		}
+		if u, ok := r.s.registered[b.UserID]; !ok || u.Phone != phone {
+			return false
+		}
+		if b.AwaitsReceipt() {
+			return now.Before(b.ExpiresAt)
+		}
+		return b.Status == models.BookingStatusPaymentSubmitted || b.Status == models.BookingStatusConfirmed
+	})
+	if len(bookings) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return bookings[len(bookings)-1], nil
+}
+
+// Update updates a booking's payment and review fields; the status is only written by UpdateStatus and the Mark* methods
+func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBooking) error {
+	return r.modify(tx, booking.ID, func(b *models.JobBooking) {
+		b.PaymentReceiptFileID = booking.PaymentReceiptFileID
+		b.PaymentReceiptMsgID = booking.PaymentReceiptMsgID
+		b.PaymentReceiptExtraFileIDs = booking.PaymentReceiptExtraFileIDs
+		b.PaymentReceiptIsDocument = booking.PaymentReceiptIsDocument
+		b.PaidAmount = booking.PaidAmount
+		b.PaymentInstructionMsgID = booking.PaymentInstructionMsgID
+		b.PaymentSubmittedAt = booking.PaymentSubmittedAt
+		b.ConfirmedAt = booking.ConfirmedAt
+		b.ReviewedByAdminID = booking.ReviewedByAdminID
+		b.ReviewedAt = booking.ReviewedAt
+		b.RejectionReason = booking.RejectionReason
+	})
+}
+
+// Delete deletes a booking
+func (r *bookingRepo) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.bookings, id)
+	// ON DELETE CASCADE
+	delete(r.s.workerRatings, id)
+	delete(r.s.feedback, id)
+	delete(r.s.vouchers, id)
+	return nil
+}
+
+// GetExpiredBookings retrieves reserved bookings whose payment window has passed, oldest deadline first
+func (r *bookingRepo) GetExpiredBookings(ctx context.Context, tx any, now time.Time, limit int) ([]*models.JobBooking, error) {
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		return b.AwaitsReceipt() && b.ExpiresAt.Before(now)
+	})
+	sort.SliceStable(bookings, func(a, b int) bool {
+		return bookings[a].ExpiresAt.Before(bookings[b].ExpiresAt)
+	})
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+	}
+	return bookings, nil
+}
+
+// CountExpired counts awaiting-receipt bookings past expires_at that the expiry worker hasn't released yet
+func (r *bookingRepo) CountExpired(ctx context.Context, now time.Time) (int, error) {
+	return len(r.filter(func(b *models.JobBooking) bool {
+		return b.AwaitsReceipt() && b.ExpiresAt.Before(now)
+	})), nil
+}
+
+// GetActiveReservations retrieves unexpired reservations whose payment instruction message is known
+func (r *bookingRepo) GetActiveReservations(ctx context.Context, now time.Time, limit int) ([]*models.JobBooking, error) {
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		return b.Status == models.BookingStatusSlotReserved && b.ExpiresAt.After(now) && b.PaymentInstructionMsgID != 0
+	})
+	sort.SliceStable(bookings, func(a, b int) bool {
+		return bookings[a].ExpiresAt.Before(bookings[b].ExpiresAt)
+	})
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+	}
+	return bookings, nil
+}
+
+// GetPendingApprovals retrieves bookings waiting for admin approval, oldest submission first
+func (r *bookingRepo) GetPendingApprovals(ctx context.Context) ([]*models.JobBooking, error) {
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		return b.Status == models.BookingStatusPaymentSubmitted
+	})
+	sort.SliceStable(bookings, func(a, b int) bool {
+		return submittedAt(bookings[a]).Before(submittedAt(bookings[b]))
+	})
+	return bookings, nil
+}
+
+// GetUserBookings retrieves all bookings for a user
+func (r *bookingRepo) GetUserBookings(ctx context.Context, userID int64) ([]*models.JobBooking, error) {
+	return r.filter(func(b *models.JobBooking) bool { return b.UserID == userID }), nil
+}
+
+// GetUserBookingsByStatus retrieves user bookings filtered by status
+func (r *bookingRepo) GetUserBookingsByStatus(ctx context.Context, userID int64, status models.BookingStatus) ([]*models.JobBooking, error) {
+	return r.filter(func(b *models.JobBooking) bool {
+		return b.UserID == userID && b.Status == status
+	}), nil
+}
+
+// GetJobBookings retrieves all bookings for a job
+func (r *bookingRepo) GetJobBookings(ctx context.Context, jobID int64) ([]*models.JobBooking, error) {
+	return r.filter(func(b *models.JobBooking) bool { return b.JobID == jobID }), nil
+}
+
+// UpdateStatus updates booking status
+func (r *bookingRepo) UpdateStatus(ctx context.Context, tx any, bookingID int64, status models.BookingStatus) error {
+	return r.modify(tx, bookingID, func(b *models.JobBooking) {
+		b.Status = status
+	})
+}
+
+// MarkAsExpired marks a booking as expired
+func (r *bookingRepo) MarkAsExpired(ctx context.Context, tx any, bookingID int64) error {
+	return r.UpdateStatus(ctx, tx, bookingID, models.BookingStatusExpired)
+}
+
+// MarkAsConfirmed marks a booking as confirmed by admin
+func (r *bookingRepo) MarkAsConfirmed(ctx context.Context, tx any, bookingID int64, adminID int64) error {
+	return r.modify(tx, bookingID, func(b *models.JobBooking) {
+		now := time.Now()
+		b.Status = models.BookingStatusConfirmed
+		b.ConfirmedAt = &now
+		b.ReviewedByAdminID = &adminID
+		b.ReviewedAt = &now
+	})
+}
+
+// MarkAsRejected marks a booking as rejected by admin
+func (r *bookingRepo) MarkAsRejected(ctx context.Context, tx any, bookingID int64, adminID int64, reason string) error {
+	return r.modify(tx, bookingID, func(b *models.JobBooking) {
+		now := time.Now()
+		b.Status = models.BookingStatusRejected
+		b.RejectionReason = reason
+		b.ReviewedByAdminID = &adminID
+		b.ReviewedAt = &now
+	})
+}
+
+// MarkAsRetryable rejects the receipt but keeps the slot held until expiresAt
+func (r *bookingRepo) MarkAsRetryable(ctx context.Context, tx any, bookingID int64, adminID int64, reason string, expiresAt time.Time) error {
+	return r.modify(tx, bookingID, func(b *models.JobBooking) {
+		now := time.Now()
+		b.Status = models.BookingStatusPaymentRejectedRetryable
+		b.RejectionReason = reason
+		b.ReviewedByAdminID = &adminID
+		b.ReviewedAt = &now
+		b.ExpiresAt = expiresAt
+		b.PaymentRejections++
+	})
+}
+
+// AddEvent appends a status change to the booking's history
+func (r *bookingRepo) AddEvent(ctx context.Context, tx any, event *models.BookingEvent) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextBookingEventID++
+	event.ID = r.s.nextBookingEventID
+	event.CreatedAt = time.Now()
+
+	e := *event
+	n := len(r.s.bookingEvents)
+	r.s.bookingEvents = append(r.s.bookingEvents, &e)
+	journal(t, func() { r.s.bookingEvents = r.s.bookingEvents[:n] })
+	return nil
+}
+
+// GetEvents returns the booking's status history, oldest first
+func (r *bookingRepo) GetEvents(ctx context.Context, bookingID int64) ([]*models.BookingEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var events []*models.BookingEvent
+	for _, e := range r.s.bookingEvents {
+		if e.BookingID == bookingID {
+			copied := *e
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
+
+// SetAttendance records whether a confirmed worker showed up
+func (r *bookingRepo) SetAttendance(ctx context.Context, bookingID int64, attendance models.AttendanceStatus) error {
+	return r.modify(nil, bookingID, func(b *models.JobBooking) {
+		b.Attendance = attendance
+	})
+}
+
+// GetUnarchivedReceipts retrieves approved bookings whose receipt isn't in the archive yet, oldest approval first
+func (r *bookingRepo) GetUnarchivedReceipts(ctx context.Context, limit int) ([]*models.JobBooking, error) {
+	bookings := r.filter(func(b *models.JobBooking) bool {
+		return b.ReceiptArchiveURL == "" && b.PaymentReceiptFileID != "" && b.ConfirmedAt != nil
+	})
+	sort.SliceStable(bookings, func(a, b int) bool {
+		return bookings[a].ConfirmedAt.Before(*bookings[b].ConfirmedAt)
+	})
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+	}
+	return bookings, nil
+}
+
+// SetReceiptArchiveURLs records where the archived receipt files are stored, in ReceiptFileIDs order
+func (r *bookingRepo) SetReceiptArchiveURLs(ctx context.Context, bookingID int64, urls []string) error {
+	if len(urls) == 0 {
+		return errors.New("no receipt archive URLs")
+	}
+	return r.modify(nil, bookingID, func(b *models.JobBooking) {
+		b.ReceiptArchiveURL = urls[0]
+		b.ReceiptArchiveExtraURLs = slices.Clone(urls[1:])
+	})
+}
+
+// SetStatusMessageID records the user's status message of the booking
+func (r *bookingRepo) SetStatusMessageID(ctx context.Context, bookingID int64, messageID int64) error {
+	return r.modify(nil, bookingID, func(b *models.JobBooking) {
+		b.StatusMessageID = messageID
+	})
+}
+
+// SetPaymentInstructionMsgID records the payment instruction message of the booking, leaving the status alone
+func (r *bookingRepo) SetPaymentInstructionMsgID(ctx context.Context, bookingID int64, messageID int64) error {
+	return r.modify(nil, bookingID, func(b *models.JobBooking) {
+		b.PaymentInstructionMsgID = messageID
+	})
+}
+
+// AnonymizeUserBookings clears payment receipts and message references from a user's bookings
+func (r *bookingRepo) AnonymizeUserBookings(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, b := range r.s.bookings {
+		if b.UserID == userID {
+			b.PaymentReceiptFileID = ""
+			b.PaymentReceiptExtraFileIDs = nil
+			b.PaymentReceiptMsgID = 0
+			b.PaymentInstructionMsgID = 0
+			b.StatusMessageID = 0
+			b.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+// GetTotalCount returns the total number of bookings
+func (r *bookingRepo) GetTotalCount(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.bookings), nil
+}
+
+// GetCountByStatus returns the number of bookings with a given status
+func (r *bookingRepo) GetCountByStatus(ctx context.Context, status models.BookingStatus) (int, error) {
+	return len(r.filter(func(b *models.JobBooking) bool { return b.Status == status })), nil
+}
+
+// GetStatsForPeriod aggregates confirmed/rejected/expired bookings in [from, to)
+func (r *bookingRepo) GetStatsForPeriod(ctx context.Context, from, to time.Time) (*models.BookingPeriodStats, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	inPeriod := func(t *time.Time) bool {
+		return t != nil && !t.Before(from) && t.Before(to)
+	}
+
+	stats := &models.BookingPeriodStats{}
+	for _, b := range r.s.bookings {
+		switch b.Status {
+		case models.BookingStatusConfirmed:
+			if inPeriod(b.ConfirmedAt) {
+				stats.Confirmed++
+				if job, ok := r.s.jobs[b.JobID]; ok {
+					stats.Revenue += job.ServiceFee
+				}
+			}
+		case models.BookingStatusRejected:
+			if inPeriod(b.ReviewedAt) {
+				stats.Rejected++
+			}
+		case models.BookingStatusExpired:
+			if inPeriod(&b.UpdatedAt) {
+				stats.Expired++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// filter returns copies of the bookings matching keep, newest first
+func (r *bookingRepo) filter(keep func(b *models.JobBooking) bool) []*models.JobBooking {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var bookings []*models.JobBooking
+	for _, b := range r.s.bookings {
+		if keep(b) {
+			booking := *b
+			bookings = append(bookings, &booking)
+		}
+	}
+
+	sort.Slice(bookings, func(a, b int) bool { return bookings[a].ID > bookings[b].ID })
+	return bookings
+}
+
+// modify applies fn to the stored booking under the write lock, journaling the old value for rollback.
+// A missing booking is not an error, mirroring an UPDATE that matched no rows.
+func (r *bookingRepo) modify(tx any, id int64, fn func(b *models.JobBooking)) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	b, ok := r.s.bookings[id]
+	if !ok {
+		return nil
+	}
+
+	journal(t, restoreFunc(r.s.bookings, id))
+	updated := *b
+	fn(&updated)
+	updated.UpdatedAt = time.Now()
+	r.s.bookings[id] = &updated
+	return nil
+}
+
+// submittedAt returns the payment submission time or the zero time
+func submittedAt(b *models.JobBooking) time.Time {
+	if b.PaymentSubmittedAt == nil {
+		return time.Time{}
+	}
+	return *b.PaymentSubmittedAt
+}
+
+// DeleteClosedBefore deletes a batch of finished unpaid bookings with what ON DELETE CASCADE would remove
+func (r *bookingRepo) DeleteClosedBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var ids []int64
+	for id, b := range r.s.bookings {
+		switch b.Status {
+		case models.BookingStatusExpired, models.BookingStatusRejected, models.BookingStatusCancelledByUser:
+			if b.UpdatedAt.Before(before) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	deleted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+		delete(r.s.bookings, id)
+		delete(r.s.workerRatings, id)
+		delete(r.s.feedback, id)
+		delete(r.s.vouchers, id)
+	}
+	events := make([]*models.BookingEvent, 0, len(r.s.bookingEvents))
+	for _, e := range r.s.bookingEvents {
+		if !deleted[e.BookingID] {
+			events = append(events, e)
+		}
+	}
+	r.s.bookingEvents = events
+	// ON DELETE SET NULL
+	for _, v := range r.s.violations {
+		if v.BookingID != nil && deleted[*v.BookingID] {
+			v.BookingID = nil
+		}
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type employerRepo struct {
+	s *Store
+}
+
+// Create creates a new employer, enforcing UNIQUE(phone)
+func (r *employerRepo) Create(ctx context.Context, employer *models.Employer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, e := range r.s.employers {
+		if e.Phone == employer.Phone {
+			return storage.ErrAlreadyExists
+		}
+	}
+
+	r.s.nextEmployerID++
+	employer.ID = r.s.nextEmployerID
+	now := time.Now()
+	employer.CreatedAt = now
+	employer.UpdatedAt = now
+
+	e := *employer
+	r.s.employers[employer.ID] = &e
+	return nil
+}
+
+// GetByID retrieves an employer by ID
+func (r *employerRepo) GetByID(ctx context.Context, id int64) (*models.Employer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	e, ok := r.s.employers[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	employer := *e
+	return &employer, nil
+}
+
+// GetByPhone retrieves an employer by phone number
+func (r *employerRepo) GetByPhone(ctx context.Context, phone string) (*models.Employer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, e := range r.s.employers {
+		if e.Phone == phone {
+			employer := *e
+			return &employer, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// GetAll returns all employers ordered by name
+func (r *employerRepo) GetAll(ctx context.Context) ([]*models.Employer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var employers []*models.Employer
+	for _, e := range r.s.employers {
+		employer := *e
+		employers = append(employers, &employer)
+	}
+
+	sort.Slice(employers, func(a, b int) bool { return employers[a].Name < employers[b].Name })
+	return employers, nil
+}
+
+// Update updates an employer
+func (r *employerRepo) Update(ctx context.Context, employer *models.Employer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	existing, ok := r.s.employers[employer.ID]
+	if !ok {
+		return nil // UPDATE on a missing row is not an error in the SQL backends
+	}
+	for id, e := range r.s.employers {
+		if id != employer.ID && e.Phone == employer.Phone {
+			return storage.ErrAlreadyExists
+		}
+	}
+
+	e := *employer
+	e.CreatedAt = existing.CreatedAt
+	e.UpdatedAt = time.Now()
+	r.s.employers[employer.ID] = &e
+	return nil
+}
+
+// GetStats aggregates job and attendance history for an employer
+func (r *employerRepo) GetStats(ctx context.Context, employerID int64) (*models.EmployerStats, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	stats := &models.EmployerStats{}
+	for _, j := range r.s.jobs {
+		if j.EmployerID != employerID {
+			continue
+		}
+		stats.TotalJobs++
+		if j.Status == models.JobStatusCompleted {
+			stats.CompletedJobs++
+		}
+	}
+
+	for _, b := range r.s.bookings {
+		j, ok := r.s.jobs[b.JobID]
+		if !ok || j.EmployerID != employerID {
+			continue
+		}
+		if b.Status == models.BookingStatusConfirmed {
+			stats.ConfirmedWorkers++
+		}
+		switch b.Attendance {
+		case models.AttendanceAttended:
+			stats.Attended++
+		case models.AttendanceNoShow:
+			stats.NoShows++
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type faqRepo struct {
+	s *Store
+}
+
+// Create creates a new FAQ entry
+func (r *faqRepo) Create(ctx context.Context, entry *models.FAQEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextFAQID++
+	entry.ID = r.s.nextFAQID
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	e := *entry
+	r.s.faq[entry.ID] = &e
+	return nil
+}
+
+// GetByID retrieves an FAQ entry by ID
+func (r *faqRepo) GetByID(ctx context.Context, id int64) (*models.FAQEntry, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	e, ok := r.s.faq[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	entry := *e
+	return &entry, nil
+}
+
+// GetAll returns every entry in creation order
+func (r *faqRepo) GetAll(ctx context.Context) ([]*models.FAQEntry, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var entries []*models.FAQEntry
+	for _, e := range r.s.faq {
+		entry := *e
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(a, b int) bool { return entries[a].ID < entries[b].ID })
+	return entries, nil
+}
+
+// Update updates category, question and answer
+func (r *faqRepo) Update(ctx context.Context, entry *models.FAQEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	e, ok := r.s.faq[entry.ID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	e.Category = entry.Category
+	e.Question = entry.Question
+	e.Answer = entry.Answer
+	e.UpdatedAt = time.Now()
+	entry.UpdatedAt = e.UpdatedAt
+	return nil
+}
+
+// Delete deletes an FAQ entry
+func (r *faqRepo) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.faq, id)
+	return nil
+}
@@ -0,0 +1,561 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/storage"
+)
+
+type jobRepo struct {
+	s *Store
+}
+
+// Create creates a new job, assigning ID and order number
+func (r *jobRepo) Create(ctx context.Context, job *models.Job) (*models.Job, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextJobID++
+	job.ID = r.s.nextJobID
+	job.OrderNumber = r.s.nextOrderNumber
+	r.s.nextOrderNumber++
+
+	job.OrderDay = config.NowLocal().Format(time.DateOnly)
+	job.DayNumber = 1
+	for _, j := range r.s.jobs {
+		if j.OrderDay == job.OrderDay && j.DayNumber >= job.DayNumber {
+			job.DayNumber = j.DayNumber + 1
+		}
+	}
+
+	now := time.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	job.Version = 1
+
+	j := *job
+	r.s.jobs[job.ID] = &j
+	return job, nil
+}
+
+// GetByID retrieves a job by ID
+func (r *jobRepo) GetByID(ctx context.Context, id int64) (*models.Job, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	j, ok := r.s.jobs[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	job := *j
+	return &job, nil
+}
+
+// GetByIDForUpdate retrieves a job; the lock is the transaction itself (see transactionManager.Begin)
+func (r *jobRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.Job, error) {
+	if _, err := checkTx(tx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetAll retrieves all jobs with optional status filter, newest first
+func (r *jobRepo) GetAll(ctx context.Context, status *models.JobStatus) ([]*models.Job, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var jobs []*models.Job
+	for _, j := range r.s.jobs {
+		if status != nil && j.Status != *status {
+			continue
+		}
+		job := *j
+		jobs = append(jobs, &job)
+	}
+
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].ID > jobs[b].ID })
+	return jobs, nil
+}
+
+// Update updates a job if its version still matches job.Version
+func (r *jobRepo) Update(ctx context.Context, job *models.Job) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	existing, ok := r.s.jobs[job.ID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if existing.Version != job.Version {
+		return storage.ErrVersionConflict
+	}
+
+	job.Version++
+	j := *job
+	j.OrderNumber = existing.OrderNumber
+	j.CreatedByAdminID = existing.CreatedByAdminID
+	j.ChannelLocationMessageID = existing.ChannelLocationMessageID
+	j.CreatedAt = existing.CreatedAt
+	j.UpdatedAt = time.Now()
+	r.s.jobs[job.ID] = &j
+	return nil
+}
+
+// UpdateStatus updates only the job status
+func (r *jobRepo) UpdateStatus(ctx context.Context, id int64, status models.JobStatus) error {
+	return r.UpdateStatusInTx(ctx, nil, id, status)
+}
+
+// UpdateStatusInTx updates only the job status within a transaction, if the job may move to it
+func (r *jobRepo) UpdateStatusInTx(ctx context.Context, tx any, id int64, status models.JobStatus) error {
+	return r.modify(tx, id, storage.ErrNotFound, func(j *models.Job) error {
+		if !j.CanTransitionTo(status) {
+			return &storage.JobTransitionError{JobID: id, From: j.Status, To: status}
+		}
+		j.Status = status
+		j.Version++
+		return nil
+	})
+}
+
+// Delete deletes a job by ID together with its bookings and admin messages
+func (r *jobRepo) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.jobs, id)
+
+	// ON DELETE CASCADE
+	for bookingID, b := range r.s.bookings {
+		if b.JobID == id {
+			delete(r.s.bookings, bookingID)
+			delete(r.s.vouchers, bookingID)
+		}
+	}
+	for key := range r.s.adminMessages {
+		if key.jobID == id {
+			delete(r.s.adminMessages, key)
+		}
+	}
+	for bookingID, wr := range r.s.workerRatings {
+		if wr.JobID == id {
+			delete(r.s.workerRatings, bookingID)
+		}
+	}
+	for bookingID, f := range r.s.feedback {
+		if f.JobID == id {
+			delete(r.s.feedback, bookingID)
+		}
+	}
+	r.s.linkStarts = slices.DeleteFunc(r.s.linkStarts, func(ls jobLinkStart) bool { return ls.jobID == id })
+	r.s.jobInterest = slices.DeleteFunc(r.s.jobInterest, func(i *models.JobInterest) bool { return i.JobID == id })
+	r.s.jobBumps = slices.DeleteFunc(r.s.jobBumps, func(b *models.JobBump) bool { return b.JobID == id })
+	for key := range r.s.requirementWaivers {
+		if key.jobID == id {
+			delete(r.s.requirementWaivers, key)
+		}
+	}
+	return nil
+}
+
+// UpdateChannelMessageID updates the channel message ID for a job
+func (r *jobRepo) UpdateChannelMessageID(ctx context.Context, id int64, messageID int64) error {
+	return r.modify(nil, id, nil, func(j *models.Job) error {
+		j.ChannelMessageID = messageID
+		return nil
+	})
+}
+
+// UpdateChannelLocationMessageID stores the ID of the location reply to the job's channel post (0 clears it)
+func (r *jobRepo) UpdateChannelLocationMessageID(ctx context.Context, id int64, messageID int64) error {
+	return r.modify(nil, id, nil, func(j *models.Job) error {
+		j.ChannelLocationMessageID = messageID
+		return nil
+	})
+}
+
+// GetByChannelMessageID retrieves the job published as the given channel message
+func (r *jobRepo) GetByChannelMessageID(ctx context.Context, messageID int64) (*models.Job, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var found *models.Job
+	for _, j := range r.s.jobs {
+		if j.ChannelMessageID == messageID && (found == nil || j.ID > found.ID) {
+			found = j
+		}
+	}
+	if found == nil {
+		return nil, storage.ErrNotFound
+	}
+	job := *found
+	return &job, nil
+}
+
+// UpdateAdminMessageID updates the admin message ID for a job
+func (r *jobRepo) UpdateAdminMessageID(ctx context.Context, id int64, messageID int64) error {
+	return r.modify(nil, id, nil, func(j *models.Job) error {
+		j.AdminMessageID = messageID
+		return nil
+	})
+}
+
+// IncrementReservedSlots atomically increments reserved_slots with validation
+func (r *jobRepo) IncrementReservedSlots(ctx context.Context, tx any, jobID int64) error {
+	return r.modify(tx, jobID, storage.ErrNotFound, func(j *models.Job) error {
+		if j.ReservedSlots+j.ConfirmedSlots >= j.RequiredWorkers {
+			return storage.ErrNotFound // Job full
+		}
+		j.ReservedSlots++
+		return nil
+	})
+}
+
+// DecrementReservedSlots atomically decrements reserved_slots
+func (r *jobRepo) DecrementReservedSlots(ctx context.Context, tx any, jobID int64) error {
+	return r.modify(tx, jobID, nil, func(j *models.Job) error {
+		j.ReservedSlots = max(j.ReservedSlots-1, 0)
+		return nil
+	})
+}
+
+// MoveReservedToConfirmed atomically moves slot from reserved to confirmed
+func (r *jobRepo) MoveReservedToConfirmed(ctx context.Context, tx any, jobID int64) error {
+	return r.modify(tx, jobID, nil, func(j *models.Job) error {
+		j.ReservedSlots = max(j.ReservedSlots-1, 0)
+		j.ConfirmedSlots++
+		return nil
+	})
+}
+
+// GetAvailableSlots returns how many slots are available
+func (r *jobRepo) GetAvailableSlots(ctx context.Context, jobID int64) (int, error) {
+	job, err := r.GetByID(ctx, jobID)
+	if err != nil {
+		return 0, err
+	}
+	return max(job.RequiredWorkers-(job.ReservedSlots+job.ConfirmedSlots), 0), nil
+}
+
+// GetSlotDrifts returns jobs whose reserved/confirmed counters disagree with their bookings
+func (r *jobRepo) GetSlotDrifts(ctx context.Context) ([]*models.SlotDrift, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var drifts []*models.SlotDrift
+	for _, j := range r.s.jobs {
+		if d := r.s.slotDrift(j); d.Size() > 0 {
+			drifts = append(drifts, d)
+		}
+	}
+	sort.Slice(drifts, func(a, b int) bool { return drifts[a].JobID < drifts[b].JobID })
+	return drifts, nil
+}
+
+// RecountSlots recounts a job's slots from its bookings and stores the result
+func (r *jobRepo) RecountSlots(ctx context.Context, tx any, jobID int64) (*models.SlotDrift, error) {
+	var drift *models.SlotDrift
+	err := r.modify(tx, jobID, storage.ErrNotFound, func(j *models.Job) error {
+		drift = r.s.slotDrift(j)
+		j.ReservedSlots = drift.ActualReserved
+		j.ConfirmedSlots = drift.ActualConfirmed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
+
+// slotDrift compares a job's counters with its bookings; the caller holds s.mu
+func (s *Store) slotDrift(j *models.Job) *models.SlotDrift {
+	d := &models.SlotDrift{
+		JobID:     j.ID,
+		Reserved:  j.ReservedSlots,
+		Confirmed: j.ConfirmedSlots,
+	}
+	for _, b := range s.bookings {
+		if b.JobID != j.ID {
+			continue
+		}
+		switch {
+		case b.Status.HoldsReservedSlot():
+			d.ActualReserved++
+		case b.Status == models.BookingStatusConfirmed:
+			d.ActualConfirmed++
+		}
+	}
+	return d
+}
+
+// GetTotalCount returns the total number of jobs
+func (r *jobRepo) GetTotalCount(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.jobs), nil
+}
+
+// GetCountByStatus returns the number of jobs with a given status
+func (r *jobRepo) GetCountByStatus(ctx context.Context, status models.JobStatus) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, j := range r.s.jobs {
+		if j.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetAllPaginated returns one page of jobs with any of the given statuses (all when empty), newest first
+func (r *jobRepo) GetAllPaginated(ctx context.Context, statuses []models.JobStatus, limit, offset int) ([]*models.Job, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var jobs []*models.Job
+	for _, j := range r.s.jobs {
+		if len(statuses) > 0 && !slices.Contains(statuses, j.Status) {
+			continue
+		}
+		job := *j
+		jobs = append(jobs, &job)
+	}
+
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].ID > jobs[b].ID })
+	if offset >= len(jobs) {
+		return nil, nil
+	}
+	return jobs[offset:min(offset+limit, len(jobs))], nil
+}
+
+// GetCountByStatuses returns the number of jobs with any of the given statuses (all jobs when empty)
+func (r *jobRepo) GetCountByStatuses(ctx context.Context, statuses []models.JobStatus) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, j := range r.s.jobs {
+		if len(statuses) == 0 || slices.Contains(statuses, j.Status) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetByEmployerID returns an employer's most recent jobs, newest first
+func (r *jobRepo) GetByEmployerID(ctx context.Context, employerID int64, limit int) ([]*models.Job, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var jobs []*models.Job
+	for _, j := range r.s.jobs {
+		if j.EmployerID == employerID {
+			job := *j
+			jobs = append(jobs, &job)
+		}
+	}
+
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].ID > jobs[b].ID })
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// modify applies fn to the stored job under the write lock, journaling the old value for rollback.
+// notFound is returned when the job does not exist; nil mirrors an UPDATE that matched no rows.
+func (r *jobRepo) modify(tx any, id int64, notFound error, fn func(j *models.Job) error) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	j, ok := r.s.jobs[id]
+	if !ok {
+		return notFound
+	}
+
+	undo := restoreFunc(r.s.jobs, id)
+	updated := *j
+	if err := fn(&updated); err != nil {
+		return err
+	}
+	updated.UpdatedAt = time.Now()
+	r.s.jobs[id] = &updated
+	journal(t, undo)
+	return nil
+}
+
+// jobLinkStart is one /start job_<id> deep-link open
+type jobLinkStart struct {
+	jobID     int64
+	userID    int64
+	variant   models.CaptionVariant
+	createdAt time.Time
+}
+
+// RecordLinkStart logs a /start job_<id> deep-link open with its caption variant; unknown jobs are ignored
+func (r *jobRepo) RecordLinkStart(ctx context.Context, jobID, userID int64, variant models.CaptionVariant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.jobs[jobID]; ok {
+		r.s.linkStarts = append(r.s.linkStarts, jobLinkStart{jobID: jobID, userID: userID, variant: variant, createdAt: time.Now()})
+	}
+	return nil
+}
+
+// GetFunnel counts a job's deep-link opens, bookings, payments and attendance
+func (r *jobRepo) GetFunnel(ctx context.Context, jobID int64) (*models.JobFunnel, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	f := &models.JobFunnel{}
+	users := make(map[int64]bool)
+	for _, ls := range r.s.linkStarts {
+		if ls.jobID == jobID {
+			f.LinkOpens++
+			users[ls.userID] = true
+		}
+	}
+	f.LinkUsers = len(users)
+
+	for _, b := range r.s.bookings {
+		if b.JobID != jobID {
+			continue
+		}
+		f.Bookings++
+		if b.PaymentSubmittedAt != nil {
+			f.PaymentsSubmitted++
+		}
+		if b.ConfirmedAt != nil {
+			f.Approved++
+		}
+		switch b.Attendance {
+		case models.AttendanceAttended:
+			f.Attended++
+		case models.AttendanceNoShow:
+			f.NoShows++
+		}
+	}
+
+	f.Captions = r.captionStats(jobID)
+	return f, nil
+}
+
+// captionStats counts the deep-link starts per caption variant, crediting each user to the
+// variant of the first link they opened; the caller holds the lock
+func (r *jobRepo) captionStats(jobID int64) []*models.JobCaptionStats {
+	byVariant := make(map[models.CaptionVariant]*models.JobCaptionStats)
+	firstVariant := make(map[int64]models.CaptionVariant)
+	for _, ls := range r.s.linkStarts {
+		if ls.jobID != jobID || ls.variant == "" {
+			continue
+		}
+		s, ok := byVariant[ls.variant]
+		if !ok {
+			s = &models.JobCaptionStats{Variant: ls.variant}
+			byVariant[ls.variant] = s
+		}
+		s.LinkOpens++
+		if _, seen := firstVariant[ls.userID]; !seen {
+			firstVariant[ls.userID] = ls.variant
+			s.LinkUsers++
+		}
+	}
+
+	booked := make(map[int64]bool)
+	approved := make(map[int64]bool)
+	for _, b := range r.s.bookings {
+		if b.JobID != jobID {
+			continue
+		}
+		v, ok := firstVariant[b.UserID]
+		if !ok {
+			continue
+		}
+		if !booked[b.UserID] {
+			booked[b.UserID] = true
+			byVariant[v].Bookings++
+		}
+		if b.ConfirmedAt != nil && !approved[b.UserID] {
+			approved[b.UserID] = true
+			byVariant[v].Approved++
+		}
+	}
+
+	var stats []*models.JobCaptionStats
+	for _, s := range byVariant {
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Variant < stats[j].Variant })
+	return stats
+}
+
+// AddBump records a repost of the job to the channel
+func (r *jobRepo) AddBump(ctx context.Context, bump *models.JobBump) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.jobs[bump.JobID]; !ok {
+		return storage.ErrNotFound
+	}
+	r.s.nextJobBumpID++
+	bump.ID = r.s.nextJobBumpID
+	bump.CreatedAt = time.Now()
+	b := *bump
+	r.s.jobBumps = append(r.s.jobBumps, &b)
+	return nil
+}
+
+// GetBumps returns the job's reposts, oldest first
+func (r *jobRepo) GetBumps(ctx context.Context, jobID int64) ([]*models.JobBump, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var bumps []*models.JobBump
+	for _, b := range r.s.jobBumps {
+		if b.JobID == jobID {
+			bump := *b
+			bumps = append(bumps, &bump)
+		}
+	}
+	return bumps, nil
+}
+
+// requirementWaiverKey identifies a user let through a job's requirements
+type requirementWaiverKey struct {
+	jobID  int64
+	userID int64
+}
+
+// WaiveRequirements lets the user book the job even though their profile misses its requirements
+func (r *jobRepo) WaiveRequirements(ctx context.Context, jobID, userID, adminID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	key := requirementWaiverKey{jobID: jobID, userID: userID}
+	if _, ok := r.s.requirementWaivers[key]; !ok {
+		r.s.requirementWaivers[key] = adminID
+	}
+	return nil
+}
+
+// IsRequirementWaived reports whether an admin waived the job's requirements for the user
+func (r *jobRepo) IsRequirementWaived(ctx context.Context, jobID, userID int64) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, ok := r.s.requirementWaivers[requirementWaiverKey{jobID: jobID, userID: userID}]
+	return ok, nil
+}
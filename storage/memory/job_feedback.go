@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type jobFeedbackRepo struct {
+	s *Store
+}
+
+// GetBookingsAwaitingPrompt returns confirmed bookings without a feedback row, oldest confirmation first
+func (r *jobFeedbackRepo) GetBookingsAwaitingPrompt(ctx context.Context, since time.Time, limit int) ([]*models.JobBooking, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var bookings []*models.JobBooking
+	for _, b := range r.s.bookings {
+		if b.Status != models.BookingStatusConfirmed || b.ConfirmedAt == nil || b.ConfirmedAt.Before(since) {
+			continue
+		}
+		if b.Attendance == models.AttendanceNoShow {
+			continue
+		}
+		if _, prompted := r.s.feedback[b.ID]; prompted {
+			continue
+		}
+		booking := *b
+		bookings = append(bookings, &booking)
+	}
+
+	sort.Slice(bookings, func(a, b int) bool { return bookings[a].ConfirmedAt.Before(*bookings[b].ConfirmedAt) })
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+	}
+	return bookings, nil
+}
+
+// Create records that the feedback prompt was sent, enforcing UNIQUE(booking_id)
+func (r *jobFeedbackRepo) Create(ctx context.Context, feedback *models.JobFeedback) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.feedback[feedback.BookingID]; ok {
+		return storage.ErrAlreadyExists
+	}
+
+	r.s.nextFeedbackID++
+	feedback.ID = r.s.nextFeedbackID
+	now := time.Now()
+	feedback.CreatedAt = now
+	feedback.UpdatedAt = now
+
+	f := *feedback
+	r.s.feedback[feedback.BookingID] = &f
+	return nil
+}
+
+// GetByBookingID retrieves the feedback for a booking
+func (r *jobFeedbackRepo) GetByBookingID(ctx context.Context, bookingID int64) (*models.JobFeedback, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	f, ok := r.s.feedback[bookingID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	feedback := *f
+	return &feedback, nil
+}
+
+// UpdateAnswers saves the worker's answers
+func (r *jobFeedbackRepo) UpdateAnswers(ctx context.Context, feedback *models.JobFeedback) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	f, ok := r.s.feedback[feedback.BookingID]
+	if !ok {
+		return nil // UPDATE on a missing row is not an error in the SQL backends
+	}
+
+	updated := *f
+	updated.PayCorrect = feedback.PayCorrect
+	updated.ConditionsOK = feedback.ConditionsOK
+	updated.UpdatedAt = time.Now()
+	r.s.feedback[feedback.BookingID] = &updated
+	return nil
+}
+
+// GetEmployerSummary aggregates answered feedback for an employer
+func (r *jobFeedbackRepo) GetEmployerSummary(ctx context.Context, employerID int64) (*models.FeedbackSummary, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	summary := &models.FeedbackSummary{}
+	for _, f := range r.s.feedback {
+		if f.EmployerID != employerID {
+			continue
+		}
+		if f.PayCorrect != nil || f.ConditionsOK != nil {
+			summary.Responses++
+		}
+		if f.PayCorrect != nil && !*f.PayCorrect {
+			summary.PayIssues++
+		}
+		if f.ConditionsOK != nil && !*f.ConditionsOK {
+			summary.ConditionIssues++
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+type jobInterestRepo struct {
+	s *Store
+}
+
+// Record stores a signup link open, enforcing UNIQUE(job_id, user_id)
+func (r *jobInterestRepo) Record(ctx context.Context, jobID, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.jobs[jobID]; !ok {
+		return nil
+	}
+	for _, i := range r.s.jobInterest {
+		if i.JobID == jobID && i.UserID == userID {
+			return nil
+		}
+	}
+
+	r.s.nextJobInterestID++
+	r.s.jobInterest = append(r.s.jobInterest, &models.JobInterest{
+		ID:        r.s.nextJobInterestID,
+		JobID:     jobID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// GetDueNudges returns pending interests in [since, before) of users who aren't registered, oldest first
+func (r *jobInterestRepo) GetDueNudges(ctx context.Context, since, before time.Time, limit int) ([]*models.JobInterest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var due []*models.JobInterest
+	for _, i := range r.s.jobInterest {
+		if i.NudgedAt != nil || i.CreatedAt.Before(since) || !i.CreatedAt.Before(before) || r.isRegistered(i.UserID) {
+			continue
+		}
+		interest := *i
+		due = append(due, &interest)
+	}
+
+	sort.Slice(due, func(a, b int) bool { return due[a].CreatedAt.Before(due[b].CreatedAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// MarkNudged marks every pending interest of the user as reminded
+func (r *jobInterestRepo) MarkNudged(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for _, i := range r.s.jobInterest {
+		if i.UserID == userID && i.NudgedAt == nil {
+			i.NudgedAt = &now
+		}
+	}
+	return nil
+}
+
+// GetUnregisteredCounts returns jobs with interested users who still aren't registered, newest job first
+func (r *jobInterestRepo) GetUnregisteredCounts(ctx context.Context, limit int) ([]*models.JobInterestCount, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	byJob := make(map[int64]*models.JobInterestCount)
+	for _, i := range r.s.jobInterest {
+		if r.isRegistered(i.UserID) {
+			continue
+		}
+		count, ok := byJob[i.JobID]
+		if !ok {
+			count = &models.JobInterestCount{JobID: i.JobID}
+			byJob[i.JobID] = count
+		}
+		count.Unregistered++
+		if i.NudgedAt != nil {
+			count.Nudged++
+		}
+	}
+
+	counts := make([]*models.JobInterestCount, 0, len(byJob))
+	for _, count := range byJob {
+		counts = append(counts, count)
+	}
+	sort.Slice(counts, func(a, b int) bool { return counts[a].JobID > counts[b].JobID })
+	if len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+// isRegistered reports whether the user has an active registration; callers hold the lock
+func (r *jobInterestRepo) isRegistered(userID int64) bool {
+	ru, ok := r.s.registered[userID]
+	return ok && ru.IsActive
+}
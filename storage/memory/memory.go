@@ -0,0 +1,201 @@
+// Package memory provides an in-memory storage.StorageI implementation.
+// It is meant for unit tests of handlers and services: all data lives in maps
+// guarded by a single RWMutex and is lost when the process exits.
+package memory
+
+import (
+	"sync"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// adminMessageKey identifies an admin job message (UNIQUE(job_id, admin_id))
+type adminMessageKey struct {
+	jobID   int64
+	adminID int64
+}
+
+// Store implements the storage.StorageI interface in memory
+type Store struct {
+	mu   sync.RWMutex // guards all maps and counters below
+	txMu sync.Mutex   // serializes transactions, like FOR UPDATE row locks
+
+	users              map[int64]*models.User
+	jobs               map[int64]*models.Job
+	bookings           map[int64]*models.JobBooking
+	drafts             map[int64]*models.RegistrationDraft // keyed by user ID
+	registered         map[int64]*models.RegisteredUser    // keyed by user ID
+	violations         []*models.UserViolation
+	blocked            map[int64]*models.BlockedUser
+	adminMessages      map[adminMessageKey]*models.AdminJobMessage
+	adminPrefs         map[int64]*models.AdminNotificationPrefs // keyed by admin ID
+	employers          map[int64]*models.Employer
+	workerRatings      map[int64]*models.WorkerRating // keyed by booking ID
+	feedback           map[int64]*models.JobFeedback  // keyed by booking ID
+	audit              []*models.AuditEntry
+	vouchers           map[int64]*models.BookingVoucher // keyed by booking ID
+	profileChanges     []*models.ProfileChange
+	faq                map[int64]*models.FAQEntry
+	offers             []*models.PublicOffer // ordered by version
+	offerAcceptances   []*models.OfferAcceptance
+	settings           map[string]string
+	linkStarts         []jobLinkStart
+	jobInterest        []*models.JobInterest
+	campaigns          []*models.ReengageCampaign
+	campaignSends      []campaignSend
+	supportThreads     []*models.SupportThread
+	refunds            map[int64]*models.Refund
+	bookingEvents      []*models.BookingEvent
+	jobBumps           []*models.JobBump
+	requirementWaivers map[requirementWaiverKey]int64 // waiving admin ID
+
+	nextJobID             int64
+	nextOrderNumber       int
+	nextBookingID         int64
+	nextDraftID           int64
+	nextRegisteredID      int64
+	nextViolationID       int64
+	nextAdminMessageID    int64
+	nextEmployerID        int64
+	nextWorkerRatingID    int64
+	nextFeedbackID        int64
+	nextAuditID           int64
+	nextVoucherID         int64
+	nextProfileChangeID   int64
+	nextFAQID             int64
+	nextOfferID           int64
+	nextOfferAcceptanceID int64
+	nextJobInterestID     int64
+	nextCampaignID        int64
+	nextSupportThreadID   int64
+	nextRefundID          int64
+	nextBookingEventID    int64
+	nextJobBumpID         int64
+}
+
+// NewMemory creates a new empty in-memory storage
+func NewMemory() storage.StorageI {
+	return &Store{
+		users:              make(map[int64]*models.User),
+		jobs:               make(map[int64]*models.Job),
+		bookings:           make(map[int64]*models.JobBooking),
+		drafts:             make(map[int64]*models.RegistrationDraft),
+		registered:         make(map[int64]*models.RegisteredUser),
+		blocked:            make(map[int64]*models.BlockedUser),
+		adminMessages:      make(map[adminMessageKey]*models.AdminJobMessage),
+		adminPrefs:         make(map[int64]*models.AdminNotificationPrefs),
+		employers:          make(map[int64]*models.Employer),
+		workerRatings:      make(map[int64]*models.WorkerRating),
+		feedback:           make(map[int64]*models.JobFeedback),
+		vouchers:           make(map[int64]*models.BookingVoucher),
+		faq:                make(map[int64]*models.FAQEntry),
+		settings:           make(map[string]string),
+		refunds:            make(map[int64]*models.Refund),
+		requirementWaivers: make(map[requirementWaiverKey]int64),
+		nextOrderNumber:    1000, // matches job_order_number_seq START 1000
+	}
+}
+
+// CloseDB is a no-op for the in-memory store
+func (s *Store) CloseDB() {}
+
+// User returns the user repository
+func (s *Store) User() storage.UserRepoI {
+	return &userRepo{s: s}
+}
+
+// Job returns the job repository
+func (s *Store) Job() storage.JobRepoI {
+	return &jobRepo{s: s}
+}
+
+// Registration returns the registration repository
+func (s *Store) Registration() storage.RegistrationRepoI {
+	return &registrationRepo{s: s}
+}
+
+// Booking returns the booking repository
+func (s *Store) Booking() storage.BookingRepoI {
+	return &bookingRepo{s: s}
+}
+
+// AdminMessage returns the admin message repository
+func (s *Store) AdminMessage() storage.AdminMessageRepoI {
+	return &adminMessageRepo{s: s}
+}
+
+// AdminPrefs returns the admin notification preferences repository
+func (s *Store) AdminPrefs() storage.AdminPrefsRepoI {
+	return &adminPrefsRepo{s: s}
+}
+
+// Employer returns the employer repository
+func (s *Store) Employer() storage.EmployerRepoI {
+	return &employerRepo{s: s}
+}
+
+// WorkerRating returns the worker rating repository
+func (s *Store) WorkerRating() storage.WorkerRatingRepoI {
+	return &workerRatingRepo{s: s}
+}
+
+// JobFeedback returns the worker feedback repository
+func (s *Store) JobFeedback() storage.JobFeedbackRepoI {
+	return &jobFeedbackRepo{s: s}
+}
+
+// Audit returns the audit log repository
+func (s *Store) Audit() storage.AuditRepoI {
+	return &auditRepo{s: s}
+}
+
+// Voucher returns the booking voucher repository
+func (s *Store) Voucher() storage.VoucherRepoI {
+	return &voucherRepo{s: s}
+}
+
+// ProfileChange returns the profile edit history repository
+func (s *Store) ProfileChange() storage.ProfileChangeRepoI {
+	return &profileChangeRepo{s: s}
+}
+
+// FAQ returns the help FAQ repository
+func (s *Store) FAQ() storage.FAQRepoI {
+	return &faqRepo{s: s}
+}
+
+// PublicOffer returns the public offer and consent log repository
+func (s *Store) PublicOffer() storage.PublicOfferRepoI {
+	return &publicOfferRepo{s: s}
+}
+
+// Settings returns the runtime bot settings repository
+func (s *Store) Settings() storage.SettingsRepoI {
+	return &settingsRepo{s: s}
+}
+
+// JobInterest returns the repository of signup link opens by unregistered users
+func (s *Store) JobInterest() storage.JobInterestRepoI {
+	return &jobInterestRepo{s: s}
+}
+
+// Reengage returns the re-engagement campaign repository
+func (s *Store) Reengage() storage.ReengageRepoI {
+	return &reengageRepo{s: s}
+}
+
+// Support returns the user support thread repository
+func (s *Store) Support() storage.SupportRepoI {
+	return &supportRepo{s: s}
+}
+
+// Refund returns the refund repository
+func (s *Store) Refund() storage.RefundRepoI {
+	return &refundRepo{s: s}
+}
+
+// Transaction returns the transaction manager
+func (s *Store) Transaction() storage.TransactionI {
+	return &transactionManager{s: s}
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+type profileChangeRepo struct {
+	s *Store
+}
+
+// Create records a profile edit
+func (r *profileChangeRepo) Create(ctx context.Context, change *models.ProfileChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextProfileChangeID++
+	change.ID = r.s.nextProfileChangeID
+	change.CreatedAt = time.Now()
+
+	c := *change
+	r.s.profileChanges = append(r.s.profileChanges, &c)
+	return nil
+}
+
+// DeleteByUserID removes a user's edit history
+func (r *profileChangeRepo) DeleteByUserID(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.profileChanges = slices.DeleteFunc(r.s.profileChanges, func(c *models.ProfileChange) bool {
+		return c.UserID == userID
+	})
+	return nil
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type publicOfferRepo struct {
+	s *Store
+}
+
+// Publish stores a new version numbered one above the latest
+func (r *publicOfferRepo) Publish(ctx context.Context, offer *models.PublicOffer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextOfferID++
+	offer.ID = r.s.nextOfferID
+	offer.Version = len(r.s.offers) + 1
+	offer.PublishedAt = time.Now()
+
+	o := *offer
+	r.s.offers = append(r.s.offers, &o)
+	return nil
+}
+
+// GetLatest returns the newest published version
+func (r *publicOfferRepo) GetLatest(ctx context.Context) (*models.PublicOffer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	if len(r.s.offers) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	offer := *r.s.offers[len(r.s.offers)-1]
+	return &offer, nil
+}
+
+// RecordAcceptance logs that a user accepted a version; the first acceptance of a version is kept
+func (r *publicOfferRepo) RecordAcceptance(ctx context.Context, acceptance *models.OfferAcceptance) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, a := range r.s.offerAcceptances {
+		if a.UserID == acceptance.UserID && a.Version == acceptance.Version {
+			acceptance.ID = a.ID
+			acceptance.AcceptedAt = a.AcceptedAt
+			return nil
+		}
+	}
+
+	r.s.nextOfferAcceptanceID++
+	acceptance.ID = r.s.nextOfferAcceptanceID
+	acceptance.AcceptedAt = time.Now()
+
+	a := *acceptance
+	r.s.offerAcceptances = append(r.s.offerAcceptances, &a)
+	return nil
+}
+
+// GetAcceptedVersion returns the highest version the user accepted, 0 if none
+func (r *publicOfferRepo) GetAcceptedVersion(ctx context.Context, userID int64) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	version := 0
+	for _, a := range r.s.offerAcceptances {
+		if a.UserID == userID && a.Version > version {
+			version = a.Version
+		}
+	}
+	return version, nil
+}
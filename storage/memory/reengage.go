@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// campaignSend is one campaign message that reached a user
+type campaignSend struct {
+	campaignID int64
+	userID     int64
+	sentAt     time.Time
+}
+
+type reengageRepo struct {
+	s *Store
+}
+
+// GetInactiveUsers returns registered users with no booking and no campaign message since since
+func (r *reengageRepo) GetInactiveUsers(ctx context.Context, since, now time.Time, limit int) ([]int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	recent := make(map[int64]bool)
+	for _, b := range r.s.bookings {
+		if !b.CreatedAt.Before(since) {
+			recent[b.UserID] = true
+		}
+	}
+	for _, cs := range r.s.campaignSends {
+		if !cs.sentAt.Before(since) {
+			recent[cs.userID] = true
+		}
+	}
+
+	var users []*models.RegisteredUser
+	for _, ru := range r.s.registered {
+		if !ru.IsActive || !ru.CreatedAt.Before(since) || recent[ru.UserID] {
+			continue
+		}
+		if u, ok := r.s.users[ru.UserID]; !ok || u.MarketingOptOut {
+			continue
+		}
+		if block, ok := r.s.blocked[ru.UserID]; ok && (block.BlockedUntil == nil || block.BlockedUntil.After(now)) {
+			continue
+		}
+		users = append(users, ru)
+	}
+
+	sort.Slice(users, func(a, b int) bool { return users[a].UserID < users[b].UserID })
+	ids := make([]int64, 0, min(len(users), limit))
+	for _, ru := range users {
+		if len(ids) == limit {
+			break
+		}
+		ids = append(ids, ru.UserID)
+	}
+	return ids, nil
+}
+
+// CreateCampaign stores a new campaign
+func (r *reengageRepo) CreateCampaign(ctx context.Context, campaign *models.ReengageCampaign) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextCampaignID++
+	campaign.ID = r.s.nextCampaignID
+	campaign.CreatedAt = time.Now()
+
+	c := *campaign
+	r.s.campaigns = append(r.s.campaigns, &c)
+	return nil
+}
+
+// AddRecipient records that the campaign message reached the user
+func (r *reengageRepo) AddRecipient(ctx context.Context, campaignID, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, cs := range r.s.campaignSends {
+		if cs.campaignID == campaignID && cs.userID == userID {
+			return nil
+		}
+	}
+	r.s.campaignSends = append(r.s.campaignSends, campaignSend{campaignID: campaignID, userID: userID, sentAt: time.Now()})
+	return nil
+}
+
+// FinishCampaign stores the sent and failed counts
+func (r *reengageRepo) FinishCampaign(ctx context.Context, campaign *models.ReengageCampaign) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, c := range r.s.campaigns {
+		if c.ID == campaign.ID {
+			c.Sent = campaign.Sent
+			c.Failed = campaign.Failed
+			return nil
+		}
+	}
+	return storage.ErrNotFound
+}
+
+// GetRecent returns the newest campaigns with the link opens and bookings of their recipients
+func (r *reengageRepo) GetRecent(ctx context.Context, limit int) ([]*models.ReengageCampaign, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var campaigns []*models.ReengageCampaign
+	for i := len(r.s.campaigns) - 1; i >= 0 && len(campaigns) < limit; i-- {
+		c := *r.s.campaigns[i]
+		for _, cs := range r.s.campaignSends {
+			if cs.campaignID != c.ID {
+				continue
+			}
+			if r.openedSince(cs.userID, cs.sentAt) {
+				c.Opened++
+			}
+			if r.bookedSince(cs.userID, cs.sentAt) {
+				c.Booked++
+			}
+		}
+		campaigns = append(campaigns, &c)
+	}
+	return campaigns, nil
+}
+
+// openedSince reports whether the user opened a job signup link at or after t; callers hold the lock
+func (r *reengageRepo) openedSince(userID int64, t time.Time) bool {
+	for _, ls := range r.s.linkStarts {
+		if ls.userID == userID && !ls.createdAt.Before(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// bookedSince reports whether the user booked a job at or after t; callers hold the lock
+func (r *reengageRepo) bookedSince(userID int64, t time.Time) bool {
+	for _, b := range r.s.bookings {
+		if b.UserID == userID && !b.CreatedAt.Before(t) {
+			return true
+		}
+	}
+	return false
+}
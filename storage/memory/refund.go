@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type refundRepo struct {
+	s *Store
+}
+
+// Create records a pending refund; a booking gets at most one
+func (r *refundRepo) Create(ctx context.Context, tx any, refund *models.Refund) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.refunds {
+		if existing.BookingID == refund.BookingID {
+			return storage.ErrAlreadyExists
+		}
+	}
+
+	r.s.nextRefundID++
+	refund.ID = r.s.nextRefundID
+	refund.Status = models.RefundPending
+	refund.CreatedAt = time.Now()
+
+	stored := *refund
+	journal(t, restoreFunc(r.s.refunds, refund.ID))
+	r.s.refunds[refund.ID] = &stored
+	return nil
+}
+
+// GetByID returns a refund
+func (r *refundRepo) GetByID(ctx context.Context, id int64) (*models.Refund, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	refund, ok := r.s.refunds[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	copied := *refund
+	return &copied, nil
+}
+
+// GetPending returns the refunds still owed, oldest first
+func (r *refundRepo) GetPending(ctx context.Context) ([]*models.Refund, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var refunds []*models.Refund
+	for _, refund := range r.s.refunds {
+		if refund.Status == models.RefundPending {
+			copied := *refund
+			refunds = append(refunds, &copied)
+		}
+	}
+	sort.Slice(refunds, func(a, b int) bool { return refunds[a].ID < refunds[b].ID })
+	return refunds, nil
+}
+
+// MarkPaid marks a pending refund paid and reports whether it was pending
+func (r *refundRepo) MarkPaid(ctx context.Context, id, adminID int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	refund, ok := r.s.refunds[id]
+	if !ok || refund.Status != models.RefundPending {
+		return false, nil
+	}
+	now := time.Now()
+	refund.Status = models.RefundPaid
+	refund.PaidAt = &now
+	refund.PaidBy = adminID
+	return true, nil
+}
@@ -0,0 +1,334 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// registrationRepo implements storage.RegistrationRepoI interface in memory
+type registrationRepo struct {
+	s *Store
+}
+
+// CreateDraft creates a new registration draft
+func (r *registrationRepo) CreateDraft(ctx context.Context, draft *models.RegistrationDraft) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.drafts[draft.UserID]; ok {
+		return fmt.Errorf("failed to create registration draft: %w", storage.ErrAlreadyExists)
+	}
+
+	r.s.nextDraftID++
+	draft.ID = r.s.nextDraftID
+	d := *draft
+	r.s.drafts[draft.UserID] = &d
+	return nil
+}
+
+// GetDraftByUserID retrieves a draft by user ID
+func (r *registrationRepo) GetDraftByUserID(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	d, ok := r.s.drafts[userID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	draft := *d
+	return &draft, nil
+}
+
+// UpdateDraft updates an existing draft
+func (r *registrationRepo) UpdateDraft(ctx context.Context, draft *models.RegistrationDraft) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	existing, ok := r.s.drafts[draft.UserID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+
+	draft.UpdatedAt = time.Now()
+	d := *draft
+	d.ID = existing.ID
+	d.CreatedAt = existing.CreatedAt
+	r.s.drafts[draft.UserID] = &d
+	return nil
+}
+
+// DeleteDraft deletes a draft by user ID
+func (r *registrationRepo) DeleteDraft(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.drafts[userID]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(r.s.drafts, userID)
+	return nil
+}
+
+// CreateRegisteredUser creates a new fully registered user
+func (r *registrationRepo) CreateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.registered[user.UserID]; ok {
+		return fmt.Errorf("failed to create registered user: %w", storage.ErrAlreadyExists)
+	}
+	if user.IsActive && r.phoneTaken(user.Phone, user.UserID) {
+		return storage.ErrAlreadyExists
+	}
+
+	r.s.nextRegisteredID++
+	user.ID = r.s.nextRegisteredID
+	u := *user
+	r.s.registered[user.UserID] = &u
+	return nil
+}
+
+// GetRegisteredUserByUserID retrieves a registered user by Telegram user ID
+func (r *registrationRepo) GetRegisteredUserByUserID(ctx context.Context, userID int64) (*models.RegisteredUser, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	u, ok := r.s.registered[userID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	user := *u
+	return &user, nil
+}
+
+// GetActiveRegisteredUserByPhone retrieves the active registered user with the given phone
+func (r *registrationRepo) GetActiveRegisteredUserByPhone(ctx context.Context, phone string) (*models.RegisteredUser, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, u := range r.s.registered {
+		if u.IsActive && u.Phone == phone {
+			user := *u
+			return &user, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// GetUserIDsByPhone returns the Telegram IDs of every registration with the phone, inactive ones included
+func (r *registrationRepo) GetUserIDsByPhone(ctx context.Context, phone string) ([]int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var users []*models.RegisteredUser
+	for _, u := range r.s.registered {
+		if u.Phone == phone {
+			users = append(users, u)
+		}
+	}
+	sort.Slice(users, func(a, b int) bool { return users[a].ID < users[b].ID })
+
+	ids := make([]int64, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	return ids, nil
+}
+
+// UpdateRegisteredUser updates a registered user
+func (r *registrationRepo) UpdateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	existing, ok := r.s.registered[user.UserID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if user.IsActive && r.phoneTaken(user.Phone, user.UserID) {
+		return storage.ErrAlreadyExists
+	}
+
+	user.UpdatedAt = time.Now()
+	u := *user
+	u.ID = existing.ID
+	u.CreatedAt = existing.CreatedAt
+	r.s.registered[user.UserID] = &u
+	return nil
+}
+
+// SetRegisteredUserActive activates or deactivates a registered user
+func (r *registrationRepo) SetRegisteredUserActive(ctx context.Context, userID int64, active bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.registered[userID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if active && r.phoneTaken(u.Phone, userID) {
+		return storage.ErrAlreadyExists
+	}
+
+	u.IsActive = active
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsUserRegistered checks if a user is fully registered and active
+func (r *registrationRepo) IsUserRegistered(ctx context.Context, userID int64) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	u, ok := r.s.registered[userID]
+	return ok && u.IsActive, nil
+}
+
+// DeleteRegisteredUser deletes a registered user
+func (r *registrationRepo) DeleteRegisteredUser(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.registered[userID]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(r.s.registered, userID)
+	return nil
+}
+
+// CompleteRegistration moves a draft to the registered users (upsert) and deletes the draft
+func (r *registrationRepo) CompleteRegistration(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	draft, ok := r.s.drafts[userID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if r.phoneTaken(draft.Phone, userID) {
+		return storage.ErrAlreadyExists
+	}
+
+	now := time.Now()
+	user := &models.RegisteredUser{
+		UserID:          userID,
+		FullName:        draft.FullName,
+		Phone:           draft.Phone,
+		Age:             draft.Age,
+		Weight:          draft.Weight,
+		Height:          draft.Height,
+		PassportPhotoID: draft.PassportPhotoID,
+		City:            draft.City,
+		Gender:          draft.Gender,
+		IsActive:        true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if existing, ok := r.s.registered[userID]; ok {
+		user.ID = existing.ID
+		user.CreatedAt = existing.CreatedAt
+	} else {
+		r.s.nextRegisteredID++
+		user.ID = r.s.nextRegisteredID
+	}
+
+	r.s.registered[userID] = user
+	delete(r.s.drafts, userID)
+	return nil
+}
+
+// GetAllRegistered retrieves all registered users ordered by creation date (newest first)
+func (r *registrationRepo) GetAllRegistered(ctx context.Context) ([]*models.RegisteredUser, error) {
+	return r.sorted(), nil
+}
+
+// GetRegisteredUsersPaginated retrieves registered users with pagination
+func (r *registrationRepo) GetRegisteredUsersPaginated(ctx context.Context, limit, offset int) ([]*models.RegisteredUser, error) {
+	users := r.sorted()
+	if offset >= len(users) {
+		return nil, nil
+	}
+	users = users[offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// SearchRegisteredUsers finds active registered users by name fragment or phone digits
+func (r *registrationRepo) SearchRegisteredUsers(ctx context.Context, name, phoneDigits string, limit int) ([]*models.RegisteredUser, error) {
+	name = strings.ToLower(name)
+
+	var users []*models.RegisteredUser
+	for _, u := range r.sorted() {
+		if !u.IsActive {
+			continue
+		}
+		if strings.Contains(strings.ToLower(u.FullName), name) || (phoneDigits != "" && strings.Contains(u.Phone, phoneDigits)) {
+			users = append(users, u)
+		}
+	}
+	sort.SliceStable(users, func(a, b int) bool { return users[a].FullName < users[b].FullName })
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// GetTotalRegisteredCount returns the total count of registered users
+func (r *registrationRepo) GetTotalRegisteredCount(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.registered), nil
+}
+
+// RecryptPersonalData is a no-op: the in-memory store never writes to disk, so nothing is encrypted
+func (r *registrationRepo) RecryptPersonalData(ctx context.Context, encrypt bool) (int, error) {
+	return 0, nil
+}
+
+// sorted returns copies of all registered users, newest first
+func (r *registrationRepo) sorted() []*models.RegisteredUser {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	users := make([]*models.RegisteredUser, 0, len(r.s.registered))
+	for _, u := range r.s.registered {
+		user := *u
+		users = append(users, &user)
+	}
+
+	sort.Slice(users, func(a, b int) bool { return users[a].ID > users[b].ID })
+	return users
+}
+
+// phoneTaken mirrors the unique index on active phones; callers hold the lock
+func (r *registrationRepo) phoneTaken(phone string, exceptUserID int64) bool {
+	for _, u := range r.s.registered {
+		if u.UserID != exceptUserID && u.IsActive && u.Phone == phone {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteDraftsBefore deletes abandoned registration drafts
+func (r *registrationRepo) DeleteDraftsBefore(ctx context.Context, before time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	n := 0
+	for userID, d := range r.s.drafts {
+		if d.UpdatedAt.Before(before) {
+			delete(r.s.drafts, userID)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"maps"
+)
+
+type settingsRepo struct {
+	s *Store
+}
+
+// GetAll returns every stored setting keyed by name
+func (r *settingsRepo) GetAll(ctx context.Context) (map[string]string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return maps.Clone(r.s.settings), nil
+}
+
+// Set creates or replaces a setting
+func (r *settingsRepo) Set(ctx context.Context, key, value string, updatedBy int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.settings[key] = value
+	return nil
+}
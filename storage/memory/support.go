@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type supportRepo struct {
+	s *Store
+}
+
+// Create opens a thread for the user, allowing one open thread per user
+func (r *supportRepo) Create(ctx context.Context, userID int64) (*models.SupportThread, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, t := range r.s.supportThreads {
+		if t.UserID == userID && t.IsOpen() {
+			return nil, storage.ErrAlreadyExists
+		}
+	}
+
+	r.s.nextSupportThreadID++
+	thread := &models.SupportThread{
+		ID:        r.s.nextSupportThreadID,
+		UserID:    userID,
+		Status:    models.SupportThreadOpen,
+		CreatedAt: time.Now(),
+	}
+	r.s.supportThreads = append(r.s.supportThreads, thread)
+	copied := *thread
+	return &copied, nil
+}
+
+// GetByID returns a thread
+func (r *supportRepo) GetByID(ctx context.Context, id int64) (*models.SupportThread, error) {
+	return r.find(func(t *models.SupportThread) bool { return t.ID == id })
+}
+
+// GetOpenByUser returns the user's open thread
+func (r *supportRepo) GetOpenByUser(ctx context.Context, userID int64) (*models.SupportThread, error) {
+	return r.find(func(t *models.SupportThread) bool { return t.UserID == userID && t.IsOpen() })
+}
+
+func (r *supportRepo) find(match func(t *models.SupportThread) bool) (*models.SupportThread, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, t := range r.s.supportThreads {
+		if match(t) {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// Close closes an open thread and reports whether it was open
+func (r *supportRepo) Close(ctx context.Context, id, closedBy int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, t := range r.s.supportThreads {
+		if t.ID == id && t.IsOpen() {
+			now := time.Now()
+			t.Status = models.SupportThreadClosed
+			t.ClosedAt = &now
+			t.ClosedBy = closedBy
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+)
+
+// memTx is the transaction handle passed around as `tx any`.
+// Writes made with it register undo functions that Rollback replays in reverse order.
+type memTx struct {
+	undo   []func()
+	closed bool
+}
+
+type transactionManager struct {
+	s *Store
+}
+
+// Begin starts a new transaction.
+// Only one transaction runs at a time; this stands in for the row locks of the SQL backends.
+func (tm *transactionManager) Begin(ctx context.Context) (any, error) {
+	tm.s.txMu.Lock()
+	return &memTx{}, nil
+}
+
+// Commit commits the transaction
+func (tm *transactionManager) Commit(ctx context.Context, tx any) error {
+	t, ok := tx.(*memTx)
+	if !ok {
+		return fmt.Errorf("invalid transaction type")
+	}
+	if t.closed {
+		return fmt.Errorf("failed to commit transaction: transaction already closed")
+	}
+
+	t.closed = true
+	t.undo = nil
+	tm.s.txMu.Unlock()
+	return nil
+}
+
+// Rollback rolls back the transaction
+func (tm *transactionManager) Rollback(ctx context.Context, tx any) error {
+	t, ok := tx.(*memTx)
+	if !ok {
+		return fmt.Errorf("invalid transaction type")
+	}
+	if t.closed {
+		return fmt.Errorf("failed to rollback transaction: transaction already closed")
+	}
+
+	tm.s.mu.Lock()
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	tm.s.mu.Unlock()
+
+	t.closed = true
+	t.undo = nil
+	tm.s.txMu.Unlock()
+	return nil
+}
+
+// checkTx validates the `tx any` argument; nil means "no transaction"
+func checkTx(tx any) (*memTx, error) {
+	if tx == nil {
+		return nil, nil
+	}
+	t, ok := tx.(*memTx)
+	if !ok || t.closed {
+		return nil, fmt.Errorf("invalid transaction type")
+	}
+	return t, nil
+}
+
+// journal registers an undo function for a write made inside tx (no-op without a transaction).
+// Must be called with s.mu held.
+func journal(t *memTx, undo func()) {
+	if t != nil {
+		t.undo = append(t.undo, undo)
+	}
+}
+
+// restoreFunc returns an undo function that puts m[key] back to its current value
+func restoreFunc[K comparable, V any](m map[K]*V, key K) func() {
+	old, existed := m[key]
+	var saved V
+	if existed {
+		saved = *old
+	}
+	return func() {
+		if existed {
+			v := saved
+			m[key] = &v
+		} else {
+			delete(m, key)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+// userRepo implements storage.UserRepoI interface in memory
+type userRepo struct {
+	s *Store
+}
+
+// Create creates a new user
+func (r *userRepo) Create(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[user.ID]; ok {
+		return storage.ErrAlreadyExists
+	}
+	u := *user
+	r.s.users[user.ID] = &u
+	return nil
+}
+
+// GetByID retrieves a user by their ID
+func (r *userRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	user := *u
+	return &user, nil
+}
+
+// Update updates an existing user
+func (r *userRepo) Update(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[user.ID]; !ok {
+		return storage.ErrNotFound
+	}
+	u := *user
+	r.s.users[user.ID] = &u
+	return nil
+}
+
+// Delete deletes a user by their ID
+func (r *userRepo) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[id]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(r.s.users, id)
+
+	// ON DELETE CASCADE
+	delete(r.s.drafts, id)
+	delete(r.s.registered, id)
+	delete(r.s.blocked, id)
+	for bookingID, b := range r.s.bookings {
+		if b.UserID == id {
+			delete(r.s.bookings, bookingID)
+			delete(r.s.vouchers, bookingID)
+		}
+	}
+	for bookingID, wr := range r.s.workerRatings {
+		if wr.UserID == id {
+			delete(r.s.workerRatings, bookingID)
+		}
+	}
+	for bookingID, f := range r.s.feedback {
+		if f.UserID == id {
+			delete(r.s.feedback, bookingID)
+		}
+	}
+	r.s.profileChanges = slices.DeleteFunc(r.s.profileChanges, func(c *models.ProfileChange) bool {
+		return c.UserID == id
+	})
+	return nil
+}
+
+// UpdateState updates the user's state
+func (r *userRepo) UpdateState(ctx context.Context, id int64, state models.UserState) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	u.State = state
+	u.UpdatedAt = time.Now()
+	u.StateUpdatedAt = u.UpdatedAt
+	return nil
+}
+
+// GetStaleStates returns users whose non-idle state last changed before the cutoff, oldest first
+func (r *userRepo) GetStaleStates(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var users []*models.User
+	for _, u := range r.s.users {
+		if u.State != models.StateIdle && u.StateUpdatedAt.Before(before) {
+			user := *u
+			users = append(users, &user)
+		}
+	}
+
+	sort.Slice(users, func(a, b int) bool { return users[a].StateUpdatedAt.Before(users[b].StateUpdatedAt) })
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// ResetStaleState moves the user back to idle only if they are still in state and have not moved since before
+func (r *userRepo) ResetStaleState(ctx context.Context, id int64, state models.UserState, before time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok || u.State != state || !u.StateUpdatedAt.Before(before) {
+		return false, nil
+	}
+	u.State = models.StateIdle
+	u.UpdatedAt = time.Now()
+	u.StateUpdatedAt = u.UpdatedAt
+	return true, nil
+}
+
+// Anonymize clears the Telegram profile data of a user but keeps the row for history
+func (r *userRepo) Anonymize(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	u.Username = ""
+	u.FirstName = ""
+	u.LastName = ""
+	u.State = models.StateIdle
+	u.UpdatedAt = time.Now()
+	u.StateUpdatedAt = u.UpdatedAt
+	return nil
+}
+
+// SetMarketingOptOut turns marketing messages off (true) or back on for a user
+func (r *userRepo) SetMarketingOptOut(ctx context.Context, id int64, optOut bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	u.MarketingOptOut = optOut
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetOrCreateUser gets a user by ID or creates a new one if not found
+func (r *userRepo) GetOrCreateUser(ctx context.Context, id int64, username, firstName, lastName string) (*models.User, error) {
+	user, err := r.GetByID(ctx, id)
+	if err == nil {
+		return user, nil
+	}
+
+	newUser := models.NewUser(id, username, firstName, lastName)
+	if err := r.Create(ctx, newUser); err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return r.GetByID(ctx, id)
+		}
+		return nil, err
+	}
+	return newUser, nil
+}
+
+// GetTotalCount returns the total number of users
+func (r *userRepo) GetTotalCount(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.users), nil
+}
+
+// AddViolation adds a violation record for a user
+func (r *userRepo) AddViolation(ctx context.Context, tx any, violation *models.UserViolation) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.nextViolationID++
+	violation.ID = r.s.nextViolationID
+	violation.CreatedAt = time.Now()
+
+	v := *violation
+	n := len(r.s.violations)
+	r.s.violations = append(r.s.violations, &v)
+	journal(t, func() { r.s.violations = r.s.violations[:n] })
+	return nil
+}
+
+// GetViolationCount returns the total number of violations for a user
+func (r *userRepo) GetViolationCount(ctx context.Context, tx any, userID int64) (int, error) {
+	if _, err := checkTx(tx); err != nil {
+		return 0, err
+	}
+
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, v := range r.s.violations {
+		if v.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetViolations returns a user's violations, newest first
+func (r *userRepo) GetViolations(ctx context.Context, userID int64) ([]*models.UserViolation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var violations []*models.UserViolation
+	for i := len(r.s.violations) - 1; i >= 0; i-- {
+		if r.s.violations[i].UserID == userID {
+			v := *r.s.violations[i]
+			violations = append(violations, &v)
+		}
+	}
+	return violations, nil
+}
+
+// GetViolationByID retrieves a single violation
+func (r *userRepo) GetViolationByID(ctx context.Context, id int64) (*models.UserViolation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, v := range r.s.violations {
+		if v.ID == id {
+			violation := *v
+			return &violation, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// DeleteViolation removes a violation record (used when an admin forgives it)
+func (r *userRepo) DeleteViolation(ctx context.Context, tx any, id int64) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	old := r.s.violations
+	kept := make([]*models.UserViolation, 0, len(old))
+	for _, v := range old {
+		if v.ID != id {
+			kept = append(kept, v)
+		}
+	}
+	r.s.violations = kept
+	journal(t, func() { r.s.violations = old })
+	return nil
+}
+
+// BlockUser blocks a user (upserts the block record)
+func (r *userRepo) BlockUser(ctx context.Context, tx any, block *models.BlockedUser) error {
+	t, err := checkTx(tx)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	journal(t, restoreFunc(r.s.blocked, block.UserID))
+
+	now := time.Now()
+	block.CreatedAt = now
+	if existing, ok := r.s.blocked[block.UserID]; ok {
+		block.CreatedAt = existing.CreatedAt
+	}
+	block.UpdatedAt = now
+
+	b := *block
+	r.s.blocked[block.UserID] = &b
+	return nil
+}
+
+// GetBlockStatus checks if a user is blocked (nil, nil when not blocked)
+func (r *userRepo) GetBlockStatus(ctx context.Context, userID int64) (*models.BlockedUser, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	b, ok := r.s.blocked[userID]
+	if !ok {
+		return nil, nil
+	}
+	block := *b
+	return &block, nil
+}
+
+// UnblockUser removes a block from a user
+func (r *userRepo) UnblockUser(ctx context.Context, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.blocked, userID)
+	return nil
+}
+
+// GetExpiredBlocks returns temporary blocks that have run out, oldest expiry first
+func (r *userRepo) GetExpiredBlocks(ctx context.Context, now time.Time, limit int) ([]*models.BlockedUser, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var blocks []*models.BlockedUser
+	for _, b := range r.s.blocked {
+		if b.BlockedUntil != nil && !b.BlockedUntil.After(now) {
+			block := *b
+			blocks = append(blocks, &block)
+		}
+	}
+
+	sort.Slice(blocks, func(a, b int) bool { return blocks[a].BlockedUntil.Before(*blocks[b].BlockedUntil) })
+	if limit > 0 && len(blocks) > limit {
+		blocks = blocks[:limit]
+	}
+	return blocks, nil
+}
+
+// UnblockIfExpired removes a temporary block that has run out
+func (r *userRepo) UnblockIfExpired(ctx context.Context, userID int64, now time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	b, ok := r.s.blocked[userID]
+	if !ok || b.BlockedUntil == nil || b.BlockedUntil.After(now) {
+		return false, nil
+	}
+	delete(r.s.blocked, userID)
+	return true, nil
+}
+
+// GetBlockedCount returns the total number of blocked users
+func (r *userRepo) GetBlockedCount(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.blocked), nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/storage"
+)
+
+type voucherRepo struct {
+	s *Store
+}
+
+// Create stores a voucher, enforcing UNIQUE(booking_id) and UNIQUE(code)
+func (r *voucherRepo) Create(ctx context.Context, voucher *models.BookingVoucher) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.vouchers[voucher.BookingID]; ok {
+		return storage.ErrAlreadyExists
+	}
+	for _, v := range r.s.vouchers {
+		if v.Code == voucher.Code {
+			return storage.ErrAlreadyExists
+		}
+	}
+
+	r.s.nextVoucherID++
+	voucher.ID = r.s.nextVoucherID
+	voucher.CreatedAt = time.Now()
+
+	v := *voucher
+	r.s.vouchers[voucher.BookingID] = &v
+	return nil
+}
+
+// GetByBookingID retrieves the voucher of a booking
+func (r *voucherRepo) GetByBookingID(ctx context.Context, bookingID int64) (*models.BookingVoucher, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	v, ok := r.s.vouchers[bookingID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	voucher := *v
+	return &voucher, nil
+}
+
+// GetByCode retrieves a voucher by its verification code
+func (r *voucherRepo) GetByCode(ctx context.Context, code string) (*models.BookingVoucher, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, v := range r.s.vouchers {
+		if v.Code == code {
+			voucher := *v
+			return &voucher, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// MarkVerified records the first on-site check of a voucher
+func (r *voucherRepo) MarkVerified(ctx context.Context, id, adminID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, v := range r.s.vouchers {
+		if v.ID == id && v.VerifiedAt == nil {
+			now := time.Now()
+			v.VerifiedAt = &now
+			v.VerifiedByAdminID = &adminID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+type workerRatingRepo struct {
+	s *Store
+}
+
+// Upsert creates or replaces the rating for a booking
+func (r *workerRatingRepo) Upsert(ctx context.Context, rating *models.WorkerRating) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := r.s.workerRatings[rating.BookingID]; ok {
+		rating.ID = existing.ID
+		rating.UserID = existing.UserID
+		rating.JobID = existing.JobID
+		rating.CreatedAt = existing.CreatedAt
+	} else {
+		r.s.nextWorkerRatingID++
+		rating.ID = r.s.nextWorkerRatingID
+		rating.CreatedAt = now
+	}
+	rating.UpdatedAt = now
+
+	wr := *rating
+	r.s.workerRatings[rating.BookingID] = &wr
+	return nil
+}
+
+// GetByJobID returns all ratings given for a job's bookings
+func (r *workerRatingRepo) GetByJobID(ctx context.Context, jobID int64) ([]*models.WorkerRating, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var ratings []*models.WorkerRating
+	for _, wr := range r.s.workerRatings {
+		if wr.JobID == jobID {
+			rating := *wr
+			ratings = append(ratings, &rating)
+		}
+	}
+	return ratings, nil
+}
+
+// GetReliability aggregates a worker's ratings and attendance marks
+func (r *workerRatingRepo) GetReliability(ctx context.Context, userID int64) (*models.WorkerReliability, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rel := &models.WorkerReliability{}
+	sum := 0
+	for _, wr := range r.s.workerRatings {
+		if wr.UserID == userID {
+			rel.RatingCount++
+			sum += wr.Rating
+		}
+	}
+	if rel.RatingCount > 0 {
+		rel.AverageRating = float64(sum) / float64(rel.RatingCount)
+	}
+
+	for _, b := range r.s.bookings {
+		if b.UserID != userID {
+			continue
+		}
+		switch b.Attendance {
+		case models.AttendanceAttended:
+			rel.Attended++
+		case models.AttendanceNoShow:
+			rel.NoShows++
+		}
+	}
+
+	return rel, nil
+}
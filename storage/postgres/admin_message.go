@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/pkg/logger"
@@ -37,7 +38,7 @@ func (r *adminMessageRepo) Upsert(ctx context.Context, adminMsg *models.AdminJob
 	err := r.db.QueryRow(ctx, query, adminMsg.JobID, adminMsg.AdminID, adminMsg.MessageID).
 		Scan(&adminMsg.ID, &adminMsg.CreatedAt, &adminMsg.UpdatedAt)
 	if err != nil {
-		r.log.Error("Failed to upsert admin message", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to upsert admin message", logger.Error(err))
 		return fmt.Errorf("failed to upsert admin message: %w", err)
 	}
 
@@ -66,7 +67,7 @@ func (r *adminMessageRepo) Get(ctx context.Context, jobID, adminID int64) (*mode
 		if err.Error() == "no rows in result set" {
 			return nil, storage.ErrNotFound
 		}
-		r.log.Error("Failed to get admin message", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to get admin message", logger.Error(err))
 		return nil, fmt.Errorf("failed to get admin message: %w", err)
 	}
 
@@ -84,7 +85,7 @@ func (r *adminMessageRepo) GetAllByJobID(ctx context.Context, jobID int64) ([]*m
 
 	rows, err := r.db.Query(ctx, query, jobID)
 	if err != nil {
-		r.log.Error("Failed to get admin messages for job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to get admin messages for job", logger.Error(err))
 		return nil, fmt.Errorf("failed to get admin messages for job: %w", err)
 	}
 	defer rows.Close()
@@ -100,7 +101,42 @@ func (r *adminMessageRepo) GetAllByJobID(ctx context.Context, jobID int64) ([]*m
 			&adminMsg.CreatedAt,
 			&adminMsg.UpdatedAt,
 		); err != nil {
-			r.log.Error("Failed to scan admin message", logger.Error(err))
+			logger.FromContext(ctx, r.log).Error("Failed to scan admin message", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan admin message: %w", err)
+		}
+		messages = append(messages, adminMsg)
+	}
+
+	return messages, nil
+}
+
+// GetAll retrieves every stored admin message, oldest first
+func (r *adminMessageRepo) GetAll(ctx context.Context) ([]*models.AdminJobMessage, error) {
+	query := `
+		SELECT id, job_id, admin_id, message_id, created_at, updated_at
+		FROM admin_job_messages
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get admin messages", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*models.AdminJobMessage
+	for rows.Next() {
+		adminMsg := &models.AdminJobMessage{}
+		if err := rows.Scan(
+			&adminMsg.ID,
+			&adminMsg.JobID,
+			&adminMsg.AdminID,
+			&adminMsg.MessageID,
+			&adminMsg.CreatedAt,
+			&adminMsg.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan admin message", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan admin message: %w", err)
 		}
 		messages = append(messages, adminMsg)
@@ -114,7 +150,7 @@ func (r *adminMessageRepo) Delete(ctx context.Context, jobID, adminID int64) err
 	query := `DELETE FROM admin_job_messages WHERE job_id = $1 AND admin_id = $2`
 	_, err := r.db.Exec(ctx, query, jobID, adminID)
 	if err != nil {
-		r.log.Error("Failed to delete admin message", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to delete admin message", logger.Error(err))
 		return fmt.Errorf("failed to delete admin message: %w", err)
 	}
 	return nil
@@ -125,8 +161,25 @@ func (r *adminMessageRepo) DeleteAllByJobID(ctx context.Context, jobID int64) er
 	query := `DELETE FROM admin_job_messages WHERE job_id = $1`
 	_, err := r.db.Exec(ctx, query, jobID)
 	if err != nil {
-		r.log.Error("Failed to delete all admin messages for job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to delete all admin messages for job", logger.Error(err))
 		return fmt.Errorf("failed to delete all admin messages for job: %w", err)
 	}
 	return nil
 }
+
+// DeleteForClosedJobsBefore deletes the admin messages of jobs finished before the cutoff
+func (r *adminMessageRepo) DeleteForClosedJobsBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		DELETE FROM admin_job_messages
+		WHERE job_id IN (
+			SELECT id FROM jobs
+			WHERE status IN ('COMPLETED', 'CANCELLED') AND updated_at < $1
+		)
+	`
+	tag, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete admin messages of closed jobs", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete admin messages of closed jobs: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewAuditRepo creates a new audit log repository
+func NewAuditRepo(db *pgxpool.Pool, log logger.LoggerI) storage.AuditRepoI {
+	return &auditRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create appends an entry to the audit log
+func (r *auditRepo) Create(ctx context.Context, entry *models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, target_user_id, details)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetUserID,
+		entry.Details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create audit entry", logger.Error(err))
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdminActivity counts each admin's jobs, receipt reviews and blocks in [from, to).
+// A review is approved when the booking got confirmed; otherwise the admin's last decision was a rejection.
+// Review time is only measured when the receipt the admin saw is still the stored one.
+func (r *auditRepo) GetAdminActivity(ctx context.Context, from, to time.Time) ([]*models.AdminActivity, error) {
+	query := `
+		SELECT admin_id, SUM(jobs), SUM(approved), SUM(rejected), SUM(timed), SUM(review_seconds), SUM(blocks)
+		FROM (
+			SELECT created_by_admin_id AS admin_id, 1 AS jobs, 0 AS approved, 0 AS rejected,
+				0 AS timed, 0::float8 AS review_seconds, 0 AS blocks
+			FROM jobs
+			WHERE created_by_admin_id <> 0 AND created_at >= $1 AND created_at < $2
+			UNION ALL
+			SELECT reviewed_by_admin_id, 0,
+				CASE WHEN confirmed_at IS NOT NULL THEN 1 ELSE 0 END,
+				CASE WHEN confirmed_at IS NULL THEN 1 ELSE 0 END,
+				CASE WHEN reviewed_at >= payment_submitted_at THEN 1 ELSE 0 END,
+				CASE WHEN reviewed_at >= payment_submitted_at
+					THEN EXTRACT(EPOCH FROM reviewed_at - payment_submitted_at)::float8 ELSE 0 END,
+				0
+			FROM job_bookings
+			WHERE reviewed_by_admin_id IS NOT NULL AND payment_submitted_at IS NOT NULL
+				AND reviewed_at >= $1 AND reviewed_at < $2
+			UNION ALL
+			SELECT actor_id, 0, 0, 0, 0, 0, 1
+			FROM audit_log
+			WHERE action = $3 AND actor_id IS NOT NULL AND created_at >= $1 AND created_at < $2
+		) activity
+		GROUP BY admin_id
+		ORDER BY SUM(jobs) + SUM(approved) + SUM(rejected) + SUM(blocks) DESC, admin_id
+	`
+
+	rows, err := r.db.Query(ctx, query, from.UTC(), to.UTC(), models.AuditActionUserBlock)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get admin activity", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activity []*models.AdminActivity
+	for rows.Next() {
+		a := &models.AdminActivity{}
+		var timed int
+		var reviewSeconds float64
+		if err := rows.Scan(&a.AdminID, &a.JobsCreated, &a.Approved, &a.Rejected, &timed, &reviewSeconds, &a.Blocks); err != nil {
+			return nil, fmt.Errorf("failed to scan admin activity: %w", err)
+		}
+		if timed > 0 {
+			a.AvgReview = time.Duration(reviewSeconds / float64(timed) * float64(time.Second))
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
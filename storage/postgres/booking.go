@@ -2,12 +2,13 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/fieldcrypt"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/storage"
 
@@ -16,16 +17,27 @@ import (
 )
 
 // bookingRepo implements storage.BookingRepoI interface using PostgreSQL
+// bookingColumns is the full booking column list read by scanBooking
+const bookingColumns = `id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
+	payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
+	reviewed_by_admin_id, reviewed_at, rejection_reason, payment_rejections, idempotency_key,
+	receipt_archive_url, receipt_archive_extra_urls, payment_receipt_extra_file_ids, payment_receipt_is_document, paid_amount, status_message_id,
+	created_at, updated_at`
+
 type bookingRepo struct {
-	db  *pgxpool.Pool
-	log logger.LoggerI
+	db    *pgxpool.Pool
+	read  *pgxpool.Pool // Replica for lag-tolerant reads; the primary when none is configured
+	log   logger.LoggerI
+	crypt *fieldcrypt.Cipher // Hashes phones for registered_users.phone_hash lookups
 }
 
 // NewBookingRepo creates a new PostgreSQL booking repository
-func NewBookingRepo(db *pgxpool.Pool, log logger.LoggerI) storage.BookingRepoI {
+func NewBookingRepo(db, read *pgxpool.Pool, log logger.LoggerI, crypt *fieldcrypt.Cipher) storage.BookingRepoI {
 	return &bookingRepo{
-		db:  db,
-		log: log,
+		db:    db,
+		read:  read,
+		log:   log,
+		crypt: crypt,
 	}
 }
 
@@ -40,6 +52,10 @@ func (r *bookingRepo) Create(ctx context.Context, tx any, booking *models.JobBoo
 			status = EXCLUDED.status,
 			reserved_at = EXCLUDED.reserved_at,
 			expires_at = EXCLUDED.expires_at,
+			-- A rebooking reuses the old row: start its receipt rounds, amount and status message afresh
+			payment_rejections = 0,
+			paid_amount = 0,
+			status_message_id = NULL,
 			updated_at = NOW()
 		RETURNING id, created_at, updated_at
 	`
@@ -67,7 +83,7 @@ func (r *bookingRepo) Create(ctx context.Context, tx any, booking *models.JobBoo
 	}
 
 	if err != nil {
-		r.log.Error("Failed to create booking", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to create booking", logger.Error(err))
 		return fmt.Errorf("failed to create booking: %w", err)
 	}
 
@@ -76,114 +92,53 @@ func (r *bookingRepo) Create(ctx context.Context, tx any, booking *models.JobBoo
 
 // GetByID retrieves a booking by ID
 func (r *bookingRepo) GetByID(ctx context.Context, id int64) (*models.JobBooking, error) {
-	query := `
-		SELECT id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
-			   payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
-			   reviewed_by_admin_id, reviewed_at, rejection_reason, idempotency_key,
-			   created_at, updated_at
-		FROM job_bookings
-		WHERE id = $1
-	`
-
-	booking := &models.JobBooking{}
-	var paymentReceiptFileID, rejectionReason sql.NullString
-	var paymentReceiptMsgID, paymentInstructionMsgID, reviewedByAdminID sql.NullInt64
-	var paymentSubmittedAt, confirmedAt, reviewedAt sql.NullTime
-
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&booking.ID,
-		&booking.JobID,
-		&booking.UserID,
-		&booking.Status,
-		&paymentReceiptFileID,
-		&paymentReceiptMsgID,
-		&paymentInstructionMsgID,
-		&booking.ReservedAt,
-		&booking.ExpiresAt,
-		&paymentSubmittedAt,
-		&confirmedAt,
-		&reviewedByAdminID,
-		&reviewedAt,
-		&rejectionReason,
-		&booking.IdempotencyKey,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+	query := `SELECT ` + bookingColumns + ` FROM job_bookings WHERE id = $1`
 
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
 		}
-		r.log.Error("Failed to get booking", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to get booking", logger.Error(err))
 		return nil, fmt.Errorf("failed to get booking: %w", err)
 	}
 
-	// Handle nullable fields
-	if paymentReceiptFileID.Valid {
-		booking.PaymentReceiptFileID = paymentReceiptFileID.String
-	}
-	if paymentReceiptMsgID.Valid {
-		booking.PaymentReceiptMsgID = paymentReceiptMsgID.Int64
-	}
-	if paymentInstructionMsgID.Valid {
-		booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
-	}
-	if paymentSubmittedAt.Valid {
-		booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
-	}
-	if confirmedAt.Valid {
-		booking.ConfirmedAt = &confirmedAt.Time
-	}
-	if reviewedByAdminID.Valid {
-		booking.ReviewedByAdminID = &reviewedByAdminID.Int64
-	}
-	if reviewedAt.Valid {
-		booking.ReviewedAt = &reviewedAt.Time
-	}
-	if rejectionReason.Valid {
-		booking.RejectionReason = rejectionReason.String
-	}
+	return booking, nil
+}
 
+// scanBooking reads a row selected with bookingColumns
+func scanBooking(row pgx.Row) (*models.JobBooking, error) {
+	booking := &models.JobBooking{}
+	var extraFileIDs, archiveExtraURLs string
+	err := row.Scan(
+		&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+		nullable(&booking.PaymentReceiptFileID), nullable(&booking.PaymentReceiptMsgID), nullable(&booking.PaymentInstructionMsgID),
+		&booking.ReservedAt, &booking.ExpiresAt, &booking.PaymentSubmittedAt, &booking.ConfirmedAt,
+		&booking.ReviewedByAdminID, &booking.ReviewedAt, nullable(&booking.RejectionReason),
+		&booking.PaymentRejections, &booking.IdempotencyKey,
+		nullable(&booking.ReceiptArchiveURL), nullable(&archiveExtraURLs), nullable(&extraFileIDs), &booking.PaymentReceiptIsDocument,
+		&booking.PaidAmount, nullable(&booking.StatusMessageID), &booking.CreatedAt, &booking.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	booking.PaymentReceiptExtraFileIDs = splitFileIDs(extraFileIDs)
+	booking.ReceiptArchiveExtraURLs = splitURLs(archiveExtraURLs)
 	return booking, nil
 }
 
 // GetByIDForUpdate retrieves a booking with row lock (FOR UPDATE)
 func (r *bookingRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.JobBooking, error) {
-	query := `
-		SELECT id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
-			   payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
-			   reviewed_by_admin_id, reviewed_at, rejection_reason, idempotency_key,
-			   created_at, updated_at
-		FROM job_bookings
-		WHERE id = $1
-		FOR UPDATE
-	`
+	query := `SELECT ` + bookingColumns + ` FROM job_bookings WHERE id = $1 FOR UPDATE`
 
-	booking := &models.JobBooking{}
-	var paymentReceiptFileID, rejectionReason sql.NullString
-	var paymentReceiptMsgID, paymentInstructionMsgID, reviewedByAdminID sql.NullInt64
-	var paymentSubmittedAt, confirmedAt, reviewedAt sql.NullTime
-
-	var err error
+	var row pgx.Row
 	if tx != nil {
-		pgxTx := tx.(pgx.Tx)
-		err = pgxTx.QueryRow(ctx, query, id).Scan(
-			&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
-			&paymentReceiptFileID, &paymentReceiptMsgID, &paymentInstructionMsgID,
-			&booking.ReservedAt, &booking.ExpiresAt, &paymentSubmittedAt, &confirmedAt,
-			&reviewedByAdminID, &reviewedAt, &rejectionReason, &booking.IdempotencyKey,
-			&booking.CreatedAt, &booking.UpdatedAt,
-		)
+		row = tx.(pgx.Tx).QueryRow(ctx, query, id)
 	} else {
-		err = r.db.QueryRow(ctx, query, id).Scan(
-			&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
-			&paymentReceiptFileID, &paymentReceiptMsgID, &paymentInstructionMsgID,
-			&booking.ReservedAt, &booking.ExpiresAt, &paymentSubmittedAt, &confirmedAt,
-			&reviewedByAdminID, &reviewedAt, &rejectionReason, &booking.IdempotencyKey,
-			&booking.CreatedAt, &booking.UpdatedAt,
-		)
+		row = r.db.QueryRow(ctx, query, id)
 	}
 
+	booking, err := scanBooking(row)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
@@ -191,61 +146,20 @@ func (r *bookingRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*
 		return nil, fmt.Errorf("failed to get booking for update: %w", err)
 	}
 
-	// Handle nullable fields (same as GetByID)
-	if paymentReceiptFileID.Valid {
-		booking.PaymentReceiptFileID = paymentReceiptFileID.String
-	}
-	if paymentReceiptMsgID.Valid {
-		booking.PaymentReceiptMsgID = paymentReceiptMsgID.Int64
-	}
-	if paymentInstructionMsgID.Valid {
-		booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
-	}
-	if paymentSubmittedAt.Valid {
-		booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
-	}
-	if confirmedAt.Valid {
-		booking.ConfirmedAt = &confirmedAt.Time
-	}
-	if reviewedByAdminID.Valid {
-		booking.ReviewedByAdminID = &reviewedByAdminID.Int64
-	}
-	if reviewedAt.Valid {
-		booking.ReviewedAt = &reviewedAt.Time
-	}
-	if rejectionReason.Valid {
-		booking.RejectionReason = rejectionReason.String
-	}
-
 	return booking, nil
 }
 
 // GetByUserAndJob retrieves a booking by user and job
 func (r *bookingRepo) GetByUserAndJob(ctx context.Context, userID, jobID int64) (*models.JobBooking, error) {
 	query := `
-		SELECT id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
-			   payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
-			   reviewed_by_admin_id, reviewed_at, rejection_reason, idempotency_key,
-			   created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM job_bookings
 		WHERE user_id = $1 AND job_id = $2
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
 
-	booking := &models.JobBooking{}
-	var paymentReceiptFileID, rejectionReason sql.NullString
-	var paymentReceiptMsgID, paymentInstructionMsgID, reviewedByAdminID sql.NullInt64
-	var paymentSubmittedAt, confirmedAt, reviewedAt sql.NullTime
-
-	err := r.db.QueryRow(ctx, query, userID, jobID).Scan(
-		&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
-		&paymentReceiptFileID, &paymentReceiptMsgID, &paymentInstructionMsgID,
-		&booking.ReservedAt, &booking.ExpiresAt, &paymentSubmittedAt, &confirmedAt,
-		&reviewedByAdminID, &reviewedAt, &rejectionReason, &booking.IdempotencyKey,
-		&booking.CreatedAt, &booking.UpdatedAt,
-	)
-
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, userID, jobID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
@@ -253,32 +167,6 @@ func (r *bookingRepo) GetByUserAndJob(ctx context.Context, userID, jobID int64)
 		return nil, fmt.Errorf("failed to get booking by user and job: %w", err)
 	}
 
-	// Handle nullable fields
-	if paymentReceiptFileID.Valid {
-		booking.PaymentReceiptFileID = paymentReceiptFileID.String
-	}
-	if paymentReceiptMsgID.Valid {
-		booking.PaymentReceiptMsgID = paymentReceiptMsgID.Int64
-	}
-	if paymentInstructionMsgID.Valid {
-		booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
-	}
-	if paymentSubmittedAt.Valid {
-		booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
-	}
-	if confirmedAt.Valid {
-		booking.ConfirmedAt = &confirmedAt.Time
-	}
-	if reviewedByAdminID.Valid {
-		booking.ReviewedByAdminID = &reviewedByAdminID.Int64
-	}
-	if reviewedAt.Valid {
-		booking.ReviewedAt = &reviewedAt.Time
-	}
-	if rejectionReason.Valid {
-		booking.RejectionReason = rejectionReason.String
-	}
-
 	return booking, nil
 }
 
@@ -317,14 +205,53 @@ func (r *bookingRepo) GetByIdempotencyKey(ctx context.Context, tx any, key strin
 	return booking, nil
 }
 
-// Update updates a booking
+// GetActiveByJobAndPhone finds another account's active booking on the job under the same registered phone
+func (r *bookingRepo) GetActiveByJobAndPhone(ctx context.Context, tx any, jobID int64, phone string, excludeUserID int64, now time.Time) (*models.JobBooking, error) {
+	query := `
+		SELECT b.id, b.job_id, b.user_id, b.status, b.reserved_at, b.expires_at, b.created_at, b.updated_at
+		FROM job_bookings b
+		JOIN registered_users ru ON ru.user_id = b.user_id
+		WHERE b.job_id = $1 AND (ru.phone_hash = $5 OR ru.phone = $2) AND b.user_id <> $3
+		  AND (b.status IN ('PAYMENT_SUBMITTED', 'CONFIRMED')
+		       OR (b.status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE') AND b.expires_at > $4))
+		ORDER BY b.created_at
+		LIMIT 1
+	`
+
+	booking := &models.JobBooking{}
+	var err error
+
+	args := []any{jobID, phone, excludeUserID, now, phoneLookup(r.crypt, phone)}
+	if tx != nil {
+		err = tx.(pgx.Tx).QueryRow(ctx, query, args...).Scan(
+			&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+			&booking.ReservedAt, &booking.ExpiresAt, &booking.CreatedAt, &booking.UpdatedAt,
+		)
+	} else {
+		err = r.db.QueryRow(ctx, query, args...).Scan(
+			&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+			&booking.ReservedAt, &booking.ExpiresAt, &booking.CreatedAt, &booking.UpdatedAt,
+		)
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by phone: %w", err)
+	}
+
+	return booking, nil
+}
+
+// Update updates a booking's payment and review fields; the status is only written by UpdateStatus and the Mark* methods
 func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBooking) error {
 	query := `
 		UPDATE job_bookings
-		SET status = $2, payment_receipt_file_id = $3, payment_receipt_message_id = $4,
-			payment_instruction_message_id = $5, payment_submitted_at = $6, confirmed_at = $7,
-			reviewed_by_admin_id = $8, reviewed_at = $9, rejection_reason = $10,
-			updated_at = NOW()
+		SET payment_receipt_file_id = $2, payment_receipt_message_id = $3,
+			payment_instruction_message_id = $4, payment_submitted_at = $5, confirmed_at = $6,
+			reviewed_by_admin_id = $7, reviewed_at = $8, rejection_reason = $9,
+			payment_receipt_extra_file_ids = $10, payment_receipt_is_document = $11, paid_amount = $12, updated_at = NOW()
 		WHERE id = $1
 	`
 
@@ -333,7 +260,6 @@ func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBoo
 		pgxTx := tx.(pgx.Tx)
 		_, err = pgxTx.Exec(ctx, query,
 			booking.ID,
-			booking.Status,
 			toNullString(booking.PaymentReceiptFileID),
 			toNullInt64(booking.PaymentReceiptMsgID),
 			toNullInt64(booking.PaymentInstructionMsgID),
@@ -342,11 +268,13 @@ func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBoo
 			toNullInt64Ptr(booking.ReviewedByAdminID),
 			toNullTime(booking.ReviewedAt),
 			toNullString(booking.RejectionReason),
+			toNullString(joinFileIDs(booking.PaymentReceiptExtraFileIDs)),
+			booking.PaymentReceiptIsDocument,
+			booking.PaidAmount,
 		)
 	} else {
 		_, err = r.db.Exec(ctx, query,
 			booking.ID,
-			booking.Status,
 			toNullString(booking.PaymentReceiptFileID),
 			toNullInt64(booking.PaymentReceiptMsgID),
 			toNullInt64(booking.PaymentInstructionMsgID),
@@ -355,11 +283,14 @@ func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBoo
 			toNullInt64Ptr(booking.ReviewedByAdminID),
 			toNullTime(booking.ReviewedAt),
 			toNullString(booking.RejectionReason),
+			toNullString(joinFileIDs(booking.PaymentReceiptExtraFileIDs)),
+			booking.PaymentReceiptIsDocument,
+			booking.PaidAmount,
 		)
 	}
 
 	if err != nil {
-		r.log.Error("Failed to update booking", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to update booking", logger.Error(err))
 		return fmt.Errorf("failed to update booking: %w", err)
 	}
 
@@ -373,21 +304,28 @@ func (r *bookingRepo) Delete(ctx context.Context, id int64) error {
 	return err
 }
 
-// GetExpiredBookings retrieves bookings that have expired.
-// No FOR UPDATE here — the single-threaded expiry worker processes each booking
-// in its own transaction with proper row locking via MarkAsExpired.
-func (r *bookingRepo) GetExpiredBookings(ctx context.Context, limit int) ([]*models.JobBooking, error) {
+// GetExpiredBookings retrieves bookings that have expired, oldest deadline first.
+// Inside a transaction the rows are claimed with FOR UPDATE SKIP LOCKED, so a
+// concurrent payment submission or another worker can't touch them until commit.
+func (r *bookingRepo) GetExpiredBookings(ctx context.Context, tx any, now time.Time, limit int) ([]*models.JobBooking, error) {
 	query := `
-		SELECT id, job_id, user_id, payment_instruction_message_id
+		SELECT id, job_id, user_id, status, payment_instruction_message_id
 		FROM job_bookings
-		WHERE status = 'SLOT_RESERVED'
+		WHERE status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE')
 		  AND expires_at < $1
+		ORDER BY expires_at
 		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, time.Now(), limit)
+	var rows pgx.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.(pgx.Tx).Query(ctx, query+" FOR UPDATE SKIP LOCKED", now, limit)
+	} else {
+		rows, err = r.db.Query(ctx, query, now, limit)
+	}
 	if err != nil {
-		r.log.Error("Failed to get expired bookings", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to get expired bookings", logger.Error(err))
 		return nil, fmt.Errorf("failed to get expired bookings: %w", err)
 	}
 	defer rows.Close()
@@ -395,17 +333,60 @@ func (r *bookingRepo) GetExpiredBookings(ctx context.Context, limit int) ([]*mod
 	var bookings []*models.JobBooking
 	for rows.Next() {
 		booking := &models.JobBooking{}
-		var msgID sql.NullInt64
-
-		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID, &msgID); err != nil {
-			r.log.Error("Failed to scan expired booking", logger.Error(err))
+		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+			nullable(&booking.PaymentInstructionMsgID)); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan expired booking", logger.Error(err))
 			continue
 		}
+		bookings = append(bookings, booking)
+	}
 
-		if msgID.Valid {
-			booking.PaymentInstructionMsgID = msgID.Int64
-		}
+	return bookings, nil
+}
+
+// CountExpired counts awaiting-receipt bookings past expires_at that the expiry worker hasn't released yet
+func (r *bookingRepo) CountExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM job_bookings
+		WHERE status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE')
+		  AND expires_at < $1
+	`
 
+	var count int
+	if err := r.db.QueryRow(ctx, query, now).Scan(&count); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to count expired bookings", logger.Error(err))
+		return 0, fmt.Errorf("failed to count expired bookings: %w", err)
+	}
+	return count, nil
+}
+
+// GetActiveReservations retrieves unexpired reservations whose payment instruction message is known
+func (r *bookingRepo) GetActiveReservations(ctx context.Context, now time.Time, limit int) ([]*models.JobBooking, error) {
+	query := `
+		SELECT id, job_id, user_id, payment_instruction_message_id, expires_at
+		FROM job_bookings
+		WHERE status = 'SLOT_RESERVED'
+		  AND expires_at > $1
+		  AND payment_instruction_message_id IS NOT NULL
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get active reservations", logger.Error(err))
+		return nil, fmt.Errorf("failed to get active reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.JobBooking
+	for rows.Next() {
+		booking := &models.JobBooking{Status: models.BookingStatusSlotReserved}
+		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID, &booking.PaymentInstructionMsgID, &booking.ExpiresAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan active reservation", logger.Error(err))
+			continue
+		}
 		bookings = append(bookings, booking)
 	}
 
@@ -431,25 +412,14 @@ func (r *bookingRepo) GetPendingApprovals(ctx context.Context) ([]*models.JobBoo
 	var bookings []*models.JobBooking
 	for rows.Next() {
 		booking := &models.JobBooking{Status: models.BookingStatusPaymentSubmitted}
-		var paymentSubmittedAt sql.NullTime
-		var paymentInstructionMsgID sql.NullInt64
-
 		if err := rows.Scan(
 			&booking.ID, &booking.JobID, &booking.UserID,
 			&booking.PaymentReceiptFileID, &booking.PaymentReceiptMsgID,
-			&paymentInstructionMsgID, &paymentSubmittedAt,
+			nullable(&booking.PaymentInstructionMsgID), &booking.PaymentSubmittedAt,
 			&booking.IdempotencyKey, &booking.CreatedAt,
 		); err != nil {
 			continue
 		}
-
-		if paymentInstructionMsgID.Valid {
-			booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
-		}
-		if paymentSubmittedAt.Valid {
-			booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
-		}
-
 		bookings = append(bookings, booking)
 	}
 
@@ -487,10 +457,7 @@ func (r *bookingRepo) GetUserBookings(ctx context.Context, userID int64) ([]*mod
 // GetUserBookingsByStatus retrieves user bookings filtered by status
 func (r *bookingRepo) GetUserBookingsByStatus(ctx context.Context, userID int64, status models.BookingStatus) ([]*models.JobBooking, error) {
 	query := `
-		SELECT id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
-			   payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
-			   reviewed_by_admin_id, reviewed_at, rejection_reason, idempotency_key,
-			   created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM job_bookings
 		WHERE user_id = $1 AND status = $2
 		ORDER BY created_at DESC
@@ -504,48 +471,11 @@ func (r *bookingRepo) GetUserBookingsByStatus(ctx context.Context, userID int64,
 
 	var bookings []*models.JobBooking
 	for rows.Next() {
-		booking := &models.JobBooking{}
-		var paymentReceiptFileID, rejectionReason sql.NullString
-		var paymentReceiptMsgID, paymentInstructionMsgID, reviewedByAdminID sql.NullInt64
-		var paymentSubmittedAt, confirmedAt, reviewedAt sql.NullTime
-
-		if err := rows.Scan(
-			&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
-			&paymentReceiptFileID, &paymentReceiptMsgID, &paymentInstructionMsgID,
-			&booking.ReservedAt, &booking.ExpiresAt, &paymentSubmittedAt, &confirmedAt,
-			&reviewedByAdminID, &reviewedAt, &rejectionReason, &booking.IdempotencyKey,
-			&booking.CreatedAt, &booking.UpdatedAt,
-		); err != nil {
-			r.log.Error("Failed to scan booking", logger.Error(err))
+		booking, err := scanBooking(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan booking", logger.Error(err))
 			continue
 		}
-
-		// Handle nullable fields
-		if paymentReceiptFileID.Valid {
-			booking.PaymentReceiptFileID = paymentReceiptFileID.String
-		}
-		if paymentReceiptMsgID.Valid {
-			booking.PaymentReceiptMsgID = paymentReceiptMsgID.Int64
-		}
-		if paymentInstructionMsgID.Valid {
-			booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
-		}
-		if paymentSubmittedAt.Valid {
-			booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
-		}
-		if confirmedAt.Valid {
-			booking.ConfirmedAt = &confirmedAt.Time
-		}
-		if reviewedByAdminID.Valid {
-			booking.ReviewedByAdminID = &reviewedByAdminID.Int64
-		}
-		if reviewedAt.Valid {
-			booking.ReviewedAt = &reviewedAt.Time
-		}
-		if rejectionReason.Valid {
-			booking.RejectionReason = rejectionReason.String
-		}
-
 		bookings = append(bookings, booking)
 	}
 
@@ -555,7 +485,7 @@ func (r *bookingRepo) GetUserBookingsByStatus(ctx context.Context, userID int64,
 // GetJobBookings retrieves all bookings for a job
 func (r *bookingRepo) GetJobBookings(ctx context.Context, jobID int64) ([]*models.JobBooking, error) {
 	query := `
-		SELECT id, user_id, status, reserved_at, expires_at, created_at
+		SELECT id, user_id, status, attendance, reserved_at, expires_at, created_at
 		FROM job_bookings
 		WHERE job_id = $1
 		ORDER BY created_at DESC
@@ -570,7 +500,7 @@ func (r *bookingRepo) GetJobBookings(ctx context.Context, jobID int64) ([]*model
 	var bookings []*models.JobBooking
 	for rows.Next() {
 		booking := &models.JobBooking{JobID: jobID}
-		if err := rows.Scan(&booking.ID, &booking.UserID, &booking.Status,
+		if err := rows.Scan(&booking.ID, &booking.UserID, &booking.Status, nullable(&booking.Attendance),
 			&booking.ReservedAt, &booking.ExpiresAt, &booking.CreatedAt); err != nil {
 			continue
 		}
@@ -650,35 +580,239 @@ func (r *bookingRepo) MarkAsRejected(ctx context.Context, tx any, bookingID int6
 	return err
 }
 
-// Helper functions for null handling
-func toNullString(s string) sql.NullString {
-	return sql.NullString{String: s, Valid: s != ""}
+// MarkAsRetryable rejects the receipt but keeps the slot held until expiresAt
+func (r *bookingRepo) MarkAsRetryable(ctx context.Context, tx any, bookingID int64, adminID int64, reason string, expiresAt time.Time) error {
+	query := `
+		UPDATE job_bookings
+		SET status = 'PAYMENT_REJECTED_RETRYABLE',
+			rejection_reason = $2,
+			reviewed_by_admin_id = $3,
+			reviewed_at = NOW(),
+			expires_at = $4,
+			payment_rejections = payment_rejections + 1,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+
+	var err error
+	if tx != nil {
+		pgxTx := tx.(pgx.Tx)
+		_, err = pgxTx.Exec(ctx, query, bookingID, reason, adminID, expiresAt)
+	} else {
+		_, err = r.db.Exec(ctx, query, bookingID, reason, adminID, expiresAt)
+	}
+
+	return err
+}
+
+// AddEvent appends a status change to the booking's history
+func (r *bookingRepo) AddEvent(ctx context.Context, tx any, event *models.BookingEvent) error {
+	query := `
+		INSERT INTO booking_events (booking_id, from_status, to_status, actor_id, note)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	args := []any{event.BookingID, event.FromStatus, event.ToStatus, event.ActorID, event.Note}
+
+	var row pgx.Row
+	if tx != nil {
+		row = tx.(pgx.Tx).QueryRow(ctx, query, args...)
+	} else {
+		row = r.db.QueryRow(ctx, query, args...)
+	}
+	if err := row.Scan(&event.ID, &event.CreatedAt); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to add booking event", logger.Error(err))
+		return fmt.Errorf("failed to add booking event: %w", err)
+	}
+	return nil
+}
+
+// GetEvents returns the booking's status history, oldest first
+func (r *bookingRepo) GetEvents(ctx context.Context, bookingID int64) ([]*models.BookingEvent, error) {
+	query := `
+		SELECT id, booking_id, from_status, to_status, actor_id, note, created_at
+		FROM booking_events
+		WHERE booking_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(ctx, query, bookingID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get booking events", logger.Error(err))
+		return nil, fmt.Errorf("failed to get booking events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.BookingEvent
+	for rows.Next() {
+		event := &models.BookingEvent{}
+		if err := rows.Scan(&event.ID, &event.BookingID, &event.FromStatus, &event.ToStatus,
+			&event.ActorID, &event.Note, &event.CreatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan booking event", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan booking event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// SetAttendance records whether a confirmed worker showed up
+func (r *bookingRepo) SetAttendance(ctx context.Context, bookingID int64, attendance models.AttendanceStatus) error {
+	query := `
+		UPDATE job_bookings
+		SET attendance = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, bookingID, toNullString(string(attendance)))
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking attendance", logger.Error(err))
+		return fmt.Errorf("failed to set booking attendance: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnarchivedReceipts retrieves approved bookings whose receipt isn't in the archive yet, oldest approval first
+func (r *bookingRepo) GetUnarchivedReceipts(ctx context.Context, limit int) ([]*models.JobBooking, error) {
+	query := `
+		SELECT id, job_id, user_id, status, payment_receipt_file_id, confirmed_at
+		FROM job_bookings
+		WHERE receipt_archive_url IS NULL
+		  AND payment_receipt_file_id IS NOT NULL
+		  AND confirmed_at IS NOT NULL
+		ORDER BY confirmed_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get unarchived receipts", logger.Error(err))
+		return nil, fmt.Errorf("failed to get unarchived receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.JobBooking
+	for rows.Next() {
+		booking := &models.JobBooking{}
+		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+			&booking.PaymentReceiptFileID, &booking.ConfirmedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan unarchived receipt", logger.Error(err))
+			continue
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
+// SetReceiptArchiveURLs records where the archived receipt files are stored, in ReceiptFileIDs order
+func (r *bookingRepo) SetReceiptArchiveURLs(ctx context.Context, bookingID int64, urls []string) error {
+	if len(urls) == 0 {
+		return errors.New("no receipt archive URLs")
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET receipt_archive_url = $2, receipt_archive_extra_urls = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, bookingID, urls[0], toNullString(joinURLs(urls[1:])))
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set receipt archive URL", logger.Error(err))
+		return fmt.Errorf("failed to set receipt archive URL: %w", err)
+	}
+
+	return nil
 }
 
-func toNullInt64(i int64) sql.NullInt64 {
-	return sql.NullInt64{Int64: i, Valid: i != 0}
+// SetStatusMessageID records the user's status message of the booking
+func (r *bookingRepo) SetStatusMessageID(ctx context.Context, bookingID int64, messageID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET status_message_id = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, bookingID, messageID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking status message", logger.Error(err))
+		return fmt.Errorf("failed to set booking status message: %w", err)
+	}
+
+	return nil
 }
 
-func toNullInt64Ptr(p *int64) sql.NullInt64 {
-	if p == nil {
-		return sql.NullInt64{Valid: false}
+// SetPaymentInstructionMsgID records the payment instruction message of the booking, leaving the status alone
+func (r *bookingRepo) SetPaymentInstructionMsgID(ctx context.Context, bookingID int64, messageID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET payment_instruction_message_id = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, bookingID, messageID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking payment instruction message", logger.Error(err))
+		return fmt.Errorf("failed to set booking payment instruction message: %w", err)
 	}
-	return sql.NullInt64{Int64: *p, Valid: true}
+
+	return nil
 }
 
-func toNullTime(t *time.Time) sql.NullTime {
-	if t == nil {
-		return sql.NullTime{Valid: false}
+// AnonymizeUserBookings clears payment receipts and message references from a user's bookings
+func (r *bookingRepo) AnonymizeUserBookings(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET payment_receipt_file_id = NULL,
+			payment_receipt_extra_file_ids = NULL,
+			payment_receipt_message_id = NULL,
+			payment_instruction_message_id = NULL,
+			status_message_id = NULL,
+			updated_at = NOW()
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to anonymize user bookings", logger.Error(err))
+		return fmt.Errorf("failed to anonymize user bookings: %w", err)
+	}
+
+	return nil
+}
+
+// joinFileIDs stores album file IDs in one column; Telegram file IDs never contain commas
+func joinFileIDs(ids []string) string {
+	return strings.Join(ids, ",")
+}
+
+func splitFileIDs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// joinURLs stores archive URLs in one column, one per line
+func joinURLs(urls []string) string {
+	return strings.Join(urls, "\n")
+}
+
+func splitURLs(s string) []string {
+	if s == "" {
+		return nil
 	}
-	return sql.NullTime{Time: *t, Valid: true}
+	return strings.Split(s, "\n")
 }
 
 // GetTotalCount returns the total number of bookings
 func (r *bookingRepo) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM job_bookings`).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM job_bookings`).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get total booking count: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get total booking count: " + err.Error())
 		return 0, fmt.Errorf("failed to get total booking count: %w", err)
 	}
 	return count, nil
@@ -687,10 +821,59 @@ func (r *bookingRepo) GetTotalCount(ctx context.Context) (int, error) {
 // GetCountByStatus returns the number of bookings with a given status
 func (r *bookingRepo) GetCountByStatus(ctx context.Context, status models.BookingStatus) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM job_bookings WHERE status = $1`, status).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM job_bookings WHERE status = $1`, status).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get booking count by status: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get booking count by status: " + err.Error())
 		return 0, fmt.Errorf("failed to get booking count by status: %w", err)
 	}
 	return count, nil
 }
+
+// GetStatsForPeriod aggregates confirmed/rejected/expired bookings in [from, to).
+// Expired bookings are dated by updated_at since MarkAsExpired has no dedicated column.
+func (r *bookingRepo) GetStatsForPeriod(ctx context.Context, from, to time.Time) (*models.BookingPeriodStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE b.status = 'CONFIRMED' AND b.confirmed_at >= $1 AND b.confirmed_at < $2),
+			COALESCE(SUM(j.service_fee) FILTER (WHERE b.status = 'CONFIRMED' AND b.confirmed_at >= $1 AND b.confirmed_at < $2), 0),
+			COUNT(*) FILTER (WHERE b.status = 'REJECTED' AND b.reviewed_at >= $1 AND b.reviewed_at < $2),
+			COUNT(*) FILTER (WHERE b.status = 'EXPIRED' AND b.updated_at >= $1 AND b.updated_at < $2)
+		FROM job_bookings b
+		JOIN jobs j ON j.id = b.job_id
+	`
+
+	stats := &models.BookingPeriodStats{}
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, query, from.UTC(), to.UTC()).Scan(
+		&stats.Confirmed,
+		&stats.Revenue,
+		&stats.Rejected,
+		&stats.Expired,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get booking stats for period", logger.Error(err))
+		return nil, fmt.Errorf("failed to get booking stats for period: %w", err)
+	}
+	return stats, nil
+}
+
+// DeleteClosedBefore deletes a batch of finished unpaid bookings; their events go by ON DELETE CASCADE
+func (r *bookingRepo) DeleteClosedBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM job_bookings
+		WHERE id IN (
+			SELECT id FROM job_bookings
+			WHERE status IN ('EXPIRED', 'REJECTED', 'CANCELLED_BY_USER')
+			  AND updated_at < $1
+			ORDER BY id
+			LIMIT $2
+		)
+	`
+
+	tag, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete closed bookings", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete closed bookings: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,131 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+)
+
+// TestCreateRepeatedIdempotencyKey repeats one booking request concurrently, as a retried tap
+// does, and expects every call to land on the same row
+func TestCreateRepeatedIdempotencyKey(t *testing.T) {
+	const attempts = 20
+	ctx := context.Background()
+
+	job := newJob(t, 1)
+	userID := newUsers(t, 2000, 1)[0]
+	expiresAt := time.Now().Add(3 * time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	ids := make(map[int64]int)
+	for range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			booking := newBooking(job.ID, userID, expiresAt)
+			if err := store.Booking().Create(ctx, nil, booking); err != nil {
+				t.Errorf("create booking: %v", err)
+				return
+			}
+			mu.Lock()
+			ids[booking.ID]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != 1 {
+		t.Fatalf("%d concurrent creates with one idempotency key returned %d booking IDs", attempts, len(ids))
+	}
+	bookings, err := store.Booking().GetJobBookings(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("get bookings: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Fatalf("%d bookings exist for one idempotency key", len(bookings))
+	}
+}
+
+// TestExpiredBookingClaims runs several expiry workers over the same expired reservations and
+// expects FOR UPDATE SKIP LOCKED to hand each booking to exactly one of them
+func TestExpiredBookingClaims(t *testing.T) {
+	const bookings, workers, batch = 20, 4, 3
+	ctx := context.Background()
+
+	job := newJob(t, bookings)
+	expired := time.Now().Add(-time.Minute)
+	for _, userID := range newUsers(t, 3000, bookings) {
+		if err := store.Booking().Create(ctx, nil, newBooking(job.ID, userID, expired)); err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claims := make(map[int64]int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				claimed, err := claimExpired(ctx, batch)
+				if err != nil {
+					t.Errorf("claim expired bookings: %v", err)
+					return
+				}
+				if len(claimed) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, id := range claimed {
+					claims[id]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claims) != bookings {
+		t.Fatalf("%d of %d expired bookings were claimed", len(claims), bookings)
+	}
+	for id, n := range claims {
+		if n != 1 {
+			t.Errorf("booking %d was claimed %d times", id, n)
+		}
+	}
+}
+
+// claimExpired is one expiry worker pass: lock a batch, expire it, commit. The pause keeps the
+// rows locked long enough for the other workers to run into them.
+func claimExpired(ctx context.Context, limit int) ([]int64, error) {
+	tx, err := store.Transaction().Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Transaction().Rollback(ctx, tx) }()
+
+	batch, err := store.Booking().GetExpiredBookings(ctx, tx, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(batch))
+	for _, booking := range batch {
+		if err := store.Booking().UpdateStatus(ctx, tx, booking.ID, models.BookingStatusExpired); err != nil {
+			return nil, err
+		}
+		ids = append(ids, booking.ID)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if err := store.Transaction().Commit(ctx, tx); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type faqRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewFAQRepo creates a new FAQ repository
+func NewFAQRepo(db *pgxpool.Pool, log logger.LoggerI) storage.FAQRepoI {
+	return &faqRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create creates a new FAQ entry
+func (r *faqRepo) Create(ctx context.Context, entry *models.FAQEntry) error {
+	query := `
+		INSERT INTO faq_entries (category, question, answer, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, entry.Category, entry.Question, entry.Answer).
+		Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to create FAQ entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an FAQ entry by ID
+func (r *faqRepo) GetByID(ctx context.Context, id int64) (*models.FAQEntry, error) {
+	query := `
+		SELECT id, category, question, answer, created_at, updated_at
+		FROM faq_entries
+		WHERE id = $1
+	`
+
+	entry := &models.FAQEntry{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&entry.ID, &entry.Category, &entry.Question, &entry.Answer, &entry.CreatedAt, &entry.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get FAQ entry", logger.Error(err))
+		return nil, fmt.Errorf("failed to get FAQ entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetAll returns every entry in creation order
+func (r *faqRepo) GetAll(ctx context.Context) ([]*models.FAQEntry, error) {
+	query := `
+		SELECT id, category, question, answer, created_at, updated_at
+		FROM faq_entries
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get FAQ entries", logger.Error(err))
+		return nil, fmt.Errorf("failed to get FAQ entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.FAQEntry
+	for rows.Next() {
+		entry := &models.FAQEntry{}
+		if err := rows.Scan(&entry.ID, &entry.Category, &entry.Question, &entry.Answer,
+			&entry.CreatedAt, &entry.UpdatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan FAQ entry", logger.Error(err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Update updates category, question and answer
+func (r *faqRepo) Update(ctx context.Context, entry *models.FAQEntry) error {
+	query := `
+		UPDATE faq_entries
+		SET category = $2, question = $3, answer = $4, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, entry.ID, entry.Category, entry.Question, entry.Answer)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to update FAQ entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an FAQ entry
+func (r *faqRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM faq_entries WHERE id = $1`, id)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to delete FAQ entry: %w", err)
+	}
+	return nil
+}
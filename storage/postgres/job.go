@@ -2,11 +2,13 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/storage"
 
@@ -15,28 +17,43 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// jobColumns is the full job column list read by scanJob
+const jobColumns = `id, order_number, salary, food, work_time, address, location, service_fee,
+	buses, additional_info, work_date, status, required_workers,
+	reserved_slots, confirmed_slots, channel_message_id, admin_message_id,
+	created_by_admin_id, employer_phone, employer_id, created_at, updated_at, version, order_day, day_number,
+	min_age, max_age, min_height, min_weight, gender, channel_location_message_id, caption_a, caption_b`
+
 type jobRepo struct {
-	db  *pgxpool.Pool
-	log logger.LoggerI
+	db   *pgxpool.Pool
+	read *pgxpool.Pool // Replica for lag-tolerant reads; the primary when none is configured
+	log  logger.LoggerI
 }
 
 // NewJobRepo creates a new job repository
-func NewJobRepo(db *pgxpool.Pool, log logger.LoggerI) storage.JobRepoI {
+func NewJobRepo(db, read *pgxpool.Pool, log logger.LoggerI) storage.JobRepoI {
 	return &jobRepo{
-		db:  db,
-		log: log,
+		db:   db,
+		read: read,
+		log:  log,
 	}
 }
 
-// Create creates a new job
+// Create creates a new job; day_number restarts at 1 for each order_day (the creation date in config.Timezone)
 func (r *jobRepo) Create(ctx context.Context, job *models.Job) (*models.Job, error) {
 	query := `
 		INSERT INTO jobs (
 			order_number, salary, food, work_time, address, location, service_fee, buses,
 			additional_info, work_date, status, required_workers, reserved_slots, 
-			confirmed_slots, channel_message_id, admin_message_id, created_by_admin_id, employer_phone
-		) VALUES (nextval('job_order_number_seq'), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
-		RETURNING id, order_number, created_at, updated_at
+			confirmed_slots, channel_message_id, admin_message_id, created_by_admin_id, employer_phone,
+			employer_id, order_day, day_number, min_age, max_age, min_height, min_weight, gender,
+			caption_a, caption_b
+		) VALUES (
+			nextval('job_order_number_seq'), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			$19, (SELECT COALESCE(MAX(day_number), 0) + 1 FROM jobs WHERE order_day = $19),
+			$20, $21, $22, $23, $24, $25, $26
+		)
+		RETURNING id, order_number, created_at, updated_at, version, order_day, day_number
 	`
 
 	err := r.db.QueryRow(ctx, query,
@@ -57,10 +74,19 @@ func (r *jobRepo) Create(ctx context.Context, job *models.Job) (*models.Job, err
 		job.AdminMessageID,
 		job.CreatedByAdminID,
 		job.EmployerPhone,
-	).Scan(&job.ID, &job.OrderNumber, &job.CreatedAt, &job.UpdatedAt)
+		toNullInt64(job.EmployerID),
+		config.NowLocal().Format(time.DateOnly),
+		job.MinAge,
+		job.MaxAge,
+		job.MinHeight,
+		job.MinWeight,
+		job.Gender,
+		job.CaptionA,
+		job.CaptionB,
+	).Scan(&job.ID, &job.OrderNumber, &job.CreatedAt, &job.UpdatedAt, &job.Version, &job.OrderDay, &job.DayNumber)
 
 	if err != nil {
-		r.log.Error("Failed to create job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to create job", logger.Error(err))
 		return nil, fmt.Errorf("failed to create job: %w", err)
 	}
 
@@ -69,113 +95,32 @@ func (r *jobRepo) Create(ctx context.Context, job *models.Job) (*models.Job, err
 
 // GetByID retrieves a job by ID
 func (r *jobRepo) GetByID(ctx context.Context, id int64) (*models.Job, error) {
-	query := `
-		SELECT id, order_number, salary, food, work_time, address, location, service_fee,
-			buses, additional_info, work_date, status, required_workers,
-			reserved_slots, confirmed_slots, channel_message_id, admin_message_id,
-			created_by_admin_id, employer_phone, created_at, updated_at
-		FROM jobs
-		WHERE id = $1
-	`
-
-	job := &models.Job{}
-	var food, buses, additionalInfo, employerPhone, location sql.NullString
-	var channelMessageID, adminMessageID sql.NullInt64
-
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&job.ID,
-		&job.OrderNumber,
-		&job.Salary,
-		&food,
-		&job.WorkTime,
-		&job.Address,
-		&location,
-		&job.ServiceFee,
-		&buses,
-		&additionalInfo,
-		&job.WorkDate,
-		&job.Status,
-		&job.RequiredWorkers,
-		&job.ReservedSlots,
-		&job.ConfirmedSlots,
-		&channelMessageID,
-		&adminMessageID,
-		&job.CreatedByAdminID,
-		&employerPhone,
-		&job.CreatedAt,
-		&job.UpdatedAt,
-	)
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
 
+	job, err := scanJob(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
 		}
-		r.log.Error("Failed to get job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to get job", logger.Error(err))
 		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
 
-	// Handle nullable fields
-	if food.Valid {
-		job.Food = food.String
-	}
-	if buses.Valid {
-		job.Buses = buses.String
-	}
-	if additionalInfo.Valid {
-		job.AdditionalInfo = additionalInfo.String
-	}
-	if location.Valid {
-		job.Location = location.String
-	}
-	if channelMessageID.Valid {
-		job.ChannelMessageID = channelMessageID.Int64
-	}
-	if adminMessageID.Valid {
-		job.AdminMessageID = adminMessageID.Int64
-	}
-	if employerPhone.Valid {
-		job.EmployerPhone = employerPhone.String
-	}
-
 	return job, nil
 }
 
 // GetByIDForUpdate retrieves a job with row lock (FOR UPDATE)
 func (r *jobRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.Job, error) {
-	query := `
-		SELECT id, order_number, salary, food, work_time, address, location, service_fee,
-			buses, additional_info, work_date, status, required_workers,
-			reserved_slots, confirmed_slots, channel_message_id, admin_message_id,
-			created_by_admin_id, employer_phone, created_at, updated_at
-		FROM jobs
-		WHERE id = $1
-		FOR UPDATE
-	`
-
-	job := &models.Job{}
-	var food, buses, additionalInfo, employerPhone, location sql.NullString
-	var channelMessageID, adminMessageID sql.NullInt64
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1 FOR UPDATE`
 
-	var err error
+	var row pgx.Row
 	if tx != nil {
-		pgxTx := tx.(pgx.Tx)
-		err = pgxTx.QueryRow(ctx, query, id).Scan(
-			&job.ID, &job.OrderNumber, &job.Salary, &food,
-			&job.WorkTime, &job.Address, &location, &job.ServiceFee, &buses,
-			&additionalInfo, &job.WorkDate, &job.Status, &job.RequiredWorkers,
-			&job.ReservedSlots, &job.ConfirmedSlots, &channelMessageID, &adminMessageID,
-			&job.CreatedByAdminID, &employerPhone, &job.CreatedAt, &job.UpdatedAt,
-		)
+		row = tx.(pgx.Tx).QueryRow(ctx, query, id)
 	} else {
-		err = r.db.QueryRow(ctx, query, id).Scan(
-			&job.ID, &job.OrderNumber, &job.Salary, &food,
-			&job.WorkTime, &job.Address, &location, &job.ServiceFee, &buses,
-			&additionalInfo, &job.WorkDate, &job.Status, &job.RequiredWorkers,
-			&job.ReservedSlots, &job.ConfirmedSlots, &channelMessageID, &adminMessageID,
-			&job.CreatedByAdminID, &employerPhone, &job.CreatedAt, &job.UpdatedAt,
-		)
+		row = r.db.QueryRow(ctx, query, id)
 	}
 
+	job, err := scanJob(row)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
@@ -183,41 +128,12 @@ func (r *jobRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*mode
 		return nil, fmt.Errorf("failed to get job for update: %w", err)
 	}
 
-	// Handle nullable fields
-	if food.Valid {
-		job.Food = food.String
-	}
-	if buses.Valid {
-		job.Buses = buses.String
-	}
-	if additionalInfo.Valid {
-		job.AdditionalInfo = additionalInfo.String
-	}
-	if location.Valid {
-		job.Location = location.String
-	}
-	if channelMessageID.Valid {
-		job.ChannelMessageID = channelMessageID.Int64
-	}
-	if adminMessageID.Valid {
-		job.AdminMessageID = adminMessageID.Int64
-	}
-	if employerPhone.Valid {
-		job.EmployerPhone = employerPhone.String
-	}
-
 	return job, nil
 }
 
 // GetAll retrieves all jobs with optional status filter
 func (r *jobRepo) GetAll(ctx context.Context, status *models.JobStatus) ([]*models.Job, error) {
-	query := `
-		SELECT id, order_number, salary, food, work_time, address, location, service_fee,
-			buses, additional_info, work_date, status, required_workers,
-			reserved_slots, confirmed_slots, channel_message_id, admin_message_id,
-			created_by_admin_id, employer_phone, created_at, updated_at
-		FROM jobs
-	`
+	query := `SELECT ` + jobColumns + ` FROM jobs`
 	args := []any{}
 
 	if status != nil {
@@ -227,72 +143,107 @@ func (r *jobRepo) GetAll(ctx context.Context, status *models.JobStatus) ([]*mode
 
 	query += " ORDER BY created_at DESC"
 
-	rows, err := r.db.Query(ctx, query, args...)
+	return r.queryJobs(ctx, reader(ctx, r.db, r.read), "failed to get all jobs", query, args...)
+}
+
+// GetAllPaginated returns one page of jobs with any of the given statuses (all when empty), newest first
+func (r *jobRepo) GetAllPaginated(ctx context.Context, statuses []models.JobStatus, limit, offset int) ([]*models.Job, error) {
+	where, args := jobStatusFilter(statuses, 3)
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	return r.queryJobs(ctx, reader(ctx, r.db, r.read), "failed to get paginated jobs", query, append([]any{limit, offset}, args...)...)
+}
+
+// jobStatusFilter builds "WHERE status IN (...)" with placeholders from $first; empty when statuses is empty
+func jobStatusFilter(statuses []models.JobStatus, first int) (string, []any) {
+	if len(statuses) == 0 {
+		return "", nil
+	}
+	placeholders := make([]string, len(statuses))
+	args := make([]any, len(statuses))
+	for i, status := range statuses {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = string(status)
+	}
+	return " WHERE status IN (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// GetByEmployerID returns an employer's most recent jobs, newest first
+func (r *jobRepo) GetByEmployerID(ctx context.Context, employerID int64, limit int) ([]*models.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE employer_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	return r.queryJobs(ctx, r.db, "failed to get employer jobs", query, employerID, limit)
+}
+
+// queryJobs runs a query selecting the full job column list on db and scans every row
+func (r *jobRepo) queryJobs(ctx context.Context, db *pgxpool.Pool, errMsg, query string, args ...any) ([]*models.Job, error) {
+	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
-		r.log.Error("Failed to get all jobs", logger.Error(err))
-		return nil, fmt.Errorf("failed to get all jobs: %w", err)
+		logger.FromContext(ctx, r.log).Error("Failed to query jobs", logger.Error(err))
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
 	}
 	defer rows.Close()
 
 	var jobs []*models.Job
 	for rows.Next() {
-		job := &models.Job{}
-		var food, buses, additionalInfo, employerPhone, location sql.NullString
-		var channelMessageID, adminMessageID sql.NullInt64
-
-		err := rows.Scan(
-			&job.ID, &job.OrderNumber, &job.Salary, &food,
-			&job.WorkTime, &job.Address, &location, &job.ServiceFee, &buses,
-			&additionalInfo, &job.WorkDate, &job.Status, &job.RequiredWorkers,
-			&job.ReservedSlots, &job.ConfirmedSlots, &channelMessageID, &adminMessageID,
-			&job.CreatedByAdminID, &employerPhone, &job.CreatedAt, &job.UpdatedAt,
-		)
+		job, err := scanJob(rows)
 		if err != nil {
-			r.log.Error("Failed to scan job", logger.Error(err))
+			logger.FromContext(ctx, r.log).Error("Failed to scan job", logger.Error(err))
 			continue
 		}
 
-		// Handle nullable fields
-		if food.Valid {
-			job.Food = food.String
-		}
-		if buses.Valid {
-			job.Buses = buses.String
-		}
-		if additionalInfo.Valid {
-			job.AdditionalInfo = additionalInfo.String
-		}
-		if location.Valid {
-			job.Location = location.String
-		}
-		if channelMessageID.Valid {
-			job.ChannelMessageID = channelMessageID.Int64
-		}
-		if adminMessageID.Valid {
-			job.AdminMessageID = adminMessageID.Int64
-		}
-		if employerPhone.Valid {
-			job.EmployerPhone = employerPhone.String
-		}
-
 		jobs = append(jobs, job)
 	}
 
 	return jobs, nil
 }
 
-// Update updates a job
+// scanJob reads a row selected with jobColumns
+func scanJob(row pgx.Row) (*models.Job, error) {
+	job := &models.Job{}
+	err := row.Scan(
+		&job.ID, &job.OrderNumber, &job.Salary, nullable(&job.Food),
+		&job.WorkTime, &job.Address, nullable(&job.Location), &job.ServiceFee, nullable(&job.Buses),
+		nullable(&job.AdditionalInfo), &job.WorkDate, &job.Status, &job.RequiredWorkers,
+		&job.ReservedSlots, &job.ConfirmedSlots, nullable(&job.ChannelMessageID), nullable(&job.AdminMessageID),
+		&job.CreatedByAdminID, nullable(&job.EmployerPhone), nullable(&job.EmployerID),
+		&job.CreatedAt, &job.UpdatedAt, &job.Version, &job.OrderDay, &job.DayNumber,
+		&job.MinAge, &job.MaxAge, &job.MinHeight, &job.MinWeight, &job.Gender, &job.ChannelLocationMessageID,
+		&job.CaptionA, &job.CaptionB,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Update updates a job if its version still matches job.Version (compare-and-swap).
+// Returns storage.ErrVersionConflict when someone else changed the job first.
 func (r *jobRepo) Update(ctx context.Context, job *models.Job) error {
 	query := `
 		UPDATE jobs
 		SET salary = $2, food = $3, work_time = $4, address = $5, location = $6, service_fee = $7,
 			buses = $8, additional_info = $9, work_date = $10, status = $11,
 			required_workers = $12, reserved_slots = $13, confirmed_slots = $14,
-			channel_message_id = $15, admin_message_id = $16, employer_phone = $17, updated_at = NOW()
-		WHERE id = $1
+			channel_message_id = $15, admin_message_id = $16, employer_phone = $17,
+			employer_id = $18, min_age = $19, max_age = $20, min_height = $21, min_weight = $22, gender = $23,
+			caption_a = $24, caption_b = $25,
+			version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND version = $26
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	result, err := r.db.Exec(ctx, query,
 		job.ID,
 		job.Salary,
 		toNullString(job.Food),
@@ -310,63 +261,134 @@ func (r *jobRepo) Update(ctx context.Context, job *models.Job) error {
 		toNullInt64(job.ChannelMessageID),
 		toNullInt64(job.AdminMessageID),
 		toNullString(job.EmployerPhone),
+		toNullInt64(job.EmployerID),
+		job.MinAge,
+		job.MaxAge,
+		job.MinHeight,
+		job.MinWeight,
+		job.Gender,
+		job.CaptionA,
+		job.CaptionB,
+		job.Version,
 	)
 
 	if err != nil {
-		r.log.Error("Failed to update job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to update job", logger.Error(err))
 		return fmt.Errorf("failed to update job: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, job.ID); err != nil {
+			return err
+		}
+		return storage.ErrVersionConflict
+	}
+
+	job.Version++
 	return nil
 }
 
-// UpdateStatus updates only the job status
+// UpdateStatus updates only the job status; see UpdateStatusInTx
 func (r *jobRepo) UpdateStatus(ctx context.Context, id int64, status models.JobStatus) error {
-	query := `UPDATE jobs SET status = $2, updated_at = NOW() WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id, status)
-	if err != nil {
-		r.log.Error("Failed to update job status", logger.Error(err))
-		return fmt.Errorf("failed to update job status: %w", err)
-	}
-	return nil
+	return r.UpdateStatusInTx(ctx, nil, id, status)
 }
 
-// UpdateStatusInTx updates only the job status within a transaction
+// UpdateStatusInTx updates only the job status within a transaction. The status is only written
+// when the stored job may move to it, so two admins, or an admin and a worker, changing the job at
+// once can't make a move the transition graph forbids; *storage.JobTransitionError otherwise.
 func (r *jobRepo) UpdateStatusInTx(ctx context.Context, tx any, id int64, status models.JobStatus) error {
-	query := `UPDATE jobs SET status = $2, updated_at = NOW() WHERE id = $1`
+	query := `UPDATE jobs SET status = $2, version = version + 1, updated_at = NOW() WHERE id = $1 AND ` + jobTransitionGuard(status)
+	var sources []string
+	for _, from := range models.JobStatusSources(status) {
+		sources = append(sources, string(from))
+	}
 
+	var result pgconn.CommandTag
 	var err error
+	var current models.JobStatus
 	if tx != nil {
 		pgxTx := tx.(pgx.Tx)
-		_, err = pgxTx.Exec(ctx, query, id, status)
+		result, err = pgxTx.Exec(ctx, query, id, status, sources)
+		if err == nil && result.RowsAffected() == 0 {
+			err = pgxTx.QueryRow(ctx, `SELECT status FROM jobs WHERE id = $1`, id).Scan(&current)
+		}
 	} else {
-		_, err = r.db.Exec(ctx, query, id, status)
+		result, err = r.db.Exec(ctx, query, id, status, sources)
+		if err == nil && result.RowsAffected() == 0 {
+			err = r.db.QueryRow(ctx, `SELECT status FROM jobs WHERE id = $1`, id).Scan(&current)
+		}
 	}
 
 	if err != nil {
-		r.log.Error("Failed to update job status in transaction", logger.Error(err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to update job status", logger.Error(err))
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		return &storage.JobTransitionError{JobID: id, From: current, To: status}
+	}
 	return nil
 }
 
+// jobTransitionGuard is the WHERE condition letting a job move to next, the SQL form of
+// models.Job.CanTransitionTo: a status the transition graph allows it from ($3), plus the slot checks
+func jobTransitionGuard(next models.JobStatus) string {
+	guard := `status = ANY($3)`
+	switch next {
+	case models.JobStatusDraft:
+		guard += ` AND COALESCE(channel_message_id, 0) = 0 AND reserved_slots = 0 AND confirmed_slots = 0`
+	case models.JobStatusActive:
+		guard += ` AND confirmed_slots < required_workers`
+	}
+	return guard
+}
+
 // UpdateChannelMessageID updates the channel message ID for a job
 func (r *jobRepo) UpdateChannelMessageID(ctx context.Context, id int64, messageID int64) error {
 	query := `UPDATE jobs SET channel_message_id = $2, updated_at = NOW() WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, id, messageID)
 	if err != nil {
-		r.log.Error("Failed to update channel message ID", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to update channel message ID", logger.Error(err))
 		return fmt.Errorf("failed to update channel message ID: %w", err)
 	}
 	return nil
 }
 
+// UpdateChannelLocationMessageID stores the ID of the location reply to the job's channel post (0 clears it)
+func (r *jobRepo) UpdateChannelLocationMessageID(ctx context.Context, id int64, messageID int64) error {
+	query := `UPDATE jobs SET channel_location_message_id = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id, messageID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update channel location message ID", logger.Error(err))
+		return fmt.Errorf("failed to update channel location message ID: %w", err)
+	}
+	return nil
+}
+
+// GetByChannelMessageID retrieves the job published as the given channel message
+func (r *jobRepo) GetByChannelMessageID(ctx context.Context, messageID int64) (*models.Job, error) {
+	query := `SELECT id FROM jobs WHERE channel_message_id = $1 ORDER BY id DESC LIMIT 1`
+
+	var id int64
+	if err := r.db.QueryRow(ctx, query, messageID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get job by channel message ID", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job by channel message ID: %w", err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
 // UpdateAdminMessageID updates the admin message ID for a job
 func (r *jobRepo) UpdateAdminMessageID(ctx context.Context, id int64, messageID int64) error {
 	query := `UPDATE jobs SET admin_message_id = $2, updated_at = NOW() WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, id, messageID)
 	if err != nil {
-		r.log.Error("Failed to update admin message ID", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to update admin message ID", logger.Error(err))
 		return fmt.Errorf("failed to update admin message ID: %w", err)
 	}
 	return nil
@@ -377,7 +399,7 @@ func (r *jobRepo) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM jobs WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, id)
 	if err != nil {
-		r.log.Error("Failed to delete job", logger.Error(err))
+		logger.FromContext(ctx, r.log).Error("Failed to delete job", logger.Error(err))
 		return fmt.Errorf("failed to delete job: %w", err)
 	}
 	return nil
@@ -487,12 +509,99 @@ func (r *jobRepo) GetAvailableSlots(ctx context.Context, jobID int64) (int, erro
 	return available, nil
 }
 
+// slotCounts counts the bookings that hold a reserved or confirmed slot, per job
+const slotCounts = `
+	SELECT job_id,
+		COUNT(*) FILTER (WHERE status IN ('SLOT_RESERVED', 'PAYMENT_SUBMITTED', 'PAYMENT_REJECTED_RETRYABLE')) AS reserved,
+		COUNT(*) FILTER (WHERE status = 'CONFIRMED') AS confirmed
+	FROM job_bookings
+	GROUP BY job_id
+`
+
+// GetSlotDrifts returns jobs whose reserved/confirmed counters disagree with their bookings
+func (r *jobRepo) GetSlotDrifts(ctx context.Context) ([]*models.SlotDrift, error) {
+	query := `
+		SELECT j.id, j.reserved_slots, j.confirmed_slots,
+			COALESCE(c.reserved, 0), COALESCE(c.confirmed, 0)
+		FROM jobs j
+		LEFT JOIN (` + slotCounts + `) c ON c.job_id = j.id
+		WHERE j.reserved_slots <> COALESCE(c.reserved, 0)
+		   OR j.confirmed_slots <> COALESCE(c.confirmed, 0)
+		ORDER BY j.id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get slot drifts", logger.Error(err))
+		return nil, fmt.Errorf("failed to get slot drifts: %w", err)
+	}
+	defer rows.Close()
+
+	var drifts []*models.SlotDrift
+	for rows.Next() {
+		var d models.SlotDrift
+		if err := rows.Scan(&d.JobID, &d.Reserved, &d.Confirmed, &d.ActualReserved, &d.ActualConfirmed); err != nil {
+			return nil, fmt.Errorf("failed to scan slot drift: %w", err)
+		}
+		drifts = append(drifts, &d)
+	}
+
+	return drifts, rows.Err()
+}
+
+// RecountSlots locks the job, recounts its slots from its bookings and stores the result.
+// The job row lock makes concurrent reservations wait, so the recount can't miss one.
+func (r *jobRepo) RecountSlots(ctx context.Context, tx any, jobID int64) (*models.SlotDrift, error) {
+	pgxTx, ok := tx.(pgx.Tx)
+	if !ok {
+		return nil, fmt.Errorf("invalid transaction type")
+	}
+
+	d := &models.SlotDrift{JobID: jobID}
+	err := pgxTx.QueryRow(ctx, `
+		SELECT reserved_slots, confirmed_slots FROM jobs WHERE id = $1 FOR UPDATE
+	`, jobID).Scan(&d.Reserved, &d.Confirmed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock job for recount: %w", err)
+	}
+
+	err = pgxTx.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status IN ('SLOT_RESERVED', 'PAYMENT_SUBMITTED', 'PAYMENT_REJECTED_RETRYABLE')),
+			COUNT(*) FILTER (WHERE status = 'CONFIRMED')
+		FROM job_bookings
+		WHERE job_id = $1
+	`, jobID).Scan(&d.ActualReserved, &d.ActualConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job bookings: %w", err)
+	}
+
+	if d.Size() == 0 {
+		return d, nil
+	}
+
+	_, err = pgxTx.Exec(ctx, `
+		UPDATE jobs
+		SET reserved_slots = $2, confirmed_slots = $3, updated_at = NOW()
+		WHERE id = $1
+	`, jobID, d.ActualReserved, d.ActualConfirmed)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to store recounted slots", logger.Error(err))
+		return nil, fmt.Errorf("failed to store recounted slots: %w", err)
+	}
+
+	return d, nil
+}
+
 // GetTotalCount returns the total number of jobs
 func (r *jobRepo) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs`).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM jobs`).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get total job count: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get total job count: " + err.Error())
 		return 0, fmt.Errorf("failed to get total job count: %w", err)
 	}
 	return count, nil
@@ -501,10 +610,179 @@ func (r *jobRepo) GetTotalCount(ctx context.Context) (int, error) {
 // GetCountByStatus returns the number of jobs with a given status
 func (r *jobRepo) GetCountByStatus(ctx context.Context, status models.JobStatus) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE status = $1`, status).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE status = $1`, status).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get job count by status: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get job count by status: " + err.Error())
 		return 0, fmt.Errorf("failed to get job count by status: %w", err)
 	}
 	return count, nil
 }
+
+// GetCountByStatuses returns the number of jobs with any of the given statuses (all jobs when empty)
+func (r *jobRepo) GetCountByStatuses(ctx context.Context, statuses []models.JobStatus) (int, error) {
+	where, args := jobStatusFilter(statuses, 1)
+	var count int
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM jobs`+where, args...).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get job count by statuses: " + err.Error())
+		return 0, fmt.Errorf("failed to get job count by statuses: %w", err)
+	}
+	return count, nil
+}
+
+// RecordLinkStart logs a /start job_<id> deep-link open with its caption variant; unknown jobs are ignored
+func (r *jobRepo) RecordLinkStart(ctx context.Context, jobID, userID int64, variant models.CaptionVariant) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_link_starts (job_id, user_id, variant)
+		SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM jobs WHERE id = $1)
+	`, jobID, userID, variant)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to record job link start", logger.Error(err))
+		return fmt.Errorf("failed to record job link start: %w", err)
+	}
+	return nil
+}
+
+// GetFunnel counts a job's deep-link opens, bookings, payments and attendance
+func (r *jobRepo) GetFunnel(ctx context.Context, jobID int64) (*models.JobFunnel, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM job_link_starts WHERE job_id = $1),
+			(SELECT COUNT(DISTINCT user_id) FROM job_link_starts WHERE job_id = $1),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE payment_submitted_at IS NOT NULL),
+			COUNT(*) FILTER (WHERE confirmed_at IS NOT NULL),
+			COUNT(*) FILTER (WHERE attendance = 'ATTENDED'),
+			COUNT(*) FILTER (WHERE attendance = 'NO_SHOW')
+		FROM job_bookings
+		WHERE job_id = $1
+	`
+
+	f := &models.JobFunnel{}
+	err := r.db.QueryRow(ctx, query, jobID).Scan(
+		&f.LinkOpens,
+		&f.LinkUsers,
+		&f.Bookings,
+		&f.PaymentsSubmitted,
+		&f.Approved,
+		&f.Attended,
+		&f.NoShows,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get job funnel", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job funnel: %w", err)
+	}
+
+	if f.Captions, err = r.getCaptionStats(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// getCaptionStats counts the deep-link starts per caption variant, crediting each user to the
+// variant of the first link they opened
+func (r *jobRepo) getCaptionStats(ctx context.Context, jobID int64) ([]*models.JobCaptionStats, error) {
+	query := `
+		WITH first_starts AS (
+			SELECT DISTINCT ON (user_id) user_id, variant
+			FROM job_link_starts
+			WHERE job_id = $1 AND variant <> ''
+			ORDER BY user_id, id
+		)
+		SELECT
+			f.variant,
+			(SELECT COUNT(*) FROM job_link_starts s WHERE s.job_id = $1 AND s.variant = f.variant),
+			COUNT(DISTINCT f.user_id),
+			COUNT(DISTINCT b.user_id),
+			COUNT(DISTINCT b.user_id) FILTER (WHERE b.confirmed_at IS NOT NULL)
+		FROM first_starts f
+		LEFT JOIN job_bookings b ON b.job_id = $1 AND b.user_id = f.user_id
+		GROUP BY f.variant
+		ORDER BY f.variant
+	`
+
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get job caption stats", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job caption stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*models.JobCaptionStats
+	for rows.Next() {
+		s := &models.JobCaptionStats{}
+		if err := rows.Scan(&s.Variant, &s.LinkOpens, &s.LinkUsers, &s.Bookings, &s.Approved); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan job caption stats", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan job caption stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// AddBump records a repost of the job to the channel
+func (r *jobRepo) AddBump(ctx context.Context, bump *models.JobBump) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO job_bumps (job_id, message_id)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`, bump.JobID, bump.MessageID).Scan(&bump.ID, &bump.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to add job bump", logger.Error(err))
+		return fmt.Errorf("failed to add job bump: %w", err)
+	}
+	return nil
+}
+
+// GetBumps returns the job's reposts, oldest first
+func (r *jobRepo) GetBumps(ctx context.Context, jobID int64) ([]*models.JobBump, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, job_id, message_id, created_at
+		FROM job_bumps
+		WHERE job_id = $1
+		ORDER BY id
+	`, jobID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get job bumps", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job bumps: %w", err)
+	}
+	defer rows.Close()
+
+	var bumps []*models.JobBump
+	for rows.Next() {
+		bump := &models.JobBump{}
+		if err := rows.Scan(&bump.ID, &bump.JobID, &bump.MessageID, &bump.CreatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan job bump", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan job bump: %w", err)
+		}
+		bumps = append(bumps, bump)
+	}
+	return bumps, rows.Err()
+}
+
+// WaiveRequirements lets the user book the job even though their profile misses its requirements
+func (r *jobRepo) WaiveRequirements(ctx context.Context, jobID, userID, adminID int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_requirement_waivers (job_id, user_id, waived_by_admin_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_id, user_id) DO NOTHING
+	`, jobID, userID, adminID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to waive job requirements", logger.Error(err))
+		return fmt.Errorf("failed to waive job requirements: %w", err)
+	}
+	return nil
+}
+
+// IsRequirementWaived reports whether an admin waived the job's requirements for the user
+func (r *jobRepo) IsRequirementWaived(ctx context.Context, jobID, userID int64) (bool, error) {
+	var waived bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM job_requirement_waivers WHERE job_id = $1 AND user_id = $2)
+	`, jobID, userID).Scan(&waived)
+	if err != nil {
+		return false, fmt.Errorf("failed to check requirement waiver: %w", err)
+	}
+	return waived, nil
+}
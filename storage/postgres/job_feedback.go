@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type jobFeedbackRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewJobFeedbackRepo creates a new worker feedback repository
+func NewJobFeedbackRepo(db *pgxpool.Pool, log logger.LoggerI) storage.JobFeedbackRepoI {
+	return &jobFeedbackRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetBookingsAwaitingPrompt returns confirmed bookings without a feedback row
+func (r *jobFeedbackRepo) GetBookingsAwaitingPrompt(ctx context.Context, since time.Time, limit int) ([]*models.JobBooking, error) {
+	query := `
+		SELECT b.id, b.job_id, b.user_id
+		FROM job_bookings b
+		LEFT JOIN job_feedback f ON f.booking_id = b.id
+		WHERE b.status = 'CONFIRMED'
+		  AND b.confirmed_at >= $1
+		  AND (b.attendance IS NULL OR b.attendance <> 'NO_SHOW')
+		  AND f.id IS NULL
+		ORDER BY b.confirmed_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, since.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get bookings awaiting feedback", logger.Error(err))
+		return nil, fmt.Errorf("failed to get bookings awaiting feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.JobBooking
+	for rows.Next() {
+		booking := &models.JobBooking{Status: models.BookingStatusConfirmed}
+		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan booking", logger.Error(err))
+			continue
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
+// Create records that the feedback prompt was sent
+func (r *jobFeedbackRepo) Create(ctx context.Context, feedback *models.JobFeedback) error {
+	query := `
+		INSERT INTO job_feedback (booking_id, job_id, user_id, employer_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		feedback.BookingID,
+		feedback.JobID,
+		feedback.UserID,
+		toNullInt64(feedback.EmployerID),
+	).Scan(&feedback.ID, &feedback.CreatedAt, &feedback.UpdatedAt)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create job feedback", logger.Error(err))
+		return fmt.Errorf("failed to create job feedback: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBookingID retrieves the feedback for a booking
+func (r *jobFeedbackRepo) GetByBookingID(ctx context.Context, bookingID int64) (*models.JobFeedback, error) {
+	query := `
+		SELECT id, booking_id, job_id, user_id, employer_id, pay_correct, conditions_ok, created_at, updated_at
+		FROM job_feedback
+		WHERE booking_id = $1
+	`
+
+	feedback := &models.JobFeedback{}
+	err := r.db.QueryRow(ctx, query, bookingID).Scan(
+		&feedback.ID,
+		&feedback.BookingID,
+		&feedback.JobID,
+		&feedback.UserID,
+		nullable(&feedback.EmployerID),
+		&feedback.PayCorrect,
+		&feedback.ConditionsOK,
+		&feedback.CreatedAt,
+		&feedback.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get job feedback", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job feedback: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// UpdateAnswers saves the worker's answers
+func (r *jobFeedbackRepo) UpdateAnswers(ctx context.Context, feedback *models.JobFeedback) error {
+	query := `
+		UPDATE job_feedback
+		SET pay_correct = $2, conditions_ok = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, feedback.ID, toNullBool(feedback.PayCorrect), toNullBool(feedback.ConditionsOK))
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update job feedback", logger.Error(err))
+		return fmt.Errorf("failed to update job feedback: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmployerSummary aggregates answered feedback for an employer
+func (r *jobFeedbackRepo) GetEmployerSummary(ctx context.Context, employerID int64) (*models.FeedbackSummary, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE pay_correct IS NOT NULL OR conditions_ok IS NOT NULL),
+			COUNT(*) FILTER (WHERE pay_correct = FALSE),
+			COUNT(*) FILTER (WHERE conditions_ok = FALSE)
+		FROM job_feedback
+		WHERE employer_id = $1
+	`
+
+	summary := &models.FeedbackSummary{}
+	err := r.db.QueryRow(ctx, query, employerID).Scan(
+		&summary.Responses,
+		&summary.PayIssues,
+		&summary.ConditionIssues,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get employer feedback summary", logger.Error(err))
+		return nil, fmt.Errorf("failed to get employer feedback summary: %w", err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,97 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"telegram-bot-starter/storage"
+)
+
+// TestIncrementReservedSlotsNeverOversells books one job from many goroutines at once, the way
+// simultaneous "Band qilish" taps do, and then releases more slots than were taken
+func TestIncrementReservedSlotsNeverOversells(t *testing.T) {
+	const slots, attempts = 5, 50
+	ctx := context.Background()
+
+	job := newJob(t, slots)
+	userIDs := newUsers(t, 1000, attempts)
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for _, userID := range userIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := reserve(ctx, job.ID, userID)
+			if err != nil {
+				t.Errorf("reserve for user %d: %v", userID, err)
+			} else if ok {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := int(reserved.Load()); got != slots {
+		t.Fatalf("%d of %d reservations succeeded for %d slots", got, attempts, slots)
+	}
+	expectReservedSlots(t, job.ID, slots)
+	bookings, err := store.Booking().GetJobBookings(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("get bookings: %v", err)
+	}
+	if len(bookings) != slots {
+		t.Fatalf("%d bookings were created for %d slots", len(bookings), slots)
+	}
+
+	// Releasing twice as many slots as were taken must stop at zero
+	for range 2 * slots {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := store.Job().DecrementReservedSlots(ctx, nil, job.ID); err != nil {
+				t.Errorf("release slot: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	expectReservedSlots(t, job.ID, 0)
+}
+
+// reserve takes a slot and creates the booking in one transaction like the booking service does;
+// it reports false when the job is already full
+func reserve(ctx context.Context, jobID, userID int64) (bool, error) {
+	tx, err := store.Transaction().Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = store.Transaction().Rollback(ctx, tx) }()
+
+	if err := store.Job().IncrementReservedSlots(ctx, tx, jobID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := store.Booking().Create(ctx, tx, newBooking(jobID, userID, time.Now().Add(3*time.Minute))); err != nil {
+		return false, err
+	}
+	return true, store.Transaction().Commit(ctx, tx)
+}
+
+func expectReservedSlots(t *testing.T, jobID int64, want int) {
+	t.Helper()
+	job, err := store.Job().GetByID(context.Background(), jobID)
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	if job.ReservedSlots != want {
+		t.Fatalf("job has reserved_slots = %d, want %d", job.ReservedSlots, want)
+	}
+}
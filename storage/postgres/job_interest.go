@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type jobInterestRepo struct {
+	db   *pgxpool.Pool
+	read *pgxpool.Pool // Replica for lag-tolerant reads; the primary when none is configured
+	log  logger.LoggerI
+}
+
+// NewJobInterestRepo creates a new job interest repository
+func NewJobInterestRepo(db, read *pgxpool.Pool, log logger.LoggerI) storage.JobInterestRepoI {
+	return &jobInterestRepo{
+		db:   db,
+		read: read,
+		log:  log,
+	}
+}
+
+// Record stores that the user opened the job's signup link; repeat opens keep the first row
+func (r *jobInterestRepo) Record(ctx context.Context, jobID, userID int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_interest (job_id, user_id)
+		SELECT $1, $2 WHERE EXISTS (SELECT 1 FROM jobs WHERE id = $1)
+		ON CONFLICT (job_id, user_id) DO NOTHING
+	`, jobID, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to record job interest", logger.Error(err))
+		return fmt.Errorf("failed to record job interest: %w", err)
+	}
+	return nil
+}
+
+// GetDueNudges returns pending interests in [since, before) of users who aren't registered, oldest first
+func (r *jobInterestRepo) GetDueNudges(ctx context.Context, since, before time.Time, limit int) ([]*models.JobInterest, error) {
+	query := `
+		SELECT i.id, i.job_id, i.user_id, i.created_at
+		FROM job_interest i
+		WHERE i.nudged_at IS NULL
+		  AND i.created_at >= $1
+		  AND i.created_at < $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM registered_users r WHERE r.user_id = i.user_id AND r.is_active
+		  )
+		ORDER BY i.created_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, since.UTC(), before.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get due job interest nudges", logger.Error(err))
+		return nil, fmt.Errorf("failed to get due job interest nudges: %w", err)
+	}
+	defer rows.Close()
+
+	var due []*models.JobInterest
+	for rows.Next() {
+		var i models.JobInterest
+		if err := rows.Scan(&i.ID, &i.JobID, &i.UserID, &i.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job interest: %w", err)
+		}
+		due = append(due, &i)
+	}
+
+	return due, rows.Err()
+}
+
+// MarkNudged marks every pending interest of the user as reminded
+func (r *jobInterestRepo) MarkNudged(ctx context.Context, userID int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE job_interest SET nudged_at = NOW()
+		WHERE user_id = $1 AND nudged_at IS NULL
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to mark job interest nudged", logger.Error(err))
+		return fmt.Errorf("failed to mark job interest nudged: %w", err)
+	}
+	return nil
+}
+
+// GetUnregisteredCounts returns jobs with interested users who still aren't registered, newest job first
+func (r *jobInterestRepo) GetUnregisteredCounts(ctx context.Context, limit int) ([]*models.JobInterestCount, error) {
+	query := `
+		SELECT i.job_id, COUNT(*), COUNT(i.nudged_at)
+		FROM job_interest i
+		WHERE NOT EXISTS (
+			SELECT 1 FROM registered_users r WHERE r.user_id = i.user_id AND r.is_active
+		)
+		GROUP BY i.job_id
+		ORDER BY i.job_id DESC
+		LIMIT $1
+	`
+
+	rows, err := reader(ctx, r.db, r.read).Query(ctx, query, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get job interest counts", logger.Error(err))
+		return nil, fmt.Errorf("failed to get job interest counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*models.JobInterestCount
+	for rows.Next() {
+		var c models.JobInterestCount
+		if err := rows.Scan(&c.JobID, &c.Unregistered, &c.Nudged); err != nil {
+			return nil, fmt.Errorf("failed to scan job interest count: %w", err)
+		}
+		counts = append(counts, &c)
+	}
+
+	return counts, rows.Err()
+}
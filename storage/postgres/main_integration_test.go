@@ -0,0 +1,165 @@
+//go:build integration
+
+// The integration tests run the concurrency-critical storage paths against a real PostgreSQL:
+// slot race safety, idempotent booking creation and the expiry claim depend on SQL that only a
+// live database can exercise. TestMain starts a throwaway postgres:15-alpine container with
+// dockertest, so they need Docker: go test -tags integration ./storage/postgres/
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+	"telegram-bot-starter/storage/postgres"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+// adminID creates every test job
+const adminID = 1
+
+// store is the storage under test, migrated in a scratch database of the container
+var store storage.StorageI
+
+func TestMain(m *testing.M) {
+	os.Exit(runIntegration(m))
+}
+
+// runIntegration starts the container, migrates a scratch database, runs the tests and cleans up
+func runIntegration(m *testing.M) int {
+	ctx := context.Background()
+	log := logger.NewLogger("integration", "error")
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to connect to Docker:", err)
+		return 1
+	}
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "15-alpine",
+		Env:        []string{"POSTGRES_PASSWORD=integration"},
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to start Postgres:", err)
+		return 1
+	}
+	defer func() {
+		if err := pool.Purge(resource); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to remove Postgres container:", err)
+		}
+	}()
+	// Docker removes the container even if the test binary is killed
+	_ = resource.Expire(600)
+
+	host, portStr, err := net.SplitHostPort(resource.GetHostPort("5432/tcp"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to read Postgres port:", err)
+		return 1
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Driver:            config.DriverPostgres,
+		Host:              host,
+		Port:              port,
+		User:              "postgres",
+		Password:          "integration",
+		DBName:            "postgres",
+		MaxConnections:    20,
+		HealthCheckPeriod: time.Minute,
+		StatementTimeout:  30 * time.Second,
+		LockTimeout:       10 * time.Second,
+		ConnectWait:       time.Minute,
+	}}
+	scratch := *cfg
+	scratch.Database.DBName = "ishchi_integration"
+
+	// CreateDatabase waits for the freshly started server to accept connections
+	if err := postgres.CreateDatabase(ctx, cfg, scratch.Database.DBName, log); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create scratch database:", err)
+		return 1
+	}
+	defer func() {
+		if err := postgres.DropDatabase(ctx, cfg, scratch.Database.DBName); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to drop scratch database:", err)
+		}
+	}()
+
+	// NewPostgres runs the migrations from ./migrations, relative to the repository root
+	if err := os.Chdir("../.."); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to change to the repository root:", err)
+		return 1
+	}
+	store, err = postgres.NewPostgres(ctx, &scratch, log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to initialize storage:", err)
+		return 1
+	}
+	defer store.CloseDB()
+
+	if err := store.User().Create(ctx, models.NewUser(adminID, "", "Admin", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create admin:", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// newUsers creates count users with IDs from first on; each test uses its own range
+func newUsers(t *testing.T, first int64, count int) []int64 {
+	t.Helper()
+	ids := make([]int64, count)
+	for i := range ids {
+		ids[i] = first + int64(i)
+		user := models.NewUser(ids[i], "", fmt.Sprintf("Integration %d", ids[i]), "")
+		if err := store.User().Create(context.Background(), user); err != nil {
+			t.Fatalf("create user %d: %v", ids[i], err)
+		}
+	}
+	return ids
+}
+
+// newJob creates an active job with the given number of slots
+func newJob(t *testing.T, slots int) *models.Job {
+	t.Helper()
+	job, err := store.Job().Create(context.Background(), &models.Job{
+		Salary:           "100 000",
+		WorkTime:         "09:00-18:00",
+		Address:          "Integration test",
+		ServiceFee:       10000,
+		WorkDate:         time.Now().Format("02.01.2006"),
+		Status:           models.JobStatusActive,
+		RequiredWorkers:  slots,
+		CreatedByAdminID: adminID,
+	})
+	if err != nil {
+		t.Fatalf("create job: %v", err)
+	}
+	return job
+}
+
+// newBooking returns an unsaved reservation whose idempotency key is fixed per job and user
+func newBooking(jobID, userID int64, expiresAt time.Time) *models.JobBooking {
+	return &models.JobBooking{
+		JobID:          jobID,
+		UserID:         userID,
+		Status:         models.BookingStatusSlotReserved,
+		ReservedAt:     time.Now(),
+		ExpiresAt:      expiresAt,
+		IdempotencyKey: fmt.Sprintf("integration:%d:%d", jobID, userID),
+	}
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// nullable returns a scan target for a column that may be NULL: the value goes to *dst and NULL
+// leaves the zero value. It replaces a sql.Null* variable plus an "if x.Valid" copy per column.
+//
+// T is the database/sql form of the column or a named type over it: string for text, int64 for
+// every integer type, time.Time for timestamps, bool for booleans. Pointer fields such as
+// *time.Time need no helper: pgx scans NULL into them as nil.
+func nullable[T any](dst *T) sql.Scanner {
+	return nullableScanner[T]{dst: dst}
+}
+
+type nullableScanner[T any] struct {
+	dst *T
+}
+
+func (s nullableScanner[T]) Scan(src any) error {
+	if src == nil {
+		var zero T
+		*s.dst = zero
+		return nil
+	}
+	if v, ok := src.(T); ok {
+		*s.dst = v
+		return nil
+	}
+	// Named types such as models.AttendanceStatus take their underlying value
+	rv, t := reflect.ValueOf(src), reflect.TypeFor[T]()
+	if rv.Kind() != t.Kind() {
+		return fmt.Errorf("cannot scan %T into %s", src, t)
+	}
+	*s.dst = rv.Convert(t).Interface().(T)
+	return nil
+}
+
+// toNullString and the other toNull* helpers store zero values (or nil pointers) as NULL
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func toNullInt64Ptr(p *int64) sql.NullInt64 {
+	if p == nil {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func toNullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{Valid: false}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/fieldcrypt"
 	"telegram-bot-starter/pkg/logger"
 	"telegram-bot-starter/storage"
 
@@ -19,12 +21,22 @@ import (
 // Store implements the storage.StorageI interface
 type Store struct {
 	db     *pgxpool.Pool
+	read   *pgxpool.Pool // DB_READ_DSN replica; db itself when no replica is configured
 	logger logger.LoggerI
+	tracer *queryTracer       // nil when slow query logging and summaries are both off
+	crypt  *fieldcrypt.Cipher // DB_ENCRYPTION_KEY cipher for personal data; nil when not configured
 }
 
 // NewPostgres creates a new PostgreSQL storage instance
 func NewPostgres(ctx context.Context, cfg *config.Config, log logger.LoggerI) (storage.StorageI, error) {
 	dsn := cfg.Database.DSN()
+
+	crypt, err := cfg.Database.Cipher()
+	if err != nil {
+		log.Error("Error while loading encryption key: " + err.Error())
+		return nil, err
+	}
+
 	log.Info("Connecting to database")
 
 	parseConfig, err := pgxpool.ParseConfig(dsn)
@@ -40,32 +52,33 @@ func NewPostgres(ctx context.Context, cfg *config.Config, log logger.LoggerI) (s
 	}
 
 	parseConfig.MaxConns = int32(maxConns)
-	parseConfig.MinConns = int32(maxConns / 3)      // Keep 1/3 as minimum for quick response
-	parseConfig.MaxConnLifetime = 2 * time.Hour     // Longer lifetime for stability
-	parseConfig.MaxConnIdleTime = 30 * time.Minute  // Allow longer idle time
-	parseConfig.HealthCheckPeriod = 1 * time.Minute // Regular health checks
+	parseConfig.MinConns = int32(min(cfg.Database.MinConnections, maxConns)) // Kept open for quick response
+	parseConfig.MaxConnLifetime = 2 * time.Hour                              // Longer lifetime for stability
+	parseConfig.MaxConnIdleTime = 30 * time.Minute                           // Allow longer idle time
+	parseConfig.HealthCheckPeriod = cfg.Database.HealthCheckPeriod
 
 	// Connection-level timeouts for reliability
 	parseConfig.ConnConfig.ConnectTimeout = 10 * time.Second
 
+	// Log slow statements and per-repository latencies
+	var tracer *queryTracer
+	if cfg.Database.SlowQuery > 0 || cfg.Database.QueryStatsInterval > 0 {
+		tracer = newQueryTracer(log, cfg.Database.SlowQuery, cfg.Database.QueryStatsInterval)
+		parseConfig.ConnConfig.Tracer = tracer
+	}
+
 	// Set statement_timeout and lock_timeout at the connection level.
 	// Without these, a stuck query or lock wait can block a connection forever,
 	// eventually exhausting the pool and hanging the entire bot.
+	timeouts := fmt.Sprintf("SET statement_timeout = %d; SET lock_timeout = %d;",
+		cfg.Database.StatementTimeout.Milliseconds(), cfg.Database.LockTimeout.Milliseconds())
 	parseConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
-		_, err := conn.Exec(ctx, "SET statement_timeout = '30s'; SET lock_timeout = '10s';")
+		_, err := conn.Exec(ctx, timeouts)
 		return err
 	}
 
-	pool, err := pgxpool.NewWithConfig(ctx, parseConfig)
+	pool, err := connect(ctx, parseConfig, cfg.Database.ConnectWait, log)
 	if err != nil {
-		log.Error("Error while creating pool: " + err.Error())
-		return nil, err
-	}
-
-	// Verify connection
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		log.Error("Failed to ping database: " + err.Error())
 		return nil, err
 	}
 
@@ -103,35 +116,118 @@ func NewPostgres(ctx context.Context, cfg *config.Config, log logger.LoggerI) (s
 		log.Info("Migrations applied successfully")
 	}
 
+	// Lag-tolerant reads (lists, counts, statistics) go to the replica. Migrations only run on the
+	// primary; the replica receives them through replication.
+	read := pool
+	if cfg.Database.ReadDSN != "" {
+		replicaConfig, err := pgxpool.ParseConfig(cfg.Database.ReadDSN)
+		if err != nil {
+			log.Error("Error while parsing DB_READ_DSN: " + err.Error())
+			pool.Close()
+			return nil, err
+		}
+		replicaConfig.MaxConns = parseConfig.MaxConns
+		replicaConfig.MinConns = parseConfig.MinConns
+		replicaConfig.MaxConnLifetime = parseConfig.MaxConnLifetime
+		replicaConfig.MaxConnIdleTime = parseConfig.MaxConnIdleTime
+		replicaConfig.HealthCheckPeriod = parseConfig.HealthCheckPeriod
+		replicaConfig.ConnConfig.ConnectTimeout = parseConfig.ConnConfig.ConnectTimeout
+		replicaConfig.ConnConfig.Tracer = parseConfig.ConnConfig.Tracer
+		replicaConfig.AfterConnect = parseConfig.AfterConnect
+
+		if read, err = connect(ctx, replicaConfig, cfg.Database.ConnectWait, log); err != nil {
+			pool.Close()
+			return nil, err
+		}
+		log.Info("Postgres read replica connection established")
+	}
+
+	if tracer != nil {
+		tracer.Start()
+	}
+
 	return &Store{
 		db:     pool,
+		read:   read,
 		logger: log,
+		tracer: tracer,
+		crypt:  crypt,
 	}, nil
 }
 
+// reader returns the pool for a lag-tolerant read: the replica, unless ctx asks for the primary
+// with storage.WithPrimary
+func reader(ctx context.Context, primary, replica *pgxpool.Pool) *pgxpool.Pool {
+	if storage.UsePrimary(ctx) {
+		return primary
+	}
+	return replica
+}
+
+// connect opens the pool and pings the database, retrying with backoff for up to wait when the
+// database is not accepting connections yet (e.g. its container is still starting)
+func connect(ctx context.Context, poolConfig *pgxpool.Config, wait time.Duration, log logger.LoggerI) (*pgxpool.Pool, error) {
+	deadline := time.Now().Add(wait)
+	backoff := time.Second
+
+	for attempt := 1; ; attempt++ {
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err == nil {
+			if err = pool.Ping(ctx); err == nil {
+				return pool, nil
+			}
+			pool.Close()
+		}
+
+		sleep := min(backoff, time.Until(deadline))
+		if sleep <= 0 {
+			log.Error("Failed to connect to database: " + err.Error())
+			return nil, err
+		}
+		log.Warn("Database not ready, retrying",
+			logger.Error(err),
+			logger.Int("attempt", attempt),
+			logger.Any("retry_in", sleep.String()),
+		)
+
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, 10*time.Second)
+	}
+}
+
 // CloseDB closes the database connection pool
 func (s *Store) CloseDB() {
+	if s.tracer != nil {
+		s.tracer.Stop()
+	}
+	if s.read != s.db {
+		s.read.Close()
+	}
 	s.db.Close()
 }
 
 // User returns the user repository
 func (s *Store) User() storage.UserRepoI {
-	return NewUserRepo(s.db, s.logger)
+	return NewUserRepo(s.db, s.read, s.logger)
 }
 
 // Job returns the job repository
 func (s *Store) Job() storage.JobRepoI {
-	return NewJobRepo(s.db, s.logger)
+	return NewJobRepo(s.db, s.read, s.logger)
 }
 
 // Registration returns the registration repository
 func (s *Store) Registration() storage.RegistrationRepoI {
-	return NewRegistrationRepo(s.db, s.logger)
+	return NewRegistrationRepo(s.db, s.read, s.logger, s.crypt)
 }
 
 // Booking returns the booking repository
 func (s *Store) Booking() storage.BookingRepoI {
-	return NewBookingRepo(s.db, s.logger)
+	return NewBookingRepo(s.db, s.read, s.logger, s.crypt)
 }
 
 // AdminMessage returns the admin message repository
@@ -139,6 +235,76 @@ func (s *Store) AdminMessage() storage.AdminMessageRepoI {
 	return NewAdminMessageRepo(s.db, s.logger)
 }
 
+// AdminPrefs returns the admin notification preferences repository
+func (s *Store) AdminPrefs() storage.AdminPrefsRepoI {
+	return NewAdminPrefsRepo(s.db, s.logger)
+}
+
+// Employer returns the employer repository
+func (s *Store) Employer() storage.EmployerRepoI {
+	return NewEmployerRepo(s.db, s.read, s.logger)
+}
+
+// WorkerRating returns the worker rating repository
+func (s *Store) WorkerRating() storage.WorkerRatingRepoI {
+	return NewWorkerRatingRepo(s.db, s.logger)
+}
+
+// JobFeedback returns the worker feedback repository
+func (s *Store) JobFeedback() storage.JobFeedbackRepoI {
+	return NewJobFeedbackRepo(s.db, s.logger)
+}
+
+// Audit returns the audit log repository
+func (s *Store) Audit() storage.AuditRepoI {
+	return NewAuditRepo(s.db, s.logger)
+}
+
+// Voucher returns the booking voucher repository
+func (s *Store) Voucher() storage.VoucherRepoI {
+	return NewVoucherRepo(s.db, s.logger)
+}
+
+// ProfileChange returns the profile edit history repository
+func (s *Store) ProfileChange() storage.ProfileChangeRepoI {
+	return NewProfileChangeRepo(s.db, s.logger)
+}
+
+// FAQ returns the help FAQ repository
+func (s *Store) FAQ() storage.FAQRepoI {
+	return NewFAQRepo(s.db, s.logger)
+}
+
+// PublicOffer returns the public offer and consent log repository
+func (s *Store) PublicOffer() storage.PublicOfferRepoI {
+	return NewPublicOfferRepo(s.db, s.logger)
+}
+
+// Settings returns the runtime bot settings repository
+func (s *Store) Settings() storage.SettingsRepoI {
+	return NewSettingsRepo(s.db, s.logger)
+}
+
+// JobInterest returns the repository of signup link opens by unregistered users
+func (s *Store) JobInterest() storage.JobInterestRepoI {
+	return NewJobInterestRepo(s.db, s.read, s.logger)
+}
+
+// Reengage returns the re-engagement campaign repository
+func (s *Store) Reengage() storage.ReengageRepoI {
+	return NewReengageRepo(s.db, s.logger)
+}
+
+// Support returns the user support thread repository
+func (s *Store) Support() storage.SupportRepoI {
+	return NewSupportRepo(s.db, s.logger)
+}
+
+// Refund returns the refund repository
+func (s *Store) Refund() storage.RefundRepoI {
+	return NewRefundRepo(s.db, s.logger)
+}
+
 // Transaction returns the transaction manager
 func (s *Store) Transaction() storage.TransactionI {
 	return NewTransactionManager(s.db, s.logger)
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type profileChangeRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewProfileChangeRepo creates a new profile change repository
+func NewProfileChangeRepo(db *pgxpool.Pool, log logger.LoggerI) storage.ProfileChangeRepoI {
+	return &profileChangeRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create records a profile edit
+func (r *profileChangeRepo) Create(ctx context.Context, change *models.ProfileChange) error {
+	query := `
+		INSERT INTO profile_changes (user_id, field, old_value, new_value)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		change.UserID,
+		change.Field,
+		change.OldValue,
+		change.NewValue,
+	).Scan(&change.ID, &change.CreatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create profile change", logger.Error(err))
+		return fmt.Errorf("failed to create profile change: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes a user's edit history
+func (r *profileChangeRepo) DeleteByUserID(ctx context.Context, userID int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM profile_changes WHERE user_id = $1`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete profile changes", logger.Error(err))
+		return fmt.Errorf("failed to delete profile changes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type publicOfferRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewPublicOfferRepo creates a new public offer repository
+func NewPublicOfferRepo(db *pgxpool.Pool, log logger.LoggerI) storage.PublicOfferRepoI {
+	return &publicOfferRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Publish stores a new version numbered one above the latest
+func (r *publicOfferRepo) Publish(ctx context.Context, offer *models.PublicOffer) error {
+	query := `
+		INSERT INTO public_offers (version, content, created_by_admin_id, published_at)
+		SELECT COALESCE(MAX(version), 0) + 1, $1, $2, NOW() FROM public_offers
+		RETURNING id, version, published_at
+	`
+
+	err := r.db.QueryRow(ctx, query, offer.Content, toNullInt64(offer.CreatedByAdminID)).
+		Scan(&offer.ID, &offer.Version, &offer.PublishedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to publish public offer", logger.Error(err))
+		return fmt.Errorf("failed to publish public offer: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest returns the newest published version
+func (r *publicOfferRepo) GetLatest(ctx context.Context) (*models.PublicOffer, error) {
+	query := `
+		SELECT id, version, content, created_by_admin_id, published_at
+		FROM public_offers
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	offer := &models.PublicOffer{}
+	err := r.db.QueryRow(ctx, query).Scan(&offer.ID, &offer.Version, &offer.Content, nullable(&offer.CreatedByAdminID), &offer.PublishedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get public offer", logger.Error(err))
+		return nil, fmt.Errorf("failed to get public offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// RecordAcceptance logs that a user accepted a version
+func (r *publicOfferRepo) RecordAcceptance(ctx context.Context, acceptance *models.OfferAcceptance) error {
+	query := `
+		INSERT INTO offer_acceptances (user_id, version, accepted_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, version) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING id, accepted_at
+	`
+
+	err := r.db.QueryRow(ctx, query, acceptance.UserID, acceptance.Version).
+		Scan(&acceptance.ID, &acceptance.AcceptedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to record offer acceptance", logger.Error(err))
+		return fmt.Errorf("failed to record offer acceptance: %w", err)
+	}
+
+	return nil
+}
+
+// GetAcceptedVersion returns the highest version the user accepted, 0 if none
+func (r *publicOfferRepo) GetAcceptedVersion(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COALESCE(MAX(version), 0) FROM offer_acceptances WHERE user_id = $1`
+
+	var version int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&version); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get accepted offer version", logger.Error(err))
+		return 0, fmt.Errorf("failed to get accepted offer version: %w", err)
+	}
+
+	return version, nil
+}
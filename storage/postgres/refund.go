@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const refundColumns = `id, booking_id, job_id, user_id, amount, status, created_at, paid_at, COALESCE(paid_by, 0)`
+
+type refundRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewRefundRepo creates a new refund repository
+func NewRefundRepo(db *pgxpool.Pool, log logger.LoggerI) storage.RefundRepoI {
+	return &refundRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create records a pending refund; a booking gets at most one
+func (r *refundRepo) Create(ctx context.Context, tx any, refund *models.Refund) error {
+	// ON CONFLICT instead of catching the unique violation, which would abort the transaction
+	query := `
+		INSERT INTO refunds (booking_id, job_id, user_id, amount, status)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING id, created_at
+	`
+	args := []any{refund.BookingID, refund.JobID, refund.UserID, refund.Amount, models.RefundPending}
+
+	var row pgx.Row
+	if tx != nil {
+		row = tx.(pgx.Tx).QueryRow(ctx, query, args...)
+	} else {
+		row = r.db.QueryRow(ctx, query, args...)
+	}
+	if err := row.Scan(&refund.ID, &refund.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create refund", logger.Error(err))
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+	refund.Status = models.RefundPending
+	return nil
+}
+
+// GetByID returns a refund
+func (r *refundRepo) GetByID(ctx context.Context, id int64) (*models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE id = $1`
+
+	refund, err := scanRefund(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get refund", logger.Error(err))
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+	return refund, nil
+}
+
+// GetPending returns the refunds still owed, oldest first
+func (r *refundRepo) GetPending(ctx context.Context) ([]*models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE status = 'PENDING' ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get pending refunds", logger.Error(err))
+		return nil, fmt.Errorf("failed to get pending refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []*models.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan refund", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+	return refunds, rows.Err()
+}
+
+// MarkPaid marks a pending refund paid and reports whether it was pending
+func (r *refundRepo) MarkPaid(ctx context.Context, id, adminID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE refunds
+		SET status = $2, paid_at = NOW(), paid_by = $3
+		WHERE id = $1 AND status = 'PENDING'
+	`, id, models.RefundPaid, adminID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to mark refund paid", logger.Error(err))
+		return false, fmt.Errorf("failed to mark refund paid: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanRefund(row pgx.Row) (*models.Refund, error) {
+	var refund models.Refund
+	if err := row.Scan(&refund.ID, &refund.BookingID, &refund.JobID, &refund.UserID, &refund.Amount,
+		&refund.Status, &refund.CreatedAt, &refund.PaidAt, &refund.PaidBy); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
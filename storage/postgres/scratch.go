@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateDatabase creates database name on the server cfg points at, waiting up to DB_CONNECT_WAIT
+// for it to accept connections (a freshly started container takes a few seconds). The maintenance
+// commands (cmd/loadtest) and the integration tests run in such scratch databases.
+func CreateDatabase(ctx context.Context, cfg *config.Config, name string, log logger.LoggerI) error {
+	deadline := time.Now().Add(cfg.Database.ConnectWait)
+	for {
+		conn, err := pgx.Connect(ctx, cfg.Database.DSN())
+		if err == nil {
+			_, err = conn.Exec(ctx, "CREATE DATABASE "+pgx.Identifier{name}.Sanitize())
+			conn.Close(ctx)
+			return err
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		log.Warn("Database not ready, retrying", logger.Error(err))
+		time.Sleep(time.Second)
+	}
+}
+
+// DropDatabase drops database name, disconnecting whoever is still using it
+func DropDatabase(ctx context.Context, cfg *config.Config, name string) error {
+	conn, err := pgx.Connect(ctx, cfg.Database.DSN())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, "DROP DATABASE IF EXISTS "+pgx.Identifier{name}.Sanitize()+" WITH (FORCE)")
+	return err
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type settingsRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewSettingsRepo creates a new bot settings repository
+func NewSettingsRepo(db *pgxpool.Pool, log logger.LoggerI) storage.SettingsRepoI {
+	return &settingsRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetAll returns every stored setting keyed by name
+func (r *settingsRepo) GetAll(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT key, value FROM bot_settings`)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get bot settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get bot settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan bot setting: %w", err)
+		}
+		settings[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bot settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Set creates or replaces a setting
+func (r *settingsRepo) Set(ctx context.Context, key, value string, updatedBy int64) error {
+	query := `
+		INSERT INTO bot_settings (key, value, updated_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key)
+		DO UPDATE SET value = $2, updated_by = $3
+	`
+
+	if _, err := r.db.Exec(ctx, query, key, value, updatedBy); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set bot setting", logger.Error(err), logger.String("key", key))
+		return fmt.Errorf("failed to set bot setting: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const supportThreadColumns = `id, user_id, status, created_at, closed_at, COALESCE(closed_by, 0)`
+
+type supportRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewSupportRepo creates a new support thread repository
+func NewSupportRepo(db *pgxpool.Pool, log logger.LoggerI) storage.SupportRepoI {
+	return &supportRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create opens a thread for the user; the partial unique index allows one open thread per user
+func (r *supportRepo) Create(ctx context.Context, userID int64) (*models.SupportThread, error) {
+	query := `
+		INSERT INTO support_threads (user_id, status)
+		VALUES ($1, $2)
+		RETURNING ` + supportThreadColumns
+
+	thread, err := scanSupportThread(r.db.QueryRow(ctx, query, userID, models.SupportThreadOpen))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create support thread", logger.Error(err))
+		return nil, fmt.Errorf("failed to create support thread: %w", err)
+	}
+	return thread, nil
+}
+
+// GetByID returns a thread
+func (r *supportRepo) GetByID(ctx context.Context, id int64) (*models.SupportThread, error) {
+	return r.getOne(ctx, "id = $1", id)
+}
+
+// GetOpenByUser returns the user's open thread
+func (r *supportRepo) GetOpenByUser(ctx context.Context, userID int64) (*models.SupportThread, error) {
+	return r.getOne(ctx, "user_id = $1 AND status = 'OPEN'", userID)
+}
+
+func (r *supportRepo) getOne(ctx context.Context, where string, arg any) (*models.SupportThread, error) {
+	query := `SELECT ` + supportThreadColumns + ` FROM support_threads WHERE ` + where
+
+	thread, err := scanSupportThread(r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get support thread", logger.Error(err))
+		return nil, fmt.Errorf("failed to get support thread: %w", err)
+	}
+	return thread, nil
+}
+
+// Close closes an open thread and reports whether it was open
+func (r *supportRepo) Close(ctx context.Context, id, closedBy int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE support_threads
+		SET status = $2, closed_at = NOW(), closed_by = $3
+		WHERE id = $1 AND status = 'OPEN'
+	`, id, models.SupportThreadClosed, closedBy)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to close support thread", logger.Error(err))
+		return false, fmt.Errorf("failed to close support thread: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanSupportThread(row pgx.Row) (*models.SupportThread, error) {
+	var thread models.SupportThread
+	if err := row.Scan(&thread.ID, &thread.UserID, &thread.Status, &thread.CreatedAt, &thread.ClosedAt, &thread.ClosedBy); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot-starter/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	slowQuerySQLLimit = 500 // Characters of a slow statement that are logged
+	slowQueryArgLimit = 32  // Characters of one string argument that are logged
+)
+
+type queryStartKey struct{}
+
+// queryTracer is the pool's pgx.QueryTracer. It logs statements slower than DB_SLOW_QUERY with
+// the repository method that ran them and a summary of the arguments, and logs per-repository
+// latency totals every DB_QUERY_STATS_INTERVAL.
+type queryTracer struct {
+	log      logger.LoggerI
+	slow     time.Duration // 0 disables slow query logging
+	interval time.Duration // 0 disables the summaries
+
+	mu    sync.Mutex
+	stats map[string]*repoStats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// repoStats are the query latencies of one repository since the last summary
+type repoStats struct {
+	count int
+	slow  int
+	total time.Duration
+	max   time.Duration
+}
+
+func newQueryTracer(log logger.LoggerI, slow, interval time.Duration) *queryTracer {
+	return &queryTracer{
+		log:      log,
+		slow:     slow,
+		interval: interval,
+		stats:    make(map[string]*repoStats),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// TraceQueryStart remembers when the statement started
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+type queryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+// TraceQueryEnd records the latency and logs the statement when it was slow. It runs while the
+// repository method is still on the stack (Exec, Scan or rows.Close), which names the caller.
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at)
+	repo, op := queryCaller()
+	slow := t.slow > 0 && elapsed >= t.slow
+
+	t.mu.Lock()
+	s := t.stats[repo]
+	if s == nil {
+		s = &repoStats{}
+		t.stats[repo] = s
+	}
+	s.count++
+	s.total += elapsed
+	s.max = max(s.max, elapsed)
+	if slow {
+		s.slow++
+	}
+	t.mu.Unlock()
+
+	if !slow {
+		return
+	}
+	fields := []logger.Field{
+		logger.String("repo", repo),
+		logger.String("op", op),
+		logger.Any("duration", elapsed.Round(time.Millisecond).String()),
+		logger.String("sql", compactSQL(start.sql)),
+		logger.String("args", summarizeArgs(start.args)),
+	}
+	if data.Err != nil {
+		fields = append(fields, logger.Error(data.Err))
+	}
+	logger.FromContext(ctx, t.log).Warn("Slow query", fields...)
+}
+
+// queryCaller returns the repository type and method that ran the statement, e.g. "jobRepo", "GetByID"
+func queryCaller() (repo, op string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		// e.g. telegram-bot-starter/storage/postgres.(*jobRepo).GetByID.func1
+		if _, method, ok := strings.Cut(frame.Function, "/storage/postgres.(*"); ok {
+			repo, op, _ = strings.Cut(method, ").")
+			op, _, _ = strings.Cut(op, ".")
+			return repo, op
+		}
+		if !more {
+			return "other", ""
+		}
+	}
+}
+
+// compactSQL collapses the statement's whitespace and cuts it to slowQuerySQLLimit characters
+func compactSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if r := []rune(sql); len(r) > slowQuerySQLLimit {
+		return string(r[:slowQuerySQLLimit]) + "…"
+	}
+	return sql
+}
+
+// summarizeArgs lists the arguments with long strings cut and byte slices replaced by their size
+func summarizeArgs(args []any) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		var s string
+		switch v := arg.(type) {
+		case nil:
+			s = "NULL"
+		case string:
+			if r := []rune(v); len(r) > slowQueryArgLimit {
+				v = string(r[:slowQueryArgLimit]) + "…"
+			}
+			s = fmt.Sprintf("%q", v)
+		case []byte:
+			s = fmt.Sprintf("<%d bytes>", len(v))
+		case time.Time:
+			s = v.Format(time.RFC3339)
+		default:
+			s = fmt.Sprint(v)
+			if r := []rune(s); len(r) > slowQueryArgLimit {
+				s = string(r[:slowQueryArgLimit]) + "…"
+			}
+		}
+		parts[i] = fmt.Sprintf("$%d=%s", i+1, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Start logs the per-repository latencies every interval until Stop
+func (t *queryTracer) Start() {
+	if t.interval <= 0 {
+		return
+	}
+	go t.run()
+}
+
+func (t *queryTracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.logStats()
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// logStats logs one line per repository that ran queries since the last summary and resets the totals
+func (t *queryTracer) logStats() {
+	t.mu.Lock()
+	stats := t.stats
+	t.stats = make(map[string]*repoStats)
+	t.mu.Unlock()
+
+	repos := make([]string, 0, len(stats))
+	for repo := range stats {
+		repos = append(repos, repo)
+	}
+	slices.Sort(repos)
+
+	for _, repo := range repos {
+		s := stats[repo]
+		t.log.Info("Query latency",
+			logger.String("repo", repo),
+			logger.Int("queries", s.count),
+			logger.Int("slow", s.slow),
+			logger.Any("avg", (s.total/time.Duration(s.count)).Round(time.Microsecond).String()),
+			logger.Any("max", s.max.Round(time.Microsecond).String()),
+		)
+	}
+}
+
+// Stop ends the summaries
+func (t *queryTracer) Stop() {
+	if t.interval <= 0 {
+		return
+	}
+	close(t.stop)
+	<-t.done
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"telegram-bot-starter/bot/models"
 	"telegram-bot-starter/pkg/logger"
@@ -16,23 +17,25 @@ import (
 
 // userRepo implements storage.UserRepoI interface using PostgreSQL
 type userRepo struct {
-	db  *pgxpool.Pool
-	log logger.LoggerI
+	db   *pgxpool.Pool
+	read *pgxpool.Pool // Replica for lag-tolerant reads; the primary when none is configured
+	log  logger.LoggerI
 }
 
 // NewUserRepo creates a new PostgreSQL user repository
-func NewUserRepo(db *pgxpool.Pool, log logger.LoggerI) storage.UserRepoI {
+func NewUserRepo(db, read *pgxpool.Pool, log logger.LoggerI) storage.UserRepoI {
 	return &userRepo{
-		db:  db,
-		log: log,
+		db:   db,
+		read: read,
+		log:  log,
 	}
 }
 
 // Create creates a new user in the database
 func (r *userRepo) Create(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (id, username, first_name, last_name, state, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (id, username, first_name, last_name, state, created_at, updated_at, state_updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -43,6 +46,7 @@ func (r *userRepo) Create(ctx context.Context, user *models.User) error {
 		user.State,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.StateUpdatedAt,
 	)
 
 	if err != nil {
@@ -51,7 +55,7 @@ func (r *userRepo) Create(ctx context.Context, user *models.User) error {
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return storage.ErrAlreadyExists
 		}
-		r.log.Error("Failed to create user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to create user: " + err.Error())
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -61,7 +65,7 @@ func (r *userRepo) Create(ctx context.Context, user *models.User) error {
 // GetByID retrieves a user by their ID
 func (r *userRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
 	query := `
-		SELECT id, username, first_name, last_name, state, created_at, updated_at
+		SELECT id, username, first_name, last_name, state, created_at, updated_at, state_updated_at, marketing_opt_out
 		FROM users
 		WHERE id = $1
 	`
@@ -75,13 +79,15 @@ func (r *userRepo) GetByID(ctx context.Context, id int64) (*models.User, error)
 		&user.State,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.StateUpdatedAt,
+		&user.MarketingOptOut,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
 		}
-		r.log.Error("Failed to get user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get user: " + err.Error())
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -106,7 +112,7 @@ func (r *userRepo) Update(ctx context.Context, user *models.User) error {
 	)
 
 	if err != nil {
-		r.log.Error("Failed to update user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to update user: " + err.Error())
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -123,7 +129,7 @@ func (r *userRepo) Delete(ctx context.Context, id int64) error {
 
 	commandTag, err := r.db.Exec(ctx, query, id)
 	if err != nil {
-		r.log.Error("Failed to delete user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to delete user: " + err.Error())
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
@@ -138,13 +144,13 @@ func (r *userRepo) Delete(ctx context.Context, id int64) error {
 func (r *userRepo) UpdateState(ctx context.Context, id int64, state models.UserState) error {
 	query := `
 		UPDATE users
-		SET state = $2
+		SET state = $2, state_updated_at = NOW()
 		WHERE id = $1
 	`
 
 	commandTag, err := r.db.Exec(ctx, query, id, state)
 	if err != nil {
-		r.log.Error("Failed to update user state: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to update user state: " + err.Error())
 		return fmt.Errorf("failed to update user state: %w", err)
 	}
 
@@ -155,6 +161,99 @@ func (r *userRepo) UpdateState(ctx context.Context, id int64, state models.UserS
 	return nil
 }
 
+// GetStaleStates returns users whose non-idle state last changed before the cutoff, oldest first
+func (r *userRepo) GetStaleStates(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	query := `
+		SELECT id, username, first_name, last_name, state, created_at, updated_at, state_updated_at
+		FROM users
+		WHERE state <> $1 AND state_updated_at < $2
+		ORDER BY state_updated_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, models.StateIdle, before, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get stale user states: " + err.Error())
+		return nil, fmt.Errorf("failed to get stale user states: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&user.State,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.StateUpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan user: " + err.Error())
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// ResetStaleState moves the user back to idle only if they are still in state and have not moved since before.
+// The condition is re-checked so a user who answered in the meantime keeps their progress.
+func (r *userRepo) ResetStaleState(ctx context.Context, id int64, state models.UserState, before time.Time) (bool, error) {
+	query := `
+		UPDATE users
+		SET state = $2, state_updated_at = NOW()
+		WHERE id = $1 AND state = $3 AND state_updated_at < $4
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, models.StateIdle, state, before)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to reset stale user state: " + err.Error())
+		return false, fmt.Errorf("failed to reset stale user state: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Anonymize clears the Telegram profile data of a user but keeps the row for history
+func (r *userRepo) Anonymize(ctx context.Context, id int64) error {
+	query := `
+		UPDATE users
+		SET username = '', first_name = '', last_name = '', state = $2, updated_at = NOW(), state_updated_at = NOW()
+		WHERE id = $1
+	`
+
+	commandTag, err := r.db.Exec(ctx, query, id, models.StateIdle)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to anonymize user: " + err.Error())
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetMarketingOptOut turns marketing messages off (true) or back on for a user
+func (r *userRepo) SetMarketingOptOut(ctx context.Context, id int64, optOut bool) error {
+	commandTag, err := r.db.Exec(ctx, `UPDATE users SET marketing_opt_out = $2, updated_at = NOW() WHERE id = $1`, id, optOut)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update marketing opt-out: " + err.Error())
+		return fmt.Errorf("failed to update marketing opt-out: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
 // GetOrCreateUser gets a user by ID or creates a new one if not found
 func (r *userRepo) GetOrCreateUser(ctx context.Context, id int64, username, firstName, lastName string) (*models.User, error) {
 	// First, try to get existing user
@@ -202,7 +301,7 @@ func (r *userRepo) AddViolation(ctx context.Context, tx any, violation *models.U
 	).Scan(&violation.ID, &violation.CreatedAt)
 
 	if err != nil {
-		r.log.Error("Failed to add violation: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to add violation: " + err.Error())
 		return fmt.Errorf("failed to add violation: %w", err)
 	}
 
@@ -227,13 +326,78 @@ func (r *userRepo) GetViolationCount(ctx context.Context, tx any, userID int64)
 	}
 
 	if err != nil {
-		r.log.Error("Failed to get violation count: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get violation count: " + err.Error())
 		return 0, fmt.Errorf("failed to get violation count: %w", err)
 	}
 
 	return count, nil
 }
 
+// GetViolations returns a user's violations, newest first
+func (r *userRepo) GetViolations(ctx context.Context, userID int64) ([]*models.UserViolation, error) {
+	query := `
+		SELECT id, user_id, violation_type, booking_id, admin_id, created_at
+		FROM user_violations
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get violations: " + err.Error())
+		return nil, fmt.Errorf("failed to get violations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []*models.UserViolation
+	for rows.Next() {
+		var v models.UserViolation
+		if err := rows.Scan(&v.ID, &v.UserID, &v.ViolationType, &v.BookingID, &v.AdminID, &v.CreatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan violation: " + err.Error())
+			return nil, fmt.Errorf("failed to scan violation: %w", err)
+		}
+		violations = append(violations, &v)
+	}
+
+	return violations, rows.Err()
+}
+
+// GetViolationByID retrieves a single violation
+func (r *userRepo) GetViolationByID(ctx context.Context, id int64) (*models.UserViolation, error) {
+	query := `
+		SELECT id, user_id, violation_type, booking_id, admin_id, created_at
+		FROM user_violations
+		WHERE id = $1
+	`
+
+	var v models.UserViolation
+	err := r.db.QueryRow(ctx, query, id).Scan(&v.ID, &v.UserID, &v.ViolationType, &v.BookingID, &v.AdminID, &v.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get violation: " + err.Error())
+		return nil, fmt.Errorf("failed to get violation: %w", err)
+	}
+
+	return &v, nil
+}
+
+// DeleteViolation removes a violation record (used when an admin forgives it)
+func (r *userRepo) DeleteViolation(ctx context.Context, tx any, id int64) error {
+	pgxTx, ok := tx.(pgx.Tx)
+	if !ok {
+		return fmt.Errorf("invalid transaction type")
+	}
+
+	if _, err := pgxTx.Exec(ctx, `DELETE FROM user_violations WHERE id = $1`, id); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete violation: " + err.Error())
+		return fmt.Errorf("failed to delete violation: %w", err)
+	}
+
+	return nil
+}
+
 // BlockUser blocks a user
 func (r *userRepo) BlockUser(ctx context.Context, tx any, block *models.BlockedUser) error {
 	pgxTx, ok := tx.(pgx.Tx)
@@ -263,7 +427,7 @@ func (r *userRepo) BlockUser(ctx context.Context, tx any, block *models.BlockedU
 	).Scan(&block.CreatedAt, &block.UpdatedAt)
 
 	if err != nil {
-		r.log.Error("Failed to block user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to block user: " + err.Error())
 		return fmt.Errorf("failed to block user: %w", err)
 	}
 
@@ -293,7 +457,7 @@ func (r *userRepo) GetBlockStatus(ctx context.Context, userID int64) (*models.Bl
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Not blocked
 		}
-		r.log.Error("Failed to get block status: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get block status: " + err.Error())
 		return nil, fmt.Errorf("failed to get block status: %w", err)
 	}
 
@@ -306,19 +470,74 @@ func (r *userRepo) UnblockUser(ctx context.Context, userID int64) error {
 
 	_, err := r.db.Exec(ctx, query, userID)
 	if err != nil {
-		r.log.Error("Failed to unblock user: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to unblock user: " + err.Error())
 		return fmt.Errorf("failed to unblock user: %w", err)
 	}
 
 	return nil
 }
 
+// GetExpiredBlocks returns temporary blocks that have run out
+func (r *userRepo) GetExpiredBlocks(ctx context.Context, now time.Time, limit int) ([]*models.BlockedUser, error) {
+	query := `
+		SELECT user_id, blocked_until, total_violations, blocked_by_admin_id, reason, created_at, updated_at
+		FROM blocked_users
+		WHERE blocked_until IS NOT NULL AND blocked_until <= $1
+		ORDER BY blocked_until
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get expired blocks: " + err.Error())
+		return nil, fmt.Errorf("failed to get expired blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []*models.BlockedUser
+	for rows.Next() {
+		var block models.BlockedUser
+		if err := rows.Scan(
+			&block.UserID,
+			&block.BlockedUntil,
+			&block.TotalViolations,
+			&block.BlockedByAdminID,
+			&block.Reason,
+			&block.CreatedAt,
+			&block.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan blocked user: " + err.Error())
+			return nil, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		blocks = append(blocks, &block)
+	}
+
+	return blocks, rows.Err()
+}
+
+// UnblockIfExpired removes a temporary block that has run out.
+// The condition is re-checked so a block escalated in the meantime is kept.
+func (r *userRepo) UnblockIfExpired(ctx context.Context, userID int64, now time.Time) (bool, error) {
+	query := `
+		DELETE FROM blocked_users
+		WHERE user_id = $1 AND blocked_until IS NOT NULL AND blocked_until <= $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, now)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to unblock expired user: " + err.Error())
+		return false, fmt.Errorf("failed to unblock expired user: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
 // GetTotalCount returns the total number of users
 func (r *userRepo) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get total user count: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get total user count: " + err.Error())
 		return 0, fmt.Errorf("failed to get total user count: %w", err)
 	}
 	return count, nil
@@ -327,9 +546,9 @@ func (r *userRepo) GetTotalCount(ctx context.Context) (int, error) {
 // GetBlockedCount returns the total number of blocked users
 func (r *userRepo) GetBlockedCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM blocked_users`).Scan(&count)
+	err := reader(ctx, r.db, r.read).QueryRow(ctx, `SELECT COUNT(*) FROM blocked_users`).Scan(&count)
 	if err != nil {
-		r.log.Error("Failed to get blocked user count: " + err.Error())
+		logger.FromContext(ctx, r.log).Error("Failed to get blocked user count: " + err.Error())
 		return 0, fmt.Errorf("failed to get blocked user count: %w", err)
 	}
 	return count, nil
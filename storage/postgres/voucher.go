@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type voucherRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewVoucherRepo creates a new booking voucher repository
+func NewVoucherRepo(db *pgxpool.Pool, log logger.LoggerI) storage.VoucherRepoI {
+	return &voucherRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create stores a voucher for a confirmed booking
+func (r *voucherRepo) Create(ctx context.Context, voucher *models.BookingVoucher) error {
+	query := `
+		INSERT INTO booking_vouchers (booking_id, code)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, voucher.BookingID, voucher.Code).Scan(&voucher.ID, &voucher.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create voucher", logger.Error(err))
+		return fmt.Errorf("failed to create voucher: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBookingID retrieves the voucher of a booking
+func (r *voucherRepo) GetByBookingID(ctx context.Context, bookingID int64) (*models.BookingVoucher, error) {
+	return r.getOne(ctx, "booking_id = $1", bookingID)
+}
+
+// GetByCode retrieves a voucher by its verification code
+func (r *voucherRepo) GetByCode(ctx context.Context, code string) (*models.BookingVoucher, error) {
+	return r.getOne(ctx, "code = $1", code)
+}
+
+func (r *voucherRepo) getOne(ctx context.Context, where string, arg any) (*models.BookingVoucher, error) {
+	query := `
+		SELECT id, booking_id, code, verified_at, verified_by_admin_id, created_at
+		FROM booking_vouchers
+		WHERE ` + where
+
+	voucher := &models.BookingVoucher{}
+	err := r.db.QueryRow(ctx, query, arg).Scan(
+		&voucher.ID,
+		&voucher.BookingID,
+		&voucher.Code,
+		&voucher.VerifiedAt,
+		&voucher.VerifiedByAdminID,
+		&voucher.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get voucher", logger.Error(err))
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+
+	return voucher, nil
+}
+
+// MarkVerified records the first on-site check of a voucher
+func (r *voucherRepo) MarkVerified(ctx context.Context, id, adminID int64) error {
+	query := `
+		UPDATE booking_vouchers
+		SET verified_at = NOW(), verified_by_admin_id = $2
+		WHERE id = $1 AND verified_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, adminID); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to mark voucher verified", logger.Error(err))
+		return fmt.Errorf("failed to mark voucher verified: %w", err)
+	}
+
+	return nil
+}
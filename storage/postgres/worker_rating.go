@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type workerRatingRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewWorkerRatingRepo creates a new worker rating repository
+func NewWorkerRatingRepo(db *pgxpool.Pool, log logger.LoggerI) storage.WorkerRatingRepoI {
+	return &workerRatingRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Upsert creates or replaces the rating for a booking
+func (r *workerRatingRepo) Upsert(ctx context.Context, rating *models.WorkerRating) error {
+	query := `
+		INSERT INTO worker_ratings (booking_id, user_id, job_id, admin_id, rating, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (booking_id)
+		DO UPDATE SET admin_id = $4, rating = $5, updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, rating.BookingID, rating.UserID, rating.JobID, rating.AdminID, rating.Rating).
+		Scan(&rating.ID, &rating.CreatedAt, &rating.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to upsert worker rating", logger.Error(err))
+		return fmt.Errorf("failed to upsert worker rating: %w", err)
+	}
+
+	return nil
+}
+
+// GetByJobID returns all ratings given for a job's bookings
+func (r *workerRatingRepo) GetByJobID(ctx context.Context, jobID int64) ([]*models.WorkerRating, error) {
+	query := `
+		SELECT id, booking_id, user_id, job_id, admin_id, rating, created_at, updated_at
+		FROM worker_ratings
+		WHERE job_id = $1
+	`
+
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get worker ratings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get worker ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*models.WorkerRating
+	for rows.Next() {
+		rating := &models.WorkerRating{}
+		if err := rows.Scan(&rating.ID, &rating.BookingID, &rating.UserID, &rating.JobID,
+			&rating.AdminID, &rating.Rating, &rating.CreatedAt, &rating.UpdatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan worker rating", logger.Error(err))
+			continue
+		}
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, nil
+}
+
+// GetReliability aggregates a worker's ratings and attendance marks
+func (r *workerRatingRepo) GetReliability(ctx context.Context, userID int64) (*models.WorkerReliability, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM worker_ratings WHERE user_id = $1),
+			(SELECT COALESCE(AVG(rating), 0) FROM worker_ratings WHERE user_id = $1),
+			COUNT(*) FILTER (WHERE attendance = 'ATTENDED'),
+			COUNT(*) FILTER (WHERE attendance = 'NO_SHOW')
+		FROM job_bookings
+		WHERE user_id = $1
+	`
+
+	rel := &models.WorkerReliability{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&rel.RatingCount,
+		&rel.AverageRating,
+		&rel.Attended,
+		&rel.NoShows,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get worker reliability", logger.Error(err))
+		return nil, fmt.Errorf("failed to get worker reliability: %w", err)
+	}
+
+	return rel, nil
+}
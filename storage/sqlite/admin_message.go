@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type adminMessageRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewAdminMessageRepo creates a new admin message repository
+func NewAdminMessageRepo(db *sql.DB, log logger.LoggerI) storage.AdminMessageRepoI {
+	return &adminMessageRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Upsert creates or updates an admin message for a job
+func (r *adminMessageRepo) Upsert(ctx context.Context, adminMsg *models.AdminJobMessage) error {
+	query := `
+		INSERT INTO admin_job_messages (job_id, admin_id, message_id, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (job_id, admin_id)
+		DO UPDATE SET message_id = excluded.message_id, updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, adminMsg.JobID, adminMsg.AdminID, adminMsg.MessageID).
+		Scan(&adminMsg.ID, &adminMsg.CreatedAt, &adminMsg.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to upsert admin message", logger.Error(err))
+		return fmt.Errorf("failed to upsert admin message: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves an admin message by job and admin ID
+func (r *adminMessageRepo) Get(ctx context.Context, jobID, adminID int64) (*models.AdminJobMessage, error) {
+	query := `
+		SELECT id, job_id, admin_id, message_id, created_at, updated_at
+		FROM admin_job_messages
+		WHERE job_id = $1 AND admin_id = $2
+	`
+
+	adminMsg := &models.AdminJobMessage{}
+	err := r.db.QueryRowContext(ctx, query, jobID, adminID).Scan(
+		&adminMsg.ID,
+		&adminMsg.JobID,
+		&adminMsg.AdminID,
+		&adminMsg.MessageID,
+		&adminMsg.CreatedAt,
+		&adminMsg.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get admin message", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin message: %w", err)
+	}
+
+	return adminMsg, nil
+}
+
+// GetAllByJobID retrieves all admin messages for a job
+func (r *adminMessageRepo) GetAllByJobID(ctx context.Context, jobID int64) ([]*models.AdminJobMessage, error) {
+	query := `
+		SELECT id, job_id, admin_id, message_id, created_at, updated_at
+		FROM admin_job_messages
+		WHERE job_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get admin messages for job", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin messages for job: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*models.AdminJobMessage
+	for rows.Next() {
+		adminMsg := &models.AdminJobMessage{}
+		if err := rows.Scan(
+			&adminMsg.ID,
+			&adminMsg.JobID,
+			&adminMsg.AdminID,
+			&adminMsg.MessageID,
+			&adminMsg.CreatedAt,
+			&adminMsg.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan admin message", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan admin message: %w", err)
+		}
+		messages = append(messages, adminMsg)
+	}
+
+	return messages, nil
+}
+
+// GetAll retrieves every stored admin message, oldest first
+func (r *adminMessageRepo) GetAll(ctx context.Context) ([]*models.AdminJobMessage, error) {
+	query := `
+		SELECT id, job_id, admin_id, message_id, created_at, updated_at
+		FROM admin_job_messages
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get admin messages", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*models.AdminJobMessage
+	for rows.Next() {
+		adminMsg := &models.AdminJobMessage{}
+		if err := rows.Scan(
+			&adminMsg.ID,
+			&adminMsg.JobID,
+			&adminMsg.AdminID,
+			&adminMsg.MessageID,
+			&adminMsg.CreatedAt,
+			&adminMsg.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan admin message", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan admin message: %w", err)
+		}
+		messages = append(messages, adminMsg)
+	}
+
+	return messages, nil
+}
+
+// Delete deletes an admin message
+func (r *adminMessageRepo) Delete(ctx context.Context, jobID, adminID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM admin_job_messages WHERE job_id = $1 AND admin_id = $2`, jobID, adminID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete admin message", logger.Error(err))
+		return fmt.Errorf("failed to delete admin message: %w", err)
+	}
+	return nil
+}
+
+// DeleteAllByJobID deletes all admin messages for a job
+func (r *adminMessageRepo) DeleteAllByJobID(ctx context.Context, jobID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM admin_job_messages WHERE job_id = $1`, jobID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete all admin messages for job", logger.Error(err))
+		return fmt.Errorf("failed to delete all admin messages for job: %w", err)
+	}
+	return nil
+}
+
+// DeleteForClosedJobsBefore deletes the admin messages of jobs finished before the cutoff
+func (r *adminMessageRepo) DeleteForClosedJobsBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		DELETE FROM admin_job_messages
+		WHERE job_id IN (
+			SELECT id FROM jobs
+			WHERE status IN ('COMPLETED', 'CANCELLED') AND datetime(updated_at) < datetime($1)
+		)
+	`
+	result, err := r.db.ExecContext(ctx, query, before.UTC())
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete admin messages of closed jobs", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete admin messages of closed jobs: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted admin messages: %w", err)
+	}
+	return int(n), nil
+}
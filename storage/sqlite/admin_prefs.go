@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type adminPrefsRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewAdminPrefsRepo creates a new admin notification preferences repository
+func NewAdminPrefsRepo(db *sql.DB, log logger.LoggerI) storage.AdminPrefsRepoI {
+	return &adminPrefsRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Get retrieves an admin's preferences, falling back to defaults when no row exists
+func (r *adminPrefsRepo) Get(ctx context.Context, adminID int64) (*models.AdminNotificationPrefs, error) {
+	query := `
+		SELECT admin_id, new_jobs, payments, expirations, daily_digest, profile_changes, created_at, updated_at
+		FROM admin_notification_prefs
+		WHERE admin_id = $1
+	`
+
+	prefs := &models.AdminNotificationPrefs{}
+	err := r.db.QueryRowContext(ctx, query, adminID).Scan(
+		&prefs.AdminID,
+		&prefs.NewJobs,
+		&prefs.Payments,
+		&prefs.Expirations,
+		&prefs.DailyDigest,
+		&prefs.ProfileEdit,
+		&prefs.CreatedAt,
+		&prefs.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultAdminNotificationPrefs(adminID), nil
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get admin notification prefs", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin notification prefs: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// Upsert creates or updates an admin's preferences
+func (r *adminPrefsRepo) Upsert(ctx context.Context, prefs *models.AdminNotificationPrefs) error {
+	query := `
+		INSERT INTO admin_notification_prefs (admin_id, new_jobs, payments, expirations, daily_digest, profile_changes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (admin_id)
+		DO UPDATE SET new_jobs = excluded.new_jobs, payments = excluded.payments,
+			expirations = excluded.expirations, daily_digest = excluded.daily_digest,
+			profile_changes = excluded.profile_changes,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, prefs.AdminID, prefs.NewJobs, prefs.Payments, prefs.Expirations, prefs.DailyDigest, prefs.ProfileEdit).
+		Scan(&prefs.CreatedAt, &prefs.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to upsert admin notification prefs", logger.Error(err))
+		return fmt.Errorf("failed to upsert admin notification prefs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type auditRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewAuditRepo creates a new SQLite audit log repository
+func NewAuditRepo(db *sql.DB, log logger.LoggerI) storage.AuditRepoI {
+	return &auditRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create appends an entry to the audit log
+func (r *auditRepo) Create(ctx context.Context, entry *models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, target_user_id, details, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetUserID,
+		entry.Details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create audit entry", logger.Error(err))
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdminActivity counts each admin's jobs, receipt reviews and blocks in [from, to).
+// A review is approved when the booking got confirmed; otherwise the admin's last decision was a rejection.
+// Review time is only measured when the receipt the admin saw is still the stored one.
+func (r *auditRepo) GetAdminActivity(ctx context.Context, from, to time.Time) ([]*models.AdminActivity, error) {
+	query := `
+		SELECT admin_id, SUM(jobs), SUM(approved), SUM(rejected), SUM(timed), SUM(review_seconds), SUM(blocks)
+		FROM (
+			SELECT created_by_admin_id AS admin_id, 1 AS jobs, 0 AS approved, 0 AS rejected,
+				0 AS timed, 0.0 AS review_seconds, 0 AS blocks
+			FROM jobs
+			WHERE created_by_admin_id <> 0 AND datetime(created_at) >= datetime($1) AND datetime(created_at) < datetime($2)
+			UNION ALL
+			SELECT reviewed_by_admin_id, 0,
+				CASE WHEN confirmed_at IS NOT NULL THEN 1 ELSE 0 END,
+				CASE WHEN confirmed_at IS NULL THEN 1 ELSE 0 END,
+				CASE WHEN julianday(reviewed_at) >= julianday(payment_submitted_at) THEN 1 ELSE 0 END,
+				CASE WHEN julianday(reviewed_at) >= julianday(payment_submitted_at)
+					THEN (julianday(reviewed_at) - julianday(payment_submitted_at)) * 86400.0 ELSE 0.0 END,
+				0
+			FROM job_bookings
+			WHERE reviewed_by_admin_id IS NOT NULL AND payment_submitted_at IS NOT NULL
+				AND datetime(reviewed_at) >= datetime($1) AND datetime(reviewed_at) < datetime($2)
+			UNION ALL
+			SELECT actor_id, 0, 0, 0, 0, 0.0, 1
+			FROM audit_log
+			WHERE action = $3 AND actor_id IS NOT NULL AND datetime(created_at) >= datetime($1) AND datetime(created_at) < datetime($2)
+		) activity
+		GROUP BY admin_id
+		ORDER BY SUM(jobs) + SUM(approved) + SUM(rejected) + SUM(blocks) DESC, admin_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from.UTC(), to.UTC(), models.AuditActionUserBlock)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get admin activity", logger.Error(err))
+		return nil, fmt.Errorf("failed to get admin activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activity []*models.AdminActivity
+	for rows.Next() {
+		a := &models.AdminActivity{}
+		var timed int
+		var reviewSeconds float64
+		if err := rows.Scan(&a.AdminID, &a.JobsCreated, &a.Approved, &a.Rejected, &timed, &reviewSeconds, &a.Blocks); err != nil {
+			return nil, fmt.Errorf("failed to scan admin activity: %w", err)
+		}
+		if timed > 0 {
+			a.AvgReview = time.Duration(reviewSeconds / float64(timed) * float64(time.Second))
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
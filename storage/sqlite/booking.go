@@ -0,0 +1,800 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/fieldcrypt"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const bookingColumns = `
+	id, job_id, user_id, status, payment_receipt_file_id, payment_receipt_message_id,
+	payment_instruction_message_id, reserved_at, expires_at, payment_submitted_at, confirmed_at,
+	reviewed_by_admin_id, reviewed_at, rejection_reason, payment_rejections, idempotency_key,
+	attendance, receipt_archive_url, receipt_archive_extra_urls, payment_receipt_extra_file_ids, payment_receipt_is_document, paid_amount,
+	status_message_id, created_at, updated_at`
+
+// bookingRepo implements storage.BookingRepoI interface using SQLite
+type bookingRepo struct {
+	db    *sql.DB
+	log   logger.LoggerI
+	crypt *fieldcrypt.Cipher // Hashes phones for registered_users.phone_hash lookups
+}
+
+// NewBookingRepo creates a new SQLite booking repository
+func NewBookingRepo(db *sql.DB, log logger.LoggerI, crypt *fieldcrypt.Cipher) storage.BookingRepoI {
+	return &bookingRepo{
+		db:    db,
+		log:   log,
+		crypt: crypt,
+	}
+}
+
+// scanBooking scans a row selected with bookingColumns
+func scanBooking(row scanner) (*models.JobBooking, error) {
+	booking := &models.JobBooking{}
+	var paymentReceiptFileID, rejectionReason, attendance, receiptArchiveURL, archiveExtraURLs, extraFileIDs sql.NullString
+	var paymentReceiptMsgID, paymentInstructionMsgID, reviewedByAdminID, statusMessageID sql.NullInt64
+	var paymentSubmittedAt, confirmedAt, reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+		&paymentReceiptFileID, &paymentReceiptMsgID, &paymentInstructionMsgID,
+		&booking.ReservedAt, &booking.ExpiresAt, &paymentSubmittedAt, &confirmedAt,
+		&reviewedByAdminID, &reviewedAt, &rejectionReason, &booking.PaymentRejections, &booking.IdempotencyKey,
+		&attendance, &receiptArchiveURL, &archiveExtraURLs, &extraFileIDs, &booking.PaymentReceiptIsDocument, &booking.PaidAmount,
+		&statusMessageID, &booking.CreatedAt, &booking.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	booking.PaymentReceiptFileID = paymentReceiptFileID.String
+	booking.PaymentReceiptMsgID = paymentReceiptMsgID.Int64
+	booking.PaymentInstructionMsgID = paymentInstructionMsgID.Int64
+	booking.StatusMessageID = statusMessageID.Int64
+	booking.RejectionReason = rejectionReason.String
+	booking.Attendance = models.AttendanceStatus(attendance.String)
+	booking.ReceiptArchiveURL = receiptArchiveURL.String
+	booking.ReceiptArchiveExtraURLs = splitURLs(archiveExtraURLs.String)
+	booking.PaymentReceiptExtraFileIDs = splitFileIDs(extraFileIDs.String)
+	if paymentSubmittedAt.Valid {
+		booking.PaymentSubmittedAt = &paymentSubmittedAt.Time
+	}
+	if confirmedAt.Valid {
+		booking.ConfirmedAt = &confirmedAt.Time
+	}
+	if reviewedByAdminID.Valid {
+		booking.ReviewedByAdminID = &reviewedByAdminID.Int64
+	}
+	if reviewedAt.Valid {
+		booking.ReviewedAt = &reviewedAt.Time
+	}
+
+	return booking, nil
+}
+
+// Create creates a new booking (must be called within transaction)
+func (r *bookingRepo) Create(ctx context.Context, tx any, booking *models.JobBooking) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO job_bookings (
+			job_id, user_id, status, reserved_at, expires_at, idempotency_key
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (idempotency_key)
+		DO UPDATE SET
+			status = excluded.status,
+			reserved_at = excluded.reserved_at,
+			expires_at = excluded.expires_at,
+			-- A rebooking reuses the old row: start its receipt rounds, amount and status message afresh
+			payment_rejections = 0,
+			paid_amount = 0,
+			status_message_id = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`
+
+	err = q.QueryRowContext(ctx, query,
+		booking.JobID,
+		booking.UserID,
+		booking.Status,
+		booking.ReservedAt,
+		booking.ExpiresAt,
+		booking.IdempotencyKey,
+	).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create booking", logger.Error(err))
+		return fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a booking by ID
+func (r *bookingRepo) GetByID(ctx context.Context, id int64) (*models.JobBooking, error) {
+	query := `SELECT ` + bookingColumns + ` FROM job_bookings WHERE id = $1`
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get booking", logger.Error(err))
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+// GetByIDForUpdate retrieves a booking inside the transaction (write lock is held by the IMMEDIATE transaction)
+func (r *bookingRepo) GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.JobBooking, error) {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + bookingColumns + ` FROM job_bookings WHERE id = $1`
+
+	booking, err := scanBooking(q.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking for update: %w", err)
+	}
+
+	return booking, nil
+}
+
+// GetByUserAndJob retrieves a booking by user and job
+func (r *bookingRepo) GetByUserAndJob(ctx context.Context, userID, jobID int64) (*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE user_id = $1 AND job_id = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, userID, jobID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by user and job: %w", err)
+	}
+
+	return booking, nil
+}
+
+// GetByIdempotencyKey retrieves a booking by idempotency key (within transaction)
+func (r *bookingRepo) GetByIdempotencyKey(ctx context.Context, tx any, key string) (*models.JobBooking, error) {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, job_id, user_id, status, reserved_at, expires_at, created_at, updated_at
+		FROM job_bookings
+		WHERE idempotency_key = $1
+	`
+
+	booking := &models.JobBooking{}
+	err = q.QueryRowContext(ctx, query, key).Scan(
+		&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+		&booking.ReservedAt, &booking.ExpiresAt, &booking.CreatedAt, &booking.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by idempotency key: %w", err)
+	}
+
+	booking.IdempotencyKey = key
+	return booking, nil
+}
+
+// GetActiveByJobAndPhone finds another account's active booking on the job under the same registered phone
+func (r *bookingRepo) GetActiveByJobAndPhone(ctx context.Context, tx any, jobID int64, phone string, excludeUserID int64, now time.Time) (*models.JobBooking, error) {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT b.id, b.job_id, b.user_id, b.status, b.reserved_at, b.expires_at, b.created_at, b.updated_at
+		FROM job_bookings b
+		JOIN registered_users ru ON ru.user_id = b.user_id
+		WHERE b.job_id = $1 AND (ru.phone_hash = $5 OR ru.phone = $2) AND b.user_id <> $3
+		  AND (b.status IN ('PAYMENT_SUBMITTED', 'CONFIRMED')
+		       OR (b.status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE') AND datetime(b.expires_at) > datetime($4)))
+		ORDER BY b.created_at
+		LIMIT 1
+	`
+
+	booking := &models.JobBooking{}
+	err = q.QueryRowContext(ctx, query, jobID, phone, excludeUserID, now.UTC(), phoneLookup(r.crypt, phone)).Scan(
+		&booking.ID, &booking.JobID, &booking.UserID, &booking.Status,
+		&booking.ReservedAt, &booking.ExpiresAt, &booking.CreatedAt, &booking.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by phone: %w", err)
+	}
+
+	return booking, nil
+}
+
+// Update updates a booking's payment and review fields; the status is only written by UpdateStatus and the Mark* methods
+func (r *bookingRepo) Update(ctx context.Context, tx any, booking *models.JobBooking) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET payment_receipt_file_id = $2, payment_receipt_message_id = $3,
+			payment_instruction_message_id = $4, payment_submitted_at = $5, confirmed_at = $6,
+			reviewed_by_admin_id = $7, reviewed_at = $8, rejection_reason = $9,
+			payment_receipt_extra_file_ids = $10, payment_receipt_is_document = $11, paid_amount = $12, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err = q.ExecContext(ctx, query,
+		booking.ID,
+		toNullString(booking.PaymentReceiptFileID),
+		toNullInt64(booking.PaymentReceiptMsgID),
+		toNullInt64(booking.PaymentInstructionMsgID),
+		toNullTime(booking.PaymentSubmittedAt),
+		toNullTime(booking.ConfirmedAt),
+		toNullInt64Ptr(booking.ReviewedByAdminID),
+		toNullTime(booking.ReviewedAt),
+		toNullString(booking.RejectionReason),
+		toNullString(joinFileIDs(booking.PaymentReceiptExtraFileIDs)),
+		booking.PaymentReceiptIsDocument,
+		booking.PaidAmount,
+	)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update booking", logger.Error(err))
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a booking
+func (r *bookingRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM job_bookings WHERE id = $1`, id)
+	return err
+}
+
+// GetExpiredBookings retrieves bookings that have expired, oldest deadline first.
+// datetime() normalizes both sides to UTC, since stored timestamps may carry different offsets.
+// SQLite has no row locks; the transaction's write lock serializes the claim instead.
+func (r *bookingRepo) GetExpiredBookings(ctx context.Context, tx any, now time.Time, limit int) ([]*models.JobBooking, error) {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, job_id, user_id, status, payment_instruction_message_id
+		FROM job_bookings
+		WHERE status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE')
+		  AND datetime(expires_at) < datetime($1)
+		ORDER BY datetime(expires_at)
+		LIMIT $2
+	`
+
+	rows, err := q.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get expired bookings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get expired bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.JobBooking
+	for rows.Next() {
+		booking := &models.JobBooking{}
+		var msgID sql.NullInt64
+
+		if err := rows.Scan(&booking.ID, &booking.JobID, &booking.UserID, &booking.Status, &msgID); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan expired booking", logger.Error(err))
+			continue
+		}
+
+		booking.PaymentInstructionMsgID = msgID.Int64
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
+// CountExpired counts awaiting-receipt bookings past expires_at that the expiry worker hasn't released yet
+func (r *bookingRepo) CountExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM job_bookings
+		WHERE status IN ('SLOT_RESERVED', 'PAYMENT_REJECTED_RETRYABLE')
+		  AND datetime(expires_at) < datetime($1)
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, now).Scan(&count); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to count expired bookings", logger.Error(err))
+		return 0, fmt.Errorf("failed to count expired bookings: %w", err)
+	}
+	return count, nil
+}
+
+// GetActiveReservations retrieves unexpired reservations whose payment instruction message is known
+func (r *bookingRepo) GetActiveReservations(ctx context.Context, now time.Time, limit int) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE status = 'SLOT_RESERVED'
+		  AND datetime(expires_at) > datetime($1)
+		  AND payment_instruction_message_id IS NOT NULL
+		ORDER BY datetime(expires_at) ASC
+		LIMIT $2
+	`
+	return r.queryBookings(ctx, "failed to get active reservations", query, now, limit)
+}
+
+// GetPendingApprovals retrieves bookings waiting for admin approval
+func (r *bookingRepo) GetPendingApprovals(ctx context.Context) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE status = 'PAYMENT_SUBMITTED'
+		ORDER BY payment_submitted_at ASC
+	`
+
+	return r.queryBookings(ctx, "failed to get pending approvals", query)
+}
+
+// GetUserBookings retrieves all bookings for a user
+func (r *bookingRepo) GetUserBookings(ctx context.Context, userID int64) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.queryBookings(ctx, "failed to get user bookings", query, userID)
+}
+
+// GetUserBookingsByStatus retrieves user bookings filtered by status
+func (r *bookingRepo) GetUserBookingsByStatus(ctx context.Context, userID int64, status models.BookingStatus) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE user_id = $1 AND status = $2
+		ORDER BY created_at DESC
+	`
+
+	return r.queryBookings(ctx, "failed to get user bookings by status", query, userID, status)
+}
+
+// GetJobBookings retrieves all bookings for a job
+func (r *bookingRepo) GetJobBookings(ctx context.Context, jobID int64) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE job_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.queryBookings(ctx, "failed to get job bookings", query, jobID)
+}
+
+// queryBookings runs a query selecting bookingColumns and scans every row
+func (r *bookingRepo) queryBookings(ctx context.Context, errMsg, query string, args ...any) ([]*models.JobBooking, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.JobBooking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan booking", logger.Error(err))
+			continue
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
+// UpdateStatus updates booking status
+func (r *bookingRepo) UpdateStatus(ctx context.Context, tx any, bookingID int64, status models.BookingStatus) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx, `UPDATE job_bookings SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, bookingID, status)
+	return err
+}
+
+// MarkAsExpired marks a booking as expired
+func (r *bookingRepo) MarkAsExpired(ctx context.Context, tx any, bookingID int64) error {
+	return r.UpdateStatus(ctx, tx, bookingID, models.BookingStatusExpired)
+}
+
+// MarkAsConfirmed marks a booking as confirmed by admin
+func (r *bookingRepo) MarkAsConfirmed(ctx context.Context, tx any, bookingID int64, adminID int64) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET status = 'CONFIRMED',
+			confirmed_at = $3,
+			reviewed_by_admin_id = $2,
+			reviewed_at = $3,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err = q.ExecContext(ctx, query, bookingID, adminID, time.Now())
+	return err
+}
+
+// MarkAsRejected marks a booking as rejected by admin
+func (r *bookingRepo) MarkAsRejected(ctx context.Context, tx any, bookingID int64, adminID int64, reason string) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET status = 'REJECTED',
+			rejection_reason = $2,
+			reviewed_by_admin_id = $3,
+			reviewed_at = $4,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err = q.ExecContext(ctx, query, bookingID, reason, adminID, time.Now())
+	return err
+}
+
+// MarkAsRetryable rejects the receipt but keeps the slot held until expiresAt
+func (r *bookingRepo) MarkAsRetryable(ctx context.Context, tx any, bookingID int64, adminID int64, reason string, expiresAt time.Time) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET status = 'PAYMENT_REJECTED_RETRYABLE',
+			rejection_reason = $2,
+			reviewed_by_admin_id = $3,
+			reviewed_at = $4,
+			expires_at = $5,
+			payment_rejections = payment_rejections + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err = q.ExecContext(ctx, query, bookingID, reason, adminID, time.Now(), expiresAt)
+	return err
+}
+
+// AddEvent appends a status change to the booking's history
+func (r *bookingRepo) AddEvent(ctx context.Context, tx any, event *models.BookingEvent) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO booking_events (booking_id, from_status, to_status, actor_id, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		RETURNING id, created_at
+	`
+	err = q.QueryRowContext(ctx, query, event.BookingID, event.FromStatus, event.ToStatus, event.ActorID, event.Note).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to add booking event", logger.Error(err))
+		return fmt.Errorf("failed to add booking event: %w", err)
+	}
+	return nil
+}
+
+// GetEvents returns the booking's status history, oldest first
+func (r *bookingRepo) GetEvents(ctx context.Context, bookingID int64) ([]*models.BookingEvent, error) {
+	query := `
+		SELECT id, booking_id, from_status, to_status, actor_id, note, created_at
+		FROM booking_events
+		WHERE booking_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get booking events", logger.Error(err))
+		return nil, fmt.Errorf("failed to get booking events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.BookingEvent
+	for rows.Next() {
+		event := &models.BookingEvent{}
+		if err := rows.Scan(&event.ID, &event.BookingID, &event.FromStatus, &event.ToStatus,
+			&event.ActorID, &event.Note, &event.CreatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan booking event", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan booking event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// SetAttendance records whether a confirmed worker showed up
+func (r *bookingRepo) SetAttendance(ctx context.Context, bookingID int64, attendance models.AttendanceStatus) error {
+	query := `
+		UPDATE job_bookings
+		SET attendance = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, bookingID, toNullString(string(attendance)))
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking attendance", logger.Error(err))
+		return fmt.Errorf("failed to set booking attendance: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnarchivedReceipts retrieves approved bookings whose receipt isn't in the archive yet, oldest approval first
+func (r *bookingRepo) GetUnarchivedReceipts(ctx context.Context, limit int) ([]*models.JobBooking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM job_bookings
+		WHERE receipt_archive_url IS NULL
+		  AND payment_receipt_file_id IS NOT NULL
+		  AND confirmed_at IS NOT NULL
+		ORDER BY confirmed_at ASC
+		LIMIT $1
+	`
+
+	return r.queryBookings(ctx, "failed to get unarchived receipts", query, limit)
+}
+
+// SetReceiptArchiveURLs records where the archived receipt files are stored, in ReceiptFileIDs order
+func (r *bookingRepo) SetReceiptArchiveURLs(ctx context.Context, bookingID int64, urls []string) error {
+	if len(urls) == 0 {
+		return errors.New("no receipt archive URLs")
+	}
+
+	query := `
+		UPDATE job_bookings
+		SET receipt_archive_url = $2, receipt_archive_extra_urls = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, bookingID, urls[0], toNullString(joinURLs(urls[1:])))
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set receipt archive URL", logger.Error(err))
+		return fmt.Errorf("failed to set receipt archive URL: %w", err)
+	}
+
+	return nil
+}
+
+// SetStatusMessageID records the user's status message of the booking
+func (r *bookingRepo) SetStatusMessageID(ctx context.Context, bookingID int64, messageID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET status_message_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, bookingID, messageID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking status message", logger.Error(err))
+		return fmt.Errorf("failed to set booking status message: %w", err)
+	}
+
+	return nil
+}
+
+// SetPaymentInstructionMsgID records the payment instruction message of the booking, leaving the status alone
+func (r *bookingRepo) SetPaymentInstructionMsgID(ctx context.Context, bookingID int64, messageID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET payment_instruction_message_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, bookingID, messageID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set booking payment instruction message", logger.Error(err))
+		return fmt.Errorf("failed to set booking payment instruction message: %w", err)
+	}
+
+	return nil
+}
+
+// AnonymizeUserBookings clears payment receipts and message references from a user's bookings
+func (r *bookingRepo) AnonymizeUserBookings(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE job_bookings
+		SET payment_receipt_file_id = NULL,
+			payment_receipt_extra_file_ids = NULL,
+			payment_receipt_message_id = NULL,
+			payment_instruction_message_id = NULL,
+			status_message_id = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to anonymize user bookings", logger.Error(err))
+		return fmt.Errorf("failed to anonymize user bookings: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions for null handling
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: i != 0}
+}
+
+func toNullInt64Ptr(p *int64) sql.NullInt64 {
+	if p == nil {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func toNullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{Valid: false}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// joinFileIDs stores album file IDs in one column; Telegram file IDs never contain commas
+func joinFileIDs(ids []string) string {
+	return strings.Join(ids, ",")
+}
+
+func splitFileIDs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// joinURLs stores archive URLs in one column, one per line
+func joinURLs(urls []string) string {
+	return strings.Join(urls, "\n")
+}
+
+func splitURLs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// GetTotalCount returns the total number of bookings
+func (r *bookingRepo) GetTotalCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_bookings`).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get total booking count: " + err.Error())
+		return 0, fmt.Errorf("failed to get total booking count: %w", err)
+	}
+	return count, nil
+}
+
+// GetCountByStatus returns the number of bookings with a given status
+func (r *bookingRepo) GetCountByStatus(ctx context.Context, status models.BookingStatus) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_bookings WHERE status = $1`, status).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get booking count by status: " + err.Error())
+		return 0, fmt.Errorf("failed to get booking count by status: %w", err)
+	}
+	return count, nil
+}
+
+// GetStatsForPeriod aggregates confirmed/rejected/expired bookings in [from, to).
+// Expired bookings are dated by updated_at since MarkAsExpired has no dedicated column.
+func (r *bookingRepo) GetStatsForPeriod(ctx context.Context, from, to time.Time) (*models.BookingPeriodStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE b.status = 'CONFIRMED' AND datetime(b.confirmed_at) >= datetime($1) AND datetime(b.confirmed_at) < datetime($2)),
+			COALESCE(SUM(j.service_fee) FILTER (WHERE b.status = 'CONFIRMED' AND datetime(b.confirmed_at) >= datetime($1) AND datetime(b.confirmed_at) < datetime($2)), 0),
+			COUNT(*) FILTER (WHERE b.status = 'REJECTED' AND datetime(b.reviewed_at) >= datetime($1) AND datetime(b.reviewed_at) < datetime($2)),
+			COUNT(*) FILTER (WHERE b.status = 'EXPIRED' AND datetime(b.updated_at) >= datetime($1) AND datetime(b.updated_at) < datetime($2))
+		FROM job_bookings b
+		JOIN jobs j ON j.id = b.job_id
+	`
+
+	stats := &models.BookingPeriodStats{}
+	err := r.db.QueryRowContext(ctx, query, from.UTC(), to.UTC()).Scan(
+		&stats.Confirmed,
+		&stats.Revenue,
+		&stats.Rejected,
+		&stats.Expired,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get booking stats for period", logger.Error(err))
+		return nil, fmt.Errorf("failed to get booking stats for period: %w", err)
+	}
+	return stats, nil
+}
+
+// DeleteClosedBefore deletes a batch of finished unpaid bookings; their events go by ON DELETE CASCADE
+func (r *bookingRepo) DeleteClosedBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM job_bookings
+		WHERE id IN (
+			SELECT id FROM job_bookings
+			WHERE status IN ('EXPIRED', 'REJECTED', 'CANCELLED_BY_USER')
+			  AND datetime(updated_at) < datetime($1)
+			ORDER BY id
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, before.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete closed bookings", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete closed bookings: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted bookings: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const employerColumns = `id, name, phone, notes, rating, contact_chat_id, created_at, updated_at`
+
+type employerRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewEmployerRepo creates a new SQLite employer repository
+func NewEmployerRepo(db *sql.DB, log logger.LoggerI) storage.EmployerRepoI {
+	return &employerRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// scanEmployer scans a row selected with employerColumns
+func scanEmployer(row scanner) (*models.Employer, error) {
+	employer := &models.Employer{}
+	var notes sql.NullString
+
+	err := row.Scan(
+		&employer.ID, &employer.Name, &employer.Phone, &notes,
+		&employer.Rating, &employer.ContactChatID, &employer.CreatedAt, &employer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	employer.Notes = notes.String
+	return employer, nil
+}
+
+// Create creates a new employer
+func (r *employerRepo) Create(ctx context.Context, employer *models.Employer) error {
+	query := `
+		INSERT INTO employers (name, phone, notes, rating, contact_chat_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		employer.Name,
+		employer.Phone,
+		toNullString(employer.Notes),
+		employer.Rating,
+		employer.ContactChatID,
+	).Scan(&employer.ID, &employer.CreatedAt, &employer.UpdatedAt)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create employer", logger.Error(err))
+		return fmt.Errorf("failed to create employer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an employer by ID
+func (r *employerRepo) GetByID(ctx context.Context, id int64) (*models.Employer, error) {
+	query := `SELECT ` + employerColumns + ` FROM employers WHERE id = $1`
+	return r.getOne(ctx, query, id)
+}
+
+// GetByPhone retrieves an employer by phone number
+func (r *employerRepo) GetByPhone(ctx context.Context, phone string) (*models.Employer, error) {
+	query := `SELECT ` + employerColumns + ` FROM employers WHERE phone = $1`
+	return r.getOne(ctx, query, phone)
+}
+
+func (r *employerRepo) getOne(ctx context.Context, query string, arg any) (*models.Employer, error) {
+	employer, err := scanEmployer(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get employer", logger.Error(err))
+		return nil, fmt.Errorf("failed to get employer: %w", err)
+	}
+	return employer, nil
+}
+
+// GetAll returns all employers ordered by name
+func (r *employerRepo) GetAll(ctx context.Context) ([]*models.Employer, error) {
+	query := `SELECT ` + employerColumns + ` FROM employers ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get employers", logger.Error(err))
+		return nil, fmt.Errorf("failed to get employers: %w", err)
+	}
+	defer rows.Close()
+
+	var employers []*models.Employer
+	for rows.Next() {
+		employer, err := scanEmployer(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan employer", logger.Error(err))
+			continue
+		}
+		employers = append(employers, employer)
+	}
+
+	return employers, nil
+}
+
+// Update updates an employer
+func (r *employerRepo) Update(ctx context.Context, employer *models.Employer) error {
+	query := `
+		UPDATE employers
+		SET name = $2, phone = $3, notes = $4, rating = $5, contact_chat_id = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		employer.ID,
+		employer.Name,
+		employer.Phone,
+		toNullString(employer.Notes),
+		employer.Rating,
+		employer.ContactChatID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to update employer", logger.Error(err))
+		return fmt.Errorf("failed to update employer: %w", err)
+	}
+
+	return nil
+}
+
+// GetStats aggregates job and attendance history for an employer
+func (r *employerRepo) GetStats(ctx context.Context, employerID int64) (*models.EmployerStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM jobs WHERE employer_id = $1),
+			(SELECT COUNT(*) FROM jobs WHERE employer_id = $1 AND status = 'COMPLETED'),
+			COUNT(*) FILTER (WHERE b.status = 'CONFIRMED'),
+			COUNT(*) FILTER (WHERE b.attendance = 'ATTENDED'),
+			COUNT(*) FILTER (WHERE b.attendance = 'NO_SHOW')
+		FROM job_bookings b
+		JOIN jobs j ON j.id = b.job_id
+		WHERE j.employer_id = $1
+	`
+
+	stats := &models.EmployerStats{}
+	err := r.db.QueryRowContext(ctx, query, employerID).Scan(
+		&stats.TotalJobs,
+		&stats.CompletedJobs,
+		&stats.ConfirmedWorkers,
+		&stats.Attended,
+		&stats.NoShows,
+	)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get employer stats", logger.Error(err))
+		return nil, fmt.Errorf("failed to get employer stats: %w", err)
+	}
+
+	return stats, nil
+}
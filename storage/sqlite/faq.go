@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const faqColumns = `id, category, question, answer, created_at, updated_at`
+
+type faqRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewFAQRepo creates a new SQLite FAQ repository
+func NewFAQRepo(db *sql.DB, log logger.LoggerI) storage.FAQRepoI {
+	return &faqRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// scanFAQEntry scans a row selected with faqColumns
+func scanFAQEntry(row scanner) (*models.FAQEntry, error) {
+	entry := &models.FAQEntry{}
+	err := row.Scan(&entry.ID, &entry.Category, &entry.Question, &entry.Answer, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Create creates a new FAQ entry
+func (r *faqRepo) Create(ctx context.Context, entry *models.FAQEntry) error {
+	query := `
+		INSERT INTO faq_entries (category, question, answer, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, entry.Category, entry.Question, entry.Answer).
+		Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to create FAQ entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an FAQ entry by ID
+func (r *faqRepo) GetByID(ctx context.Context, id int64) (*models.FAQEntry, error) {
+	query := `SELECT ` + faqColumns + ` FROM faq_entries WHERE id = $1`
+
+	entry, err := scanFAQEntry(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get FAQ entry", logger.Error(err))
+		return nil, fmt.Errorf("failed to get FAQ entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetAll returns every entry in creation order
+func (r *faqRepo) GetAll(ctx context.Context) ([]*models.FAQEntry, error) {
+	query := `SELECT ` + faqColumns + ` FROM faq_entries ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get FAQ entries", logger.Error(err))
+		return nil, fmt.Errorf("failed to get FAQ entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.FAQEntry
+	for rows.Next() {
+		entry, err := scanFAQEntry(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan FAQ entry", logger.Error(err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Update updates category, question and answer
+func (r *faqRepo) Update(ctx context.Context, entry *models.FAQEntry) error {
+	query := `
+		UPDATE faq_entries
+		SET category = $2, question = $3, answer = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	res, err := r.db.ExecContext(ctx, query, entry.ID, entry.Category, entry.Question, entry.Answer)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to update FAQ entry: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an FAQ entry
+func (r *faqRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM faq_entries WHERE id = $1`, id)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete FAQ entry", logger.Error(err))
+		return fmt.Errorf("failed to delete FAQ entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type publicOfferRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewPublicOfferRepo creates a new SQLite public offer repository
+func NewPublicOfferRepo(db *sql.DB, log logger.LoggerI) storage.PublicOfferRepoI {
+	return &publicOfferRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Publish stores a new version numbered one above the latest
+func (r *publicOfferRepo) Publish(ctx context.Context, offer *models.PublicOffer) error {
+	query := `
+		INSERT INTO public_offers (version, content, created_by_admin_id, published_at)
+		SELECT COALESCE(MAX(version), 0) + 1, $1, $2, CURRENT_TIMESTAMP FROM public_offers
+		RETURNING id, version, published_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, offer.Content, toNullInt64(offer.CreatedByAdminID)).
+		Scan(&offer.ID, &offer.Version, &offer.PublishedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to publish public offer", logger.Error(err))
+		return fmt.Errorf("failed to publish public offer: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest returns the newest published version
+func (r *publicOfferRepo) GetLatest(ctx context.Context) (*models.PublicOffer, error) {
+	query := `
+		SELECT id, version, content, created_by_admin_id, published_at
+		FROM public_offers
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	offer := &models.PublicOffer{}
+	var adminID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, query).Scan(&offer.ID, &offer.Version, &offer.Content, &adminID, &offer.PublishedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get public offer", logger.Error(err))
+		return nil, fmt.Errorf("failed to get public offer: %w", err)
+	}
+
+	offer.CreatedByAdminID = adminID.Int64
+	return offer, nil
+}
+
+// RecordAcceptance logs that a user accepted a version
+func (r *publicOfferRepo) RecordAcceptance(ctx context.Context, acceptance *models.OfferAcceptance) error {
+	query := `
+		INSERT INTO offer_acceptances (user_id, version, accepted_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, version) DO UPDATE SET user_id = excluded.user_id
+		RETURNING id, accepted_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, acceptance.UserID, acceptance.Version).
+		Scan(&acceptance.ID, &acceptance.AcceptedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to record offer acceptance", logger.Error(err))
+		return fmt.Errorf("failed to record offer acceptance: %w", err)
+	}
+
+	return nil
+}
+
+// GetAcceptedVersion returns the highest version the user accepted, 0 if none
+func (r *publicOfferRepo) GetAcceptedVersion(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COALESCE(MAX(version), 0) FROM offer_acceptances WHERE user_id = $1`
+
+	var version int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&version); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get accepted offer version", logger.Error(err))
+		return 0, fmt.Errorf("failed to get accepted offer version: %w", err)
+	}
+
+	return version, nil
+}
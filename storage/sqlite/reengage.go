@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type reengageRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewReengageRepo creates a new SQLite re-engagement campaign repository
+func NewReengageRepo(db *sql.DB, log logger.LoggerI) storage.ReengageRepoI {
+	return &reengageRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetInactiveUsers returns registered users with no booking and no campaign message since since
+func (r *reengageRepo) GetInactiveUsers(ctx context.Context, since, now time.Time, limit int) ([]int64, error) {
+	query := `
+		SELECT ru.user_id
+		FROM registered_users ru
+		JOIN users u ON u.id = ru.user_id
+		WHERE ru.is_active = 1
+		  AND u.marketing_opt_out = 0
+		  AND datetime(ru.created_at) < datetime($1)
+		  AND NOT EXISTS (SELECT 1 FROM job_bookings b WHERE b.user_id = ru.user_id AND datetime(b.created_at) >= datetime($1))
+		  AND NOT EXISTS (SELECT 1 FROM reengage_recipients rr WHERE rr.user_id = ru.user_id AND datetime(rr.sent_at) >= datetime($1))
+		  AND NOT EXISTS (
+			SELECT 1 FROM blocked_users bu
+			WHERE bu.user_id = ru.user_id AND (bu.blocked_until IS NULL OR datetime(bu.blocked_until) > datetime($2))
+		  )
+		ORDER BY ru.user_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since.UTC(), now.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get inactive users", logger.Error(err))
+		return nil, fmt.Errorf("failed to get inactive users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan inactive user: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CreateCampaign stores a new campaign
+func (r *reengageRepo) CreateCampaign(ctx context.Context, campaign *models.ReengageCampaign) error {
+	query := `
+		INSERT INTO reengage_campaigns (started_by, inactive_days, jobs, recipients)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		campaign.StartedBy,
+		campaign.InactiveDays,
+		campaign.Jobs,
+		campaign.Recipients,
+	).Scan(&campaign.ID, &campaign.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create re-engagement campaign", logger.Error(err))
+		return fmt.Errorf("failed to create re-engagement campaign: %w", err)
+	}
+
+	return nil
+}
+
+// AddRecipient records that the campaign message reached the user
+func (r *reengageRepo) AddRecipient(ctx context.Context, campaignID, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reengage_recipients (campaign_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (campaign_id, user_id) DO NOTHING
+	`, campaignID, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to add campaign recipient", logger.Error(err))
+		return fmt.Errorf("failed to add campaign recipient: %w", err)
+	}
+	return nil
+}
+
+// FinishCampaign stores the sent and failed counts
+func (r *reengageRepo) FinishCampaign(ctx context.Context, campaign *models.ReengageCampaign) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE reengage_campaigns SET sent = $2, failed = $3 WHERE id = $1
+	`, campaign.ID, campaign.Sent, campaign.Failed)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to finish re-engagement campaign", logger.Error(err))
+		return fmt.Errorf("failed to finish re-engagement campaign: %w", err)
+	}
+	return nil
+}
+
+// GetRecent returns the newest campaigns with the link opens and bookings of their recipients
+func (r *reengageRepo) GetRecent(ctx context.Context, limit int) ([]*models.ReengageCampaign, error) {
+	query := `
+		SELECT c.id, c.started_by, c.inactive_days, c.jobs, c.recipients, c.sent, c.failed, c.created_at,
+			(SELECT COUNT(*) FROM reengage_recipients rr WHERE rr.campaign_id = c.id AND EXISTS (
+				SELECT 1 FROM job_link_starts s WHERE s.user_id = rr.user_id AND datetime(s.created_at) >= datetime(rr.sent_at)
+			)),
+			(SELECT COUNT(*) FROM reengage_recipients rr WHERE rr.campaign_id = c.id AND EXISTS (
+				SELECT 1 FROM job_bookings b WHERE b.user_id = rr.user_id AND datetime(b.created_at) >= datetime(rr.sent_at)
+			))
+		FROM reengage_campaigns c
+		ORDER BY c.id DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get re-engagement campaigns", logger.Error(err))
+		return nil, fmt.Errorf("failed to get re-engagement campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*models.ReengageCampaign
+	for rows.Next() {
+		var c models.ReengageCampaign
+		if err := rows.Scan(&c.ID, &c.StartedBy, &c.InactiveDays, &c.Jobs, &c.Recipients, &c.Sent, &c.Failed,
+			&c.CreatedAt, &c.Opened, &c.Booked); err != nil {
+			return nil, fmt.Errorf("failed to scan re-engagement campaign: %w", err)
+		}
+		campaigns = append(campaigns, &c)
+	}
+
+	return campaigns, rows.Err()
+}
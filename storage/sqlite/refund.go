@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+const refundColumns = `id, booking_id, job_id, user_id, amount, status, created_at, paid_at, COALESCE(paid_by, 0)`
+
+type refundRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewRefundRepo creates a new SQLite refund repository
+func NewRefundRepo(db *sql.DB, log logger.LoggerI) storage.RefundRepoI {
+	return &refundRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create records a pending refund; a booking gets at most one
+func (r *refundRepo) Create(ctx context.Context, tx any, refund *models.Refund) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO refunds (booking_id, job_id, user_id, amount, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING id, created_at
+	`
+	err = q.QueryRowContext(ctx, query, refund.BookingID, refund.JobID, refund.UserID, refund.Amount, models.RefundPending).
+		Scan(&refund.ID, &refund.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create refund", logger.Error(err))
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+	refund.Status = models.RefundPending
+	return nil
+}
+
+// GetByID returns a refund
+func (r *refundRepo) GetByID(ctx context.Context, id int64) (*models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE id = $1`
+
+	refund, err := scanRefund(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get refund", logger.Error(err))
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+	return refund, nil
+}
+
+// GetPending returns the refunds still owed, oldest first
+func (r *refundRepo) GetPending(ctx context.Context) ([]*models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE status = 'PENDING' ORDER BY datetime(created_at), id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get pending refunds", logger.Error(err))
+		return nil, fmt.Errorf("failed to get pending refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []*models.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan refund", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+	return refunds, rows.Err()
+}
+
+// MarkPaid marks a pending refund paid and reports whether it was pending
+func (r *refundRepo) MarkPaid(ctx context.Context, id, adminID int64) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE refunds
+		SET status = $2, paid_at = CURRENT_TIMESTAMP, paid_by = $3
+		WHERE id = $1 AND status = 'PENDING'
+	`, id, models.RefundPaid, adminID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to mark refund paid", logger.Error(err))
+		return false, fmt.Errorf("failed to mark refund paid: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark refund paid: %w", err)
+	}
+	return n > 0, nil
+}
+
+func scanRefund(row scanner) (*models.Refund, error) {
+	var refund models.Refund
+	var paidAt sql.NullTime
+	if err := row.Scan(&refund.ID, &refund.BookingID, &refund.JobID, &refund.UserID, &refund.Amount,
+		&refund.Status, &refund.CreatedAt, &paidAt, &refund.PaidBy); err != nil {
+		return nil, err
+	}
+	if paidAt.Valid {
+		refund.PaidAt = &paidAt.Time
+	}
+	return &refund, nil
+}
@@ -0,0 +1,719 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/fieldcrypt"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/pkg/validation"
+	"telegram-bot-starter/storage"
+)
+
+const registeredUserColumns = `id, user_id, full_name, phone, age, weight, height, passport_photo_id, is_active, created_at, updated_at, city, gender`
+
+// registrationRepo implements storage.RegistrationRepoI interface using SQLite
+type registrationRepo struct {
+	db    *sql.DB
+	log   logger.LoggerI
+	crypt *fieldcrypt.Cipher // Encrypts phones and passport photo IDs; nil stores them in plaintext
+}
+
+// NewRegistrationRepo creates a new SQLite registration repository
+func NewRegistrationRepo(db *sql.DB, log logger.LoggerI, crypt *fieldcrypt.Cipher) storage.RegistrationRepoI {
+	return &registrationRepo{
+		db:    db,
+		log:   log,
+		crypt: crypt,
+	}
+}
+
+// sealedFields are the stored forms of a registration's encrypted columns
+type sealedFields struct {
+	phone           string
+	passportPhotoID string
+	phoneHash       *string // NULL when encryption is off
+}
+
+// seal encrypts the phone and passport photo ID for writing
+func (r *registrationRepo) seal(phone, passportPhotoID string) (sealedFields, error) {
+	var f sealedFields
+	var err error
+	if f.phone, err = r.crypt.Encrypt(phone); err != nil {
+		return f, fmt.Errorf("failed to encrypt phone: %w", err)
+	}
+	if f.passportPhotoID, err = r.crypt.Encrypt(passportPhotoID); err != nil {
+		return f, fmt.Errorf("failed to encrypt passport photo ID: %w", err)
+	}
+	f.phoneHash = phoneLookup(r.crypt, phone)
+	return f, nil
+}
+
+// open decrypts a scanned phone and passport photo ID in place
+func (r *registrationRepo) open(phone, passportPhotoID *string) error {
+	var err error
+	if *phone, err = r.crypt.Decrypt(*phone); err != nil {
+		return fmt.Errorf("failed to decrypt phone: %w", err)
+	}
+	if *passportPhotoID, err = r.crypt.Decrypt(*passportPhotoID); err != nil {
+		return fmt.Errorf("failed to decrypt passport photo ID: %w", err)
+	}
+	return nil
+}
+
+// phoneLookup returns the phone_hash query argument for a phone: its keyed hash, or nil when
+// encryption is off. Queries match "phone_hash = hash OR phone = plaintext", which finds both
+// encrypted rows and rows written before encryption was enabled.
+func phoneLookup(crypt *fieldcrypt.Cipher, phone string) *string {
+	if hash := crypt.Lookup(phone); hash != "" {
+		return &hash
+	}
+	return nil
+}
+
+// scanRegisteredUser scans a row selected with registeredUserColumns
+func scanRegisteredUser(row scanner) (*models.RegisteredUser, error) {
+	var user models.RegisteredUser
+	err := row.Scan(
+		&user.ID,
+		&user.UserID,
+		&user.FullName,
+		&user.Phone,
+		&user.Age,
+		&user.Weight,
+		&user.Height,
+		&user.PassportPhotoID,
+		&user.IsActive,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.City,
+		&user.Gender,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CreateDraft creates a new registration draft
+func (r *registrationRepo) CreateDraft(ctx context.Context, draft *models.RegistrationDraft) error {
+	query := `
+		INSERT INTO registration_drafts (user_id, state, previous_state, full_name, phone, age, weight, height, passport_photo_id, created_at, updated_at, pending_job_id, city, gender)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+
+	sealed, err := r.seal(draft.Phone, draft.PassportPhotoID)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
+		draft.UserID,
+		draft.State,
+		draft.PreviousState,
+		draft.FullName,
+		sealed.phone,
+		draft.Age,
+		draft.Weight,
+		draft.Height,
+		sealed.passportPhotoID,
+		draft.CreatedAt,
+		draft.UpdatedAt,
+		draft.PendingJobID,
+		draft.City,
+		draft.Gender,
+	).Scan(&draft.ID)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to create registration draft: " + err.Error())
+		return fmt.Errorf("failed to create registration draft: %w", err)
+	}
+
+	return nil
+}
+
+// GetDraftByUserID retrieves a draft by user ID
+func (r *registrationRepo) GetDraftByUserID(ctx context.Context, userID int64) (*models.RegistrationDraft, error) {
+	query := `
+		SELECT id, user_id, state, previous_state, full_name, phone, age, weight, height, passport_photo_id, created_at, updated_at, pending_job_id, city, gender
+		FROM registration_drafts
+		WHERE user_id = $1
+	`
+
+	var draft models.RegistrationDraft
+	var fullName, phone, passportPhotoID, city sql.NullString
+	var age, weight, height sql.NullInt64
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&draft.ID,
+		&draft.UserID,
+		&draft.State,
+		&draft.PreviousState,
+		&fullName,
+		&phone,
+		&age,
+		&weight,
+		&height,
+		&passportPhotoID,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+		&draft.PendingJobID,
+		&city,
+		&draft.Gender,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get registration draft: " + err.Error())
+		return nil, fmt.Errorf("failed to get registration draft: %w", err)
+	}
+
+	draft.FullName = fullName.String
+	draft.Phone = phone.String
+	draft.Age = int(age.Int64)
+	draft.Weight = int(weight.Int64)
+	draft.Height = int(height.Int64)
+	draft.PassportPhotoID = passportPhotoID.String
+	draft.City = city.String
+	if err := r.open(&draft.Phone, &draft.PassportPhotoID); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+// UpdateDraft updates an existing draft
+func (r *registrationRepo) UpdateDraft(ctx context.Context, draft *models.RegistrationDraft) error {
+	query := `
+		UPDATE registration_drafts
+		SET state = $2, previous_state = $3, full_name = $4, phone = $5, age = $6, weight = $7, height = $8, passport_photo_id = $9, updated_at = $10, pending_job_id = $11, city = $12, gender = $13
+		WHERE user_id = $1
+	`
+
+	sealed, err := r.seal(draft.Phone, draft.PassportPhotoID)
+	if err != nil {
+		return err
+	}
+	draft.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		draft.UserID,
+		draft.State,
+		draft.PreviousState,
+		draft.FullName,
+		sealed.phone,
+		draft.Age,
+		draft.Weight,
+		draft.Height,
+		sealed.passportPhotoID,
+		draft.UpdatedAt,
+		draft.PendingJobID,
+		draft.City,
+		draft.Gender,
+	)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update registration draft: " + err.Error())
+		return fmt.Errorf("failed to update registration draft: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// DeleteDraft deletes a draft by user ID
+func (r *registrationRepo) DeleteDraft(ctx context.Context, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM registration_drafts WHERE user_id = $1`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete registration draft: " + err.Error())
+		return fmt.Errorf("failed to delete registration draft: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// CreateRegisteredUser creates a new fully registered user
+func (r *registrationRepo) CreateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error {
+	query := `
+		INSERT INTO registered_users (user_id, full_name, phone, age, weight, height, passport_photo_id, is_active, created_at, updated_at, city, gender, phone_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+
+	sealed, err := r.seal(user.Phone, user.PassportPhotoID)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
+		user.UserID,
+		user.FullName,
+		sealed.phone,
+		user.Age,
+		user.Weight,
+		user.Height,
+		sealed.passportPhotoID,
+		user.IsActive,
+		user.CreatedAt,
+		user.UpdatedAt,
+		user.City,
+		user.Gender,
+		sealed.phoneHash,
+	).Scan(&user.ID)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create registered user: " + err.Error())
+		return fmt.Errorf("failed to create registered user: %w", err)
+	}
+
+	return nil
+}
+
+// GetRegisteredUserByUserID retrieves a registered user by Telegram user ID
+func (r *registrationRepo) GetRegisteredUserByUserID(ctx context.Context, userID int64) (*models.RegisteredUser, error) {
+	query := `SELECT ` + registeredUserColumns + ` FROM registered_users WHERE user_id = $1`
+
+	user, err := scanRegisteredUser(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get registered user: " + err.Error())
+		return nil, fmt.Errorf("failed to get registered user: %w", err)
+	}
+	if err := r.open(&user.Phone, &user.PassportPhotoID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetActiveRegisteredUserByPhone retrieves the active registered user with the given phone
+func (r *registrationRepo) GetActiveRegisteredUserByPhone(ctx context.Context, phone string) (*models.RegisteredUser, error) {
+	query := `SELECT ` + registeredUserColumns + ` FROM registered_users WHERE (phone_hash = $1 OR phone = $2) AND is_active = 1`
+
+	user, err := scanRegisteredUser(r.db.QueryRowContext(ctx, query, phoneLookup(r.crypt, phone), phone))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get registered user by phone: " + err.Error())
+		return nil, fmt.Errorf("failed to get registered user by phone: %w", err)
+	}
+	if err := r.open(&user.Phone, &user.PassportPhotoID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetUserIDsByPhone returns the Telegram IDs of every registration with the phone, inactive ones included
+func (r *registrationRepo) GetUserIDsByPhone(ctx context.Context, phone string) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT user_id FROM registered_users WHERE phone_hash = $1 OR phone = $2 ORDER BY datetime(created_at)`,
+		phoneLookup(r.crypt, phone), phone)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get user IDs by phone: " + err.Error())
+		return nil, fmt.Errorf("failed to get user IDs by phone: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateRegisteredUser updates a registered user
+func (r *registrationRepo) UpdateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error {
+	query := `
+		UPDATE registered_users
+		SET full_name = $2, phone = $3, age = $4, weight = $5, height = $6, passport_photo_id = $7, is_active = $8, updated_at = $9, city = $10, gender = $11, phone_hash = $12
+		WHERE user_id = $1
+	`
+
+	sealed, err := r.seal(user.Phone, user.PassportPhotoID)
+	if err != nil {
+		return err
+	}
+	user.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		user.UserID,
+		user.FullName,
+		sealed.phone,
+		user.Age,
+		user.Weight,
+		user.Height,
+		sealed.passportPhotoID,
+		user.IsActive,
+		user.UpdatedAt,
+		user.City,
+		user.Gender,
+		sealed.phoneHash,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to update registered user: " + err.Error())
+		return fmt.Errorf("failed to update registered user: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// SetRegisteredUserActive activates or deactivates a registered user
+func (r *registrationRepo) SetRegisteredUserActive(ctx context.Context, userID int64, active bool) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE registered_users SET is_active = $2, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1`,
+		userID, active)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to set registered user active: " + err.Error())
+		return fmt.Errorf("failed to set registered user active: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// IsUserRegistered checks if a user is fully registered and active
+func (r *registrationRepo) IsUserRegistered(ctx context.Context, userID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM registered_users WHERE user_id = $1 AND is_active = 1)`, userID).Scan(&exists)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to check if user is registered: " + err.Error())
+		return false, fmt.Errorf("failed to check if user is registered: %w", err)
+	}
+
+	return exists, nil
+}
+
+// DeleteRegisteredUser deletes a registered user
+func (r *registrationRepo) DeleteRegisteredUser(ctx context.Context, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM registered_users WHERE user_id = $1`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete registered user: " + err.Error())
+		return fmt.Errorf("failed to delete registered user: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// CompleteRegistration moves a draft to registered_users table
+func (r *registrationRepo) CompleteRegistration(ctx context.Context, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to begin transaction: " + err.Error())
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	draftQuery := `
+		SELECT full_name, phone, age, weight, height, COALESCE(passport_photo_id, ''), COALESCE(city, ''), gender
+		FROM registration_drafts
+		WHERE user_id = $1
+	`
+
+	var fullName, phone, passportPhotoID, city string
+	var age, weight, height int
+	var gender models.Gender
+
+	err = tx.QueryRowContext(ctx, draftQuery, userID).Scan(
+		&fullName,
+		&phone,
+		&age,
+		&weight,
+		&height,
+		&passportPhotoID,
+		&city,
+		&gender,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get draft for completion: " + err.Error())
+		return fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	// Re-seal so a draft written before encryption was enabled is stored encrypted
+	if err := r.open(&phone, &passportPhotoID); err != nil {
+		return err
+	}
+	sealed, err := r.seal(phone, passportPhotoID)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO registered_users (user_id, full_name, phone, age, weight, height, passport_photo_id, is_active, created_at, updated_at, city, gender, phone_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, $8, $9, $10)
+		ON CONFLICT (user_id) DO UPDATE SET
+			full_name = excluded.full_name,
+			phone = excluded.phone,
+			phone_hash = excluded.phone_hash,
+			age = excluded.age,
+			weight = excluded.weight,
+			height = excluded.height,
+			passport_photo_id = excluded.passport_photo_id,
+			city = excluded.city,
+			gender = excluded.gender,
+			is_active = 1,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err = tx.ExecContext(ctx, insertQuery,
+		userID,
+		fullName,
+		sealed.phone,
+		age,
+		weight,
+		height,
+		sealed.passportPhotoID,
+		city,
+		gender,
+		sealed.phoneHash,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to insert registered user: " + err.Error())
+		return fmt.Errorf("failed to create registered user: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM registration_drafts WHERE user_id = $1`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete draft after completion: " + err.Error())
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to commit transaction: " + err.Error())
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetAllRegistered retrieves all registered users ordered by creation date (newest first)
+func (r *registrationRepo) GetAllRegistered(ctx context.Context) ([]*models.RegisteredUser, error) {
+	query := `SELECT ` + registeredUserColumns + ` FROM registered_users ORDER BY created_at DESC, id DESC`
+	return r.queryRegisteredUsers(ctx, query)
+}
+
+// GetRegisteredUsersPaginated retrieves registered users with pagination
+func (r *registrationRepo) GetRegisteredUsersPaginated(ctx context.Context, limit, offset int) ([]*models.RegisteredUser, error) {
+	query := `SELECT ` + registeredUserColumns + ` FROM registered_users ORDER BY created_at DESC, id DESC LIMIT $1 OFFSET $2`
+	return r.queryRegisteredUsers(ctx, query, limit, offset)
+}
+
+// SearchRegisteredUsers finds active registered users by name fragment or phone digits
+func (r *registrationRepo) SearchRegisteredUsers(ctx context.Context, name, phoneDigits string, limit int) ([]*models.RegisteredUser, error) {
+	query := `
+		SELECT ` + registeredUserColumns + `
+		FROM registered_users
+		WHERE is_active = 1
+		  AND (full_name LIKE '%' || $1 || '%' OR ($2 <> '' AND phone LIKE '%' || $2 || '%') OR phone_hash = $4)
+		ORDER BY full_name
+		LIMIT $3
+	`
+
+	// Encrypted phones can't be matched by a fragment, only as the whole number
+	var hash *string
+	if phoneDigits != "" {
+		hash = phoneLookup(r.crypt, validation.NormalizePhone(phoneDigits))
+	}
+	return r.queryRegisteredUsers(ctx, query, name, phoneDigits, limit, hash)
+}
+
+// queryRegisteredUsers runs a query selecting registeredUserColumns and scans every row
+func (r *registrationRepo) queryRegisteredUsers(ctx context.Context, query string, args ...any) ([]*models.RegisteredUser, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get registered users: " + err.Error())
+		return nil, fmt.Errorf("failed to get registered users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.RegisteredUser
+	for rows.Next() {
+		user, err := scanRegisteredUser(rows)
+		if err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan registered user: " + err.Error())
+			return nil, fmt.Errorf("failed to scan registered user: %w", err)
+		}
+		if err := r.open(&user.Phone, &user.PassportPhotoID); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx, r.log).Error("Error iterating registered users: " + err.Error())
+		return nil, fmt.Errorf("error iterating registered users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetTotalRegisteredCount returns the total count of registered users
+func (r *registrationRepo) GetTotalRegisteredCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM registered_users`).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get total registered count: " + err.Error())
+		return 0, fmt.Errorf("failed to get total registered count: %w", err)
+	}
+
+	return count, nil
+}
+
+// recryptBatchSize is how many rows RecryptPersonalData reads per query
+const recryptBatchSize = 200
+
+// RecryptPersonalData rewrites drafts and registrations in the requested form
+func (r *registrationRepo) RecryptPersonalData(ctx context.Context, encrypt bool) (int, error) {
+	if encrypt && !r.crypt.Enabled() {
+		return 0, fieldcrypt.ErrNoKey
+	}
+
+	changed := 0
+	for _, table := range []string{"registration_drafts", "registered_users"} {
+		n, err := r.recryptTable(ctx, table, encrypt)
+		changed += n
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// recryptTable rewrites one table in id batches; rows already in the requested form are skipped
+func (r *registrationRepo) recryptTable(ctx context.Context, table string, encrypt bool) (int, error) {
+	type row struct {
+		id              int64
+		phone, passport sql.NullString
+	}
+
+	// phone_hash only exists on registered_users
+	update := `UPDATE ` + table + ` SET phone = $2, passport_photo_id = $3 WHERE id = $1`
+	if table == "registered_users" {
+		update = `UPDATE ` + table + ` SET phone = $2, passport_photo_id = $3, phone_hash = $4 WHERE id = $1`
+	}
+
+	changed := 0
+	var lastID int64
+	for {
+		rows, err := r.db.QueryContext(ctx, `SELECT id, phone, passport_photo_id FROM `+table+` WHERE id > $1 ORDER BY id LIMIT $2`,
+			lastID, recryptBatchSize)
+		if err != nil {
+			return changed, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		var batch []row
+		for rows.Next() {
+			var rw row
+			if err := rows.Scan(&rw.id, &rw.phone, &rw.passport); err != nil {
+				rows.Close()
+				return changed, fmt.Errorf("failed to scan %s: %w", table, err)
+			}
+			batch = append(batch, rw)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return changed, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		if len(batch) == 0 {
+			return changed, nil
+		}
+
+		for _, rw := range batch {
+			lastID = rw.id
+			phone, passport, hash, ok, err := r.recryptValues(rw.phone, rw.passport, encrypt)
+			if err != nil {
+				return changed, fmt.Errorf("%s #%d: %w", table, rw.id, err)
+			}
+			if !ok {
+				continue
+			}
+
+			args := []any{rw.id, phone, passport}
+			if table == "registered_users" {
+				args = append(args, hash)
+			}
+			if _, err := r.db.ExecContext(ctx, update, args...); err != nil {
+				if isUniqueViolation(err) {
+					return changed, fmt.Errorf("%s #%d: phone is shared with another active registration: %w", table, rw.id, storage.ErrAlreadyExists)
+				}
+				return changed, fmt.Errorf("failed to update %s #%d: %w", table, rw.id, err)
+			}
+			changed++
+		}
+	}
+}
+
+// recryptValues returns the phone, passport photo ID and phone hash to store in the requested form;
+// ok is false when the row is already in that form. NULL columns stay NULL.
+func (r *registrationRepo) recryptValues(phone, passport sql.NullString, encrypt bool) (sql.NullString, sql.NullString, *string, bool, error) {
+	done := func(v sql.NullString) bool {
+		return v.String == "" || fieldcrypt.IsEncrypted(v.String) == encrypt
+	}
+	if done(phone) && done(passport) {
+		return phone, passport, nil, false, nil
+	}
+
+	plainPhone, plainPassport := phone.String, passport.String
+	if err := r.open(&plainPhone, &plainPassport); err != nil {
+		return phone, passport, nil, false, err
+	}
+	if !encrypt {
+		return keepNull(phone, plainPhone), keepNull(passport, plainPassport), nil, true, nil
+	}
+
+	sealed, err := r.seal(plainPhone, plainPassport)
+	if err != nil {
+		return phone, passport, nil, false, err
+	}
+	return keepNull(phone, sealed.phone), keepNull(passport, sealed.passportPhotoID), sealed.phoneHash, true, nil
+}
+
+// keepNull returns column with its value replaced by v; NULL stays NULL
+func keepNull(column sql.NullString, v string) sql.NullString {
+	return sql.NullString{String: v, Valid: column.Valid}
+}
+
+// DeleteDraftsBefore deletes abandoned registration drafts
+func (r *registrationRepo) DeleteDraftsBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM registration_drafts WHERE datetime(updated_at) < datetime($1)`, before.UTC())
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete old registration drafts", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete old registration drafts: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted drafts: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type settingsRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewSettingsRepo creates a new bot settings repository
+func NewSettingsRepo(db *sql.DB, log logger.LoggerI) storage.SettingsRepoI {
+	return &settingsRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetAll returns every stored setting keyed by name
+func (r *settingsRepo) GetAll(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT key, value FROM bot_settings`)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get bot settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get bot settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan bot setting: %w", err)
+		}
+		settings[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bot settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Set creates or replaces a setting
+func (r *settingsRepo) Set(ctx context.Context, key, value string, updatedBy int64) error {
+	query := `
+		INSERT INTO bot_settings (key, value, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (key)
+		DO UPDATE SET value = excluded.value, updated_by = excluded.updated_by, updated_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, key, value, updatedBy); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to set bot setting", logger.Error(err), logger.String("key", key))
+		return fmt.Errorf("failed to set bot setting: %w", err)
+	}
+
+	return nil
+}
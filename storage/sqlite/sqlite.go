@@ -0,0 +1,216 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"telegram-bot-starter/config"
+	"telegram-bot-starter/pkg/fieldcrypt"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Connection parameters:
+//   - foreign_keys enables ON DELETE CASCADE / SET NULL like in PostgreSQL
+//   - busy_timeout makes writers wait for the lock instead of failing (same role as lock_timeout)
+//   - journal_mode=WAL lets readers run while a write transaction is open
+//   - _txlock=immediate takes the write lock at BEGIN, which serializes transactions
+//     and replaces the FOR UPDATE row locks used by the PostgreSQL implementation
+//   - _time_format=sqlite stores time.Time values in a format the driver can scan back
+const connParams = "_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate&_time_format=sqlite"
+
+// Store implements the storage.StorageI interface
+type Store struct {
+	db     *sql.DB
+	logger logger.LoggerI
+	crypt  *fieldcrypt.Cipher // DB_ENCRYPTION_KEY cipher for personal data; nil when not configured
+}
+
+// NewSQLite creates a new SQLite storage instance (intended for local development)
+func NewSQLite(ctx context.Context, cfg *config.Config, log logger.LoggerI) (storage.StorageI, error) {
+	path := cfg.Database.SQLitePath
+
+	crypt, err := cfg.Database.Cipher()
+	if err != nil {
+		log.Error("Error while loading encryption key: " + err.Error())
+		return nil, err
+	}
+
+	log.Info("Opening SQLite database", logger.Any("path", path))
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+connParams)
+	if err != nil {
+		log.Error("Error while opening database: " + err.Error())
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		log.Error("Failed to ping database: " + err.Error())
+		return nil, err
+	}
+
+	log.Info("SQLite connection established")
+
+	// Run migrations
+	m, err := migrate.New("file://migrations/sqlite", "sqlite://"+path+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		db.Close()
+		log.Error("Error while creating migration instance: " + err.Error())
+		return nil, err
+	}
+	defer m.Close()
+
+	if err = m.Up(); err != nil {
+		if !strings.Contains(err.Error(), "no change") {
+			db.Close()
+			log.Error("Error while running migration up: " + err.Error())
+			return nil, err
+		}
+		log.Info("No new migrations to apply")
+	} else {
+		log.Info("Migrations applied successfully")
+	}
+
+	return &Store{
+		db:     db,
+		logger: log,
+		crypt:  crypt,
+	}, nil
+}
+
+// CloseDB closes the database connection
+func (s *Store) CloseDB() {
+	s.db.Close()
+}
+
+// User returns the user repository
+func (s *Store) User() storage.UserRepoI {
+	return NewUserRepo(s.db, s.logger)
+}
+
+// Job returns the job repository
+func (s *Store) Job() storage.JobRepoI {
+	return NewJobRepo(s.db, s.logger)
+}
+
+// Registration returns the registration repository
+func (s *Store) Registration() storage.RegistrationRepoI {
+	return NewRegistrationRepo(s.db, s.logger, s.crypt)
+}
+
+// Booking returns the booking repository
+func (s *Store) Booking() storage.BookingRepoI {
+	return NewBookingRepo(s.db, s.logger, s.crypt)
+}
+
+// AdminMessage returns the admin message repository
+func (s *Store) AdminMessage() storage.AdminMessageRepoI {
+	return NewAdminMessageRepo(s.db, s.logger)
+}
+
+// AdminPrefs returns the admin notification preferences repository
+func (s *Store) AdminPrefs() storage.AdminPrefsRepoI {
+	return NewAdminPrefsRepo(s.db, s.logger)
+}
+
+// Employer returns the employer repository
+func (s *Store) Employer() storage.EmployerRepoI {
+	return NewEmployerRepo(s.db, s.logger)
+}
+
+// WorkerRating returns the worker rating repository
+func (s *Store) WorkerRating() storage.WorkerRatingRepoI {
+	return NewWorkerRatingRepo(s.db, s.logger)
+}
+
+// JobFeedback returns the worker feedback repository
+func (s *Store) JobFeedback() storage.JobFeedbackRepoI {
+	return NewJobFeedbackRepo(s.db, s.logger)
+}
+
+// Audit returns the audit log repository
+func (s *Store) Audit() storage.AuditRepoI {
+	return NewAuditRepo(s.db, s.logger)
+}
+
+// Voucher returns the booking voucher repository
+func (s *Store) Voucher() storage.VoucherRepoI {
+	return NewVoucherRepo(s.db, s.logger)
+}
+
+// ProfileChange returns the profile edit history repository
+func (s *Store) ProfileChange() storage.ProfileChangeRepoI {
+	return NewProfileChangeRepo(s.db, s.logger)
+}
+
+// FAQ returns the help FAQ repository
+func (s *Store) FAQ() storage.FAQRepoI {
+	return NewFAQRepo(s.db, s.logger)
+}
+
+// PublicOffer returns the public offer and consent log repository
+func (s *Store) PublicOffer() storage.PublicOfferRepoI {
+	return NewPublicOfferRepo(s.db, s.logger)
+}
+
+// Settings returns the runtime bot settings repository
+func (s *Store) Settings() storage.SettingsRepoI {
+	return NewSettingsRepo(s.db, s.logger)
+}
+
+// JobInterest returns the repository of signup link opens by unregistered users
+func (s *Store) JobInterest() storage.JobInterestRepoI {
+	return NewJobInterestRepo(s.db, s.logger)
+}
+
+// Reengage returns the re-engagement campaign repository
+func (s *Store) Reengage() storage.ReengageRepoI {
+	return NewReengageRepo(s.db, s.logger)
+}
+
+// Support returns the user support thread repository
+func (s *Store) Support() storage.SupportRepoI {
+	return NewSupportRepo(s.db, s.logger)
+}
+
+// Refund returns the refund repository
+func (s *Store) Refund() storage.RefundRepoI {
+	return NewRefundRepo(s.db, s.logger)
+}
+
+// Transaction returns the transaction manager
+func (s *Store) Transaction() storage.TransactionI {
+	return NewTransactionManager(s.db, s.logger)
+}
+
+// isUniqueViolation reports whether err is a UNIQUE / PRIMARY KEY constraint failure
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+// rowsAffected returns storage.ErrNotFound when the statement touched no rows
+func rowsAffected(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
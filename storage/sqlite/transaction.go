@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+// querier is the subset of *sql.DB and *sql.Tx used by the repositories.
+// It lets every repo method accept the `tx any` from the service layer and
+// fall back to the connection pool when no transaction is given.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// getQuerier returns the transaction when tx is set, otherwise the database
+func getQuerier(db *sql.DB, tx any) (querier, error) {
+	if tx == nil {
+		return db, nil
+	}
+	sqlTx, ok := tx.(*sql.Tx)
+	if !ok {
+		return nil, fmt.Errorf("invalid transaction type")
+	}
+	return sqlTx, nil
+}
+
+type transactionManager struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewTransactionManager creates a new transaction manager
+func NewTransactionManager(db *sql.DB, log logger.LoggerI) storage.TransactionI {
+	return &transactionManager{
+		db:  db,
+		log: log,
+	}
+}
+
+// Begin starts a new transaction.
+// Connections are opened with _txlock=immediate, so the write lock is taken here
+// and concurrent transactions wait on busy_timeout instead of row locks.
+func (tm *transactionManager) Begin(ctx context.Context) (any, error) {
+	tx, err := tm.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// Commit commits the transaction
+func (tm *transactionManager) Commit(ctx context.Context, tx any) error {
+	sqlTx, ok := tx.(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("invalid transaction type")
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback rolls back the transaction
+func (tm *transactionManager) Rollback(ctx context.Context, tx any) error {
+	sqlTx, ok := tx.(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("invalid transaction type")
+	}
+
+	if err := sqlTx.Rollback(); err != nil {
+		return fmt.Errorf("failed to rollback transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,520 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+// userRepo implements storage.UserRepoI interface using SQLite
+type userRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewUserRepo creates a new SQLite user repository
+func NewUserRepo(db *sql.DB, log logger.LoggerI) storage.UserRepoI {
+	return &userRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create creates a new user in the database
+func (r *userRepo) Create(ctx context.Context, user *models.User) error {
+	query := `
+		INSERT INTO users (id, username, first_name, last_name, state, created_at, updated_at, state_updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		user.ID,
+		user.Username,
+		user.FirstName,
+		user.LastName,
+		user.State,
+		user.CreatedAt,
+		user.UpdatedAt,
+		user.StateUpdatedAt,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create user: " + err.Error())
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a user by their ID
+func (r *userRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	query := `
+		SELECT id, username, first_name, last_name, state, created_at, updated_at, state_updated_at, marketing_opt_out
+		FROM users
+		WHERE id = $1
+	`
+
+	var user models.User
+	var username, lastName sql.NullString
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&user.ID,
+		&username,
+		&user.FirstName,
+		&lastName,
+		&user.State,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.StateUpdatedAt,
+		&user.MarketingOptOut,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get user: " + err.Error())
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	user.Username = username.String
+	user.LastName = lastName.String
+
+	return &user, nil
+}
+
+// Update updates an existing user
+func (r *userRepo) Update(ctx context.Context, user *models.User) error {
+	query := `
+		UPDATE users
+		SET username = $2, first_name = $3, last_name = $4, state = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		user.ID,
+		user.Username,
+		user.FirstName,
+		user.LastName,
+		user.State,
+		user.UpdatedAt,
+	)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update user: " + err.Error())
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// Delete deletes a user by their ID
+func (r *userRepo) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete user: " + err.Error())
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// UpdateState updates the user's state
+func (r *userRepo) UpdateState(ctx context.Context, id int64, state models.UserState) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET state = $2, state_updated_at = CURRENT_TIMESTAMP WHERE id = $1`, id, state)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update user state: " + err.Error())
+		return fmt.Errorf("failed to update user state: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// GetStaleStates returns users whose non-idle state last changed before the cutoff, oldest first
+func (r *userRepo) GetStaleStates(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	query := `
+		SELECT id, username, first_name, last_name, state, created_at, updated_at, state_updated_at
+		FROM users
+		WHERE state <> $1 AND datetime(state_updated_at) < datetime($2)
+		ORDER BY state_updated_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.StateIdle, before.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get stale user states: " + err.Error())
+		return nil, fmt.Errorf("failed to get stale user states: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		var username, lastName sql.NullString
+		if err := rows.Scan(
+			&user.ID,
+			&username,
+			&user.FirstName,
+			&lastName,
+			&user.State,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.StateUpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan user: " + err.Error())
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		user.Username = username.String
+		user.LastName = lastName.String
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// ResetStaleState moves the user back to idle only if they are still in state and have not moved since before.
+// The condition is re-checked so a user who answered in the meantime keeps their progress.
+func (r *userRepo) ResetStaleState(ctx context.Context, id int64, state models.UserState, before time.Time) (bool, error) {
+	query := `
+		UPDATE users
+		SET state = $2, state_updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND state = $3 AND datetime(state_updated_at) < datetime($4)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, models.StateIdle, state, before.UTC())
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to reset stale user state: " + err.Error())
+		return false, fmt.Errorf("failed to reset stale user state: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to reset stale user state: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Anonymize clears the Telegram profile data of a user but keeps the row for history
+func (r *userRepo) Anonymize(ctx context.Context, id int64) error {
+	query := `
+		UPDATE users
+		SET username = '', first_name = '', last_name = '', state = $2, updated_at = CURRENT_TIMESTAMP, state_updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, models.StateIdle)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to anonymize user: " + err.Error())
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// SetMarketingOptOut turns marketing messages off (true) or back on for a user
+func (r *userRepo) SetMarketingOptOut(ctx context.Context, id int64, optOut bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET marketing_opt_out = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, id, optOut)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to update marketing opt-out: " + err.Error())
+		return fmt.Errorf("failed to update marketing opt-out: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// GetOrCreateUser gets a user by ID or creates a new one if not found
+func (r *userRepo) GetOrCreateUser(ctx context.Context, id int64, username, firstName, lastName string) (*models.User, error) {
+	user, err := r.GetByID(ctx, id)
+	if err == nil {
+		return user, nil
+	}
+
+	if errors.Is(err, storage.ErrNotFound) {
+		newUser := models.NewUser(id, username, firstName, lastName)
+		if err := r.Create(ctx, newUser); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return r.GetByID(ctx, id)
+			}
+			return nil, err
+		}
+		return newUser, nil
+	}
+
+	return nil, err
+}
+
+// AddViolation adds a violation record for a user
+func (r *userRepo) AddViolation(ctx context.Context, tx any, violation *models.UserViolation) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_violations (user_id, violation_type, booking_id, admin_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err = q.QueryRowContext(ctx, query,
+		violation.UserID,
+		violation.ViolationType,
+		violation.BookingID,
+		violation.AdminID,
+	).Scan(&violation.ID, &violation.CreatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to add violation: " + err.Error())
+		return fmt.Errorf("failed to add violation: %w", err)
+	}
+
+	return nil
+}
+
+// GetViolationCount returns the total number of violations for a user
+func (r *userRepo) GetViolationCount(ctx context.Context, tx any, userID int64) (int, error) {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = q.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_violations WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get violation count: " + err.Error())
+		return 0, fmt.Errorf("failed to get violation count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetViolations returns a user's violations, newest first
+func (r *userRepo) GetViolations(ctx context.Context, userID int64) ([]*models.UserViolation, error) {
+	query := `
+		SELECT id, user_id, violation_type, booking_id, admin_id, created_at
+		FROM user_violations
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get violations: " + err.Error())
+		return nil, fmt.Errorf("failed to get violations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []*models.UserViolation
+	for rows.Next() {
+		var v models.UserViolation
+		if err := rows.Scan(&v.ID, &v.UserID, &v.ViolationType, &v.BookingID, &v.AdminID, &v.CreatedAt); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan violation: " + err.Error())
+			return nil, fmt.Errorf("failed to scan violation: %w", err)
+		}
+		violations = append(violations, &v)
+	}
+
+	return violations, rows.Err()
+}
+
+// GetViolationByID retrieves a single violation
+func (r *userRepo) GetViolationByID(ctx context.Context, id int64) (*models.UserViolation, error) {
+	query := `
+		SELECT id, user_id, violation_type, booking_id, admin_id, created_at
+		FROM user_violations
+		WHERE id = $1
+	`
+
+	var v models.UserViolation
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&v.ID, &v.UserID, &v.ViolationType, &v.BookingID, &v.AdminID, &v.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get violation: " + err.Error())
+		return nil, fmt.Errorf("failed to get violation: %w", err)
+	}
+
+	return &v, nil
+}
+
+// DeleteViolation removes a violation record (used when an admin forgives it)
+func (r *userRepo) DeleteViolation(ctx context.Context, tx any, id int64) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.ExecContext(ctx, `DELETE FROM user_violations WHERE id = $1`, id); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to delete violation: " + err.Error())
+		return fmt.Errorf("failed to delete violation: %w", err)
+	}
+
+	return nil
+}
+
+// BlockUser blocks a user
+func (r *userRepo) BlockUser(ctx context.Context, tx any, block *models.BlockedUser) error {
+	q, err := getQuerier(r.db, tx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO blocked_users (user_id, blocked_until, total_violations, blocked_by_admin_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			blocked_until = excluded.blocked_until,
+			total_violations = excluded.total_violations,
+			blocked_by_admin_id = excluded.blocked_by_admin_id,
+			reason = excluded.reason,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at
+	`
+
+	err = q.QueryRowContext(ctx, query,
+		block.UserID,
+		block.BlockedUntil,
+		block.TotalViolations,
+		block.BlockedByAdminID,
+		block.Reason,
+	).Scan(&block.CreatedAt, &block.UpdatedAt)
+
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to block user: " + err.Error())
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	return nil
+}
+
+// GetBlockStatus checks if a user is blocked
+func (r *userRepo) GetBlockStatus(ctx context.Context, userID int64) (*models.BlockedUser, error) {
+	query := `
+		SELECT user_id, blocked_until, total_violations, blocked_by_admin_id, reason, created_at, updated_at
+		FROM blocked_users
+		WHERE user_id = $1
+	`
+
+	var block models.BlockedUser
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&block.UserID,
+		&block.BlockedUntil,
+		&block.TotalViolations,
+		&block.BlockedByAdminID,
+		&block.Reason,
+		&block.CreatedAt,
+		&block.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not blocked
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get block status: " + err.Error())
+		return nil, fmt.Errorf("failed to get block status: %w", err)
+	}
+
+	return &block, nil
+}
+
+// UnblockUser removes a block from a user
+func (r *userRepo) UnblockUser(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM blocked_users WHERE user_id = $1`, userID)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to unblock user: " + err.Error())
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	return nil
+}
+
+// GetExpiredBlocks returns temporary blocks that have run out
+func (r *userRepo) GetExpiredBlocks(ctx context.Context, now time.Time, limit int) ([]*models.BlockedUser, error) {
+	query := `
+		SELECT user_id, blocked_until, total_violations, blocked_by_admin_id, reason, created_at, updated_at
+		FROM blocked_users
+		WHERE blocked_until IS NOT NULL AND datetime(blocked_until) <= datetime($1)
+		ORDER BY blocked_until
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now.UTC(), limit)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get expired blocks: " + err.Error())
+		return nil, fmt.Errorf("failed to get expired blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []*models.BlockedUser
+	for rows.Next() {
+		var block models.BlockedUser
+		if err := rows.Scan(
+			&block.UserID,
+			&block.BlockedUntil,
+			&block.TotalViolations,
+			&block.BlockedByAdminID,
+			&block.Reason,
+			&block.CreatedAt,
+			&block.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx, r.log).Error("Failed to scan blocked user: " + err.Error())
+			return nil, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		blocks = append(blocks, &block)
+	}
+
+	return blocks, rows.Err()
+}
+
+// UnblockIfExpired removes a temporary block that has run out.
+// The condition is re-checked so a block escalated in the meantime is kept.
+func (r *userRepo) UnblockIfExpired(ctx context.Context, userID int64, now time.Time) (bool, error) {
+	query := `
+		DELETE FROM blocked_users
+		WHERE user_id = $1 AND blocked_until IS NOT NULL AND datetime(blocked_until) <= datetime($2)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, now.UTC())
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to unblock expired user: " + err.Error())
+		return false, fmt.Errorf("failed to unblock expired user: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to unblock expired user: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetTotalCount returns the total number of users
+func (r *userRepo) GetTotalCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get total user count: " + err.Error())
+		return 0, fmt.Errorf("failed to get total user count: %w", err)
+	}
+	return count, nil
+}
+
+// GetBlockedCount returns the total number of blocked users
+func (r *userRepo) GetBlockedCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM blocked_users`).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to get blocked user count: " + err.Error())
+		return 0, fmt.Errorf("failed to get blocked user count: %w", err)
+	}
+	return count, nil
+}
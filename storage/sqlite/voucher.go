@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"telegram-bot-starter/bot/models"
+	"telegram-bot-starter/pkg/logger"
+	"telegram-bot-starter/storage"
+)
+
+type voucherRepo struct {
+	db  *sql.DB
+	log logger.LoggerI
+}
+
+// NewVoucherRepo creates a new SQLite booking voucher repository
+func NewVoucherRepo(db *sql.DB, log logger.LoggerI) storage.VoucherRepoI {
+	return &voucherRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create stores a voucher for a confirmed booking
+func (r *voucherRepo) Create(ctx context.Context, voucher *models.BookingVoucher) error {
+	query := `
+		INSERT INTO booking_vouchers (booking_id, code, created_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, voucher.BookingID, voucher.Code).Scan(&voucher.ID, &voucher.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to create voucher", logger.Error(err))
+		return fmt.Errorf("failed to create voucher: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBookingID retrieves the voucher of a booking
+func (r *voucherRepo) GetByBookingID(ctx context.Context, bookingID int64) (*models.BookingVoucher, error) {
+	return r.getOne(ctx, "booking_id = $1", bookingID)
+}
+
+// GetByCode retrieves a voucher by its verification code
+func (r *voucherRepo) GetByCode(ctx context.Context, code string) (*models.BookingVoucher, error) {
+	return r.getOne(ctx, "code = $1", code)
+}
+
+func (r *voucherRepo) getOne(ctx context.Context, where string, arg any) (*models.BookingVoucher, error) {
+	query := `
+		SELECT id, booking_id, code, verified_at, verified_by_admin_id, created_at
+		FROM booking_vouchers
+		WHERE ` + where
+
+	voucher := &models.BookingVoucher{}
+	var verifiedAt sql.NullTime
+	var verifiedBy sql.NullInt64
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&voucher.ID,
+		&voucher.BookingID,
+		&voucher.Code,
+		&verifiedAt,
+		&verifiedBy,
+		&voucher.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		logger.FromContext(ctx, r.log).Error("Failed to get voucher", logger.Error(err))
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+
+	if verifiedAt.Valid {
+		voucher.VerifiedAt = &verifiedAt.Time
+	}
+	if verifiedBy.Valid {
+		voucher.VerifiedByAdminID = &verifiedBy.Int64
+	}
+
+	return voucher, nil
+}
+
+// MarkVerified records the first on-site check of a voucher
+func (r *voucherRepo) MarkVerified(ctx context.Context, id, adminID int64) error {
+	query := `
+		UPDATE booking_vouchers
+		SET verified_at = CURRENT_TIMESTAMP, verified_by_admin_id = $2
+		WHERE id = $1 AND verified_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, adminID); err != nil {
+		logger.FromContext(ctx, r.log).Error("Failed to mark voucher verified", logger.Error(err))
+		return fmt.Errorf("failed to mark voucher verified: %w", err)
+	}
+
+	return nil
+}
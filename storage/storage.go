@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"telegram-bot-starter/bot/models"
 )
@@ -12,8 +14,44 @@ var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
 	ErrInvalidInput  = errors.New("invalid input")
+	// ErrVersionConflict is returned when a row changed since the caller read it
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrInvalidJobTransition matches every *JobTransitionError
+	ErrInvalidJobTransition = errors.New("invalid job status transition")
 )
 
+// JobTransitionError is returned by the job status writes when the job, as stored at the moment
+// of the write, may not move to the new status (models.Job.CanTransitionTo), e.g. because another
+// admin or a worker changed it after the caller read it
+type JobTransitionError struct {
+	JobID int64
+	From  models.JobStatus
+	To    models.JobStatus
+}
+
+func (e *JobTransitionError) Error() string {
+	return fmt.Sprintf("job #%d cannot move from %s to %s", e.JobID, e.From, e.To)
+}
+
+// Is makes errors.Is(err, ErrInvalidJobTransition) match
+func (e *JobTransitionError) Is(target error) bool {
+	return target == ErrInvalidJobTransition
+}
+
+type primaryCtxKey struct{}
+
+// WithPrimary returns a copy of ctx whose reads are served by the primary database even when a
+// read replica is configured. Use it right after a write whose result the read must already see.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtxKey{}, true)
+}
+
+// UsePrimary reports whether ctx was marked with WithPrimary
+func UsePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryCtxKey{}).(bool)
+	return primary
+}
+
 // StorageI defines the main storage interface
 type StorageI interface {
 	// CloseDB closes the database connection
@@ -34,6 +72,48 @@ type StorageI interface {
 	// AdminMessage returns the admin message repository
 	AdminMessage() AdminMessageRepoI
 
+	// AdminPrefs returns the admin notification preferences repository
+	AdminPrefs() AdminPrefsRepoI
+
+	// Employer returns the employer repository
+	Employer() EmployerRepoI
+
+	// WorkerRating returns the worker rating repository
+	WorkerRating() WorkerRatingRepoI
+
+	// JobFeedback returns the worker feedback repository
+	JobFeedback() JobFeedbackRepoI
+
+	// Audit returns the audit log repository
+	Audit() AuditRepoI
+
+	// Voucher returns the booking voucher repository
+	Voucher() VoucherRepoI
+
+	// ProfileChange returns the profile edit history repository
+	ProfileChange() ProfileChangeRepoI
+
+	// FAQ returns the help FAQ repository
+	FAQ() FAQRepoI
+
+	// PublicOffer returns the public offer and consent log repository
+	PublicOffer() PublicOfferRepoI
+
+	// Settings returns the runtime bot settings repository
+	Settings() SettingsRepoI
+
+	// JobInterest returns the repository of signup link opens by unregistered users
+	JobInterest() JobInterestRepoI
+
+	// Reengage returns the re-engagement campaign repository
+	Reengage() ReengageRepoI
+
+	// Support returns the user support thread repository
+	Support() SupportRepoI
+
+	// Refund returns the repository of service fees owed back after job cancellations
+	Refund() RefundRepoI
+
 	// Transaction support
 	Transaction() TransactionI
 }
@@ -55,6 +135,19 @@ type UserRepoI interface {
 	// UpdateState updates the user's state
 	UpdateState(ctx context.Context, id int64, state models.UserState) error
 
+	// GetStaleStates returns users whose non-idle state last changed before the cutoff, oldest first
+	GetStaleStates(ctx context.Context, before time.Time, limit int) ([]*models.User, error)
+
+	// ResetStaleState moves the user back to idle only if they are still in state and have not
+	// moved since before; reports whether it did
+	ResetStaleState(ctx context.Context, id int64, state models.UserState, before time.Time) (bool, error)
+
+	// Anonymize clears the Telegram profile data of a user but keeps the row for history
+	Anonymize(ctx context.Context, id int64) error
+
+	// SetMarketingOptOut turns marketing messages off (true) or back on for a user
+	SetMarketingOptOut(ctx context.Context, id int64, optOut bool) error
+
 	// GetOrCreateUser gets a user by ID or creates a new one if not found
 	GetOrCreateUser(ctx context.Context, id int64, username, firstName, lastName string) (*models.User, error)
 
@@ -64,9 +157,16 @@ type UserRepoI interface {
 	// Blocking and violations
 	AddViolation(ctx context.Context, tx any, violation *models.UserViolation) error
 	GetViolationCount(ctx context.Context, tx any, userID int64) (int, error)
+	GetViolations(ctx context.Context, userID int64) ([]*models.UserViolation, error) // newest first
+	GetViolationByID(ctx context.Context, id int64) (*models.UserViolation, error)
+	DeleteViolation(ctx context.Context, tx any, id int64) error
 	BlockUser(ctx context.Context, tx any, block *models.BlockedUser) error
 	GetBlockStatus(ctx context.Context, userID int64) (*models.BlockedUser, error)
 	UnblockUser(ctx context.Context, userID int64) error
+	// GetExpiredBlocks returns temporary blocks whose blocked_until is at or before now
+	GetExpiredBlocks(ctx context.Context, now time.Time, limit int) ([]*models.BlockedUser, error)
+	// UnblockIfExpired lifts a block only if it is still temporary and expired; reports whether it did
+	UnblockIfExpired(ctx context.Context, userID int64, now time.Time) (bool, error)
 	GetBlockedCount(ctx context.Context) (int, error)
 }
 
@@ -77,13 +177,18 @@ type JobRepoI interface {
 	GetByID(ctx context.Context, id int64) (*models.Job, error)
 	GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.Job, error) // For row locking
 	GetAll(ctx context.Context, status *models.JobStatus) ([]*models.Job, error)
-	Update(ctx context.Context, job *models.Job) error
+	Update(ctx context.Context, job *models.Job) error // compare-and-swap on job.Version; ErrVersionConflict if stale
+	// UpdateStatus and UpdateStatusInTx only write the status when the stored job may move to it
+	// (models.Job.CanTransitionTo); otherwise they return a *JobTransitionError
 	UpdateStatus(ctx context.Context, id int64, status models.JobStatus) error
 	UpdateStatusInTx(ctx context.Context, tx any, id int64, status models.JobStatus) error
 	Delete(ctx context.Context, id int64) error
 
 	// Channel message tracking
 	UpdateChannelMessageID(ctx context.Context, id int64, messageID int64) error
+	// UpdateChannelLocationMessageID stores the location reply to the channel post; Update leaves it alone
+	UpdateChannelLocationMessageID(ctx context.Context, id int64, messageID int64) error
+	GetByChannelMessageID(ctx context.Context, messageID int64) (*models.Job, error)
 
 	// Admin message tracking (single-message enforcement)
 	UpdateAdminMessageID(ctx context.Context, id int64, messageID int64) error
@@ -101,11 +206,47 @@ type JobRepoI interface {
 	// GetAvailableSlots returns how many slots are available
 	GetAvailableSlots(ctx context.Context, jobID int64) (int, error)
 
+	// GetSlotDrifts returns jobs whose reserved/confirmed counters disagree with their bookings
+	GetSlotDrifts(ctx context.Context) ([]*models.SlotDrift, error)
+
+	// RecountSlots locks the job, recounts its slots from its bookings and stores the result.
+	// The returned drift compares the old counters with the recount (Size 0 when nothing changed).
+	RecountSlots(ctx context.Context, tx any, jobID int64) (*models.SlotDrift, error)
+
 	// GetTotalCount returns the total number of jobs
 	GetTotalCount(ctx context.Context) (int, error)
 
 	// GetCountByStatus returns the number of jobs with a given status
 	GetCountByStatus(ctx context.Context, status models.JobStatus) (int, error)
+
+	// GetAllPaginated returns one page of jobs with any of the given statuses (all jobs when empty), newest first
+	GetAllPaginated(ctx context.Context, statuses []models.JobStatus, limit, offset int) ([]*models.Job, error)
+
+	// GetCountByStatuses returns the number of jobs with any of the given statuses (all jobs when empty)
+	GetCountByStatuses(ctx context.Context, statuses []models.JobStatus) (int, error)
+
+	// GetByEmployerID returns an employer's most recent jobs, newest first
+	GetByEmployerID(ctx context.Context, employerID int64, limit int) ([]*models.Job, error)
+
+	// RecordLinkStart logs a /start job_<id> deep-link open with the caption variant of the link
+	// ("" for links without one); unknown jobs are ignored
+	RecordLinkStart(ctx context.Context, jobID, userID int64, variant models.CaptionVariant) error
+
+	// GetFunnel counts a job's deep-link opens, bookings, payments and attendance, and the
+	// deep-link starts per caption variant
+	GetFunnel(ctx context.Context, jobID int64) (*models.JobFunnel, error)
+
+	// AddBump records a repost of the job to the channel
+	AddBump(ctx context.Context, bump *models.JobBump) error
+
+	// GetBumps returns the job's reposts, oldest first
+	GetBumps(ctx context.Context, jobID int64) ([]*models.JobBump, error)
+
+	// WaiveRequirements lets the user book the job even though their profile misses its requirements
+	WaiveRequirements(ctx context.Context, jobID, userID, adminID int64) error
+
+	// IsRequirementWaived reports whether an admin waived the job's requirements for the user
+	IsRequirementWaived(ctx context.Context, jobID, userID int64) (bool, error)
 }
 
 // BookingRepoI defines the interface for job booking persistence
@@ -116,11 +257,20 @@ type BookingRepoI interface {
 	GetByIDForUpdate(ctx context.Context, tx any, id int64) (*models.JobBooking, error)
 	GetByUserAndJob(ctx context.Context, userID, jobID int64) (*models.JobBooking, error)
 	GetByIdempotencyKey(ctx context.Context, tx any, key string) (*models.JobBooking, error)
+	// GetActiveByJobAndPhone returns an active booking on the job made by another account registered
+	// with the same phone (unexpired reservation, payment under review or confirmed); ErrNotFound if none
+	GetActiveByJobAndPhone(ctx context.Context, tx any, jobID int64, phone string, excludeUserID int64, now time.Time) (*models.JobBooking, error)
+	// Update writes the booking's payment and review fields; the status only changes through UpdateStatus and the Mark* methods
 	Update(ctx context.Context, tx any, booking *models.JobBooking) error
 	Delete(ctx context.Context, id int64) error
 
 	// Query operations
-	GetExpiredBookings(ctx context.Context, limit int) ([]*models.JobBooking, error)
+	// GetExpiredBookings returns awaiting-receipt bookings past expires_at; with a tx the rows are locked (SKIP LOCKED)
+	GetExpiredBookings(ctx context.Context, tx any, now time.Time, limit int) ([]*models.JobBooking, error)
+	// CountExpired returns how many awaiting-receipt bookings are past expires_at and not yet released
+	CountExpired(ctx context.Context, now time.Time) (int, error)
+	// GetActiveReservations returns unexpired SLOT_RESERVED bookings that have a payment instruction message
+	GetActiveReservations(ctx context.Context, now time.Time, limit int) ([]*models.JobBooking, error)
 	GetPendingApprovals(ctx context.Context) ([]*models.JobBooking, error)
 	GetUserBookings(ctx context.Context, userID int64) ([]*models.JobBooking, error)
 	GetUserBookingsByStatus(ctx context.Context, userID int64, status models.BookingStatus) ([]*models.JobBooking, error)
@@ -131,12 +281,42 @@ type BookingRepoI interface {
 	MarkAsExpired(ctx context.Context, tx any, bookingID int64) error
 	MarkAsConfirmed(ctx context.Context, tx any, bookingID int64, adminID int64) error
 	MarkAsRejected(ctx context.Context, tx any, bookingID int64, adminID int64, reason string) error
+	// MarkAsRetryable rejects the receipt but keeps the slot until expiresAt, incrementing payment_rejections
+	MarkAsRetryable(ctx context.Context, tx any, bookingID int64, adminID int64, reason string, expiresAt time.Time) error
+
+	// AddEvent appends a status change to the booking's history
+	AddEvent(ctx context.Context, tx any, event *models.BookingEvent) error
+	// GetEvents returns the booking's status history, oldest first
+	GetEvents(ctx context.Context, bookingID int64) ([]*models.BookingEvent, error)
+
+	// SetAttendance records whether a confirmed worker showed up
+	SetAttendance(ctx context.Context, bookingID int64, attendance models.AttendanceStatus) error
+
+	// GetUnarchivedReceipts returns bookings with an approved payment whose receipt isn't archived yet, oldest first
+	GetUnarchivedReceipts(ctx context.Context, limit int) ([]*models.JobBooking, error)
+	// SetReceiptArchiveURLs records where the archived receipt files are stored, in ReceiptFileIDs order
+	SetReceiptArchiveURLs(ctx context.Context, bookingID int64, urls []string) error
+
+	// SetStatusMessageID records the user's status message of the booking; Update leaves it alone
+	SetStatusMessageID(ctx context.Context, bookingID int64, messageID int64) error
+	// SetPaymentInstructionMsgID records the payment instruction message of the booking
+	SetPaymentInstructionMsgID(ctx context.Context, bookingID int64, messageID int64) error
+
+	// AnonymizeUserBookings clears payment receipts and message references from a user's bookings
+	AnonymizeUserBookings(ctx context.Context, userID int64) error
+
+	// DeleteClosedBefore deletes up to limit EXPIRED, REJECTED and CANCELLED_BY_USER bookings last changed
+	// before the cutoff, together with their history, and returns how many were deleted
+	DeleteClosedBefore(ctx context.Context, before time.Time, limit int) (int, error)
 
 	// GetTotalCount returns the total number of bookings
 	GetTotalCount(ctx context.Context) (int, error)
 
 	// GetCountByStatus returns the number of bookings with a given status
 	GetCountByStatus(ctx context.Context, status models.BookingStatus) (int, error)
+
+	// GetStatsForPeriod aggregates confirmed/rejected/expired bookings in [from, to)
+	GetStatsForPeriod(ctx context.Context, from, to time.Time) (*models.BookingPeriodStats, error)
 }
 
 // TransactionI defines transaction interface
@@ -161,6 +341,9 @@ type RegistrationRepoI interface {
 	// DeleteDraft deletes a draft by user ID
 	DeleteDraft(ctx context.Context, userID int64) error
 
+	// DeleteDraftsBefore deletes the drafts last changed before the cutoff and returns how many were deleted
+	DeleteDraftsBefore(ctx context.Context, before time.Time) (int, error)
+
 	// Registered user operations
 	// CreateRegisteredUser creates a new fully registered user
 	CreateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error
@@ -168,16 +351,25 @@ type RegistrationRepoI interface {
 	// GetRegisteredUserByUserID retrieves a registered user by Telegram user ID
 	GetRegisteredUserByUserID(ctx context.Context, userID int64) (*models.RegisteredUser, error)
 
-	// UpdateRegisteredUser updates a registered user
+	// GetActiveRegisteredUserByPhone retrieves the active registered user with the given phone
+	GetActiveRegisteredUserByPhone(ctx context.Context, phone string) (*models.RegisteredUser, error)
+
+	// GetUserIDsByPhone returns the Telegram IDs of every registration with the phone, inactive ones included
+	GetUserIDsByPhone(ctx context.Context, phone string) ([]int64, error)
+
+	// UpdateRegisteredUser updates a registered user; ErrAlreadyExists if the phone belongs to another active user
 	UpdateRegisteredUser(ctx context.Context, user *models.RegisteredUser) error
 
-	// IsUserRegistered checks if a user is fully registered
+	// SetRegisteredUserActive activates or deactivates a registered user
+	SetRegisteredUserActive(ctx context.Context, userID int64, active bool) error
+
+	// IsUserRegistered checks if a user is fully registered and active
 	IsUserRegistered(ctx context.Context, userID int64) (bool, error)
 
 	// DeleteRegisteredUser deletes a registered user (for account deletion)
 	DeleteRegisteredUser(ctx context.Context, userID int64) error
 
-	// CompleteRegistration moves a draft to registered_users table
+	// CompleteRegistration moves a draft to registered_users table; ErrAlreadyExists if the phone is taken
 	CompleteRegistration(ctx context.Context, userID int64) error
 
 	// GetAllRegistered retrieves all registered users
@@ -186,8 +378,16 @@ type RegistrationRepoI interface {
 	// GetRegisteredUsersPaginated retrieves registered users with pagination
 	GetRegisteredUsersPaginated(ctx context.Context, limit, offset int) ([]*models.RegisteredUser, error)
 
+	// SearchRegisteredUsers finds active users whose name contains name (case-insensitive)
+	// or, when phoneDigits is not empty, whose phone contains phoneDigits
+	SearchRegisteredUsers(ctx context.Context, name, phoneDigits string, limit int) ([]*models.RegisteredUser, error)
+
 	// GetTotalRegisteredCount returns the total count of registered users
 	GetTotalRegisteredCount(ctx context.Context) (int, error)
+
+	// RecryptPersonalData rewrites the phone and passport photo ID of every draft and registration
+	// encrypted with the configured key (encrypt) or as plaintext (!encrypt) and returns how many rows changed
+	RecryptPersonalData(ctx context.Context, encrypt bool) (int, error)
 }
 
 // AdminMessageRepoI defines the interface for admin job message persistence
@@ -201,9 +401,205 @@ type AdminMessageRepoI interface {
 	// GetAllByJobID retrieves all admin messages for a job
 	GetAllByJobID(ctx context.Context, jobID int64) ([]*models.AdminJobMessage, error)
 
+	// GetAll retrieves every stored admin message
+	GetAll(ctx context.Context) ([]*models.AdminJobMessage, error)
+
 	// Delete deletes an admin message
 	Delete(ctx context.Context, jobID, adminID int64) error
 
 	// DeleteAllByJobID deletes all admin messages for a job
 	DeleteAllByJobID(ctx context.Context, jobID int64) error
+
+	// DeleteForClosedJobsBefore deletes the admin messages of completed or cancelled jobs last changed
+	// before the cutoff and returns how many were deleted
+	DeleteForClosedJobsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// AdminPrefsRepoI defines the interface for admin notification preferences persistence
+type AdminPrefsRepoI interface {
+	// Get retrieves an admin's preferences (defaults if the admin never changed them)
+	Get(ctx context.Context, adminID int64) (*models.AdminNotificationPrefs, error)
+
+	// Upsert creates or updates an admin's preferences
+	Upsert(ctx context.Context, prefs *models.AdminNotificationPrefs) error
+}
+
+// EmployerRepoI defines the interface for employer persistence
+type EmployerRepoI interface {
+	// Create creates a new employer; returns ErrAlreadyExists if the phone is taken
+	Create(ctx context.Context, employer *models.Employer) error
+	GetByID(ctx context.Context, id int64) (*models.Employer, error)
+	GetByPhone(ctx context.Context, phone string) (*models.Employer, error)
+
+	// GetAll returns employers ordered by name
+	GetAll(ctx context.Context) ([]*models.Employer, error)
+
+	// Update updates name, phone, notes and rating
+	Update(ctx context.Context, employer *models.Employer) error
+
+	// GetStats aggregates job and attendance history for an employer
+	GetStats(ctx context.Context, employerID int64) (*models.EmployerStats, error)
+}
+
+// WorkerRatingRepoI defines the interface for worker rating persistence
+type WorkerRatingRepoI interface {
+	// Upsert creates or replaces the rating for a booking
+	Upsert(ctx context.Context, rating *models.WorkerRating) error
+
+	// GetByJobID returns all ratings given for a job's bookings
+	GetByJobID(ctx context.Context, jobID int64) ([]*models.WorkerRating, error)
+
+	// GetReliability aggregates a worker's ratings and attendance marks
+	GetReliability(ctx context.Context, userID int64) (*models.WorkerReliability, error)
+}
+
+// JobFeedbackRepoI defines the interface for worker feedback persistence
+type JobFeedbackRepoI interface {
+	// GetBookingsAwaitingPrompt returns confirmed bookings (confirmed since the given time)
+	// that have no feedback row yet; no-shows are excluded
+	GetBookingsAwaitingPrompt(ctx context.Context, since time.Time, limit int) ([]*models.JobBooking, error)
+
+	// Create records that the prompt was sent; returns ErrAlreadyExists for a second prompt
+	Create(ctx context.Context, feedback *models.JobFeedback) error
+	GetByBookingID(ctx context.Context, bookingID int64) (*models.JobFeedback, error)
+
+	// UpdateAnswers saves the worker's answers
+	UpdateAnswers(ctx context.Context, feedback *models.JobFeedback) error
+
+	// GetEmployerSummary aggregates answered feedback for an employer
+	GetEmployerSummary(ctx context.Context, employerID int64) (*models.FeedbackSummary, error)
+}
+
+// AuditRepoI defines the interface for the append-only audit log
+type AuditRepoI interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+
+	// GetAdminActivity counts per admin, within [from, to): jobs created (created_by_admin_id),
+	// receipts reviewed (reviewed_by_admin_id) and blocks issued (user_block entries).
+	// Admins with nothing in the period are left out; the busiest come first.
+	GetAdminActivity(ctx context.Context, from, to time.Time) ([]*models.AdminActivity, error)
+}
+
+// JobInterestRepoI defines the interface for signup link opens by unregistered users
+type JobInterestRepoI interface {
+	// Record stores that the user opened the job's signup link; repeat opens keep the first row
+	// and unknown jobs are ignored
+	Record(ctx context.Context, jobID, userID int64) error
+
+	// GetDueNudges returns interests created in [since, before) that weren't reminded yet, by users
+	// without an active registration, oldest first
+	GetDueNudges(ctx context.Context, since, before time.Time, limit int) ([]*models.JobInterest, error)
+
+	// MarkNudged marks every pending interest of the user as reminded
+	MarkNudged(ctx context.Context, userID int64) error
+
+	// GetUnregisteredCounts returns jobs with interested users who still have no active registration,
+	// newest job first
+	GetUnregisteredCounts(ctx context.Context, limit int) ([]*models.JobInterestCount, error)
+}
+
+// ReengageRepoI defines the interface for re-engagement campaigns and their opt-outs
+type ReengageRepoI interface {
+	// GetInactiveUsers returns active registered users who registered before since, made no booking
+	// and got no campaign message since then, haven't opted out and aren't blocked at now
+	GetInactiveUsers(ctx context.Context, since, now time.Time, limit int) ([]int64, error)
+
+	// CreateCampaign stores a new campaign; fills ID and CreatedAt
+	CreateCampaign(ctx context.Context, campaign *models.ReengageCampaign) error
+
+	// AddRecipient records that the campaign message reached the user
+	AddRecipient(ctx context.Context, campaignID, userID int64) error
+
+	// FinishCampaign stores the sent and failed counts
+	FinishCampaign(ctx context.Context, campaign *models.ReengageCampaign) error
+
+	// GetRecent returns the newest campaigns with the link opens and bookings of their recipients
+	GetRecent(ctx context.Context, limit int) ([]*models.ReengageCampaign, error)
+}
+
+// SettingsRepoI defines the interface for runtime bot settings (key/value)
+type SettingsRepoI interface {
+	// GetAll returns every stored setting keyed by name
+	GetAll(ctx context.Context) (map[string]string, error)
+
+	// Set creates or replaces a setting
+	Set(ctx context.Context, key, value string, updatedBy int64) error
+}
+
+// ProfileChangeRepoI defines the interface for the profile edit history
+type ProfileChangeRepoI interface {
+	Create(ctx context.Context, change *models.ProfileChange) error
+
+	// DeleteByUserID removes a user's edit history (account deletion)
+	DeleteByUserID(ctx context.Context, userID int64) error
+}
+
+// FAQRepoI defines the interface for help FAQ entries
+type FAQRepoI interface {
+	Create(ctx context.Context, entry *models.FAQEntry) error
+	GetByID(ctx context.Context, id int64) (*models.FAQEntry, error)
+
+	// GetAll returns every entry in creation order
+	GetAll(ctx context.Context) ([]*models.FAQEntry, error)
+
+	// Update updates category, question and answer
+	Update(ctx context.Context, entry *models.FAQEntry) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// PublicOfferRepoI defines the interface for public offer versions and the consent log
+type PublicOfferRepoI interface {
+	// Publish stores a new version numbered one above the latest; fills ID, Version and PublishedAt.
+	// Returns ErrAlreadyExists if another version was published concurrently.
+	Publish(ctx context.Context, offer *models.PublicOffer) error
+
+	// GetLatest returns the newest version; ErrNotFound when nothing is published
+	GetLatest(ctx context.Context) (*models.PublicOffer, error)
+
+	// RecordAcceptance logs that a user accepted a version; accepting the same version twice keeps the first time
+	RecordAcceptance(ctx context.Context, acceptance *models.OfferAcceptance) error
+
+	// GetAcceptedVersion returns the highest version the user accepted, 0 if none
+	GetAcceptedVersion(ctx context.Context, userID int64) (int, error)
+}
+
+// VoucherRepoI defines the interface for booking voucher persistence
+type VoucherRepoI interface {
+	// Create stores a voucher; returns ErrAlreadyExists if the booking already has one or the code is taken
+	Create(ctx context.Context, voucher *models.BookingVoucher) error
+	GetByBookingID(ctx context.Context, bookingID int64) (*models.BookingVoucher, error)
+	GetByCode(ctx context.Context, code string) (*models.BookingVoucher, error)
+
+	// MarkVerified records the first on-site check; later checks keep the original time and admin
+	MarkVerified(ctx context.Context, id, adminID int64) error
+}
+
+// SupportRepoI defines the interface for user support threads
+type SupportRepoI interface {
+	// Create opens a thread for the user; ErrAlreadyExists when the user already has an open one
+	Create(ctx context.Context, userID int64) (*models.SupportThread, error)
+
+	// GetByID returns a thread; ErrNotFound when it doesn't exist
+	GetByID(ctx context.Context, id int64) (*models.SupportThread, error)
+
+	// GetOpenByUser returns the user's open thread; ErrNotFound when there is none
+	GetOpenByUser(ctx context.Context, userID int64) (*models.SupportThread, error)
+
+	// Close closes an open thread and reports whether it was open
+	Close(ctx context.Context, id, closedBy int64) (bool, error)
+}
+
+// RefundRepoI defines the interface for service fees owed back to workers
+type RefundRepoI interface {
+	// Create records a pending refund; ErrAlreadyExists when the booking already has one
+	Create(ctx context.Context, tx any, refund *models.Refund) error
+
+	// GetByID returns a refund; ErrNotFound when it doesn't exist
+	GetByID(ctx context.Context, id int64) (*models.Refund, error)
+
+	// GetPending returns the refunds still owed, oldest first
+	GetPending(ctx context.Context) ([]*models.Refund, error)
+
+	// MarkPaid marks a pending refund paid and reports whether it was pending
+	MarkPaid(ctx context.Context, id, adminID int64) (bool, error)
 }